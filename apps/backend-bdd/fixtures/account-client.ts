@@ -17,6 +17,12 @@ export type AccountReorderRequestModel =
   components["schemas"]["ReorderAccountsModel"];
 export type PaginatedAccountResponseModel =
   components["schemas"]["AccountsPagedModel"];
+export type SharesListModel = components["schemas"]["SharesListModel"];
+export type UpsertShareRequestModel = components["schemas"]["UpsertShareModel"];
+export type AccountReconciliationModel =
+  components["schemas"]["AccountReconciliationDetailModel"];
+export type CreateAccountReconciliationRequestModel =
+  components["schemas"]["CreateAccountReconciliationModel"];
 
 /**
  * Account API client
@@ -52,20 +58,31 @@ export class AccountAPIClient extends BaseAPIClient {
   }
 
   /**
-   * Update an existing account
+   * Update an existing account; with ifMatch it fails with 412 once the account has changed
    */
   async updateAccount(
     id: number,
-    data: UpdateAccountRequestModel
+    data: UpdateAccountRequestModel,
+    ifMatch?: string
   ): Promise<APIResponse<AccountModel>> {
-    return this.patch<AccountModel>(`/accounts/${id}`, data);
+    return this.patch<AccountModel>(
+      `/accounts/${id}`,
+      data,
+      ifMatch ? { "If-Match": ifMatch } : undefined
+    );
   }
 
   /**
-   * Delete an account
+   * Delete an account; with ifMatch it fails with 412 once the account has changed
    */
-  async deleteAccount(id: number): Promise<APIResponse<void>> {
-    return this.delete<void>(`/accounts/${id}`);
+  async deleteAccount(
+    id: number,
+    ifMatch?: string
+  ): Promise<APIResponse<void>> {
+    return this.delete<void>(
+      `/accounts/${id}`,
+      ifMatch ? { "If-Match": ifMatch } : undefined
+    );
   }
 
   /**
@@ -90,4 +107,87 @@ export class AccountAPIClient extends BaseAPIClient {
   async unarchiveAccount(id: number): Promise<APIResponse<AccountModel>> {
     return this.updateAccount(id, { archivedAt: "" });
   }
+
+  /**
+   * List the users an account is shared with
+   */
+  async getAccountShares(id: number): Promise<APIResponse<SharesListModel>> {
+    return this.get<SharesListModel>(`/accounts/${id}/shares`);
+  }
+
+  /**
+   * Share an account with another user, or change their role
+   */
+  async shareAccount(
+    id: number,
+    data: UpsertShareRequestModel
+  ): Promise<APIResponse<SharesListModel>> {
+    return this.put<SharesListModel>(`/accounts/${id}/shares`, data);
+  }
+
+  /**
+   * Stop sharing an account with a user
+   */
+  async unshareAccount(
+    id: number,
+    userId: number
+  ): Promise<APIResponse<void>> {
+    return this.delete<void>(`/accounts/${id}/shares/${userId}`);
+  }
+
+  /**
+   * Open a reconciliation of an account against a bank statement
+   */
+  async createReconciliation(
+    accountId: number,
+    data: CreateAccountReconciliationRequestModel
+  ): Promise<APIResponse<AccountReconciliationModel>> {
+    return this.post<AccountReconciliationModel>(
+      `/accounts/${accountId}/reconciliations`,
+      data
+    );
+  }
+
+  /**
+   * Get a reconciliation with its balance while it is open
+   */
+  async getReconciliation(
+    accountId: number,
+    reconciliationId: number
+  ): Promise<APIResponse<AccountReconciliationModel>> {
+    return this.get<AccountReconciliationModel>(
+      `/accounts/${accountId}/reconciliations/${reconciliationId}`
+    );
+  }
+
+  /**
+   * Complete a reconciliation, locking the cleared transactions it covers
+   */
+  async completeReconciliation(
+    accountId: number,
+    reconciliationId: number
+  ): Promise<APIResponse<AccountReconciliationModel>> {
+    return this.post<AccountReconciliationModel>(
+      `/accounts/${accountId}/reconciliations/${reconciliationId}/complete`
+    );
+  }
+
+  /**
+   * Discard an open reconciliation
+   */
+  async deleteReconciliation(
+    accountId: number,
+    reconciliationId: number
+  ): Promise<APIResponse<void>> {
+    return this.delete<void>(
+      `/accounts/${accountId}/reconciliations/${reconciliationId}`
+    );
+  }
+
+  /**
+   * Restore an account from the trash
+   */
+  async restoreAccount(id: number): Promise<APIResponse<AccountModel>> {
+    return this.post<AccountModel>(`/accounts/${id}/restore`);
+  }
 }
@@ -0,0 +1,31 @@
+import { APIRequestContext } from "@playwright/test";
+import { BaseAPIClient } from "./base-client";
+import type { TestContext, APIResponse } from "../types/common";
+import type { operations, components } from "../types/openapi";
+
+/**
+ * Audit log types from OpenAPI operations
+ */
+export type AuditLogModel = components["schemas"]["AuditLogModel"];
+export type AuditLogSearchSchema =
+  operations["list-audit-logs"]["parameters"]["query"];
+export type PaginatedAuditLogResponseModel =
+  components["schemas"]["AuditLogsPagedModel"];
+
+/**
+ * Audit log API client, entries are written by the services on every change
+ */
+export class AuditAPIClient extends BaseAPIClient {
+  constructor(request: APIRequestContext, context: TestContext) {
+    super(request, context);
+  }
+
+  /**
+   * List the audit log entries of the current user with optional filters
+   */
+  async getAuditLogs(
+    params?: AuditLogSearchSchema
+  ): Promise<APIResponse<PaginatedAuditLogResponseModel>> {
+    return this.get<PaginatedAuditLogResponseModel>("/audit", params);
+  }
+}
@@ -11,6 +11,24 @@ export type LoginResponseModel = components["schemas"]["LoginResponseModel"];
 export type RefreshRequestModel = components["schemas"]["RefreshRequestModel"];
 export type RefreshResponseModel =
   components["schemas"]["RefreshResponseModel"];
+export type LoginVerifyRequestModel =
+  components["schemas"]["LoginVerifyRequestModel"];
+export type TwoFactorEnrollResponseModel =
+  components["schemas"]["TwoFactorEnrollResponseModel"];
+export type TwoFactorCodeRequestModel =
+  components["schemas"]["TwoFactorCodeRequestModel"];
+export type TwoFactorRecoveryCodesModel =
+  components["schemas"]["TwoFactorRecoveryCodesModel"];
+export type SessionsListModel = components["schemas"]["SessionsListModel"];
+export type CreateAPITokenRequestModel =
+  components["schemas"]["CreateAPITokenModel"];
+export type CreateAPITokenResponseModel =
+  components["schemas"]["CreateAPITokenResponseModel"];
+export type APITokensListModel = components["schemas"]["APITokensListModel"];
+export type OIDCAuthorizeResponseModel =
+  components["schemas"]["OIDCAuthorizeResponseModel"];
+export type OIDCCallbackRequestModel =
+  components["schemas"]["OIDCCallbackRequestModel"];
 
 /**
  * Auth API client for authentication operations
@@ -21,16 +39,65 @@ export class AuthAPIClient extends BaseAPIClient {
   }
 
   /**
-   * Login with username and password
+   * Login with username and password, optionally as the client the deploy proxy forwarded
    */
   async login(
     username: string,
-    password: string
+    password: string,
+    forwardedFor?: string
   ): Promise<APIResponse<LoginResponseModel>> {
-    const response = await this.post<LoginResponseModel>("/auth/login", {
-      username,
-      password,
-    });
+    const response = await this.post<LoginResponseModel>(
+      "/auth/login",
+      { username, password },
+      forwardedFor ? { "X-Forwarded-For": forwardedFor } : undefined
+    );
+
+    // Store tokens in context if successful
+    if (response.data) {
+      this.context.accessToken = response.data.access_token;
+      this.context.refreshToken = response.data.refresh_token;
+    }
+
+    return response;
+  }
+
+  /**
+   * Complete a two-factor login with the challenge token and a code
+   */
+  async loginVerify(
+    data: LoginVerifyRequestModel
+  ): Promise<APIResponse<LoginResponseModel>> {
+    const response = await this.post<LoginResponseModel>(
+      "/auth/login/verify",
+      data
+    );
+
+    // Store tokens in context if successful
+    if (response.data) {
+      this.context.accessToken = response.data.access_token;
+      this.context.refreshToken = response.data.refresh_token;
+    }
+
+    return response;
+  }
+
+  /**
+   * Start an OpenID Connect login and get the identity provider URL
+   */
+  async oidcAuthorize(): Promise<APIResponse<OIDCAuthorizeResponseModel>> {
+    return this.get<OIDCAuthorizeResponseModel>("/auth/oidc/authorize");
+  }
+
+  /**
+   * Complete an OpenID Connect login with the code and state of the redirect
+   */
+  async oidcCallback(
+    data: OIDCCallbackRequestModel
+  ): Promise<APIResponse<LoginResponseModel>> {
+    const response = await this.post<LoginResponseModel>(
+      "/auth/oidc/callback",
+      data
+    );
 
     // Store tokens in context if successful
     if (response.data) {
@@ -56,14 +123,109 @@ export class AuthAPIClient extends BaseAPIClient {
       refresh_token: token,
     });
 
-    // Update access token if successful
+    // Refresh tokens are single-use, keep the rotated pair
     if (response.data) {
       this.context.accessToken = response.data.access_token;
+      this.context.refreshToken = response.data.refresh_token;
     }
 
     return response;
   }
 
+  /**
+   * Log out on the server, ending the current session and revoking the given refresh token
+   */
+  async signOut(refreshToken?: string): Promise<APIResponse<void>> {
+    return this.post<void>("/auth/logout", { refresh_token: refreshToken });
+  }
+
+  /**
+   * Revoke every token and session of the current user
+   */
+  async logoutAll(): Promise<APIResponse<void>> {
+    return this.post<void>("/auth/logout-all");
+  }
+
+  /**
+   * Change the password of the current user, which revokes every token issued so far
+   */
+  async changePassword(
+    currentPassword: string,
+    newPassword: string
+  ): Promise<APIResponse<void>> {
+    return this.post<void>("/auth/change-password", {
+      current_password: currentPassword,
+      new_password: newPassword,
+    });
+  }
+
+  /**
+   * List the login sessions of the current user
+   */
+  async getSessions(): Promise<APIResponse<SessionsListModel>> {
+    return this.get<SessionsListModel>("/auth/sessions");
+  }
+
+  /**
+   * Revoke a login session of the current user
+   */
+  async revokeSession(id: number): Promise<APIResponse<void>> {
+    return this.delete<void>(`/auth/sessions/${id}`);
+  }
+
+  /**
+   * Create a personal access token; the token itself is only returned here
+   */
+  async createAPIToken(
+    data: CreateAPITokenRequestModel,
+    idempotencyKey?: string
+  ): Promise<APIResponse<CreateAPITokenResponseModel>> {
+    return this.post<CreateAPITokenResponseModel>(
+      "/auth/tokens",
+      data,
+      idempotencyKey ? { "Idempotency-Key": idempotencyKey } : undefined
+    );
+  }
+
+  /**
+   * List the personal access tokens of the current user
+   */
+  async getAPITokens(): Promise<APIResponse<APITokensListModel>> {
+    return this.get<APITokensListModel>("/auth/tokens");
+  }
+
+  /**
+   * Revoke a personal access token
+   */
+  async revokeAPIToken(id: number): Promise<APIResponse<void>> {
+    return this.delete<void>(`/auth/tokens/${id}`);
+  }
+
+  /**
+   * Start two-factor enrollment and get a new secret
+   */
+  async enrollTwoFactor(): Promise<APIResponse<TwoFactorEnrollResponseModel>> {
+    return this.post<TwoFactorEnrollResponseModel>("/auth/2fa/enroll");
+  }
+
+  /**
+   * Confirm the enrolled secret with a code, enabling two-factor
+   */
+  async verifyTwoFactor(
+    data: TwoFactorCodeRequestModel
+  ): Promise<APIResponse<TwoFactorRecoveryCodesModel>> {
+    return this.post<TwoFactorRecoveryCodesModel>("/auth/2fa/verify", data);
+  }
+
+  /**
+   * Turn off two-factor after confirming a code or recovery code
+   */
+  async disableTwoFactor(
+    data: TwoFactorCodeRequestModel
+  ): Promise<APIResponse<void>> {
+    return this.post<void>("/auth/2fa/disable", data);
+  }
+
   /**
    * Clear stored tokens
    */
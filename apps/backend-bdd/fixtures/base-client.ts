@@ -27,7 +27,8 @@ export class BaseAPIClient {
    */
   protected async get<T>(
     path: string,
-    params?: Record<string, any>
+    params?: Record<string, any>,
+    headers?: Record<string, string>
   ): Promise<APIResponse<T>> {
     const url = new URL(path, this.context.baseURL);
     if (params) {
@@ -39,7 +40,7 @@ export class BaseAPIClient {
     }
 
     const response = await this.request.get(url.toString(), {
-      headers: this.getAuthHeaders(),
+      headers: { ...this.getAuthHeaders(), ...headers },
     });
 
     return this.parseResponse<T>(response);
@@ -48,10 +49,14 @@ export class BaseAPIClient {
   /**
    * Make a POST request
    */
-  protected async post<T>(path: string, body?: any): Promise<APIResponse<T>> {
+  protected async post<T>(
+    path: string,
+    body?: any,
+    headers?: Record<string, string>
+  ): Promise<APIResponse<T>> {
     const url = new URL(path, this.context.baseURL);
     const response = await this.request.post(url.toString(), {
-      headers: this.getAuthHeaders(),
+      headers: { ...this.getAuthHeaders(), ...headers },
       data: body,
     });
 
@@ -61,10 +66,14 @@ export class BaseAPIClient {
   /**
    * Make a PATCH request
    */
-  protected async patch<T>(path: string, body?: any): Promise<APIResponse<T>> {
+  protected async patch<T>(
+    path: string,
+    body?: any,
+    headers?: Record<string, string>
+  ): Promise<APIResponse<T>> {
     const url = new URL(path, this.context.baseURL);
     const response = await this.request.patch(url.toString(), {
-      headers: this.getAuthHeaders(),
+      headers: { ...this.getAuthHeaders(), ...headers },
       data: body,
     });
 
@@ -74,23 +83,75 @@ export class BaseAPIClient {
   /**
    * Make a PUT request
    */
-  protected async put<T>(path: string, body?: any): Promise<APIResponse<T>> {
+  protected async put<T>(
+    path: string,
+    body?: any,
+    headers?: Record<string, string>
+  ): Promise<APIResponse<T>> {
     const url = new URL(path, this.context.baseURL);
     const response = await this.request.put(url.toString(), {
-      headers: this.getAuthHeaders(),
+      headers: { ...this.getAuthHeaders(), ...headers },
       data: body,
     });
 
     return this.parseResponse<T>(response);
   }
 
+  /**
+   * Make a POST request with a multipart form body
+   */
+  protected async postMultipart<T>(
+    path: string,
+    multipart: Record<
+      string,
+      string | { name: string; mimeType: string; buffer: Buffer }
+    >,
+    headers?: Record<string, string>
+  ): Promise<APIResponse<T>> {
+    const url = new URL(path, this.context.baseURL);
+    const response = await this.request.post(url.toString(), {
+      headers: { ...this.getAuthHeaders(), ...headers },
+      multipart,
+    });
+
+    return this.parseResponse<T>(response);
+  }
+
+  /**
+   * Make a GET request for a file, returning the raw body instead of JSON
+   */
+  protected async getFile(
+    path: string,
+    headers?: Record<string, string>
+  ): Promise<APIResponse<Buffer>> {
+    const url = new URL(path, this.context.baseURL);
+    const response = await this.request.get(url.toString(), {
+      headers: { ...this.getAuthHeaders(), ...headers },
+    });
+
+    const status = response.status();
+    if (status < 200 || status >= 300) {
+      return this.parseResponse<Buffer>(response);
+    }
+
+    const responseHeaders: Record<string, string> = {};
+    response.headersArray().forEach((header) => {
+      responseHeaders[header.name.toLowerCase()] = header.value;
+    });
+
+    return { data: await response.body(), status, headers: responseHeaders };
+  }
+
   /**
    * Make a DELETE request
    */
-  protected async delete<T>(path: string): Promise<APIResponse<T>> {
+  protected async delete<T>(
+    path: string,
+    headers?: Record<string, string>
+  ): Promise<APIResponse<T>> {
     const url = new URL(path, this.context.baseURL);
     const response = await this.request.delete(url.toString(), {
-      headers: this.getAuthHeaders(),
+      headers: { ...this.getAuthHeaders(), ...headers },
     });
 
     return this.parseResponse<T>(response);
@@ -103,10 +164,11 @@ export class BaseAPIClient {
     const status = response.status();
     const headers: Record<string, string> = {};
 
+    // Header names are case-insensitive, keep them lowercase so lookups do not depend on the server's casing
     response
       .headersArray()
       .forEach((header: { name: string; value: string }) => {
-        headers[header.name] = header.value;
+        headers[header.name.toLowerCase()] = header.value;
       });
 
     let data: T | undefined;
@@ -53,8 +53,13 @@ export class BudgetTemplateAPIClient extends BaseAPIClient {
   async updateBudgetTemplate(
     id: number,
     data: UpdateBudgetTemplateRequestModel,
+    ifMatch?: string,
   ): Promise<APIResponse<BudgetTemplateModel>> {
-    return this.patch<BudgetTemplateModel>(`/budgets/${id}`, data);
+    return this.patch<BudgetTemplateModel>(
+      `/budgets/${id}`,
+      data,
+      ifMatch ? { "If-Match": ifMatch } : undefined,
+    );
   }
 
   async getBudgetTemplateRelatedBudgets(
@@ -90,4 +90,11 @@ export class CategoryAPIClient extends BaseAPIClient {
   async unarchiveCategory(id: number): Promise<APIResponse<CategoryModel>> {
     return this.updateCategory(id, { archivedAt: "" });
   }
+
+  /**
+   * Restore a category from the trash
+   */
+  async restoreCategory(id: number): Promise<APIResponse<CategoryModel>> {
+    return this.post<CategoryModel>(`/categories/${id}/restore`);
+  }
 }
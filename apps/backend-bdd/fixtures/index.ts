@@ -10,6 +10,12 @@ import { SummaryAPIClient } from "./summary-client";
 import { BudgetTemplateAPIClient } from "./budget-template-client";
 import { TransactionTemplateAPIClient } from "./transaction-template-client";
 import { PreferenceAPIClient } from "./preference-client";
+import { UserAPIClient } from "./user-client";
+import { TrashAPIClient } from "./trash-client";
+import { TransactionRuleAPIClient } from "./transaction-rule-client";
+import { ReimbursementAPIClient } from "./reimbursement-client";
+import { PayeeAPIClient } from "./payee-client";
+import { AuditAPIClient } from "./audit-client";
 import type { TestContext } from "../types/common";
 import * as fs from "fs";
 import * as path from "path";
@@ -30,8 +36,25 @@ type APIFixtures = {
   budgetTemplateAPI: BudgetTemplateAPIClient;
   transactionTemplateAPI: TransactionTemplateAPIClient;
   preferenceAPI: PreferenceAPIClient;
+  userAPI: UserAPIClient;
+  trashAPI: TrashAPIClient;
+  transactionRuleAPI: TransactionRuleAPIClient;
+  reimbursementAPI: ReimbursementAPIClient;
+  payeeAPI: PayeeAPIClient;
+  auditAPI: AuditAPIClient;
   authenticatedContext: TestContext;
   ensureCleanDB: () => Promise<void>;
+  createUser: (prefix?: string) => Promise<TestUser>;
+};
+
+/**
+ * A user registered by the admin during a test, with its own logged in context
+ */
+export type TestUser = {
+  id: number;
+  username: string;
+  password: string;
+  context: TestContext;
 };
 
 /**
@@ -110,6 +133,34 @@ async function ensureCleanDatabase(request: any, testContext: TestContext) {
   }
 }
 
+/**
+ * Register a new user as the admin and log it in on a context of its own,
+ * so a test can act as a second user without touching the admin tokens
+ */
+async function createTestUser(
+  request: any,
+  testContext: TestContext,
+  prefix: string,
+): Promise<TestUser> {
+  const username = `${prefix}-${Date.now()}-${Math.floor(Math.random() * 10000)}`;
+  const password = "e2e-user-password";
+
+  const userAPI = new UserAPIClient(request, testContext);
+  const user = await userAPI.createUser({ username, password });
+  if (!user.data) {
+    throw new Error(`Could not create user ${username}: ${user.error?.detail}`);
+  }
+
+  const context: TestContext = { baseURL: testContext.baseURL };
+  const authAPI = new AuthAPIClient(request, context);
+  const login = await authAPI.login(username, password);
+  if (!login.data?.access_token) {
+    throw new Error(`Could not log in as ${username}: ${login.error?.detail}`);
+  }
+
+  return { id: user.data.id, username, password, context };
+}
+
 /**
  * Extend Playwright test with custom fixtures
  */
@@ -219,6 +270,54 @@ export const test = base.extend<APIFixtures>({
     await use(client);
   },
 
+  /**
+   * User API client
+   */
+  userAPI: async ({ request, testContext }, use) => {
+    const client = new UserAPIClient(request, testContext);
+    await use(client);
+  },
+
+  /**
+   * Trash API client
+   */
+  trashAPI: async ({ request, testContext }, use) => {
+    const client = new TrashAPIClient(request, testContext);
+    await use(client);
+  },
+
+  /**
+   * Transaction rule API client
+   */
+  transactionRuleAPI: async ({ request, testContext }, use) => {
+    const client = new TransactionRuleAPIClient(request, testContext);
+    await use(client);
+  },
+
+  /**
+   * Reimbursement API client
+   */
+  reimbursementAPI: async ({ request, testContext }, use) => {
+    const client = new ReimbursementAPIClient(request, testContext);
+    await use(client);
+  },
+
+  /**
+   * Payee API client
+   */
+  payeeAPI: async ({ request, testContext }, use) => {
+    const client = new PayeeAPIClient(request, testContext);
+    await use(client);
+  },
+
+  /**
+   * Audit log API client
+   */
+  auditAPI: async ({ request, testContext }, use) => {
+    const client = new AuditAPIClient(request, testContext);
+    await use(client);
+  },
+
   /**
    * Authenticated context - now automatically loaded from global setup
    * This fixture is kept for backward compatibility but tokens are
@@ -241,7 +340,17 @@ export const test = base.extend<APIFixtures>({
     const cleanupFn = () => ensureCleanDatabase(request, testContext);
     await use(cleanupFn);
   },
+
+  /**
+   * Fixture to register and log in additional users
+   */
+  createUser: async ({ request, testContext }, use) => {
+    await use((prefix = "e2e-user") =>
+      createTestUser(request, testContext, prefix),
+    );
+  },
 });
 
 // Remove the global beforeEach
 export { expect } from "@playwright/test";
+export type { TestContext };
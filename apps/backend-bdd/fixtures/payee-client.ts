@@ -0,0 +1,86 @@
+import { APIRequestContext } from "@playwright/test";
+import { BaseAPIClient } from "./base-client";
+import type { TestContext, APIResponse } from "../types/common";
+import type { operations, components } from "../types/openapi";
+
+/**
+ * Payee types from OpenAPI operations
+ */
+export type PayeeModel = components["schemas"]["PayeeModel"];
+export type PayeeSearchSchema =
+  operations["list-payees"]["parameters"]["query"];
+export type CreatePayeeRequestModel = components["schemas"]["CreatePayeeModel"];
+export type UpdatePayeeRequestModel = components["schemas"]["UpdatePayeeModel"];
+export type MergePayeesRequestModel = components["schemas"]["MergePayeesModel"];
+export type PayeeMergeResultModel =
+  components["schemas"]["PayeeMergeResultModel"];
+export type PaginatedPayeeResponseModel =
+  components["schemas"]["PayeesPagedModel"];
+
+/**
+ * Payee API client
+ */
+export class PayeeAPIClient extends BaseAPIClient {
+  constructor(request: APIRequestContext, context: TestContext) {
+    super(request, context);
+  }
+
+  /**
+   * Get all payees, optionally searching by name or alias
+   */
+  async getPayees(
+    params?: PayeeSearchSchema
+  ): Promise<APIResponse<PaginatedPayeeResponseModel>> {
+    return this.get<PaginatedPayeeResponseModel>("/payees", params);
+  }
+
+  /**
+   * Get a single payee by ID
+   */
+  async getPayee(id: number): Promise<APIResponse<PayeeModel>> {
+    return this.get<PayeeModel>(`/payees/${id}`);
+  }
+
+  /**
+   * Create a new payee
+   */
+  async createPayee(
+    data: CreatePayeeRequestModel
+  ): Promise<APIResponse<PayeeModel>> {
+    return this.post<PayeeModel>("/payees", data);
+  }
+
+  /**
+   * Update an existing payee
+   */
+  async updatePayee(
+    id: number,
+    data: UpdatePayeeRequestModel
+  ): Promise<APIResponse<PayeeModel>> {
+    return this.patch<PayeeModel>(`/payees/${id}`, data);
+  }
+
+  /**
+   * Delete a payee
+   */
+  async deletePayee(id: number): Promise<APIResponse<void>> {
+    return this.delete<void>(`/payees/${id}`);
+  }
+
+  /**
+   * Restore a deleted payee
+   */
+  async restorePayee(id: number): Promise<APIResponse<PayeeModel>> {
+    return this.post<PayeeModel>(`/payees/${id}/restore`);
+  }
+
+  /**
+   * Merge duplicate payees into the given one
+   */
+  async mergePayees(
+    id: number,
+    data: MergePayeesRequestModel
+  ): Promise<APIResponse<PayeeMergeResultModel>> {
+    return this.post<PayeeMergeResultModel>(`/payees/${id}/merge`, data);
+  }
+}
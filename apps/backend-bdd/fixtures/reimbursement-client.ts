@@ -0,0 +1,95 @@
+import { APIRequestContext } from "@playwright/test";
+import { BaseAPIClient } from "./base-client";
+import type { TestContext, APIResponse } from "../types/common";
+import type { operations, components } from "../types/openapi";
+
+/**
+ * Reimbursement types from OpenAPI operations
+ */
+export type ReimbursementModel = components["schemas"]["ReimbursementModel"];
+export type ReimbursementSearchSchema =
+  operations["list-reimbursements"]["parameters"]["query"];
+export type CreateReimbursementRequestModel =
+  components["schemas"]["CreateReimbursementModel"];
+export type UpdateReimbursementRequestModel =
+  components["schemas"]["UpdateReimbursementModel"];
+export type CreateReimbursementMatchRequestModel =
+  components["schemas"]["CreateReimbursementMatchModel"];
+export type PaginatedReimbursementResponseModel =
+  components["schemas"]["ReimbursementsPagedModel"];
+
+/**
+ * Reimbursement API client
+ */
+export class ReimbursementAPIClient extends BaseAPIClient {
+  constructor(request: APIRequestContext, context: TestContext) {
+    super(request, context);
+  }
+
+  /**
+   * Get all reimbursements with optional filters
+   */
+  async getReimbursements(
+    params?: ReimbursementSearchSchema
+  ): Promise<APIResponse<PaginatedReimbursementResponseModel>> {
+    return this.get<PaginatedReimbursementResponseModel>(
+      "/reimbursements",
+      params
+    );
+  }
+
+  /**
+   * Get a single reimbursement by ID
+   */
+  async getReimbursement(id: number): Promise<APIResponse<ReimbursementModel>> {
+    return this.get<ReimbursementModel>(`/reimbursements/${id}`);
+  }
+
+  /**
+   * Mark an expense as expected to be paid back
+   */
+  async createReimbursement(
+    data: CreateReimbursementRequestModel
+  ): Promise<APIResponse<ReimbursementModel>> {
+    return this.post<ReimbursementModel>("/reimbursements", data);
+  }
+
+  /**
+   * Update an existing reimbursement
+   */
+  async updateReimbursement(
+    id: number,
+    data: UpdateReimbursementRequestModel
+  ): Promise<APIResponse<ReimbursementModel>> {
+    return this.patch<ReimbursementModel>(`/reimbursements/${id}`, data);
+  }
+
+  /**
+   * Delete a reimbursement
+   */
+  async deleteReimbursement(id: number): Promise<APIResponse<void>> {
+    return this.delete<void>(`/reimbursements/${id}`);
+  }
+
+  /**
+   * Count part of an income towards a reimbursement
+   */
+  async matchReimbursement(
+    id: number,
+    data: CreateReimbursementMatchRequestModel
+  ): Promise<APIResponse<ReimbursementModel>> {
+    return this.post<ReimbursementModel>(`/reimbursements/${id}/matches`, data);
+  }
+
+  /**
+   * Remove a match from a reimbursement
+   */
+  async unmatchReimbursement(
+    id: number,
+    matchId: number
+  ): Promise<APIResponse<ReimbursementModel>> {
+    return this.delete<ReimbursementModel>(
+      `/reimbursements/${id}/matches/${matchId}`
+    );
+  }
+}
@@ -14,6 +14,8 @@ export type SummaryTransactionResponseModel =
   components["schemas"]["SummaryTransactionListModel"];
 export type SummaryGeospatialResponseModel =
   components["schemas"]["SummaryGeospatialListModel"];
+export type SummaryPayeeResponseModel =
+  components["schemas"]["SummaryPayeeListModel"];
 
 /**
  * Query parameter types
@@ -26,6 +28,8 @@ export type TransactionSummaryParams =
   operations["get-transaction-summary"]["parameters"]["query"];
 export type GeospatialSummaryParams =
   operations["get-geospatial-summary"]["parameters"]["query"];
+export type PayeeSummaryParams =
+  operations["get-payee-summary"]["parameters"]["query"];
 
 /**
  * Summary API client for analytics and aggregation endpoints
@@ -58,6 +62,16 @@ export class SummaryAPIClient extends BaseAPIClient {
     );
   }
 
+  /**
+   * Get payee summary with date filtering
+   * Returns summary data grouped by payee
+   */
+  async getPayeeSummary(
+    params: PayeeSummaryParams
+  ): Promise<APIResponse<SummaryPayeeResponseModel>> {
+    return this.get<SummaryPayeeResponseModel>("/summary/payees", params);
+  }
+
   /**
    * Get transaction summary grouped by period
    * Supports daily, weekly, monthly, or yearly frequency
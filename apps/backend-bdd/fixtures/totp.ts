@@ -0,0 +1,53 @@
+import { createHmac } from "crypto";
+
+const BASE32_ALPHABET = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567";
+const TOTP_PERIOD_SECONDS = 30;
+const TOTP_DIGITS = 6;
+
+/**
+ * Decode an unpadded RFC 4648 base32 string, as returned by 2FA enrollment
+ */
+function decodeBase32(secret: string): Buffer {
+  let bits = 0;
+  let value = 0;
+  const bytes: number[] = [];
+
+  for (const char of secret.replace(/=+$/, "").toUpperCase()) {
+    const index = BASE32_ALPHABET.indexOf(char);
+    if (index === -1) {
+      throw new Error(`Invalid base32 character: ${char}`);
+    }
+    value = (value << 5) | index;
+    bits += 5;
+    if (bits >= 8) {
+      bytes.push((value >>> (bits - 8)) & 0xff);
+      bits -= 8;
+    }
+  }
+
+  return Buffer.from(bytes);
+}
+
+/**
+ * Current TOTP time step
+ */
+export function currentTOTPStep(now: number = Date.now()): number {
+  return Math.floor(now / 1000 / TOTP_PERIOD_SECONDS);
+}
+
+/**
+ * Compute the TOTP code (RFC 6238, SHA1, 6 digits, 30 seconds) of a secret for a time step
+ */
+export function generateTOTP(
+  secret: string,
+  step: number = currentTOTPStep()
+): string {
+  const counter = Buffer.alloc(8);
+  counter.writeBigUInt64BE(BigInt(step));
+
+  const sum = createHmac("sha1", decodeBase32(secret)).update(counter).digest();
+  const offset = sum[sum.length - 1] & 0x0f;
+  const value = sum.readUInt32BE(offset) & 0x7fffffff;
+
+  return String(value % 10 ** TOTP_DIGITS).padStart(TOTP_DIGITS, "0");
+}
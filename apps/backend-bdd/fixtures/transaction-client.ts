@@ -22,6 +22,8 @@ export type TransactionRelationsPagedModel =
   components["schemas"]["TransactionRelationsPagedModel"];
 export type TransactionRelationModel =
   components["schemas"]["TransactionRelationModel"];
+export type TransactionGraphModel =
+  components["schemas"]["TransactionGraphModel"];
 
 export type BulkTransactionUpdateItemModel =
   components["schemas"]["BulkTransactionUpdateItemModel"];
@@ -31,6 +33,24 @@ export type BulkTransactionDraftResponseModel =
   components["schemas"]["BulkTransactionDraftResponseModel"];
 export type BulkTransactionCommitResponseModel =
   components["schemas"]["BulkTransactionCommitResponseModel"];
+export type TransactionSplitsListModel =
+  components["schemas"]["TransactionSplitsListModel"];
+export type ReplaceTransactionSplitsRequestModel =
+  components["schemas"]["ReplaceTransactionSplitsModel"];
+export type TransactionDuplicatesSearchSchema =
+  operations["list-transaction-duplicates"]["parameters"]["query"];
+export type TransactionDuplicatesPagedModel =
+  components["schemas"]["TransactionDuplicatesPagedModel"];
+export type MergeTransactionDuplicatesRequestModel =
+  components["schemas"]["MergeTransactionDuplicatesModel"];
+export type TransactionMergeResultModel =
+  components["schemas"]["TransactionMergeResultModel"];
+export type TransactionAttachmentModel =
+  components["schemas"]["TransactionAttachmentModel"];
+export type TransactionAttachmentsListModel =
+  components["schemas"]["TransactionAttachmentsListModel"];
+export type TransactionRevisionsListModel =
+  components["schemas"]["TransactionRevisionsListModel"];
 /**
  * Transaction API client
  */
@@ -56,29 +76,45 @@ export class TransactionAPIClient extends BaseAPIClient {
   }
 
   /**
-   * Create a new transaction
+   * Create a new transaction; retries with the same idempotency key replay the first response
    */
   async createTransaction(
     data: CreateTransactionRequestModel,
+    idempotencyKey?: string,
   ): Promise<APIResponse<TransactionModel>> {
-    return this.post<TransactionModel>("/transactions", data);
+    return this.post<TransactionModel>(
+      "/transactions",
+      data,
+      idempotencyKey ? { "Idempotency-Key": idempotencyKey } : undefined,
+    );
   }
 
   /**
-   * Update an existing transaction
+   * Update an existing transaction; with ifMatch it fails with 412 once the transaction has changed
    */
   async updateTransaction(
     id: number,
     data: UpdateTransactionRequestModel,
+    ifMatch?: string,
   ): Promise<APIResponse<TransactionModel>> {
-    return this.patch<TransactionModel>(`/transactions/${id}`, data);
+    return this.patch<TransactionModel>(
+      `/transactions/${id}`,
+      data,
+      ifMatch ? { "If-Match": ifMatch } : undefined,
+    );
   }
 
   /**
-   * Delete a transaction
+   * Delete a transaction; with ifMatch it fails with 412 once the transaction has changed
    */
-  async deleteTransaction(id: number): Promise<APIResponse<void>> {
-    return this.delete<void>(`/transactions/${id}`);
+  async deleteTransaction(
+    id: number,
+    ifMatch?: string,
+  ): Promise<APIResponse<void>> {
+    return this.delete<void>(
+      `/transactions/${id}`,
+      ifMatch ? { "If-Match": ifMatch } : undefined,
+    );
   }
 
   /**
@@ -154,6 +190,15 @@ export class TransactionAPIClient extends BaseAPIClient {
     );
   }
 
+  /**
+   * Get the transactions connected to a transaction through relations
+   */
+  async getTransactionGraph(
+    id: number,
+  ): Promise<APIResponse<TransactionGraphModel>> {
+    return this.get<TransactionGraphModel>(`/transactions/${id}/graph`);
+  }
+
   /**
    * Delete transaction relation
    */
@@ -213,4 +258,132 @@ export class TransactionAPIClient extends BaseAPIClient {
   async deleteBulkDraft(): Promise<APIResponse<void>> {
     return this.delete<void>("/transactions/bulk/draft");
   }
+
+  /**
+   * Get the split lines of a transaction
+   */
+  async getTransactionSplits(
+    id: number,
+  ): Promise<APIResponse<TransactionSplitsListModel>> {
+    return this.get<TransactionSplitsListModel>(`/transactions/${id}/splits`);
+  }
+
+  /**
+   * Replace the split lines of a transaction; no lines removes the split
+   */
+  async replaceTransactionSplits(
+    id: number,
+    data: ReplaceTransactionSplitsRequestModel,
+    ifMatch?: string,
+  ): Promise<APIResponse<TransactionModel>> {
+    return this.put<TransactionModel>(
+      `/transactions/${id}/splits`,
+      data,
+      ifMatch ? { "If-Match": ifMatch } : undefined,
+    );
+  }
+
+  /**
+   * Restore a transaction from the trash
+   */
+  async restoreTransaction(id: number): Promise<APIResponse<TransactionModel>> {
+    return this.post<TransactionModel>(`/transactions/${id}/restore`);
+  }
+
+  /**
+   * Get the files attached to a transaction
+   */
+  async getTransactionAttachments(
+    id: number,
+  ): Promise<APIResponse<TransactionAttachmentsListModel>> {
+    return this.get<TransactionAttachmentsListModel>(
+      `/transactions/${id}/attachments`,
+    );
+  }
+
+  /**
+   * Attach a file to a transaction
+   */
+  async uploadTransactionAttachment(
+    id: number,
+    file: { name: string; mimeType: string; buffer: Buffer },
+  ): Promise<APIResponse<TransactionAttachmentModel>> {
+    return this.postMultipart<TransactionAttachmentModel>(
+      `/transactions/${id}/attachments`,
+      { file },
+    );
+  }
+
+  /**
+   * Download the original file of an attachment, or its thumbnail
+   */
+  async downloadTransactionAttachment(
+    id: number,
+    attachmentId: number,
+    thumbnail = false,
+  ): Promise<APIResponse<Buffer>> {
+    return this.getFile(
+      `/transactions/${id}/attachments/${attachmentId}/${thumbnail ? "thumbnail" : "content"}`,
+    );
+  }
+
+  /**
+   * Remove an attachment from a transaction
+   */
+  async deleteTransactionAttachment(
+    id: number,
+    attachmentId: number,
+  ): Promise<APIResponse<void>> {
+    return this.delete<void>(
+      `/transactions/${id}/attachments/${attachmentId}`,
+    );
+  }
+
+  /**
+   * Get the update history of a transaction, newest first
+   */
+  async getTransactionRevisions(
+    id: number,
+  ): Promise<APIResponse<TransactionRevisionsListModel>> {
+    return this.get<TransactionRevisionsListModel>(
+      `/transactions/${id}/revisions`,
+    );
+  }
+
+  /**
+   * Set the fields changed by a revision back to their previous values
+   */
+  async revertTransactionRevision(
+    id: number,
+    revision: number,
+  ): Promise<APIResponse<TransactionModel>> {
+    return this.post<TransactionModel>(
+      `/transactions/${id}/revisions/${revision}/revert`,
+    );
+  }
+
+  /**
+   * Get pairs of transactions that may be duplicates
+   */
+  async getTransactionDuplicates(
+    params?: TransactionDuplicatesSearchSchema,
+  ): Promise<APIResponse<TransactionDuplicatesPagedModel>> {
+    return this.get<TransactionDuplicatesPagedModel>(
+      "/transactions/duplicates",
+      params,
+    );
+  }
+
+  /**
+   * Merge duplicates into a transaction, deleting the duplicates
+   */
+  async mergeTransactions(
+    id: number,
+    data: MergeTransactionDuplicatesRequestModel,
+  ): Promise<APIResponse<TransactionMergeResultModel>> {
+    return this.post<TransactionMergeResultModel>(
+      `/transactions/${id}/merge`,
+      data,
+    );
+  }
 }
@@ -0,0 +1,105 @@
+import { APIRequestContext } from "@playwright/test";
+import { BaseAPIClient } from "./base-client";
+import type { TestContext, APIResponse } from "../types/common";
+import type { operations, components } from "../types/openapi";
+
+/**
+ * Transaction rule types from OpenAPI operations
+ */
+export type TransactionRuleModel =
+  components["schemas"]["TransactionRuleModel"];
+export type TransactionRuleSearchSchema =
+  operations["list-transaction-rules"]["parameters"]["query"];
+export type CreateTransactionRuleRequestModel =
+  components["schemas"]["CreateTransactionRuleModel"];
+export type UpdateTransactionRuleRequestModel =
+  components["schemas"]["UpdateTransactionRuleModel"];
+export type PaginatedTransactionRuleResponseModel =
+  components["schemas"]["TransactionRulesPagedModel"];
+export type TransactionRuleRangeModel =
+  components["schemas"]["TransactionRuleRangeModel"];
+export type TransactionRuleDryRunModel =
+  components["schemas"]["TransactionRuleDryRunModel"];
+export type TransactionRuleApplyResultModel =
+  components["schemas"]["TransactionRuleApplyResultModel"];
+
+/**
+ * Transaction rule API client
+ */
+export class TransactionRuleAPIClient extends BaseAPIClient {
+  constructor(request: APIRequestContext, context: TestContext) {
+    super(request, context);
+  }
+
+  /**
+   * Get all transaction rules with optional filters
+   */
+  async getTransactionRules(
+    params?: TransactionRuleSearchSchema
+  ): Promise<APIResponse<PaginatedTransactionRuleResponseModel>> {
+    return this.get<PaginatedTransactionRuleResponseModel>(
+      "/transaction-rules",
+      params
+    );
+  }
+
+  /**
+   * Get a single transaction rule by ID
+   */
+  async getTransactionRule(
+    id: number
+  ): Promise<APIResponse<TransactionRuleModel>> {
+    return this.get<TransactionRuleModel>(`/transaction-rules/${id}`);
+  }
+
+  /**
+   * Create a new transaction rule
+   */
+  async createTransactionRule(
+    data: CreateTransactionRuleRequestModel
+  ): Promise<APIResponse<TransactionRuleModel>> {
+    return this.post<TransactionRuleModel>("/transaction-rules", data);
+  }
+
+  /**
+   * Update an existing transaction rule
+   */
+  async updateTransactionRule(
+    id: number,
+    data: UpdateTransactionRuleRequestModel
+  ): Promise<APIResponse<TransactionRuleModel>> {
+    return this.patch<TransactionRuleModel>(`/transaction-rules/${id}`, data);
+  }
+
+  /**
+   * Delete a transaction rule
+   */
+  async deleteTransactionRule(id: number): Promise<APIResponse<void>> {
+    return this.delete<void>(`/transaction-rules/${id}`);
+  }
+
+  /**
+   * Show what a rule would change in a range of transactions without changing them
+   */
+  async dryRunTransactionRule(
+    id: number,
+    params: TransactionRuleRangeModel
+  ): Promise<APIResponse<TransactionRuleDryRunModel>> {
+    return this.get<TransactionRuleDryRunModel>(
+      `/transaction-rules/${id}/dry-run`,
+      params
+    );
+  }
+
+  /**
+   * Apply all enabled rules to the transactions in a range
+   */
+  async applyTransactionRules(
+    data: TransactionRuleRangeModel
+  ): Promise<APIResponse<TransactionRuleApplyResultModel>> {
+    return this.post<TransactionRuleApplyResultModel>(
+      "/transaction-rules/apply",
+      data
+    );
+  }
+}
@@ -0,0 +1,30 @@
+import { APIRequestContext } from "@playwright/test";
+import { BaseAPIClient } from "./base-client";
+import type { TestContext, APIResponse } from "../types/common";
+import type { operations, components } from "../types/openapi";
+
+/**
+ * Trash types from OpenAPI operations
+ */
+export type TrashItemModel = components["schemas"]["TrashItemModel"];
+export type TrashSearchSchema = operations["list-trash"]["parameters"]["query"];
+export type PaginatedTrashResponseModel =
+  components["schemas"]["TrashPagedModel"];
+
+/**
+ * Trash API client, restoring goes through each resource's own client
+ */
+export class TrashAPIClient extends BaseAPIClient {
+  constructor(request: APIRequestContext, context: TestContext) {
+    super(request, context);
+  }
+
+  /**
+   * List soft-deleted records with the time they will be purged
+   */
+  async getTrash(
+    params?: TrashSearchSchema
+  ): Promise<APIResponse<PaginatedTrashResponseModel>> {
+    return this.get<PaginatedTrashResponseModel>("/trash", params);
+  }
+}
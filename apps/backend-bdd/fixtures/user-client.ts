@@ -0,0 +1,35 @@
+import { APIRequestContext } from "@playwright/test";
+import { BaseAPIClient } from "./base-client";
+import type { TestContext, APIResponse } from "../types/common";
+import type { components } from "../types/openapi";
+
+/**
+ * User types from OpenAPI schemas
+ */
+export type UserModel = components["schemas"]["UserModel"];
+export type CreateUserRequestModel = components["schemas"]["CreateUserModel"];
+
+/**
+ * User API client
+ */
+export class UserAPIClient extends BaseAPIClient {
+  constructor(request: APIRequestContext, context: TestContext) {
+    super(request, context);
+  }
+
+  /**
+   * Create a new user, only allowed for the admin
+   */
+  async createUser(
+    data: CreateUserRequestModel
+  ): Promise<APIResponse<UserModel>> {
+    return this.post<UserModel>("/users", data);
+  }
+
+  /**
+   * Get the user the current token belongs to
+   */
+  async getCurrentUser(): Promise<APIResponse<UserModel>> {
+    return this.get<UserModel>("/users/me");
+  }
+}
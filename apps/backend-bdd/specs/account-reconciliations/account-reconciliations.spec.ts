@@ -0,0 +1,116 @@
+import { test, expect } from "@fixtures/index";
+
+test.describe("Account Reconciliations - Common", () => {
+  test("POST /accounts/:id/reconciliations - reports the cleared balance against the statement", async ({
+    accountAPI,
+    categoryAPI,
+    transactionAPI,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `recon-balance-acc-${Date.now()}`,
+      note: "reconciliation balance",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `recon-balance-cat-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const accountId = acc.data!.id as number;
+    const yesterday = new Date(Date.now() - 24 * 3600 * 1000).toISOString();
+
+    const cleared = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: cat.data!.id as number,
+      amount: 1000,
+      date: yesterday,
+      type: "expense",
+      status: "cleared",
+    });
+    const pending = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: cat.data!.id as number,
+      amount: 400,
+      date: yesterday,
+      type: "expense",
+    });
+    expect(pending.data!.status).toBe("pending");
+
+    const recon = await accountAPI.createReconciliation(accountId, {
+      statementDate: new Date().toISOString(),
+      statementBalance: -1000,
+    });
+    expect(recon.status).toBe(201);
+    expect(recon.data!.status).toBe("open");
+    expect(recon.data!.balance!.accountBalance).toBe(-1400);
+    expect(recon.data!.balance!.clearedBalance).toBe(-1000);
+    expect(recon.data!.balance!.difference).toBe(0);
+    expect(recon.data!.balance!.unclearedCount).toBe(1);
+    expect(recon.data!.balance!.unclearedAmount).toBe(-400);
+
+    // Only one open reconciliation per account
+    const second = await accountAPI.createReconciliation(accountId, {
+      statementDate: new Date().toISOString(),
+      statementBalance: -1000,
+    });
+    expect(second.status).toBe(409);
+
+    const del = await accountAPI.deleteReconciliation(
+      accountId,
+      recon.data!.id as number
+    );
+    expect(del.status).toBe(204);
+
+    await transactionAPI.deleteTransaction(cleared.data!.id as number);
+    await transactionAPI.deleteTransaction(pending.data!.id as number);
+    await accountAPI.deleteAccount(accountId);
+    await categoryAPI.deleteCategory(cat.data!.id as number);
+  });
+
+  test("POST /accounts/:id/reconciliations/:id/complete - a difference returns 409", async ({
+    accountAPI,
+    categoryAPI,
+    transactionAPI,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `recon-diff-acc-${Date.now()}`,
+      note: "reconciliation difference",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `recon-diff-cat-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const accountId = acc.data!.id as number;
+
+    const tx = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: cat.data!.id as number,
+      amount: 1000,
+      date: new Date(Date.now() - 24 * 3600 * 1000).toISOString(),
+      type: "expense",
+      status: "cleared",
+    });
+
+    const recon = await accountAPI.createReconciliation(accountId, {
+      statementDate: new Date().toISOString(),
+      statementBalance: -900,
+    });
+    expect(recon.data!.balance!.difference).toBe(100);
+
+    const complete = await accountAPI.completeReconciliation(
+      accountId,
+      recon.data!.id as number
+    );
+    expect(complete.status).toBe(409);
+
+    const still = await transactionAPI.getTransaction(tx.data!.id as number);
+    expect(still.data!.status).toBe("cleared");
+
+    await accountAPI.deleteReconciliation(accountId, recon.data!.id as number);
+    await transactionAPI.deleteTransaction(tx.data!.id as number);
+    await accountAPI.deleteAccount(accountId);
+    await categoryAPI.deleteCategory(cat.data!.id as number);
+  });
+});
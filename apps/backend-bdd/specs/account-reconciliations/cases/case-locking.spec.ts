@@ -0,0 +1,149 @@
+import { test, expect } from "@fixtures/index";
+
+test.describe("Account Reconciliations - Locking Cases", () => {
+  test("completing locks the cleared transactions it covers", async ({
+    accountAPI,
+    categoryAPI,
+    transactionAPI,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `recon-lock-acc-${Date.now()}`,
+      note: "reconciliation locking",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `recon-lock-cat-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const accountId = acc.data!.id as number;
+    const yesterday = new Date(Date.now() - 24 * 3600 * 1000).toISOString();
+
+    const cleared = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: cat.data!.id as number,
+      amount: 1500,
+      date: yesterday,
+      type: "expense",
+      status: "cleared",
+    });
+    const pending = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: cat.data!.id as number,
+      amount: 300,
+      date: yesterday,
+      type: "expense",
+    });
+    const clearedId = cleared.data!.id as number;
+    const pendingId = pending.data!.id as number;
+
+    const recon = await accountAPI.createReconciliation(accountId, {
+      statementDate: new Date().toISOString(),
+      statementBalance: -1500,
+    });
+    const reconId = recon.data!.id as number;
+
+    const complete = await accountAPI.completeReconciliation(
+      accountId,
+      reconId
+    );
+    expect(complete.status).toBe(200);
+    expect(complete.data!.status).toBe("completed");
+    expect(complete.data!.reconciledCount).toBe(1);
+
+    const locked = await transactionAPI.getTransaction(clearedId);
+    expect(locked.data!.status).toBe("reconciled");
+
+    const update = await transactionAPI.updateTransaction(clearedId, {
+      amount: 2000,
+    });
+    expect(update.status).toBe(409);
+
+    const del = await transactionAPI.deleteTransaction(clearedId);
+    expect(del.status).toBe(409);
+
+    // The balance is untouched by the rejected changes
+    const after = await accountAPI.getAccount(accountId);
+    expect(after.data!.amount).toBe(-1800);
+
+    // Pending transactions are not part of the statement and stay editable
+    const open = await transactionAPI.getTransaction(pendingId);
+    expect(open.data!.status).toBe("pending");
+    const pendingUpdate = await transactionAPI.updateTransaction(pendingId, {
+      amount: 350,
+    });
+    expect(pendingUpdate.status).toBe(200);
+
+    // A completed reconciliation is final
+    const again = await accountAPI.completeReconciliation(accountId, reconId);
+    expect(again.status).toBe(409);
+    const discard = await accountAPI.deleteReconciliation(accountId, reconId);
+    expect(discard.status).toBe(409);
+
+    await transactionAPI.deleteTransaction(pendingId);
+    await accountAPI.deleteAccount(accountId);
+    await categoryAPI.deleteCategory(cat.data!.id as number);
+  });
+
+  test("transactions after the statement date are not locked", async ({
+    accountAPI,
+    categoryAPI,
+    transactionAPI,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `recon-later-acc-${Date.now()}`,
+      note: "after statement",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `recon-later-cat-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const accountId = acc.data!.id as number;
+
+    const covered = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: cat.data!.id as number,
+      amount: 700,
+      date: new Date(Date.now() - 3 * 24 * 3600 * 1000).toISOString(),
+      type: "expense",
+      status: "cleared",
+    });
+    const later = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: cat.data!.id as number,
+      amount: 200,
+      date: new Date().toISOString(),
+      type: "expense",
+      status: "cleared",
+    });
+
+    const recon = await accountAPI.createReconciliation(accountId, {
+      statementDate: new Date(Date.now() - 24 * 3600 * 1000).toISOString(),
+      statementBalance: -700,
+    });
+    const complete = await accountAPI.completeReconciliation(
+      accountId,
+      recon.data!.id as number
+    );
+    expect(complete.status).toBe(200);
+    expect(complete.data!.reconciledCount).toBe(1);
+
+    const coveredAfter = await transactionAPI.getTransaction(
+      covered.data!.id as number
+    );
+    expect(coveredAfter.data!.status).toBe("reconciled");
+
+    const laterUpdate = await transactionAPI.updateTransaction(
+      later.data!.id as number,
+      { note: "still editable" }
+    );
+    expect(laterUpdate.status).toBe(200);
+    expect(laterUpdate.data!.status).toBe("cleared");
+
+    await transactionAPI.deleteTransaction(later.data!.id as number);
+    await accountAPI.deleteAccount(accountId);
+    await categoryAPI.deleteCategory(cat.data!.id as number);
+  });
+});
@@ -0,0 +1,45 @@
+import { test, expect } from "@fixtures/index";
+
+test.describe("Accounts - If-Match Cases", () => {
+  test("PATCH and DELETE /accounts/:id - a stale If-Match returns 412", async ({
+    accountAPI,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `ifm-acc-${Date.now()}`,
+      note: "original",
+      type: "expense",
+    });
+    const accountId = acc.data!.id as number;
+
+    const read = await accountAPI.getAccount(accountId);
+    const staleETag = read.data!.etag!;
+    expect(staleETag).toBeTruthy();
+    expect(read.headers["etag"]).toBe(staleETag);
+
+    const first = await accountAPI.updateAccount(
+      accountId,
+      { note: "first writer" },
+      staleETag
+    );
+    expect(first.status).toBe(200);
+    expect(first.data!.etag).not.toBe(staleETag);
+
+    const second = await accountAPI.updateAccount(
+      accountId,
+      { note: "second writer" },
+      staleETag
+    );
+    expect(second.status).toBe(412);
+    expect(second.error).toHaveProperty("current.note", "first writer");
+    expect(second.headers["etag"]).toBe(first.data!.etag);
+
+    const staleDelete = await accountAPI.deleteAccount(accountId, staleETag);
+    expect(staleDelete.status).toBe(412);
+    const stillThere = await accountAPI.getAccount(accountId);
+    expect(stillThere.status).toBe(200);
+    expect(stillThere.data!.note).toBe("first writer");
+
+    const del = await accountAPI.deleteAccount(accountId, first.data!.etag);
+    expect(del.status).toBe(204);
+  });
+});
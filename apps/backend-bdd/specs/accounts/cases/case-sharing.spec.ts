@@ -0,0 +1,169 @@
+import { test, expect } from "@fixtures/index";
+import { AccountAPIClient } from "@fixtures/account-client";
+import { CategoryAPIClient } from "@fixtures/category-client";
+import { TransactionAPIClient } from "@fixtures/transaction-client";
+
+test.describe("Accounts - Sharing Cases", () => {
+  test("viewer can read a shared account but not change it", async ({
+    accountAPI,
+    createUser,
+    request,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `share-viewer-acc-${Date.now()}`,
+      note: "shared read only",
+      type: "expense",
+    });
+    const accountId = acc.data!.id as number;
+    const user = await createUser("e2e-share-viewer");
+
+    const share = await accountAPI.shareAccount(accountId, {
+      username: user.username,
+      role: "viewer",
+    });
+    expect(share.status).toBe(200);
+    expect(
+      (share.data!.items ?? []).find((s) => s.userId === user.id)?.role
+    ).toBe("viewer");
+
+    const accountAPIAsUser = new AccountAPIClient(request, user.context);
+    const seen = await accountAPIAsUser.getAccount(accountId);
+    expect(seen.status).toBe(200);
+    expect(seen.data!.role).toBe("viewer");
+
+    const update = await accountAPIAsUser.updateAccount(accountId, {
+      name: `share-viewer-renamed-${Date.now()}`,
+    });
+    expect(update.status).toBe(403);
+
+    const cat = await new CategoryAPIClient(
+      request,
+      user.context
+    ).createCategory({
+      name: `share-viewer-cat-${Date.now()}`,
+      note: "viewer category",
+      type: "expense",
+    });
+    const tx = await new TransactionAPIClient(
+      request,
+      user.context
+    ).createTransaction({
+      accountId,
+      categoryId: cat.data!.id as number,
+      amount: 1000,
+      date: new Date().toISOString(),
+      type: "expense",
+    });
+    expect(tx.status).toBe(403);
+
+    const after = await accountAPI.getAccount(accountId);
+    expect(after.data!.amount).toBe(0);
+
+    await accountAPI.deleteAccount(accountId);
+  });
+
+  test("editor can record transactions but only the owner manages the account", async ({
+    accountAPI,
+    createUser,
+    request,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `share-editor-acc-${Date.now()}`,
+      note: "shared for editing",
+      type: "expense",
+    });
+    const accountId = acc.data!.id as number;
+    const user = await createUser("e2e-share-editor");
+
+    const share = await accountAPI.shareAccount(accountId, {
+      username: user.username,
+      role: "editor",
+    });
+    expect(share.status).toBe(200);
+
+    const accountAPIAsUser = new AccountAPIClient(request, user.context);
+    const transactionAPIAsUser = new TransactionAPIClient(
+      request,
+      user.context
+    );
+    const cat = await new CategoryAPIClient(
+      request,
+      user.context
+    ).createCategory({
+      name: `share-editor-cat-${Date.now()}`,
+      note: "editor category",
+      type: "expense",
+    });
+
+    const tx = await transactionAPIAsUser.createTransaction({
+      accountId,
+      categoryId: cat.data!.id as number,
+      amount: 2500,
+      date: new Date().toISOString(),
+      type: "expense",
+    });
+    expect(tx.status).toBe(200);
+
+    const owned = await accountAPI.getAccount(accountId);
+    expect(owned.data!.amount).toBe(-2500);
+
+    // Deleting and re-sharing are left to the owner
+    const del = await accountAPIAsUser.deleteAccount(accountId);
+    expect(del.status).toBe(403);
+    const reshare = await accountAPIAsUser.shareAccount(accountId, {
+      username: user.username,
+      role: "editor",
+    });
+    expect(reshare.status).toBe(403);
+
+    await transactionAPIAsUser.deleteTransaction(tx.data!.id as number);
+    await accountAPI.deleteAccount(accountId);
+  });
+
+  test("changing the role and unsharing take effect right away", async ({
+    accountAPI,
+    createUser,
+    request,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `share-role-acc-${Date.now()}`,
+      note: "role changes",
+      type: "expense",
+    });
+    const accountId = acc.data!.id as number;
+    const user = await createUser("e2e-share-role");
+    const accountAPIAsUser = new AccountAPIClient(request, user.context);
+
+    await accountAPI.shareAccount(accountId, {
+      username: user.username,
+      role: "editor",
+    });
+    const renamed = await accountAPIAsUser.updateAccount(accountId, {
+      name: `share-role-renamed-${Date.now()}`,
+    });
+    expect(renamed.status).toBe(200);
+
+    await accountAPI.shareAccount(accountId, {
+      username: user.username,
+      role: "viewer",
+    });
+    const blocked = await accountAPIAsUser.updateAccount(accountId, {
+      name: `share-role-blocked-${Date.now()}`,
+    });
+    expect(blocked.status).toBe(403);
+
+    const unshare = await accountAPI.unshareAccount(accountId, user.id);
+    expect(unshare.status).toBe(204);
+
+    const gone = await accountAPIAsUser.getAccount(accountId);
+    expect(gone.status).toBe(404);
+
+    const shares = await accountAPI.getAccountShares(accountId);
+    expect(shares.status).toBe(200);
+    expect((shares.data!.items ?? []).some((s) => s.userId === user.id)).toBe(
+      false
+    );
+
+    await accountAPI.deleteAccount(accountId);
+  });
+});
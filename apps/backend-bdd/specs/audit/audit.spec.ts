@@ -0,0 +1,196 @@
+import { test, expect } from "@fixtures/index";
+import { AccountAPIClient } from "@fixtures/account-client";
+import { AuditAPIClient } from "@fixtures/audit-client";
+import { CategoryAPIClient } from "@fixtures/category-client";
+import { TagAPIClient } from "@fixtures/tag-client";
+import { TransactionAPIClient } from "@fixtures/transaction-client";
+
+const day = 24 * 3600 * 1000;
+
+test.describe("Audit - Common", () => {
+  test("GET /audit - records creates, updates and deletes with their snapshots", async ({
+    createUser,
+    request,
+  }) => {
+    // A fresh user starts with an empty audit log
+    const user = await createUser("e2e-audit-list");
+    const accountAPI = new AccountAPIClient(request, user.context);
+    const categoryAPI = new CategoryAPIClient(request, user.context);
+    const auditAPI = new AuditAPIClient(request, user.context);
+
+    const empty = await auditAPI.getAuditLogs();
+    expect(empty.status).toBe(200);
+    expect(empty.data!.items ?? []).toHaveLength(0);
+
+    const accountName = `audit-acc-${Date.now()}`;
+    const acc = await accountAPI.createAccount({
+      name: accountName,
+      note: "audit",
+      type: "expense",
+    });
+    const accountId = acc.data!.id as number;
+    await accountAPI.updateAccount(accountId, {
+      name: `${accountName}-renamed`,
+    });
+
+    const categoryName = `audit-cat-${Date.now()}`;
+    const cat = await categoryAPI.createCategory({
+      name: categoryName,
+      note: "audit",
+      type: "expense",
+    });
+    const categoryId = cat.data!.id as number;
+    const del = await categoryAPI.deleteCategory(categoryId);
+    expect(del.status).toBe(204);
+
+    const res = await auditAPI.getAuditLogs();
+    expect(res.status).toBe(200);
+    expect(res.data!.totalCount).toBe(4);
+    const items = res.data!.items ?? [];
+    expect(items.map((e) => [e.entityType, e.operation])).toEqual([
+      ["category", "delete"],
+      ["category", "create"],
+      ["account", "update"],
+      ["account", "create"],
+    ]);
+
+    const deleted = items[0];
+    expect(deleted.entityId).toBe(categoryId);
+    expect(deleted.actorId).toBe(user.id);
+    expect(deleted.actorUsername).toBe(user.username);
+    expect(deleted.source).toBe("api");
+    expect(deleted).toHaveProperty("before.name", categoryName);
+    expect(deleted.after).toBeNull();
+
+    const updated = items[2];
+    expect(updated).toHaveProperty("before.name", accountName);
+    expect(updated).toHaveProperty("after.name", `${accountName}-renamed`);
+
+    const created = items[3];
+    expect(created.before).toBeNull();
+    expect(created).toHaveProperty("after.name", accountName);
+  });
+
+  test("GET /audit - filters by entity, operation, actor and time", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-audit-filters");
+    const accountAPI = new AccountAPIClient(request, user.context);
+    const categoryAPI = new CategoryAPIClient(request, user.context);
+    const auditAPI = new AuditAPIClient(request, user.context);
+
+    const acc = await accountAPI.createAccount({
+      name: `audit-filter-acc-${Date.now()}`,
+      note: "audit",
+      type: "expense",
+    });
+    const accountId = acc.data!.id as number;
+    await accountAPI.updateAccount(accountId, { note: "changed" });
+    const otherAcc = await accountAPI.createAccount({
+      name: `audit-filter-other-${Date.now()}`,
+      note: "audit",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `audit-filter-cat-${Date.now()}`,
+      note: "audit",
+      type: "expense",
+    });
+    await categoryAPI.deleteCategory(cat.data!.id as number);
+
+    const accounts = await auditAPI.getAuditLogs({
+      entityType: ["account"],
+      sortOrder: "asc",
+    });
+    expect(accounts.status).toBe(200);
+    expect((accounts.data!.items ?? []).map((e) => e.operation)).toEqual([
+      "create",
+      "update",
+      "create",
+    ]);
+
+    const oneAccount = await auditAPI.getAuditLogs({
+      entityType: ["account"],
+      entityId: accountId,
+    });
+    expect(oneAccount.data!.items ?? []).toHaveLength(2);
+    expect(
+      (oneAccount.data!.items ?? []).every((e) => e.entityId === accountId)
+    ).toBe(true);
+
+    const deletes = await auditAPI.getAuditLogs({ operation: ["delete"] });
+    expect(deletes.data!.items ?? []).toHaveLength(1);
+    expect(deletes.data!.items![0].entityType).toBe("category");
+
+    const byActor = await auditAPI.getAuditLogs({ actorId: user.id });
+    expect(byActor.data!.totalCount).toBe(5);
+
+    const future = await auditAPI.getAuditLogs({
+      startDate: new Date(Date.now() + day).toISOString(),
+    });
+    expect(future.data!.items ?? []).toHaveLength(0);
+    const past = await auditAPI.getAuditLogs({
+      endDate: new Date(Date.now() - day).toISOString(),
+    });
+    expect(past.data!.items ?? []).toHaveLength(0);
+
+    const paged = await auditAPI.getAuditLogs({ pageSize: 2 });
+    expect(paged.data!.items ?? []).toHaveLength(2);
+    expect(paged.data!.totalCount).toBe(5);
+    expect(paged.data!.totalPages).toBe(3);
+
+    const invalid = await auditAPI.getAuditLogs({
+      entityType: ["not-an-entity" as "account"],
+    });
+    expect(invalid.status).toBe(422);
+
+    await accountAPI.deleteAccount(otherAcc.data!.id as number);
+  });
+
+  test("GET /audit - tag links are recorded against their transaction", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-audit-tags");
+    const accountAPI = new AccountAPIClient(request, user.context);
+    const categoryAPI = new CategoryAPIClient(request, user.context);
+    const tagAPI = new TagAPIClient(request, user.context);
+    const transactionAPI = new TransactionAPIClient(request, user.context);
+    const auditAPI = new AuditAPIClient(request, user.context);
+
+    const acc = await accountAPI.createAccount({
+      name: `audit-tag-acc-${Date.now()}`,
+      note: "audit",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `audit-tag-cat-${Date.now()}`,
+      note: "audit",
+      type: "expense",
+    });
+    const tag = await tagAPI.createTag({ name: `audit-tag-${Date.now()}` });
+    const tx = await transactionAPI.createTransaction({
+      accountId: acc.data!.id as number,
+      categoryId: cat.data!.id as number,
+      amount: 1000,
+      date: new Date().toISOString(),
+      type: "expense",
+    });
+    const txId = tx.data!.id as number;
+    const tagId = tag.data!.id as number;
+
+    await transactionAPI.addTransactionTag(txId, tagId);
+    await transactionAPI.removeTransactionTag(txId, tagId);
+
+    const links = await auditAPI.getAuditLogs({
+      entityType: ["transaction_tag"],
+      entityId: txId,
+      sortOrder: "asc",
+    });
+    expect(links.status).toBe(200);
+    const items = links.data!.items ?? [];
+    expect(items.map((e) => e.operation)).toEqual(["create", "delete"]);
+    expect(items[1]).toHaveProperty("before.tagId", tagId);
+  });
+});
@@ -0,0 +1,62 @@
+import { test, expect } from "@fixtures/index";
+import { AuthAPIClient } from "@fixtures/auth-client";
+import { UserAPIClient } from "@fixtures/user-client";
+
+test.describe("Auth - Common", () => {
+  test("POST /auth/login - returns an access and refresh token pair", async ({
+    createUser,
+    request,
+    testContext,
+  }) => {
+    const user = await createUser("e2e-auth-login");
+    const authAPI = new AuthAPIClient(request, {
+      baseURL: testContext.baseURL,
+    });
+
+    const res = await authAPI.login(user.username, user.password);
+    expect(res.status).toBe(200);
+    expect(res.data!.access_token).toBeTruthy();
+    expect(res.data!.refresh_token).toBeTruthy();
+    expect(res.data!.access_token).not.toBe(res.data!.refresh_token);
+  });
+
+  test("POST /auth/login - wrong password returns 401", async ({
+    createUser,
+    request,
+    testContext,
+  }) => {
+    const user = await createUser("e2e-auth-wrong");
+    const authAPI = new AuthAPIClient(request, {
+      baseURL: testContext.baseURL,
+    });
+
+    const res = await authAPI.login(user.username, "not-the-password");
+    expect(res.status).toBe(401);
+    expect(res.data).toBeUndefined();
+  });
+
+  test("refresh token is not accepted as an access token", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-auth-typed");
+    const userAPI = new UserAPIClient(request, {
+      baseURL: user.context.baseURL,
+      accessToken: user.context.refreshToken,
+    });
+
+    const res = await userAPI.getCurrentUser();
+    expect(res.status).toBe(401);
+  });
+
+  test("access token is not accepted as a refresh token", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-auth-typed-refresh");
+    const authAPI = new AuthAPIClient(request, user.context);
+
+    const res = await authAPI.refresh(user.context.accessToken);
+    expect(res.status).toBe(401);
+  });
+});
@@ -0,0 +1,181 @@
+import { test, expect } from "@fixtures/index";
+import { AuthAPIClient } from "@fixtures/auth-client";
+import { AccountAPIClient } from "@fixtures/account-client";
+import { SummaryAPIClient } from "@fixtures/summary-client";
+import { UserAPIClient } from "@fixtures/user-client";
+
+test.describe("Auth - Personal Access Token Cases", () => {
+  test("read-only token can read but not write", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-pat-read");
+    const authAPI = new AuthAPIClient(request, user.context);
+
+    const created = await authAPI.createAPIToken({
+      name: "read only",
+      scopes: ["transactions:read"],
+    });
+    expect(created.status).toBe(200);
+    expect(created.data!.token).toBeTruthy();
+    expect(created.data!.scopes).toEqual(["transactions:read"]);
+
+    const patContext = {
+      baseURL: user.context.baseURL,
+      accessToken: created.data!.token,
+    };
+    const accountAPIWithToken = new AccountAPIClient(request, patContext);
+
+    const list = await accountAPIWithToken.getAccounts();
+    expect(list.status).toBe(200);
+
+    const create = await accountAPIWithToken.createAccount({
+      name: `pat-acc-${Date.now()}`,
+      note: "should be rejected",
+      type: "expense",
+    });
+    expect(create.status).toBe(403);
+  });
+
+  test("each operation requires its own scope", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-pat-scopes");
+    const authAPI = new AuthAPIClient(request, user.context);
+
+    const writer = await authAPI.createAPIToken({
+      name: "writer",
+      scopes: ["transactions:read", "transactions:write"],
+    });
+    const reporter = await authAPI.createAPIToken({
+      name: "reporter",
+      scopes: ["reports:read"],
+    });
+    expect(writer.status).toBe(200);
+    expect(reporter.status).toBe(200);
+
+    const range = {
+      startDate: new Date(Date.now() - 3600 * 1000).toISOString(),
+      endDate: new Date(Date.now() + 3600 * 1000).toISOString(),
+    };
+
+    const writerContext = {
+      baseURL: user.context.baseURL,
+      accessToken: writer.data!.token,
+    };
+    const acc = await new AccountAPIClient(
+      request,
+      writerContext
+    ).createAccount({
+      name: `pat-writer-acc-${Date.now()}`,
+      note: "created with a token",
+      type: "expense",
+    });
+    expect(acc.status).toBe(200);
+
+    const writerSummary = await new SummaryAPIClient(
+      request,
+      writerContext
+    ).getCategorySummary(range);
+    expect(writerSummary.status).toBe(403);
+
+    const reporterContext = {
+      baseURL: user.context.baseURL,
+      accessToken: reporter.data!.token,
+    };
+    const reporterSummary = await new SummaryAPIClient(
+      request,
+      reporterContext
+    ).getCategorySummary(range);
+    expect(reporterSummary.status).toBe(200);
+
+    const reporterAccounts = await new AccountAPIClient(
+      request,
+      reporterContext
+    ).getAccounts();
+    expect(reporterAccounts.status).toBe(403);
+  });
+
+  test("tokens cannot reach session-only operations", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-pat-session");
+    const authAPI = new AuthAPIClient(request, user.context);
+
+    const created = await authAPI.createAPIToken({
+      name: "all scopes",
+      scopes: [
+        "transactions:read",
+        "transactions:write",
+        "budgets:read",
+        "budgets:write",
+        "reports:read",
+      ],
+    });
+    expect(created.status).toBe(200);
+
+    const patContext = {
+      baseURL: user.context.baseURL,
+      accessToken: created.data!.token,
+    };
+
+    const me = await new UserAPIClient(request, patContext).getCurrentUser();
+    expect(me.status).toBe(403);
+
+    // A token cannot mint further tokens
+    const minted = await new AuthAPIClient(request, patContext).createAPIToken(
+      { name: "minted", scopes: ["transactions:read"] }
+    );
+    expect(minted.status).toBe(403);
+  });
+
+  test("DELETE /auth/tokens/:id - revoked token is rejected", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-pat-revoke");
+    const authAPI = new AuthAPIClient(request, user.context);
+
+    const created = await authAPI.createAPIToken({
+      name: "to revoke",
+      scopes: ["transactions:read"],
+    });
+    const tokenId = created.data!.id as number;
+    const accountAPIWithToken = new AccountAPIClient(request, {
+      baseURL: user.context.baseURL,
+      accessToken: created.data!.token,
+    });
+
+    const before = await accountAPIWithToken.getAccounts();
+    expect(before.status).toBe(200);
+
+    const listed = await authAPI.getAPITokens();
+    expect(listed.status).toBe(200);
+    const item = (listed.data!.items ?? []).find((t) => t.id === tokenId);
+    expect(item).toBeDefined();
+    expect(item).not.toHaveProperty("token");
+
+    const revoke = await authAPI.revokeAPIToken(tokenId);
+    expect(revoke.status).toBe(204);
+
+    const after = await accountAPIWithToken.getAccounts();
+    expect(after.status).toBe(401);
+  });
+
+  test("POST /auth/tokens - expiry in the past returns 400", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-pat-expiry");
+    const authAPI = new AuthAPIClient(request, user.context);
+
+    const res = await authAPI.createAPIToken({
+      name: "expired",
+      scopes: ["transactions:read"],
+      expiresAt: new Date(Date.now() - 3600 * 1000).toISOString(),
+    });
+    expect(res.status).toBe(400);
+  });
+});
@@ -0,0 +1,87 @@
+import { test, expect, type TestContext } from "@fixtures/index";
+import { AuthAPIClient } from "@fixtures/auth-client";
+import { AccountAPIClient } from "@fixtures/account-client";
+import { UserAPIClient } from "@fixtures/user-client";
+
+test.describe("Auth - Change Password Cases", () => {
+  test("POST /auth/change-password - revokes sessions and personal access tokens", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-change-password");
+    const authAPI = new AuthAPIClient(request, user.context);
+
+    const other: TestContext = { baseURL: user.context.baseURL };
+    await new AuthAPIClient(request, other).login(user.username, user.password);
+
+    const pat = await authAPI.createAPIToken({
+      name: "before password change",
+      scopes: ["transactions:read"],
+    });
+    expect(pat.status).toBe(200);
+    const patContext = {
+      baseURL: user.context.baseURL,
+      accessToken: pat.data!.token,
+    };
+
+    const tokens = { ...user.context };
+    const newPassword = "e2e-user-new-password";
+    const res = await authAPI.changePassword(user.password, newPassword);
+    expect(res.status).toBe(204);
+
+    for (const ctx of [tokens, other]) {
+      const me = await new UserAPIClient(request, ctx).getCurrentUser();
+      expect(me.status).toBe(401);
+
+      const refreshed = await new AuthAPIClient(request, {
+        baseURL: ctx.baseURL,
+      }).refresh(ctx.refreshToken);
+      expect(refreshed.status).toBe(401);
+    }
+
+    const accounts = await new AccountAPIClient(
+      request,
+      patContext
+    ).getAccounts();
+    expect(accounts.status).toBe(401);
+
+    // The old password no longer works, the new one does right away
+    const fresh: TestContext = { baseURL: user.context.baseURL };
+    const freshAuthAPI = new AuthAPIClient(request, fresh);
+    const oldLogin = await freshAuthAPI.login(user.username, user.password);
+    expect(oldLogin.status).toBe(401);
+
+    const newLogin = await freshAuthAPI.login(user.username, newPassword);
+    expect(newLogin.status).toBe(200);
+    const me = await new UserAPIClient(request, fresh).getCurrentUser();
+    expect(me.status).toBe(200);
+  });
+
+  test("POST /auth/change-password - wrong current password returns 403 and keeps the session", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-change-password-wrong");
+    const authAPI = new AuthAPIClient(request, user.context);
+
+    const res = await authAPI.changePassword(
+      "not-the-password",
+      "e2e-user-new-password"
+    );
+    expect(res.status).toBe(403);
+
+    const me = await new UserAPIClient(request, user.context).getCurrentUser();
+    expect(me.status).toBe(200);
+  });
+
+  test("POST /auth/change-password - new password shorter than 8 characters returns 422", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-change-password-short");
+    const authAPI = new AuthAPIClient(request, user.context);
+
+    const res = await authAPI.changePassword(user.password, "short");
+    expect(res.status).toBe(422);
+  });
+});
@@ -0,0 +1,115 @@
+import { test, expect, type TestContext } from "@fixtures/index";
+import { AuthAPIClient } from "@fixtures/auth-client";
+
+test.describe("Auth - Login Throttle Cases", () => {
+  test("POST /auth/login - five failures from one client lock it out with 429", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-throttle-lock");
+    const context: TestContext = { baseURL: user.context.baseURL };
+    const authAPI = new AuthAPIClient(request, context);
+    const clientIP = "203.0.113.10";
+
+    for (let i = 0; i < 4; i++) {
+      const res = await authAPI.login(
+        user.username,
+        "wrong-password",
+        clientIP
+      );
+      expect(res.status).toBe(401);
+    }
+
+    const locked = await authAPI.login(
+      user.username,
+      "wrong-password",
+      clientIP
+    );
+    expect(locked.status).toBe(429);
+    expect(locked.headers["retry-after"]).toBeTruthy();
+    expect(locked.error).toHaveProperty("lockedUntil");
+    const lockedUntil = new Date(
+      (locked.error as unknown as { lockedUntil: string }).lockedUntil
+    );
+    expect(lockedUntil.getTime()).toBeGreaterThan(Date.now());
+
+    // The right password does not get through while the lockout lasts
+    const stillLocked = await authAPI.login(
+      user.username,
+      user.password,
+      clientIP
+    );
+    expect(stillLocked.status).toBe(429);
+
+    // Another client of the same user is tracked on its own
+    const otherClient = await authAPI.login(
+      user.username,
+      user.password,
+      "203.0.113.20"
+    );
+    expect(otherClient.status).toBe(200);
+  });
+
+  test("POST /auth/login - a successful login resets the failure count", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-throttle-reset");
+    const context: TestContext = { baseURL: user.context.baseURL };
+    const authAPI = new AuthAPIClient(request, context);
+    const clientIP = "203.0.113.30";
+
+    for (let i = 0; i < 4; i++) {
+      const res = await authAPI.login(
+        user.username,
+        "wrong-password",
+        clientIP
+      );
+      expect(res.status).toBe(401);
+    }
+
+    const success = await authAPI.login(user.username, user.password, clientIP);
+    expect(success.status).toBe(200);
+
+    // Four more failures stay below the threshold once the count was reset
+    for (let i = 0; i < 4; i++) {
+      const res = await authAPI.login(
+        user.username,
+        "wrong-password",
+        clientIP
+      );
+      expect(res.status).toBe(401);
+    }
+    const afterReset = await authAPI.login(
+      user.username,
+      user.password,
+      clientIP
+    );
+    expect(afterReset.status).toBe(200);
+  });
+
+  test("POST /auth/login - the port of a forwarded address does not start a new count", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-throttle-port");
+    const context: TestContext = { baseURL: user.context.baseURL };
+    const authAPI = new AuthAPIClient(request, context);
+
+    for (let i = 0; i < 4; i++) {
+      const res = await authAPI.login(
+        user.username,
+        "wrong-password",
+        `203.0.113.40:${50000 + i}`
+      );
+      expect(res.status).toBe(401);
+    }
+
+    const locked = await authAPI.login(
+      user.username,
+      "wrong-password",
+      "203.0.113.40:50004"
+    );
+    expect(locked.status).toBe(429);
+  });
+});
@@ -0,0 +1,228 @@
+import { test, expect, type TestContext } from "@fixtures/index";
+import { AuthAPIClient } from "@fixtures/auth-client";
+import { UserAPIClient } from "@fixtures/user-client";
+import type { APIRequestContext } from "@playwright/test";
+
+// The API reaches the mock issuer by its compose service name, the specs through the published port
+const ISSUER_ORIGIN = "http://localhost:8082";
+
+/**
+ * Sign in at the mock issuer as the given subject and return the code and
+ * state it redirects back with
+ */
+async function signInAtIssuer(
+  request: APIRequestContext,
+  authorizationURL: string,
+  subject: string,
+  claims: Record<string, unknown>
+): Promise<{ code: string; state: string }> {
+  const issuerURL = new URL(authorizationURL);
+  const origin = new URL(ISSUER_ORIGIN);
+  issuerURL.protocol = origin.protocol;
+  issuerURL.host = origin.host;
+
+  const res = await request.post(issuerURL.toString(), {
+    form: { username: subject, claims: JSON.stringify(claims) },
+    maxRedirects: 0,
+  });
+  expect(res.status()).toBe(302);
+
+  const redirect = new URL(res.headers()["location"]);
+  return {
+    code: redirect.searchParams.get("code") ?? "",
+    state: redirect.searchParams.get("state") ?? "",
+  };
+}
+
+test.describe("Auth - OpenID Connect Cases", () => {
+  test("GET /auth/oidc/authorize - returns the issuer URL with state, nonce and an S256 challenge", async ({
+    request,
+  }) => {
+    const authAPI = new AuthAPIClient(request, {
+      baseURL: "http://localhost:8080",
+    });
+
+    const res = await authAPI.oidcAuthorize();
+    expect(res.status).toBe(200);
+
+    const url = new URL(res.data!.authorization_url);
+    expect(url.pathname).toBe("/default/authorize");
+    expect(url.searchParams.get("response_type")).toBe("code");
+    expect(url.searchParams.get("client_id")).toBe("spenicle");
+    expect(url.searchParams.get("state")).toBe(res.data!.state);
+    expect(url.searchParams.get("nonce")).toBeTruthy();
+    expect(url.searchParams.get("code_challenge")).toBeTruthy();
+    expect(url.searchParams.get("code_challenge_method")).toBe("S256");
+    expect(url.searchParams.has("code_verifier")).toBe(false);
+
+    const other = await authAPI.oidcAuthorize();
+    expect(other.data!.state).not.toBe(res.data!.state);
+    const otherURL = new URL(other.data!.authorization_url);
+    expect(otherURL.searchParams.get("nonce")).not.toBe(
+      url.searchParams.get("nonce")
+    );
+    expect(otherURL.searchParams.get("code_challenge")).not.toBe(
+      url.searchParams.get("code_challenge")
+    );
+  });
+
+  test("POST /auth/oidc/callback - links a verified email to the user and logs in by subject afterwards", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-oidc");
+    const subject = `oidc-subject-${Date.now()}`;
+    const context: TestContext = { baseURL: user.context.baseURL };
+    const authAPI = new AuthAPIClient(request, context);
+
+    const start = await authAPI.oidcAuthorize();
+    const redirect = await signInAtIssuer(
+      request,
+      start.data!.authorization_url,
+      subject,
+      { email: user.username, email_verified: true }
+    );
+    expect(redirect.state).toBe(start.data!.state);
+
+    const res = await authAPI.oidcCallback({
+      ...redirect,
+      device_name: "OIDC browser",
+    });
+    expect(res.status).toBe(200);
+    expect(res.data!.access_token).toBeTruthy();
+    expect(res.data!.refresh_token).toBeTruthy();
+
+    const me = await new UserAPIClient(request, context).getCurrentUser();
+    expect(me.status).toBe(200);
+    expect(me.data!.id).toBe(user.id);
+
+    const sessions = await authAPI.getSessions();
+    expect(
+      sessions.data!.items!.some((s) => s.deviceName === "OIDC browser")
+    ).toBe(true);
+
+    // Once linked the subject is enough, the email claim is no longer used
+    const next: TestContext = { baseURL: user.context.baseURL };
+    const nextAPI = new AuthAPIClient(request, next);
+    const again = await nextAPI.oidcAuthorize();
+    const nextRedirect = await signInAtIssuer(
+      request,
+      again.data!.authorization_url,
+      subject,
+      {}
+    );
+    const relogin = await nextAPI.oidcCallback(nextRedirect);
+    expect(relogin.status).toBe(200);
+
+    const nextMe = await new UserAPIClient(request, next).getCurrentUser();
+    expect(nextMe.data!.id).toBe(user.id);
+  });
+
+  test("POST /auth/oidc/callback - rejects an email the issuer did not mark as verified", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-oidc-unverified");
+    const authAPI = new AuthAPIClient(request, {
+      baseURL: user.context.baseURL,
+    });
+
+    const cases: Record<string, unknown>[] = [
+      { email: user.username, email_verified: false },
+      { email: user.username, email_verified: "false" },
+      { email: user.username, email_verified: "yes" },
+      { email: user.username },
+    ];
+
+    for (const claims of cases) {
+      const start = await authAPI.oidcAuthorize();
+      const redirect = await signInAtIssuer(
+        request,
+        start.data!.authorization_url,
+        `oidc-unverified-${Date.now()}`,
+        claims
+      );
+
+      const res = await authAPI.oidcCallback(redirect);
+      expect(res.status).toBe(403);
+      expect(res.error!.detail).toContain("not verified");
+    }
+  });
+
+  test("POST /auth/oidc/callback - rejects an identity that matches no user", async ({
+    request,
+  }) => {
+    const authAPI = new AuthAPIClient(request, {
+      baseURL: "http://localhost:8080",
+    });
+
+    const start = await authAPI.oidcAuthorize();
+    const redirect = await signInAtIssuer(
+      request,
+      start.data!.authorization_url,
+      `oidc-stranger-${Date.now()}`,
+      { email: `nobody-${Date.now()}`, email_verified: true }
+    );
+
+    const res = await authAPI.oidcCallback(redirect);
+    expect(res.status).toBe(403);
+  });
+
+  test("POST /auth/oidc/callback - a state can be used once", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-oidc-state");
+    const authAPI = new AuthAPIClient(request, {
+      baseURL: user.context.baseURL,
+    });
+
+    const start = await authAPI.oidcAuthorize();
+    const redirect = await signInAtIssuer(
+      request,
+      start.data!.authorization_url,
+      `oidc-state-${Date.now()}`,
+      { email: user.username, email_verified: true }
+    );
+
+    const first = await authAPI.oidcCallback(redirect);
+    expect(first.status).toBe(200);
+
+    const replay = await authAPI.oidcCallback(redirect);
+    expect(replay.status).toBe(401);
+
+    const unknown = await authAPI.oidcCallback({
+      code: redirect.code,
+      state: "unknown-state",
+    });
+    expect(unknown.status).toBe(401);
+  });
+
+  test("POST /auth/oidc/callback - a code only redeems with the nonce and verifier of its own attempt", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-oidc-nonce");
+    const authAPI = new AuthAPIClient(request, {
+      baseURL: user.context.baseURL,
+    });
+
+    const first = await authAPI.oidcAuthorize();
+    const second = await authAPI.oidcAuthorize();
+
+    // The code is issued for the first attempt but sent back with the state of the second,
+    // so the API redeems it with the second nonce and code verifier
+    const redirect = await signInAtIssuer(
+      request,
+      first.data!.authorization_url,
+      `oidc-nonce-${Date.now()}`,
+      { email: user.username, email_verified: true }
+    );
+    const res = await authAPI.oidcCallback({
+      code: redirect.code,
+      state: second.data!.state,
+    });
+    expect(res.status).toBe(401);
+    expect(res.error!.detail).toContain("identity provider");
+  });
+});
@@ -0,0 +1,62 @@
+import { test, expect } from "@fixtures/index";
+import { AuthAPIClient } from "@fixtures/auth-client";
+import { UserAPIClient } from "@fixtures/user-client";
+
+test.describe("Auth - Refresh Rotation Cases", () => {
+  test("POST /auth/refresh - issues a new pair that works", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-rotate");
+    const authAPI = new AuthAPIClient(request, user.context);
+    const firstRefresh = user.context.refreshToken!;
+
+    const res = await authAPI.refresh();
+    expect(res.status).toBe(200);
+    expect(res.data!.refresh_token).not.toBe(firstRefresh);
+
+    const userAPI = new UserAPIClient(request, user.context);
+    const me = await userAPI.getCurrentUser();
+    expect(me.status).toBe(200);
+    expect(me.data!.username).toBe(user.username);
+  });
+
+  test("POST /auth/refresh - a refresh token can only be used once", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-rotate-reuse");
+    const authAPI = new AuthAPIClient(request, user.context);
+    const firstRefresh = user.context.refreshToken!;
+
+    const rotated = await authAPI.refresh(firstRefresh);
+    expect(rotated.status).toBe(200);
+
+    const replay = await authAPI.refresh(firstRefresh);
+    expect(replay.status).toBe(401);
+
+    // The rotated token is still valid
+    const next = await authAPI.refresh(rotated.data!.refresh_token);
+    expect(next.status).toBe(200);
+  });
+
+  test("POST /auth/refresh - concurrent replays succeed only once", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-rotate-race");
+    const refreshToken = user.context.refreshToken!;
+
+    const results = await Promise.all(
+      Array.from({ length: 5 }, () =>
+        new AuthAPIClient(request, { baseURL: user.context.baseURL }).refresh(
+          refreshToken
+        )
+      )
+    );
+
+    const statuses = results.map((r) => r.status);
+    expect(statuses.filter((s) => s === 200)).toHaveLength(1);
+    expect(statuses.filter((s) => s === 401)).toHaveLength(4);
+  });
+});
@@ -0,0 +1,91 @@
+import { test, expect, type TestContext } from "@fixtures/index";
+import { AuthAPIClient } from "@fixtures/auth-client";
+import { UserAPIClient } from "@fixtures/user-client";
+
+test.describe("Auth - Revocation Cases", () => {
+  test("POST /auth/logout - revokes the access token and the given refresh token", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-logout");
+    const tokens = { ...user.context };
+    const authAPI = new AuthAPIClient(request, user.context);
+
+    const res = await authAPI.signOut(tokens.refreshToken);
+    expect(res.status).toBe(204);
+
+    const me = await new UserAPIClient(request, tokens).getCurrentUser();
+    expect(me.status).toBe(401);
+
+    const refreshed = await authAPI.refresh(tokens.refreshToken);
+    expect(refreshed.status).toBe(401);
+  });
+
+  test("POST /auth/logout - other sessions stay logged in", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-logout-other");
+    const other: TestContext = { baseURL: user.context.baseURL };
+    const login = await new AuthAPIClient(request, other).login(
+      user.username,
+      user.password
+    );
+    expect(login.status).toBe(200);
+
+    const res = await new AuthAPIClient(request, user.context).signOut(
+      user.context.refreshToken
+    );
+    expect(res.status).toBe(204);
+
+    const me = await new UserAPIClient(request, other).getCurrentUser();
+    expect(me.status).toBe(200);
+  });
+
+  test("POST /auth/logout-all - revokes every session of the user", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-logout-all");
+    const other: TestContext = { baseURL: user.context.baseURL };
+    await new AuthAPIClient(request, other).login(user.username, user.password);
+    const tokens = { ...user.context };
+
+    const res = await new AuthAPIClient(request, user.context).logoutAll();
+    expect(res.status).toBe(204);
+
+    for (const ctx of [tokens, other]) {
+      const me = await new UserAPIClient(request, ctx).getCurrentUser();
+      expect(me.status).toBe(401);
+
+      const refreshed = await new AuthAPIClient(request, {
+        baseURL: ctx.baseURL,
+      }).refresh(ctx.refreshToken);
+      expect(refreshed.status).toBe(401);
+    }
+
+    // Logging in again right away works
+    const fresh: TestContext = { baseURL: user.context.baseURL };
+    const relogin = await new AuthAPIClient(request, fresh).login(
+      user.username,
+      user.password
+    );
+    expect(relogin.status).toBe(200);
+    const me = await new UserAPIClient(request, fresh).getCurrentUser();
+    expect(me.status).toBe(200);
+  });
+
+  test("POST /auth/logout-all - does not affect other users", async ({
+    createUser,
+    userAPI,
+    request,
+  }) => {
+    const user = await createUser("e2e-logout-all-scope");
+
+    const res = await new AuthAPIClient(request, user.context).logoutAll();
+    expect(res.status).toBe(204);
+
+    const me = await userAPI.getCurrentUser();
+    expect(me.status).toBe(200);
+  });
+});
@@ -0,0 +1,147 @@
+import { test, expect, type TestContext, type TestUser } from "@fixtures/index";
+import { AuthAPIClient } from "@fixtures/auth-client";
+import { UserAPIClient } from "@fixtures/user-client";
+import type { APIRequestContext } from "@playwright/test";
+
+/**
+ * Log the user in on a context of its own, as a device behind the deploy proxy
+ * forwarding the given address, and return that context
+ */
+async function loginAsDevice(
+  request: APIRequestContext,
+  user: TestUser,
+  forwardedFor: string
+): Promise<TestContext> {
+  const context: TestContext = { baseURL: user.context.baseURL };
+  const login = await new AuthAPIClient(request, context).login(
+    user.username,
+    user.password,
+    forwardedFor
+  );
+  expect(login.status).toBe(200);
+  return context;
+}
+
+test.describe("Auth - Session Cases", () => {
+  test("GET /auth/sessions - lists every device with the forwarded client IP", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-sessions");
+    await loginAsDevice(request, user, "198.51.100.7, 203.0.113.10:51234");
+
+    const res = await new AuthAPIClient(request, user.context).getSessions();
+    expect(res.status).toBe(200);
+    expect(res.data!.items).toHaveLength(2);
+
+    const current = res.data!.items!.filter((s) => s.current);
+    expect(current).toHaveLength(1);
+
+    // The proxy appends the address it saw last, the port is dropped
+    const device = res.data!.items!.find((s) => !s.current)!;
+    expect(device.ipAddress).toBe("203.0.113.10");
+    expect(device).toHaveProperty("userAgent");
+    expect(device).toHaveProperty("createdAt");
+    expect(device).toHaveProperty("lastSeenAt");
+    expect(new Date(device.expiresAt).getTime()).toBeGreaterThan(Date.now());
+  });
+
+  test("GET /auth/sessions - the IP address follows the device as it keeps using the session", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-sessions-seen");
+    const device = await loginAsDevice(request, user, "203.0.113.20");
+
+    const me = await request.get(`${device.baseURL}/users/me`, {
+      headers: {
+        Authorization: `Bearer ${device.accessToken}`,
+        "X-Forwarded-For": "203.0.113.21",
+      },
+    });
+    expect(me.status()).toBe(200);
+
+    const res = await new AuthAPIClient(request, user.context).getSessions();
+    const other = res.data!.items!.find((s) => !s.current)!;
+    expect(other.ipAddress).toBe("203.0.113.21");
+  });
+
+  test("DELETE /auth/sessions/:id - invalidates the access and refresh tokens of that session only", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-sessions-revoke");
+    const device = await loginAsDevice(request, user, "203.0.113.30");
+    const tokens = { ...device };
+    const authAPI = new AuthAPIClient(request, user.context);
+
+    const before = await authAPI.getSessions();
+    const target = before.data!.items!.find((s) => !s.current)!;
+
+    const res = await authAPI.revokeSession(target.id);
+    expect(res.status).toBe(204);
+
+    const me = await new UserAPIClient(request, tokens).getCurrentUser();
+    expect(me.status).toBe(401);
+
+    const refreshed = await new AuthAPIClient(request, {
+      baseURL: tokens.baseURL,
+    }).refresh(tokens.refreshToken);
+    expect(refreshed.status).toBe(401);
+
+    const after = await authAPI.getSessions();
+    expect(after.data!.items!.map((s) => s.id)).not.toContain(target.id);
+
+    // The session making the request is untouched
+    const own = await new UserAPIClient(request, user.context).getCurrentUser();
+    expect(own.status).toBe(200);
+
+    const again = await authAPI.revokeSession(target.id);
+    expect(again.status).toBe(404);
+  });
+
+  test("DELETE /auth/sessions/:id - revoking the current session logs it out", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-sessions-self");
+    const tokens = { ...user.context };
+    const authAPI = new AuthAPIClient(request, user.context);
+
+    const list = await authAPI.getSessions();
+    const current = list.data!.items!.find((s) => s.current)!;
+
+    const res = await authAPI.revokeSession(current.id);
+    expect(res.status).toBe(204);
+
+    const me = await new UserAPIClient(request, tokens).getCurrentUser();
+    expect(me.status).toBe(401);
+
+    const refreshed = await new AuthAPIClient(request, {
+      baseURL: tokens.baseURL,
+    }).refresh(tokens.refreshToken);
+    expect(refreshed.status).toBe(401);
+  });
+
+  test("DELETE /auth/sessions/:id - sessions of other users are not found", async ({
+    createUser,
+    request,
+  }) => {
+    const owner = await createUser("e2e-sessions-owner");
+    const other = await createUser("e2e-sessions-other");
+
+    const list = await new AuthAPIClient(request, owner.context).getSessions();
+    const session = list.data!.items![0];
+
+    const res = await new AuthAPIClient(request, other.context).revokeSession(
+      session.id
+    );
+    expect(res.status).toBe(404);
+
+    const me = await new UserAPIClient(
+      request,
+      owner.context
+    ).getCurrentUser();
+    expect(me.status).toBe(200);
+  });
+});
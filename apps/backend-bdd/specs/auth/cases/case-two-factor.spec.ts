@@ -0,0 +1,162 @@
+import { test, expect, type TestUser } from "@fixtures/index";
+import { AuthAPIClient } from "@fixtures/auth-client";
+import { currentTOTPStep, generateTOTP } from "@fixtures/totp";
+import type { APIRequestContext } from "@playwright/test";
+
+/**
+ * Enable two-factor for a user, returning the secret, the time step used to confirm it and the recovery codes
+ */
+async function enableTwoFactor(request: APIRequestContext, user: TestUser) {
+  const authAPI = new AuthAPIClient(request, user.context);
+
+  const enroll = await authAPI.enrollTwoFactor();
+  expect(enroll.status).toBe(200);
+  const secret = enroll.data!.secret;
+
+  const step = currentTOTPStep();
+  const verify = await authAPI.verifyTwoFactor({
+    code: generateTOTP(secret, step),
+  });
+  expect(verify.status).toBe(200);
+  const recoveryCodes = verify.data!.recoveryCodes ?? [];
+  expect(recoveryCodes.length).toBeGreaterThan(0);
+
+  return { secret, step, recoveryCodes };
+}
+
+/**
+ * Log in with the password only and return the challenge token
+ */
+async function startLogin(request: APIRequestContext, user: TestUser) {
+  const authAPI = new AuthAPIClient(request, { baseURL: user.context.baseURL });
+  const login = await authAPI.login(user.username, user.password);
+  expect(login.status).toBe(200);
+  expect(login.data!.two_factor_required).toBe(true);
+  expect(login.data!.access_token).toBeFalsy();
+  expect(login.data!.challenge_token).toBeTruthy();
+  return { authAPI, challenge: login.data!.challenge_token! };
+}
+
+test.describe("Auth - Two-Factor Cases", () => {
+  test("a TOTP code cannot be replayed", async ({ createUser, request }) => {
+    const user = await createUser("e2e-2fa-replay");
+    const { secret, step } = await enableTwoFactor(request, user);
+
+    // The code that confirmed enrollment is already used
+    const first = await startLogin(request, user);
+    const replay = await first.authAPI.loginVerify({
+      challenge_token: first.challenge,
+      code: generateTOTP(secret, step),
+    });
+    expect(replay.status).toBe(401);
+
+    // The next time step is within the allowed clock skew and has not been used
+    const nextCode = generateTOTP(secret, step + 1);
+    const verified = await first.authAPI.loginVerify({
+      challenge_token: first.challenge,
+      code: nextCode,
+    });
+    expect(verified.status).toBe(200);
+    expect(verified.data!.access_token).toBeTruthy();
+
+    // Once accepted, the same code is rejected on a new login
+    const second = await startLogin(request, user);
+    const secondReplay = await second.authAPI.loginVerify({
+      challenge_token: second.challenge,
+      code: nextCode,
+    });
+    expect(secondReplay.status).toBe(401);
+  });
+
+  test("a wrong code is rejected", async ({ createUser, request }) => {
+    const user = await createUser("e2e-2fa-wrong");
+    await enableTwoFactor(request, user);
+
+    const { authAPI, challenge } = await startLogin(request, user);
+    const res = await authAPI.loginVerify({
+      challenge_token: challenge,
+      code: "000000",
+    });
+    expect(res.status).toBe(401);
+    expect(res.data).toBeUndefined();
+  });
+
+  test("a recovery code can only be used once", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-2fa-recovery");
+    const { recoveryCodes } = await enableTwoFactor(request, user);
+
+    const first = await startLogin(request, user);
+    const verified = await first.authAPI.loginVerify({
+      challenge_token: first.challenge,
+      recovery_code: recoveryCodes[0],
+    });
+    expect(verified.status).toBe(200);
+
+    const second = await startLogin(request, user);
+    const replay = await second.authAPI.loginVerify({
+      challenge_token: second.challenge,
+      recovery_code: recoveryCodes[0],
+    });
+    expect(replay.status).toBe(401);
+  });
+
+  test("a challenge token can only be exchanged once", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-2fa-challenge");
+    const { recoveryCodes } = await enableTwoFactor(request, user);
+
+    const { authAPI, challenge } = await startLogin(request, user);
+    const first = await authAPI.loginVerify({
+      challenge_token: challenge,
+      recovery_code: recoveryCodes[0],
+    });
+    expect(first.status).toBe(200);
+
+    const second = await authAPI.loginVerify({
+      challenge_token: challenge,
+      recovery_code: recoveryCodes[1],
+    });
+    expect(second.status).toBe(401);
+  });
+
+  test("wrong codes on fresh challenges count towards the login lockout", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-2fa-throttle");
+    const { secret, step } = await enableTwoFactor(request, user);
+
+    // A correct password alone does not reset the failure count
+    for (let i = 0; i < 4; i++) {
+      const { authAPI, challenge } = await startLogin(request, user);
+      const res = await authAPI.loginVerify({
+        challenge_token: challenge,
+        code: "000000",
+      });
+      expect(res.status).toBe(401);
+    }
+
+    const { authAPI, challenge } = await startLogin(request, user);
+    const locked = await authAPI.loginVerify({
+      challenge_token: challenge,
+      code: "000000",
+    });
+    expect(locked.status).toBe(429);
+    expect(locked.error).toHaveProperty("lockedUntil");
+
+    // Neither a new login nor a right code on an open challenge gets through while locked
+    const login = await authAPI.login(user.username, user.password);
+    expect(login.status).toBe(429);
+
+    const verified = await authAPI.loginVerify({
+      challenge_token: challenge,
+      code: generateTOTP(secret, step + 1),
+    });
+    expect(verified.status).toBe(429);
+  });
+});
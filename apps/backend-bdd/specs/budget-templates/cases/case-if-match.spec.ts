@@ -0,0 +1,48 @@
+import { test, expect } from "@fixtures/index";
+
+test.describe("Budget Templates - If-Match Cases", () => {
+  test("PATCH /budgets/:id - a stale If-Match returns 412", async ({
+    budgetTemplateAPI,
+    accountAPI,
+  }) => {
+    const account = await accountAPI.createAccount({
+      name: `bt-ifm-account-${Date.now()}`,
+      note: "test account",
+      type: "expense",
+    });
+    const created = await budgetTemplateAPI.createBudgetTemplate({
+      accountId: account.data!.id as number,
+      amountLimit: 100000,
+      recurrence: "monthly",
+      startDate: new Date().toISOString(),
+      name: `bt-ifm-${Date.now()}`,
+      note: "original",
+      active: true,
+    });
+    expect(created.status).toBe(200);
+    const id = created.data!.id as number;
+
+    const read = await budgetTemplateAPI.getBudgetTemplate(id);
+    const staleETag = read.data!.etag!;
+    expect(staleETag).toBeTruthy();
+
+    const first = await budgetTemplateAPI.updateBudgetTemplate(
+      id,
+      { note: "first writer" },
+      staleETag
+    );
+    expect(first.status).toBe(200);
+
+    const second = await budgetTemplateAPI.updateBudgetTemplate(
+      id,
+      { note: "second writer" },
+      staleETag
+    );
+    expect(second.status).toBe(412);
+    expect(second.error).toHaveProperty("current.note", "first writer");
+
+    // Cleanup - deactivate instead of delete
+    await budgetTemplateAPI.updateBudgetTemplate(id, { active: false });
+    await accountAPI.deleteAccount(account.data!.id as number);
+  });
+});
@@ -0,0 +1,155 @@
+import { test, expect } from "@fixtures/index";
+import { PayeeAPIClient } from "@fixtures/payee-client";
+
+test.describe("Payees - Merge Cases", () => {
+  test("POST /payees/:id/merge - moves transactions and templates and keeps the names as aliases", async ({
+    payeeAPI,
+    accountAPI,
+    categoryAPI,
+    transactionAPI,
+    transactionTemplateAPI,
+  }) => {
+    const suffix = Date.now();
+    const acc = await accountAPI.createAccount({
+      name: `merge-acc-${suffix}`,
+      note: "a",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `merge-cat-${suffix}`,
+      note: "c",
+      type: "expense",
+    });
+    const accountId = acc.data!.id as number;
+    const categoryId = cat.data!.id as number;
+
+    const target = await payeeAPI.createPayee({
+      name: `Corner Coffee ${suffix}`,
+      aliases: ["CORNER COFFEE"],
+    });
+    const first = await payeeAPI.createPayee({
+      name: `Corner Cofee ${suffix}`,
+      aliases: ["SQ *CORNER", "corner coffee"],
+    });
+    const second = await payeeAPI.createPayee({
+      name: `CC Downtown ${suffix}`,
+    });
+    const targetId = target.data!.id as number;
+    const firstId = first.data!.id as number;
+    const secondId = second.data!.id as number;
+
+    const createExpense = async (payeeId: number) => {
+      const res = await transactionAPI.createTransaction({
+        accountId,
+        amount: 450,
+        categoryId,
+        date: new Date().toISOString(),
+        type: "expense",
+        payeeId,
+      });
+      expect(res.status).toBe(200);
+      return res.data!.id as number;
+    };
+    const onTarget = await createExpense(targetId);
+    const onFirst = await createExpense(firstId);
+    const onSecond = await createExpense(secondId);
+
+    const template = await transactionTemplateAPI.createTransactionTemplate({
+      name: `merge-template-${suffix}`,
+      amount: 450,
+      type: "expense",
+      accountId,
+      categoryId,
+      payeeId: secondId,
+      startDate: new Date(Date.now() + 24 * 3600 * 1000).toISOString(),
+      recurrence: "weekly",
+    });
+    expect(template.status).toBe(200);
+
+    // Repeated sources are merged once
+    const merged = await payeeAPI.mergePayees(targetId, {
+      sourceIds: [firstId, secondId, firstId],
+    });
+    expect(merged.status).toBe(200);
+    expect(merged.data!.mergedCount).toBe(2);
+    expect(merged.data!.transactionsMoved).toBe(2);
+    expect(merged.data!.templatesMoved).toBe(1);
+    expect(merged.data!.payee.id).toBe(targetId);
+    expect(merged.data!.payee.name).toBe(`Corner Coffee ${suffix}`);
+    // Aliases repeating the name or one another, ignoring case, are dropped
+    expect(merged.data!.payee.aliases).toEqual([
+      "CORNER COFFEE",
+      `Corner Cofee ${suffix}`,
+      "SQ *CORNER",
+      `CC Downtown ${suffix}`,
+    ]);
+
+    const firstGone = await payeeAPI.getPayee(firstId);
+    expect(firstGone.status).toBe(404);
+    const secondGone = await payeeAPI.getPayee(secondId);
+    expect(secondGone.status).toBe(404);
+
+    const byTarget = await transactionAPI.getTransactions({
+      payeeId: [targetId],
+    });
+    expect(byTarget.data!.items!.map((t) => t.id).sort()).toEqual(
+      [onTarget, onFirst, onSecond].sort()
+    );
+
+    const movedTemplate = await transactionTemplateAPI.getTransactionTemplate(
+      template.data!.id as number
+    );
+    expect(movedTemplate.data!.payee!.id).toBe(targetId);
+
+    // The old names now find the kept payee
+    const byOldName = await payeeAPI.getPayees({
+      name: `CC Downtown ${suffix}`,
+    });
+    expect(byOldName.data!.items!.map((p) => p.id)).toEqual([targetId]);
+  });
+
+  test("POST /payees/:id/merge - rejects invalid sources and changes nothing", async ({
+    payeeAPI,
+    createUser,
+    request,
+  }) => {
+    const suffix = Date.now();
+    const target = await payeeAPI.createPayee({ name: `Target ${suffix}` });
+    const source = await payeeAPI.createPayee({ name: `Source ${suffix}` });
+    const targetId = target.data!.id as number;
+    const sourceId = source.data!.id as number;
+
+    const self = await payeeAPI.mergePayees(targetId, {
+      sourceIds: [sourceId, targetId],
+    });
+    expect(self.status).toBe(400);
+
+    const unknown = await payeeAPI.mergePayees(targetId, {
+      sourceIds: [sourceId, 999999999],
+    });
+    expect(unknown.status).toBe(400);
+
+    const other = await createUser("e2e-payees-merge-other");
+    const theirs = await new PayeeAPIClient(request, other.context).createPayee(
+      { name: `Theirs ${suffix}` }
+    );
+    const foreign = await payeeAPI.mergePayees(targetId, {
+      sourceIds: [theirs.data!.id as number],
+    });
+    expect(foreign.status).toBe(400);
+
+    const empty = await payeeAPI.mergePayees(targetId, { sourceIds: [] });
+    expect(empty.status).toBe(422);
+
+    const missingTarget = await payeeAPI.mergePayees(999999999, {
+      sourceIds: [sourceId],
+    });
+    expect(missingTarget.status).toBe(404);
+
+    // The source listed before the failing one was not merged
+    const stillThere = await payeeAPI.getPayee(sourceId);
+    expect(stillThere.status).toBe(200);
+    const unchanged = await payeeAPI.getPayee(targetId);
+    expect(unchanged.data!.aliases).toEqual([]);
+  });
+});
@@ -0,0 +1,205 @@
+import { test, expect, type TestUser } from "@fixtures/index";
+import { AccountAPIClient } from "@fixtures/account-client";
+import { CategoryAPIClient } from "@fixtures/category-client";
+import { PayeeAPIClient } from "@fixtures/payee-client";
+import { SummaryAPIClient } from "@fixtures/summary-client";
+import { TransactionAPIClient } from "@fixtures/transaction-client";
+import type { APIRequestContext } from "@playwright/test";
+
+/**
+ * Clients for a fresh user, so filters and summaries only see its own payees
+ */
+async function setup(request: APIRequestContext, user: TestUser) {
+  const accountAPI = new AccountAPIClient(request, user.context);
+  const categoryAPI = new CategoryAPIClient(request, user.context);
+  const acc = await accountAPI.createAccount({
+    name: `payee-acc-${Date.now()}`,
+    note: "a",
+    type: "expense",
+  });
+  const expenseCat = await categoryAPI.createCategory({
+    name: `payee-exp-${Date.now()}`,
+    note: "c",
+    type: "expense",
+  });
+  const incomeCat = await categoryAPI.createCategory({
+    name: `payee-inc-${Date.now()}`,
+    note: "c",
+    type: "income",
+  });
+
+  return {
+    payeeAPI: new PayeeAPIClient(request, user.context),
+    summaryAPI: new SummaryAPIClient(request, user.context),
+    transactionAPI: new TransactionAPIClient(request, user.context),
+    accountId: acc.data!.id as number,
+    expenseCategoryId: expenseCat.data!.id as number,
+    incomeCategoryId: incomeCat.data!.id as number,
+  };
+}
+
+test.describe("Payees - Transaction Cases", () => {
+  test("GET /transactions - filters by payeeId", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-payees-filter");
+    const { payeeAPI, transactionAPI, accountId, expenseCategoryId } =
+      await setup(request, user);
+
+    const coffee = await payeeAPI.createPayee({ name: "Corner Coffee" });
+    const grocer = await payeeAPI.createPayee({ name: "Grocer" });
+    const coffeeId = coffee.data!.id as number;
+    const grocerId = grocer.data!.id as number;
+
+    const createExpense = async (payeeId?: number) => {
+      const res = await transactionAPI.createTransaction({
+        accountId,
+        amount: 500,
+        categoryId: expenseCategoryId,
+        date: new Date().toISOString(),
+        type: "expense",
+        payeeId,
+      });
+      expect(res.status).toBe(200);
+      return res.data!;
+    };
+
+    const latte = await createExpense(coffeeId);
+    const groceries = await createExpense(grocerId);
+    const unnamed = await createExpense();
+    expect(latte.payee).toEqual({ id: coffeeId, name: "Corner Coffee" });
+    expect(unnamed.payee).toBeUndefined();
+
+    const byCoffee = await transactionAPI.getTransactions({
+      payeeId: [coffeeId],
+    });
+    expect(byCoffee.status).toBe(200);
+    expect(byCoffee.data!.items!.map((t) => t.id)).toEqual([latte.id]);
+    expect(byCoffee.data!.items![0].payee!.name).toBe("Corner Coffee");
+
+    const byEither = await transactionAPI.getTransactions({
+      payeeId: [coffeeId, grocerId],
+    });
+    expect(byEither.data!.items!.map((t) => t.id).sort()).toEqual(
+      [latte.id, groceries.id].sort()
+    );
+
+    // Setting the payee later, and 0 to take it off again
+    const named = await transactionAPI.updateTransaction(unnamed.id as number, {
+      payeeId: coffeeId,
+    });
+    expect(named.status).toBe(200);
+    expect(named.data!.payee!.id).toBe(coffeeId);
+
+    const cleared = await transactionAPI.updateTransaction(latte.id as number, {
+      payeeId: 0,
+    });
+    expect(cleared.status).toBe(200);
+    expect(cleared.data!.payee).toBeUndefined();
+
+    const afterUpdates = await transactionAPI.getTransactions({
+      payeeId: [coffeeId],
+    });
+    expect(afterUpdates.data!.items!.map((t) => t.id)).toEqual([unnamed.id]);
+  });
+
+  test("POST /transactions - rejects a payee of another user", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-payees-owner");
+    const { transactionAPI, accountId, expenseCategoryId } = await setup(
+      request,
+      user
+    );
+    const other = await createUser("e2e-payees-stranger");
+    const theirs = await new PayeeAPIClient(request, other.context).createPayee(
+      { name: "Their Shop" }
+    );
+
+    const res = await transactionAPI.createTransaction({
+      accountId,
+      amount: 500,
+      categoryId: expenseCategoryId,
+      date: new Date().toISOString(),
+      type: "expense",
+      payeeId: theirs.data!.id as number,
+    });
+    expect(res.status).toBe(400);
+  });
+
+  test("GET /summary/payees - totals the transactions of each payee", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-payees-summary");
+    const {
+      payeeAPI,
+      summaryAPI,
+      transactionAPI,
+      accountId,
+      expenseCategoryId,
+      incomeCategoryId,
+    } = await setup(request, user);
+
+    const coffee = await payeeAPI.createPayee({ name: "Corner Coffee" });
+    const landlord = await payeeAPI.createPayee({ name: "Landlord" });
+    const unused = await payeeAPI.createPayee({ name: "Unused" });
+    const coffeeId = coffee.data!.id as number;
+    const landlordId = landlord.data!.id as number;
+
+    const create = async (
+      type: "expense" | "income",
+      amount: number,
+      payeeId?: number
+    ) => {
+      const res = await transactionAPI.createTransaction({
+        accountId,
+        amount,
+        categoryId: type === "expense" ? expenseCategoryId : incomeCategoryId,
+        date: new Date().toISOString(),
+        type,
+        payeeId,
+      });
+      expect(res.status).toBe(200);
+    };
+
+    await create("expense", 1000, coffeeId);
+    await create("expense", 500, coffeeId);
+    await create("income", 200, coffeeId);
+    await create("expense", 3000, landlordId);
+    await create("expense", 9999);
+
+    const range = {
+      startDate: new Date(Date.now() - 3600 * 1000).toISOString(),
+      endDate: new Date(Date.now() + 3600 * 1000).toISOString(),
+    };
+
+    const res = await summaryAPI.getPayeeSummary(range);
+    expect(res.status).toBe(200);
+
+    // Highest spend first; payees without transactions are left out
+    expect(res.data!.data!.map((p) => p.id)).toEqual([landlordId, coffeeId]);
+    expect(res.data!.data!.map((p) => p.id)).not.toContain(unused.data!.id);
+
+    const coffeeRow = res.data!.data!.find((p) => p.id === coffeeId)!;
+    expect(coffeeRow.name).toBe("Corner Coffee");
+    expect(coffeeRow.totalCount).toBe(3);
+    expect(coffeeRow.expenseAmount).toBe(1500);
+    expect(coffeeRow.incomeAmount).toBe(200);
+    expect(coffeeRow.net).toBe(-1300);
+
+    // A range before the transactions is empty
+    const earlier = await summaryAPI.getPayeeSummary({
+      startDate: new Date(Date.now() - 48 * 3600 * 1000).toISOString(),
+      endDate: new Date(Date.now() - 24 * 3600 * 1000).toISOString(),
+    });
+    expect(earlier.data!.data).toEqual([]);
+
+    // Deleted payees drop out of the summary
+    await payeeAPI.deletePayee(landlordId);
+    const afterDelete = await summaryAPI.getPayeeSummary(range);
+    expect(afterDelete.data!.data!.map((p) => p.id)).toEqual([coffeeId]);
+  });
+});
@@ -0,0 +1,132 @@
+import { test, expect } from "@fixtures/index";
+import { PayeeAPIClient } from "@fixtures/payee-client";
+
+test.describe("Payees - Common", () => {
+  test("POST /payees - creates, updates, deletes and restores a payee", async ({
+    payeeAPI,
+  }) => {
+    const name = `Corner Coffee ${Date.now()}`;
+
+    // Aliases are trimmed, and blanks, repeats and the name itself dropped
+    const created = await payeeAPI.createPayee({
+      name,
+      aliases: [
+        " CORNER COFFEE #12 ",
+        "corner coffee #12",
+        "",
+        name.toUpperCase(),
+      ],
+    });
+    expect(created.status).toBe(200);
+    expect(created.data!.name).toBe(name);
+    expect(created.data!.aliases).toEqual(["CORNER COFFEE #12"]);
+    const id = created.data!.id as number;
+
+    const fetched = await payeeAPI.getPayee(id);
+    expect(fetched.status).toBe(200);
+    expect(fetched.data!.aliases).toEqual(["CORNER COFFEE #12"]);
+
+    // Leaving out the aliases keeps them
+    const renamed = await payeeAPI.updatePayee(id, {
+      name: `${name} Downtown`,
+    });
+    expect(renamed.status).toBe(200);
+    expect(renamed.data!.name).toBe(`${name} Downtown`);
+    expect(renamed.data!.aliases).toEqual(["CORNER COFFEE #12"]);
+
+    // Giving them replaces the whole list
+    const realiased = await payeeAPI.updatePayee(id, {
+      aliases: ["SQ *CORNER COFFEE", "Corner Cafe"],
+    });
+    expect(realiased.status).toBe(200);
+    expect(realiased.data!.aliases).toEqual([
+      "SQ *CORNER COFFEE",
+      "Corner Cafe",
+    ]);
+
+    const del = await payeeAPI.deletePayee(id);
+    expect([200, 204]).toContain(del.status);
+
+    const gone = await payeeAPI.getPayee(id);
+    expect(gone.status).toBe(404);
+    const updateGone = await payeeAPI.updatePayee(id, { name: "again" });
+    expect(updateGone.status).toBe(404);
+
+    const restored = await payeeAPI.restorePayee(id);
+    expect(restored.status).toBe(200);
+    expect(restored.data!.name).toBe(`${name} Downtown`);
+    expect(restored.data!.aliases).toEqual([
+      "SQ *CORNER COFFEE",
+      "Corner Cafe",
+    ]);
+
+    const restoreAgain = await payeeAPI.restorePayee(id);
+    expect(restoreAgain.status).toBe(404);
+  });
+
+  test("GET /payees - searches by name or alias", async ({ payeeAPI }) => {
+    const suffix = Date.now();
+    const bakery = await payeeAPI.createPayee({
+      name: `Bakery ${suffix}`,
+      aliases: [`BKRY-${suffix} POS`],
+    });
+    const grocer = await payeeAPI.createPayee({ name: `Grocer ${suffix}` });
+
+    const byName = await payeeAPI.getPayees({ name: `bakery ${suffix}` });
+    expect(byName.status).toBe(200);
+    expect(byName.data!.items!.map((p) => p.id)).toEqual([bakery.data!.id]);
+
+    const byAlias = await payeeAPI.getPayees({ name: `bkry-${suffix}` });
+    expect(byAlias.data!.items!.map((p) => p.id)).toEqual([bakery.data!.id]);
+
+    const both = await payeeAPI.getPayees({ name: `${suffix}` });
+    expect(both.data!.totalCount).toBe(2);
+    expect(both.data!.items!.map((p) => p.id)).toEqual([
+      bakery.data!.id,
+      grocer.data!.id,
+    ]);
+
+    // Deleted payees are left out
+    await payeeAPI.deletePayee(grocer.data!.id as number);
+    const remaining = await payeeAPI.getPayees({ name: `${suffix}` });
+    expect(remaining.data!.items!.map((p) => p.id)).toEqual([bakery.data!.id]);
+  });
+
+  test("POST /payees - rejects invalid payloads", async ({ payeeAPI }) => {
+    const empty = await payeeAPI.createPayee({ name: "" });
+    expect(empty.status).toBe(422);
+
+    const tooLong = await payeeAPI.createPayee({ name: "p".repeat(101) });
+    expect(tooLong.status).toBe(422);
+
+    const created = await payeeAPI.createPayee({ name: `Valid ${Date.now()}` });
+    const blankName = await payeeAPI.updatePayee(created.data!.id as number, {
+      name: "",
+    });
+    expect(blankName.status).toBe(422);
+  });
+
+  test("GET /payees/:id - payees of other users are not found", async ({
+    payeeAPI,
+    createUser,
+    request,
+  }) => {
+    const created = await payeeAPI.createPayee({ name: `Mine ${Date.now()}` });
+    const id = created.data!.id as number;
+
+    const other = await createUser("e2e-payees-other");
+    const otherAPI = new PayeeAPIClient(request, other.context);
+
+    const get = await otherAPI.getPayee(id);
+    expect(get.status).toBe(404);
+
+    const update = await otherAPI.updatePayee(id, { name: "theirs" });
+    expect(update.status).toBe(404);
+
+    const del = await otherAPI.deletePayee(id);
+    expect(del.status).toBe(404);
+
+    const list = await otherAPI.getPayees();
+    expect(list.data!.items!.map((p) => p.id)).not.toContain(id);
+  });
+});
@@ -0,0 +1,100 @@
+import { test, expect } from "@fixtures/index";
+
+test.describe("Reimbursements - Summary Exclusion Cases", () => {
+  test("GET /summary/categories - excludeReimbursed leaves out the part paid back", async ({
+    accountAPI,
+    categoryAPI,
+    transactionAPI,
+    reimbursementAPI,
+    summaryAPI,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `reimb-sum-acc-${Date.now()}`,
+      note: "reimbursements",
+      type: "expense",
+    });
+    const expenseCat = await categoryAPI.createCategory({
+      name: `reimb-sum-exp-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const incomeCat = await categoryAPI.createCategory({
+      name: `reimb-sum-inc-${Date.now()}`,
+      note: "c",
+      type: "income",
+    });
+    const accountId = acc.data!.id as number;
+
+    const dinner = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: expenseCat.data!.id as number,
+      amount: 3000,
+      date: new Date().toISOString(),
+      type: "expense",
+    });
+    // Only part of this income pays the dinner back, the rest is income of its own
+    const payback = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: incomeCat.data!.id as number,
+      amount: 1500,
+      date: new Date().toISOString(),
+      type: "income",
+    });
+
+    const reimb = await reimbursementAPI.createReimbursement({
+      transactionId: dinner.data!.id as number,
+      counterparty: "Friends",
+      expectedAmount: 1000,
+    });
+    const match = await reimbursementAPI.matchReimbursement(
+      reimb.data!.id as number,
+      { transactionId: payback.data!.id as number }
+    );
+    expect(match.status).toBe(200);
+    expect(match.data!.status).toBe("settled");
+    expect(match.data!.matchedAmount).toBe(1000);
+
+    const range = {
+      startDate: new Date(Date.now() - 3600 * 1000).toISOString(),
+      endDate: new Date(Date.now() + 3600 * 1000).toISOString(),
+    };
+
+    const full = await summaryAPI.getCategorySummary(range);
+    expect(full.status).toBe(200);
+    const fullItems = full.data!.data ?? [];
+    expect(
+      Number(fullItems.find((c) => c.id === expenseCat.data!.id)!.expenseAmount)
+    ).toBe(3000);
+    expect(
+      Number(fullItems.find((c) => c.id === incomeCat.data!.id)!.incomeAmount)
+    ).toBe(1500);
+
+    const excluded = await summaryAPI.getCategorySummary({
+      ...range,
+      excludeReimbursed: true,
+    });
+    expect(excluded.status).toBe(200);
+    const excludedItems = excluded.data!.data ?? [];
+    expect(
+      Number(
+        excludedItems.find((c) => c.id === expenseCat.data!.id)!.expenseAmount
+      )
+    ).toBe(2000);
+    expect(
+      Number(
+        excludedItems.find((c) => c.id === incomeCat.data!.id)!.incomeAmount
+      )
+    ).toBe(500);
+
+    // Account balances always hold the full amounts
+    const accAfter = await accountAPI.getAccount(accountId);
+    expect(accAfter.data!.amount).toBe(-3000 + 1500);
+
+    await reimbursementAPI.deleteReimbursement(reimb.data!.id as number);
+    await transactionAPI.deleteTransaction(dinner.data!.id as number);
+    await transactionAPI.deleteTransaction(payback.data!.id as number);
+    await accountAPI.deleteAccount(accountId);
+    await categoryAPI.deleteCategory(expenseCat.data!.id as number);
+    await categoryAPI.deleteCategory(incomeCat.data!.id as number);
+  });
+});
@@ -0,0 +1,170 @@
+import { test, expect } from "@fixtures/index";
+
+test.describe("Reimbursements - Common", () => {
+  test("POST /reimbursements - matches move it from open to partial to settled", async ({
+    accountAPI,
+    categoryAPI,
+    transactionAPI,
+    reimbursementAPI,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `reimb-acc-${Date.now()}`,
+      note: "reimbursements",
+      type: "expense",
+    });
+    const expenseCat = await categoryAPI.createCategory({
+      name: `reimb-exp-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const incomeCat = await categoryAPI.createCategory({
+      name: `reimb-inc-${Date.now()}`,
+      note: "c",
+      type: "income",
+    });
+    const accountId = acc.data!.id as number;
+
+    const dinner = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: expenseCat.data!.id as number,
+      amount: 3000,
+      date: new Date().toISOString(),
+      type: "expense",
+      note: "Dinner for three",
+    });
+    const firstPayback = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: incomeCat.data!.id as number,
+      amount: 1000,
+      date: new Date().toISOString(),
+      type: "income",
+    });
+    const secondPayback = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: incomeCat.data!.id as number,
+      amount: 1500,
+      date: new Date().toISOString(),
+      type: "income",
+    });
+
+    const created = await reimbursementAPI.createReimbursement({
+      transactionId: dinner.data!.id as number,
+      counterparty: "Friends",
+      expectedAmount: 2000,
+    });
+    expect(created.status).toBe(200);
+    expect(created.data!.status).toBe("open");
+    expect(created.data!.outstandingAmount).toBe(2000);
+    const reimbId = created.data!.id as number;
+
+    const partial = await reimbursementAPI.matchReimbursement(reimbId, {
+      transactionId: firstPayback.data!.id as number,
+    });
+    expect(partial.status).toBe(200);
+    expect(partial.data!.status).toBe("partial");
+    expect(partial.data!.matchedAmount).toBe(1000);
+    expect(partial.data!.outstandingAmount).toBe(1000);
+
+    // Without an amount only what is still owed is used
+    const settled = await reimbursementAPI.matchReimbursement(reimbId, {
+      transactionId: secondPayback.data!.id as number,
+    });
+    expect(settled.status).toBe(200);
+    expect(settled.data!.status).toBe("settled");
+    expect(settled.data!.outstandingAmount).toBe(0);
+    expect(settled.data!.matches ?? []).toHaveLength(2);
+
+    const again = await reimbursementAPI.matchReimbursement(reimbId, {
+      transactionId: secondPayback.data!.id as number,
+    });
+    expect(again.status).toBe(409);
+
+    const matchId = settled.data!.matches![0].id as number;
+    const unmatched = await reimbursementAPI.unmatchReimbursement(
+      reimbId,
+      matchId
+    );
+    expect(unmatched.status).toBe(200);
+    expect(unmatched.data!.status).toBe("partial");
+
+    await reimbursementAPI.deleteReimbursement(reimbId);
+    await transactionAPI.deleteTransaction(dinner.data!.id as number);
+    await transactionAPI.deleteTransaction(firstPayback.data!.id as number);
+    await transactionAPI.deleteTransaction(secondPayback.data!.id as number);
+    await accountAPI.deleteAccount(accountId);
+    await categoryAPI.deleteCategory(expenseCat.data!.id as number);
+    await categoryAPI.deleteCategory(incomeCat.data!.id as number);
+  });
+
+  test("POST /reimbursements - only expenses, up to their amount", async ({
+    accountAPI,
+    categoryAPI,
+    transactionAPI,
+    reimbursementAPI,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `reimb-bad-acc-${Date.now()}`,
+      note: "reimbursements",
+      type: "expense",
+    });
+    const expenseCat = await categoryAPI.createCategory({
+      name: `reimb-bad-exp-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const incomeCat = await categoryAPI.createCategory({
+      name: `reimb-bad-inc-${Date.now()}`,
+      note: "c",
+      type: "income",
+    });
+    const accountId = acc.data!.id as number;
+
+    const expense = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: expenseCat.data!.id as number,
+      amount: 500,
+      date: new Date().toISOString(),
+      type: "expense",
+    });
+    const income = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: incomeCat.data!.id as number,
+      amount: 500,
+      date: new Date().toISOString(),
+      type: "income",
+    });
+
+    const fromIncome = await reimbursementAPI.createReimbursement({
+      transactionId: income.data!.id as number,
+      counterparty: "Nobody",
+    });
+    expect(fromIncome.status).toBe(400);
+
+    const tooMuch = await reimbursementAPI.createReimbursement({
+      transactionId: expense.data!.id as number,
+      counterparty: "Friends",
+      expectedAmount: 600,
+    });
+    expect(tooMuch.status).toBe(400);
+
+    const created = await reimbursementAPI.createReimbursement({
+      transactionId: expense.data!.id as number,
+      counterparty: "Friends",
+    });
+    expect(created.status).toBe(200);
+    expect(created.data!.expectedAmount).toBe(500);
+    const reimbId = created.data!.id as number;
+
+    const byExpense = await reimbursementAPI.matchReimbursement(reimbId, {
+      transactionId: expense.data!.id as number,
+    });
+    expect(byExpense.status).toBe(400);
+
+    await reimbursementAPI.deleteReimbursement(reimbId);
+    await transactionAPI.deleteTransaction(expense.data!.id as number);
+    await transactionAPI.deleteTransaction(income.data!.id as number);
+    await accountAPI.deleteAccount(accountId);
+    await categoryAPI.deleteCategory(expenseCat.data!.id as number);
+    await categoryAPI.deleteCategory(incomeCat.data!.id as number);
+  });
+});
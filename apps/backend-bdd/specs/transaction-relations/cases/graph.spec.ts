@@ -0,0 +1,99 @@
+import { test, expect } from "@fixtures/index";
+
+test.describe("Transaction Relations - Graph", () => {
+  test("GET /transactions/:id/graph - follows relations both ways and totals the amounts", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+  }) => {
+    const account = await accountAPI.createAccount({
+      name: `e2e-graph-${Date.now()}`,
+      note: "test account",
+      type: "expense",
+    });
+    const accountId = account.data!.id as number;
+
+    const expenseCategory = await categoryAPI.createCategory({
+      name: `e2e-graph-expense-${Date.now()}`,
+      type: "expense",
+      note: "test category",
+    });
+    const incomeCategory = await categoryAPI.createCategory({
+      name: `e2e-graph-income-${Date.now()}`,
+      type: "income",
+      note: "test category",
+    });
+    const expenseCategoryId = expenseCategory.data!.id as number;
+    const incomeCategoryId = incomeCategory.data!.id as number;
+
+    const purchase = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: expenseCategoryId,
+      amount: 5000,
+      note: "purchase",
+      type: "expense",
+      date: new Date().toISOString(),
+    });
+    const refund = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: incomeCategoryId,
+      amount: 2000,
+      note: "partial refund",
+      type: "income",
+      date: new Date().toISOString(),
+    });
+    const fee = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: expenseCategoryId,
+      amount: 300,
+      note: "card fee",
+      type: "expense",
+      date: new Date().toISOString(),
+    });
+    const unrelated = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: expenseCategoryId,
+      amount: 700,
+      note: "unrelated",
+      type: "expense",
+      date: new Date().toISOString(),
+    });
+    const purchaseId = purchase.data!.id as number;
+    const refundId = refund.data!.id as number;
+    const feeId = fee.data!.id as number;
+    const unrelatedId = unrelated.data!.id as number;
+
+    await transactionAPI.createTransactionRelation(
+      refundId,
+      purchaseId,
+      "refund_of"
+    );
+    await transactionAPI.createTransactionRelation(feeId, purchaseId, "fee_of");
+
+    // Starting from the refund reaches the fee through the purchase it points to
+    const res = await transactionAPI.getTransactionGraph(refundId);
+    expect(res.status).toBe(200);
+    expect(res.data!.transactionId).toBe(refundId);
+    expect((res.data!.nodes ?? []).map((n) => n.id).sort((a, b) => a - b)).toEqual(
+      [purchaseId, refundId, feeId].sort((a, b) => a - b)
+    );
+    expect(res.data!.edges ?? []).toHaveLength(2);
+    expect(res.data!.incomeAmount).toBe(2000);
+    expect(res.data!.expenseAmount).toBe(5300);
+    expect(res.data!.net).toBe(-3300);
+    expect(res.data!.truncated).toBe(false);
+
+    const alone = await transactionAPI.getTransactionGraph(unrelatedId);
+    expect(alone.status).toBe(200);
+    expect(alone.data!.nodes ?? []).toHaveLength(1);
+    expect(alone.data!.edges ?? []).toHaveLength(0);
+
+    // Cleanup
+    for (const id of [purchaseId, refundId, feeId, unrelatedId]) {
+      await transactionAPI.deleteTransaction(id);
+    }
+    await accountAPI.deleteAccount(accountId);
+    await categoryAPI.deleteCategory(expenseCategoryId);
+    await categoryAPI.deleteCategory(incomeCategoryId);
+  });
+});
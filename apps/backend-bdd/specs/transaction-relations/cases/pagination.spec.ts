@@ -44,7 +44,7 @@ test.describe("Transaction Relations - Pagination and Filtering", () => {
       await transactionAPI.createTransactionRelation(
         sourceId,
         tx.data!.id as number,
-        `relation-${i + 1}`
+        "related"
       );
     }
 
@@ -154,7 +154,7 @@ test.describe("Transaction Relations - Pagination and Filtering", () => {
       await transactionAPI.createTransactionRelation(
         sourceId,
         tx.data!.id as number,
-        `relation-${i + 1}`
+        "related"
       );
       // Small delay to ensure different creation times
       await new Promise((resolve) => setTimeout(resolve, 100));
@@ -223,7 +223,7 @@ test.describe("Transaction Relations - Pagination and Filtering", () => {
     await transactionAPI.createTransactionRelation(
       sourceId,
       relatedId,
-      "transfer"
+      "split_of"
     );
 
     // Get relations
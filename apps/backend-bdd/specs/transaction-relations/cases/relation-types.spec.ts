@@ -1,283 +1,322 @@
 import { test, expect } from "@fixtures/index";
+import type { AccountAPIClient } from "@fixtures/account-client";
+import type { CategoryAPIClient } from "@fixtures/category-client";
+import type { TransactionAPIClient } from "@fixtures/transaction-client";
 
-test.describe("Transaction Relations - Relation Types", () => {
-  test("POST /transactions/:id/relations - transfer relation", async ({
-    transactionAPI,
-    accountAPI,
-    categoryAPI,
-  }) => {
-    // Create test data
-    const account = await accountAPI.createAccount({
-      name: `e2e-relations-transfer-${Date.now()}`,
-      note: "test account",
-      type: "expense",
-    });
-    const accountId = account.data!.id as number;
-
-    const category = await categoryAPI.createCategory({
-      name: `e2e-relations-transfer-${Date.now()}`,
-      type: "expense",
-      note: "test category",
-    });
-    const categoryId = category.data!.id as number;
+/**
+ * Create an account with an expense and an income category, and a helper recording transactions on it
+ */
+async function createRelationFixtures(
+  prefix: string,
+  accountAPI: AccountAPIClient,
+  categoryAPI: CategoryAPIClient,
+  transactionAPI: TransactionAPIClient
+) {
+  const account = await accountAPI.createAccount({
+    name: `${prefix}-${Date.now()}`,
+    note: "test account",
+    type: "expense",
+  });
+  const expenseCategory = await categoryAPI.createCategory({
+    name: `${prefix}-expense-${Date.now()}`,
+    type: "expense",
+    note: "test category",
+  });
+  const incomeCategory = await categoryAPI.createCategory({
+    name: `${prefix}-income-${Date.now()}`,
+    type: "income",
+    note: "test category",
+  });
+  const accountId = account.data!.id as number;
+  const expenseCategoryId = expenseCategory.data!.id as number;
+  const incomeCategoryId = incomeCategory.data!.id as number;
+  const transactionIds: number[] = [];
 
-    const sourceTx = await transactionAPI.createTransaction({
+  const record = async (type: "expense" | "income", amount: number) => {
+    const tx = await transactionAPI.createTransaction({
       accountId,
-      categoryId,
-      amount: 50000,
-      note: "source transaction",
-      type: "expense",
+      categoryId: type === "expense" ? expenseCategoryId : incomeCategoryId,
+      amount,
+      type,
       date: new Date().toISOString(),
     });
-    const sourceId = sourceTx.data!.id as number;
+    expect(tx.status).toBe(200);
+    transactionIds.push(tx.data!.id as number);
+    return tx.data!.id as number;
+  };
 
-    const relatedTx = await transactionAPI.createTransaction({
-      accountId,
-      categoryId,
-      amount: 25000,
-      note: "related transaction",
-      type: "expense",
-      date: new Date().toISOString(),
-    });
-    const relatedId = relatedTx.data!.id as number;
+  const cleanup = async () => {
+    for (const id of transactionIds) {
+      await transactionAPI.deleteTransaction(id);
+    }
+    await accountAPI.deleteAccount(accountId);
+    await categoryAPI.deleteCategory(expenseCategoryId);
+    await categoryAPI.deleteCategory(incomeCategoryId);
+  };
+
+  return { record, cleanup };
+}
+
+test.describe("Transaction Relations - Relation Types", () => {
+  test("POST /transactions/:id/relations - refund_of links an income to an expense", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+  }) => {
+    const { record, cleanup } = await createRelationFixtures(
+      "e2e-relations-refund",
+      accountAPI,
+      categoryAPI,
+      transactionAPI
+    );
+    const expenseId = await record("expense", 50000);
+    const refundId = await record("income", 20000);
 
-    // Create transfer relation
     const res = await transactionAPI.createTransactionRelation(
-      sourceId,
-      relatedId,
-      "transfer"
+      refundId,
+      expenseId,
+      "refund_of"
     );
     expect(res.status).toBe(200);
-    expect(res.data!.relationType).toBe("transfer");
+    expect(res.data!.relationType).toBe("refund_of");
 
-    // Cleanup
-    await transactionAPI.deleteTransaction(sourceId);
-    await transactionAPI.deleteTransaction(relatedId);
-    await accountAPI.deleteAccount(accountId);
-    await categoryAPI.deleteCategory(categoryId);
+    // The direction is fixed: an expense does not refund an income
+    const reversed = await transactionAPI.createTransactionRelation(
+      expenseId,
+      refundId,
+      "refund_of"
+    );
+    expect(reversed.status).toBe(400);
+
+    await cleanup();
   });
 
-  test("POST /transactions/:id/relations - split relation", async ({
+  test("POST /transactions/:id/relations - fee_of links an expense to any transaction", async ({
     transactionAPI,
     accountAPI,
     categoryAPI,
   }) => {
-    // Create test data
-    const account = await accountAPI.createAccount({
-      name: `e2e-relations-split-${Date.now()}`,
-      type: "expense",
-      note: "test account",
-    });
-    const accountId = account.data!.id as number;
-
-    const category = await categoryAPI.createCategory({
-      name: `e2e-relations-split-${Date.now()}`,
-      type: "expense",
-      note: "test category",
-    });
-    const categoryId = category.data!.id as number;
-
-    const sourceTx = await transactionAPI.createTransaction({
-      accountId,
-      categoryId,
-      amount: 50000,
-      note: "source transaction",
-      type: "expense",
-      date: new Date().toISOString(),
-    });
-    const sourceId = sourceTx.data!.id as number;
-
-    const relatedTx = await transactionAPI.createTransaction({
-      accountId,
-      categoryId,
-      amount: 25000,
-      note: "related transaction",
-      type: "expense",
-      date: new Date().toISOString(),
-    });
-    const relatedId = relatedTx.data!.id as number;
+    const { record, cleanup } = await createRelationFixtures(
+      "e2e-relations-fee",
+      accountAPI,
+      categoryAPI,
+      transactionAPI
+    );
+    const incomeId = await record("income", 50000);
+    const feeId = await record("expense", 300);
+    const otherIncomeId = await record("income", 300);
 
-    // Create split relation
     const res = await transactionAPI.createTransactionRelation(
-      sourceId,
-      relatedId,
-      "split"
+      feeId,
+      incomeId,
+      "fee_of"
     );
     expect(res.status).toBe(200);
-    expect(res.data!.relationType).toBe("split");
+    expect(res.data!.relationType).toBe("fee_of");
 
-    // Cleanup
-    await transactionAPI.deleteTransaction(sourceId);
-    await transactionAPI.deleteTransaction(relatedId);
-    await accountAPI.deleteAccount(accountId);
-    await categoryAPI.deleteCategory(categoryId);
+    const fromIncome = await transactionAPI.createTransactionRelation(
+      otherIncomeId,
+      incomeId,
+      "fee_of"
+    );
+    expect(fromIncome.status).toBe(400);
+
+    await cleanup();
   });
 
-  test("POST /transactions/:id/relations - refund relation", async ({
+  test("POST /transactions/:id/relations - split_of and duplicate_of need the same type", async ({
     transactionAPI,
     accountAPI,
     categoryAPI,
   }) => {
-    // Create test data
-    const account = await accountAPI.createAccount({
-      name: `e2e-relations-refund-${Date.now()}`,
-      type: "expense",
-      note: "test account",
-    });
-    const accountId = account.data!.id as number;
+    const { record, cleanup } = await createRelationFixtures(
+      "e2e-relations-same-type",
+      accountAPI,
+      categoryAPI,
+      transactionAPI
+    );
+    const wholeId = await record("expense", 50000);
+    const partId = await record("expense", 25000);
+    const copyId = await record("expense", 50000);
+    const incomeId = await record("income", 25000);
 
-    const category = await categoryAPI.createCategory({
-      name: `e2e-relations-refund-${Date.now()}`,
-      type: "expense",
-      note: "test category",
-    });
-    const categoryId = category.data!.id as number;
+    const split = await transactionAPI.createTransactionRelation(
+      partId,
+      wholeId,
+      "split_of"
+    );
+    expect(split.status).toBe(200);
+    expect(split.data!.relationType).toBe("split_of");
 
-    const sourceTx = await transactionAPI.createTransaction({
-      accountId,
-      categoryId,
-      amount: 50000,
-      note: "source transaction",
-      type: "expense",
-      date: new Date().toISOString(),
-    });
-    const sourceId = sourceTx.data!.id as number;
+    const duplicate = await transactionAPI.createTransactionRelation(
+      copyId,
+      wholeId,
+      "duplicate_of"
+    );
+    expect(duplicate.status).toBe(200);
+    expect(duplicate.data!.relationType).toBe("duplicate_of");
 
-    const relatedTx = await transactionAPI.createTransaction({
-      accountId,
-      categoryId,
-      amount: 25000,
-      note: "related transaction",
-      type: "expense",
-      date: new Date().toISOString(),
-    });
-    const relatedId = relatedTx.data!.id as number;
+    for (const relationType of ["split_of", "duplicate_of"]) {
+      const mixed = await transactionAPI.createTransactionRelation(
+        incomeId,
+        wholeId,
+        relationType
+      );
+      expect(mixed.status).toBe(400);
+    }
+
+    await cleanup();
+  });
+
+  test("POST /transactions/:id/relations - related links any two transactions once", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+  }) => {
+    const { record, cleanup } = await createRelationFixtures(
+      "e2e-relations-related",
+      accountAPI,
+      categoryAPI,
+      transactionAPI
+    );
+    const expenseId = await record("expense", 50000);
+    const incomeId = await record("income", 10000);
 
-    // Create refund relation
     const res = await transactionAPI.createTransactionRelation(
-      sourceId,
-      relatedId,
-      "refund"
+      expenseId,
+      incomeId,
+      "related"
     );
     expect(res.status).toBe(200);
-    expect(res.data!.relationType).toBe("refund");
+    expect(res.data!.relationType).toBe("related");
 
-    // Cleanup
-    await transactionAPI.deleteTransaction(sourceId);
-    await transactionAPI.deleteTransaction(relatedId);
-    await accountAPI.deleteAccount(accountId);
-    await categoryAPI.deleteCategory(categoryId);
+    // related reads the same both ways, so the reverse link already exists
+    const reverse = await transactionAPI.createTransactionRelation(
+      incomeId,
+      expenseId,
+      "related"
+    );
+    expect(reverse.status).toBe(409);
+
+    await cleanup();
   });
 
-  test("POST /transactions/:id/relations - adjustment relation", async ({
+  test("POST /transactions/:id/relations - unknown relation types are rejected", async ({
     transactionAPI,
     accountAPI,
     categoryAPI,
   }) => {
-    // Create test data
-    const account = await accountAPI.createAccount({
-      name: `e2e-relations-adjustment-${Date.now()}`,
-      type: "expense",
-      note: "test account",
-    });
-    const accountId = account.data!.id as number;
+    const { record, cleanup } = await createRelationFixtures(
+      "e2e-relations-unknown",
+      accountAPI,
+      categoryAPI,
+      transactionAPI
+    );
+    const sourceId = await record("expense", 50000);
+    const relatedId = await record("expense", 25000);
 
-    const category = await categoryAPI.createCategory({
-      name: `e2e-relations-adjustment-${Date.now()}`,
-      type: "expense",
-      note: "test category",
-    });
-    const categoryId = category.data!.id as number;
+    for (const relationType of ["transfer", "refund", "adjustment"]) {
+      const res = await transactionAPI.createTransactionRelation(
+        sourceId,
+        relatedId,
+        relationType
+      );
+      expect(res.status).toBe(422);
+    }
 
-    const sourceTx = await transactionAPI.createTransaction({
-      accountId,
-      categoryId,
-      amount: 50000,
-      note: "source transaction",
-      type: "expense",
-      date: new Date().toISOString(),
-    });
-    const sourceId = sourceTx.data!.id as number;
+    const listRes = await transactionAPI.getTransactionRelations(sourceId);
+    expect(listRes.data!.items ?? []).toHaveLength(0);
 
-    const relatedTx = await transactionAPI.createTransaction({
-      accountId,
-      categoryId,
-      amount: 25000,
-      note: "related transaction",
-      type: "expense",
-      date: new Date().toISOString(),
-    });
-    const relatedId = relatedTx.data!.id as number;
+    await cleanup();
+  });
 
-    // Create adjustment relation
-    const res = await transactionAPI.createTransactionRelation(
-      sourceId,
-      relatedId,
-      "adjustment"
+  test("POST /transactions/:id/relations - single-target types allow one relation per source", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+  }) => {
+    const { record, cleanup } = await createRelationFixtures(
+      "e2e-relations-single",
+      accountAPI,
+      categoryAPI,
+      transactionAPI
     );
-    expect(res.status).toBe(200);
-    expect(res.data!.relationType).toBe("adjustment");
+    const firstExpenseId = await record("expense", 50000);
+    const secondExpenseId = await record("expense", 30000);
+    const refundId = await record("income", 20000);
 
-    // Cleanup
-    await transactionAPI.deleteTransaction(sourceId);
-    await transactionAPI.deleteTransaction(relatedId);
-    await accountAPI.deleteAccount(accountId);
-    await categoryAPI.deleteCategory(categoryId);
+    const first = await transactionAPI.createTransactionRelation(
+      refundId,
+      firstExpenseId,
+      "refund_of"
+    );
+    expect(first.status).toBe(200);
+
+    const second = await transactionAPI.createTransactionRelation(
+      refundId,
+      secondExpenseId,
+      "refund_of"
+    );
+    expect(second.status).toBe(409);
+
+    // Once the first relation is gone the income can refund the other expense
+    await transactionAPI.deleteTransactionRelation(
+      refundId,
+      first.data!.id as number
+    );
+    const replaced = await transactionAPI.createTransactionRelation(
+      refundId,
+      secondExpenseId,
+      "refund_of"
+    );
+    expect(replaced.status).toBe(200);
+
+    await cleanup();
   });
 
-  test("POST /transactions/:id/relations - correction relation", async ({
+  test("POST /transactions/:id/relations - relations that would form a cycle are rejected", async ({
     transactionAPI,
     accountAPI,
     categoryAPI,
   }) => {
-    // Create test data
-    const account = await accountAPI.createAccount({
-      name: `e2e-relations-correction-${Date.now()}`,
-      type: "expense",
-      note: "test account",
-    });
-    const accountId = account.data!.id as number;
-
-    const category = await categoryAPI.createCategory({
-      name: `e2e-relations-correction-${Date.now()}`,
-      type: "expense",
-      note: "test category",
-    });
-    const categoryId = category.data!.id as number;
+    const { record, cleanup } = await createRelationFixtures(
+      "e2e-relations-cycle",
+      accountAPI,
+      categoryAPI,
+      transactionAPI
+    );
+    const aId = await record("expense", 1000);
+    const bId = await record("expense", 1000);
+    const cId = await record("expense", 1000);
 
-    const sourceTx = await transactionAPI.createTransaction({
-      accountId,
-      categoryId,
-      amount: 50000,
-      note: "source transaction",
-      type: "expense",
-      date: new Date().toISOString(),
-    });
-    const sourceId = sourceTx.data!.id as number;
+    const ab = await transactionAPI.createTransactionRelation(
+      aId,
+      bId,
+      "split_of"
+    );
+    expect(ab.status).toBe(200);
+    const bc = await transactionAPI.createTransactionRelation(
+      bId,
+      cId,
+      "duplicate_of"
+    );
+    expect(bc.status).toBe(200);
 
-    const relatedTx = await transactionAPI.createTransaction({
-      accountId,
-      categoryId,
-      amount: 25000,
-      note: "related transaction",
-      type: "expense",
-      date: new Date().toISOString(),
-    });
-    const relatedId = relatedTx.data!.id as number;
+    const ca = await transactionAPI.createTransactionRelation(
+      cId,
+      aId,
+      "split_of"
+    );
+    expect(ca.status).toBe(409);
 
-    // Create correction relation
-    const res = await transactionAPI.createTransactionRelation(
-      sourceId,
-      relatedId,
-      "correction"
+    // related is undirected and never closes a cycle
+    const related = await transactionAPI.createTransactionRelation(
+      cId,
+      aId,
+      "related"
     );
-    expect(res.status).toBe(200);
-    expect(res.data!.relationType).toBe("correction");
+    expect(related.status).toBe(200);
 
-    // Cleanup
-    await transactionAPI.deleteTransaction(sourceId);
-    await transactionAPI.deleteTransaction(relatedId);
-    await accountAPI.deleteAccount(accountId);
-    await categoryAPI.deleteCategory(categoryId);
+    await cleanup();
   });
 });
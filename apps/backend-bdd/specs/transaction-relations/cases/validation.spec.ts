@@ -35,7 +35,7 @@ test.describe("Transaction Relations - Validation Cases", () => {
     const res = await transactionAPI.createTransactionRelation(
       99999, // Invalid source ID
       relatedId,
-      "transfer"
+      "split_of"
     );
     expect(res.status).toBeGreaterThanOrEqual(400);
 
@@ -80,7 +80,7 @@ test.describe("Transaction Relations - Validation Cases", () => {
     const res = await transactionAPI.createTransactionRelation(
       sourceId,
       99999, // Invalid related ID
-      "transfer"
+      "split_of"
     );
     expect(res.status).toBeGreaterThanOrEqual(400);
 
@@ -135,14 +135,14 @@ test.describe("Transaction Relations - Validation Cases", () => {
     await transactionAPI.createTransactionRelation(
       sourceId,
       relatedId,
-      "transfer"
+      "split_of"
     );
 
     // Try to create duplicate relation
     const res = await transactionAPI.createTransactionRelation(
       sourceId,
       relatedId,
-      "transfer"
+      "split_of"
     );
     expect(res.status).toBeGreaterThanOrEqual(400);
 
@@ -188,7 +188,7 @@ test.describe("Transaction Relations - Validation Cases", () => {
     const res = await transactionAPI.createTransactionRelation(
       sourceId,
       sourceId, // Same ID
-      "transfer"
+      "split_of"
     );
     expect(res.status).toBeGreaterThanOrEqual(400);
 
@@ -198,7 +198,7 @@ test.describe("Transaction Relations - Validation Cases", () => {
     await categoryAPI.deleteCategory(categoryId);
   });
 
-  test("POST /transactions/:id/relations - empty relation type returns 400", async ({
+  test("POST /transactions/:id/relations - empty relation type returns 422", async ({
     transactionAPI,
     accountAPI,
     categoryAPI,
@@ -245,8 +245,7 @@ test.describe("Transaction Relations - Validation Cases", () => {
       relatedId,
       "" // Empty relation type
     );
-    expect(res.status).toBe(200);
-    expect(res.data!.relationType).toBe("");
+    expect(res.status).toBe(422);
 
     // Cleanup
     await transactionAPI.deleteTransaction(sourceId);
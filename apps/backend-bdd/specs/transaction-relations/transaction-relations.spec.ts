@@ -51,13 +51,13 @@ test.describe("Transaction Relations - Common CRUD", () => {
     const res = await transactionAPI.createTransactionRelation(
       sourceId,
       relatedId,
-      "transfer"
+      "split_of"
     );
     expect(res.status).toBe(200);
     expect(res.data).toBeDefined();
     expect(res.data!.sourceTransactionId).toBe(sourceId);
     expect(res.data!.relatedTransactionId).toBe(relatedId);
-    expect(res.data!.relationType).toBe("transfer");
+    expect(res.data!.relationType).toBe("split_of");
 
     // Cleanup
     await transactionAPI.deleteTransaction(sourceId);
@@ -110,7 +110,7 @@ test.describe("Transaction Relations - Common CRUD", () => {
     await transactionAPI.createTransactionRelation(
       sourceId,
       relatedId,
-      "transfer"
+      "split_of"
     );
 
     // List relations
@@ -123,7 +123,7 @@ test.describe("Transaction Relations - Common CRUD", () => {
       (r: any) => r.relatedTransactionId === relatedId
     );
     expect(relation).toBeDefined();
-    expect(relation!.relationType).toBe("transfer");
+    expect(relation!.relationType).toBe("split_of");
 
     // Cleanup
     await transactionAPI.deleteTransaction(sourceId);
@@ -176,7 +176,7 @@ test.describe("Transaction Relations - Common CRUD", () => {
     const createRes = await transactionAPI.createTransactionRelation(
       sourceId,
       relatedId,
-      "transfer"
+      "split_of"
     );
     expect(createRes.status).toBe(200);
     const relationId = createRes.data!.id as number;
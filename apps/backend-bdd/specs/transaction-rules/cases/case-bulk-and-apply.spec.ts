@@ -0,0 +1,284 @@
+import { test, expect } from "@fixtures/index";
+import { AccountAPIClient } from "@fixtures/account-client";
+import { CategoryAPIClient } from "@fixtures/category-client";
+import { TagAPIClient } from "@fixtures/tag-client";
+import { TransactionAPIClient } from "@fixtures/transaction-client";
+import { TransactionRuleAPIClient } from "@fixtures/transaction-rule-client";
+
+const day = 24 * 3600 * 1000;
+
+test.describe("Transaction Rules - Bulk and Apply Cases", () => {
+  test("bulk commit fills the fields an update leaves out, destination only on transfers", async ({
+    createUser,
+    request,
+  }) => {
+    // Rules apply to every transaction of their owner, so this runs as a fresh user
+    const user = await createUser("e2e-rules-bulk");
+    const accountAPI = new AccountAPIClient(request, user.context);
+    const categoryAPI = new CategoryAPIClient(request, user.context);
+    const tagAPI = new TagAPIClient(request, user.context);
+    const transactionAPI = new TransactionAPIClient(request, user.context);
+    const ruleAPI = new TransactionRuleAPIClient(request, user.context);
+
+    const checking = await accountAPI.createAccount({
+      name: `rule-bulk-checking-${Date.now()}`,
+      note: "source",
+      type: "expense",
+    });
+    const other = await accountAPI.createAccount({
+      name: `rule-bulk-other-${Date.now()}`,
+      note: "original destination",
+      type: "expense",
+    });
+    const savings = await accountAPI.createAccount({
+      name: `rule-bulk-savings-${Date.now()}`,
+      note: "rule destination",
+      type: "expense",
+    });
+    const general = await categoryAPI.createCategory({
+      name: `rule-bulk-general-${Date.now()}`,
+      note: "general",
+      type: "expense",
+    });
+    const coffee = await categoryAPI.createCategory({
+      name: `rule-bulk-coffee-${Date.now()}`,
+      note: "coffee",
+      type: "expense",
+    });
+    const tag = await tagAPI.createTag({ name: `rule-bulk-tag-${Date.now()}` });
+    const checkingId = checking.data!.id as number;
+    const otherId = other.data!.id as number;
+    const savingsId = savings.data!.id as number;
+    const generalId = general.data!.id as number;
+
+    // Transactions recorded before the rules exist keep their values until they are updated
+    const categorized = await transactionAPI.createTransaction({
+      accountId: checkingId,
+      categoryId: generalId,
+      amount: 500,
+      date: new Date().toISOString(),
+      type: "expense",
+      note: "coffee",
+    });
+    const skipped = await transactionAPI.createTransaction({
+      accountId: checkingId,
+      categoryId: generalId,
+      amount: 500,
+      date: new Date().toISOString(),
+      type: "expense",
+      note: "coffee",
+    });
+    const expense = await transactionAPI.createTransaction({
+      accountId: checkingId,
+      categoryId: generalId,
+      amount: 300,
+      date: new Date().toISOString(),
+      type: "expense",
+      note: "savings jar",
+    });
+    const transfer = await transactionAPI.createTransaction({
+      accountId: checkingId,
+      destinationAccountId: otherId,
+      categoryId: generalId,
+      amount: 2000,
+      date: new Date().toISOString(),
+      type: "transfer",
+      note: "monthly savings",
+    });
+    const categorizedId = categorized.data!.id as number;
+    const skippedId = skipped.data!.id as number;
+    const expenseId = expense.data!.id as number;
+    const transferId = transfer.data!.id as number;
+
+    await ruleAPI.createTransactionRule({
+      name: "coffee",
+      conditions: { notePattern: "(?i)coffee", type: "expense" },
+      actions: {
+        categoryId: coffee.data!.id as number,
+        tagIds: [tag.data!.id as number],
+      },
+    });
+    await ruleAPI.createTransactionRule({
+      name: "savings",
+      conditions: { notePattern: "(?i)savings", type: "transfer" },
+      actions: { destinationAccountId: savingsId },
+    });
+
+    const draft = await transactionAPI.saveBulkDraft({
+      updates: [
+        { id: categorizedId, amount: 600 },
+        { id: skippedId, amount: 600, skipRules: true },
+        { id: expenseId, amount: 400 },
+        { id: transferId, amount: 2500 },
+      ],
+    });
+    expect(draft.status).toBe(200);
+
+    const commit = await transactionAPI.commitBulkDraft();
+    expect(commit.status).toBe(200);
+    expect(commit.data!.successCount).toBe(4);
+
+    const categorizedAfter = await transactionAPI.getTransaction(categorizedId);
+    expect(categorizedAfter.data!.amount).toBe(600);
+    expect(categorizedAfter.data!.category.id).toBe(coffee.data!.id);
+    expect((categorizedAfter.data!.tags ?? []).map((t) => t.id)).toContain(
+      tag.data!.id
+    );
+
+    const skippedAfter = await transactionAPI.getTransaction(skippedId);
+    expect(skippedAfter.data!.category.id).toBe(generalId);
+    expect(skippedAfter.data!.tags ?? []).toHaveLength(0);
+
+    const expenseAfter = await transactionAPI.getTransaction(expenseId);
+    expect(expenseAfter.data!.type).toBe("expense");
+    expect(expenseAfter.data!.destinationAccount).toBeFalsy();
+
+    const transferAfter = await transactionAPI.getTransaction(transferId);
+    expect(transferAfter.data!.destinationAccount!.id).toBe(savingsId);
+
+    // The transfer moved to the rule's destination and the expense credited nothing
+    const checkingAfter = await accountAPI.getAccount(checkingId);
+    const otherAfter = await accountAPI.getAccount(otherId);
+    const savingsAfter = await accountAPI.getAccount(savingsId);
+    expect(checkingAfter.data!.amount).toBe(-(600 + 600 + 400 + 2500));
+    expect(otherAfter.data!.amount).toBe(0);
+    expect(savingsAfter.data!.amount).toBe(2500);
+  });
+
+  test("dry run lists changes without applying them, apply changes the range", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-rules-apply");
+    const accountAPI = new AccountAPIClient(request, user.context);
+    const categoryAPI = new CategoryAPIClient(request, user.context);
+    const transactionAPI = new TransactionAPIClient(request, user.context);
+    const ruleAPI = new TransactionRuleAPIClient(request, user.context);
+
+    const checking = await accountAPI.createAccount({
+      name: `rule-apply-checking-${Date.now()}`,
+      note: "source",
+      type: "expense",
+    });
+    const other = await accountAPI.createAccount({
+      name: `rule-apply-other-${Date.now()}`,
+      note: "original destination",
+      type: "expense",
+    });
+    const savings = await accountAPI.createAccount({
+      name: `rule-apply-savings-${Date.now()}`,
+      note: "rule destination",
+      type: "expense",
+    });
+    const general = await categoryAPI.createCategory({
+      name: `rule-apply-general-${Date.now()}`,
+      note: "general",
+      type: "expense",
+    });
+    const coffee = await categoryAPI.createCategory({
+      name: `rule-apply-coffee-${Date.now()}`,
+      note: "coffee",
+      type: "expense",
+    });
+    const checkingId = checking.data!.id as number;
+    const otherId = other.data!.id as number;
+    const savingsId = savings.data!.id as number;
+    const generalId = general.data!.id as number;
+    const coffeeId = coffee.data!.id as number;
+
+    const expense = await transactionAPI.createTransaction({
+      accountId: checkingId,
+      categoryId: generalId,
+      amount: 450,
+      date: new Date().toISOString(),
+      type: "expense",
+      note: "Coffee with savings club",
+    });
+    const transfer = await transactionAPI.createTransaction({
+      accountId: checkingId,
+      destinationAccountId: otherId,
+      categoryId: generalId,
+      amount: 1000,
+      date: new Date().toISOString(),
+      type: "transfer",
+      note: "savings",
+    });
+    const expenseId = expense.data!.id as number;
+    const transferId = transfer.data!.id as number;
+
+    const coffeeRule = await ruleAPI.createTransactionRule({
+      name: "coffee",
+      conditions: { notePattern: "(?i)coffee", type: "expense" },
+      actions: { categoryId: coffeeId },
+    });
+    await ruleAPI.createTransactionRule({
+      name: "savings",
+      conditions: { notePattern: "(?i)savings", type: "transfer" },
+      actions: { destinationAccountId: savingsId },
+    });
+    const coffeeRuleId = coffeeRule.data!.id as number;
+
+    const range = {
+      startDate: new Date(Date.now() - day).toISOString(),
+      endDate: new Date(Date.now() + day).toISOString(),
+    };
+
+    const dryRun = await ruleAPI.dryRunTransactionRule(coffeeRuleId, range);
+    expect(dryRun.status).toBe(200);
+    expect(dryRun.data!.checkedCount).toBe(2);
+    const items = dryRun.data!.items ?? [];
+    expect(items).toHaveLength(1);
+    expect(items[0].transactionId).toBe(expenseId);
+    expect(items[0].changedFields).toEqual(["categoryId"]);
+    expect(items[0].before.categoryId).toBe(generalId);
+    expect(items[0].after.categoryId).toBe(coffeeId);
+    expect(items[0].ruleIds).toEqual([coffeeRuleId]);
+
+    const untouched = await transactionAPI.getTransaction(expenseId);
+    expect(untouched.data!.category.id).toBe(generalId);
+
+    const applied = await ruleAPI.applyTransactionRules(range);
+    expect(applied.status).toBe(200);
+    expect(applied.data!.checkedCount).toBe(2);
+    expect([...(applied.data!.updatedIds ?? [])].sort((a, b) => a - b)).toEqual(
+      [expenseId, transferId].sort((a, b) => a - b)
+    );
+
+    const expenseAfter = await transactionAPI.getTransaction(expenseId);
+    expect(expenseAfter.data!.category.id).toBe(coffeeId);
+    expect(expenseAfter.data!.destinationAccount).toBeFalsy();
+
+    const transferAfter = await transactionAPI.getTransaction(transferId);
+    expect(transferAfter.data!.destinationAccount!.id).toBe(savingsId);
+
+    const checkingAfter = await accountAPI.getAccount(checkingId);
+    const otherAfter = await accountAPI.getAccount(otherId);
+    const savingsAfter = await accountAPI.getAccount(savingsId);
+    expect(checkingAfter.data!.amount).toBe(-1450);
+    expect(otherAfter.data!.amount).toBe(0);
+    expect(savingsAfter.data!.amount).toBe(1000);
+
+    // Nothing is left to change once the rules have been applied
+    const again = await ruleAPI.applyTransactionRules(range);
+    expect(again.data!.updatedIds ?? []).toHaveLength(0);
+  });
+
+  test("GET /transaction-rules/{id}/dry-run - end before start returns 400", async ({
+    transactionRuleAPI,
+  }) => {
+    const rule = await transactionRuleAPI.createTransactionRule({
+      name: `rule-range-${Date.now()}`,
+      conditions: { notePattern: `rule-range-${Date.now()}` },
+      actions: { note: "never" },
+    });
+    const ruleId = rule.data!.id as number;
+
+    const res = await transactionRuleAPI.dryRunTransactionRule(ruleId, {
+      startDate: new Date().toISOString(),
+      endDate: new Date(Date.now() - day).toISOString(),
+    });
+    expect(res.status).toBe(400);
+
+    await transactionRuleAPI.deleteTransactionRule(ruleId);
+  });
+});
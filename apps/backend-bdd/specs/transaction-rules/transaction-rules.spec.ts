@@ -0,0 +1,248 @@
+import { test, expect, type TestUser } from "@fixtures/index";
+import { AccountAPIClient } from "@fixtures/account-client";
+import { CategoryAPIClient } from "@fixtures/category-client";
+import { TagAPIClient } from "@fixtures/tag-client";
+import { TransactionAPIClient } from "@fixtures/transaction-client";
+import { TransactionRuleAPIClient } from "@fixtures/transaction-rule-client";
+import type { APIRequestContext } from "@playwright/test";
+
+/**
+ * Rules apply to every transaction of their owner, so each spec runs as a fresh user
+ */
+function clientsFor(request: APIRequestContext, user: TestUser) {
+  return {
+    accountAPI: new AccountAPIClient(request, user.context),
+    categoryAPI: new CategoryAPIClient(request, user.context),
+    tagAPI: new TagAPIClient(request, user.context),
+    transactionAPI: new TransactionAPIClient(request, user.context),
+    ruleAPI: new TransactionRuleAPIClient(request, user.context),
+  };
+}
+
+test.describe("Transaction Rules - Common", () => {
+  test("POST /transaction-rules - create, get, update and delete", async ({
+    transactionRuleAPI,
+    categoryAPI,
+  }) => {
+    const cat = await categoryAPI.createCategory({
+      name: `rule-crud-cat-${Date.now()}`,
+      note: "rule target",
+      type: "expense",
+    });
+    const marker = `rule-crud-${Date.now()}`;
+
+    const created = await transactionRuleAPI.createTransactionRule({
+      name: marker,
+      conditions: { notePattern: marker, type: "expense" },
+      actions: { categoryId: cat.data!.id as number },
+    });
+    expect(created.status).toBe(200);
+    expect(created.data!.enabled).toBe(true);
+    const ruleId = created.data!.id as number;
+
+    const fetched = await transactionRuleAPI.getTransactionRule(ruleId);
+    expect(fetched.status).toBe(200);
+    expect(fetched.data!.conditions.notePattern).toBe(marker);
+
+    const updated = await transactionRuleAPI.updateTransactionRule(ruleId, {
+      enabled: false,
+    });
+    expect(updated.status).toBe(200);
+    expect(updated.data!.enabled).toBe(false);
+
+    const del = await transactionRuleAPI.deleteTransactionRule(ruleId);
+    expect(del.status).toBe(204);
+    const gone = await transactionRuleAPI.getTransactionRule(ruleId);
+    expect(gone.status).toBe(404);
+
+    await categoryAPI.deleteCategory(cat.data!.id as number);
+  });
+
+  test("POST /transaction-rules - invalid note pattern returns 400", async ({
+    transactionRuleAPI,
+  }) => {
+    const res = await transactionRuleAPI.createTransactionRule({
+      name: `rule-invalid-${Date.now()}`,
+      conditions: { notePattern: "(unclosed" },
+      actions: { note: "never" },
+    });
+    expect(res.status).toBe(400);
+  });
+
+  test("POST /transaction-rules - actions need a matching type condition", async ({
+    transactionRuleAPI,
+    accountAPI,
+    categoryAPI,
+  }) => {
+    const cat = await categoryAPI.createCategory({
+      name: `rule-untyped-cat-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const acc = await accountAPI.createAccount({
+      name: `rule-untyped-acc-${Date.now()}`,
+      note: "a",
+      type: "expense",
+    });
+
+    const untypedCategory = await transactionRuleAPI.createTransactionRule({
+      name: `rule-untyped-${Date.now()}`,
+      conditions: { notePattern: "groceries" },
+      actions: { categoryId: cat.data!.id as number },
+    });
+    expect(untypedCategory.status).toBe(400);
+
+    const incomeCategory = await transactionRuleAPI.createTransactionRule({
+      name: `rule-untyped-${Date.now()}`,
+      conditions: { notePattern: "groceries", type: "income" },
+      actions: { categoryId: cat.data!.id as number },
+    });
+    expect(incomeCategory.status).toBe(400);
+
+    const expenseDestination = await transactionRuleAPI.createTransactionRule({
+      name: `rule-untyped-${Date.now()}`,
+      conditions: { notePattern: "savings", type: "expense" },
+      actions: { destinationAccountId: acc.data!.id as number },
+    });
+    expect(expenseDestination.status).toBe(400);
+
+    await accountAPI.deleteAccount(acc.data!.id as number);
+    await categoryAPI.deleteCategory(cat.data!.id as number);
+  });
+
+  test("POST /transactions - matching rule sets category and tags", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-rules-create");
+    const { accountAPI, categoryAPI, tagAPI, transactionAPI, ruleAPI } =
+      clientsFor(request, user);
+
+    const acc = await accountAPI.createAccount({
+      name: `rule-create-acc-${Date.now()}`,
+      note: "rules",
+      type: "expense",
+    });
+    const catOther = await categoryAPI.createCategory({
+      name: `rule-create-other-${Date.now()}`,
+      note: "other",
+      type: "expense",
+    });
+    const catCoffee = await categoryAPI.createCategory({
+      name: `rule-create-coffee-${Date.now()}`,
+      note: "coffee",
+      type: "expense",
+    });
+    const tag = await tagAPI.createTag({ name: `rule-tag-${Date.now()}` });
+
+    const rule = await ruleAPI.createTransactionRule({
+      name: "coffee",
+      conditions: { notePattern: "(?i)coffee", type: "expense" },
+      actions: {
+        categoryId: catCoffee.data!.id as number,
+        tagIds: [tag.data!.id as number],
+      },
+    });
+    expect(rule.status).toBe(200);
+
+    const matched = await transactionAPI.createTransaction({
+      accountId: acc.data!.id as number,
+      categoryId: catOther.data!.id as number,
+      amount: 450,
+      date: new Date().toISOString(),
+      type: "expense",
+      note: "Morning Coffee",
+    });
+    expect(matched.status).toBe(200);
+    expect(matched.data!.category.id).toBe(catCoffee.data!.id);
+    expect((matched.data!.tags ?? []).map((t) => t.id)).toContain(
+      tag.data!.id
+    );
+
+    const unmatched = await transactionAPI.createTransaction({
+      accountId: acc.data!.id as number,
+      categoryId: catOther.data!.id as number,
+      amount: 450,
+      date: new Date().toISOString(),
+      type: "expense",
+      note: "Lunch",
+    });
+    expect(unmatched.data!.category.id).toBe(catOther.data!.id);
+
+    const skipped = await transactionAPI.createTransaction({
+      accountId: acc.data!.id as number,
+      categoryId: catOther.data!.id as number,
+      amount: 450,
+      date: new Date().toISOString(),
+      type: "expense",
+      note: "Coffee beans",
+      skipRules: true,
+    });
+    expect(skipped.data!.category.id).toBe(catOther.data!.id);
+    expect(skipped.data!.tags ?? []).toHaveLength(0);
+  });
+
+  test("POST /transactions - a rule's destination account fills in transfers only", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-rules-destination");
+    const { accountAPI, categoryAPI, transactionAPI, ruleAPI } = clientsFor(
+      request,
+      user
+    );
+
+    const checking = await accountAPI.createAccount({
+      name: `rule-dest-checking-${Date.now()}`,
+      note: "source",
+      type: "expense",
+    });
+    const savings = await accountAPI.createAccount({
+      name: `rule-dest-savings-${Date.now()}`,
+      note: "destination",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `rule-dest-cat-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const checkingId = checking.data!.id as number;
+    const savingsId = savings.data!.id as number;
+
+    const rule = await ruleAPI.createTransactionRule({
+      name: "savings",
+      conditions: { notePattern: "(?i)savings", type: "transfer" },
+      actions: { destinationAccountId: savingsId },
+    });
+    expect(rule.status).toBe(200);
+
+    const expense = await transactionAPI.createTransaction({
+      accountId: checkingId,
+      categoryId: cat.data!.id as number,
+      amount: 1000,
+      date: new Date().toISOString(),
+      type: "expense",
+      note: "savings jar",
+    });
+    expect(expense.status).toBe(200);
+    expect(expense.data!.type).toBe("expense");
+    expect(expense.data!.destinationAccount).toBeFalsy();
+
+    const transfer = await transactionAPI.createTransaction({
+      accountId: checkingId,
+      categoryId: cat.data!.id as number,
+      amount: 2000,
+      date: new Date().toISOString(),
+      type: "transfer",
+      note: "monthly savings",
+    });
+    expect(transfer.status).toBe(200);
+    expect(transfer.data!.destinationAccount!.id).toBe(savingsId);
+
+    const checkingAfter = await accountAPI.getAccount(checkingId);
+    const savingsAfter = await accountAPI.getAccount(savingsId);
+    expect(checkingAfter.data!.amount).toBe(-3000);
+    expect(savingsAfter.data!.amount).toBe(2000);
+  });
+});
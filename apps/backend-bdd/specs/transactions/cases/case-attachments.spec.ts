@@ -0,0 +1,290 @@
+import { test, expect } from "@fixtures/index";
+import type { AccountAPIClient } from "@fixtures/account-client";
+import type { CategoryAPIClient } from "@fixtures/category-client";
+import { TransactionAPIClient } from "@fixtures/transaction-client";
+import { createHash } from "crypto";
+
+// A 1x1 PNG, small enough to inline and decodable for the thumbnail
+const PNG = Buffer.from(
+  "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNk+M9QDwADhgGAWjR9awAAAABJRU5ErkJggg==",
+  "base64",
+);
+const PDF = Buffer.from(
+  "%PDF-1.4\n1 0 obj\n<<>>\nendobj\ntrailer\n<<>>\n%%EOF\n",
+);
+const MAX_SIZE_BYTES = 10 * 1024 * 1024;
+
+async function createExpense(
+  transactionAPI: TransactionAPIClient,
+  accountAPI: AccountAPIClient,
+  categoryAPI: CategoryAPIClient,
+): Promise<number> {
+  const acc = await accountAPI.createAccount({
+    name: `att-acc-${Date.now()}`,
+    note: "a",
+    type: "expense",
+  });
+  const cat = await categoryAPI.createCategory({
+    name: `att-cat-${Date.now()}`,
+    note: "c",
+    type: "expense",
+  });
+
+  const tx = await transactionAPI.createTransaction({
+    accountId: acc.data!.id as number,
+    amount: 1500,
+    categoryId: cat.data!.id as number,
+    date: new Date().toISOString(),
+    type: "expense" as const,
+  });
+  expect(tx.status).toBe(200);
+  return tx.data!.id as number;
+}
+
+test.describe("Transactions - Attachments", () => {
+  test("uploads an image, downloads it and its thumbnail", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+  }) => {
+    const tid = await createExpense(transactionAPI, accountAPI, categoryAPI);
+
+    const upload = await transactionAPI.uploadTransactionAttachment(tid, {
+      name: "receipt.png",
+      mimeType: "image/png",
+      buffer: PNG,
+    });
+    expect(upload.status).toBe(201);
+    expect(upload.data!.transactionId).toBe(tid);
+    expect(upload.data!.fileName).toBe("receipt.png");
+    expect(upload.data!.contentType).toBe("image/png");
+    expect(upload.data!.sizeBytes).toBe(PNG.length);
+    expect(upload.data!.checksumSha256).toBe(
+      createHash("sha256").update(PNG).digest("hex"),
+    );
+    expect(upload.data!.hasThumbnail).toBe(true);
+    const aid = upload.data!.id;
+
+    const list = await transactionAPI.getTransactionAttachments(tid);
+    expect(list.status).toBe(200);
+    expect(list.data!.items!.map((a) => a.id)).toEqual([aid]);
+
+    const content = await transactionAPI.downloadTransactionAttachment(
+      tid,
+      aid,
+    );
+    expect(content.status).toBe(200);
+    expect(content.headers["content-type"]).toBe("image/png");
+    expect(content.headers["content-disposition"]).toContain("attachment");
+    expect(content.headers["content-disposition"]).toContain("receipt.png");
+    expect(content.headers["x-content-type-options"]).toBe("nosniff");
+    expect(Buffer.compare(content.data!, PNG)).toBe(0);
+
+    const thumbnail = await transactionAPI.downloadTransactionAttachment(
+      tid,
+      aid,
+      true,
+    );
+    expect(thumbnail.status).toBe(200);
+    expect(thumbnail.headers["content-type"]).toBe("image/jpeg");
+    expect(thumbnail.headers["content-disposition"]).toContain("inline");
+    // JPEG start of image marker
+    expect(thumbnail.data!.subarray(0, 2).toString("hex")).toBe("ffd8");
+  });
+
+  test("a PDF is accepted without a thumbnail", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+  }) => {
+    const tid = await createExpense(transactionAPI, accountAPI, categoryAPI);
+
+    const upload = await transactionAPI.uploadTransactionAttachment(tid, {
+      name: "invoice.pdf",
+      mimeType: "application/pdf",
+      buffer: PDF,
+    });
+    expect(upload.status).toBe(201);
+    expect(upload.data!.contentType).toBe("application/pdf");
+    expect(upload.data!.hasThumbnail).toBe(false);
+
+    const thumbnail = await transactionAPI.downloadTransactionAttachment(
+      tid,
+      upload.data!.id,
+      true,
+    );
+    expect(thumbnail.status).toBe(404);
+  });
+
+  test("the file type is detected from the content, not the declared type", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+  }) => {
+    const tid = await createExpense(transactionAPI, accountAPI, categoryAPI);
+
+    const text = await transactionAPI.uploadTransactionAttachment(tid, {
+      name: "receipt.png",
+      mimeType: "image/png",
+      buffer: Buffer.from("not really an image\n"),
+    });
+    expect(text.status).toBe(415);
+    expect(text.error!.detail).toContain("text/plain");
+
+    // Declared as text but the content is a PNG
+    const png = await transactionAPI.uploadTransactionAttachment(tid, {
+      name: "receipt.txt",
+      mimeType: "text/plain",
+      buffer: PNG,
+    });
+    expect(png.status).toBe(201);
+    expect(png.data!.contentType).toBe("image/png");
+
+    const empty = await transactionAPI.uploadTransactionAttachment(tid, {
+      name: "empty.pdf",
+      mimeType: "application/pdf",
+      buffer: Buffer.alloc(0),
+    });
+    expect(empty.status).toBe(400);
+
+    const list = await transactionAPI.getTransactionAttachments(tid);
+    expect(list.data!.items).toHaveLength(1);
+  });
+
+  test("files over 10 MB are rejected", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+  }) => {
+    const tid = await createExpense(transactionAPI, accountAPI, categoryAPI);
+
+    const limit = Buffer.alloc(MAX_SIZE_BYTES, " ");
+    PDF.copy(limit);
+    const atLimit = await transactionAPI.uploadTransactionAttachment(tid, {
+      name: "limit.pdf",
+      mimeType: "application/pdf",
+      buffer: limit,
+    });
+    expect(atLimit.status).toBe(201);
+    expect(atLimit.data!.sizeBytes).toBe(MAX_SIZE_BYTES);
+
+    const over = Buffer.alloc(MAX_SIZE_BYTES + 1, " ");
+    PDF.copy(over);
+    const tooLarge = await transactionAPI.uploadTransactionAttachment(tid, {
+      name: "large.pdf",
+      mimeType: "application/pdf",
+      buffer: over,
+    });
+    expect(tooLarge.status).toBe(413);
+
+    const list = await transactionAPI.getTransactionAttachments(tid);
+    expect(list.data!.items).toHaveLength(1);
+  });
+
+  test("deleting an attachment removes it from the transaction", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+  }) => {
+    const tid = await createExpense(transactionAPI, accountAPI, categoryAPI);
+    const upload = await transactionAPI.uploadTransactionAttachment(tid, {
+      name: "receipt.png",
+      mimeType: "image/png",
+      buffer: PNG,
+    });
+    const aid = upload.data!.id;
+
+    const del = await transactionAPI.deleteTransactionAttachment(tid, aid);
+    expect([200, 204]).toContain(del.status);
+
+    const list = await transactionAPI.getTransactionAttachments(tid);
+    expect(list.data!.items).toHaveLength(0);
+
+    const content = await transactionAPI.downloadTransactionAttachment(
+      tid,
+      aid,
+    );
+    expect(content.status).toBe(404);
+
+    const again = await transactionAPI.deleteTransactionAttachment(tid, aid);
+    expect(again.status).toBe(404);
+  });
+
+  test("attachments are deleted and restored together with their transaction", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+  }) => {
+    const tid = await createExpense(transactionAPI, accountAPI, categoryAPI);
+    const kept = await transactionAPI.uploadTransactionAttachment(tid, {
+      name: "receipt.png",
+      mimeType: "image/png",
+      buffer: PNG,
+    });
+    const removed = await transactionAPI.uploadTransactionAttachment(tid, {
+      name: "invoice.pdf",
+      mimeType: "application/pdf",
+      buffer: PDF,
+    });
+    await transactionAPI.deleteTransactionAttachment(tid, removed.data!.id);
+
+    const del = await transactionAPI.deleteTransaction(tid);
+    expect([200, 204]).toContain(del.status);
+
+    const hidden = await transactionAPI.getTransactionAttachments(tid);
+    expect(hidden.status).toBe(404);
+    const hiddenContent = await transactionAPI.downloadTransactionAttachment(
+      tid,
+      kept.data!.id,
+    );
+    expect(hiddenContent.status).toBe(404);
+
+    const restore = await transactionAPI.restoreTransaction(tid);
+    expect(restore.status).toBe(200);
+
+    // Only the attachment deleted along with the transaction comes back
+    const list = await transactionAPI.getTransactionAttachments(tid);
+    expect(list.data!.items!.map((a) => a.id)).toEqual([kept.data!.id]);
+
+    const content = await transactionAPI.downloadTransactionAttachment(
+      tid,
+      kept.data!.id,
+    );
+    expect(content.status).toBe(200);
+    expect(Buffer.compare(content.data!, PNG)).toBe(0);
+  });
+
+  test("attachments of another user's transaction are not found", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+    createUser,
+    request,
+  }) => {
+    const tid = await createExpense(transactionAPI, accountAPI, categoryAPI);
+    const upload = await transactionAPI.uploadTransactionAttachment(tid, {
+      name: "receipt.png",
+      mimeType: "image/png",
+      buffer: PNG,
+    });
+
+    const other = await createUser("e2e-attachments-other");
+    const otherAPI = new TransactionAPIClient(request, other.context);
+
+    const list = await otherAPI.getTransactionAttachments(tid);
+    expect(list.status).toBe(404);
+
+    const content = await otherAPI.downloadTransactionAttachment(
+      tid,
+      upload.data!.id,
+    );
+    expect(content.status).toBe(404);
+
+    const uploadAsOther = await otherAPI.uploadTransactionAttachment(tid, {
+      name: "receipt.png",
+      mimeType: "image/png",
+      buffer: PNG,
+    });
+    expect(uploadAsOther.status).toBe(404);
+  });
+});
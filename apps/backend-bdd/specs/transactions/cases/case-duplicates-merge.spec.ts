@@ -0,0 +1,231 @@
+import { test, expect } from "@fixtures/index";
+
+test.describe("Transactions - Duplicates and Merge Cases", () => {
+  test("merging a duplicate counts the amount once and keeps its tags", async ({
+    accountAPI,
+    categoryAPI,
+    tagAPI,
+    transactionAPI,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `merge-acc-${Date.now()}`,
+      note: "merge",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `merge-cat-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const tag = await tagAPI.createTag({ name: `merge-tag-${Date.now()}` });
+    const accountId = acc.data!.id as number;
+    const date = new Date().toISOString();
+
+    const kept = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: cat.data!.id as number,
+      amount: 1000,
+      date,
+      type: "expense",
+      note: "Grocery store",
+    });
+    const duplicate = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: cat.data!.id as number,
+      amount: 1000,
+      date,
+      type: "expense",
+      note: "Grocery store",
+    });
+    const keptId = kept.data!.id as number;
+    const duplicateId = duplicate.data!.id as number;
+    await transactionAPI.addTransactionTag(duplicateId, tag.data!.id as number);
+
+    const pairs = await transactionAPI.getTransactionDuplicates({
+      accountId: [accountId],
+    });
+    expect(pairs.status).toBe(200);
+    expect(pairs.data!.items ?? []).toHaveLength(1);
+    expect(pairs.data!.items![0].transaction.id).toBe(keptId);
+    expect(pairs.data!.items![0].duplicate.id).toBe(duplicateId);
+    expect(pairs.data!.items![0].reasons).toContain("same_note");
+
+    const before = await accountAPI.getAccount(accountId);
+    expect(before.data!.amount).toBe(-2000);
+
+    const merge = await transactionAPI.mergeTransactions(keptId, {
+      duplicateIds: [duplicateId],
+    });
+    expect(merge.status).toBe(200);
+    expect(merge.data!.mergedCount).toBe(1);
+    expect(merge.data!.tagsMoved).toBe(1);
+    expect((merge.data!.transaction.tags ?? []).map((t) => t.id)).toContain(
+      tag.data!.id
+    );
+
+    const after = await accountAPI.getAccount(accountId);
+    expect(after.data!.amount).toBe(-1000);
+
+    const gone = await transactionAPI.getTransaction(duplicateId);
+    expect(gone.status).toBe(404);
+
+    const remaining = await transactionAPI.getTransactionDuplicates({
+      accountId: [accountId],
+    });
+    expect(remaining.data!.items ?? []).toHaveLength(0);
+
+    // The duplicate went through the trash, so restoring it brings its amount back
+    const restored = await transactionAPI.restoreTransaction(duplicateId);
+    expect(restored.status).toBe(200);
+    const afterRestore = await accountAPI.getAccount(accountId);
+    expect(afterRestore.data!.amount).toBe(-2000);
+
+    await transactionAPI.deleteTransaction(duplicateId);
+    await transactionAPI.deleteTransaction(keptId);
+    await accountAPI.deleteAccount(accountId);
+    await categoryAPI.deleteCategory(cat.data!.id as number);
+  });
+
+  test("merging duplicate transfers counts both balances once", async ({
+    accountAPI,
+    categoryAPI,
+    transactionAPI,
+  }) => {
+    const source = await accountAPI.createAccount({
+      name: `merge-src-${Date.now()}`,
+      note: "source",
+      type: "expense",
+    });
+    const dest = await accountAPI.createAccount({
+      name: `merge-dst-${Date.now()}`,
+      note: "destination",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `merge-transfer-cat-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const sourceId = source.data!.id as number;
+    const destId = dest.data!.id as number;
+    const payload = {
+      accountId: sourceId,
+      destinationAccountId: destId,
+      categoryId: cat.data!.id as number,
+      amount: 1500,
+      date: new Date().toISOString(),
+      type: "transfer" as const,
+    };
+
+    const kept = await transactionAPI.createTransaction(payload);
+    const duplicate = await transactionAPI.createTransaction(payload);
+    const keptId = kept.data!.id as number;
+
+    const merge = await transactionAPI.mergeTransactions(keptId, {
+      duplicateIds: [duplicate.data!.id as number],
+    });
+    expect(merge.status).toBe(200);
+    expect(merge.data!.mergedCount).toBe(1);
+
+    const sourceAfter = await accountAPI.getAccount(sourceId);
+    const destAfter = await accountAPI.getAccount(destId);
+    expect(sourceAfter.data!.amount).toBe(-1500);
+    expect(destAfter.data!.amount).toBe(1500);
+
+    await transactionAPI.deleteTransaction(keptId);
+    await accountAPI.deleteAccount(sourceId);
+    await accountAPI.deleteAccount(destId);
+    await categoryAPI.deleteCategory(cat.data!.id as number);
+  });
+
+  test("POST /transactions/{id}/merge - only same account, type and amount can merge", async ({
+    accountAPI,
+    categoryAPI,
+    transactionAPI,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `merge-bad-acc-${Date.now()}`,
+      note: "merge",
+      type: "expense",
+    });
+    const otherAcc = await accountAPI.createAccount({
+      name: `merge-bad-other-${Date.now()}`,
+      note: "merge",
+      type: "expense",
+    });
+    const expenseCat = await categoryAPI.createCategory({
+      name: `merge-bad-exp-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const incomeCat = await categoryAPI.createCategory({
+      name: `merge-bad-inc-${Date.now()}`,
+      note: "c",
+      type: "income",
+    });
+    const accountId = acc.data!.id as number;
+    const otherAccountId = otherAcc.data!.id as number;
+    const date = new Date().toISOString();
+
+    const kept = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: expenseCat.data!.id as number,
+      amount: 1000,
+      date,
+      type: "expense",
+    });
+    const otherAmount = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: expenseCat.data!.id as number,
+      amount: 1200,
+      date,
+      type: "expense",
+    });
+    const otherType = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: incomeCat.data!.id as number,
+      amount: 1000,
+      date,
+      type: "income",
+    });
+    const otherAccount = await transactionAPI.createTransaction({
+      accountId: otherAccountId,
+      categoryId: expenseCat.data!.id as number,
+      amount: 1000,
+      date,
+      type: "expense",
+    });
+    const keptId = kept.data!.id as number;
+    const mismatched = [otherAmount, otherType, otherAccount].map(
+      (t) => t.data!.id as number
+    );
+
+    for (const id of mismatched) {
+      const res = await transactionAPI.mergeTransactions(keptId, {
+        duplicateIds: [id],
+      });
+      expect(res.status).toBe(400);
+    }
+
+    const self = await transactionAPI.mergeTransactions(keptId, {
+      duplicateIds: [keptId],
+    });
+    expect(self.status).toBe(400);
+
+    // Rejected merges leave every transaction and balance in place
+    const accAfter = await accountAPI.getAccount(accountId);
+    expect(accAfter.data!.amount).toBe(-1000 - 1200 + 1000);
+    for (const id of mismatched) {
+      const res = await transactionAPI.getTransaction(id);
+      expect(res.status).toBe(200);
+    }
+
+    for (const id of [keptId, ...mismatched]) {
+      await transactionAPI.deleteTransaction(id);
+    }
+    await accountAPI.deleteAccount(accountId);
+    await accountAPI.deleteAccount(otherAccountId);
+    await categoryAPI.deleteCategory(expenseCat.data!.id as number);
+    await categoryAPI.deleteCategory(incomeCat.data!.id as number);
+  });
+});
@@ -0,0 +1,128 @@
+import { test, expect } from "@fixtures/index";
+import { AuthAPIClient } from "@fixtures/auth-client";
+
+const newKey = (prefix: string) =>
+  `${prefix}-${Date.now()}-${Math.floor(Math.random() * 10000)}`;
+
+test.describe("Transactions - Idempotency Cases", () => {
+  test("retrying with the same key replays the first response and records once", async ({
+    accountAPI,
+    categoryAPI,
+    transactionAPI,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `idem-acc-${Date.now()}`,
+      note: "idempotency",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `idem-cat-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const accountId = acc.data!.id as number;
+    const payload = {
+      accountId,
+      categoryId: cat.data!.id as number,
+      amount: 1234,
+      date: new Date().toISOString(),
+      type: "expense" as const,
+    };
+    const key = newKey("idem-replay");
+
+    const first = await transactionAPI.createTransaction(payload, key);
+    expect(first.status).toBe(200);
+    expect(first.headers["idempotent-replayed"]).toBeUndefined();
+
+    const retry = await transactionAPI.createTransaction(payload, key);
+    expect(retry.status).toBe(200);
+    expect(retry.headers["idempotent-replayed"]).toBe("true");
+    expect(retry.data!.id).toBe(first.data!.id);
+
+    const after = await accountAPI.getAccount(accountId);
+    expect(after.data!.amount).toBe(-1234);
+
+    // Another key is another request
+    const other = await transactionAPI.createTransaction(
+      payload,
+      newKey("idem-other")
+    );
+    expect(other.status).toBe(200);
+    expect(other.data!.id).not.toBe(first.data!.id);
+    const afterOther = await accountAPI.getAccount(accountId);
+    expect(afterOther.data!.amount).toBe(-2468);
+
+    await transactionAPI.deleteTransaction(first.data!.id as number);
+    await transactionAPI.deleteTransaction(other.data!.id as number);
+    await accountAPI.deleteAccount(accountId);
+    await categoryAPI.deleteCategory(cat.data!.id as number);
+  });
+
+  test("reusing a key for a different body returns 422", async ({
+    accountAPI,
+    categoryAPI,
+    transactionAPI,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `idem-reuse-acc-${Date.now()}`,
+      note: "idempotency",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `idem-reuse-cat-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const accountId = acc.data!.id as number;
+    const payload = {
+      accountId,
+      categoryId: cat.data!.id as number,
+      amount: 500,
+      date: new Date().toISOString(),
+      type: "expense" as const,
+    };
+    const key = newKey("idem-reuse");
+
+    const first = await transactionAPI.createTransaction(payload, key);
+    expect(first.status).toBe(200);
+
+    const changed = await transactionAPI.createTransaction(
+      { ...payload, amount: 900 },
+      key
+    );
+    expect(changed.status).toBe(422);
+
+    const after = await accountAPI.getAccount(accountId);
+    expect(after.data!.amount).toBe(-500);
+
+    await transactionAPI.deleteTransaction(first.data!.id as number);
+    await accountAPI.deleteAccount(accountId);
+    await categoryAPI.deleteCategory(cat.data!.id as number);
+  });
+
+  test("operations returning secrets are never replayed", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-idem-exempt");
+    const authAPI = new AuthAPIClient(request, user.context);
+    const key = newKey("idem-token");
+
+    const first = await authAPI.createAPIToken(
+      { name: "ci", scopes: ["transactions:read"] },
+      key
+    );
+    expect(first.status).toBe(200);
+    const second = await authAPI.createAPIToken(
+      { name: "ci", scopes: ["transactions:read"] },
+      key
+    );
+    expect(second.status).toBe(200);
+    expect(second.headers["idempotent-replayed"]).toBeUndefined();
+    expect(second.data!.id).not.toBe(first.data!.id);
+    expect(second.data!.token).not.toBe(first.data!.token);
+
+    await authAPI.revokeAPIToken(first.data!.id as number);
+    await authAPI.revokeAPIToken(second.data!.id as number);
+  });
+});
@@ -0,0 +1,182 @@
+import { test, expect } from "@fixtures/index";
+
+test.describe("Transactions - If-Match Cases", () => {
+  test("PATCH /transactions/:id - a stale If-Match returns 412 with the current transaction", async ({
+    accountAPI,
+    categoryAPI,
+    transactionAPI,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `ifm-tx-acc-${Date.now()}`,
+      note: "if-match",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `ifm-tx-cat-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const tx = await transactionAPI.createTransaction({
+      accountId: acc.data!.id as number,
+      categoryId: cat.data!.id as number,
+      amount: 1000,
+      date: new Date().toISOString(),
+      type: "expense",
+      note: "original",
+    });
+    const txId = tx.data!.id as number;
+
+    const read = await transactionAPI.getTransaction(txId);
+    const staleETag = read.data!.etag!;
+    expect(staleETag).toBeTruthy();
+    expect(read.headers["etag"]).toBe(staleETag);
+
+    // Another client changes the transaction first
+    const first = await transactionAPI.updateTransaction(
+      txId,
+      { note: "first writer" },
+      staleETag
+    );
+    expect(first.status).toBe(200);
+    expect(first.data!.etag).not.toBe(staleETag);
+    expect(first.headers["etag"]).toBe(first.data!.etag);
+
+    const second = await transactionAPI.updateTransaction(
+      txId,
+      { note: "second writer", amount: 2000 },
+      staleETag
+    );
+    expect(second.status).toBe(412);
+    expect(second.error).toHaveProperty("current.id", txId);
+    expect(second.error).toHaveProperty("current.note", "first writer");
+    expect(second.headers["etag"]).toBe(first.data!.etag);
+
+    const after = await transactionAPI.getTransaction(txId);
+    expect(after.data!.note).toBe("first writer");
+    expect(after.data!.amount).toBe(1000);
+    const balance = await accountAPI.getAccount(acc.data!.id as number);
+    expect(balance.data!.amount).toBe(-1000);
+
+    // Without If-Match the last write still wins
+    const blind = await transactionAPI.updateTransaction(txId, {
+      note: "blind write",
+    });
+    expect(blind.status).toBe(200);
+
+    await transactionAPI.deleteTransaction(txId);
+    await accountAPI.deleteAccount(acc.data!.id as number);
+    await categoryAPI.deleteCategory(cat.data!.id as number);
+  });
+
+  test("PATCH /transactions/:id - If-Match accepts * and lists, never weak tags", async ({
+    accountAPI,
+    categoryAPI,
+    transactionAPI,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `ifm-forms-acc-${Date.now()}`,
+      note: "if-match",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `ifm-forms-cat-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const tx = await transactionAPI.createTransaction({
+      accountId: acc.data!.id as number,
+      categoryId: cat.data!.id as number,
+      amount: 1000,
+      date: new Date().toISOString(),
+      type: "expense",
+    });
+    const txId = tx.data!.id as number;
+
+    // If-Match compares strongly, so the weak form of the current tag does not match
+    const read = await transactionAPI.getTransaction(txId);
+    const weak = await transactionAPI.updateTransaction(
+      txId,
+      { note: "weak" },
+      `W/${read.data!.etag}`
+    );
+    expect(weak.status).toBe(412);
+
+    const any = await transactionAPI.updateTransaction(
+      txId,
+      { note: "any" },
+      "*"
+    );
+    expect(any.status).toBe(200);
+
+    const list = await transactionAPI.updateTransaction(
+      txId,
+      { note: "list" },
+      `"0-0", ${any.data!.etag}`
+    );
+    expect(list.status).toBe(200);
+
+    const noneMatching = await transactionAPI.updateTransaction(
+      txId,
+      { note: "none" },
+      `"0-0", ${read.data!.etag}`
+    );
+    expect(noneMatching.status).toBe(412);
+
+    await transactionAPI.deleteTransaction(txId);
+    await accountAPI.deleteAccount(acc.data!.id as number);
+    await categoryAPI.deleteCategory(cat.data!.id as number);
+  });
+
+  test("DELETE /transactions/:id - a stale If-Match keeps the transaction and its balance", async ({
+    accountAPI,
+    categoryAPI,
+    transactionAPI,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `ifm-del-acc-${Date.now()}`,
+      note: "if-match",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `ifm-del-cat-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const accountId = acc.data!.id as number;
+    const tx = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: cat.data!.id as number,
+      amount: 1000,
+      date: new Date().toISOString(),
+      type: "expense",
+    });
+    const txId = tx.data!.id as number;
+
+    const read = await transactionAPI.getTransaction(txId);
+    const changed = await transactionAPI.updateTransaction(txId, {
+      amount: 1500,
+    });
+    expect(changed.status).toBe(200);
+
+    const stale = await transactionAPI.deleteTransaction(
+      txId,
+      read.data!.etag
+    );
+    expect(stale.status).toBe(412);
+    expect(stale.error).toHaveProperty("current.amount", 1500);
+
+    const kept = await accountAPI.getAccount(accountId);
+    expect(kept.data!.amount).toBe(-1500);
+
+    const current = await transactionAPI.deleteTransaction(
+      txId,
+      changed.data!.etag
+    );
+    expect(current.status).toBe(204);
+    const after = await accountAPI.getAccount(accountId);
+    expect(after.data!.amount).toBe(0);
+
+    await accountAPI.deleteAccount(accountId);
+    await categoryAPI.deleteCategory(cat.data!.id as number);
+  });
+});
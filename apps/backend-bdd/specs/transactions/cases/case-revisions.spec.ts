@@ -0,0 +1,250 @@
+import { test, expect } from "@fixtures/index";
+import { TransactionAPIClient } from "@fixtures/transaction-client";
+
+test.describe("Transactions - Revisions", () => {
+  test("an update records the changed fields and the balance delta", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `rev-acc-${Date.now()}`,
+      note: "a",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `rev-cat-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const accId = acc.data!.id as number;
+
+    const create = await transactionAPI.createTransaction({
+      accountId: accId,
+      amount: 1000,
+      categoryId: cat.data!.id as number,
+      date: new Date().toISOString(),
+      type: "expense" as const,
+      note: "lunch",
+    });
+    const tid = create.data!.id as number;
+
+    const none = await transactionAPI.getTransactionRevisions(tid);
+    expect(none.status).toBe(200);
+    expect(none.data!.items).toHaveLength(0);
+
+    await transactionAPI.updateTransaction(tid, {
+      amount: 1500,
+      note: "lunch and coffee",
+    });
+
+    const list = await transactionAPI.getTransactionRevisions(tid);
+    expect(list.data!.items).toHaveLength(1);
+    const rev = list.data!.items![0];
+    expect(rev.revision).toBe(1);
+    expect(rev.transactionId).toBe(tid);
+    expect(rev.changedFields).toEqual(["amount", "note"]);
+    expect(rev.before).toEqual({ amount: 1000, note: "lunch" });
+    expect(rev.after).toEqual({ amount: 1500, note: "lunch and coffee" });
+    expect(rev.balanceDeltas).toEqual([{ accountId: accId, amount: -500 }]);
+    expect(rev.actorId).not.toBeNull();
+
+    // An update that changes nothing is not recorded
+    await transactionAPI.updateTransaction(tid, { amount: 1500 });
+    const unchanged = await transactionAPI.getTransactionRevisions(tid);
+    expect(unchanged.data!.items).toHaveLength(1);
+  });
+
+  test("reverting restores the fields and the account balance", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `rev-amt-acc-${Date.now()}`,
+      note: "a",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `rev-amt-cat-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const accId = acc.data!.id as number;
+
+    const create = await transactionAPI.createTransaction({
+      accountId: accId,
+      amount: 1000,
+      categoryId: cat.data!.id as number,
+      date: new Date().toISOString(),
+      type: "expense" as const,
+    });
+    const tid = create.data!.id as number;
+
+    await transactionAPI.updateTransaction(tid, { amount: 4000 });
+    const mid = await accountAPI.getAccount(accId);
+    expect(mid.data!.amount).toBe(-4000);
+
+    const revert = await transactionAPI.revertTransactionRevision(tid, 1);
+    expect(revert.status).toBe(200);
+    expect(revert.data!.amount).toBe(1000);
+
+    const after = await accountAPI.getAccount(accId);
+    expect(after.data!.amount).toBe(-1000);
+
+    // The revert is itself a revision, listed first
+    const list = await transactionAPI.getTransactionRevisions(tid);
+    expect(list.data!.items!.map((r) => r.revision)).toEqual([2, 1]);
+    const reverted = list.data!.items![0];
+    expect(reverted.before).toEqual({ amount: 4000 });
+    expect(reverted.after).toEqual({ amount: 1000 });
+    expect(reverted.balanceDeltas).toEqual([
+      { accountId: accId, amount: 3000 },
+    ]);
+  });
+
+  test("reverting a move between accounts restores both balances", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+  }) => {
+    const from = await accountAPI.createAccount({
+      name: `rev-from-${Date.now()}`,
+      note: "a",
+      type: "expense",
+    });
+    const to = await accountAPI.createAccount({
+      name: `rev-to-${Date.now()}`,
+      note: "a",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `rev-move-cat-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const fromId = from.data!.id as number;
+    const toId = to.data!.id as number;
+
+    const create = await transactionAPI.createTransaction({
+      accountId: fromId,
+      amount: 2500,
+      categoryId: cat.data!.id as number,
+      date: new Date().toISOString(),
+      type: "expense" as const,
+    });
+    const tid = create.data!.id as number;
+
+    await transactionAPI.updateTransaction(tid, { accountId: toId });
+
+    const list = await transactionAPI.getTransactionRevisions(tid);
+    const rev = list.data!.items![0];
+    expect(rev.changedFields).toEqual(["accountId"]);
+    expect(rev.before).toEqual({ accountId: fromId });
+    expect(rev.after).toEqual({ accountId: toId });
+    expect(rev.balanceDeltas).toEqual([
+      { accountId: fromId, amount: 2500 },
+      { accountId: toId, amount: -2500 },
+    ]);
+
+    const revert = await transactionAPI.revertTransactionRevision(tid, 1);
+    expect(revert.status).toBe(200);
+    expect(revert.data!.account.id).toBe(fromId);
+
+    const fromAfter = await accountAPI.getAccount(fromId);
+    const toAfter = await accountAPI.getAccount(toId);
+    expect(fromAfter.data!.amount).toBe(-2500);
+    expect(toAfter.data!.amount).toBe(0);
+  });
+
+  test("a transfer revision moves the amount on both accounts", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+  }) => {
+    const source = await accountAPI.createAccount({
+      name: `rev-src-${Date.now()}`,
+      note: "a",
+      type: "expense",
+    });
+    const dest = await accountAPI.createAccount({
+      name: `rev-dst-${Date.now()}`,
+      note: "a",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `rev-tr-cat-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const sourceId = source.data!.id as number;
+    const destId = dest.data!.id as number;
+
+    const create = await transactionAPI.createTransaction({
+      accountId: sourceId,
+      destinationAccountId: destId,
+      amount: 1000,
+      categoryId: cat.data!.id as number,
+      date: new Date().toISOString(),
+      type: "transfer" as const,
+    });
+    const tid = create.data!.id as number;
+
+    await transactionAPI.updateTransaction(tid, { amount: 1200 });
+
+    const list = await transactionAPI.getTransactionRevisions(tid);
+    expect(list.data!.items![0].balanceDeltas).toEqual([
+      { accountId: sourceId, amount: -200 },
+      { accountId: destId, amount: 200 },
+    ]);
+
+    await transactionAPI.revertTransactionRevision(tid, 1);
+    const sourceAfter = await accountAPI.getAccount(sourceId);
+    const destAfter = await accountAPI.getAccount(destId);
+    expect(sourceAfter.data!.amount).toBe(-1000);
+    expect(destAfter.data!.amount).toBe(1000);
+  });
+
+  test("unknown revisions and other users' transactions are not found", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+    createUser,
+    request,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `rev-404-acc-${Date.now()}`,
+      note: "a",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `rev-404-cat-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const create = await transactionAPI.createTransaction({
+      accountId: acc.data!.id as number,
+      amount: 1000,
+      categoryId: cat.data!.id as number,
+      date: new Date().toISOString(),
+      type: "expense" as const,
+    });
+    const tid = create.data!.id as number;
+    await transactionAPI.updateTransaction(tid, { amount: 1100 });
+
+    const missing = await transactionAPI.revertTransactionRevision(tid, 99);
+    expect(missing.status).toBe(404);
+
+    const other = await createUser("e2e-revisions-other");
+    const otherAPI = new TransactionAPIClient(request, other.context);
+
+    const list = await otherAPI.getTransactionRevisions(tid);
+    expect(list.status).toBe(404);
+
+    const revert = await otherAPI.revertTransactionRevision(tid, 1);
+    expect(revert.status).toBe(404);
+
+    const unchanged = await transactionAPI.getTransaction(tid);
+    expect(unchanged.data!.amount).toBe(1100);
+  });
+});
@@ -0,0 +1,284 @@
+import { test, expect, type TestUser } from "@fixtures/index";
+import { AccountAPIClient } from "@fixtures/account-client";
+import { CategoryAPIClient } from "@fixtures/category-client";
+import { TagAPIClient } from "@fixtures/tag-client";
+import { TransactionAPIClient } from "@fixtures/transaction-client";
+import type { APIRequestContext } from "@playwright/test";
+
+const DAY = 24 * 60 * 60 * 1000;
+
+/**
+ * Clients for a fresh user so a search only matches transactions of the test
+ */
+function clientsFor(request: APIRequestContext, user: TestUser) {
+  return {
+    accountAPI: new AccountAPIClient(request, user.context),
+    categoryAPI: new CategoryAPIClient(request, user.context),
+    tagAPI: new TagAPIClient(request, user.context),
+    transactionAPI: new TransactionAPIClient(request, user.context),
+  };
+}
+
+async function createExpense(
+  transactionAPI: TransactionAPIClient,
+  accountId: number,
+  categoryId: number,
+  note: string,
+  daysAgo = 0,
+): Promise<number> {
+  const r = await transactionAPI.createTransaction({
+    accountId,
+    amount: 1000,
+    categoryId,
+    date: new Date(Date.now() - daysAgo * DAY).toISOString(),
+    type: "expense" as const,
+    note,
+  });
+  expect(r.status).toBe(200);
+  return r.data!.id as number;
+}
+
+async function searchIDs(
+  transactionAPI: TransactionAPIClient,
+  q: string,
+): Promise<number[]> {
+  const res = await transactionAPI.getTransactions({ q });
+  expect(res.status).toBe(200);
+  return res.data!.items!.map((t) => t.id as number);
+}
+
+test.describe("Transactions - Full-Text Search", () => {
+  test("every word of q matches as a prefix of the note", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-search-prefix");
+    const { accountAPI, categoryAPI, transactionAPI } = clientsFor(
+      request,
+      user,
+    );
+    const acc = await accountAPI.createAccount({
+      name: "Wallet",
+      note: "a",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: "Health",
+      note: "c",
+      type: "expense",
+    });
+    const accountId = acc.data!.id as number;
+    const categoryId = cat.data!.id as number;
+
+    const dentist = await createExpense(
+      transactionAPI,
+      accountId,
+      categoryId,
+      "Dentist appointment",
+    );
+    await createExpense(transactionAPI, accountId, categoryId, "Pharmacy");
+
+    expect(await searchIDs(transactionAPI, "dent")).toEqual([dentist]);
+    expect(await searchIDs(transactionAPI, "DENTIST")).toEqual([dentist]);
+    expect(await searchIDs(transactionAPI, "appo  dent")).toEqual([dentist]);
+
+    // Every word has to match, and only at the start of a word
+    expect(await searchIDs(transactionAPI, "dent pharm")).toEqual([]);
+    expect(await searchIDs(transactionAPI, "tist")).toEqual([]);
+
+    // Query syntax in the input is dropped instead of reaching Postgres
+    expect(await searchIDs(transactionAPI, "(dent):* | !")).toEqual([dentist]);
+
+    // Nothing but punctuation leaves the list unfiltered
+    expect(await searchIDs(transactionAPI, "&&")).toHaveLength(2);
+  });
+
+  test("q matches category, account and tag names", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-search-names");
+    const { accountAPI, categoryAPI, tagAPI, transactionAPI } = clientsFor(
+      request,
+      user,
+    );
+    const wallet = await accountAPI.createAccount({
+      name: "Wallet",
+      note: "a",
+      type: "expense",
+    });
+    const brokerage = await accountAPI.createAccount({
+      name: "Brokerage",
+      note: "a",
+      type: "expense",
+    });
+    const general = await categoryAPI.createCategory({
+      name: "General",
+      note: "c",
+      type: "expense",
+    });
+    const veterinary = await categoryAPI.createCategory({
+      name: "Veterinary",
+      note: "c",
+      type: "expense",
+    });
+    const holiday = await tagAPI.createTag({ name: "Holiday" });
+    const walletId = wallet.data!.id as number;
+    const generalId = general.data!.id as number;
+
+    const byCategory = await createExpense(
+      transactionAPI,
+      walletId,
+      veterinary.data!.id as number,
+      "first",
+    );
+    const byAccount = await createExpense(
+      transactionAPI,
+      brokerage.data!.id as number,
+      generalId,
+      "second",
+    );
+    const byTag = await createExpense(
+      transactionAPI,
+      walletId,
+      generalId,
+      "third",
+    );
+    await transactionAPI.addTransactionTag(byTag, holiday.data!.id as number);
+
+    expect(await searchIDs(transactionAPI, "veter")).toEqual([byCategory]);
+    expect(await searchIDs(transactionAPI, "broker")).toEqual([byAccount]);
+    expect(await searchIDs(transactionAPI, "holi")).toEqual([byTag]);
+
+    // A removed tag no longer matches
+    await transactionAPI.removeTransactionTag(
+      byTag,
+      holiday.data!.id as number,
+    );
+    expect(await searchIDs(transactionAPI, "holi")).toEqual([]);
+  });
+
+  test("sortBy=relevance ranks note matches above account name matches", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-search-relevance");
+    const { accountAPI, categoryAPI, transactionAPI } = clientsFor(
+      request,
+      user,
+    );
+    const wallet = await accountAPI.createAccount({
+      name: "Wallet",
+      note: "a",
+      type: "expense",
+    });
+    const fund = await accountAPI.createAccount({
+      name: "Coffee fund",
+      note: "a",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: "Drinks",
+      note: "c",
+      type: "expense",
+    });
+    const categoryId = cat.data!.id as number;
+
+    // The note match is older, so date order puts it last
+    const inNote = await createExpense(
+      transactionAPI,
+      wallet.data!.id as number,
+      categoryId,
+      "coffee beans",
+      3,
+    );
+    const inAccount = await createExpense(
+      transactionAPI,
+      fund.data!.id as number,
+      categoryId,
+      "refill",
+      1,
+    );
+
+    const byDate = await transactionAPI.getTransactions({ q: "coffee" });
+    expect(byDate.data!.items!.map((t) => t.id)).toEqual([inAccount, inNote]);
+
+    const byRelevance = await transactionAPI.getTransactions({
+      q: "coffee",
+      sortBy: "relevance",
+    });
+    expect(byRelevance.status).toBe(200);
+    expect(byRelevance.data!.items!.map((t) => t.id)).toEqual([
+      inNote,
+      inAccount,
+    ]);
+
+    // Ranked pages go by number only
+    const first = await transactionAPI.getTransactions({
+      q: "coffee",
+      sortBy: "relevance",
+      pageSize: 1,
+    });
+    expect(first.data!.items!.map((t) => t.id)).toEqual([inNote]);
+    expect(first.data!.nextCursor).toBeUndefined();
+
+    const dated = await transactionAPI.getTransactions({
+      q: "coffee",
+      pageSize: 1,
+    });
+    const withCursor = await transactionAPI.getTransactions({
+      q: "coffee",
+      sortBy: "relevance",
+      after: dated.data!.nextCursor,
+    });
+    expect(withCursor.status).toBe(400);
+  });
+
+  test("renaming a category or tag refreshes the search document", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-search-rename");
+    const { accountAPI, categoryAPI, tagAPI, transactionAPI } = clientsFor(
+      request,
+      user,
+    );
+    const acc = await accountAPI.createAccount({
+      name: "Wallet",
+      note: "a",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: "Groceries",
+      note: "c",
+      type: "expense",
+    });
+    const tag = await tagAPI.createTag({ name: "Weekend" });
+    const categoryId = cat.data!.id as number;
+    const tagId = tag.data!.id as number;
+
+    const tid = await createExpense(
+      transactionAPI,
+      acc.data!.id as number,
+      categoryId,
+      "market",
+    );
+    await transactionAPI.addTransactionTag(tid, tagId);
+
+    // Searched once before the renames so a cached page would show up here
+    expect(await searchIDs(transactionAPI, "groc")).toEqual([tid]);
+    expect(await searchIDs(transactionAPI, "weekend")).toEqual([tid]);
+
+    const renamedCategory = await categoryAPI.updateCategory(categoryId, {
+      name: "Supermarket",
+    });
+    expect(renamedCategory.status).toBe(200);
+    expect(await searchIDs(transactionAPI, "groc")).toEqual([]);
+    expect(await searchIDs(transactionAPI, "supermar")).toEqual([tid]);
+
+    const renamedTag = await tagAPI.updateTag(tagId, { name: "Saturday" });
+    expect(renamedTag.status).toBe(200);
+    expect(await searchIDs(transactionAPI, "weekend")).toEqual([]);
+    expect(await searchIDs(transactionAPI, "satur")).toEqual([tid]);
+  });
+});
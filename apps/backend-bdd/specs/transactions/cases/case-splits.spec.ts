@@ -0,0 +1,304 @@
+import { test, expect } from "@fixtures/index";
+
+test.describe("Transactions - Split Cases", () => {
+  test("PUT /transactions/:id/splits - lines must add up to the amount", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `split-acc-${Date.now()}`,
+      note: "splits",
+      type: "expense",
+    });
+    const catMain = await categoryAPI.createCategory({
+      name: `split-main-${Date.now()}`,
+      note: "main",
+      type: "expense",
+    });
+    const catFood = await categoryAPI.createCategory({
+      name: `split-food-${Date.now()}`,
+      note: "food",
+      type: "expense",
+    });
+    const catHome = await categoryAPI.createCategory({
+      name: `split-home-${Date.now()}`,
+      note: "home",
+      type: "expense",
+    });
+    const accountId = acc.data!.id as number;
+
+    const tx = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: catMain.data!.id as number,
+      amount: 3000,
+      date: new Date().toISOString(),
+      type: "expense",
+    });
+    expect(tx.status).toBe(200);
+    const txId = tx.data!.id as number;
+
+    const short = await transactionAPI.replaceTransactionSplits(txId, {
+      lines: [
+        { categoryId: catFood.data!.id as number, amount: 1000 },
+        { categoryId: catHome.data!.id as number, amount: 1500 },
+      ],
+    });
+    expect(short.status).toBe(400);
+
+    const over = await transactionAPI.replaceTransactionSplits(txId, {
+      lines: [
+        { categoryId: catFood.data!.id as number, amount: 1000 },
+        { categoryId: catHome.data!.id as number, amount: 2500 },
+      ],
+    });
+    expect(over.status).toBe(400);
+
+    const res = await transactionAPI.replaceTransactionSplits(txId, {
+      lines: [
+        { categoryId: catFood.data!.id as number, amount: 1000 },
+        { categoryId: catHome.data!.id as number, amount: 2000 },
+      ],
+    });
+    expect(res.status).toBe(200);
+    expect(res.data!.amount).toBe(3000);
+    expect(res.data!.splits).toHaveLength(2);
+
+    const splits = await transactionAPI.getTransactionSplits(txId);
+    expect(splits.status).toBe(200);
+    const total = (splits.data!.items ?? []).reduce(
+      (sum, line) => sum + line.amount,
+      0
+    );
+    expect(total).toBe(3000);
+
+    // Splitting moves money between categories, not out of the account
+    const after = await accountAPI.getAccount(accountId);
+    expect(after.data!.amount).toBe(-3000);
+
+    await transactionAPI.deleteTransaction(txId);
+    await accountAPI.deleteAccount(accountId);
+    await categoryAPI.deleteCategory(catMain.data!.id as number);
+    await categoryAPI.deleteCategory(catFood.data!.id as number);
+    await categoryAPI.deleteCategory(catHome.data!.id as number);
+  });
+
+  test("category summary counts each split line under its own category", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+    summaryAPI,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `split-sum-acc-${Date.now()}`,
+      note: "split summary",
+      type: "expense",
+    });
+    const catMain = await categoryAPI.createCategory({
+      name: `split-sum-main-${Date.now()}`,
+      note: "main",
+      type: "expense",
+    });
+    const catFood = await categoryAPI.createCategory({
+      name: `split-sum-food-${Date.now()}`,
+      note: "food",
+      type: "expense",
+    });
+    const catHome = await categoryAPI.createCategory({
+      name: `split-sum-home-${Date.now()}`,
+      note: "home",
+      type: "expense",
+    });
+
+    const tx = await transactionAPI.createTransaction({
+      accountId: acc.data!.id as number,
+      categoryId: catMain.data!.id as number,
+      amount: 5000,
+      date: new Date().toISOString(),
+      type: "expense",
+    });
+    const txId = tx.data!.id as number;
+
+    const res = await transactionAPI.replaceTransactionSplits(txId, {
+      lines: [
+        { categoryId: catFood.data!.id as number, amount: 1200 },
+        { categoryId: catHome.data!.id as number, amount: 3800 },
+      ],
+    });
+    expect(res.status).toBe(200);
+
+    const catSum = await summaryAPI.getCategorySummary({
+      startDate: new Date(Date.now() - 3600 * 1000).toISOString(),
+      endDate: new Date(Date.now() + 3600 * 1000).toISOString(),
+    });
+    expect(catSum.status).toBe(200);
+    const items = catSum.data!.data ?? [];
+    const food = items.find((c) => c.id === catFood.data!.id);
+    const home = items.find((c) => c.id === catHome.data!.id);
+    const main = items.find((c) => c.id === catMain.data!.id);
+    expect(Number(food!.expenseAmount)).toBe(1200);
+    expect(Number(home!.expenseAmount)).toBe(3800);
+    expect(Number(main?.expenseAmount ?? 0)).toBe(0);
+
+    await transactionAPI.deleteTransaction(txId);
+    await accountAPI.deleteAccount(acc.data!.id as number);
+    await categoryAPI.deleteCategory(catMain.data!.id as number);
+    await categoryAPI.deleteCategory(catFood.data!.id as number);
+    await categoryAPI.deleteCategory(catHome.data!.id as number);
+  });
+
+  test("split transaction amount only changes together with its lines", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `split-amount-acc-${Date.now()}`,
+      note: "split amount",
+      type: "expense",
+    });
+    const catFood = await categoryAPI.createCategory({
+      name: `split-amount-food-${Date.now()}`,
+      note: "food",
+      type: "expense",
+    });
+    const catHome = await categoryAPI.createCategory({
+      name: `split-amount-home-${Date.now()}`,
+      note: "home",
+      type: "expense",
+    });
+
+    const tx = await transactionAPI.createTransaction({
+      accountId: acc.data!.id as number,
+      categoryId: catFood.data!.id as number,
+      amount: 2000,
+      date: new Date().toISOString(),
+      type: "expense",
+    });
+    const txId = tx.data!.id as number;
+
+    await transactionAPI.replaceTransactionSplits(txId, {
+      lines: [
+        { categoryId: catFood.data!.id as number, amount: 500 },
+        { categoryId: catHome.data!.id as number, amount: 1500 },
+      ],
+    });
+
+    const update = await transactionAPI.updateTransaction(txId, {
+      amount: 2500,
+    });
+    expect(update.status).toBe(400);
+
+    // Removing the lines makes the amount editable again
+    const cleared = await transactionAPI.replaceTransactionSplits(txId, {
+      lines: [],
+    });
+    expect(cleared.status).toBe(200);
+    expect(cleared.data!.splits ?? []).toHaveLength(0);
+
+    const retry = await transactionAPI.updateTransaction(txId, {
+      amount: 2500,
+    });
+    expect(retry.status).toBe(200);
+
+    await transactionAPI.deleteTransaction(txId);
+    await accountAPI.deleteAccount(acc.data!.id as number);
+    await categoryAPI.deleteCategory(catFood.data!.id as number);
+    await categoryAPI.deleteCategory(catHome.data!.id as number);
+  });
+
+  test("PUT /transactions/:id/splits - transfers cannot be split", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+  }) => {
+    const a1 = await accountAPI.createAccount({
+      name: `split-tr-a1-${Date.now()}`,
+      note: "a1",
+      type: "expense",
+    });
+    const a2 = await accountAPI.createAccount({
+      name: `split-tr-a2-${Date.now()}`,
+      note: "a2",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `split-tr-cat-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+
+    const tx = await transactionAPI.createTransaction({
+      accountId: a1.data!.id as number,
+      destinationAccountId: a2.data!.id as number,
+      categoryId: cat.data!.id as number,
+      amount: 1000,
+      date: new Date().toISOString(),
+      type: "transfer",
+    });
+    expect(tx.status).toBe(200);
+
+    const res = await transactionAPI.replaceTransactionSplits(
+      tx.data!.id as number,
+      { lines: [{ categoryId: cat.data!.id as number, amount: 1000 }] }
+    );
+    expect(res.status).toBe(400);
+
+    await transactionAPI.deleteTransaction(tx.data!.id as number);
+    await accountAPI.deleteAccount(a1.data!.id as number);
+    await accountAPI.deleteAccount(a2.data!.id as number);
+    await categoryAPI.deleteCategory(cat.data!.id as number);
+  });
+
+  test("PUT /transactions/:id/splits - stale If-Match returns 412", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `split-etag-acc-${Date.now()}`,
+      note: "split etag",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `split-etag-cat-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const tx = await transactionAPI.createTransaction({
+      accountId: acc.data!.id as number,
+      categoryId: cat.data!.id as number,
+      amount: 1000,
+      date: new Date().toISOString(),
+      type: "expense",
+    });
+    const txId = tx.data!.id as number;
+    const staleETag = tx.data!.etag!;
+
+    const changed = await transactionAPI.updateTransaction(txId, {
+      note: "changed elsewhere",
+    });
+    expect(changed.status).toBe(200);
+
+    const lines = [{ categoryId: cat.data!.id as number, amount: 1000 }];
+    const stale = await transactionAPI.replaceTransactionSplits(
+      txId,
+      { lines },
+      staleETag
+    );
+    expect(stale.status).toBe(412);
+
+    const fresh = await transactionAPI.replaceTransactionSplits(
+      txId,
+      { lines },
+      changed.data!.etag!
+    );
+    expect(fresh.status).toBe(200);
+    expect(fresh.headers["etag"]).toBeTruthy();
+    expect(fresh.headers["etag"]).not.toBe(changed.data!.etag);
+
+    await transactionAPI.deleteTransaction(txId);
+    await accountAPI.deleteAccount(acc.data!.id as number);
+    await categoryAPI.deleteCategory(cat.data!.id as number);
+  });
+});
@@ -0,0 +1,242 @@
+import { test, expect } from "@fixtures/index";
+
+test.describe("Transactions - Transfer Fee and Destination Amount Cases", () => {
+  test("transfer with a fee and destination amount adjusts both balances", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+  }) => {
+    const source = await accountAPI.createAccount({
+      name: `fee-src-${Date.now()}`,
+      note: "source",
+      type: "expense",
+    });
+    const dest = await accountAPI.createAccount({
+      name: `fee-dst-${Date.now()}`,
+      note: "destination",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `fee-transfer-cat-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const feeCat = await categoryAPI.createCategory({
+      name: `fee-cat-${Date.now()}`,
+      note: "bank fees",
+      type: "expense",
+    });
+    const sourceId = source.data!.id as number;
+    const destId = dest.data!.id as number;
+
+    const res = await transactionAPI.createTransaction({
+      accountId: sourceId,
+      destinationAccountId: destId,
+      categoryId: cat.data!.id as number,
+      amount: 10000,
+      destinationAmount: 9000,
+      feeAmount: 250,
+      feeCategoryId: feeCat.data!.id as number,
+      date: new Date().toISOString(),
+      type: "transfer",
+    });
+    expect(res.status).toBe(200);
+    expect(res.data!.destinationAmount).toBe(9000);
+    expect(res.data!.fee!.amount).toBe(250);
+    expect(res.data!.fee!.categoryId).toBe(feeCat.data!.id);
+    const transferId = res.data!.id as number;
+    const feeId = res.data!.fee!.id as number;
+
+    // The fee is an expense of its own on the source account, linked to the transfer
+    const fee = await transactionAPI.getTransaction(feeId);
+    expect(fee.status).toBe(200);
+    expect(fee.data!.type).toBe("expense");
+    expect(fee.data!.account.id).toBe(sourceId);
+    expect(fee.data!.amount).toBe(250);
+    const feeRelations = await transactionAPI.getTransactionRelations(feeId);
+    expect(feeRelations.data!.items ?? []).toHaveLength(1);
+    expect(feeRelations.data!.items![0].relationType).toBe("fee_of");
+    expect(feeRelations.data!.items![0].relatedTransactionId).toBe(transferId);
+
+    const sourceAfter = await accountAPI.getAccount(sourceId);
+    const destAfter = await accountAPI.getAccount(destId);
+    expect(sourceAfter.data!.amount).toBe(-10250);
+    expect(destAfter.data!.amount).toBe(9000);
+
+    // Deleting the transfer takes the fee with it
+    const del = await transactionAPI.deleteTransaction(transferId);
+    expect(del.status).toBe(204);
+    const feeGone = await transactionAPI.getTransaction(feeId);
+    expect(feeGone.status).toBe(404);
+    const sourceDeleted = await accountAPI.getAccount(sourceId);
+    const destDeleted = await accountAPI.getAccount(destId);
+    expect(sourceDeleted.data!.amount).toBe(0);
+    expect(destDeleted.data!.amount).toBe(0);
+
+    // Restoring brings the fee back too
+    const restored = await transactionAPI.restoreTransaction(transferId);
+    expect(restored.status).toBe(200);
+    const feeRestored = await transactionAPI.getTransaction(feeId);
+    expect(feeRestored.status).toBe(200);
+    const sourceRestored = await accountAPI.getAccount(sourceId);
+    const destRestored = await accountAPI.getAccount(destId);
+    expect(sourceRestored.data!.amount).toBe(-10250);
+    expect(destRestored.data!.amount).toBe(9000);
+
+    await transactionAPI.deleteTransaction(transferId);
+    await accountAPI.deleteAccount(sourceId);
+    await accountAPI.deleteAccount(destId);
+    await categoryAPI.deleteCategory(cat.data!.id as number);
+    await categoryAPI.deleteCategory(feeCat.data!.id as number);
+  });
+
+  test("updating the fee and destination amount moves the balances", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+  }) => {
+    const source = await accountAPI.createAccount({
+      name: `fee-upd-src-${Date.now()}`,
+      note: "source",
+      type: "expense",
+    });
+    const dest = await accountAPI.createAccount({
+      name: `fee-upd-dst-${Date.now()}`,
+      note: "destination",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `fee-upd-cat-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const feeCat = await categoryAPI.createCategory({
+      name: `fee-upd-fee-${Date.now()}`,
+      note: "bank fees",
+      type: "expense",
+    });
+    const sourceId = source.data!.id as number;
+    const destId = dest.data!.id as number;
+
+    const res = await transactionAPI.createTransaction({
+      accountId: sourceId,
+      destinationAccountId: destId,
+      categoryId: cat.data!.id as number,
+      amount: 10000,
+      date: new Date().toISOString(),
+      type: "transfer",
+    });
+    const transferId = res.data!.id as number;
+    expect(res.data!.fee).toBeFalsy();
+
+    const added = await transactionAPI.updateTransaction(transferId, {
+      destinationAmount: 9500,
+      feeAmount: 400,
+      feeCategoryId: feeCat.data!.id as number,
+    });
+    expect(added.status).toBe(200);
+    const feeId = added.data!.fee!.id as number;
+    let sourceAfter = await accountAPI.getAccount(sourceId);
+    let destAfter = await accountAPI.getAccount(destId);
+    expect(sourceAfter.data!.amount).toBe(-10400);
+    expect(destAfter.data!.amount).toBe(9500);
+
+    // 0 credits the amount again and removes the fee
+    const cleared = await transactionAPI.updateTransaction(transferId, {
+      destinationAmount: 0,
+      feeAmount: 0,
+    });
+    expect(cleared.status).toBe(200);
+    expect(cleared.data!.fee).toBeFalsy();
+    const feeGone = await transactionAPI.getTransaction(feeId);
+    expect(feeGone.status).toBe(404);
+    sourceAfter = await accountAPI.getAccount(sourceId);
+    destAfter = await accountAPI.getAccount(destId);
+    expect(sourceAfter.data!.amount).toBe(-10000);
+    expect(destAfter.data!.amount).toBe(10000);
+
+    // Bulk commit goes through the same fee handling
+    const draft = await transactionAPI.saveBulkDraft({
+      updates: [
+        {
+          id: transferId,
+          destinationAmount: 8000,
+          feeAmount: 150,
+          feeCategoryId: feeCat.data!.id as number,
+        },
+      ],
+    });
+    expect(draft.status).toBe(200);
+    const commit = await transactionAPI.commitBulkDraft();
+    expect(commit.status).toBe(200);
+    const bulked = await transactionAPI.getTransaction(transferId);
+    expect(bulked.data!.destinationAmount).toBe(8000);
+    expect(bulked.data!.fee!.amount).toBe(150);
+    sourceAfter = await accountAPI.getAccount(sourceId);
+    destAfter = await accountAPI.getAccount(destId);
+    expect(sourceAfter.data!.amount).toBe(-10150);
+    expect(destAfter.data!.amount).toBe(8000);
+
+    await transactionAPI.deleteTransaction(transferId);
+    await accountAPI.deleteAccount(sourceId);
+    await accountAPI.deleteAccount(destId);
+    await categoryAPI.deleteCategory(cat.data!.id as number);
+    await categoryAPI.deleteCategory(feeCat.data!.id as number);
+  });
+
+  test("fee fields are rejected outside transfers and without a fee category", async ({
+    transactionAPI,
+    accountAPI,
+    categoryAPI,
+  }) => {
+    const source = await accountAPI.createAccount({
+      name: `fee-bad-src-${Date.now()}`,
+      note: "source",
+      type: "expense",
+    });
+    const dest = await accountAPI.createAccount({
+      name: `fee-bad-dst-${Date.now()}`,
+      note: "destination",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `fee-bad-cat-${Date.now()}`,
+      note: "c",
+      type: "expense",
+    });
+    const sourceId = source.data!.id as number;
+    const destId = dest.data!.id as number;
+
+    const onExpense = await transactionAPI.createTransaction({
+      accountId: sourceId,
+      categoryId: cat.data!.id as number,
+      amount: 1000,
+      feeAmount: 100,
+      feeCategoryId: cat.data!.id as number,
+      date: new Date().toISOString(),
+      type: "expense",
+    });
+    expect(onExpense.status).toBe(400);
+
+    const noCategory = await transactionAPI.createTransaction({
+      accountId: sourceId,
+      destinationAccountId: destId,
+      categoryId: cat.data!.id as number,
+      amount: 1000,
+      feeAmount: 100,
+      date: new Date().toISOString(),
+      type: "transfer",
+    });
+    expect(noCategory.status).toBe(400);
+
+    // Nothing was recorded by the rejected requests
+    const sourceAfter = await accountAPI.getAccount(sourceId);
+    const destAfter = await accountAPI.getAccount(destId);
+    expect(sourceAfter.data!.amount).toBe(0);
+    expect(destAfter.data!.amount).toBe(0);
+
+    await accountAPI.deleteAccount(sourceId);
+    await accountAPI.deleteAccount(destId);
+    await categoryAPI.deleteCategory(cat.data!.id as number);
+  });
+});
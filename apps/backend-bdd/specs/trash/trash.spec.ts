@@ -0,0 +1,125 @@
+import { test, expect } from "@fixtures/index";
+import { AccountAPIClient } from "@fixtures/account-client";
+import { CategoryAPIClient } from "@fixtures/category-client";
+import { TransactionAPIClient } from "@fixtures/transaction-client";
+import { TrashAPIClient } from "@fixtures/trash-client";
+
+// Purging runs from the worker once the retention period has passed, so these specs cover what
+// the API exposes before that: the trash listing, its purge dates and restoring. The purge guards
+// themselves are covered by the repository tests against a real database.
+test.describe("Trash - Common", () => {
+  test("GET /trash - lists deleted records with their purge date", async ({
+    createUser,
+    request,
+  }) => {
+    // A fresh user keeps the trash free of records deleted by other specs
+    const user = await createUser("e2e-trash-list");
+    const accountAPI = new AccountAPIClient(request, user.context);
+    const categoryAPI = new CategoryAPIClient(request, user.context);
+    const transactionAPI = new TransactionAPIClient(request, user.context);
+    const trashAPI = new TrashAPIClient(request, user.context);
+
+    const acc = await accountAPI.createAccount({
+      name: `trash-list-acc-${Date.now()}`,
+      note: "trash",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `trash-list-cat-${Date.now()}`,
+      note: "trash",
+      type: "expense",
+    });
+    const tx = await transactionAPI.createTransaction({
+      accountId: acc.data!.id as number,
+      categoryId: cat.data!.id as number,
+      amount: 1000,
+      date: new Date().toISOString(),
+      type: "expense",
+    });
+
+    const empty = await trashAPI.getTrash();
+    expect(empty.status).toBe(200);
+    expect(empty.data!.items ?? []).toHaveLength(0);
+
+    await transactionAPI.deleteTransaction(tx.data!.id as number);
+    await categoryAPI.deleteCategory(cat.data!.id as number);
+
+    const res = await trashAPI.getTrash();
+    expect(res.status).toBe(200);
+    const items = res.data!.items ?? [];
+    expect(items).toHaveLength(2);
+
+    const trashedTx = items.find((i) => i.entityType === "transaction");
+    expect(trashedTx!.id).toBe(tx.data!.id);
+    expect(trashedTx!.amount).toBe(1000);
+    const trashedCat = items.find((i) => i.entityType === "category");
+    expect(trashedCat!.id).toBe(cat.data!.id);
+
+    for (const item of items) {
+      const retentionDays =
+        (new Date(item.purgeAt).getTime() -
+          new Date(item.deletedAt).getTime()) /
+        (24 * 3600 * 1000);
+      expect(retentionDays).toBe(30);
+    }
+
+    const filtered = await trashAPI.getTrash({ entityType: ["category"] });
+    expect(filtered.status).toBe(200);
+    expect(filtered.data!.items ?? []).toHaveLength(1);
+    expect(filtered.data!.items![0].entityType).toBe("category");
+  });
+
+  test("restoring a transaction needs its account back first and reapplies the balance", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-trash-restore");
+    const accountAPI = new AccountAPIClient(request, user.context);
+    const categoryAPI = new CategoryAPIClient(request, user.context);
+    const transactionAPI = new TransactionAPIClient(request, user.context);
+    const trashAPI = new TrashAPIClient(request, user.context);
+
+    const acc = await accountAPI.createAccount({
+      name: `trash-restore-acc-${Date.now()}`,
+      note: "trash",
+      type: "expense",
+    });
+    const cat = await categoryAPI.createCategory({
+      name: `trash-restore-cat-${Date.now()}`,
+      note: "trash",
+      type: "expense",
+    });
+    const accountId = acc.data!.id as number;
+    const tx = await transactionAPI.createTransaction({
+      accountId,
+      categoryId: cat.data!.id as number,
+      amount: 1000,
+      date: new Date().toISOString(),
+      type: "expense",
+    });
+    const txId = tx.data!.id as number;
+
+    await transactionAPI.deleteTransaction(txId);
+    const afterDelete = await accountAPI.getAccount(accountId);
+    expect(afterDelete.data!.amount).toBe(0);
+
+    await accountAPI.deleteAccount(accountId);
+
+    const blocked = await transactionAPI.restoreTransaction(txId);
+    expect(blocked.status).toBe(400);
+
+    const restoredAcc = await accountAPI.restoreAccount(accountId);
+    expect(restoredAcc.status).toBe(200);
+    expect(restoredAcc.data!.amount).toBe(0);
+
+    const restoredTx = await transactionAPI.restoreTransaction(txId);
+    expect(restoredTx.status).toBe(200);
+    expect(restoredTx.data!.deletedAt).toBeFalsy();
+
+    const afterRestore = await accountAPI.getAccount(accountId);
+    expect(afterRestore.data!.amount).toBe(-1000);
+
+    const trash = await trashAPI.getTrash();
+    expect(trash.data!.items ?? []).toHaveLength(0);
+  });
+});
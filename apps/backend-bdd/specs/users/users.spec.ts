@@ -0,0 +1,79 @@
+import { test, expect } from "@fixtures/index";
+import { UserAPIClient } from "@fixtures/user-client";
+import { AccountAPIClient } from "@fixtures/account-client";
+
+test.describe("Users - Common CRUD", () => {
+  test("POST /users - admin creates a user that can log in", async ({
+    userAPI,
+    createUser,
+    request,
+  }) => {
+    const me = await userAPI.getCurrentUser();
+    expect(me.status).toBe(200);
+    expect(me.data!.isAdmin).toBe(true);
+
+    const user = await createUser("e2e-users");
+    const userAPIAsUser = new UserAPIClient(request, user.context);
+
+    const current = await userAPIAsUser.getCurrentUser();
+    expect(current.status).toBe(200);
+    expect(current.data!.id).toBe(user.id);
+    expect(current.data!.username).toBe(user.username);
+    expect(current.data!.isAdmin).toBe(false);
+  });
+
+  test("POST /users - duplicate username returns 409", async ({
+    userAPI,
+    createUser,
+  }) => {
+    const user = await createUser("e2e-users-dup");
+
+    const res = await userAPI.createUser({
+      username: user.username,
+      password: "another-password",
+    });
+    expect(res.status).toBe(409);
+  });
+
+  test("POST /users - a user that is not the admin gets 403", async ({
+    createUser,
+    request,
+  }) => {
+    const user = await createUser("e2e-users-forbidden");
+    const userAPIAsUser = new UserAPIClient(request, user.context);
+
+    const res = await userAPIAsUser.createUser({
+      username: `e2e-users-blocked-${Date.now()}`,
+      password: "e2e-user-password",
+    });
+    expect(res.status).toBe(403);
+  });
+
+  test("data of one user is not visible to another", async ({
+    accountAPI,
+    createUser,
+    request,
+  }) => {
+    const acc = await accountAPI.createAccount({
+      name: `users-scope-acc-${Date.now()}`,
+      note: "owned by admin",
+      type: "expense",
+    });
+    expect(acc.status).toBe(200);
+    const accountId = acc.data!.id as number;
+
+    const user = await createUser("e2e-users-scope");
+    const accountAPIAsUser = new AccountAPIClient(request, user.context);
+
+    const res = await accountAPIAsUser.getAccount(accountId);
+    expect(res.status).toBe(404);
+
+    const list = await accountAPIAsUser.getAccounts({ pageSize: 100 });
+    expect(list.status).toBe(200);
+    expect((list.data!.items ?? []).some((a) => a.id === accountId)).toBe(
+      false
+    );
+
+    await accountAPI.deleteAccount(accountId);
+  });
+});
@@ -48,6 +48,74 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
+    "/accounts/{accountId}/reconciliations": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List account reconciliations
+         * @description Get the statement reconciliations of an account, newest first
+         */
+        get: operations["list-account-reconciliations"];
+        put?: never;
+        /**
+         * Start account reconciliation
+         * @description Open a reconciliation against a bank statement end date and closing balance. The response shows how far the cleared balance is from the statement. An account has at most one open reconciliation
+         */
+        post: operations["create-account-reconciliation"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/accounts/{accountId}/reconciliations/{reconciliationId}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get account reconciliation
+         * @description Get a reconciliation; while it is open, the response includes the cleared balance, the difference with the statement and the pending transactions up to the statement date
+         */
+        get: operations["get-account-reconciliation"];
+        put?: never;
+        post?: never;
+        /**
+         * Discard account reconciliation
+         * @description Discard an open reconciliation; transaction statuses are kept. Completed reconciliations cannot be deleted
+         */
+        delete: operations["delete-account-reconciliation"];
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/accounts/{accountId}/reconciliations/{reconciliationId}/complete": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Complete account reconciliation
+         * @description Mark the cleared transactions up to the statement date as reconciled. Fails with 409 while the cleared balance differs from the statement balance. Reconciled transactions can no longer be changed or deleted
+         */
+        post: operations["complete-account-reconciliation"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
     "/accounts/{id}": {
         parameters: {
             query?: never;
@@ -64,18 +132,82 @@ export interface paths {
         post?: never;
         /**
          * Delete account
-         * @description Delete an account
+         * @description Delete an account. With If-Match, it only succeeds while the account still has that ETag, and fails with 412 carrying the current account otherwise
          */
         delete: operations["delete-account"];
         options?: never;
         head?: never;
         /**
          * Update account
-         * @description Update an existing account
+         * @description Update an existing account. With If-Match, it only succeeds while the account still has that ETag, and fails with 412 carrying the current account otherwise
          */
         patch: operations["update-account"];
         trace?: never;
     };
+    "/accounts/{id}/restore": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Restore account
+         * @description Bring a deleted account back from the trash, with the balance it had when it was deleted. Only the owner can restore an account
+         */
+        post: operations["restore-account"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/accounts/{id}/shares": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List account shares
+         * @description List the users a account is shared with and their roles. Only the owner can see this list
+         */
+        get: operations["list-account-shares"];
+        /**
+         * Share account
+         * @description Share a account with another user as viewer or editor, or change the role of an existing share. Viewers can read it, editors can also change it. Only the owner can manage shares
+         */
+        put: operations["share-account"];
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/accounts/{id}/shares/{userId}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        post?: never;
+        /**
+         * Unshare account
+         * @description Revoke a user's access to a account
+         */
+        delete: operations["unshare-account"];
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
     "/accounts/{id}/statistics": {
         parameters: {
             query?: never;
@@ -216,27 +348,27 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/auth/login": {
+    "/audit": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        get?: never;
-        put?: never;
         /**
-         * Login
-         * @description Authenticate and receive access and refresh tokens
+         * List audit logs
+         * @description Get a paginated, append-only history of every create, update and delete, with before/after snapshots. Includes changes made by the current user and changes made by others to data the current user owns
          */
-        post: operations["login"];
+        get: operations["list-audit-logs"];
+        put?: never;
+        post?: never;
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/auth/refresh": {
+    "/auth/2fa/disable": {
         parameters: {
             query?: never;
             header?: never;
@@ -246,133 +378,117 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * Refresh token
-         * @description Get new access token using refresh token
+         * Disable two-factor authentication
+         * @description Turn off two-factor authentication after confirming a TOTP or recovery code
          */
-        post: operations["refresh"];
+        post: operations["disable-two-factor"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/budgets": {
+    "/auth/2fa/enroll": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        /**
-         * List budget templates
-         * @description Get a paginated list of budget templates
-         */
-        get: operations["list-budget-templates"];
+        get?: never;
         put?: never;
         /**
-         * Create budget template
-         * @description Create a new budget template
+         * Start two-factor enrollment
+         * @description Generate a TOTP secret and provisioning URI. Two-factor authentication is enabled once a code is verified
          */
-        post: operations["create-budget-template"];
+        post: operations["enroll-two-factor"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/budgets/{id}": {
+    "/auth/2fa/recovery-codes": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
+        put?: never;
         /**
-         * Get budget template
-         * @description Get a single budget template by ID
+         * Regenerate recovery codes
+         * @description Replace all recovery codes after confirming a TOTP or recovery code
          */
-        get: operations["get-budget-template"];
-        put?: never;
-        post?: never;
+        post: operations["regenerate-two-factor-recovery-codes"];
         delete?: never;
         options?: never;
         head?: never;
-        /**
-         * Update budget template
-         * @description Update an existing budget template (name, note, active status only)
-         */
-        patch: operations["update-budget-template"];
+        patch?: never;
         trace?: never;
     };
-    "/budgets/{id}/list": {
+    "/auth/2fa/verify": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
+        put?: never;
         /**
-         * Get budget template related budgets
-         * @description Get budgets generated from a budget template with pagination
+         * Verify two-factor enrollment
+         * @description Confirm the pending secret with a code from the authenticator app, enable two-factor authentication and receive recovery codes
          */
-        get: operations["list-budget-template-related-budgets"];
-        put?: never;
-        post?: never;
+        post: operations["verify-two-factor"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/budgets/{template_id}/list/{budget_id}": {
+    "/auth/change-password": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
+        put?: never;
         /**
-         * Get generated budget
-         * @description Get a single budget generated from a budget template by ID
+         * Change password
+         * @description Replace the password of the current user. Every access and refresh token issued so far is revoked, including the one used for this request, so all devices have to log in again. Personal access tokens are revoked too. Wrong current passwords count towards the login throttle
          */
-        get: operations["get-generated-budget"];
-        put?: never;
-        post?: never;
+        post: operations["change-password"];
         delete?: never;
         options?: never;
         head?: never;
-        /**
-         * Update generated budget
-         * @description Update an individual budget's amount limit only (does not affect template or future budgets)
-         */
-        patch: operations["update-budget-from-template"];
+        patch?: never;
         trace?: never;
     };
-    "/categories": {
+    "/auth/login": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        /**
-         * List categories
-         * @description Get a paginated list of categories with optional search
-         */
-        get: operations["list-categories"];
+        get?: never;
         put?: never;
         /**
-         * Create category
-         * @description Create a new category
+         * Login
+         * @description Authenticate and receive access and refresh tokens, or a challenge token when two-factor authentication is enabled. Repeated failures lock the username with an exponential backoff; while locked, 429 is returned with lockedUntil in the body
          */
-        post: operations["create-category"];
+        post: operations["login"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/categories/reorder": {
+    "/auth/login/verify": {
         parameters: {
             query?: never;
             header?: never;
@@ -382,65 +498,57 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * Reorder categories
-         * @description Update display order for multiple categories
+         * Verify two-factor login
+         * @description Exchange the challenge token from login and a TOTP or recovery code for access and refresh tokens
          */
-        post: operations["reorder-categories"];
+        post: operations["login-verify"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/categories/{id}": {
+    "/auth/logout": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        /**
-         * Get category
-         * @description Get a single category by ID
-         */
-        get: operations["get-category"];
+        get?: never;
         put?: never;
-        post?: never;
         /**
-         * Delete category
-         * @description Delete a category
+         * Logout
+         * @description Revoke the current access token and optionally its refresh token
          */
-        delete: operations["delete-category"];
+        post: operations["logout"];
+        delete?: never;
         options?: never;
         head?: never;
-        /**
-         * Update category
-         * @description Update an existing category
-         */
-        patch: operations["update-category"];
+        patch?: never;
         trace?: never;
     };
-    "/categories/{id}/statistics": {
+    "/auth/logout-all": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
+        put?: never;
         /**
-         * Get comprehensive category statistics
-         * @description Returns all lifestyle spending metrics for a category including spending velocity, account distribution, average transaction size, day-of-week patterns, and budget utilization
+         * Logout everywhere
+         * @description Revoke every access and refresh token issued to the current user
          */
-        get: operations["get-category-statistics"];
-        put?: never;
-        post?: never;
+        post: operations["logout-all"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/categories/{id}/statistics/account-distribution": {
+    "/auth/oidc/authorize": {
         parameters: {
             query?: never;
             header?: never;
@@ -448,10 +556,10 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Get account distribution for category
-         * @description Returns which accounts pay for this category (donut chart data)
+         * Start OpenID Connect login
+         * @description Return the identity provider URL for an authorization code login with PKCE. After signing in, the provider redirects to the configured redirect URL with code and state, which are sent to /auth/oidc/callback. Returns 404 when no issuer is configured
          */
-        get: operations["get-account-distribution"];
+        get: operations["oidc-authorize"];
         put?: never;
         post?: never;
         delete?: never;
@@ -460,47 +568,47 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/categories/{id}/statistics/average-transaction-size": {
+    "/auth/oidc/callback": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
+        put?: never;
         /**
-         * Get average transaction size for category
-         * @description Returns typical transaction amounts, including min, max, median, and average
+         * Complete OpenID Connect login
+         * @description Exchange the code and state from the identity provider redirect for access and refresh tokens. The issuer's subject is linked to the local user whose username matches the configured claim (email by default) on first login
          */
-        get: operations["get-average-transaction-size"];
-        put?: never;
-        post?: never;
+        post: operations["oidc-callback"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/categories/{id}/statistics/budget-utilization": {
+    "/auth/refresh": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
+        put?: never;
         /**
-         * Get budget utilization for category
-         * @description Returns budget progress and remaining amounts for active budgets tied to this category
+         * Refresh token
+         * @description Exchange a refresh token for a new token pair, the used refresh token is revoked
          */
-        get: operations["get-budget-utilization"];
-        put?: never;
-        post?: never;
+        post: operations["refresh"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/categories/{id}/statistics/day-of-week-pattern": {
+    "/auth/sessions": {
         parameters: {
             query?: never;
             header?: never;
@@ -508,10 +616,10 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Get day-of-week spending pattern for category
-         * @description Returns spending patterns by day of week to show behavioral patterns
+         * List sessions
+         * @description List every device currently holding a token, with its device name, user agent, IP address and last-seen time
          */
-        get: operations["get-day-of-week-pattern"];
+        get: operations["list-sessions"];
         put?: never;
         post?: never;
         delete?: never;
@@ -520,47 +628,51 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/categories/{id}/statistics/spending-velocity": {
+    "/auth/sessions/{id}": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        /**
-         * Get category spending velocity trend
-         * @description Returns monthly spending trend over the specified period (line chart data)
-         */
-        get: operations["get-spending-velocity"];
+        get?: never;
         put?: never;
         post?: never;
-        delete?: never;
+        /**
+         * Revoke session
+         * @description End a session, immediately invalidating its access and refresh tokens, e.g. for a lost device
+         */
+        delete: operations["revoke-session"];
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/preferences/refresh-geo-cache": {
+    "/auth/tokens": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        get?: never;
+        /**
+         * List personal access tokens
+         * @description Get the active personal access tokens of the current user
+         */
+        get: operations["list-api-tokens"];
         put?: never;
         /**
-         * Refresh geolocation cache
-         * @description Triggers background refresh of geolocation cache from database. Accepts optional user location to prioritize nearby transactions.
+         * Create personal access token
+         * @description Create a long-lived scoped token for scripts and integrations. The token value is only returned once
          */
-        post: operations["refresh-geo-cache"];
+        post: operations["create-api-token"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/seed/development": {
+    "/auth/tokens/{id}": {
         parameters: {
             query?: never;
             header?: never;
@@ -569,18 +681,18 @@ export interface paths {
         };
         get?: never;
         put?: never;
+        post?: never;
         /**
-         * Seed development data
-         * @description Seed the database with development data.
+         * Revoke personal access token
+         * @description Revoke a personal access token, it stops working immediately
          */
-        post: operations["seed-development-data"];
-        delete?: never;
+        delete: operations["revoke-api-token"];
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/summary/accounts": {
+    "/budgets": {
         parameters: {
             query?: never;
             header?: never;
@@ -588,19 +700,23 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Get account summary
-         * @description Returns transaction summary grouped by account
+         * List budget templates
+         * @description Get a paginated list of budget templates
          */
-        get: operations["get-account-summary"];
+        get: operations["list-budget-templates"];
         put?: never;
-        post?: never;
+        /**
+         * Create budget template
+         * @description Create a new budget template
+         */
+        post: operations["create-budget-template"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/summary/categories": {
+    "/budgets/{id}": {
         parameters: {
             query?: never;
             header?: never;
@@ -608,19 +724,23 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Get category summary
-         * @description Returns transaction summary grouped by category
+         * Get budget template
+         * @description Get a single budget template by ID
          */
-        get: operations["get-category-summary"];
+        get: operations["get-budget-template"];
         put?: never;
         post?: never;
         delete?: never;
         options?: never;
         head?: never;
-        patch?: never;
+        /**
+         * Update budget template
+         * @description Update an existing budget template (name, note, active status only). With If-Match, it only succeeds while the template still has that ETag, and fails with 412 carrying the current template otherwise
+         */
+        patch: operations["update-budget-template"];
         trace?: never;
     };
-    "/summary/geospatial": {
+    "/budgets/{id}/list": {
         parameters: {
             query?: never;
             header?: never;
@@ -628,10 +748,10 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Get geospatial transaction summary
-         * @description Returns transaction summary aggregated by geographic grid cells within a radius
+         * Get budget template related budgets
+         * @description Get budgets generated from a budget template with pagination
          */
-        get: operations["get-geospatial-summary"];
+        get: operations["list-budget-template-related-budgets"];
         put?: never;
         post?: never;
         delete?: never;
@@ -640,7 +760,7 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/summary/transactions": {
+    "/budgets/{id}/shares": {
         parameters: {
             query?: never;
             header?: never;
@@ -648,11 +768,15 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Get transaction summary
-         * @description Returns transaction summary grouped by frequency (daily, weekly, monthly, yearly)
+         * List budget template shares
+         * @description List the users a budget template is shared with and their roles. Only the owner can see this list
          */
-        get: operations["get-transaction-summary"];
-        put?: never;
+        get: operations["list-budget-template-shares"];
+        /**
+         * Share budget template
+         * @description Share a budget template with another user as viewer or editor, or change the role of an existing share. Viewers can read it, editors can also change it. Only the owner can manage shares
+         */
+        put: operations["share-budget-template"];
         post?: never;
         delete?: never;
         options?: never;
@@ -660,31 +784,27 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/tags": {
+    "/budgets/{id}/shares/{userId}": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        /**
-         * List tags
-         * @description Get a paginated list of tags with optional search
-         */
-        get: operations["list-tags"];
+        get?: never;
         put?: never;
+        post?: never;
         /**
-         * Create tag
-         * @description Create a new tag
+         * Unshare budget template
+         * @description Revoke a user's access to a budget template
          */
-        post: operations["create-tag"];
-        delete?: never;
+        delete: operations["unshare-budget-template"];
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/tags/{id}": {
+    "/budgets/{template_id}/list/{budget_id}": {
         parameters: {
             query?: never;
             header?: never;
@@ -692,27 +812,23 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Get tag
-         * @description Get a single tag by ID
+         * Get generated budget
+         * @description Get a single budget generated from a budget template by ID
          */
-        get: operations["get-tag"];
+        get: operations["get-generated-budget"];
         put?: never;
         post?: never;
-        /**
-         * Delete tag
-         * @description Delete a tag
-         */
-        delete: operations["delete-tag"];
+        delete?: never;
         options?: never;
         head?: never;
         /**
-         * Update tag
-         * @description Update an existing tag
+         * Update generated budget
+         * @description Update an individual budget's amount limit only (does not affect template or future budgets)
          */
-        patch: operations["update-tag"];
+        patch: operations["update-budget-from-template"];
         trace?: never;
     };
-    "/transaction-templates": {
+    "/categories": {
         parameters: {
             query?: never;
             header?: never;
@@ -720,71 +836,91 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * List transaction templates
-         * @description Get a paginated list of transaction templates
+         * List categories
+         * @description Get a paginated list of categories with optional search
          */
-        get: operations["list-transaction-templates"];
+        get: operations["list-categories"];
         put?: never;
         /**
-         * Create transaction template
-         * @description Create a new transaction template for recurring/installment payments
+         * Create category
+         * @description Create a new category
          */
-        post: operations["create-transaction-template"];
+        post: operations["create-category"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/transaction-templates/{templateId}": {
+    "/categories/reorder": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
+        put?: never;
         /**
-         * Get transaction template
-         * @description Get a single transaction template by ID
+         * Reorder categories
+         * @description Update display order for multiple categories
          */
-        get: operations["get-transaction-template"];
+        post: operations["reorder-categories"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/categories/{id}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get category
+         * @description Get a single category by ID
+         */
+        get: operations["get-category"];
         put?: never;
         post?: never;
         /**
-         * Delete transaction template
-         * @description Delete a transaction template
+         * Delete category
+         * @description Delete a category
          */
-        delete: operations["delete-transaction-template"];
+        delete: operations["delete-category"];
         options?: never;
         head?: never;
         /**
-         * Update transaction template
-         * @description Update an existing transaction template
+         * Update category
+         * @description Update an existing category
          */
-        patch: operations["update-transaction-template"];
+        patch: operations["update-category"];
         trace?: never;
     };
-    "/transaction-templates/{templateId}/related": {
+    "/categories/{id}/restore": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
+        put?: never;
         /**
-         * List related transactions
-         * @description Get a paginated list of transactions related to a template
+         * Restore category
+         * @description Bring a deleted category back from the trash, placed at the end of the display order
          */
-        get: operations["list-transaction-template-related-transactions"];
-        put?: never;
-        post?: never;
+        post: operations["restore-category"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/transactions": {
+    "/categories/{id}/statistics": {
         parameters: {
             query?: never;
             header?: never;
@@ -792,23 +928,19 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * List transactions
-         * @description Get a paginated list of transactions with optional filters
+         * Get comprehensive category statistics
+         * @description Returns all lifestyle spending metrics for a category including spending velocity, account distribution, average transaction size, day-of-week patterns, and budget utilization
          */
-        get: operations["list-transactions"];
+        get: operations["get-category-statistics"];
         put?: never;
-        /**
-         * Create transaction
-         * @description Create a new transaction
-         */
-        post: operations["create-transaction"];
+        post?: never;
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/transactions/bulk/draft": {
+    "/categories/{id}/statistics/account-distribution": {
         parameters: {
             query?: never;
             header?: never;
@@ -816,47 +948,39 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Retrieve bulk transaction draft
-         * @description Retrieves saved draft from Redis to resume editing. One draft per user.
+         * Get account distribution for category
+         * @description Returns which accounts pay for this category (donut chart data)
          */
-        get: operations["get-transactions-bulk-draft"];
+        get: operations["get-account-distribution"];
         put?: never;
         post?: never;
-        /**
-         * Delete draft without committing
-         * @description Discards pending draft changes without applying them to database.
-         */
-        delete: operations["delete-transactions-bulk-draft"];
+        delete?: never;
         options?: never;
         head?: never;
-        /**
-         * Save bulk transaction updates as draft
-         * @description Saves pending transaction updates to Redis as a draft/savepoint. One draft per user - overwrites existing draft. Expires after 24 hours.
-         */
-        patch: operations["patch-transactions-bulk-draft"];
+        patch?: never;
         trace?: never;
     };
-    "/transactions/bulk/draft/commit": {
+    "/categories/{id}/statistics/average-transaction-size": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        get?: never;
-        put?: never;
         /**
-         * Commit bulk transaction updates atomically
-         * @description Applies all draft changes to database in a single transaction (all-or-nothing). Invalidates caches and deletes draft.
+         * Get average transaction size for category
+         * @description Returns typical transaction amounts, including min, max, median, and average
          */
-        post: operations["post-transactions-bulk-commit"];
+        get: operations["get-average-transaction-size"];
+        put?: never;
+        post?: never;
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/transactions/{id}": {
+    "/categories/{id}/statistics/budget-utilization": {
         parameters: {
             query?: never;
             header?: never;
@@ -864,27 +988,19 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Get transaction
-         * @description Get a single transaction by ID
+         * Get budget utilization for category
+         * @description Returns budget progress and remaining amounts for active budgets tied to this category
          */
-        get: operations["get-transaction"];
+        get: operations["get-budget-utilization"];
         put?: never;
         post?: never;
-        /**
-         * Delete transaction
-         * @description Delete a transaction
-         */
-        delete: operations["delete-transaction"];
+        delete?: never;
         options?: never;
         head?: never;
-        /**
-         * Update transaction
-         * @description Update an existing transaction
-         */
-        patch: operations["update-transaction"];
+        patch?: never;
         trace?: never;
     };
-    "/transactions/{sourceTransactionId}/relations": {
+    "/categories/{id}/statistics/day-of-week-pattern": {
         parameters: {
             query?: never;
             header?: never;
@@ -892,23 +1008,19 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * List transaction relations
-         * @description Get a paginated list of relations for a transaction
+         * Get day-of-week spending pattern for category
+         * @description Returns spending patterns by day of week to show behavioral patterns
          */
-        get: operations["list-transaction-relations"];
+        get: operations["get-day-of-week-pattern"];
         put?: never;
-        /**
-         * Create transaction relation
-         * @description Create a relation between two transactions
-         */
-        post: operations["create-transaction-relation"];
+        post?: never;
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/transactions/{sourceTransactionId}/relations/{relationId}": {
+    "/categories/{id}/statistics/spending-velocity": {
         parameters: {
             query?: never;
             header?: never;
@@ -916,23 +1028,19 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Get transaction relation
-         * @description Get a specific relation between transactions
+         * Get category spending velocity trend
+         * @description Returns monthly spending trend over the specified period (line chart data)
          */
-        get: operations["get-transaction-relation"];
+        get: operations["get-spending-velocity"];
         put?: never;
         post?: never;
-        /**
-         * Delete transaction relation
-         * @description Delete a relation between transactions
-         */
-        delete: operations["delete-transaction-relation"];
+        delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/transactions/{transactionId}/tags": {
+    "/payees": {
         parameters: {
             query?: never;
             header?: never;
@@ -940,23 +1048,23 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * List transaction tags
-         * @description Get a paginated list of tags for a transaction
+         * List payees
+         * @description Get a paginated list of payees, searching by name or alias
          */
-        get: operations["list-transaction-tags"];
+        get: operations["list-payees"];
         put?: never;
         /**
-         * Add tag to transaction
-         * @description Add a tag to a transaction
+         * Create payee
+         * @description Create a new payee
          */
-        post: operations["create-transaction-tag"];
+        post: operations["create-payee"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/transactions/{transactionId}/tags/{tagId}": {
+    "/payees/{id}": {
         parameters: {
             query?: never;
             header?: never;
@@ -964,2338 +1072,7408 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Get transaction tag
-         * @description Get a specific tag on a transaction
+         * Get payee
+         * @description Get a single payee by ID
          */
-        get: operations["get-transaction-tag"];
+        get: operations["get-payee"];
         put?: never;
         post?: never;
         /**
-         * Remove tag from transaction
-         * @description Remove a tag from a transaction
+         * Delete payee
+         * @description Delete a payee; transactions keep pointing at it until it is purged from the trash
          */
-        delete: operations["delete-transaction-tag"];
+        delete: operations["delete-payee"];
+        options?: never;
+        head?: never;
+        /**
+         * Update payee
+         * @description Update an existing payee
+         */
+        patch: operations["update-payee"];
+        trace?: never;
+    };
+    "/payees/{id}/merge": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Merge payees
+         * @description Merge duplicate payees into this one. Their transactions and templates move to it, their names and aliases become its aliases, and they are deleted
+         */
+        post: operations["merge-payees"];
+        delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-}
-export type webhooks = Record<string, never>;
-export interface components {
-    schemas: {
-        AccountModel: {
-            /**
-             * Format: int64
-             * @description Current balance in base currency
-             */
-            amount: number;
-            /**
-             * Format: date-time
-             * @description Timestamp when archived (null if active)
-             */
-            archivedAt?: string;
-            /** @description Currently active budget for this account */
-            budget?: components["schemas"]["EmbeddedBudget"];
-            /**
-             * Format: date-time
-             * @description Creation timestamp
-             */
-            createdAt: string;
-            /**
-             * Format: date-time
-             * @description Soft delete timestamp
-             */
-            deletedAt?: string;
-            /**
-             * Format: int64
-             * @description Display order sequence
-             */
-            displayOrder: number;
-            /** @description Icon identifier */
-            icon?: string;
-            /** @description Icon color code */
-            iconColor?: string;
-            /**
-             * Format: int64
-             * @description Unique identifier
-             */
-            id: number;
-            /** @description Account name */
-            name: string;
-            /** @description Account notes */
-            note: string;
-            /**
-             * @description Account type (expense or income)
-             * @enum {string}
-             */
-            type: "expense" | "income";
-            /**
-             * Format: date-time
-             * @description Last update timestamp
-             */
-            updatedAt?: string;
+    "/payees/{id}/restore": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        AccountStatisticsBudgetHealthEntry: {
-            /**
-             * Format: int64
-             * @description Budget limit in cents
-             * @example 5000000
-             */
-            amountLimit: number;
-            /**
-             * Format: int64
-             * @description Amount spent in cents
-             * @example 3500000
-             */
-            amountSpent: number;
-            /**
-             * Format: int64
-             * @description Budget ID
-             * @example 1
-             */
-            budgetId: number;
-            /**
-             * @description Budget name
-             * @example Monthly Groceries
-             */
-            budgetName: string;
-            /**
-             * Format: int64
-             * @description Days remaining in budget period
-             * @example 10
-             */
-            daysRemaining: number;
-            /**
-             * Format: double
-             * @description Percentage of budget used
-             * @example 70
-             */
-            percentageUsed: number;
-            /**
-             * @description Period end (YYYY-MM-DD)
-             * @example 2024-01-31
-             */
-            periodEnd: string;
-            /**
-             * @description Period start (YYYY-MM-DD)
-             * @example 2024-01-01
-             */
-            periodStart: string;
-            /**
-             * @description Budget health status
-             * @example on-track
-             * @enum {string}
-             */
-            status: "on-track" | "warning" | "exceeded" | "active" | "inactive" | "past";
+        get?: never;
+        put?: never;
+        /**
+         * Restore payee
+         * @description Bring a deleted payee back from the trash
+         */
+        post: operations["restore-payee"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/preferences/refresh-geo-cache": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        AccountStatisticsBudgetHealthModel: {
-            /**
-             * Format: double
-             * @description Percentage of budgets successfully completed on track
-             * @example 80
-             */
-            achievementRate: number;
-            /** @description Currently active budgets */
-            activeBudgets: components["schemas"]["AccountStatisticsBudgetHealthEntry"][] | null;
-            /**
-             * @description Overall budget health status
-             * @example healthy
-             * @enum {string}
-             */
-            overallStatus: "healthy" | "at-risk" | "concerning";
-            /** @description Past/completed budgets */
-            pastBudgets: components["schemas"]["AccountStatisticsBudgetHealthEntry"][] | null;
-            /**
-             * Format: int64
-             * @description Total number of budgets (active and past)
-             * @example 15
-             */
-            totalBudgets: number;
+        get?: never;
+        put?: never;
+        /**
+         * Refresh geolocation cache
+         * @description Triggers background refresh of geolocation cache from database. Accepts optional user location to prioritize nearby transactions.
+         */
+        post: operations["refresh-geo-cache"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/reimbursements": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        AccountStatisticsBurnRateModel: {
-            /**
-             * @description Status relative to active budget
-             * @example at-risk
-             * @enum {string}
-             */
-            budgetLimitStatus: "within" | "at-risk" | "exceeded" | "no-budget";
-            /**
-             * Format: int64
-             * @description Average daily spending in cents
-             * @example 150000
-             */
-            dailyAverageSpend: number;
-            /**
-             * Format: int64
-             * @description Estimated days until budget limit (if active budget exists)
-             * @example 10
-             */
-            daysRemaining: number;
-            /**
-             * Format: int64
-             * @description Average monthly spending in cents
-             * @example 4500000
-             */
-            monthlyAverageSpend: number;
-            /**
-             * Format: int64
-             * @description Number of days with spending
-             * @example 28
-             */
-            spendingDays: number;
-            /**
-             * Format: int64
-             * @description Total spending in the period in cents
-             * @example 90000000
-             */
-            totalSpending: number;
-            /**
-             * Format: int64
-             * @description Average weekly spending in cents
-             * @example 1050000
-             */
-            weeklyAverageSpend: number;
+        /**
+         * List reimbursements
+         * @description Get a paginated list of expenses expected to be paid back. By default only those still owed are listed, with the total still owed across them
+         */
+        get: operations["list-reimbursements"];
+        put?: never;
+        /**
+         * Mark expense for reimbursement
+         * @description Mark an expense as expected to be paid back by a counterparty, in full or in part
+         */
+        post: operations["create-reimbursement"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/reimbursements/{id}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        AccountStatisticsCashFlowDataPoint: {
-            /**
-             * Format: int64
-             * @description Account balance at this date in cents
-             * @example 5000000
-             */
-            balance: number;
-            /**
-             * @description Date (YYYY-MM-DD)
-             * @example 2024-01-15
-             */
-            date: string;
+        /**
+         * Get reimbursement
+         * @description Get a single reimbursement by ID with the income matched against it
+         */
+        get: operations["get-reimbursement"];
+        put?: never;
+        post?: never;
+        /**
+         * Unmark expense for reimbursement
+         * @description Delete a reimbursement and its matches; the expense counts as plain spending again and the matched income is free to match other reimbursements
+         */
+        delete: operations["delete-reimbursement"];
+        options?: never;
+        head?: never;
+        /**
+         * Update reimbursement
+         * @description Update the expected amount, counterparty or notes of a reimbursement. The expected amount cannot drop below what is already paid back
+         */
+        patch: operations["update-reimbursement"];
+        trace?: never;
+    };
+    "/reimbursements/{id}/matches": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        AccountStatisticsCashFlowPulseModel: {
-            /** @description Daily balance data points */
-            data: components["schemas"]["AccountStatisticsCashFlowDataPoint"][] | null;
-            /**
-             * Format: int64
-             * @description Balance at the end of the period in cents
-             * @example 5000000
-             */
-            endingBalance: number;
-            /**
-             * Format: int64
-             * @description Maximum balance during the period in cents
-             * @example 10000000
-             */
-            maxBalance: number;
-            /**
-             * Format: int64
-             * @description Minimum balance during the period in cents
-             * @example 3000000
-             */
-            minBalance: number;
-            /**
-             * Format: int64
-             * @description Balance at the start of the period in cents
-             * @example 10000000
-             */
-            startingBalance: number;
-            /**
-             * @description Overall trend direction
-             * @example decreasing
-             * @enum {string}
-             */
-            trendDirection: "increasing" | "decreasing" | "stable";
+        get?: never;
+        put?: never;
+        /**
+         * Match income to reimbursement
+         * @description Count part or all of an income transaction as paying back the expense. One income can be split across several reimbursements, but never for more than its amount
+         */
+        post: operations["match-reimbursement"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/reimbursements/{id}/matches/{matchId}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        AccountStatisticsCategoryHeatmapEntry: {
-            /**
-             * Format: int64
-             * @description Category ID
-             * @example 1
-             */
-            categoryId: number;
-            /**
-             * @description Category name
-             * @example Food
-             */
-            categoryName: string;
-            /**
-             * Format: double
-             * @description Percentage of total spending
-             * @example 15.5
-             */
-            percentageOfTotal: number;
-            /**
-             * Format: int64
-             * @description Total spending amount in cents
-             * @example 1500000
-             */
-            totalAmount: number;
-            /**
-             * Format: int64
-             * @description Total number of transactions
-             * @example 25
-             */
-            totalCount: number;
+        get?: never;
+        put?: never;
+        post?: never;
+        /**
+         * Unmatch income from reimbursement
+         * @description Remove a match, so that part of the expense is owed again
+         */
+        delete: operations["unmatch-reimbursement"];
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/seed/development": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        AccountStatisticsCategoryHeatmapModel: {
-            /**
-             * Format: int64
-             * @description Number of categories with transactions
-             * @example 10
-             */
-            categoryCount: number;
-            /** @description Category spending data */
-            data: components["schemas"]["AccountStatisticsCategoryHeatmapEntry"][] | null;
-            /**
-             * Format: int64
-             * @description Total spending in the period
-             * @example 9680000
-             */
-            totalSpending: number;
+        get?: never;
+        put?: never;
+        /**
+         * Seed development data
+         * @description Seed the database with development data.
+         */
+        post: operations["seed-development-data"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/summary/accounts": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        AccountStatisticsMonthlyVelocityEntry: {
-            /**
-             * Format: int64
-             * @description Average daily spending in cents
-             * @example 112903
-             */
-            dailyAverage: number;
-            /**
-             * Format: int64
-             * @description Total expense amount in cents
-             * @example 3500000
-             */
-            expenseAmount: number;
-            /**
-             * Format: int64
-             * @description Expense transactions
-             * @example 95
-             */
-            expenseCount: number;
-            /**
-             * Format: int64
-             * @description Total income amount in cents
-             * @example 5000000
-             */
-            incomeAmount: number;
-            /**
-             * Format: int64
-             * @description Income transactions
-             * @example 50
-             */
-            incomeCount: number;
-            /**
-             * Format: int64
-             * @description Net amount (income - expense) in cents
-             * @example 1500000
-             */
-            net: number;
-            /**
-             * @description Month period (YYYY-MM)
-             * @example 2024-01
-             */
-            period: string;
-            /**
-             * Format: int64
-             * @description Total transactions in month
-             * @example 150
-             */
-            totalCount: number;
-            /**
-             * Format: int64
-             * @description Total transfer amount in cents
-             * @example 500000
-             */
-            transferAmount: number;
-            /**
-             * Format: int64
-             * @description Transfer transactions
-             * @example 5
-             */
-            transferCount: number;
+        /**
+         * Get account summary
+         * @description Returns transaction summary grouped by account
+         */
+        get: operations["get-account-summary"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/summary/categories": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        AccountStatisticsMonthlyVelocityModel: {
-            /**
-             * Format: int64
-             * @description Average monthly spending in cents
-             * @example 3500000
-             */
-            averageMonthlySpend: number;
-            /** @description Monthly velocity data */
-            data: components["schemas"]["AccountStatisticsMonthlyVelocityEntry"][] | null;
-            /**
-             * @description Spending trend direction
-             * @example increasing
-             * @enum {string}
-             */
-            trendDirection: "increasing" | "decreasing" | "stable";
+        /**
+         * Get category summary
+         * @description Returns transaction summary grouped by category
+         */
+        get: operations["get-category-summary"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/summary/geospatial": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        AccountStatisticsResponse: {
-            /**
-             * Format: int64
-             * @description Account ID
-             * @example 1
-             */
-            accountId: number;
-            /** @description Budget health metrics */
-            budgetHealth: components["schemas"]["AccountStatisticsBudgetHealthModel"];
-            /** @description Spending rate analysis */
-            burnRate: components["schemas"]["AccountStatisticsBurnRateModel"];
-            /** @description Daily balance trend over time */
-            cashFlowPulse: components["schemas"]["AccountStatisticsCashFlowPulseModel"];
-            /** @description Category spending distribution */
-            categoryHeatmap: components["schemas"]["AccountStatisticsCategoryHeatmapModel"];
-            /** @description Monthly spending velocity */
-            monthlyVelocity: components["schemas"]["AccountStatisticsMonthlyVelocityModel"];
-            /**
-             * @description Period covered (ISO 8601)
-             * @example 2024-01-01T00:00:00Z to 2024-12-31T23:59:59Z
-             */
-            period: string;
-            /** @description Transaction frequency distribution */
-            timeFrequencyHeatmap: components["schemas"]["AccountStatisticsTimeFrequencyHeatmapModel"];
+        /**
+         * Get geospatial transaction summary
+         * @description Returns transaction summary aggregated by geographic grid cells within a radius
+         */
+        get: operations["get-geospatial-summary"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/summary/payees": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        AccountStatisticsTimeFrequencyEntry: {
-            /**
-             * Format: int64
-             * @description Number of transactions at this frequency
-             * @example 45
-             */
-            count: number;
-            /**
-             * @description Time frequency (e.g., daily, weekly, monthly)
-             * @example weekly
-             */
-            frequency: string;
+        /**
+         * Get payee summary
+         * @description Returns transaction summary grouped by payee
+         */
+        get: operations["get-payee-summary"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/summary/transactions": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        AccountStatisticsTimeFrequencyHeatmapModel: {
-            /** @description Frequency distribution data */
-            data: components["schemas"]["AccountStatisticsTimeFrequencyEntry"][] | null;
-            /**
-             * @description Most common transaction frequency
-             * @example weekly
-             */
-            mostCommonPattern: string;
-            /**
-             * Format: int64
-             * @description Total transactions in period
-             * @example 240
-             */
-            totalTransactions: number;
+        /**
+         * Get transaction summary
+         * @description Returns transaction summary grouped by frequency (daily, weekly, monthly, yearly)
+         */
+        get: operations["get-transaction-summary"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/tags": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        AccountsPagedModel: {
-            /** @description List of accounts */
-            items: components["schemas"]["AccountModel"][] | null;
-            /**
-             * Format: int64
-             * @description Current page number
-             */
-            pageNumber: number;
-            /**
-             * Format: int64
-             * @description Items per page
-             */
-            pageSize: number;
-            /**
-             * Format: int64
-             * @description Total number of matching items
-             */
-            totalCount: number;
-            /**
-             * Format: int64
-             * @description Total number of pages
-             */
-            totalPages: number;
+        /**
+         * List tags
+         * @description Get a paginated list of tags with optional search
+         */
+        get: operations["list-tags"];
+        put?: never;
+        /**
+         * Create tag
+         * @description Create a new tag
+         */
+        post: operations["create-tag"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/tags/{id}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        BudgetModel: {
-            /**
-             * Format: int64
-             * @description Account ID to filter transactions
-             */
-            accountId?: number;
-            /**
-             * Format: int64
-             * @description Actual spent amount in cents (computed from transactions)
-             */
-            actualAmount: number;
-            /**
-             * Format: int64
-             * @description Budget limit amount in cents
-             */
-            amountLimit: number;
-            /**
-             * Format: int64
-             * @description Category ID to filter transactions
-             */
-            categoryId?: number;
-            /**
-             * Format: date-time
-             * @description Creation timestamp
-             */
-            createdAt: string;
-            /**
-             * Format: date-time
-             * @description Soft delete timestamp
-             */
-            deletedAt?: string;
-            /**
-             * Format: int64
-             * @description Unique identifier
-             */
-            id: number;
-            /** @description Budget name */
-            name: string;
-            /** @description Optional note for the budget */
-            note: string | null;
-            /**
-             * Format: date-time
-             * @description Budget period end date
-             */
-            periodEnd: string;
-            /**
-             * Format: date-time
-             * @description Budget period start date
-             */
-            periodStart: string;
-            /**
-             * @description Budget period type
-             * @enum {string}
-             */
-            periodType: "weekly" | "monthly" | "yearly" | "custom";
-            /**
-             * @description Budget status (active or inactive)
-             * @enum {string}
-             */
-            status: "active" | "inactive";
-            /**
-             * Format: int64
-             * @description Budget template ID if generated from template
-             */
-            templateId?: number;
-            /**
-             * Format: date-time
-             * @description Last update timestamp
-             */
-            updatedAt?: string;
+        /**
+         * Get tag
+         * @description Get a single tag by ID
+         */
+        get: operations["get-tag"];
+        put?: never;
+        post?: never;
+        /**
+         * Delete tag
+         * @description Delete a tag
+         */
+        delete: operations["delete-tag"];
+        options?: never;
+        head?: never;
+        /**
+         * Update tag
+         * @description Update an existing tag
+         */
+        patch: operations["update-tag"];
+        trace?: never;
+    };
+    "/tags/{id}/restore": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        BudgetTemplateModel: {
-            /**
-             * Format: int64
-             * @description Account ID to filter transactions
-             */
-            accountId?: number;
-            /** @description Whether this template is active and generating budgets */
-            active: boolean;
-            /**
-             * Format: int64
-             * @description Budget limit amount in cents
-             */
-            amountLimit: number;
-            /**
-             * Format: int64
-             * @description Category ID to filter transactions
-             */
-            categoryId?: number;
-            /**
-             * Format: date-time
-             * @description Creation timestamp
-             */
-            createdAt: string;
-            /**
-             * Format: date-time
-             * @description Soft delete timestamp
-             */
-            deletedAt?: string;
-            /**
-             * Format: date-time
-             * @description Optional end date for recurrence
-             */
-            endDate?: string;
-            /**
-             * Format: int64
-             * @description Unique identifier
-             */
-            id: number;
-            /**
-             * Format: date-time
-             * @description Timestamp of the last execution of this template
-             */
-            lastExecutedAt?: string;
-            /** @description Template name */
-            name: string;
-            /**
-             * Format: date-time
-             * @description Next run date for recurring budget creation
-             */
-            nextRunAt?: string;
-            /** @description Optional note for the template */
-            note?: string;
-            /**
-             * @description Recurrence pattern
-             * @enum {string}
-             */
-            recurrence: "none" | "weekly" | "monthly" | "yearly";
-            /**
-             * Format: date-time
-             * @description Start date for recurrence
-             */
-            startDate: string;
-            /**
-             * Format: date-time
-             * @description Last update timestamp
-             */
-            updatedAt?: string;
+        get?: never;
+        put?: never;
+        /**
+         * Restore tag
+         * @description Bring a deleted tag back from the trash together with its links to transactions
+         */
+        post: operations["restore-tag"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transaction-rules": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        BudgetTemplatesPagedModel: {
-            /** @description List of budget templates */
-            items: components["schemas"]["BudgetTemplateModel"][] | null;
-            /**
-             * Format: int64
-             * @description Current page number
-             */
-            pageNumber: number;
-            /**
-             * Format: int64
-             * @description Items per page
-             */
-            pageSize: number;
-            /**
-             * Format: int64
-             * @description Total number of matching items
-             */
-            totalCount: number;
-            /**
-             * Format: int64
-             * @description Total number of pages
-             */
-            totalPages: number;
+        /**
+         * List transaction rules
+         * @description Get a paginated list of transaction rules in the order they are evaluated
+         */
+        get: operations["list-transaction-rules"];
+        put?: never;
+        /**
+         * Create transaction rule
+         * @description Create a rule applied to new transactions that meet all of its conditions. Rules run in ascending priority; the first matching rule to set a field wins and tags of all matching rules are added
+         */
+        post: operations["create-transaction-rule"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transaction-rules/apply": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        BudgetsPagedModel: {
-            /** @description List of budgets */
-            items: components["schemas"]["BudgetModel"][] | null;
-            /**
-             * Format: int64
-             * @description Current page number
-             */
-            pageNumber: number;
-            /**
-             * Format: int64
-             * @description Items per page
-             */
-            pageSize: number;
-            /**
-             * Format: int64
-             * @description Total number of matching items
-             */
-            totalCount: number;
-            /**
-             * Format: int64
-             * @description Total number of pages
-             */
-            totalPages: number;
+        get?: never;
+        put?: never;
+        /**
+         * Re-apply rules to range
+         * @description Apply all enabled rules to the existing transactions dated within the range in one database transaction. Reconciled transactions are skipped, and each changed transaction gets a revision that can be reverted
+         */
+        post: operations["apply-transaction-rules"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transaction-rules/{id}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        BulkTransactionCommitResponseModel: {
-            /**
-             * Format: int64
-             * @description Total processing time in milliseconds
-             */
-            durationMs: number;
-            /**
-             * Format: int64
-             * @description Number of successfully updated transactions
-             */
-            successCount: number;
-            /** @description List of updated transaction IDs */
-            updatedIds: number[] | null;
+        /**
+         * Get transaction rule
+         * @description Get a single transaction rule by ID
+         */
+        get: operations["get-transaction-rule"];
+        put?: never;
+        post?: never;
+        /**
+         * Delete transaction rule
+         * @description Delete a transaction rule; transactions keep the changes it already made
+         */
+        delete: operations["delete-transaction-rule"];
+        options?: never;
+        head?: never;
+        /**
+         * Update transaction rule
+         * @description Update an existing transaction rule; conditions and actions are replaced as a whole when provided
+         */
+        patch: operations["update-transaction-rule"];
+        trace?: never;
+    };
+    "/transaction-rules/{id}/dry-run": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        BulkTransactionDraftModel: {
-            /** @description List of transaction updates (max 500) */
-            updates: components["schemas"]["BulkTransactionUpdateItemModel"][] | null;
+        /**
+         * Dry run transaction rule
+         * @description Show which existing transactions dated within the range this rule would change, and how, without changing them. Disabled rules can be tried out too
+         */
+        get: operations["dry-run-transaction-rule"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transaction-templates": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        BulkTransactionDraftResponseModel: {
-            /**
-             * Format: date-time
-             * @description Draft creation timestamp
-             */
-            createdAt: string;
-            /**
-             * Format: date-time
-             * @description Draft expiration timestamp
-             */
-            expiresAt: string;
-            /**
-             * Format: int64
-             * @description Number of transactions in draft
-             */
-            transactionCount: number;
-            /**
-             * Format: date-time
-             * @description Draft last update timestamp
-             */
-            updatedAt: string;
+        /**
+         * List transaction templates
+         * @description Get a paginated list of transaction templates
+         */
+        get: operations["list-transaction-templates"];
+        put?: never;
+        /**
+         * Create transaction template
+         * @description Create a new transaction template for recurring/installment payments
+         */
+        post: operations["create-transaction-template"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transaction-templates/{templateId}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        BulkTransactionUpdateItemModel: {
-            /**
-             * Format: int64
-             * @description Source account ID
-             */
-            accountId?: number;
-            /**
-             * Format: int64
-             * @description Transaction amount in the specified currency
-             */
-            amount?: number;
-            /**
-             * Format: int64
-             * @description Category ID
-             */
-            categoryId?: number;
-            /** @description ISO 4217 currency code (e.g., USD, EUR) */
-            currencyCode?: string;
-            /**
-             * Format: date-time
-             * @description Transaction date
-             */
-            date?: string;
-            /**
-             * Format: int64
-             * @description Destination account ID (transfers only)
-             */
-            destinationAccountId?: number;
-            /**
-             * Format: int64
-             * @description Transaction ID to update
-             */
-            id: number;
-            /**
-             * Format: double
-             * @description Transaction latitude
-             */
-            latitude?: number;
-            /**
-             * Format: double
-             * @description Transaction longitude
-             */
-            longitude?: number;
-            /** @description Transaction notes */
-            note?: string;
-            /**
-             * @description Transaction type
-             * @enum {string}
-             */
-            type?: "expense" | "income" | "transfer";
+        /**
+         * Get transaction template
+         * @description Get a single transaction template by ID
+         */
+        get: operations["get-transaction-template"];
+        put?: never;
+        post?: never;
+        /**
+         * Delete transaction template
+         * @description Delete a transaction template
+         */
+        delete: operations["delete-transaction-template"];
+        options?: never;
+        head?: never;
+        /**
+         * Update transaction template
+         * @description Update an existing transaction template
+         */
+        patch: operations["update-transaction-template"];
+        trace?: never;
+    };
+    "/transaction-templates/{templateId}/related": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        CategoriesPagedModel: {
-            /** @description List of categories */
-            items: components["schemas"]["CategoryModel"][] | null;
-            /**
-             * Format: int64
-             * @description Current page number
-             */
-            pageNumber: number;
-            /**
-             * Format: int64
-             * @description Items per page
-             */
-            pageSize: number;
-            /**
-             * Format: int64
-             * @description Total number of matching items
-             */
-            totalCount: number;
-            /**
-             * Format: int64
-             * @description Total number of pages
-             */
-            totalPages: number;
+        /**
+         * List related transactions
+         * @description Get a paginated list of transactions related to a template
+         */
+        get: operations["list-transaction-template-related-transactions"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transaction-templates/{templateId}/restore": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        CategoryModel: {
-            /**
-             * Format: date-time
-             * @description Timestamp when archived (null if active)
-             */
-            archivedAt?: string;
-            /** @description Currently active budget for this category */
-            budget?: components["schemas"]["EmbeddedBudget"];
-            /**
-             * Format: date-time
-             * @description Creation timestamp
-             */
-            createdAt: string;
-            /**
-             * Format: date-time
-             * @description Soft delete timestamp
-             */
-            deletedAt?: string;
-            /**
-             * Format: int64
-             * @description Display order sequence
-             */
-            displayOrder: number;
-            /** @description Icon identifier */
-            icon?: string;
-            /** @description Icon color code */
-            iconColor?: string;
-            /**
-             * Format: int64
-             * @description Unique identifier
-             */
-            id: number;
-            /** @description Category name */
-            name: string;
-            /** @description Category notes */
-            note: string;
-            /**
-             * @description Category type
-             * @enum {string}
-             */
-            type: "expense" | "income" | "transfer";
-            /**
-             * Format: date-time
-             * @description Last update timestamp
-             */
-            updatedAt?: string;
+        get?: never;
+        put?: never;
+        /**
+         * Restore transaction template
+         * @description Bring a deleted transaction template back from the trash; it resumes from its next due date. Only the owner can restore a template
+         */
+        post: operations["restore-transaction-template"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transaction-templates/{templateId}/shares": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        CategoryStatisticAccountDistributionEntry: {
-            /**
-             * Format: int64
-             * @description Account ID
-             * @example 1
-             */
-            accountId: number;
-            /**
-             * @description Account name
-             * @example Credit Card
-             */
-            accountName: string;
-            /**
-             * Format: int64
-             * @description Total spending from this account in cents
-             * @example 2250000
-             */
-            amount: number;
-            /**
-             * Format: double
-             * @description Percentage of total spending
-             * @example 75
-             */
-            percentage: number;
+        /**
+         * List transaction template shares
+         * @description List the users a transaction template is shared with and their roles. Only the owner can see this list
+         */
+        get: operations["list-transaction-template-shares"];
+        /**
+         * Share transaction template
+         * @description Share a transaction template with another user as viewer or editor, or change the role of an existing share. Viewers can read it, editors can also change it. Only the owner can manage shares
+         */
+        put: operations["share-transaction-template"];
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transaction-templates/{templateId}/shares/{userId}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        CategoryStatisticAccountDistributionModel: {
-            /** @description Account spending distribution */
-            accounts: components["schemas"]["CategoryStatisticAccountDistributionEntry"][] | null;
-            /**
-             * Format: int64
-             * @description Total spending in cents
-             * @example 3000000
-             */
-            totalSpending: number;
+        get?: never;
+        put?: never;
+        post?: never;
+        /**
+         * Unshare transaction template
+         * @description Revoke a user's access to a transaction template
+         */
+        delete: operations["unshare-transaction-template"];
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transactions": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        CategoryStatisticAverageTransactionSizeModel: {
-            /**
-             * Format: int64
-             * @description Average expense transaction amount in cents
-             * @example 25000
-             */
-            averageAmount: number;
-            /**
-             * Format: int64
-             * @description Average income amount in cents
-             * @example 150000
-             */
-            averageIncomeAmount: number;
-            /**
-             * Format: int64
-             * @description Expense transaction count
-             * @example 80
-             */
-            expenseCount: number;
-            /**
-             * Format: int64
-             * @description Income transaction count
-             * @example 40
-             */
-            incomeCount: number;
-            /**
-             * Format: double
-             * @description Ratio of income/expense counts
-             * @example 0.5
-             */
-            incomeToExpenseRatio: number;
-            /**
-             * Format: int64
-             * @description Maximum expense transaction amount in cents
-             * @example 500000
-             */
-            maxAmount: number;
-            /**
-             * Format: int64
-             * @description Maximum income amount in cents
-             * @example 1000000
-             */
-            maxIncomeAmount: number;
-            /**
-             * Format: int64
-             * @description Median expense transaction amount in cents
-             * @example 20000
-             */
-            medianAmount: number;
+        /**
+         * List transactions
+         * @description Get a paginated list of transactions with optional filters and full-text search
+         */
+        get: operations["list-transactions"];
+        put?: never;
+        /**
+         * Create transaction
+         * @description Create a new transaction. A transfer can credit a different destinationAmount to its destination account, and a feeAmount with feeCategoryId records the fee as an expense on the source account linked to the transfer
+         */
+        post: operations["create-transaction"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transactions/bulk/draft": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Retrieve bulk transaction draft
+         * @description Retrieves saved draft from Redis to resume editing. One draft per user.
+         */
+        get: operations["get-transactions-bulk-draft"];
+        put?: never;
+        post?: never;
+        /**
+         * Delete draft without committing
+         * @description Discards pending draft changes without applying them to database.
+         */
+        delete: operations["delete-transactions-bulk-draft"];
+        options?: never;
+        head?: never;
+        /**
+         * Save bulk transaction updates as draft
+         * @description Saves pending transaction updates to Redis as a draft/savepoint. One draft per user - overwrites existing draft. Expires after 24 hours.
+         */
+        patch: operations["patch-transactions-bulk-draft"];
+        trace?: never;
+    };
+    "/transactions/bulk/draft/commit": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Commit bulk transaction updates atomically
+         * @description Applies all draft changes to database in a single transaction (all-or-nothing). Invalidates caches and deletes draft.
+         */
+        post: operations["post-transactions-bulk-commit"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transactions/duplicates": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List possible duplicates
+         * @description Get pairs of transactions on the same account with the same type and amount, dated within three days of each other, scored by how likely they are the same transaction. Dates, note similarity and being recorded together raise the score
+         */
+        get: operations["list-transaction-duplicates"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transactions/{id}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get transaction
+         * @description Get a single transaction by ID
+         */
+        get: operations["get-transaction"];
+        put?: never;
+        post?: never;
+        /**
+         * Delete transaction
+         * @description Delete a transaction. With If-Match, it only succeeds while the transaction still has that ETag, and fails with 412 carrying the current transaction otherwise
+         */
+        delete: operations["delete-transaction"];
+        options?: never;
+        head?: never;
+        /**
+         * Update transaction
+         * @description Update an existing transaction. With If-Match, it only succeeds while the transaction still has that ETag, and fails with 412 carrying the current transaction otherwise
+         */
+        patch: operations["update-transaction"];
+        trace?: never;
+    };
+    "/transactions/{id}/graph": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get transaction graph
+         * @description Get every transaction connected to this one through relations in either direction, the relations between them, and their income, expense and net totals
+         */
+        get: operations["get-transaction-graph"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transactions/{id}/merge": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Merge duplicate transactions
+         * @description Keep this transaction and delete its duplicates, which must have the same account, type and amount (400 otherwise). Their tags, relations, attachments, split lines and reimbursements move to it, and deleting them takes their amounts and transfer fees off the account balances
+         */
+        post: operations["merge-transactions"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transactions/{id}/restore": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Restore transaction
+         * @description Bring a deleted transaction back from the trash and reapply it to the account balances, together with the attachments and transfer fee deleted along with it. Its accounts and category must not be deleted
+         */
+        post: operations["restore-transaction"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transactions/{sourceTransactionId}/relations": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List transaction relations
+         * @description Get a paginated list of relations for a transaction
+         */
+        get: operations["list-transaction-relations"];
+        put?: never;
+        /**
+         * Create transaction relation
+         * @description Create a relation between two transactions
+         */
+        post: operations["create-transaction-relation"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transactions/{sourceTransactionId}/relations/{relationId}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get transaction relation
+         * @description Get a specific relation between transactions
+         */
+        get: operations["get-transaction-relation"];
+        put?: never;
+        post?: never;
+        /**
+         * Delete transaction relation
+         * @description Delete a relation between transactions
+         */
+        delete: operations["delete-transaction-relation"];
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transactions/{transactionId}/attachments": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List transaction attachments
+         * @description Get the receipts and documents attached to a transaction
+         */
+        get: operations["list-transaction-attachments"];
+        put?: never;
+        /**
+         * Upload transaction attachment
+         * @description Attach a JPEG, PNG, GIF, WebP or PDF file of up to 10 MB to a transaction, sent as the file field of a multipart form. The type is detected from the file content, and JPEG, PNG and GIF images get a thumbnail
+         */
+        post: operations["upload-transaction-attachment"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transactions/{transactionId}/attachments/{attachmentId}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        post?: never;
+        /**
+         * Delete transaction attachment
+         * @description Remove an attachment from a transaction
+         */
+        delete: operations["delete-transaction-attachment"];
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transactions/{transactionId}/attachments/{attachmentId}/content": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Download transaction attachment
+         * @description Download the original file of an attachment
+         */
+        get: operations["download-transaction-attachment"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transactions/{transactionId}/attachments/{attachmentId}/thumbnail": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Download transaction attachment thumbnail
+         * @description Download the JPEG thumbnail of an image attachment; 404 when the attachment has none
+         */
+        get: operations["download-transaction-attachment-thumbnail"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transactions/{transactionId}/revisions": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List transaction revisions
+         * @description Get the change history of a transaction, newest first. Each revision lists the changed fields with their values before and after, and the balance change applied to each account
+         */
+        get: operations["list-transaction-revisions"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transactions/{transactionId}/revisions/{revision}/revert": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Revert transaction revision
+         * @description Set the fields changed by a revision back to their previous values. The revert goes through the regular update rules, balances included, and is recorded as a new revision
+         */
+        post: operations["revert-transaction-revision"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transactions/{transactionId}/splits": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List transaction split lines
+         * @description Get the lines dividing a transaction between categories, empty when it is not split
+         */
+        get: operations["list-transaction-splits"];
+        /**
+         * Replace transaction split lines
+         * @description Replace the split lines of an income or expense transaction. Each line has its own category, amount, note and tags, and the amounts must sum to the transaction amount. Category summaries, category statistics and budgets count the line amounts. Send an empty list to remove the split. While split, the transaction amount and type can only change after updating or removing its lines. Lines of a reconciled transaction cannot be replaced, and If-Match is checked against the transaction ETag
+         */
+        put: operations["replace-transaction-splits"];
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transactions/{transactionId}/tags": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List transaction tags
+         * @description Get a paginated list of tags for a transaction
+         */
+        get: operations["list-transaction-tags"];
+        put?: never;
+        /**
+         * Add tag to transaction
+         * @description Add a tag to a transaction
+         */
+        post: operations["create-transaction-tag"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/transactions/{transactionId}/tags/{tagId}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get transaction tag
+         * @description Get a specific tag on a transaction
+         */
+        get: operations["get-transaction-tag"];
+        put?: never;
+        post?: never;
+        /**
+         * Remove tag from transaction
+         * @description Remove a tag from a transaction
+         */
+        delete: operations["delete-transaction-tag"];
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/trash": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List trash
+         * @description Get the deleted transactions, accounts, categories, tags, payees and transaction templates that can still be restored, most recently deleted first. Each kind has its own restore endpoint; records are permanently removed once the retention period has passed
+         */
+        get: operations["list-trash"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/users": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Create user
+         * @description Create another user with its own isolated accounts, categories and transactions. Only an admin can create users
+         */
+        post: operations["create-user"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/users/me": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get current user
+         * @description Get the user the auth token was issued for
+         */
+        get: operations["get-current-user"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+}
+export type webhooks = Record<string, never>;
+export interface components {
+    schemas: {
+        APITokenModel: {
+            /**
+             * Format: date-time
+             * @description Creation timestamp
+             */
+            createdAt: string;
+            /**
+             * Format: date-time
+             * @description Expiry timestamp (null if the token never expires)
+             */
+            expiresAt?: string;
+            /**
+             * Format: int64
+             * @description Unique identifier
+             */
+            id: number;
+            /**
+             * Format: date-time
+             * @description Last time the token authenticated a request
+             */
+            lastUsedAt?: string;
+            /** @description Label to recognise the token by */
+            name: string;
+            /** @description First characters of the token, for identification only */
+            prefix: string;
+            /** @description Granted scopes */
+            scopes: string[] | null;
+        };
+        APITokensListModel: {
+            /** @description List of active personal access tokens */
+            items: components["schemas"]["APITokenModel"][] | null;
+        };
+        AccountModel: {
+            /**
+             * Format: int64
+             * @description Current balance in base currency
+             */
+            amount: number;
+            /**
+             * Format: date-time
+             * @description Timestamp when archived (null if active)
+             */
+            archivedAt?: string;
+            /** @description Currently active budget for this account */
+            budget?: components["schemas"]["EmbeddedBudget"];
+            /**
+             * Format: date-time
+             * @description Creation timestamp
+             */
+            createdAt: string;
+            /**
+             * Format: date-time
+             * @description Soft delete timestamp
+             */
+            deletedAt?: string;
+            /**
+             * Format: int64
+             * @description Display order sequence
+             */
+            displayOrder: number;
+            /** @description Version of the record, changed on every update; send it as If-Match to only change or delete the record as it was read */
+            etag?: string;
+            /** @description Icon identifier */
+            icon?: string;
+            /** @description Icon color code */
+            iconColor?: string;
+            /**
+             * Format: int64
+             * @description Unique identifier
+             */
+            id: number;
+            /** @description Account name */
+            name: string;
+            /** @description Account notes */
+            note: string;
+            /**
+             * @description Access role of the current user on this account
+             * @enum {string}
+             */
+            role: "owner" | "editor" | "viewer";
+            /** @description Users this account is shared with, empty when it is not shared */
+            sharedWith: components["schemas"]["ShareEmbedded"][] | null;
+            /**
+             * @description Account type (expense or income)
+             * @enum {string}
+             */
+            type: "expense" | "income";
+            /**
+             * Format: date-time
+             * @description Last update timestamp
+             */
+            updatedAt?: string;
+        };
+        AccountReconciliationBalanceModel: {
+            /**
+             * Format: int64
+             * @description Current account balance
+             */
+            accountBalance: number;
+            /**
+             * Format: int64
+             * @description Balance of cleared and reconciled transactions up to the statement date
+             */
+            clearedBalance: number;
+            /**
+             * Format: int64
+             * @description Number of cleared transactions up to the statement date, locked on completion
+             */
+            clearedCount: number;
+            /**
+             * Format: int64
+             * @description Statement balance minus cleared balance; must be zero to complete
+             */
+            difference: number;
+            /**
+             * Format: int64
+             * @description Net amount of pending transactions up to the statement date
+             */
+            unclearedAmount: number;
+            /**
+             * Format: int64
+             * @description Number of pending transactions up to the statement date
+             */
+            unclearedCount: number;
+        };
+        AccountReconciliationDetailModel: {
+            /**
+             * Format: int64
+             * @description Reconciled account
+             */
+            accountId: number;
+            /** @description Comparison with the statement, only while open */
+            balance?: components["schemas"]["AccountReconciliationBalanceModel"];
+            /**
+             * Format: date-time
+             * @description Completion timestamp
+             */
+            completedAt?: string;
+            /**
+             * Format: date-time
+             * @description Creation timestamp
+             */
+            createdAt: string;
+            /**
+             * Format: int64
+             * @description Unique identifier
+             */
+            id: number;
+            /**
+             * Format: int64
+             * @description Number of transactions locked on completion
+             */
+            reconciledCount: number;
+            /**
+             * Format: int64
+             * @description Closing balance on the bank statement
+             */
+            statementBalance: number;
+            /**
+             * Format: date-time
+             * @description End date of the bank statement; transactions up to this time are matched
+             */
+            statementDate: string;
+            /**
+             * @description Open while matching transactions, completed once locked
+             * @enum {string}
+             */
+            status: "open" | "completed";
+            /**
+             * Format: date-time
+             * @description Last update timestamp
+             */
+            updatedAt: string;
+        };
+        AccountReconciliationModel: {
+            /**
+             * Format: int64
+             * @description Reconciled account
+             */
+            accountId: number;
+            /**
+             * Format: date-time
+             * @description Completion timestamp
+             */
+            completedAt?: string;
+            /**
+             * Format: date-time
+             * @description Creation timestamp
+             */
+            createdAt: string;
+            /**
+             * Format: int64
+             * @description Unique identifier
+             */
+            id: number;
+            /**
+             * Format: int64
+             * @description Number of transactions locked on completion
+             */
+            reconciledCount: number;
+            /**
+             * Format: int64
+             * @description Closing balance on the bank statement
+             */
+            statementBalance: number;
+            /**
+             * Format: date-time
+             * @description End date of the bank statement; transactions up to this time are matched
+             */
+            statementDate: string;
+            /**
+             * @description Open while matching transactions, completed once locked
+             * @enum {string}
+             */
+            status: "open" | "completed";
+            /**
+             * Format: date-time
+             * @description Last update timestamp
+             */
+            updatedAt: string;
+        };
+        AccountReconciliationsPagedModel: {
+            /** @description Reconciliations of the account, newest first */
+            items: components["schemas"]["AccountReconciliationModel"][] | null;
+            /**
+             * Format: int64
+             * @description Current page number
+             */
+            pageNumber: number;
+            /**
+             * Format: int64
+             * @description Items per page
+             */
+            pageSize: number;
+            /**
+             * Format: int64
+             * @description Total number of matching items
+             */
+            totalCount: number;
+            /**
+             * Format: int64
+             * @description Total number of pages
+             */
+            totalPages: number;
+        };
+        AccountStatisticsBudgetHealthEntry: {
+            /**
+             * Format: int64
+             * @description Budget limit in cents
+             * @example 5000000
+             */
+            amountLimit: number;
+            /**
+             * Format: int64
+             * @description Amount spent in cents
+             * @example 3500000
+             */
+            amountSpent: number;
+            /**
+             * Format: int64
+             * @description Budget ID
+             * @example 1
+             */
+            budgetId: number;
+            /**
+             * @description Budget name
+             * @example Monthly Groceries
+             */
+            budgetName: string;
+            /**
+             * Format: int64
+             * @description Days remaining in budget period
+             * @example 10
+             */
+            daysRemaining: number;
+            /**
+             * Format: double
+             * @description Percentage of budget used
+             * @example 70
+             */
+            percentageUsed: number;
+            /**
+             * @description Period end (YYYY-MM-DD)
+             * @example 2024-01-31
+             */
+            periodEnd: string;
+            /**
+             * @description Period start (YYYY-MM-DD)
+             * @example 2024-01-01
+             */
+            periodStart: string;
+            /**
+             * @description Budget health status
+             * @example on-track
+             * @enum {string}
+             */
+            status: "on-track" | "warning" | "exceeded" | "active" | "inactive" | "past";
+        };
+        AccountStatisticsBudgetHealthModel: {
+            /**
+             * Format: double
+             * @description Percentage of budgets successfully completed on track
+             * @example 80
+             */
+            achievementRate: number;
+            /** @description Currently active budgets */
+            activeBudgets: components["schemas"]["AccountStatisticsBudgetHealthEntry"][] | null;
+            /**
+             * @description Overall budget health status
+             * @example healthy
+             * @enum {string}
+             */
+            overallStatus: "healthy" | "at-risk" | "concerning";
+            /** @description Past/completed budgets */
+            pastBudgets: components["schemas"]["AccountStatisticsBudgetHealthEntry"][] | null;
+            /**
+             * Format: int64
+             * @description Total number of budgets (active and past)
+             * @example 15
+             */
+            totalBudgets: number;
+        };
+        AccountStatisticsBurnRateModel: {
+            /**
+             * @description Status relative to active budget
+             * @example at-risk
+             * @enum {string}
+             */
+            budgetLimitStatus: "within" | "at-risk" | "exceeded" | "no-budget";
+            /**
+             * Format: int64
+             * @description Average daily spending in cents
+             * @example 150000
+             */
+            dailyAverageSpend: number;
+            /**
+             * Format: int64
+             * @description Estimated days until budget limit (if active budget exists)
+             * @example 10
+             */
+            daysRemaining: number;
+            /**
+             * Format: int64
+             * @description Average monthly spending in cents
+             * @example 4500000
+             */
+            monthlyAverageSpend: number;
+            /**
+             * Format: int64
+             * @description Number of days with spending
+             * @example 28
+             */
+            spendingDays: number;
+            /**
+             * Format: int64
+             * @description Total spending in the period in cents
+             * @example 90000000
+             */
+            totalSpending: number;
+            /**
+             * Format: int64
+             * @description Average weekly spending in cents
+             * @example 1050000
+             */
+            weeklyAverageSpend: number;
+        };
+        AccountStatisticsCashFlowDataPoint: {
+            /**
+             * Format: int64
+             * @description Account balance at this date in cents
+             * @example 5000000
+             */
+            balance: number;
+            /**
+             * @description Date (YYYY-MM-DD)
+             * @example 2024-01-15
+             */
+            date: string;
+        };
+        AccountStatisticsCashFlowPulseModel: {
+            /** @description Daily balance data points */
+            data: components["schemas"]["AccountStatisticsCashFlowDataPoint"][] | null;
+            /**
+             * Format: int64
+             * @description Balance at the end of the period in cents
+             * @example 5000000
+             */
+            endingBalance: number;
+            /**
+             * Format: int64
+             * @description Maximum balance during the period in cents
+             * @example 10000000
+             */
+            maxBalance: number;
+            /**
+             * Format: int64
+             * @description Minimum balance during the period in cents
+             * @example 3000000
+             */
+            minBalance: number;
+            /**
+             * Format: int64
+             * @description Balance at the start of the period in cents
+             * @example 10000000
+             */
+            startingBalance: number;
+            /**
+             * @description Overall trend direction
+             * @example decreasing
+             * @enum {string}
+             */
+            trendDirection: "increasing" | "decreasing" | "stable";
+        };
+        AccountStatisticsCategoryHeatmapEntry: {
+            /**
+             * Format: int64
+             * @description Category ID
+             * @example 1
+             */
+            categoryId: number;
+            /**
+             * @description Category name
+             * @example Food
+             */
+            categoryName: string;
+            /**
+             * Format: double
+             * @description Percentage of total spending
+             * @example 15.5
+             */
+            percentageOfTotal: number;
+            /**
+             * Format: int64
+             * @description Total spending amount in cents
+             * @example 1500000
+             */
+            totalAmount: number;
+            /**
+             * Format: int64
+             * @description Total number of transactions
+             * @example 25
+             */
+            totalCount: number;
+        };
+        AccountStatisticsCategoryHeatmapModel: {
+            /**
+             * Format: int64
+             * @description Number of categories with transactions
+             * @example 10
+             */
+            categoryCount: number;
+            /** @description Category spending data */
+            data: components["schemas"]["AccountStatisticsCategoryHeatmapEntry"][] | null;
+            /**
+             * Format: int64
+             * @description Total spending in the period
+             * @example 9680000
+             */
+            totalSpending: number;
+        };
+        AccountStatisticsMonthlyVelocityEntry: {
+            /**
+             * Format: int64
+             * @description Average daily spending in cents
+             * @example 112903
+             */
+            dailyAverage: number;
+            /**
+             * Format: int64
+             * @description Total expense amount in cents
+             * @example 3500000
+             */
+            expenseAmount: number;
+            /**
+             * Format: int64
+             * @description Expense transactions
+             * @example 95
+             */
+            expenseCount: number;
+            /**
+             * Format: int64
+             * @description Total income amount in cents
+             * @example 5000000
+             */
+            incomeAmount: number;
+            /**
+             * Format: int64
+             * @description Income transactions
+             * @example 50
+             */
+            incomeCount: number;
+            /**
+             * Format: int64
+             * @description Net amount (income - expense) in cents
+             * @example 1500000
+             */
+            net: number;
+            /**
+             * @description Month period (YYYY-MM)
+             * @example 2024-01
+             */
+            period: string;
+            /**
+             * Format: int64
+             * @description Total transactions in month
+             * @example 150
+             */
+            totalCount: number;
+            /**
+             * Format: int64
+             * @description Total transfer amount in cents
+             * @example 500000
+             */
+            transferAmount: number;
+            /**
+             * Format: int64
+             * @description Transfer transactions
+             * @example 5
+             */
+            transferCount: number;
+        };
+        AccountStatisticsMonthlyVelocityModel: {
+            /**
+             * Format: int64
+             * @description Average monthly spending in cents
+             * @example 3500000
+             */
+            averageMonthlySpend: number;
+            /** @description Monthly velocity data */
+            data: components["schemas"]["AccountStatisticsMonthlyVelocityEntry"][] | null;
+            /**
+             * @description Spending trend direction
+             * @example increasing
+             * @enum {string}
+             */
+            trendDirection: "increasing" | "decreasing" | "stable";
+        };
+        AccountStatisticsResponse: {
+            /**
+             * Format: int64
+             * @description Account ID
+             * @example 1
+             */
+            accountId: number;
+            /** @description Budget health metrics */
+            budgetHealth: components["schemas"]["AccountStatisticsBudgetHealthModel"];
+            /** @description Spending rate analysis */
+            burnRate: components["schemas"]["AccountStatisticsBurnRateModel"];
+            /** @description Daily balance trend over time */
+            cashFlowPulse: components["schemas"]["AccountStatisticsCashFlowPulseModel"];
+            /** @description Category spending distribution */
+            categoryHeatmap: components["schemas"]["AccountStatisticsCategoryHeatmapModel"];
+            /** @description Monthly spending velocity */
+            monthlyVelocity: components["schemas"]["AccountStatisticsMonthlyVelocityModel"];
+            /**
+             * @description Period covered (ISO 8601)
+             * @example 2024-01-01T00:00:00Z to 2024-12-31T23:59:59Z
+             */
+            period: string;
+            /** @description Transaction frequency distribution */
+            timeFrequencyHeatmap: components["schemas"]["AccountStatisticsTimeFrequencyHeatmapModel"];
+        };
+        AccountStatisticsTimeFrequencyEntry: {
+            /**
+             * Format: int64
+             * @description Number of transactions at this frequency
+             * @example 45
+             */
+            count: number;
+            /**
+             * @description Time frequency (e.g., daily, weekly, monthly)
+             * @example weekly
+             */
+            frequency: string;
+        };
+        AccountStatisticsTimeFrequencyHeatmapModel: {
+            /** @description Frequency distribution data */
+            data: components["schemas"]["AccountStatisticsTimeFrequencyEntry"][] | null;
+            /**
+             * @description Most common transaction frequency
+             * @example weekly
+             */
+            mostCommonPattern: string;
+            /**
+             * Format: int64
+             * @description Total transactions in period
+             * @example 240
+             */
+            totalTransactions: number;
+        };
+        AccountsPagedModel: {
+            /** @description List of accounts */
+            items: components["schemas"]["AccountModel"][] | null;
+            /**
+             * Format: int64
+             * @description Current page number
+             */
+            pageNumber: number;
+            /**
+             * Format: int64
+             * @description Items per page
+             */
+            pageSize: number;
+            /**
+             * Format: int64
+             * @description Total number of matching items
+             */
+            totalCount: number;
+            /**
+             * Format: int64
+             * @description Total number of pages
+             */
+            totalPages: number;
+        };
+        AuditLogModel: {
+            /**
+             * Format: int64
+             * @description ID of the user who made the change
+             */
+            actorId: number | null;
+            /** @description Username of the user who made the change */
+            actorUsername: string | null;
+            /** @description Snapshot of the entity after the change, null on delete */
+            after: unknown;
+            /** @description Snapshot of the entity before the change, null on create */
+            before: unknown;
+            /**
+             * Format: date-time
+             * @description When the change was made
+             */
+            createdAt: string;
+            /**
+             * Format: int64
+             * @description ID of the changed entity. Transaction tag changes are recorded against the transaction ID
+             */
+            entityId: number;
+            /** @description Type of the changed entity */
+            entityType: string;
+            /**
+             * Format: int64
+             * @description Unique identifier
+             */
+            id: number;
+            /**
+             * @description Kind of change
+             * @enum {string}
+             */
+            operation: "create" | "update" | "delete" | "restore";
+            /**
+             * @description Where the change originated from
+             * @enum {string}
+             */
+            source: "api" | "bulk_commit" | "budget_template_worker" | "transaction_template_worker" | "transaction_rules";
+        };
+        AuditLogsPagedModel: {
+            /** @description List of audit log entries */
+            items: components["schemas"]["AuditLogModel"][] | null;
+            /**
+             * Format: int64
+             * @description Current page number
+             */
+            pageNumber: number;
+            /**
+             * Format: int64
+             * @description Items per page
+             */
+            pageSize: number;
+            /**
+             * Format: int64
+             * @description Total number of matching items
+             */
+            totalCount: number;
+            /**
+             * Format: int64
+             * @description Total number of pages
+             */
+            totalPages: number;
+        };
+        BudgetModel: {
+            /**
+             * Format: int64
+             * @description Account ID to filter transactions
+             */
+            accountId?: number;
+            /**
+             * Format: int64
+             * @description Actual spent amount in cents (computed from transactions)
+             */
+            actualAmount: number;
+            /**
+             * Format: int64
+             * @description Budget limit amount in cents
+             */
+            amountLimit: number;
+            /**
+             * Format: int64
+             * @description Category ID to filter transactions
+             */
+            categoryId?: number;
+            /**
+             * Format: date-time
+             * @description Creation timestamp
+             */
+            createdAt: string;
+            /**
+             * Format: date-time
+             * @description Soft delete timestamp
+             */
+            deletedAt?: string;
+            /**
+             * Format: int64
+             * @description Unique identifier
+             */
+            id: number;
+            /** @description Budget name */
+            name: string;
+            /** @description Optional note for the budget */
+            note: string | null;
+            /**
+             * Format: date-time
+             * @description Budget period end date
+             */
+            periodEnd: string;
+            /**
+             * Format: date-time
+             * @description Budget period start date
+             */
+            periodStart: string;
+            /**
+             * @description Budget period type
+             * @enum {string}
+             */
+            periodType: "weekly" | "monthly" | "yearly" | "custom";
+            /**
+             * @description Budget status (active or inactive)
+             * @enum {string}
+             */
+            status: "active" | "inactive";
+            /**
+             * Format: int64
+             * @description Budget template ID if generated from template
+             */
+            templateId?: number;
+            /**
+             * Format: date-time
+             * @description Last update timestamp
+             */
+            updatedAt?: string;
+        };
+        BudgetTemplateModel: {
+            /**
+             * Format: int64
+             * @description Account ID to filter transactions
+             */
+            accountId?: number;
+            /** @description Whether this template is active and generating budgets */
+            active: boolean;
+            /**
+             * Format: int64
+             * @description Budget limit amount in cents
+             */
+            amountLimit: number;
+            /**
+             * Format: int64
+             * @description Category ID to filter transactions
+             */
+            categoryId?: number;
+            /**
+             * Format: date-time
+             * @description Creation timestamp
+             */
+            createdAt: string;
+            /**
+             * Format: date-time
+             * @description Soft delete timestamp
+             */
+            deletedAt?: string;
+            /**
+             * Format: date-time
+             * @description Optional end date for recurrence
+             */
+            endDate?: string;
+            /** @description Version of the record, changed on every update; send it as If-Match to only change or delete the record as it was read */
+            etag?: string;
+            /**
+             * Format: int64
+             * @description Unique identifier
+             */
+            id: number;
+            /**
+             * Format: date-time
+             * @description Timestamp of the last execution of this template
+             */
+            lastExecutedAt?: string;
+            /** @description Template name */
+            name: string;
+            /**
+             * Format: date-time
+             * @description Next run date for recurring budget creation
+             */
+            nextRunAt?: string;
+            /** @description Optional note for the template */
+            note?: string;
+            /**
+             * @description Recurrence pattern
+             * @enum {string}
+             */
+            recurrence: "none" | "weekly" | "monthly" | "yearly";
+            /**
+             * Format: date-time
+             * @description Start date for recurrence
+             */
+            startDate: string;
+            /**
+             * Format: date-time
+             * @description Last update timestamp
+             */
+            updatedAt?: string;
+        };
+        BudgetTemplatesPagedModel: {
+            /** @description List of budget templates */
+            items: components["schemas"]["BudgetTemplateModel"][] | null;
+            /**
+             * Format: int64
+             * @description Current page number
+             */
+            pageNumber: number;
+            /**
+             * Format: int64
+             * @description Items per page
+             */
+            pageSize: number;
+            /**
+             * Format: int64
+             * @description Total number of matching items
+             */
+            totalCount: number;
+            /**
+             * Format: int64
+             * @description Total number of pages
+             */
+            totalPages: number;
+        };
+        BudgetsPagedModel: {
+            /** @description List of budgets */
+            items: components["schemas"]["BudgetModel"][] | null;
+            /**
+             * Format: int64
+             * @description Current page number
+             */
+            pageNumber: number;
+            /**
+             * Format: int64
+             * @description Items per page
+             */
+            pageSize: number;
+            /**
+             * Format: int64
+             * @description Total number of matching items
+             */
+            totalCount: number;
+            /**
+             * Format: int64
+             * @description Total number of pages
+             */
+            totalPages: number;
+        };
+        BulkTransactionCommitResponseModel: {
+            /**
+             * Format: int64
+             * @description Total processing time in milliseconds
+             */
+            durationMs: number;
+            /**
+             * Format: int64
+             * @description Number of successfully updated transactions
+             */
+            successCount: number;
+            /** @description List of updated transaction IDs */
+            updatedIds: number[] | null;
+        };
+        BulkTransactionDraftModel: {
+            /** @description List of transaction updates (max 500) */
+            updates: components["schemas"]["BulkTransactionUpdateItemModel"][] | null;
+        };
+        BulkTransactionDraftResponseModel: {
+            /**
+             * Format: date-time
+             * @description Draft creation timestamp
+             */
+            createdAt: string;
+            /**
+             * Format: date-time
+             * @description Draft expiration timestamp
+             */
+            expiresAt: string;
+            /**
+             * Format: int64
+             * @description Number of transactions in draft
+             */
+            transactionCount: number;
+            /**
+             * Format: date-time
+             * @description Draft last update timestamp
+             */
+            updatedAt: string;
+        };
+        BulkTransactionUpdateItemModel: {
+            /**
+             * Format: int64
+             * @description Source account ID
+             */
+            accountId?: number;
+            /**
+             * Format: int64
+             * @description Transaction amount in the specified currency
+             */
+            amount?: number;
+            /**
+             * Format: int64
+             * @description Category ID
+             */
+            categoryId?: number;
+            /** @description ISO 4217 currency code (e.g., USD, EUR) */
+            currencyCode?: string;
+            /**
+             * Format: date-time
+             * @description Transaction date
+             */
+            date?: string;
+            /**
+             * Format: int64
+             * @description Destination account ID (transfers only)
+             */
+            destinationAccountId?: number;
+            /**
+             * Format: int64
+             * @description Amount credited to the destination account in base currency; 0 credits the amount again (transfers only)
+             */
+            destinationAmount?: number;
+            /**
+             * Format: int64
+             * @description Fee taken for the transfer in base currency; 0 removes the fee (transfers only)
+             */
+            feeAmount?: number;
+            /**
+             * Format: int64
+             * @description Expense category of the fee; required when adding a fee
+             */
+            feeCategoryId?: number;
+            /**
+             * Format: int64
+             * @description Transaction ID to update
+             */
+            id: number;
+            /**
+             * Format: double
+             * @description Transaction latitude
+             */
+            latitude?: number;
+            /**
+             * Format: double
+             * @description Transaction longitude
+             */
+            longitude?: number;
+            /** @description Transaction notes */
+            note?: string;
+            /**
+             * Format: int64
+             * @description Payee ID; 0 removes the payee
+             */
+            payeeId?: number;
+            /** @description Apply the update as given, without filling the fields it leaves out from transaction rules */
+            skipRules?: boolean;
+            /**
+             * @description Bank status; reconciled is only set by completing a reconciliation
+             * @enum {string}
+             */
+            status?: "pending" | "cleared";
+            /**
+             * @description Transaction type
+             * @enum {string}
+             */
+            type?: "expense" | "income" | "transfer";
+        };
+        CategoriesPagedModel: {
+            /** @description List of categories */
+            items: components["schemas"]["CategoryModel"][] | null;
+            /**
+             * Format: int64
+             * @description Current page number
+             */
+            pageNumber: number;
+            /**
+             * Format: int64
+             * @description Items per page
+             */
+            pageSize: number;
+            /**
+             * Format: int64
+             * @description Total number of matching items
+             */
+            totalCount: number;
+            /**
+             * Format: int64
+             * @description Total number of pages
+             */
+            totalPages: number;
+        };
+        CategoryModel: {
+            /**
+             * Format: date-time
+             * @description Timestamp when archived (null if active)
+             */
+            archivedAt?: string;
+            /** @description Currently active budget for this category */
+            budget?: components["schemas"]["EmbeddedBudget"];
+            /**
+             * Format: date-time
+             * @description Creation timestamp
+             */
+            createdAt: string;
+            /**
+             * Format: date-time
+             * @description Soft delete timestamp
+             */
+            deletedAt?: string;
+            /**
+             * Format: int64
+             * @description Display order sequence
+             */
+            displayOrder: number;
+            /** @description Icon identifier */
+            icon?: string;
+            /** @description Icon color code */
+            iconColor?: string;
+            /**
+             * Format: int64
+             * @description Unique identifier
+             */
+            id: number;
+            /** @description Category name */
+            name: string;
+            /** @description Category notes */
+            note: string;
+            /**
+             * @description Category type
+             * @enum {string}
+             */
+            type: "expense" | "income" | "transfer";
+            /**
+             * Format: date-time
+             * @description Last update timestamp
+             */
+            updatedAt?: string;
+        };
+        CategoryStatisticAccountDistributionEntry: {
+            /**
+             * Format: int64
+             * @description Account ID
+             * @example 1
+             */
+            accountId: number;
+            /**
+             * @description Account name
+             * @example Credit Card
+             */
+            accountName: string;
+            /**
+             * Format: int64
+             * @description Total spending from this account in cents
+             * @example 2250000
+             */
+            amount: number;
+            /**
+             * Format: double
+             * @description Percentage of total spending
+             * @example 75
+             */
+            percentage: number;
+        };
+        CategoryStatisticAccountDistributionModel: {
+            /** @description Account spending distribution */
+            accounts: components["schemas"]["CategoryStatisticAccountDistributionEntry"][] | null;
+            /**
+             * Format: int64
+             * @description Total spending in cents
+             * @example 3000000
+             */
+            totalSpending: number;
+        };
+        CategoryStatisticAverageTransactionSizeModel: {
+            /**
+             * Format: int64
+             * @description Average expense transaction amount in cents
+             * @example 25000
+             */
+            averageAmount: number;
+            /**
+             * Format: int64
+             * @description Average income amount in cents
+             * @example 150000
+             */
+            averageIncomeAmount: number;
+            /**
+             * Format: int64
+             * @description Expense transaction count
+             * @example 80
+             */
+            expenseCount: number;
+            /**
+             * Format: int64
+             * @description Income transaction count
+             * @example 40
+             */
+            incomeCount: number;
+            /**
+             * Format: double
+             * @description Ratio of income/expense counts
+             * @example 0.5
+             */
+            incomeToExpenseRatio: number;
+            /**
+             * Format: int64
+             * @description Maximum expense transaction amount in cents
+             * @example 500000
+             */
+            maxAmount: number;
+            /**
+             * Format: int64
+             * @description Maximum income amount in cents
+             * @example 1000000
+             */
+            maxIncomeAmount: number;
+            /**
+             * Format: int64
+             * @description Median expense transaction amount in cents
+             * @example 20000
+             */
+            medianAmount: number;
+            /**
+             * Format: int64
+             * @description Median income amount in cents
+             * @example 100000
+             */
+            medianIncomeAmount: number;
+            /**
+             * Format: int64
+             * @description Minimum expense transaction amount in cents
+             * @example 5000
+             */
+            minAmount: number;
+            /**
+             * Format: int64
+             * @description Minimum income amount in cents
+             * @example 50000
+             */
+            minIncomeAmount: number;
+            /**
+             * Format: int64
+             * @description Total number of transactions
+             * @example 120
+             */
+            transactionCount: number;
+        };
+        CategoryStatisticBudgetUtilizationEntry: {
+            /**
+             * Format: int64
+             * @description Budget ID
+             * @example 1
+             */
+            budgetId: number;
+            /**
+             * Format: int64
+             * @description Budget limit in cents
+             * @example 1000000
+             */
+            limit: number;
+            /**
+             * @description Budget name
+             * @example Food Budget
+             */
+            name: string;
+            /**
+             * Format: date-time
+             * @description Budget period end date
+             */
+            periodEnd: string;
+            /**
+             * Format: date-time
+             * @description Budget period start date
+             */
+            periodStart: string;
+            /**
+             * Format: int64
+             * @description Remaining budget in cents
+             * @example 250000
+             */
+            remaining: number;
+            /**
+             * Format: int64
+             * @description Amount spent in cents
+             * @example 750000
+             */
+            spent: number;
+            /**
+             * Format: double
+             * @description Percentage of budget utilized
+             * @example 75
+             */
+            utilization: number;
+        };
+        CategoryStatisticBudgetUtilizationModel: {
+            /** @description Budget utilization data */
+            budgets: components["schemas"]["CategoryStatisticBudgetUtilizationEntry"][] | null;
+        };
+        CategoryStatisticDayOfWeekPatternEntry: {
+            /**
+             * Format: int64
+             * @description Average expense amount on this day in cents
+             * @example 25000
+             */
+            averageAmount: number;
+            /**
+             * @description Day of week
+             * @example Friday
+             */
+            dayOfWeek: string;
+            /**
+             * Format: int64
+             * @description Average expense amount in cents
+             * @example 33333
+             */
+            expenseAverage: number;
+            /**
+             * Format: int64
+             * @description Expense transactions on this day
+             * @example 15
+             */
+            expenseCount: number;
+            /**
+             * Format: int64
+             * @description Total expense amount in cents
+             * @example 500000
+             */
+            expenseTotal: number;
+            /**
+             * Format: int64
+             * @description Average income amount in cents
+             * @example 150000
+             */
+            incomeAverage: number;
+            /**
+             * Format: int64
+             * @description Income transactions on this day
+             * @example 5
+             */
+            incomeCount: number;
+            /**
+             * Format: int64
+             * @description Total income amount in cents
+             * @example 750000
+             */
+            incomeTotal: number;
+            /**
+             * Format: int64
+             * @description Total expense amount on this day in cents
+             * @example 500000
+             */
+            totalAmount: number;
+            /**
+             * Format: int64
+             * @description Number of transactions on this day
+             * @example 20
+             */
+            transactionCount: number;
+        };
+        CategoryStatisticDayOfWeekPatternModel: {
+            /** @description Spending pattern by day of week */
+            data: components["schemas"]["CategoryStatisticDayOfWeekPatternEntry"][] | null;
+            /**
+             * @description Day with highest expense total
+             * @example Saturday
+             */
+            highestSpendDay: string;
+            /**
+             * @description Day with highest transaction count
+             * @example Friday
+             */
+            mostActiveDay: string;
+            /**
+             * Format: int64
+             * @description Total transactions across all days
+             * @example 150
+             */
+            totalTransactions: number;
+        };
+        CategoryStatisticSpendingVelocityDataPoint: {
+            /**
+             * Format: int64
+             * @description Total expense amount in cents (legacy field)
+             * @example 2500000
+             */
+            amount: number;
+            /**
+             * Format: int64
+             * @description Total expense in cents
+             * @example 2500000
+             */
+            expenseAmount: number;
+            /**
+             * Format: int64
+             * @description Expense transaction count
+             * @example 35
+             */
+            expenseCount: number;
+            /**
+             * Format: int64
+             * @description Total income in cents
+             * @example 5000000
+             */
+            incomeAmount: number;
+            /**
+             * Format: int64
+             * @description Income transaction count
+             * @example 10
+             */
+            incomeCount: number;
+            /**
+             * Format: double
+             * @description Percentage of income vs total volume
+             * @example 66.67
+             */
+            incomePercentage: number;
+            /**
+             * @description Month (YYYY-MM)
+             * @example 2024-01
+             */
+            month: string;
+            /**
+             * Format: int64
+             * @description Net flow (income - expense) in cents
+             * @example 2500000
+             */
+            net: number;
+            /**
+             * Format: int64
+             * @description Total transactions in month
+             * @example 45
+             */
+            totalCount: number;
+        };
+        CategoryStatisticSpendingVelocityModel: {
+            /**
+             * Format: int64
+             * @description Average monthly expense in cents
+             * @example 2500000
+             */
+            averageMonthlySpend: number;
+            /** @description Monthly spending data for line chart */
+            data: components["schemas"]["CategoryStatisticSpendingVelocityDataPoint"][] | null;
+            /**
+             * Format: int64
+             * @description Total net (income - expense) in cents
+             * @example 20000000
+             */
+            netFlow: number;
+            /**
+             * Format: int64
+             * @description Sum of all expense across months in cents
+             * @example 30000000
+             */
+            totalExpense: number;
+            /**
+             * Format: int64
+             * @description Sum of all income across months in cents
+             * @example 50000000
+             */
+            totalIncome: number;
+            /**
+             * @description Spending trend direction
+             * @example stable
+             * @enum {string}
+             */
+            trendDirection: "increasing" | "decreasing" | "stable";
+        };
+        CategoryStatisticsResponse: {
+            /** @description Which accounts pay for this category */
+            accountDistribution: components["schemas"]["CategoryStatisticAccountDistributionModel"];
+            /** @description Typical transaction amounts */
+            averageTransactionSize: components["schemas"]["CategoryStatisticAverageTransactionSizeModel"];
+            /** @description Budget progress */
+            budgetUtilization: components["schemas"]["CategoryStatisticBudgetUtilizationModel"];
+            /** @description Spending patterns by day of week */
+            dayOfWeekPattern: components["schemas"]["CategoryStatisticDayOfWeekPatternModel"];
+            /** @description Spending trend over time */
+            spendingVelocity: components["schemas"]["CategoryStatisticSpendingVelocityModel"];
+        };
+        ChangePasswordRequestModel: {
+            /** @description Current password */
+            current_password: string;
+            /** @description New password */
+            new_password: string;
+        };
+        CreateAPITokenModel: {
+            /**
+             * Format: date-time
+             * @description Optional expiry timestamp
+             */
+            expiresAt?: string;
+            /** @description Label to recognise the token by */
+            name: string;
+            /** @description Scopes granted to the token */
+            scopes: ("transactions:read" | "transactions:write" | "budgets:read" | "budgets:write" | "reports:read")[] | null;
+        };
+        CreateAPITokenResponseModel: {
+            /**
+             * Format: date-time
+             * @description Creation timestamp
+             */
+            createdAt: string;
+            /**
+             * Format: date-time
+             * @description Expiry timestamp (null if the token never expires)
+             */
+            expiresAt?: string;
+            /**
+             * Format: int64
+             * @description Unique identifier
+             */
+            id: number;
+            /**
+             * Format: date-time
+             * @description Last time the token authenticated a request
+             */
+            lastUsedAt?: string;
+            /** @description Label to recognise the token by */
+            name: string;
+            /** @description First characters of the token, for identification only */
+            prefix: string;
+            /** @description Granted scopes */
+            scopes: string[] | null;
+            /** @description Plain token value, only returned once. Send it as 'Authorization: Bearer <token>' */
+            token: string;
+        };
+        CreateAccountModel: {
+            /** @description Icon identifier */
+            icon?: string;
+            /** @description Icon color code */
+            iconColor?: string;
+            /** @description Account name */
+            name: string;
+            /** @description Optional account notes */
+            note: string;
+            /**
+             * @description Account type (expense or income)
+             * @enum {string}
+             */
+            type: "expense" | "income";
+        };
+        CreateAccountReconciliationModel: {
+            /**
+             * Format: int64
+             * @description Closing balance on the bank statement
+             */
+            statementBalance: number;
+            /**
+             * Format: date-time
+             * @description End date of the bank statement
+             */
+            statementDate: string;
+        };
+        CreateBudgetTemplateModel: {
+            /**
+             * Format: int64
+             * @description Account ID to filter transactions
+             */
+            accountId?: number;
+            /**
+             * @description Whether this template is active and generating budgets
+             * @default true
+             */
+            active: boolean;
+            /**
+             * Format: int64
+             * @description Budget limit amount in cents
+             */
+            amountLimit: number;
+            /**
+             * Format: int64
+             * @description Category ID to filter transactions
+             */
+            categoryId?: number;
+            /**
+             * Format: date-time
+             * @description Optional end date for recurrence
+             */
+            endDate?: string;
+            /** @description Template name */
+            name: string;
+            /** @description Optional note for the template */
+            note?: string;
+            /**
+             * @description Recurrence pattern
+             * @enum {string}
+             */
+            recurrence: "none" | "weekly" | "monthly" | "yearly";
+            /**
+             * Format: date-time
+             * @description Start date for recurrence
+             */
+            startDate: string;
+        };
+        CreateCategoryModel: {
+            /** @description Icon identifier */
+            icon?: string;
+            /** @description Icon color code */
+            iconColor?: string;
+            /** @description Category name */
+            name: string;
+            /** @description Optional category notes */
+            note: string;
+            /**
+             * @description Category type
+             * @enum {string}
+             */
+            type: "expense" | "income" | "transfer";
+        };
+        CreatePayeeModel: {
+            /** @description Other names of the payee */
+            aliases?: string[] | null;
+            /** @description Payee name */
+            name: string;
+        };
+        CreateReimbursementMatchModel: {
+            /**
+             * Format: int64
+             * @description Part of the income counted towards this reimbursement; defaults to as much as both the reimbursement and the income have left
+             */
+            amount?: number;
+            /**
+             * Format: int64
+             * @description Income transaction that paid the expense back
+             */
+            transactionId: number;
+        };
+        CreateReimbursementModel: {
+            /** @description Who is expected to pay the expense back */
+            counterparty: string;
+            /**
+             * Format: int64
+             * @description Amount expected to be paid back in base currency; defaults to the whole expense
+             */
+            expectedAmount?: number;
+            /** @description Reimbursement notes */
+            note?: string;
+            /**
+             * Format: int64
+             * @description Expense transaction expected to be paid back
+             */
+            transactionId: number;
+        };
+        CreateTagModel: {
+            /** @description Tag color code */
+            color?: string;
+            /** @description Tag name */
+            name: string;
+        };
+        CreateTransactionModel: {
+            /**
+             * Format: int64
+             * @description Source account ID
+             */
+            accountId: number;
+            /**
+             * Format: int64
+             * @description Transaction amount in the specified currency (defaults to base currency if currencyCode not provided)
+             */
+            amount: number;
+            /**
+             * Format: int64
+             * @description Category ID
+             */
+            categoryId: number;
+            /** @description ISO 4217 currency code (e.g., USD, EUR). If omitted, amount is treated as base currency. */
+            currencyCode?: string;
+            /**
+             * Format: date-time
+             * @description Transaction date
+             */
+            date: string;
+            /**
+             * Format: int64
+             * @description Destination account ID (transfers only)
+             */
+            destinationAccountId?: number;
+            /**
+             * Format: int64
+             * @description Amount credited to the destination account in base currency, e.g. after a currency conversion; defaults to the converted amount (transfers only)
+             */
+            destinationAmount?: number;
+            /**
+             * Format: int64
+             * @description Fee taken for the transfer in base currency, recorded as an expense on the source account linked with fee_of (transfers only)
+             */
+            feeAmount?: number;
+            /**
+             * Format: int64
+             * @description Expense category of the fee; required with feeAmount
+             */
+            feeCategoryId?: number;
+            /**
+             * Format: double
+             * @description Transaction latitude
+             */
+            latitude?: number;
+            /**
+             * Format: double
+             * @description Transaction longitude
+             */
+            longitude?: number;
+            /** @description Optional transaction notes */
+            note?: string;
+            /**
+             * Format: int64
+             * @description Payee ID
+             */
+            payeeId?: number;
+            /** @description Record the transaction as given, without applying transaction rules */
+            skipRules?: boolean;
+            /**
+             * @description Bank status, defaults to pending
+             * @enum {string}
+             */
+            status?: "pending" | "cleared";
+            /**
+             * @description Transaction type
+             * @enum {string}
+             */
+            type: "expense" | "income" | "transfer";
+        };
+        CreateTransactionRelationModel: {
+            /**
+             * Format: int64
+             * @description ID of the source transaction
+             */
+            SourceTransactionID: number;
+            /**
+             * Format: int64
+             * @description ID of the related transaction
+             */
+            relatedTransactionId: number;
+            /**
+             * @description Type of relation, read from the source transaction to the related one. refund_of: an income refunding one expense. fee_of: an expense charged as a fee for one transaction. split_of: one part of a transaction of the same type. duplicate_of: a transaction recording the same thing as one of the same type. related: any other link, both ways
+             * @enum {string}
+             */
+            relationType: "refund_of" | "fee_of" | "split_of" | "duplicate_of" | "related";
+        };
+        CreateTransactionRuleModel: {
+            /** @description Changes made to matching transactions; at least one is required */
+            actions: components["schemas"]["TransactionRuleActionsModel"];
+            /** @description Conditions a transaction must all meet */
+            conditions: components["schemas"]["TransactionRuleConditionsModel"];
+            /** @description Whether the rule runs; defaults to true */
+            enabled?: boolean;
+            /** @description Rule name */
+            name: string;
+            /**
+             * Format: int64
+             * @description Evaluation order, lowest first
+             * @default 100
+             */
+            priority: number;
+        };
+        CreateTransactionTagModel: {
+            /**
+             * Format: int64
+             * @description ID of the tag
+             */
+            tagId: number;
+            /**
+             * Format: int64
+             * @description ID of the transaction
+             */
+            transactionId?: number;
+        };
+        CreateTransactionTemplateModel: {
+            /**
+             * Format: int64
+             * @description Source account ID
+             */
+            accountId: number;
+            /**
+             * Format: int64
+             * @description Template amount in base currency (IDR)
+             */
+            amount: number;
+            /**
+             * Format: int64
+             * @description Category ID
+             */
+            categoryId: number;
+            /** @description ISO 4217 currency code (optional, defaults to base currency) */
+            currencyCode?: string;
+            /**
+             * Format: int64
+             * @description Destination account ID (transfers only)
+             */
+            destinationAccountId?: number;
+            /**
+             * Format: date-time
+             * @description Template end date
+             */
+            endDate?: string;
+            /** @description Template name */
+            name: string;
+            /** @description Template notes */
+            note?: string;
+            /**
+             * Format: int64
+             * @description Payee ID
+             */
+            payeeId?: number;
+            /**
+             * @description Recurrence pattern
+             * @enum {string}
+             */
+            recurrence: "none" | "weekly" | "monthly" | "yearly";
+            /**
+             * Format: date-time
+             * @description Template start date
+             */
+            startDate: string;
+            /**
+             * @description Transaction type
+             * @enum {string}
+             */
+            type: "expense" | "income" | "transfer";
+        };
+        CreateUserModel: {
+            /** @description Login password */
+            password: string;
+            /** @description Login username */
+            username: string;
+        };
+        EmbeddedBudget: {
+            /**
+             * Format: int64
+             * @description Account ID to filter transactions
+             */
+            accountId?: number;
+            /**
+             * Format: int64
+             * @description Actual spent amount in cents
+             */
+            actualAmount: number;
+            /**
+             * Format: int64
+             * @description Budget limit amount in cents
+             */
+            amountLimit: number;
+            /**
+             * Format: int64
+             * @description Category ID to filter transactions
+             */
+            categoryId?: number;
+            /**
+             * Format: int64
+             * @description Budget unique identifier
+             */
+            id: number;
+            /** @description Budget name */
+            name: string;
+            /**
+             * Format: date-time
+             * @description Budget period end date
+             */
+            periodEnd: string;
+            /**
+             * Format: date-time
+             * @description Budget period start date
+             */
+            periodStart: string;
+            /** @description Budget period type */
+            periodType: string;
+            /**
+             * Format: int64
+             * @description Budget template ID if generated from template
+             */
+            templateId?: number;
+        };
+        ErrorDetail: {
+            /** @description Where the error occurred, e.g. 'body.items[3].tags' or 'path.thing-id' */
+            location?: string;
+            /** @description Error message text */
+            message?: string;
+            /** @description The value at the given location */
+            value?: unknown;
+        };
+        ErrorModel: {
+            /**
+             * @description A human-readable explanation specific to this occurrence of the problem.
+             * @example Property foo is required but is missing.
+             */
+            detail?: string;
+            /** @description Optional list of individual error details */
+            errors?: components["schemas"]["ErrorDetail"][] | null;
+            /**
+             * Format: uri
+             * @description A URI reference that identifies the specific occurrence of the problem.
+             * @example https://example.com/error-log/abc123
+             */
+            instance?: string;
+            /**
+             * Format: int64
+             * @description HTTP status code
+             * @example 400
+             */
+            status?: number;
+            /**
+             * @description A short, human-readable summary of the problem type. This value should not change between occurrences of the error.
+             * @example Bad Request
+             */
+            title?: string;
+            /**
+             * Format: uri
+             * @description A URI reference to human-readable documentation for the error.
+             * @default about:blank
+             * @example https://example.com/errors/example
+             */
+            type: string;
+        };
+        LoginRequestModel: {
+            /** @description Name of this device in the session list, e.g. Work laptop */
+            device_name?: string;
+            /** @description Admin password */
+            password: string;
+            /** @description Admin username */
+            username: string;
+        };
+        LoginResponseModel: {
+            /** @description Access token valid for 7 days */
+            access_token?: string;
+            /** @description Challenge token valid for 5 minutes, exchanged for a token pair at /auth/login/verify */
+            challenge_token?: string;
+            /** @description Refresh token valid for 30 days */
+            refresh_token?: string;
+            /** @description True when a TOTP or recovery code must be sent to /auth/login/verify */
+            two_factor_required?: boolean;
+        };
+        LoginVerifyRequestModel: {
+            /** @description Challenge token returned by /auth/login */
+            challenge_token: string;
+            /** @description Current code from the authenticator app */
+            code?: string;
+            /** @description Name of this device in the session list, e.g. Work laptop */
+            device_name?: string;
+            /** @description Single-use recovery code, used instead of code */
+            recovery_code?: string;
+        };
+        LogoutRequestModel: {
+            /** @description Refresh token to revoke along with the current access token */
+            refresh_token?: string;
+        };
+        MergePayeesModel: {
+            /** @description Duplicate payees to merge into this one; their transactions and templates move over, their names become aliases and they are deleted */
+            sourceIds: number[] | null;
+        };
+        MergeTransactionDuplicatesModel: {
+            /** @description Transactions to fold into this one and delete */
+            duplicateIds: number[] | null;
+        };
+        OIDCAuthorizeResponseModel: {
+            /** @description Identity provider URL to send the browser to */
+            authorization_url: string;
+            /** @description Opaque value the identity provider echoes back to the redirect URL, valid for 10 minutes */
+            state: string;
+        };
+        OIDCCallbackRequestModel: {
+            /** @description Authorization code from the redirect URL */
+            code: string;
+            /** @description Name of this device in the session list, e.g. Work laptop */
+            device_name?: string;
+            /** @description State from the redirect URL */
+            state: string;
+        };
+        PayeeMergeResultModel: {
+            /**
+             * Format: int64
+             * @description Number of payees merged into it
+             */
+            mergedCount: number;
+            /** @description The payee that was kept */
+            payee: components["schemas"]["PayeeModel"];
+            /**
+             * Format: int64
+             * @description Number of transaction templates moved to it
+             */
+            templatesMoved: number;
+            /**
+             * Format: int64
+             * @description Number of transactions moved to it
+             */
+            transactionsMoved: number;
+        };
+        PayeeModel: {
+            /** @description Other names of the payee, such as the text printed on bank statements */
+            aliases: string[] | null;
+            /**
+             * Format: date-time
+             * @description Creation timestamp
+             */
+            createdAt: string;
+            /**
+             * Format: date-time
+             * @description Soft delete timestamp
+             */
+            deletedAt?: string;
+            /**
+             * Format: int64
+             * @description Unique identifier
+             */
+            id: number;
+            /** @description Payee name */
+            name: string;
+            /**
+             * Format: date-time
+             * @description Last update timestamp
+             */
+            updatedAt?: string;
+        };
+        PayeesPagedModel: {
+            /** @description List of payees */
+            items: components["schemas"]["PayeeModel"][] | null;
+            /**
+             * Format: int64
+             * @description Current page number
+             */
+            pageNumber: number;
+            /**
+             * Format: int64
+             * @description Items per page
+             */
+            pageSize: number;
+            /**
+             * Format: int64
+             * @description Total number of matching items
+             */
+            totalCount: number;
+            /**
+             * Format: int64
+             * @description Total number of pages
+             */
+            totalPages: number;
+        };
+        RefreshGeoCache: {
+            /**
+             * Format: double
+             * @description User's latitude for distance-based ordering (optional)
+             */
+            latitude: number | null;
+            /**
+             * Format: double
+             * @description User's longitude for distance-based ordering (optional)
+             */
+            longitude: number | null;
+        };
+        RefreshRequestModel: {
+            /** @description Refresh token */
+            refresh_token: string;
+        };
+        RefreshResponseModel: {
+            /** @description New access token valid for 7 days */
+            access_token: string;
+            /** @description New refresh token valid for 30 days, the submitted one is no longer usable */
+            refresh_token: string;
+        };
+        ReimbursementMatchModel: {
+            /**
+             * Format: int64
+             * @description Part of the income counted towards this reimbursement
+             */
+            amount: number;
+            /**
+             * Format: date-time
+             * @description Creation timestamp
+             */
+            createdAt: string;
+            /**
+             * Format: int64
+             * @description Unique identifier
+             */
+            id: number;
+            /**
+             * Format: date-time
+             * @description Date of the income
+             */
+            transactionDate: string;
+            /**
+             * Format: int64
+             * @description Income transaction that paid the expense back
+             */
+            transactionId: number;
+        };
+        ReimbursementModel: {
+            /** @description Who is expected to pay the expense back */
+            counterparty: string;
+            /**
+             * Format: date-time
+             * @description Creation timestamp
+             */
+            createdAt: string;
+            /**
+             * Format: int64
+             * @description Amount expected to be paid back in base currency
+             */
+            expectedAmount: number;
+            /**
+             * Format: int64
+             * @description Unique identifier
+             */
+            id: number;
+            /**
+             * Format: int64
+             * @description Amount paid back so far by matched income
+             */
+            matchedAmount: number;
+            /** @description Income transactions that paid the expense back; only included when getting a single reimbursement */
+            matches?: components["schemas"]["ReimbursementMatchModel"][] | null;
+            /** @description Reimbursement notes */
+            note?: string;
+            /**
+             * Format: int64
+             * @description Amount still owed
+             */
+            outstandingAmount: number;
+            /**
+             * @description open when nothing is paid back yet, partial when some is, settled when nothing is owed anymore
+             * @enum {string}
+             */
+            status: "open" | "partial" | "settled";
+            /**
+             * Format: int64
+             * @description Amount of the expense in base currency
+             */
+            transactionAmount: number;
+            /**
+             * Format: date-time
+             * @description Date of the expense
+             */
+            transactionDate: string;
+            /**
+             * Format: int64
+             * @description Expense transaction expected to be paid back
+             */
+            transactionId: number;
+            /** @description Notes of the expense */
+            transactionNote?: string;
+            /**
+             * Format: date-time
+             * @description Last update timestamp
+             */
+            updatedAt?: string;
+        };
+        ReimbursementsPagedModel: {
+            /** @description List of reimbursements */
+            items: components["schemas"]["ReimbursementModel"][] | null;
+            /**
+             * Format: int64
+             * @description Current page number
+             */
+            pageNumber: number;
+            /**
+             * Format: int64
+             * @description Items per page
+             */
+            pageSize: number;
+            /**
+             * Format: int64
+             * @description Total number of matching items
+             */
+            totalCount: number;
+            /**
+             * Format: int64
+             * @description Amount still owed across all matching items
+             */
+            totalOutstanding: number;
+            /**
+             * Format: int64
+             * @description Total number of pages
+             */
+            totalPages: number;
+        };
+        ReorderAccountsModel: {
+            /** @description Ordered list of account IDs, first item will receive displayOrder 0 */
+            data: number[] | null;
+        };
+        ReorderCategoriesModel: {
+            /** @description Ordered list of category IDs, first item will receive displayOrder 0 */
+            items: number[] | null;
+        };
+        ReplaceTransactionSplitsModel: {
+            /** @description Split lines replacing the current ones; their amounts must sum to the transaction amount. An empty list removes the split */
+            lines: components["schemas"]["TransactionSplitLineModel"][] | null;
+        };
+        SeedDevelopmentDataResponseBody: {
+            /** @description Response message */
+            message: string;
+        };
+        SessionModel: {
+            /**
+             * Format: date-time
+             * @description Login time
+             */
+            createdAt: string;
+            /** @description True for the session making this request */
+            current: boolean;
+            /** @description Device name given at login */
+            deviceName: string | null;
+            /**
+             * Format: date-time
+             * @description When the session ends unless its refresh token is used
+             */
+            expiresAt: string;
+            /**
+             * Format: int64
+             * @description Unique identifier
+             */
+            id: number;
+            /** @description IP address the session was last used from */
+            ipAddress: string | null;
+            /**
+             * Format: date-time
+             * @description Last time the session was used, updated at most once a minute
+             */
+            lastSeenAt: string;
+            /** @description User agent of the client that logged in */
+            userAgent: string | null;
+        };
+        SessionsListModel: {
+            /** @description Active sessions, most recently used first */
+            items: components["schemas"]["SessionModel"][] | null;
+        };
+        ShareEmbedded: {
+            /**
+             * @description Granted role
+             * @enum {string}
+             */
+            role: "viewer" | "editor";
+            /**
+             * Format: int64
+             * @description User the resource is shared with
+             */
+            userId: number;
+            /** @description Username of the user the resource is shared with */
+            username: string;
+        };
+        ShareModel: {
+            /**
+             * Format: date-time
+             * @description Time the resource was first shared with the user
+             */
+            createdAt: string;
+            /**
+             * @description Granted role
+             * @enum {string}
+             */
+            role: "viewer" | "editor";
+            /**
+             * Format: int64
+             * @description User the resource is shared with
+             */
+            userId: number;
+            /** @description Username of the user the resource is shared with */
+            username: string;
+        };
+        SharesListModel: {
+            /** @description Users the resource is shared with */
+            items: components["schemas"]["ShareModel"][] | null;
+        };
+        SummaryAccountListModel: {
+            /** @description Summary data grouped by account */
+            data: components["schemas"]["SummaryAccountModel"][] | null;
+        };
+        SummaryAccountModel: {
+            /**
+             * Format: int64
+             * @description Total expense amount
+             * @example 2500000
+             */
+            expenseAmount: number;
+            /**
+             * Format: int64
+             * @description Account ID
+             * @example 1
+             */
+            id: number;
+            /**
+             * Format: int64
+             * @description Total income amount
+             * @example 3000000
+             */
+            incomeAmount: number;
+            /**
+             * @description Account name
+             * @example Cash
+             */
+            name: string;
+            /**
+             * Format: int64
+             * @description Net amount (income - expense)
+             * @example 500000
+             */
+            net: number;
+            /**
+             * Format: int64
+             * @description Total number of transactions
+             * @example 100
+             */
+            totalCount: number;
+            /**
+             * @description Account type
+             * @example expense
+             * @enum {string}
+             */
+            type: "income" | "expense";
+        };
+        SummaryCategoryListModel: {
+            /** @description Summary data grouped by category */
+            data: components["schemas"]["SummaryCategoryModel"][] | null;
+        };
+        SummaryCategoryModel: {
+            /**
+             * Format: int64
+             * @description Total expense amount
+             * @example 1500000
+             */
+            expenseAmount: number;
+            /**
+             * Format: int64
+             * @description Category ID
+             * @example 1
+             */
+            id: number;
+            /**
+             * Format: int64
+             * @description Total income amount
+             * @example 0
+             */
+            incomeAmount: number;
+            /**
+             * @description Category name
+             * @example Food
+             */
+            name: string;
+            /**
+             * Format: int64
+             * @description Net amount (income - expense)
+             * @example -1500000
+             */
+            net: number;
+            /**
+             * Format: int64
+             * @description Total number of transactions
+             * @example 75
+             */
+            totalCount: number;
+            /**
+             * @description Category type
+             * @example expense
+             * @enum {string}
+             */
+            type: "income" | "expense" | "transfer";
+        };
+        SummaryGeospatialGridCell: {
+            /**
+             * Format: int64
+             * @description Total expense amount
+             * @example 3000000
+             */
+            expenseAmount: number;
+            /**
+             * Format: int64
+             * @description Number of expense transactions
+             * @example 28
+             */
+            expenseCount: number;
+            /**
+             * Format: double
+             * @description Grid cell center latitude
+             * @example -6.175
+             */
+            gridLat: number;
+            /**
+             * Format: double
+             * @description Grid cell center longitude
+             * @example 106.827
+             */
+            gridLon: number;
+            /**
+             * Format: int64
+             * @description Total income amount
+             * @example 2000000
+             */
+            incomeAmount: number;
+            /**
+             * Format: int64
+             * @description Number of income transactions
+             * @example 15
+             */
+            incomeCount: number;
+            /**
+             * Format: int64
+             * @description Total transaction amount in this grid cell
+             * @example 5000000
+             */
+            totalAmount: number;
+            /**
+             * Format: int64
+             * @description Total number of transactions in this grid cell
+             * @example 45
+             */
+            transactionCount: number;
+            /**
+             * Format: int64
+             * @description Number of transfer transactions
+             * @example 2
+             */
+            transferCount: number;
+        };
+        SummaryGeospatialListModel: {
+            /**
+             * Format: double
+             * @description Search center latitude
+             * @example -6.175
+             */
+            centerLat: number;
+            /**
+             * Format: double
+             * @description Search center longitude
+             * @example 106.827
+             */
+            centerLon: number;
+            /** @description Grid cells with aggregated transaction data */
+            data: components["schemas"]["SummaryGeospatialGridCell"][] | null;
+            /**
+             * Format: int64
+             * @description Grid cell precision level
+             * @example 3
+             */
+            gridPrecision: number;
+            /**
+             * Format: int64
+             * @description Search radius in meters
+             * @example 5000
+             */
+            radiusMeters: number;
+            /**
+             * Format: int64
+             * @description Total number of grid cells with transactions
+             * @example 8
+             */
+            totalCells: number;
+        };
+        SummaryPayeeListModel: {
+            /** @description Summary data grouped by payee */
+            data: components["schemas"]["SummaryPayeeModel"][] | null;
+        };
+        SummaryPayeeModel: {
+            /**
+             * Format: int64
+             * @description Total expense amount
+             * @example 1260000
+             */
+            expenseAmount: number;
+            /**
+             * Format: int64
+             * @description Payee ID
+             * @example 1
+             */
+            id: number;
+            /**
+             * Format: int64
+             * @description Total income amount
+             * @example 0
+             */
+            incomeAmount: number;
+            /**
+             * @description Payee name
+             * @example Corner Coffee
+             */
+            name: string;
+            /**
+             * Format: int64
+             * @description Net amount (income - expense)
+             * @example -1260000
+             */
+            net: number;
+            /**
+             * Format: int64
+             * @description Total number of transactions
+             * @example 42
+             */
+            totalCount: number;
+        };
+        SummaryTransactionListModel: {
+            /** @description Summary data grouped by period */
+            data: components["schemas"]["SummaryTransactionModel"][] | null;
+            /**
+             * @description Grouping frequency
+             * @example monthly
+             */
+            frequency: string;
+        };
+        SummaryTransactionModel: {
+            /**
+             * Format: int64
+             * @description Total expense amount
+             * @example 3500000
+             */
+            expenseAmount: number;
+            /**
+             * Format: int64
+             * @description Number of expense transactions
+             * @example 95
+             */
+            expenseCount: number;
+            /**
+             * Format: int64
+             * @description Total income amount
+             * @example 5000000
+             */
+            incomeAmount: number;
+            /**
+             * Format: int64
+             * @description Number of income transactions
+             * @example 50
+             */
+            incomeCount: number;
+            /**
+             * Format: int64
+             * @description Net amount (income - expense)
+             * @example 1500000
+             */
+            net: number;
+            /**
+             * @description Time period (e.g., 2024-01, 2024-W01, 2024-01-01, 2024)
+             * @example 2024-01
+             */
+            period: string;
+            /**
+             * Format: int64
+             * @description Total number of transactions
+             * @example 150
+             */
+            totalCount: number;
+            /**
+             * Format: int64
+             * @description Total transfer amount
+             * @example 500000
+             */
+            transferAmount: number;
+            /**
+             * Format: int64
+             * @description Number of transfer transactions
+             * @example 5
+             */
+            transferCount: number;
+        };
+        TagModel: {
+            /** @description Tag color code */
+            color?: string;
+            /**
+             * Format: date-time
+             * @description Creation timestamp
+             */
+            createdAt: string;
+            /**
+             * Format: date-time
+             * @description Soft delete timestamp
+             */
+            deletedAt?: string;
+            /**
+             * Format: int64
+             * @description Unique identifier
+             */
+            id: number;
+            /** @description Tag name */
+            name: string;
+            /**
+             * Format: date-time
+             * @description Last update timestamp
+             */
+            updatedAt?: string;
+        };
+        TagsPagedModel: {
+            /** @description List of tags */
+            items: components["schemas"]["TagModel"][] | null;
+            /**
+             * Format: int64
+             * @description Current page number
+             */
+            pageNumber: number;
+            /**
+             * Format: int64
+             * @description Items per page
+             */
+            pageSize: number;
+            /**
+             * Format: int64
+             * @description Total number of matching items
+             */
+            totalCount: number;
+            /**
+             * Format: int64
+             * @description Total number of pages
+             */
+            totalPages: number;
+        };
+        TransactionAccountEmbedded: {
+            /**
+             * Format: int64
+             * @description Account balance
+             */
+            amount: number;
+            /** @description Icon identifier */
+            icon?: string;
+            /** @description Icon color */
+            iconColor?: string;
+            /**
+             * Format: int64
+             * @description Account ID
+             */
+            id: number;
+            /** @description Account name */
+            name: string;
+            /**
+             * @description Account type
+             * @enum {string}
+             */
+            type: "expense" | "income";
+        };
+        TransactionAttachmentModel: {
+            /** @description Hex encoded SHA-256 of the file */
+            checksumSha256: string;
+            /** @description File type detected from its content */
+            contentType: string;
+            /**
+             * Format: date-time
+             * @description Upload timestamp
+             */
+            createdAt: string;
+            /** @description Original file name */
+            fileName: string;
+            /** @description Whether a thumbnail is available, only for JPEG, PNG and GIF images */
+            hasThumbnail: boolean;
+            /**
+             * Format: int64
+             * @description Unique identifier
+             */
+            id: number;
+            /**
+             * Format: int64
+             * @description File size in bytes
+             */
+            sizeBytes: number;
+            /**
+             * Format: int64
+             * @description Transaction the file is attached to
+             */
+            transactionId: number;
+        };
+        TransactionAttachmentsListModel: {
+            /** @description Attachments of the transaction, oldest first */
+            items: components["schemas"]["TransactionAttachmentModel"][] | null;
+        };
+        TransactionCategoryEmbedded: {
+            /** @description Icon identifier */
+            icon?: string;
+            /** @description Icon color */
+            iconColor?: string;
+            /**
+             * Format: int64
+             * @description Category ID
+             */
+            id: number;
+            /** @description Category name */
+            name: string;
+            /**
+             * @description Category type
+             * @enum {string}
+             */
+            type: "expense" | "income" | "transfer";
+        };
+        TransactionCreatedModel: {
+            /** @description Source account details */
+            account: components["schemas"]["TransactionAccountEmbedded"];
+            /**
+             * Format: int64
+             * @description Transaction amount in base currency (IDR)
+             */
+            amount: number;
+            /**
+             * Format: int64
+             * @description Foreign currency amount (as input by user). Null if transaction is in base currency.
+             */
+            amountForeign?: number;
+            /** @description Category details */
+            category: components["schemas"]["TransactionCategoryEmbedded"];
+            /**
+             * Format: date-time
+             * @description Creation timestamp
+             */
+            createdAt: string;
+            /** @description ISO 4217 currency code for foreign amount (e.g., USD, EUR). Null if transaction is in base currency. */
+            currencyCode?: string;
+            /**
+             * Format: date-time
+             * @description Transaction date
+             */
+            date: string;
+            /**
+             * Format: date-time
+             * @description Soft delete timestamp
+             */
+            deletedAt?: string;
+            /** @description Destination account (transfers only) */
+            destinationAccount?: components["schemas"]["TransactionAccountEmbedded"];
+            /**
+             * Format: int64
+             * @description Amount credited to the destination account in base currency, when it differs from amount (transfers only)
+             */
+            destinationAmount?: number;
+            /** @description Version of the record, changed on every update; send it as If-Match to only change or delete the record as it was read */
+            etag?: string;
+            /**
+             * Format: date-time
+             * @description Timestamp when the currency conversion was applied. Null for base currency transactions.
+             */
+            exchangeAt?: string;
+            /**
+             * Format: double
+             * @description Exchange rate applied: foreign_currency → base_currency (IDR). e.g., 16500.5 for USD→IDR. Null if no conversion.
+             */
+            exchangeRate?: number;
+            /** @description Fee taken for the transfer, recorded as a linked expense on the source account (transfers only) */
+            fee?: components["schemas"]["TransactionFeeEmbedded"];
+            /**
+             * Format: int64
+             * @description Unique identifier
+             */
+            id: number;
+            /**
+             * Format: double
+             * @description Transaction latitude
+             */
+            latitude?: number;
+            /**
+             * Format: double
+             * @description Transaction longitude
+             */
+            longitude?: number;
+            /** @description Transaction notes */
+            note?: string;
+            /** @description Merchant or person on the other side of the transaction */
+            payee?: components["schemas"]["TransactionPayeeEmbedded"];
+            /** @description Warning: existing transactions this one may duplicate, most likely first. The transaction is recorded either way */
+            possibleDuplicates?: components["schemas"]["TransactionDuplicateCandidateModel"][] | null;
+            /** @description Split lines dividing the amount between categories, omitted when the transaction is not split */
+            splits?: components["schemas"]["TransactionSplitModel"][] | null;
+            /**
+             * @description Bank status: pending until the bank posts it, cleared once posted, reconciled once matched to a statement. Reconciled transactions cannot be changed or deleted
+             * @enum {string}
+             */
+            status: "pending" | "cleared" | "reconciled";
+            /** @description Transaction tags */
+            tags: components["schemas"]["TransactionTagEmbedded"][] | null;
+            /** @description Associated transaction template details */
+            template: components["schemas"]["TransactionTemplateEmbedded"];
+            /**
+             * @description Transaction type
+             * @enum {string}
+             */
+            type: "expense" | "income" | "transfer";
+            /**
+             * Format: date-time
+             * @description Last update timestamp
+             */
+            updatedAt?: string;
+        };
+        TransactionDuplicateCandidateModel: {
+            /** @description What the two have in common: same_account_amount_type, same_day, within_days, same_note, similar_note, recorded_together */
+            reasons: string[] | null;
+            /**
+             * Format: int64
+             * @description How likely the two are the same transaction, from 0 to 100
+             */
+            score: number;
+            /** @description Existing transaction that may be the same one */
+            transaction: components["schemas"]["TransactionDuplicateEntryModel"];
+        };
+        TransactionDuplicateEntryModel: {
+            /**
+             * Format: int64
+             * @description Source account ID
+             */
+            accountId: number;
+            /**
+             * Format: int64
+             * @description Transaction amount in base currency
+             */
+            amount: number;
+            /**
+             * Format: date-time
+             * @description When the transaction was recorded
+             */
+            createdAt: string;
+            /**
+             * Format: date-time
+             * @description Transaction date
+             */
+            date: string;
+            /**
+             * Format: int64
+             * @description Transaction ID
+             */
+            id: number;
+            /** @description Transaction notes */
+            note?: string;
+            /**
+             * @description Transaction type
+             * @enum {string}
+             */
+            type: "expense" | "income" | "transfer";
+        };
+        TransactionDuplicatePairModel: {
+            /** @description Transaction recorded later that may duplicate it */
+            duplicate: components["schemas"]["TransactionDuplicateEntryModel"];
+            /** @description What the two have in common */
+            reasons: string[] | null;
+            /**
+             * Format: int64
+             * @description How likely the two are the same transaction, from 0 to 100
+             */
+            score: number;
+            /** @description Transaction recorded first */
+            transaction: components["schemas"]["TransactionDuplicateEntryModel"];
+        };
+        TransactionDuplicatesPagedModel: {
+            /** @description Possible duplicate pairs, most likely first */
+            items: components["schemas"]["TransactionDuplicatePairModel"][] | null;
+            /**
+             * Format: int64
+             * @description Current page number
+             */
+            pageNumber: number;
+            /**
+             * Format: int64
+             * @description Items per page
+             */
+            pageSize: number;
+            /**
+             * Format: int64
+             * @description Total number of matching pairs
+             */
+            totalCount: number;
+            /**
+             * Format: int64
+             * @description Total number of pages
+             */
+            totalPages: number;
+        };
+        TransactionFeeEmbedded: {
+            /**
+             * Format: int64
+             * @description Fee amount in base currency
+             */
+            amount: number;
+            /**
+             * Format: int64
+             * @description Expense category of the fee
+             */
+            categoryId: number;
+            /**
+             * Format: int64
+             * @description Fee expense transaction ID
+             */
+            id: number;
+        };
+        TransactionGraphModel: {
+            /** @description Relations between those transactions */
+            edges: components["schemas"]["TransactionRelationModel"][] | null;
+            /**
+             * Format: int64
+             * @description Total of the expense transactions
+             */
+            expenseAmount: number;
+            /**
+             * Format: int64
+             * @description Total of the income transactions
+             */
+            incomeAmount: number;
+            /**
+             * Format: int64
+             * @description Net amount (income - expense); transfers move money between accounts and do not count
+             */
+            net: number;
+            /** @description Transactions connected to it through relations in either direction, itself included, oldest first */
+            nodes: components["schemas"]["TransactionGraphNodeModel"][] | null;
+            /**
+             * Format: int64
+             * @description Transaction the graph was requested for
+             */
+            transactionId: number;
+            /** @description Whether the graph has more transactions than were returned */
+            truncated: boolean;
+        };
+        TransactionGraphNodeModel: {
+            /**
+             * Format: int64
+             * @description Source account ID
+             */
+            accountId: number;
+            /**
+             * Format: int64
+             * @description Transaction amount in base currency
+             */
+            amount: number;
+            /**
+             * Format: date-time
+             * @description Transaction date
+             */
+            date: string;
+            /**
+             * Format: int64
+             * @description Transaction ID
+             */
+            id: number;
+            /** @description Transaction notes */
+            note?: string;
+            /**
+             * @description Transaction type
+             * @enum {string}
+             */
+            type: "expense" | "income" | "transfer";
+        };
+        TransactionMergeResultModel: {
+            /**
+             * Format: int64
+             * @description Number of attachments moved to the kept transaction
+             */
+            attachmentsMoved: number;
+            /**
+             * Format: int64
+             * @description Number of duplicates deleted
+             */
+            mergedCount: number;
+            /**
+             * Format: int64
+             * @description Number of relations moved to the kept transaction
+             */
+            relationsMoved: number;
+            /**
+             * Format: int64
+             * @description Number of tags added to the kept transaction
+             */
+            tagsMoved: number;
+            /** @description Kept transaction after the merge */
+            transaction: components["schemas"]["TransactionModel"];
+        };
+        TransactionModel: {
+            /** @description Source account details */
+            account: components["schemas"]["TransactionAccountEmbedded"];
+            /**
+             * Format: int64
+             * @description Transaction amount in base currency (IDR)
+             */
+            amount: number;
+            /**
+             * Format: int64
+             * @description Foreign currency amount (as input by user). Null if transaction is in base currency.
+             */
+            amountForeign?: number;
+            /** @description Category details */
+            category: components["schemas"]["TransactionCategoryEmbedded"];
+            /**
+             * Format: date-time
+             * @description Creation timestamp
+             */
+            createdAt: string;
+            /** @description ISO 4217 currency code for foreign amount (e.g., USD, EUR). Null if transaction is in base currency. */
+            currencyCode?: string;
+            /**
+             * Format: date-time
+             * @description Transaction date
+             */
+            date: string;
+            /**
+             * Format: date-time
+             * @description Soft delete timestamp
+             */
+            deletedAt?: string;
+            /** @description Destination account (transfers only) */
+            destinationAccount?: components["schemas"]["TransactionAccountEmbedded"];
+            /**
+             * Format: int64
+             * @description Amount credited to the destination account in base currency, when it differs from amount (transfers only)
+             */
+            destinationAmount?: number;
+            /** @description Version of the record, changed on every update; send it as If-Match to only change or delete the record as it was read */
+            etag?: string;
+            /**
+             * Format: date-time
+             * @description Timestamp when the currency conversion was applied. Null for base currency transactions.
+             */
+            exchangeAt?: string;
+            /**
+             * Format: double
+             * @description Exchange rate applied: foreign_currency → base_currency (IDR). e.g., 16500.5 for USD→IDR. Null if no conversion.
+             */
+            exchangeRate?: number;
+            /** @description Fee taken for the transfer, recorded as a linked expense on the source account (transfers only) */
+            fee?: components["schemas"]["TransactionFeeEmbedded"];
+            /**
+             * Format: int64
+             * @description Unique identifier
+             */
+            id: number;
+            /**
+             * Format: double
+             * @description Transaction latitude
+             */
+            latitude?: number;
+            /**
+             * Format: double
+             * @description Transaction longitude
+             */
+            longitude?: number;
+            /** @description Transaction notes */
+            note?: string;
+            /** @description Merchant or person on the other side of the transaction */
+            payee?: components["schemas"]["TransactionPayeeEmbedded"];
+            /** @description Split lines dividing the amount between categories, omitted when the transaction is not split */
+            splits?: components["schemas"]["TransactionSplitModel"][] | null;
+            /**
+             * @description Bank status: pending until the bank posts it, cleared once posted, reconciled once matched to a statement. Reconciled transactions cannot be changed or deleted
+             * @enum {string}
+             */
+            status: "pending" | "cleared" | "reconciled";
+            /** @description Transaction tags */
+            tags: components["schemas"]["TransactionTagEmbedded"][] | null;
+            /** @description Associated transaction template details */
+            template: components["schemas"]["TransactionTemplateEmbedded"];
+            /**
+             * @description Transaction type
+             * @enum {string}
+             */
+            type: "expense" | "income" | "transfer";
+            /**
+             * Format: date-time
+             * @description Last update timestamp
+             */
+            updatedAt?: string;
+        };
+        TransactionPayeeEmbedded: {
+            /**
+             * Format: int64
+             * @description Payee ID
+             */
+            id: number;
+            /** @description Payee name */
+            name: string;
+        };
+        TransactionRelationModel: {
+            /**
+             * Format: date-time
+             * @description Creation timestamp
+             */
+            createdAt: string;
+            /**
+             * Format: date-time
+             * @description Deletion timestamp
+             */
+            deletedAt: string | null;
+            /**
+             * Format: int64
+             * @description Unique identifier
+             */
+            id: number;
+            /**
+             * Format: int64
+             * @description ID of the related transaction
+             */
+            relatedTransactionId: number;
+            /**
+             * @description Type of relation, read from the source transaction to the related one
+             * @enum {string}
+             */
+            relationType: "refund_of" | "fee_of" | "split_of" | "duplicate_of" | "related";
+            /**
+             * Format: int64
+             * @description ID of the source transaction
+             */
+            sourceTransactionId: number;
+            /**
+             * Format: date-time
+             * @description Last update timestamp
+             */
+            updatedAt: string;
+        };
+        TransactionRelationsPagedModel: {
+            /** @description List of transaction relations */
+            items: components["schemas"]["TransactionRelationModel"][] | null;
+            /**
+             * Format: int64
+             * @description Current page number
+             */
+            pageNumber: number;
+            /**
+             * Format: int64
+             * @description Items per page
+             */
+            pageSize: number;
+            /**
+             * Format: int64
+             * @description Total number of matching items
+             */
+            totalCount: number;
+            /**
+             * Format: int64
+             * @description Total number of pages
+             */
+            totalPages: number;
+        };
+        TransactionRevisionBalanceDeltaModel: {
+            /**
+             * Format: int64
+             * @description Account whose balance changed
+             */
+            accountId: number;
+            /**
+             * Format: int64
+             * @description Change applied to the account balance by the update
+             */
+            amount: number;
+        };
+        TransactionRevisionModel: {
+            /**
+             * Format: int64
+             * @description ID of the user who made the update
+             */
+            actorId: number | null;
+            /** @description Values of the changed fields after the update */
+            after: components["schemas"]["TransactionRevisionValuesModel"];
+            /** @description Net change to each account balance, omitted for accounts left unchanged */
+            balanceDeltas: components["schemas"]["TransactionRevisionBalanceDeltaModel"][] | null;
+            /** @description Values of the changed fields before the update */
+            before: components["schemas"]["TransactionRevisionValuesModel"];
+            /** @description Names of the changed fields; a field listed here but missing from before or after was empty */
+            changedFields: string[] | null;
+            /**
+             * Format: date-time
+             * @description When the update was made
+             */
+            createdAt: string;
+            /**
+             * Format: int64
+             * @description Unique identifier
+             */
+            id: number;
+            /**
+             * Format: int64
+             * @description Revision number, counting the updates of the transaction from 1
+             */
+            revision: number;
+            /**
+             * Format: int64
+             * @description Revised transaction
+             */
+            transactionId: number;
+        };
+        TransactionRevisionValuesModel: {
+            /**
+             * Format: int64
+             * @description Source account ID
+             */
+            accountId?: number;
+            /**
+             * Format: int64
+             * @description Transaction amount in base currency
+             */
+            amount?: number;
+            /**
+             * Format: int64
+             * @description Category ID
+             */
+            categoryId?: number;
+            /**
+             * Format: date-time
+             * @description Transaction date
+             */
+            date?: string;
+            /**
+             * Format: int64
+             * @description Destination account ID
+             */
+            destinationAccountId?: number;
+            /**
+             * Format: int64
+             * @description Amount credited to the destination account
+             */
+            destinationAmount?: number;
+            /**
+             * Format: double
+             * @description Transaction latitude
+             */
+            latitude?: number;
+            /**
+             * Format: double
+             * @description Transaction longitude
+             */
+            longitude?: number;
+            /** @description Transaction notes */
+            note?: string;
+            /**
+             * Format: int64
+             * @description Payee ID
+             */
+            payeeId?: number;
+            /**
+             * @description Bank status
+             * @enum {string}
+             */
+            status?: "pending" | "cleared" | "reconciled";
+            /**
+             * @description Transaction type
+             * @enum {string}
+             */
+            type?: "expense" | "income" | "transfer";
+        };
+        TransactionRevisionsListModel: {
+            /** @description Revisions of the transaction, newest first */
+            items: components["schemas"]["TransactionRevisionModel"][] | null;
+        };
+        TransactionRuleActionsModel: {
+            /**
+             * Format: int64
+             * @description Category to set; the rule needs a type condition matching the category type
+             */
+            categoryId?: number;
+            /**
+             * Format: int64
+             * @description Destination account to set; the rule needs the transfer type condition
+             */
+            destinationAccountId?: number;
+            /** @description Note to set, replacing the current one */
+            note?: string;
+            /** @description Tags to add */
+            tagIds?: number[] | null;
+        };
+        TransactionRuleApplyResultModel: {
+            /**
+             * Format: int64
+             * @description Number of transactions in the range that were evaluated
+             */
+            checkedCount: number;
+            /**
+             * Format: int64
+             * @description Total processing time in milliseconds
+             */
+            durationMs: number;
+            /** @description Transactions changed by the rules */
+            updatedIds: number[] | null;
+        };
+        TransactionRuleChangeModel: {
+            /** @description Tags that would be added */
+            addedTagIds: number[] | null;
+            /** @description Values the rules would set */
+            after: components["schemas"]["TransactionRuleValuesModel"];
+            /** @description Current values of the changed fields */
+            before: components["schemas"]["TransactionRuleValuesModel"];
+            /** @description Names of the fields that would change */
+            changedFields: string[] | null;
+            /**
+             * Format: date-time
+             * @description Transaction date
+             */
+            date: string;
+            /** @description Rules that matched the transaction, in evaluation order */
+            ruleIds: number[] | null;
+            /**
+             * Format: int64
+             * @description Transaction that would change
+             */
+            transactionId: number;
+        };
+        TransactionRuleConditionsModel: {
+            /**
+             * Format: int64
+             * @description Source account of the transaction
+             */
+            accountId?: number;
+            /** @description Currency the transaction was entered in; transactions without one are in the base currency */
+            currencyCode?: string;
+            /**
+             * Format: double
+             * @description Latitude of the center of the location condition
+             */
+            latitude?: number;
+            /**
+             * Format: double
+             * @description Longitude of the center of the location condition
+             */
+            longitude?: number;
+            /**
+             * Format: int64
+             * @description Maximum amount in base currency, inclusive
+             */
+            maxAmount?: number;
+            /**
+             * Format: int64
+             * @description Minimum amount in base currency, inclusive
+             */
+            minAmount?: number;
+            /**
+             * @description Regular expression (RE2 syntax) the note must match; prefix with (?i) to ignore case
+             * @example (?i)coffee|espresso
+             */
+            notePattern?: string;
+            /**
+             * Format: int64
+             * @description Radius of the location condition; transactions without coordinates never match it
+             */
+            radiusMeters?: number;
+            /**
+             * @description Transaction type
+             * @enum {string}
+             */
+            type?: "expense" | "income" | "transfer";
+        };
+        TransactionRuleDryRunModel: {
+            /**
+             * Format: int64
+             * @description Number of transactions in the range that were evaluated
+             */
+            checkedCount: number;
+            /** @description Transactions the rule would change, oldest first */
+            items: components["schemas"]["TransactionRuleChangeModel"][] | null;
+        };
+        TransactionRuleModel: {
+            /** @description Changes made to matching transactions */
+            actions: components["schemas"]["TransactionRuleActionsModel"];
+            /** @description Conditions a transaction must all meet */
+            conditions: components["schemas"]["TransactionRuleConditionsModel"];
+            /**
+             * Format: date-time
+             * @description Creation timestamp
+             */
+            createdAt: string;
+            /** @description Whether the rule runs on new transactions and range re-applies */
+            enabled: boolean;
+            /**
+             * Format: int64
+             * @description Unique identifier
+             */
+            id: number;
+            /** @description Rule name */
+            name: string;
+            /**
+             * Format: int64
+             * @description Evaluation order, lowest first; ties are broken by ID
+             */
+            priority: number;
+            /**
+             * Format: date-time
+             * @description Last update timestamp
+             */
+            updatedAt?: string;
+        };
+        TransactionRuleRangeModel: {
+            /**
+             * Format: date-time
+             * @description End of the range of transaction dates
+             */
+            endDate: string;
+            /**
+             * Format: date-time
+             * @description Start of the range of transaction dates
+             */
+            startDate: string;
+        };
+        TransactionRuleValuesModel: {
+            /**
+             * Format: int64
+             * @description Category ID
+             */
+            categoryId?: number;
+            /**
+             * Format: int64
+             * @description Destination account ID
+             */
+            destinationAccountId?: number;
+            /** @description Transaction notes */
+            note?: string;
+        };
+        TransactionRulesPagedModel: {
+            /** @description List of rules in evaluation order */
+            items: components["schemas"]["TransactionRuleModel"][] | null;
+            /**
+             * Format: int64
+             * @description Current page number
+             */
+            pageNumber: number;
+            /**
+             * Format: int64
+             * @description Items per page
+             */
+            pageSize: number;
+            /**
+             * Format: int64
+             * @description Total number of matching items
+             */
+            totalCount: number;
+            /**
+             * Format: int64
+             * @description Total number of pages
+             */
+            totalPages: number;
+        };
+        TransactionSplitLineModel: {
+            /**
+             * Format: int64
+             * @description Line amount in base currency
+             */
+            amount: number;
+            /**
+             * Format: int64
+             * @description Category ID, its type must match the transaction type
+             */
+            categoryId: number;
+            /** @description Optional line notes */
+            note?: string;
+            /** @description Tag IDs for this line */
+            tagIds?: number[] | null;
+        };
+        TransactionSplitModel: {
+            /**
+             * Format: int64
+             * @description Line amount in base currency
+             */
+            amount: number;
+            /** @description Category of this line */
+            category: components["schemas"]["TransactionCategoryEmbedded"];
+            /**
+             * Format: int64
+             * @description Unique identifier
+             */
+            id: number;
+            /** @description Line notes */
+            note?: string;
+            /** @description Line tags */
+            tags: components["schemas"]["TransactionTagEmbedded"][] | null;
+        };
+        TransactionSplitsListModel: {
+            /** @description Split lines of the transaction, empty when it is not split */
+            items: components["schemas"]["TransactionSplitModel"][] | null;
+        };
+        TransactionTagEmbedded: {
+            /**
+             * Format: int64
+             * @description Tag ID
+             */
+            id: number;
+            /** @description Tag name */
+            name: string;
+        };
+        TransactionTagModel: {
+            /**
+             * Format: date-time
+             * @description Creation timestamp
+             */
+            createdAt: string;
+            /**
+             * Format: int64
+             * @description Unique identifier
+             */
+            id: number;
+            /**
+             * Format: int64
+             * @description ID of the tag
+             */
+            tagId: number;
+            /** @description Name of the tag */
+            tagName: string;
+            /**
+             * Format: int64
+             * @description ID of the transaction
+             */
+            transactionId: number;
+        };
+        TransactionTagsPagedModel: {
+            /** @description List of transaction tags */
+            items: components["schemas"]["TransactionTagModel"][] | null;
+            /**
+             * Format: int64
+             * @description Current page number
+             */
+            pageNumber: number;
+            /**
+             * Format: int64
+             * @description Items per page
+             */
+            pageSize: number;
+            /**
+             * Format: int64
+             * @description Total number of matching items
+             */
+            totalCount: number;
+            /**
+             * Format: int64
+             * @description Total number of pages
+             */
+            totalPages: number;
+        };
+        TransactionTemplateEmbedded: {
+            /**
+             * Format: int64
+             * @description Template amount
+             */
+            amount: number;
+            /**
+             * Format: date-time
+             * @description Template end date
+             */
+            endDate: string | null;
+            /**
+             * Format: int64
+             * @description Template ID
+             */
+            id: number;
+            /** @description Template name */
+            name: string;
+            /**
+             * @description Recurrence pattern
+             * @enum {string}
+             */
+            recurrence: "none" | "weekly" | "monthly" | "yearly";
+            /**
+             * Format: date-time
+             * @description Template start date
+             */
+            startDate: string;
+        };
+        TransactionTemplateModel: {
+            /** @description Source account details */
+            account: components["schemas"]["TransactionAccountEmbedded"];
+            /**
+             * Format: int64
+             * @description Template amount in base currency (IDR)
+             */
+            amount: number;
+            /** @description Category details */
+            category: components["schemas"]["TransactionCategoryEmbedded"];
+            /**
+             * Format: date-time
+             * @description Creation timestamp
+             */
+            createdAt: string;
+            /** @description ISO 4217 currency code (null = base currency only) */
+            currencyCode?: string;
+            /**
+             * Format: date-time
+             * @description Soft delete timestamp
+             */
+            deletedAt?: string;
+            /** @description Destination account details (transfers only) */
+            destinationAccount?: components["schemas"]["TransactionAccountEmbedded"];
+            /**
+             * Format: date-time
+             * @description Template end date
+             */
+            endDate: string | null;
+            /**
+             * Format: int64
+             * @description Unique identifier
+             */
+            id: number;
+            /**
+             * Format: date-time
+             * @description Last execution timestamp
+             */
+            lastExecutedAt?: string;
+            /** @description Template name */
+            name: string;
+            /**
+             * Format: date-time
+             * @description Next due date for recurring transactions
+             */
+            nextDueAt: string | null;
+            /** @description Template notes */
+            note?: string;
+            /** @description Payee given to the transactions created from the template */
+            payee?: components["schemas"]["TransactionPayeeEmbedded"];
+            /**
+             * @description Recurrence pattern
+             * @enum {string}
+             */
+            recurrence: "none" | "weekly" | "monthly" | "yearly";
+            /** @description Recurring transaction statistics */
+            recurringStats: components["schemas"]["TransactionTemplateRecurringStats"];
+            /**
+             * Format: date-time
+             * @description Template start date
+             */
+            startDate: string;
+            /**
+             * @description Transaction type
+             * @enum {string}
+             */
+            type: "expense" | "income" | "transfer";
+            /**
+             * Format: date-time
+             * @description Last update timestamp
+             */
+            updatedAt: string;
+        };
+        TransactionTemplateRecurringStats: {
+            /**
+             * Format: int64
+             * @description Number of related transactions created
+             */
+            occurrences: number;
+            /**
+             * Format: int64
+             * @description Number of remaining occurrences (null if no end date)
+             */
+            remaining: number | null;
+            /**
+             * Format: int64
+             * @description Total amount spent from related transactions
+             */
+            totalSpent: number;
+        };
+        TransactionTemplatesPagedModel: {
+            /** @description List of transaction templates */
+            items: components["schemas"]["TransactionTemplateModel"][] | null;
+            /**
+             * Format: int64
+             * @description Current page number
+             */
+            pageNumber: number;
+            /**
+             * Format: int64
+             * @description Items per page
+             */
+            pageSize: number;
+            /**
+             * Format: int64
+             * @description Total number of matching items
+             */
+            totalCount: number;
+            /**
+             * Format: int64
+             * @description Total number of pages
+             */
+            totalPages: number;
+        };
+        TransactionsPagedModel: {
+            /** @description List of transactions */
+            items: components["schemas"]["TransactionModel"][] | null;
+            /** @description Pass as after to get the next page; omitted on the last page and when not sorted by date */
+            nextCursor?: string;
+            /**
+             * Format: int64
+             * @description Current page number
+             */
+            pageNumber: number;
+            /**
+             * Format: int64
+             * @description Items per page
+             */
+            pageSize: number;
+            /** @description Pass as before to get the previous page; omitted on the first page and when not sorted by date */
+            prevCursor?: string;
+            /**
+             * Format: int64
+             * @description Total number of matching items
+             */
+            totalCount: number;
+            /**
+             * Format: int64
+             * @description Total number of pages
+             */
+            totalPages: number;
+        };
+        TrashItemModel: {
+            /**
+             * Format: int64
+             * @description Amount of deleted transactions and templates
+             */
+            amount?: number;
+            /**
+             * Format: date-time
+             * @description Deletion timestamp
+             */
+            deletedAt: string;
+            /**
+             * @description Kind of deleted record
+             * @enum {string}
+             */
+            entityType: "transaction" | "account" | "category" | "tag" | "payee" | "transaction_template";
+            /**
+             * Format: int64
+             * @description Identifier of the deleted record, used by its restore endpoint
+             */
+            id: number;
+            /** @description Name of the record; the note or type for transactions */
+            name: string;
+            /**
+             * Format: date-time
+             * @description Earliest time the record is permanently removed; accounts, categories and payees still used by other records are kept longer
+             */
+            purgeAt: string;
+        };
+        TrashPagedModel: {
+            /** @description Deleted records, most recently deleted first */
+            items: components["schemas"]["TrashItemModel"][] | null;
+            /**
+             * Format: int64
+             * @description Current page number
+             */
+            pageNumber: number;
+            /**
+             * Format: int64
+             * @description Items per page
+             */
+            pageSize: number;
+            /**
+             * Format: int64
+             * @description Total number of matching items
+             */
+            totalCount: number;
+            /**
+             * Format: int64
+             * @description Total number of pages
+             */
+            totalPages: number;
+        };
+        TwoFactorCodeRequestModel: {
+            /** @description Current code from the authenticator app */
+            code?: string;
+            /** @description Single-use recovery code, used instead of code */
+            recovery_code?: string;
+        };
+        TwoFactorEnrollResponseModel: {
+            /** @description otpauth:// URI to render as a QR code */
+            provisioningUri: string;
+            /** @description Base32 TOTP secret for manual entry */
+            secret: string;
+        };
+        TwoFactorRecoveryCodesModel: {
+            /** @description Single-use recovery codes, only shown once */
+            recoveryCodes: string[] | null;
+        };
+        UpdateAccountModel: {
+            /** @description Archive status (null string to unarchive, any other value to archive) */
+            archivedAt?: string;
+            /** @description Icon identifier */
+            icon?: string;
+            /** @description Icon color code */
+            iconColor?: string;
+            /** @description Account name */
+            name?: string;
+            /** @description Account notes */
+            note?: string;
+            /**
+             * @description Account type (expense or income)
+             * @enum {string}
+             */
+            type?: "expense" | "income";
+        };
+        UpdateBudgetRequestModel: {
+            /**
+             * Format: int64
+             * @description Budget limit amount in cents
+             */
+            amountLimit?: number;
+        };
+        UpdateBudgetTemplateModel: {
+            /** @description Whether this template is active and generating budgets */
+            active?: boolean;
+            /**
+             * Format: int64
+             * @description Budget limit amount in cents
+             */
+            amountLimit?: number;
+            /** @description Template name */
+            name?: string;
+            /** @description Optional note for the template */
+            note?: string;
+        };
+        UpdateCategoryModel: {
+            /** @description Archive status (null string to unarchive, any other value to archive) */
+            archivedAt?: string;
+            /** @description Icon identifier */
+            icon?: string;
+            /** @description Icon color code */
+            iconColor?: string;
+            /** @description Category name */
+            name?: string;
+            /** @description Category notes */
+            note?: string;
+            /**
+             * @description Category type
+             * @enum {string}
+             */
+            type?: "expense" | "income" | "transfer";
+        };
+        UpdatePayeeModel: {
+            /** @description Other names of the payee; replaces the current list when provided */
+            aliases?: string[] | null;
+            /** @description Payee name */
+            name?: string;
+        };
+        UpdateReimbursementModel: {
+            /** @description Who is expected to pay the expense back */
+            counterparty?: string;
+            /**
+             * Format: int64
+             * @description Amount expected to be paid back in base currency
+             */
+            expectedAmount?: number;
+            /** @description Reimbursement notes */
+            note?: string;
+        };
+        UpdateTagModel: {
+            /** @description Tag color code */
+            color?: string;
+            /** @description Tag name */
+            name?: string;
+        };
+        UpdateTransactionModel: {
+            /**
+             * Format: int64
+             * @description Source account ID
+             */
+            accountId?: number;
+            /**
+             * Format: int64
+             * @description Transaction amount in the specified currency
+             */
+            amount?: number;
+            /**
+             * Format: int64
+             * @description Category ID
+             */
+            categoryId?: number;
+            /** @description ISO 4217 currency code (e.g., USD, EUR) */
+            currencyCode?: string;
+            /**
+             * Format: date-time
+             * @description Transaction date
+             */
+            date?: string;
+            /**
+             * Format: int64
+             * @description Destination account ID (transfers only)
+             */
+            destinationAccountId?: number;
+            /**
+             * Format: int64
+             * @description Amount credited to the destination account in base currency; 0 credits the amount again (transfers only)
+             */
+            destinationAmount?: number;
+            /**
+             * Format: int64
+             * @description Fee taken for the transfer in base currency; 0 removes the fee (transfers only)
+             */
+            feeAmount?: number;
+            /**
+             * Format: int64
+             * @description Expense category of the fee; required when adding a fee
+             */
+            feeCategoryId?: number;
+            /**
+             * Format: double
+             * @description Transaction latitude
+             */
+            latitude?: number;
+            /**
+             * Format: double
+             * @description Transaction longitude
+             */
+            longitude?: number;
+            /** @description Transaction notes */
+            note?: string;
+            /**
+             * Format: int64
+             * @description Payee ID; 0 removes the payee
+             */
+            payeeId?: number;
+            /**
+             * @description Bank status; reconciled is only set by completing a reconciliation
+             * @enum {string}
+             */
+            status?: "pending" | "cleared";
+            /**
+             * @description Transaction type
+             * @enum {string}
+             */
+            type?: "expense" | "income" | "transfer";
+        };
+        UpdateTransactionRuleModel: {
+            /** @description Actions; replaces all current actions when provided */
+            actions?: components["schemas"]["TransactionRuleActionsModel"];
+            /** @description Conditions; replaces all current conditions when provided */
+            conditions?: components["schemas"]["TransactionRuleConditionsModel"];
+            /** @description Whether the rule runs */
+            enabled?: boolean;
+            /** @description Rule name */
+            name?: string;
+            /**
+             * Format: int64
+             * @description Evaluation order, lowest first
+             */
+            priority?: number;
+        };
+        UpdateTransactionTemplateModel: {
+            /**
+             * Format: int64
+             * @description Source account ID
+             */
+            accountId?: number;
+            /**
+             * Format: int64
+             * @description Template amount in base currency (IDR)
+             */
+            amount?: number;
+            /**
+             * Format: int64
+             * @description Category ID
+             */
+            categoryId?: number;
+            /** @description ISO 4217 currency code (null = base currency only, affects future-dated transactions) */
+            currencyCode?: string;
+            /**
+             * Format: int64
+             * @description Destination account ID (transfers only)
+             */
+            destinationAccountId?: number;
+            /**
+             * Format: date-time
+             * @description Template end date
+             */
+            endDate?: string;
+            /** @description Template name */
+            name?: string;
+            /** @description Template notes */
+            note?: string;
             /**
              * Format: int64
-             * @description Median income amount in cents
-             * @example 100000
+             * @description Payee ID; 0 removes the payee
              */
-            medianIncomeAmount: number;
+            payeeId?: number;
             /**
-             * Format: int64
-             * @description Minimum expense transaction amount in cents
-             * @example 5000
+             * @description Recurrence pattern
+             * @enum {string}
              */
-            minAmount: number;
+            recurrence?: "none" | "weekly" | "monthly" | "yearly";
             /**
-             * Format: int64
-             * @description Minimum income amount in cents
-             * @example 50000
+             * @description Transaction type
+             * @enum {string}
              */
-            minIncomeAmount: number;
+            type?: "expense" | "income" | "transfer";
+        };
+        UpsertShareModel: {
+            /**
+             * @description Role to grant, replaces the current role when already shared
+             * @enum {string}
+             */
+            role: "viewer" | "editor";
+            /** @description Username to share with */
+            username: string;
+        };
+        UserModel: {
+            /**
+             * Format: date-time
+             * @description Creation timestamp
+             */
+            createdAt: string;
+            /**
+             * Format: date-time
+             * @description Soft delete timestamp
+             */
+            deletedAt?: string;
             /**
              * Format: int64
-             * @description Total number of transactions
-             * @example 120
+             * @description Unique identifier
              */
-            transactionCount: number;
+            id: number;
+            /** @description Whether the user may register other users */
+            isAdmin: boolean;
+            /** @description Whether TOTP two-factor authentication is enabled */
+            twoFactorEnabled: boolean;
+            /**
+             * Format: date-time
+             * @description Last update timestamp
+             */
+            updatedAt?: string;
+            /** @description Login username */
+            username: string;
+        };
+    };
+    responses: never;
+    parameters: never;
+    requestBodies: never;
+    headers: never;
+    pathItems: never;
+}
+export type $defs = Record<string, never>;
+export interface operations {
+    "list-accounts": {
+        parameters: {
+            query?: {
+                /** @description Page number for pagination */
+                pageNumber?: number;
+                /** @description Number of items per page */
+                pageSize?: number;
+                /** @description Field to sort by (name, type, amount, displayOrder, createdAt, updatedAt) */
+                sortBy?: "name" | "type" | "amount" | "displayOrder" | "createdAt" | "updatedAt";
+                /** @description Sort order (asc or desc) */
+                sortOrder?: "asc" | "desc";
+                /** @description Filter by account IDs */
+                id?: number[] | null;
+                /** @description Filter by account name (partial match) */
+                name?: string;
+                /** @description Filter by account type (expense or income) */
+                type?: ("expense" | "income")[] | null;
+                /** @description Filter by archived status (true or false) */
+                archived?: string;
+            };
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["AccountsPagedModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "create-account": {
+        parameters: {
+            query?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["CreateAccountModel"];
+            };
+        };
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["AccountModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "reorder-accounts": {
+        parameters: {
+            query?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["ReorderAccountsModel"];
+            };
+        };
+        responses: {
+            /** @description No Content */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "list-account-reconciliations": {
+        parameters: {
+            query?: {
+                /** @description Page number for pagination */
+                pageNumber?: number;
+                /** @description Number of items per page */
+                pageSize?: number;
+            };
+            header?: never;
+            path: {
+                /** @description Account ID */
+                accountId: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["AccountReconciliationsPagedModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "create-account-reconciliation": {
+        parameters: {
+            query?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
+            path: {
+                /** @description Account ID */
+                accountId: number;
+            };
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["CreateAccountReconciliationModel"];
+            };
+        };
+        responses: {
+            /** @description Created */
+            201: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["AccountReconciliationDetailModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "get-account-reconciliation": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Account ID */
+                accountId: number;
+                /** @description Reconciliation ID */
+                reconciliationId: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["AccountReconciliationDetailModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "delete-account-reconciliation": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Account ID */
+                accountId: number;
+                /** @description Reconciliation ID */
+                reconciliationId: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description No Content */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "complete-account-reconciliation": {
+        parameters: {
+            query?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
+            path: {
+                /** @description Account ID */
+                accountId: number;
+                /** @description Reconciliation ID */
+                reconciliationId: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["AccountReconciliationDetailModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "get-account": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /**
+                 * @description Unique identifier of the account
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    ETag?: string;
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["AccountModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "delete-account": {
+        parameters: {
+            query?: never;
+            header?: {
+                /** @description ETag the account was read with; the request fails with 412 when it has changed since */
+                "If-Match"?: string;
+            };
+            path: {
+                /**
+                 * @description Unique identifier of the account
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description No Content */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "update-account": {
+        parameters: {
+            query?: never;
+            header?: {
+                /** @description ETag the account was read with; the request fails with 412 when it has changed since */
+                "If-Match"?: string;
+            };
+            path: {
+                /**
+                 * @description Unique identifier of the account
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["UpdateAccountModel"];
+            };
+        };
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    ETag?: string;
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["AccountModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "restore-account": {
+        parameters: {
+            query?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
+            path: {
+                /**
+                 * @description Unique identifier of the account
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["AccountModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "list-account-shares": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /**
+                 * @description Unique identifier of the account
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["SharesListModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "share-account": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /**
+                 * @description Unique identifier of the account
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["UpsertShareModel"];
+            };
+        };
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["SharesListModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "unshare-account": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /**
+                 * @description Unique identifier of the account
+                 * @example 1
+                 */
+                id: number;
+                /**
+                 * @description ID of the user to revoke access from
+                 * @example 2
+                 */
+                userId: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description No Content */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "get-account-statistics": {
+        parameters: {
+            query: {
+                /**
+                 * @description Start date for filtering (ISO 8601 format)
+                 * @example 2024-01-01T00:00:00Z
+                 */
+                startDate: string;
+                /**
+                 * @description End date for filtering (ISO 8601 format)
+                 * @example 2024-12-31T23:59:59Z
+                 */
+                endDate: string;
+            };
+            header?: never;
+            path: {
+                /**
+                 * @description Unique identifier of the account
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["AccountStatisticsResponse"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "get-budget-health": {
+        parameters: {
+            query: {
+                /**
+                 * @description Start date for filtering (ISO 8601 format)
+                 * @example 2024-01-01T00:00:00Z
+                 */
+                startDate: string;
+                /**
+                 * @description End date for filtering (ISO 8601 format)
+                 * @example 2024-12-31T23:59:59Z
+                 */
+                endDate: string;
+            };
+            header?: never;
+            path: {
+                /**
+                 * @description Unique identifier of the account
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["AccountStatisticsBudgetHealthModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "get-burn-rate": {
+        parameters: {
+            query: {
+                /**
+                 * @description Start date for filtering (ISO 8601 format)
+                 * @example 2024-01-01T00:00:00Z
+                 */
+                startDate: string;
+                /**
+                 * @description End date for filtering (ISO 8601 format)
+                 * @example 2024-12-31T23:59:59Z
+                 */
+                endDate: string;
+            };
+            header?: never;
+            path: {
+                /**
+                 * @description Unique identifier of the account
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["AccountStatisticsBurnRateModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "get-cash-flow-pulse": {
+        parameters: {
+            query: {
+                /**
+                 * @description Start date for filtering (ISO 8601 format)
+                 * @example 2024-01-01T00:00:00Z
+                 */
+                startDate: string;
+                /**
+                 * @description End date for filtering (ISO 8601 format)
+                 * @example 2024-12-31T23:59:59Z
+                 */
+                endDate: string;
+            };
+            header?: never;
+            path: {
+                /**
+                 * @description Unique identifier of the account
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["AccountStatisticsCashFlowPulseModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "get-category-heatmap": {
+        parameters: {
+            query: {
+                /**
+                 * @description Start date for filtering (ISO 8601 format)
+                 * @example 2024-01-01T00:00:00Z
+                 */
+                startDate: string;
+                /**
+                 * @description End date for filtering (ISO 8601 format)
+                 * @example 2024-12-31T23:59:59Z
+                 */
+                endDate: string;
+            };
+            header?: never;
+            path: {
+                /**
+                 * @description Unique identifier of the account
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["AccountStatisticsCategoryHeatmapModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "get-monthly-velocity": {
+        parameters: {
+            query: {
+                /**
+                 * @description Start date for filtering (ISO 8601 format)
+                 * @example 2024-01-01T00:00:00Z
+                 */
+                startDate: string;
+                /**
+                 * @description End date for filtering (ISO 8601 format)
+                 * @example 2024-12-31T23:59:59Z
+                 */
+                endDate: string;
+            };
+            header?: never;
+            path: {
+                /**
+                 * @description Unique identifier of the account
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["AccountStatisticsMonthlyVelocityModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "get-time-frequency": {
+        parameters: {
+            query: {
+                /**
+                 * @description Start date for filtering (ISO 8601 format)
+                 * @example 2024-01-01T00:00:00Z
+                 */
+                startDate: string;
+                /**
+                 * @description End date for filtering (ISO 8601 format)
+                 * @example 2024-12-31T23:59:59Z
+                 */
+                endDate: string;
+            };
+            header?: never;
+            path: {
+                /**
+                 * @description Unique identifier of the account
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["AccountStatisticsTimeFrequencyHeatmapModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "list-audit-logs": {
+        parameters: {
+            query?: {
+                /** @description Page number for pagination */
+                pageNumber?: number;
+                /** @description Number of items per page */
+                pageSize?: number;
+                /** @description Sort order by creation time */
+                sortOrder?: "asc" | "desc";
+                /** @description Filter by entity types */
+                entityType?: ("account" | "category" | "tag" | "payee" | "transaction" | "transaction_tag" | "transaction_relation" | "transaction_attachment" | "transaction_template" | "transaction_rule" | "reimbursement" | "budget_template" | "budget" | "share" | "account_reconciliation")[] | null;
+                /** @description Filter by entity ID */
+                entityId?: number;
+                /** @description Filter by the user who made the change */
+                actorId?: number;
+                /** @description Filter by operations */
+                operation?: ("create" | "update" | "delete" | "restore")[] | null;
+                /** @description Only include changes made at or after this time */
+                startDate?: string;
+                /** @description Only include changes made at or before this time */
+                endDate?: string;
+            };
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["AuditLogsPagedModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "disable-two-factor": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["TwoFactorCodeRequestModel"];
+            };
+        };
+        responses: {
+            /** @description No Content */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "enroll-two-factor": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["TwoFactorEnrollResponseModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "regenerate-two-factor-recovery-codes": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["TwoFactorCodeRequestModel"];
+            };
+        };
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["TwoFactorRecoveryCodesModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "verify-two-factor": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["TwoFactorCodeRequestModel"];
+            };
+        };
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["TwoFactorRecoveryCodesModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        CategoryStatisticBudgetUtilizationEntry: {
-            /**
-             * Format: int64
-             * @description Budget ID
-             * @example 1
-             */
-            budgetId: number;
-            /**
-             * Format: int64
-             * @description Budget limit in cents
-             * @example 1000000
-             */
-            limit: number;
-            /**
-             * @description Budget name
-             * @example Food Budget
-             */
-            name: string;
-            /**
-             * Format: date-time
-             * @description Budget period end date
-             */
-            periodEnd: string;
-            /**
-             * Format: date-time
-             * @description Budget period start date
-             */
-            periodStart: string;
-            /**
-             * Format: int64
-             * @description Remaining budget in cents
-             * @example 250000
-             */
-            remaining: number;
-            /**
-             * Format: int64
-             * @description Amount spent in cents
-             * @example 750000
-             */
-            spent: number;
-            /**
-             * Format: double
-             * @description Percentage of budget utilized
-             * @example 75
-             */
-            utilization: number;
+    };
+    "change-password": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["ChangePasswordRequestModel"];
+            };
+        };
+        responses: {
+            /** @description No Content */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    login: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["LoginRequestModel"];
+            };
+        };
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["LoginResponseModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "login-verify": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["LoginVerifyRequestModel"];
+            };
+        };
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["LoginResponseModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    logout: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: {
+            content: {
+                "application/json": components["schemas"]["LogoutRequestModel"];
+            };
+        };
+        responses: {
+            /** @description No Content */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "logout-all": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description No Content */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "oidc-authorize": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["OIDCAuthorizeResponseModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "oidc-callback": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["OIDCCallbackRequestModel"];
+            };
+        };
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["LoginResponseModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    refresh: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        CategoryStatisticBudgetUtilizationModel: {
-            /** @description Budget utilization data */
-            budgets: components["schemas"]["CategoryStatisticBudgetUtilizationEntry"][] | null;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["RefreshRequestModel"];
+            };
         };
-        CategoryStatisticDayOfWeekPatternEntry: {
-            /**
-             * Format: int64
-             * @description Average expense amount on this day in cents
-             * @example 25000
-             */
-            averageAmount: number;
-            /**
-             * @description Day of week
-             * @example Friday
-             */
-            dayOfWeek: string;
-            /**
-             * Format: int64
-             * @description Average expense amount in cents
-             * @example 33333
-             */
-            expenseAverage: number;
-            /**
-             * Format: int64
-             * @description Expense transactions on this day
-             * @example 15
-             */
-            expenseCount: number;
-            /**
-             * Format: int64
-             * @description Total expense amount in cents
-             * @example 500000
-             */
-            expenseTotal: number;
-            /**
-             * Format: int64
-             * @description Average income amount in cents
-             * @example 150000
-             */
-            incomeAverage: number;
-            /**
-             * Format: int64
-             * @description Income transactions on this day
-             * @example 5
-             */
-            incomeCount: number;
-            /**
-             * Format: int64
-             * @description Total income amount in cents
-             * @example 750000
-             */
-            incomeTotal: number;
-            /**
-             * Format: int64
-             * @description Total expense amount on this day in cents
-             * @example 500000
-             */
-            totalAmount: number;
-            /**
-             * Format: int64
-             * @description Number of transactions on this day
-             * @example 20
-             */
-            transactionCount: number;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["RefreshResponseModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        CategoryStatisticDayOfWeekPatternModel: {
-            /** @description Spending pattern by day of week */
-            data: components["schemas"]["CategoryStatisticDayOfWeekPatternEntry"][] | null;
-            /**
-             * @description Day with highest expense total
-             * @example Saturday
-             */
-            highestSpendDay: string;
-            /**
-             * @description Day with highest transaction count
-             * @example Friday
-             */
-            mostActiveDay: string;
-            /**
-             * Format: int64
-             * @description Total transactions across all days
-             * @example 150
-             */
-            totalTransactions: number;
+    };
+    "list-sessions": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        CategoryStatisticSpendingVelocityDataPoint: {
-            /**
-             * Format: int64
-             * @description Total expense amount in cents (legacy field)
-             * @example 2500000
-             */
-            amount: number;
-            /**
-             * Format: int64
-             * @description Total expense in cents
-             * @example 2500000
-             */
-            expenseAmount: number;
-            /**
-             * Format: int64
-             * @description Expense transaction count
-             * @example 35
-             */
-            expenseCount: number;
-            /**
-             * Format: int64
-             * @description Total income in cents
-             * @example 5000000
-             */
-            incomeAmount: number;
-            /**
-             * Format: int64
-             * @description Income transaction count
-             * @example 10
-             */
-            incomeCount: number;
-            /**
-             * Format: double
-             * @description Percentage of income vs total volume
-             * @example 66.67
-             */
-            incomePercentage: number;
-            /**
-             * @description Month (YYYY-MM)
-             * @example 2024-01
-             */
-            month: string;
-            /**
-             * Format: int64
-             * @description Net flow (income - expense) in cents
-             * @example 2500000
-             */
-            net: number;
-            /**
-             * Format: int64
-             * @description Total transactions in month
-             * @example 45
-             */
-            totalCount: number;
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["SessionsListModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        CategoryStatisticSpendingVelocityModel: {
-            /**
-             * Format: int64
-             * @description Average monthly expense in cents
-             * @example 2500000
-             */
-            averageMonthlySpend: number;
-            /** @description Monthly spending data for line chart */
-            data: components["schemas"]["CategoryStatisticSpendingVelocityDataPoint"][] | null;
-            /**
-             * Format: int64
-             * @description Total net (income - expense) in cents
-             * @example 20000000
-             */
-            netFlow: number;
-            /**
-             * Format: int64
-             * @description Sum of all expense across months in cents
-             * @example 30000000
-             */
-            totalExpense: number;
-            /**
-             * Format: int64
-             * @description Sum of all income across months in cents
-             * @example 50000000
-             */
-            totalIncome: number;
-            /**
-             * @description Spending trend direction
-             * @example stable
-             * @enum {string}
-             */
-            trendDirection: "increasing" | "decreasing" | "stable";
+    };
+    "revoke-session": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /**
+                 * @description Unique identifier of the session
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description No Content */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "list-api-tokens": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        CategoryStatisticsResponse: {
-            /** @description Which accounts pay for this category */
-            accountDistribution: components["schemas"]["CategoryStatisticAccountDistributionModel"];
-            /** @description Typical transaction amounts */
-            averageTransactionSize: components["schemas"]["CategoryStatisticAverageTransactionSizeModel"];
-            /** @description Budget progress */
-            budgetUtilization: components["schemas"]["CategoryStatisticBudgetUtilizationModel"];
-            /** @description Spending patterns by day of week */
-            dayOfWeekPattern: components["schemas"]["CategoryStatisticDayOfWeekPatternModel"];
-            /** @description Spending trend over time */
-            spendingVelocity: components["schemas"]["CategoryStatisticSpendingVelocityModel"];
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["APITokensListModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        CreateAccountModel: {
-            /** @description Icon identifier */
-            icon?: string;
-            /** @description Icon color code */
-            iconColor?: string;
-            /** @description Account name */
-            name: string;
-            /** @description Optional account notes */
-            note: string;
-            /**
-             * @description Account type (expense or income)
-             * @enum {string}
-             */
-            type: "expense" | "income";
+    };
+    "create-api-token": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        CreateBudgetTemplateModel: {
-            /**
-             * Format: int64
-             * @description Account ID to filter transactions
-             */
-            accountId?: number;
-            /**
-             * @description Whether this template is active and generating budgets
-             * @default true
-             */
-            active: boolean;
-            /**
-             * Format: int64
-             * @description Budget limit amount in cents
-             */
-            amountLimit: number;
-            /**
-             * Format: int64
-             * @description Category ID to filter transactions
-             */
-            categoryId?: number;
-            /**
-             * Format: date-time
-             * @description Optional end date for recurrence
-             */
-            endDate?: string;
-            /** @description Template name */
-            name: string;
-            /** @description Optional note for the template */
-            note?: string;
-            /**
-             * @description Recurrence pattern
-             * @enum {string}
-             */
-            recurrence: "none" | "weekly" | "monthly" | "yearly";
-            /**
-             * Format: date-time
-             * @description Start date for recurrence
-             */
-            startDate: string;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["CreateAPITokenModel"];
+            };
         };
-        CreateCategoryModel: {
-            /** @description Icon identifier */
-            icon?: string;
-            /** @description Icon color code */
-            iconColor?: string;
-            /** @description Category name */
-            name: string;
-            /** @description Optional category notes */
-            note: string;
-            /**
-             * @description Category type
-             * @enum {string}
-             */
-            type: "expense" | "income" | "transfer";
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["CreateAPITokenResponseModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        CreateTagModel: {
-            /** @description Tag color code */
-            color?: string;
-            /** @description Tag name */
-            name: string;
+    };
+    "revoke-api-token": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /**
+                 * @description Unique identifier of the token
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
         };
-        CreateTransactionModel: {
-            /**
-             * Format: int64
-             * @description Source account ID
-             */
-            accountId: number;
-            /**
-             * Format: int64
-             * @description Transaction amount in the specified currency (defaults to base currency if currencyCode not provided)
-             */
-            amount: number;
-            /**
-             * Format: int64
-             * @description Category ID
-             */
-            categoryId: number;
-            /** @description ISO 4217 currency code (e.g., USD, EUR). If omitted, amount is treated as base currency. */
-            currencyCode?: string;
-            /**
-             * Format: date-time
-             * @description Transaction date
-             */
-            date: string;
-            /**
-             * Format: int64
-             * @description Destination account ID (transfers only)
-             */
-            destinationAccountId?: number;
-            /**
-             * Format: double
-             * @description Transaction latitude
-             */
-            latitude?: number;
-            /**
-             * Format: double
-             * @description Transaction longitude
-             */
-            longitude?: number;
-            /** @description Optional transaction notes */
-            note?: string;
-            /**
-             * @description Transaction type
-             * @enum {string}
-             */
-            type: "expense" | "income" | "transfer";
+        requestBody?: never;
+        responses: {
+            /** @description No Content */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        CreateTransactionRelationModel: {
-            /**
-             * Format: int64
-             * @description ID of the source transaction
-             */
-            SourceTransactionID: number;
-            /**
-             * Format: int64
-             * @description ID of the related transaction
-             */
-            relatedTransactionId: number;
-            /** @description Type of relation */
-            relationType: string;
+    };
+    "list-budget-templates": {
+        parameters: {
+            query?: {
+                /** @description Page number for pagination */
+                pageNumber?: number;
+                /** @description Number of items per page */
+                pageSize?: number;
+                /** @description Field to sort by */
+                sortBy?: "id" | "accountId" | "categoryId" | "amountLimit" | "recurrence" | "startDate" | "endDate" | "name" | "active" | "nextRunAt" | "createdAt" | "updatedAt";
+                /** @description Sort order */
+                sortOrder?: "asc" | "desc";
+                /** @description Filter by template IDs */
+                id?: number[] | null;
+                /** @description Filter by account IDs */
+                accountId?: number[] | null;
+                /** @description Filter by category IDs */
+                categoryId?: number[] | null;
+                /** @description Filter by recurrence pattern */
+                recurrence?: "none" | "weekly" | "monthly" | "yearly";
+                /** @description Filter by active status */
+                active?: boolean;
+            };
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["BudgetTemplatesPagedModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        CreateTransactionTagModel: {
-            /**
-             * Format: int64
-             * @description ID of the tag
-             */
-            tagId: number;
-            /**
-             * Format: int64
-             * @description ID of the transaction
-             */
-            transactionId?: number;
+    };
+    "create-budget-template": {
+        parameters: {
+            query?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
+            path?: never;
+            cookie?: never;
         };
-        CreateTransactionTemplateModel: {
-            /**
-             * Format: int64
-             * @description Source account ID
-             */
-            accountId: number;
-            /**
-             * Format: int64
-             * @description Template amount in base currency (IDR)
-             */
-            amount: number;
-            /**
-             * Format: int64
-             * @description Category ID
-             */
-            categoryId: number;
-            /** @description ISO 4217 currency code (optional, defaults to base currency) */
-            currencyCode?: string;
-            /**
-             * Format: int64
-             * @description Destination account ID (transfers only)
-             */
-            destinationAccountId?: number;
-            /**
-             * Format: date-time
-             * @description Template end date
-             */
-            endDate?: string;
-            /** @description Template name */
-            name: string;
-            /** @description Template notes */
-            note?: string;
-            /**
-             * @description Recurrence pattern
-             * @enum {string}
-             */
-            recurrence: "none" | "weekly" | "monthly" | "yearly";
-            /**
-             * Format: date-time
-             * @description Template start date
-             */
-            startDate: string;
-            /**
-             * @description Transaction type
-             * @enum {string}
-             */
-            type: "expense" | "income" | "transfer";
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["CreateBudgetTemplateModel"];
+            };
         };
-        EmbeddedBudget: {
-            /**
-             * Format: int64
-             * @description Account ID to filter transactions
-             */
-            accountId?: number;
-            /**
-             * Format: int64
-             * @description Actual spent amount in cents
-             */
-            actualAmount: number;
-            /**
-             * Format: int64
-             * @description Budget limit amount in cents
-             */
-            amountLimit: number;
-            /**
-             * Format: int64
-             * @description Category ID to filter transactions
-             */
-            categoryId?: number;
-            /**
-             * Format: int64
-             * @description Budget unique identifier
-             */
-            id: number;
-            /** @description Budget name */
-            name: string;
-            /**
-             * Format: date-time
-             * @description Budget period end date
-             */
-            periodEnd: string;
-            /**
-             * Format: date-time
-             * @description Budget period start date
-             */
-            periodStart: string;
-            /** @description Budget period type */
-            periodType: string;
-            /**
-             * Format: int64
-             * @description Budget template ID if generated from template
-             */
-            templateId?: number;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["BudgetTemplateModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        ErrorDetail: {
-            /** @description Where the error occurred, e.g. 'body.items[3].tags' or 'path.thing-id' */
-            location?: string;
-            /** @description Error message text */
-            message?: string;
-            /** @description The value at the given location */
-            value?: unknown;
+    };
+    "get-budget-template": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Budget Template ID */
+                id: number;
+            };
+            cookie?: never;
         };
-        ErrorModel: {
-            /**
-             * @description A human-readable explanation specific to this occurrence of the problem.
-             * @example Property foo is required but is missing.
-             */
-            detail?: string;
-            /** @description Optional list of individual error details */
-            errors?: components["schemas"]["ErrorDetail"][] | null;
-            /**
-             * Format: uri
-             * @description A URI reference that identifies the specific occurrence of the problem.
-             * @example https://example.com/error-log/abc123
-             */
-            instance?: string;
-            /**
-             * Format: int64
-             * @description HTTP status code
-             * @example 400
-             */
-            status?: number;
-            /**
-             * @description A short, human-readable summary of the problem type. This value should not change between occurrences of the error.
-             * @example Bad Request
-             */
-            title?: string;
-            /**
-             * Format: uri
-             * @description A URI reference to human-readable documentation for the error.
-             * @default about:blank
-             * @example https://example.com/errors/example
-             */
-            type: string;
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    ETag?: string;
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["BudgetTemplateModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        LoginRequestModel: {
-            /** @description Admin password */
-            password: string;
-            /** @description Admin username */
-            username: string;
+    };
+    "update-budget-template": {
+        parameters: {
+            query?: never;
+            header?: {
+                /** @description ETag the budget template was read with; the request fails with 412 when it has changed since */
+                "If-Match"?: string;
+            };
+            path: {
+                /** @description Budget Template ID */
+                id: number;
+            };
+            cookie?: never;
         };
-        LoginResponseModel: {
-            /** @description Access token valid for 7 days */
-            access_token: string;
-            /** @description Refresh token valid for 30 days */
-            refresh_token: string;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["UpdateBudgetTemplateModel"];
+            };
         };
-        RefreshGeoCache: {
-            /**
-             * Format: double
-             * @description User's latitude for distance-based ordering (optional)
-             */
-            latitude: number | null;
-            /**
-             * Format: double
-             * @description User's longitude for distance-based ordering (optional)
-             */
-            longitude: number | null;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    ETag?: string;
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["BudgetTemplateModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        RefreshRequestModel: {
-            /** @description Refresh token */
-            refresh_token: string;
+    };
+    "list-budget-template-related-budgets": {
+        parameters: {
+            query?: {
+                /** @description Page number for pagination */
+                pageNumber?: number;
+                /** @description Number of items per page */
+                pageSize?: number;
+                /** @description Field to sort by */
+                sortBy?: "id" | "templateId" | "accountId" | "categoryId" | "periodStart" | "periodEnd" | "amountLimit" | "createdAt" | "updatedAt";
+                /** @description Sort order */
+                sortOrder?: "asc" | "desc";
+                /** @description Filter by template IDs */
+                templateId?: number[] | null;
+                /** @description Filter by account IDs */
+                accountId?: number[] | null;
+                /** @description Filter by category IDs */
+                categoryId?: number[] | null;
+            };
+            header?: never;
+            path: {
+                /** @description Budget Template ID */
+                id: number;
+            };
+            cookie?: never;
         };
-        RefreshResponseModel: {
-            /** @description New access token valid for 7 days */
-            access_token: string;
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["BudgetsPagedModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        ReorderAccountsModel: {
-            /** @description Ordered list of account IDs, first item will receive displayOrder 0 */
-            data: number[] | null;
+    };
+    "list-budget-template-shares": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /**
+                 * @description Budget Template ID
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
         };
-        ReorderCategoriesModel: {
-            /** @description Ordered list of category IDs, first item will receive displayOrder 0 */
-            items: number[] | null;
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["SharesListModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        SeedDevelopmentDataResponseBody: {
-            /** @description Response message */
-            message: string;
+    };
+    "share-budget-template": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /**
+                 * @description Budget Template ID
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
         };
-        SummaryAccountListModel: {
-            /** @description Summary data grouped by account */
-            data: components["schemas"]["SummaryAccountModel"][] | null;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["UpsertShareModel"];
+            };
         };
-        SummaryAccountModel: {
-            /**
-             * Format: int64
-             * @description Total expense amount
-             * @example 2500000
-             */
-            expenseAmount: number;
-            /**
-             * Format: int64
-             * @description Account ID
-             * @example 1
-             */
-            id: number;
-            /**
-             * Format: int64
-             * @description Total income amount
-             * @example 3000000
-             */
-            incomeAmount: number;
-            /**
-             * @description Account name
-             * @example Cash
-             */
-            name: string;
-            /**
-             * Format: int64
-             * @description Net amount (income - expense)
-             * @example 500000
-             */
-            net: number;
-            /**
-             * Format: int64
-             * @description Total number of transactions
-             * @example 100
-             */
-            totalCount: number;
-            /**
-             * @description Account type
-             * @example expense
-             * @enum {string}
-             */
-            type: "income" | "expense";
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["SharesListModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        SummaryCategoryListModel: {
-            /** @description Summary data grouped by category */
-            data: components["schemas"]["SummaryCategoryModel"][] | null;
+    };
+    "unshare-budget-template": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /**
+                 * @description Budget Template ID
+                 * @example 1
+                 */
+                id: number;
+                /**
+                 * @description ID of the user to revoke access from
+                 * @example 2
+                 */
+                userId: number;
+            };
+            cookie?: never;
         };
-        SummaryCategoryModel: {
-            /**
-             * Format: int64
-             * @description Total expense amount
-             * @example 1500000
-             */
-            expenseAmount: number;
-            /**
-             * Format: int64
-             * @description Category ID
-             * @example 1
-             */
-            id: number;
-            /**
-             * Format: int64
-             * @description Total income amount
-             * @example 0
-             */
-            incomeAmount: number;
-            /**
-             * @description Category name
-             * @example Food
-             */
-            name: string;
-            /**
-             * Format: int64
-             * @description Net amount (income - expense)
-             * @example -1500000
-             */
-            net: number;
-            /**
-             * Format: int64
-             * @description Total number of transactions
-             * @example 75
-             */
-            totalCount: number;
-            /**
-             * @description Category type
-             * @example expense
-             * @enum {string}
-             */
-            type: "income" | "expense" | "transfer";
+        requestBody?: never;
+        responses: {
+            /** @description No Content */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "get-generated-budget": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Budget Template ID */
+                template_id: number;
+                /** @description Budget ID */
+                budget_id: number;
+            };
+            cookie?: never;
         };
-        SummaryGeospatialGridCell: {
-            /**
-             * Format: int64
-             * @description Total expense amount
-             * @example 3000000
-             */
-            expenseAmount: number;
-            /**
-             * Format: int64
-             * @description Number of expense transactions
-             * @example 28
-             */
-            expenseCount: number;
-            /**
-             * Format: double
-             * @description Grid cell center latitude
-             * @example -6.175
-             */
-            gridLat: number;
-            /**
-             * Format: double
-             * @description Grid cell center longitude
-             * @example 106.827
-             */
-            gridLon: number;
-            /**
-             * Format: int64
-             * @description Total income amount
-             * @example 2000000
-             */
-            incomeAmount: number;
-            /**
-             * Format: int64
-             * @description Number of income transactions
-             * @example 15
-             */
-            incomeCount: number;
-            /**
-             * Format: int64
-             * @description Total transaction amount in this grid cell
-             * @example 5000000
-             */
-            totalAmount: number;
-            /**
-             * Format: int64
-             * @description Total number of transactions in this grid cell
-             * @example 45
-             */
-            transactionCount: number;
-            /**
-             * Format: int64
-             * @description Number of transfer transactions
-             * @example 2
-             */
-            transferCount: number;
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["BudgetModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        SummaryGeospatialListModel: {
-            /**
-             * Format: double
-             * @description Search center latitude
-             * @example -6.175
-             */
-            centerLat: number;
-            /**
-             * Format: double
-             * @description Search center longitude
-             * @example 106.827
-             */
-            centerLon: number;
-            /** @description Grid cells with aggregated transaction data */
-            data: components["schemas"]["SummaryGeospatialGridCell"][] | null;
-            /**
-             * Format: int64
-             * @description Grid cell precision level
-             * @example 3
-             */
-            gridPrecision: number;
-            /**
-             * Format: int64
-             * @description Search radius in meters
-             * @example 5000
-             */
-            radiusMeters: number;
-            /**
-             * Format: int64
-             * @description Total number of grid cells with transactions
-             * @example 8
-             */
-            totalCells: number;
+    };
+    "update-budget-from-template": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Budget Template ID */
+                template_id: number;
+                /** @description Budget ID */
+                budget_id: number;
+            };
+            cookie?: never;
         };
-        SummaryTransactionListModel: {
-            /** @description Summary data grouped by period */
-            data: components["schemas"]["SummaryTransactionModel"][] | null;
-            /**
-             * @description Grouping frequency
-             * @example monthly
-             */
-            frequency: string;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["UpdateBudgetRequestModel"];
+            };
         };
-        SummaryTransactionModel: {
-            /**
-             * Format: int64
-             * @description Total expense amount
-             * @example 3500000
-             */
-            expenseAmount: number;
-            /**
-             * Format: int64
-             * @description Number of expense transactions
-             * @example 95
-             */
-            expenseCount: number;
-            /**
-             * Format: int64
-             * @description Total income amount
-             * @example 5000000
-             */
-            incomeAmount: number;
-            /**
-             * Format: int64
-             * @description Number of income transactions
-             * @example 50
-             */
-            incomeCount: number;
-            /**
-             * Format: int64
-             * @description Net amount (income - expense)
-             * @example 1500000
-             */
-            net: number;
-            /**
-             * @description Time period (e.g., 2024-01, 2024-W01, 2024-01-01, 2024)
-             * @example 2024-01
-             */
-            period: string;
-            /**
-             * Format: int64
-             * @description Total number of transactions
-             * @example 150
-             */
-            totalCount: number;
-            /**
-             * Format: int64
-             * @description Total transfer amount
-             * @example 500000
-             */
-            transferAmount: number;
-            /**
-             * Format: int64
-             * @description Number of transfer transactions
-             * @example 5
-             */
-            transferCount: number;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["BudgetModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "list-categories": {
+        parameters: {
+            query?: {
+                /** @description Page number for pagination */
+                pageNumber?: number;
+                /** @description Number of items per page */
+                pageSize?: number;
+                /** @description Field to sort by */
+                sortBy?: "name" | "type" | "createdAt" | "updatedAt" | "displayOrder";
+                /** @description Sort order (asc or desc) */
+                sortOrder?: "asc" | "desc";
+                /** @description Filter by category IDs */
+                id?: number[] | null;
+                /** @description Filter by category name (partial match) */
+                name?: string;
+                /** @description Filter by category type */
+                type?: ("expense" | "income" | "transfer")[] | null;
+                /** @description Filter by archived status (true or false) */
+                archived?: string;
+            };
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["CategoriesPagedModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        TagModel: {
-            /** @description Tag color code */
-            color?: string;
-            /**
-             * Format: date-time
-             * @description Creation timestamp
-             */
-            createdAt: string;
-            /**
-             * Format: date-time
-             * @description Soft delete timestamp
-             */
-            deletedAt?: string;
-            /**
-             * Format: int64
-             * @description Unique identifier
-             */
-            id: number;
-            /** @description Tag name */
-            name: string;
-            /**
-             * Format: date-time
-             * @description Last update timestamp
-             */
-            updatedAt?: string;
+    };
+    "create-category": {
+        parameters: {
+            query?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
+            path?: never;
+            cookie?: never;
         };
-        TagsPagedModel: {
-            /** @description List of tags */
-            items: components["schemas"]["TagModel"][] | null;
-            /**
-             * Format: int64
-             * @description Current page number
-             */
-            pageNumber: number;
-            /**
-             * Format: int64
-             * @description Items per page
-             */
-            pageSize: number;
-            /**
-             * Format: int64
-             * @description Total number of matching items
-             */
-            totalCount: number;
-            /**
-             * Format: int64
-             * @description Total number of pages
-             */
-            totalPages: number;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["CreateCategoryModel"];
+            };
         };
-        TransactionAccountEmbedded: {
-            /**
-             * Format: int64
-             * @description Account balance
-             */
-            amount: number;
-            /** @description Icon identifier */
-            icon?: string;
-            /** @description Icon color */
-            iconColor?: string;
-            /**
-             * Format: int64
-             * @description Account ID
-             */
-            id: number;
-            /** @description Account name */
-            name: string;
-            /**
-             * @description Account type
-             * @enum {string}
-             */
-            type: "expense" | "income";
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["CategoryModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        TransactionCategoryEmbedded: {
-            /** @description Icon identifier */
-            icon?: string;
-            /** @description Icon color */
-            iconColor?: string;
-            /**
-             * Format: int64
-             * @description Category ID
-             */
-            id: number;
-            /** @description Category name */
-            name: string;
-            /**
-             * @description Category type
-             * @enum {string}
-             */
-            type: "expense" | "income" | "transfer";
+    };
+    "reorder-categories": {
+        parameters: {
+            query?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
+            path?: never;
+            cookie?: never;
         };
-        TransactionModel: {
-            /** @description Source account details */
-            account: components["schemas"]["TransactionAccountEmbedded"];
-            /**
-             * Format: int64
-             * @description Transaction amount in base currency (IDR)
-             */
-            amount: number;
-            /**
-             * Format: int64
-             * @description Foreign currency amount (as input by user). Null if transaction is in base currency.
-             */
-            amountForeign?: number;
-            /** @description Category details */
-            category: components["schemas"]["TransactionCategoryEmbedded"];
-            /**
-             * Format: date-time
-             * @description Creation timestamp
-             */
-            createdAt: string;
-            /** @description ISO 4217 currency code for foreign amount (e.g., USD, EUR). Null if transaction is in base currency. */
-            currencyCode?: string;
-            /**
-             * Format: date-time
-             * @description Transaction date
-             */
-            date: string;
-            /**
-             * Format: date-time
-             * @description Soft delete timestamp
-             */
-            deletedAt?: string;
-            /** @description Destination account (transfers only) */
-            destinationAccount?: components["schemas"]["TransactionAccountEmbedded"];
-            /**
-             * Format: date-time
-             * @description Timestamp when the currency conversion was applied. Null for base currency transactions.
-             */
-            exchangeAt?: string;
-            /**
-             * Format: double
-             * @description Exchange rate applied: foreign_currency → base_currency (IDR). e.g., 16500.5 for USD→IDR. Null if no conversion.
-             */
-            exchangeRate?: number;
-            /**
-             * Format: int64
-             * @description Unique identifier
-             */
-            id: number;
-            /**
-             * Format: double
-             * @description Transaction latitude
-             */
-            latitude?: number;
-            /**
-             * Format: double
-             * @description Transaction longitude
-             */
-            longitude?: number;
-            /** @description Transaction notes */
-            note?: string;
-            /** @description Transaction tags */
-            tags: components["schemas"]["TransactionTagEmbedded"][] | null;
-            /** @description Associated transaction template details */
-            template: components["schemas"]["TransactionTemplateEmbedded"];
-            /**
-             * @description Transaction type
-             * @enum {string}
-             */
-            type: "expense" | "income" | "transfer";
-            /**
-             * Format: date-time
-             * @description Last update timestamp
-             */
-            updatedAt?: string;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["ReorderCategoriesModel"];
+            };
+        };
+        responses: {
+            /** @description No Content */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "get-category": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /**
+                 * @description Unique identifier of the category
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["CategoryModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        TransactionRelationModel: {
-            /**
-             * Format: date-time
-             * @description Creation timestamp
-             */
-            createdAt: string;
-            /**
-             * Format: date-time
-             * @description Deletion timestamp
-             */
-            deletedAt: string | null;
-            /**
-             * Format: int64
-             * @description Unique identifier
-             */
-            id: number;
-            /**
-             * Format: int64
-             * @description ID of the related transaction
-             */
-            relatedTransactionId: number;
-            /** @description Type of relation */
-            relationType: string;
-            /**
-             * Format: int64
-             * @description ID of the source transaction
-             */
-            sourceTransactionId: number;
-            /**
-             * Format: date-time
-             * @description Last update timestamp
-             */
-            updatedAt: string;
+    };
+    "delete-category": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /**
+                 * @description Unique identifier of the category
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
         };
-        TransactionRelationsPagedModel: {
-            /** @description List of transaction relations */
-            items: components["schemas"]["TransactionRelationModel"][] | null;
-            /**
-             * Format: int64
-             * @description Current page number
-             */
-            pageNumber: number;
-            /**
-             * Format: int64
-             * @description Items per page
-             */
-            pageSize: number;
-            /**
-             * Format: int64
-             * @description Total number of matching items
-             */
-            totalCount: number;
-            /**
-             * Format: int64
-             * @description Total number of pages
-             */
-            totalPages: number;
+        requestBody?: never;
+        responses: {
+            /** @description No Content */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        TransactionTagEmbedded: {
-            /**
-             * Format: int64
-             * @description Tag ID
-             */
-            id: number;
-            /** @description Tag name */
-            name: string;
+    };
+    "update-category": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /**
+                 * @description Unique identifier of the category
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
         };
-        TransactionTagModel: {
-            /**
-             * Format: date-time
-             * @description Creation timestamp
-             */
-            createdAt: string;
-            /**
-             * Format: int64
-             * @description Unique identifier
-             */
-            id: number;
-            /**
-             * Format: int64
-             * @description ID of the tag
-             */
-            tagId: number;
-            /** @description Name of the tag */
-            tagName: string;
-            /**
-             * Format: int64
-             * @description ID of the transaction
-             */
-            transactionId: number;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["UpdateCategoryModel"];
+            };
         };
-        TransactionTagsPagedModel: {
-            /** @description List of transaction tags */
-            items: components["schemas"]["TransactionTagModel"][] | null;
-            /**
-             * Format: int64
-             * @description Current page number
-             */
-            pageNumber: number;
-            /**
-             * Format: int64
-             * @description Items per page
-             */
-            pageSize: number;
-            /**
-             * Format: int64
-             * @description Total number of matching items
-             */
-            totalCount: number;
-            /**
-             * Format: int64
-             * @description Total number of pages
-             */
-            totalPages: number;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["CategoryModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        TransactionTemplateEmbedded: {
-            /**
-             * Format: int64
-             * @description Template amount
-             */
-            amount: number;
-            /**
-             * Format: date-time
-             * @description Template end date
-             */
-            endDate: string | null;
-            /**
-             * Format: int64
-             * @description Template ID
-             */
-            id: number;
-            /** @description Template name */
-            name: string;
-            /**
-             * @description Recurrence pattern
-             * @enum {string}
-             */
-            recurrence: "none" | "weekly" | "monthly" | "yearly";
-            /**
-             * Format: date-time
-             * @description Template start date
-             */
-            startDate: string;
+    };
+    "restore-category": {
+        parameters: {
+            query?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
+            path: {
+                /**
+                 * @description Unique identifier of the category
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["CategoryModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "get-category-statistics": {
+        parameters: {
+            query: {
+                /**
+                 * @description Start date for filtering (ISO 8601 format)
+                 * @example 2024-01-01T00:00:00Z
+                 */
+                startDate: string;
+                /**
+                 * @description End date for filtering (ISO 8601 format)
+                 * @example 2024-12-31T23:59:59Z
+                 */
+                endDate: string;
+            };
+            header?: never;
+            path: {
+                /**
+                 * @description Category ID
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
         };
-        TransactionTemplateModel: {
-            /** @description Source account details */
-            account: components["schemas"]["TransactionAccountEmbedded"];
-            /**
-             * Format: int64
-             * @description Template amount in base currency (IDR)
-             */
-            amount: number;
-            /** @description Category details */
-            category: components["schemas"]["TransactionCategoryEmbedded"];
-            /**
-             * Format: date-time
-             * @description Creation timestamp
-             */
-            createdAt: string;
-            /** @description ISO 4217 currency code (null = base currency only) */
-            currencyCode?: string;
-            /**
-             * Format: date-time
-             * @description Soft delete timestamp
-             */
-            deletedAt?: string;
-            /** @description Destination account details (transfers only) */
-            destinationAccount?: components["schemas"]["TransactionAccountEmbedded"];
-            /**
-             * Format: date-time
-             * @description Template end date
-             */
-            endDate: string | null;
-            /**
-             * Format: int64
-             * @description Unique identifier
-             */
-            id: number;
-            /**
-             * Format: date-time
-             * @description Last execution timestamp
-             */
-            lastExecutedAt?: string;
-            /** @description Template name */
-            name: string;
-            /**
-             * Format: date-time
-             * @description Next due date for recurring transactions
-             */
-            nextDueAt: string | null;
-            /** @description Template notes */
-            note?: string;
-            /**
-             * @description Recurrence pattern
-             * @enum {string}
-             */
-            recurrence: "none" | "weekly" | "monthly" | "yearly";
-            /** @description Recurring transaction statistics */
-            recurringStats: components["schemas"]["TransactionTemplateRecurringStats"];
-            /**
-             * Format: date-time
-             * @description Template start date
-             */
-            startDate: string;
-            /**
-             * @description Transaction type
-             * @enum {string}
-             */
-            type: "expense" | "income" | "transfer";
-            /**
-             * Format: date-time
-             * @description Last update timestamp
-             */
-            updatedAt: string;
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["CategoryStatisticsResponse"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        TransactionTemplateRecurringStats: {
-            /**
-             * Format: int64
-             * @description Number of related transactions created
-             */
-            occurrences: number;
-            /**
-             * Format: int64
-             * @description Number of remaining occurrences (null if no end date)
-             */
-            remaining: number | null;
-            /**
-             * Format: int64
-             * @description Total amount spent from related transactions
-             */
-            totalSpent: number;
+    };
+    "get-account-distribution": {
+        parameters: {
+            query: {
+                /**
+                 * @description Start date for filtering (ISO 8601 format)
+                 * @example 2024-01-01T00:00:00Z
+                 */
+                startDate: string;
+                /**
+                 * @description End date for filtering (ISO 8601 format)
+                 * @example 2024-12-31T23:59:59Z
+                 */
+                endDate: string;
+            };
+            header?: never;
+            path: {
+                /**
+                 * @description Category ID
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
         };
-        TransactionTemplatesPagedModel: {
-            /** @description List of transaction templates */
-            items: components["schemas"]["TransactionTemplateModel"][] | null;
-            /**
-             * Format: int64
-             * @description Current page number
-             */
-            pageNumber: number;
-            /**
-             * Format: int64
-             * @description Items per page
-             */
-            pageSize: number;
-            /**
-             * Format: int64
-             * @description Total number of matching items
-             */
-            totalCount: number;
-            /**
-             * Format: int64
-             * @description Total number of pages
-             */
-            totalPages: number;
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["CategoryStatisticAccountDistributionModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        TransactionsPagedModel: {
-            /** @description List of transactions */
-            items: components["schemas"]["TransactionModel"][] | null;
-            /**
-             * Format: int64
-             * @description Current page number
-             */
-            pageNumber: number;
-            /**
-             * Format: int64
-             * @description Items per page
-             */
-            pageSize: number;
-            /**
-             * Format: int64
-             * @description Total number of matching items
-             */
-            totalCount: number;
-            /**
-             * Format: int64
-             * @description Total number of pages
-             */
-            totalPages: number;
+    };
+    "get-average-transaction-size": {
+        parameters: {
+            query: {
+                /**
+                 * @description Start date for filtering (ISO 8601 format)
+                 * @example 2024-01-01T00:00:00Z
+                 */
+                startDate: string;
+                /**
+                 * @description End date for filtering (ISO 8601 format)
+                 * @example 2024-12-31T23:59:59Z
+                 */
+                endDate: string;
+            };
+            header?: never;
+            path: {
+                /**
+                 * @description Category ID
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
         };
-        UpdateAccountModel: {
-            /** @description Archive status (null string to unarchive, any other value to archive) */
-            archivedAt?: string;
-            /** @description Icon identifier */
-            icon?: string;
-            /** @description Icon color code */
-            iconColor?: string;
-            /** @description Account name */
-            name?: string;
-            /** @description Account notes */
-            note?: string;
-            /**
-             * @description Account type (expense or income)
-             * @enum {string}
-             */
-            type?: "expense" | "income";
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["CategoryStatisticAverageTransactionSizeModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        UpdateBudgetRequestModel: {
-            /**
-             * Format: int64
-             * @description Budget limit amount in cents
-             */
-            amountLimit?: number;
+    };
+    "get-budget-utilization": {
+        parameters: {
+            query: {
+                /**
+                 * @description Start date for filtering (ISO 8601 format)
+                 * @example 2024-01-01T00:00:00Z
+                 */
+                startDate: string;
+                /**
+                 * @description End date for filtering (ISO 8601 format)
+                 * @example 2024-12-31T23:59:59Z
+                 */
+                endDate: string;
+            };
+            header?: never;
+            path: {
+                /**
+                 * @description Category ID
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
         };
-        UpdateBudgetTemplateModel: {
-            /** @description Whether this template is active and generating budgets */
-            active?: boolean;
-            /**
-             * Format: int64
-             * @description Budget limit amount in cents
-             */
-            amountLimit?: number;
-            /** @description Template name */
-            name?: string;
-            /** @description Optional note for the template */
-            note?: string;
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["CategoryStatisticBudgetUtilizationModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        UpdateCategoryModel: {
-            /** @description Archive status (null string to unarchive, any other value to archive) */
-            archivedAt?: string;
-            /** @description Icon identifier */
-            icon?: string;
-            /** @description Icon color code */
-            iconColor?: string;
-            /** @description Category name */
-            name?: string;
-            /** @description Category notes */
-            note?: string;
-            /**
-             * @description Category type
-             * @enum {string}
-             */
-            type?: "expense" | "income" | "transfer";
+    };
+    "get-day-of-week-pattern": {
+        parameters: {
+            query: {
+                /**
+                 * @description Start date for filtering (ISO 8601 format)
+                 * @example 2024-01-01T00:00:00Z
+                 */
+                startDate: string;
+                /**
+                 * @description End date for filtering (ISO 8601 format)
+                 * @example 2024-12-31T23:59:59Z
+                 */
+                endDate: string;
+            };
+            header?: never;
+            path: {
+                /**
+                 * @description Category ID
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
         };
-        UpdateTagModel: {
-            /** @description Tag color code */
-            color?: string;
-            /** @description Tag name */
-            name?: string;
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["CategoryStatisticDayOfWeekPatternModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
-        UpdateTransactionModel: {
-            /**
-             * Format: int64
-             * @description Source account ID
-             */
-            accountId?: number;
-            /**
-             * Format: int64
-             * @description Transaction amount in the specified currency
-             */
-            amount?: number;
-            /**
-             * Format: int64
-             * @description Category ID
-             */
-            categoryId?: number;
-            /** @description ISO 4217 currency code (e.g., USD, EUR) */
-            currencyCode?: string;
-            /**
-             * Format: date-time
-             * @description Transaction date
-             */
-            date?: string;
-            /**
-             * Format: int64
-             * @description Destination account ID (transfers only)
-             */
-            destinationAccountId?: number;
-            /**
-             * Format: double
-             * @description Transaction latitude
-             */
-            latitude?: number;
-            /**
-             * Format: double
-             * @description Transaction longitude
-             */
-            longitude?: number;
-            /** @description Transaction notes */
-            note?: string;
-            /**
-             * @description Transaction type
-             * @enum {string}
-             */
-            type?: "expense" | "income" | "transfer";
+    };
+    "get-spending-velocity": {
+        parameters: {
+            query: {
+                /**
+                 * @description Start date for filtering (ISO 8601 format)
+                 * @example 2024-01-01T00:00:00Z
+                 */
+                startDate: string;
+                /**
+                 * @description End date for filtering (ISO 8601 format)
+                 * @example 2024-12-31T23:59:59Z
+                 */
+                endDate: string;
+            };
+            header?: never;
+            path: {
+                /**
+                 * @description Category ID
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
         };
-        UpdateTransactionTemplateModel: {
-            /**
-             * Format: int64
-             * @description Source account ID
-             */
-            accountId?: number;
-            /**
-             * Format: int64
-             * @description Template amount in base currency (IDR)
-             */
-            amount?: number;
-            /**
-             * Format: int64
-             * @description Category ID
-             */
-            categoryId?: number;
-            /** @description ISO 4217 currency code (null = base currency only, affects future-dated transactions) */
-            currencyCode?: string;
-            /**
-             * Format: int64
-             * @description Destination account ID (transfers only)
-             */
-            destinationAccountId?: number;
-            /**
-             * Format: date-time
-             * @description Template end date
-             */
-            endDate?: string;
-            /** @description Template name */
-            name?: string;
-            /** @description Template notes */
-            note?: string;
-            /**
-             * @description Recurrence pattern
-             * @enum {string}
-             */
-            recurrence?: "none" | "weekly" | "monthly" | "yearly";
-            /**
-             * @description Transaction type
-             * @enum {string}
-             */
-            type?: "expense" | "income" | "transfer";
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["CategoryStatisticSpendingVelocityModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
         };
     };
-    responses: never;
-    parameters: never;
-    requestBodies: never;
-    headers: never;
-    pathItems: never;
-}
-export type $defs = Record<string, never>;
-export interface operations {
-    "list-accounts": {
+    "list-payees": {
         parameters: {
             query?: {
                 /** @description Page number for pagination */
                 pageNumber?: number;
                 /** @description Number of items per page */
                 pageSize?: number;
-                /** @description Field to sort by (name, type, amount, displayOrder, createdAt, updatedAt) */
-                sortBy?: "name" | "type" | "amount" | "displayOrder" | "createdAt" | "updatedAt";
-                /** @description Sort order (asc or desc) */
+                /** @description Field to sort by */
+                sortBy?: "id" | "name" | "createdAt" | "updatedAt";
+                /** @description Sort order */
                 sortOrder?: "asc" | "desc";
-                /** @description Filter by account IDs */
-                id?: number[] | null;
-                /** @description Filter by account name (partial match) */
+                /** @description Search by payee name or alias */
                 name?: string;
-                /** @description Filter by account type (expense or income) */
-                type?: ("expense" | "income")[] | null;
-                /** @description Filter by archived status (true or false) */
-                archived?: string;
             };
             header?: never;
             path?: never;
@@ -3309,7 +8487,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["AccountsPagedModel"];
+                    "application/json": components["schemas"]["PayeesPagedModel"];
                 };
             };
             /** @description Error */
@@ -3323,16 +8501,19 @@ export interface operations {
             };
         };
     };
-    "create-account": {
+    "create-payee": {
         parameters: {
             query?: never;
-            header?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
             path?: never;
             cookie?: never;
         };
         requestBody: {
             content: {
-                "application/json": components["schemas"]["CreateAccountModel"];
+                "application/json": components["schemas"]["CreatePayeeModel"];
             };
         };
         responses: {
@@ -3342,7 +8523,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["AccountModel"];
+                    "application/json": components["schemas"]["PayeeModel"];
                 };
             };
             /** @description Error */
@@ -3356,18 +8537,55 @@ export interface operations {
             };
         };
     };
-    "reorder-accounts": {
+    "get-payee": {
         parameters: {
             query?: never;
             header?: never;
-            path?: never;
+            path: {
+                /**
+                 * @description Unique identifier of the payee
+                 * @example 1
+                 */
+                id: number;
+            };
             cookie?: never;
         };
-        requestBody: {
-            content: {
-                "application/json": components["schemas"]["ReorderAccountsModel"];
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["PayeeModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "delete-payee": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /**
+                 * @description Unique identifier of the payee
+                 * @example 1
+                 */
+                id: number;
             };
+            cookie?: never;
         };
+        requestBody?: never;
         responses: {
             /** @description No Content */
             204: {
@@ -3387,20 +8605,24 @@ export interface operations {
             };
         };
     };
-    "get-account": {
+    "update-payee": {
         parameters: {
             query?: never;
             header?: never;
             path: {
                 /**
-                 * @description Unique identifier of the account
+                 * @description Unique identifier of the payee
                  * @example 1
                  */
                 id: number;
             };
             cookie?: never;
         };
-        requestBody?: never;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["UpdatePayeeModel"];
+            };
+        };
         responses: {
             /** @description OK */
             200: {
@@ -3408,7 +8630,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["AccountModel"];
+                    "application/json": components["schemas"]["PayeeModel"];
                 };
             };
             /** @description Error */
@@ -3422,27 +8644,36 @@ export interface operations {
             };
         };
     };
-    "delete-account": {
+    "merge-payees": {
         parameters: {
             query?: never;
-            header?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
             path: {
                 /**
-                 * @description Unique identifier of the account
+                 * @description Unique identifier of the payee to keep
                  * @example 1
                  */
                 id: number;
             };
             cookie?: never;
         };
-        requestBody?: never;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["MergePayeesModel"];
+            };
+        };
         responses: {
-            /** @description No Content */
-            204: {
+            /** @description OK */
+            200: {
                 headers: {
                     [name: string]: unknown;
                 };
-                content?: never;
+                content: {
+                    "application/json": components["schemas"]["PayeeMergeResultModel"];
+                };
             };
             /** @description Error */
             default: {
@@ -3455,24 +8686,99 @@ export interface operations {
             };
         };
     };
-    "update-account": {
+    "restore-payee": {
         parameters: {
             query?: never;
-            header?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
             path: {
                 /**
-                 * @description Unique identifier of the account
+                 * @description Unique identifier of the payee
                  * @example 1
                  */
                 id: number;
             };
             cookie?: never;
         };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["PayeeModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "refresh-geo-cache": {
+        parameters: {
+            query?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
+            path?: never;
+            cookie?: never;
+        };
         requestBody: {
             content: {
-                "application/json": components["schemas"]["UpdateAccountModel"];
+                "application/json": components["schemas"]["RefreshGeoCache"];
+            };
+        };
+        responses: {
+            /** @description No Content */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "list-reimbursements": {
+        parameters: {
+            query?: {
+                /** @description Page number for pagination */
+                pageNumber?: number;
+                /** @description Number of items per page */
+                pageSize?: number;
+                /** @description Field to sort by */
+                sortBy?: "id" | "transactionDate" | "expectedAmount" | "outstandingAmount" | "createdAt";
+                /** @description Sort order */
+                sortOrder?: "asc" | "desc";
+                /** @description outstanding lists what is still owed, settled what is fully paid back */
+                status?: "outstanding" | "settled" | "all";
+                /** @description Search by counterparty */
+                counterparty?: string;
             };
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
+        requestBody?: never;
         responses: {
             /** @description OK */
             200: {
@@ -3480,7 +8786,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["AccountModel"];
+                    "application/json": components["schemas"]["ReimbursementsPagedModel"];
                 };
             };
             /** @description Error */
@@ -3494,31 +8800,128 @@ export interface operations {
             };
         };
     };
-    "get-account-statistics": {
+    "create-reimbursement": {
         parameters: {
-            query: {
+            query?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["CreateReimbursementModel"];
+            };
+        };
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["ReimbursementModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "get-reimbursement": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
                 /**
-                 * @description Start date for filtering (ISO 8601 format)
-                 * @example 2024-01-01T00:00:00Z
+                 * @description Unique identifier of the reimbursement
+                 * @example 1
                  */
-                startDate: string;
+                id: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["ReimbursementModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "delete-reimbursement": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
                 /**
-                 * @description End date for filtering (ISO 8601 format)
-                 * @example 2024-12-31T23:59:59Z
+                 * @description Unique identifier of the reimbursement
+                 * @example 1
                  */
-                endDate: string;
+                id: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description No Content */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
             };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "update-reimbursement": {
+        parameters: {
+            query?: never;
             header?: never;
             path: {
                 /**
-                 * @description Unique identifier of the account
+                 * @description Unique identifier of the reimbursement
                  * @example 1
                  */
                 id: number;
             };
             cookie?: never;
         };
-        requestBody?: never;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["UpdateReimbursementModel"];
+            };
+        };
         responses: {
             /** @description OK */
             200: {
@@ -3526,7 +8929,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["AccountStatisticsResponse"];
+                    "application/json": components["schemas"]["ReimbursementModel"];
                 };
             };
             /** @description Error */
@@ -3540,31 +8943,27 @@ export interface operations {
             };
         };
     };
-    "get-budget-health": {
+    "match-reimbursement": {
         parameters: {
-            query: {
-                /**
-                 * @description Start date for filtering (ISO 8601 format)
-                 * @example 2024-01-01T00:00:00Z
-                 */
-                startDate: string;
-                /**
-                 * @description End date for filtering (ISO 8601 format)
-                 * @example 2024-12-31T23:59:59Z
-                 */
-                endDate: string;
+            query?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
             };
-            header?: never;
             path: {
                 /**
-                 * @description Unique identifier of the account
+                 * @description Unique identifier of the reimbursement
                  * @example 1
                  */
                 id: number;
             };
             cookie?: never;
         };
-        requestBody?: never;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["CreateReimbursementMatchModel"];
+            };
+        };
         responses: {
             /** @description OK */
             200: {
@@ -3572,7 +8971,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["AccountStatisticsBudgetHealthModel"];
+                    "application/json": components["schemas"]["ReimbursementModel"];
                 };
             };
             /** @description Error */
@@ -3586,27 +8985,21 @@ export interface operations {
             };
         };
     };
-    "get-burn-rate": {
+    "unmatch-reimbursement": {
         parameters: {
-            query: {
-                /**
-                 * @description Start date for filtering (ISO 8601 format)
-                 * @example 2024-01-01T00:00:00Z
-                 */
-                startDate: string;
-                /**
-                 * @description End date for filtering (ISO 8601 format)
-                 * @example 2024-12-31T23:59:59Z
-                 */
-                endDate: string;
-            };
+            query?: never;
             header?: never;
             path: {
                 /**
-                 * @description Unique identifier of the account
+                 * @description Unique identifier of the reimbursement
                  * @example 1
                  */
                 id: number;
+                /**
+                 * @description Unique identifier of the match
+                 * @example 1
+                 */
+                matchId: number;
             };
             cookie?: never;
         };
@@ -3618,7 +9011,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["AccountStatisticsBurnRateModel"];
+                    "application/json": components["schemas"]["ReimbursementModel"];
                 };
             };
             /** @description Error */
@@ -3632,28 +9025,14 @@ export interface operations {
             };
         };
     };
-    "get-cash-flow-pulse": {
+    "seed-development-data": {
         parameters: {
-            query: {
-                /**
-                 * @description Start date for filtering (ISO 8601 format)
-                 * @example 2024-01-01T00:00:00Z
-                 */
-                startDate: string;
-                /**
-                 * @description End date for filtering (ISO 8601 format)
-                 * @example 2024-12-31T23:59:59Z
-                 */
-                endDate: string;
-            };
-            header?: never;
-            path: {
-                /**
-                 * @description Unique identifier of the account
-                 * @example 1
-                 */
-                id: number;
+            query?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
             };
+            path?: never;
             cookie?: never;
         };
         requestBody?: never;
@@ -3664,7 +9043,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["AccountStatisticsCashFlowPulseModel"];
+                    "application/json": components["schemas"]["SeedDevelopmentDataResponseBody"];
                 };
             };
             /** @description Error */
@@ -3678,7 +9057,7 @@ export interface operations {
             };
         };
     };
-    "get-category-heatmap": {
+    "get-account-summary": {
         parameters: {
             query: {
                 /**
@@ -3691,15 +9070,11 @@ export interface operations {
                  * @example 2024-12-31T23:59:59Z
                  */
                 endDate: string;
+                /** @description Leave out money paid back through reimbursements: expenses only count the part not paid back, and income that paid them back does not count */
+                excludeReimbursed?: boolean;
             };
             header?: never;
-            path: {
-                /**
-                 * @description Unique identifier of the account
-                 * @example 1
-                 */
-                id: number;
-            };
+            path?: never;
             cookie?: never;
         };
         requestBody?: never;
@@ -3710,7 +9085,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["AccountStatisticsCategoryHeatmapModel"];
+                    "application/json": components["schemas"]["SummaryAccountListModel"];
                 };
             };
             /** @description Error */
@@ -3724,7 +9099,7 @@ export interface operations {
             };
         };
     };
-    "get-monthly-velocity": {
+    "get-category-summary": {
         parameters: {
             query: {
                 /**
@@ -3737,15 +9112,11 @@ export interface operations {
                  * @example 2024-12-31T23:59:59Z
                  */
                 endDate: string;
+                /** @description Leave out money paid back through reimbursements: expenses only count the part not paid back, and income that paid them back does not count */
+                excludeReimbursed?: boolean;
             };
             header?: never;
-            path: {
-                /**
-                 * @description Unique identifier of the account
-                 * @example 1
-                 */
-                id: number;
-            };
+            path?: never;
             cookie?: never;
         };
         requestBody?: never;
@@ -3756,7 +9127,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["AccountStatisticsMonthlyVelocityModel"];
+                    "application/json": components["schemas"]["SummaryCategoryListModel"];
                 };
             };
             /** @description Error */
@@ -3770,7 +9141,7 @@ export interface operations {
             };
         };
     };
-    "get-time-frequency": {
+    "get-geospatial-summary": {
         parameters: {
             query: {
                 /**
@@ -3783,15 +9154,31 @@ export interface operations {
                  * @example 2024-12-31T23:59:59Z
                  */
                 endDate: string;
-            };
-            header?: never;
-            path: {
+                /** @description Leave out money paid back through reimbursements: expenses only count the part not paid back, and income that paid them back does not count */
+                excludeReimbursed?: boolean;
                 /**
-                 * @description Unique identifier of the account
-                 * @example 1
+                 * @description Center latitude for geographic search
+                 * @example -6.175
                  */
-                id: number;
+                latitude: number;
+                /**
+                 * @description Center longitude for geographic search
+                 * @example 106.827
+                 */
+                longitude: number;
+                /**
+                 * @description Search radius in meters
+                 * @example 5000
+                 */
+                radiusMeters?: number;
+                /**
+                 * @description Grid cell precision (1=~11km, 2=~1.1km, 3=~110m, 4=~11m)
+                 * @example 3
+                 */
+                gridPrecision?: number;
             };
+            header?: never;
+            path?: never;
             cookie?: never;
         };
         requestBody?: never;
@@ -3802,7 +9189,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["AccountStatisticsTimeFrequencyHeatmapModel"];
+                    "application/json": components["schemas"]["SummaryGeospatialListModel"];
                 };
             };
             /** @description Error */
@@ -3816,18 +9203,27 @@ export interface operations {
             };
         };
     };
-    login: {
+    "get-payee-summary": {
         parameters: {
-            query?: never;
+            query: {
+                /**
+                 * @description Start date for filtering (ISO 8601 format)
+                 * @example 2024-01-01T00:00:00Z
+                 */
+                startDate: string;
+                /**
+                 * @description End date for filtering (ISO 8601 format)
+                 * @example 2024-12-31T23:59:59Z
+                 */
+                endDate: string;
+                /** @description Leave out money paid back through reimbursements: expenses only count the part not paid back, and income that paid them back does not count */
+                excludeReimbursed?: boolean;
+            };
             header?: never;
             path?: never;
             cookie?: never;
         };
-        requestBody: {
-            content: {
-                "application/json": components["schemas"]["LoginRequestModel"];
-            };
-        };
+        requestBody?: never;
         responses: {
             /** @description OK */
             200: {
@@ -3835,7 +9231,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["LoginResponseModel"];
+                    "application/json": components["schemas"]["SummaryPayeeListModel"];
                 };
             };
             /** @description Error */
@@ -3849,18 +9245,29 @@ export interface operations {
             };
         };
     };
-    refresh: {
+    "get-transaction-summary": {
         parameters: {
-            query?: never;
+            query: {
+                /**
+                 * @description Start date for filtering (ISO 8601 format)
+                 * @example 2024-01-01T00:00:00Z
+                 */
+                startDate: string;
+                /**
+                 * @description End date for filtering (ISO 8601 format)
+                 * @example 2024-12-31T23:59:59Z
+                 */
+                endDate: string;
+                /** @description Leave out money paid back through reimbursements: expenses only count the part not paid back, and income that paid them back does not count */
+                excludeReimbursed?: boolean;
+                /** @description Grouping frequency */
+                frequency?: "daily" | "weekly" | "monthly" | "yearly";
+            };
             header?: never;
             path?: never;
             cookie?: never;
         };
-        requestBody: {
-            content: {
-                "application/json": components["schemas"]["RefreshRequestModel"];
-            };
-        };
+        requestBody?: never;
         responses: {
             /** @description OK */
             200: {
@@ -3868,7 +9275,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["RefreshResponseModel"];
+                    "application/json": components["schemas"]["SummaryTransactionListModel"];
                 };
             };
             /** @description Error */
@@ -3882,7 +9289,7 @@ export interface operations {
             };
         };
     };
-    "list-budget-templates": {
+    "list-tags": {
         parameters: {
             query?: {
                 /** @description Page number for pagination */
@@ -3890,19 +9297,11 @@ export interface operations {
                 /** @description Number of items per page */
                 pageSize?: number;
                 /** @description Field to sort by */
-                sortBy?: "id" | "accountId" | "categoryId" | "amountLimit" | "recurrence" | "startDate" | "endDate" | "name" | "active" | "nextRunAt" | "createdAt" | "updatedAt";
+                sortBy?: "id" | "name" | "createdAt" | "updatedAt";
                 /** @description Sort order */
                 sortOrder?: "asc" | "desc";
-                /** @description Filter by template IDs */
-                id?: number[] | null;
-                /** @description Filter by account IDs */
-                accountId?: number[] | null;
-                /** @description Filter by category IDs */
-                categoryId?: number[] | null;
-                /** @description Filter by recurrence pattern */
-                recurrence?: "none" | "weekly" | "monthly" | "yearly";
-                /** @description Filter by active status */
-                active?: boolean;
+                /** @description Search by tag name */
+                name?: string;
             };
             header?: never;
             path?: never;
@@ -3916,7 +9315,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["BudgetTemplatesPagedModel"];
+                    "application/json": components["schemas"]["TagsPagedModel"];
                 };
             };
             /** @description Error */
@@ -3930,16 +9329,19 @@ export interface operations {
             };
         };
     };
-    "create-budget-template": {
+    "create-tag": {
         parameters: {
             query?: never;
-            header?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
             path?: never;
             cookie?: never;
         };
         requestBody: {
             content: {
-                "application/json": components["schemas"]["CreateBudgetTemplateModel"];
+                "application/json": components["schemas"]["CreateTagModel"];
             };
         };
         responses: {
@@ -3949,7 +9351,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["BudgetTemplateModel"];
+                    "application/json": components["schemas"]["TagModel"];
                 };
             };
             /** @description Error */
@@ -3963,12 +9365,15 @@ export interface operations {
             };
         };
     };
-    "get-budget-template": {
+    "get-tag": {
         parameters: {
             query?: never;
             header?: never;
             path: {
-                /** @description Budget Template ID */
+                /**
+                 * @description Unique identifier of the tag
+                 * @example 1
+                 */
                 id: number;
             };
             cookie?: never;
@@ -3981,43 +9386,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["BudgetTemplateModel"];
-                };
-            };
-            /** @description Error */
-            default: {
-                headers: {
-                    [name: string]: unknown;
-                };
-                content: {
-                    "application/problem+json": components["schemas"]["ErrorModel"];
-                };
-            };
-        };
-    };
-    "update-budget-template": {
-        parameters: {
-            query?: never;
-            header?: never;
-            path: {
-                /** @description Budget Template ID */
-                id: number;
-            };
-            cookie?: never;
-        };
-        requestBody: {
-            content: {
-                "application/json": components["schemas"]["UpdateBudgetTemplateModel"];
-            };
-        };
-        responses: {
-            /** @description OK */
-            200: {
-                headers: {
-                    [name: string]: unknown;
-                };
-                content: {
-                    "application/json": components["schemas"]["BudgetTemplateModel"];
+                    "application/json": components["schemas"]["TagModel"];
                 };
             };
             /** @description Error */
@@ -4031,41 +9400,27 @@ export interface operations {
             };
         };
     };
-    "list-budget-template-related-budgets": {
+    "delete-tag": {
         parameters: {
-            query?: {
-                /** @description Page number for pagination */
-                pageNumber?: number;
-                /** @description Number of items per page */
-                pageSize?: number;
-                /** @description Field to sort by */
-                sortBy?: "id" | "templateId" | "accountId" | "categoryId" | "periodStart" | "periodEnd" | "amountLimit" | "createdAt" | "updatedAt";
-                /** @description Sort order */
-                sortOrder?: "asc" | "desc";
-                /** @description Filter by template IDs */
-                templateId?: number[] | null;
-                /** @description Filter by account IDs */
-                accountId?: number[] | null;
-                /** @description Filter by category IDs */
-                categoryId?: number[] | null;
-            };
+            query?: never;
             header?: never;
             path: {
-                /** @description Budget Template ID */
+                /**
+                 * @description Unique identifier of the tag
+                 * @example 1
+                 */
                 id: number;
             };
             cookie?: never;
         };
         requestBody?: never;
         responses: {
-            /** @description OK */
-            200: {
+            /** @description No Content */
+            204: {
                 headers: {
                     [name: string]: unknown;
                 };
-                content: {
-                    "application/json": components["schemas"]["BudgetsPagedModel"];
-                };
+                content?: never;
             };
             /** @description Error */
             default: {
@@ -4078,19 +9433,24 @@ export interface operations {
             };
         };
     };
-    "get-generated-budget": {
+    "update-tag": {
         parameters: {
             query?: never;
             header?: never;
             path: {
-                /** @description Budget Template ID */
-                template_id: number;
-                /** @description Budget ID */
-                budget_id: number;
+                /**
+                 * @description Unique identifier of the tag
+                 * @example 1
+                 */
+                id: number;
             };
             cookie?: never;
         };
-        requestBody?: never;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["UpdateTagModel"];
+            };
+        };
         responses: {
             /** @description OK */
             200: {
@@ -4098,7 +9458,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["BudgetModel"];
+                    "application/json": components["schemas"]["TagModel"];
                 };
             };
             /** @description Error */
@@ -4112,23 +9472,23 @@ export interface operations {
             };
         };
     };
-    "update-budget-from-template": {
+    "restore-tag": {
         parameters: {
             query?: never;
-            header?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
             path: {
-                /** @description Budget Template ID */
-                template_id: number;
-                /** @description Budget ID */
-                budget_id: number;
+                /**
+                 * @description Unique identifier of the tag
+                 * @example 1
+                 */
+                id: number;
             };
             cookie?: never;
         };
-        requestBody: {
-            content: {
-                "application/json": components["schemas"]["UpdateBudgetRequestModel"];
-            };
-        };
+        requestBody?: never;
         responses: {
             /** @description OK */
             200: {
@@ -4136,7 +9496,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["BudgetModel"];
+                    "application/json": components["schemas"]["TagModel"];
                 };
             };
             /** @description Error */
@@ -4150,25 +9510,15 @@ export interface operations {
             };
         };
     };
-    "list-categories": {
+    "list-transaction-rules": {
         parameters: {
             query?: {
                 /** @description Page number for pagination */
                 pageNumber?: number;
                 /** @description Number of items per page */
                 pageSize?: number;
-                /** @description Field to sort by */
-                sortBy?: "name" | "type" | "createdAt" | "updatedAt" | "displayOrder";
-                /** @description Sort order (asc or desc) */
-                sortOrder?: "asc" | "desc";
-                /** @description Filter by category IDs */
-                id?: number[] | null;
-                /** @description Filter by category name (partial match) */
+                /** @description Search by rule name */
                 name?: string;
-                /** @description Filter by category type */
-                type?: ("expense" | "income" | "transfer")[] | null;
-                /** @description Filter by archived status (true or false) */
-                archived?: string;
             };
             header?: never;
             path?: never;
@@ -4182,7 +9532,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["CategoriesPagedModel"];
+                    "application/json": components["schemas"]["TransactionRulesPagedModel"];
                 };
             };
             /** @description Error */
@@ -4196,16 +9546,19 @@ export interface operations {
             };
         };
     };
-    "create-category": {
+    "create-transaction-rule": {
         parameters: {
             query?: never;
-            header?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
             path?: never;
             cookie?: never;
         };
         requestBody: {
             content: {
-                "application/json": components["schemas"]["CreateCategoryModel"];
+                "application/json": components["schemas"]["CreateTransactionRuleModel"];
             };
         };
         responses: {
@@ -4215,7 +9568,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["CategoryModel"];
+                    "application/json": components["schemas"]["TransactionRuleModel"];
                 };
             };
             /** @description Error */
@@ -4229,25 +9582,30 @@ export interface operations {
             };
         };
     };
-    "reorder-categories": {
+    "apply-transaction-rules": {
         parameters: {
             query?: never;
-            header?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
             path?: never;
             cookie?: never;
         };
         requestBody: {
             content: {
-                "application/json": components["schemas"]["ReorderCategoriesModel"];
+                "application/json": components["schemas"]["TransactionRuleRangeModel"];
             };
         };
         responses: {
-            /** @description No Content */
-            204: {
+            /** @description OK */
+            200: {
                 headers: {
                     [name: string]: unknown;
                 };
-                content?: never;
+                content: {
+                    "application/json": components["schemas"]["TransactionRuleApplyResultModel"];
+                };
             };
             /** @description Error */
             default: {
@@ -4260,13 +9618,13 @@ export interface operations {
             };
         };
     };
-    "get-category": {
+    "get-transaction-rule": {
         parameters: {
             query?: never;
             header?: never;
             path: {
                 /**
-                 * @description Unique identifier of the category
+                 * @description Unique identifier of the transaction rule
                  * @example 1
                  */
                 id: number;
@@ -4281,7 +9639,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["CategoryModel"];
+                    "application/json": components["schemas"]["TransactionRuleModel"];
                 };
             };
             /** @description Error */
@@ -4295,13 +9653,13 @@ export interface operations {
             };
         };
     };
-    "delete-category": {
+    "delete-transaction-rule": {
         parameters: {
             query?: never;
             header?: never;
             path: {
                 /**
-                 * @description Unique identifier of the category
+                 * @description Unique identifier of the transaction rule
                  * @example 1
                  */
                 id: number;
@@ -4328,13 +9686,13 @@ export interface operations {
             };
         };
     };
-    "update-category": {
+    "update-transaction-rule": {
         parameters: {
             query?: never;
             header?: never;
             path: {
                 /**
-                 * @description Unique identifier of the category
+                 * @description Unique identifier of the transaction rule
                  * @example 1
                  */
                 id: number;
@@ -4343,7 +9701,7 @@ export interface operations {
         };
         requestBody: {
             content: {
-                "application/json": components["schemas"]["UpdateCategoryModel"];
+                "application/json": components["schemas"]["UpdateTransactionRuleModel"];
             };
         };
         responses: {
@@ -4353,7 +9711,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["CategoryModel"];
+                    "application/json": components["schemas"]["TransactionRuleModel"];
                 };
             };
             /** @description Error */
@@ -4367,24 +9725,18 @@ export interface operations {
             };
         };
     };
-    "get-category-statistics": {
+    "dry-run-transaction-rule": {
         parameters: {
             query: {
-                /**
-                 * @description Start date for filtering (ISO 8601 format)
-                 * @example 2024-01-01T00:00:00Z
-                 */
+                /** @description Start of the range of transaction dates */
                 startDate: string;
-                /**
-                 * @description End date for filtering (ISO 8601 format)
-                 * @example 2024-12-31T23:59:59Z
-                 */
+                /** @description End of the range of transaction dates */
                 endDate: string;
             };
             header?: never;
             path: {
                 /**
-                 * @description Category ID
+                 * @description Unique identifier of the transaction rule
                  * @example 1
                  */
                 id: number;
@@ -4399,7 +9751,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["CategoryStatisticsResponse"];
+                    "application/json": components["schemas"]["TransactionRuleDryRunModel"];
                 };
             };
             /** @description Error */
@@ -4413,28 +9765,30 @@ export interface operations {
             };
         };
     };
-    "get-account-distribution": {
+    "list-transaction-templates": {
         parameters: {
-            query: {
-                /**
-                 * @description Start date for filtering (ISO 8601 format)
-                 * @example 2024-01-01T00:00:00Z
-                 */
-                startDate: string;
-                /**
-                 * @description End date for filtering (ISO 8601 format)
-                 * @example 2024-12-31T23:59:59Z
-                 */
-                endDate: string;
+            query?: {
+                /** @description Page number for pagination */
+                pageNumber?: number;
+                /** @description Number of items per page */
+                pageSize?: number;
+                /** @description Field to sort by */
+                sortBy?: "id" | "name" | "amount" | "type" | "createdAt" | "updatedAt" | "nextDueAt";
+                /** @description Sort order */
+                sortOrder?: "asc" | "desc";
+                /** @description Search by template name */
+                name?: string;
+                /** @description Filter by transaction type */
+                type?: "expense" | "income" | "transfer";
+                /** @description Filter by source account ID */
+                accountId?: number;
+                /** @description Filter by category ID */
+                categoryId?: number;
+                /** @description Filter by destination account ID */
+                destinationAccountId?: number;
             };
             header?: never;
-            path: {
-                /**
-                 * @description Category ID
-                 * @example 1
-                 */
-                id: number;
-            };
+            path?: never;
             cookie?: never;
         };
         requestBody?: never;
@@ -4445,7 +9799,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["CategoryStatisticAccountDistributionModel"];
+                    "application/json": components["schemas"]["TransactionTemplatesPagedModel"];
                 };
             };
             /** @description Error */
@@ -4459,77 +9813,21 @@ export interface operations {
             };
         };
     };
-    "get-average-transaction-size": {
+    "create-transaction-template": {
         parameters: {
-            query: {
-                /**
-                 * @description Start date for filtering (ISO 8601 format)
-                 * @example 2024-01-01T00:00:00Z
-                 */
-                startDate: string;
-                /**
-                 * @description End date for filtering (ISO 8601 format)
-                 * @example 2024-12-31T23:59:59Z
-                 */
-                endDate: string;
-            };
-            header?: never;
-            path: {
-                /**
-                 * @description Category ID
-                 * @example 1
-                 */
-                id: number;
+            query?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
             };
+            path?: never;
             cookie?: never;
         };
-        requestBody?: never;
-        responses: {
-            /** @description OK */
-            200: {
-                headers: {
-                    [name: string]: unknown;
-                };
-                content: {
-                    "application/json": components["schemas"]["CategoryStatisticAverageTransactionSizeModel"];
-                };
-            };
-            /** @description Error */
-            default: {
-                headers: {
-                    [name: string]: unknown;
-                };
-                content: {
-                    "application/problem+json": components["schemas"]["ErrorModel"];
-                };
-            };
-        };
-    };
-    "get-budget-utilization": {
-        parameters: {
-            query: {
-                /**
-                 * @description Start date for filtering (ISO 8601 format)
-                 * @example 2024-01-01T00:00:00Z
-                 */
-                startDate: string;
-                /**
-                 * @description End date for filtering (ISO 8601 format)
-                 * @example 2024-12-31T23:59:59Z
-                 */
-                endDate: string;
-            };
-            header?: never;
-            path: {
-                /**
-                 * @description Category ID
-                 * @example 1
-                 */
-                id: number;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["CreateTransactionTemplateModel"];
             };
-            cookie?: never;
         };
-        requestBody?: never;
         responses: {
             /** @description OK */
             200: {
@@ -4537,41 +9835,30 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["CategoryStatisticBudgetUtilizationModel"];
+                    "application/json": components["schemas"]["TransactionTemplateModel"];
                 };
             };
             /** @description Error */
             default: {
                 headers: {
-                    [name: string]: unknown;
-                };
-                content: {
-                    "application/problem+json": components["schemas"]["ErrorModel"];
-                };
-            };
-        };
-    };
-    "get-day-of-week-pattern": {
-        parameters: {
-            query: {
-                /**
-                 * @description Start date for filtering (ISO 8601 format)
-                 * @example 2024-01-01T00:00:00Z
-                 */
-                startDate: string;
-                /**
-                 * @description End date for filtering (ISO 8601 format)
-                 * @example 2024-12-31T23:59:59Z
-                 */
-                endDate: string;
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
             };
+        };
+    };
+    "get-transaction-template": {
+        parameters: {
+            query?: never;
             header?: never;
             path: {
                 /**
-                 * @description Category ID
+                 * @description Unique identifier of the transaction template
                  * @example 1
                  */
-                id: number;
+                templateId: number;
             };
             cookie?: never;
         };
@@ -4583,7 +9870,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["CategoryStatisticDayOfWeekPatternModel"];
+                    "application/json": components["schemas"]["TransactionTemplateModel"];
                 };
             };
             /** @description Error */
@@ -4597,40 +9884,27 @@ export interface operations {
             };
         };
     };
-    "get-spending-velocity": {
+    "delete-transaction-template": {
         parameters: {
-            query: {
-                /**
-                 * @description Start date for filtering (ISO 8601 format)
-                 * @example 2024-01-01T00:00:00Z
-                 */
-                startDate: string;
-                /**
-                 * @description End date for filtering (ISO 8601 format)
-                 * @example 2024-12-31T23:59:59Z
-                 */
-                endDate: string;
-            };
+            query?: never;
             header?: never;
             path: {
                 /**
-                 * @description Category ID
+                 * @description Unique identifier of the transaction template
                  * @example 1
                  */
-                id: number;
+                templateId: number;
             };
             cookie?: never;
         };
         requestBody?: never;
         responses: {
-            /** @description OK */
-            200: {
+            /** @description No Content */
+            204: {
                 headers: {
                     [name: string]: unknown;
                 };
-                content: {
-                    "application/json": components["schemas"]["CategoryStatisticSpendingVelocityModel"];
-                };
+                content?: never;
             };
             /** @description Error */
             default: {
@@ -4643,25 +9917,33 @@ export interface operations {
             };
         };
     };
-    "refresh-geo-cache": {
+    "update-transaction-template": {
         parameters: {
             query?: never;
             header?: never;
-            path?: never;
+            path: {
+                /**
+                 * @description Unique identifier of the transaction template
+                 * @example 1
+                 */
+                templateId: number;
+            };
             cookie?: never;
         };
         requestBody: {
             content: {
-                "application/json": components["schemas"]["RefreshGeoCache"];
+                "application/json": components["schemas"]["UpdateTransactionTemplateModel"];
             };
         };
         responses: {
-            /** @description No Content */
-            204: {
+            /** @description OK */
+            200: {
                 headers: {
                     [name: string]: unknown;
                 };
-                content?: never;
+                content: {
+                    "application/json": components["schemas"]["TransactionTemplateModel"];
+                };
             };
             /** @description Error */
             default: {
@@ -4674,11 +9956,50 @@ export interface operations {
             };
         };
     };
-    "seed-development-data": {
+    "list-transaction-template-related-transactions": {
         parameters: {
-            query?: never;
+            query?: {
+                /** @description Page number for pagination */
+                pageNumber?: number;
+                /** @description Number of items per page */
+                pageSize?: number;
+                /** @description Field to sort by */
+                sortBy?: "id" | "type" | "date" | "amount" | "createdAt" | "updatedAt";
+                /** @description Sort order (asc or desc) */
+                sortOrder?: "asc" | "desc";
+                /** @description Cursor from nextCursor; returns the page after it instead of pageNumber. Requires sortBy=date */
+                after?: string;
+                /** @description Cursor from prevCursor; returns the page before it instead of pageNumber. Requires sortBy=date */
+                before?: string;
+                /** @description Count all matching transactions; false skips the count and leaves totalCount and totalPages at 0 */
+                includeTotal?: boolean;
+                /** @description Filter by transaction type */
+                type?: ("expense" | "income" | "transfer")[] | null;
+                /** @description Filter by source account IDs */
+                accountId?: number[] | null;
+                /** @description Filter by category IDs */
+                categoryId?: number[] | null;
+                /** @description Filter by destination account IDs (transfers) */
+                destinationAccountId?: number[] | null;
+                /** @description Filter by tag IDs */
+                tagId?: number[] | null;
+                /** @description Filter by start date (YYYY-MM-DD) */
+                startDate?: string;
+                /** @description Filter by end date (YYYY-MM-DD) */
+                endDate?: string;
+                /** @description Filter by minimum amount */
+                minAmount?: number;
+                /** @description Filter by maximum amount */
+                maxAmount?: number;
+            };
             header?: never;
-            path?: never;
+            path: {
+                /**
+                 * @description Unique identifier of the transaction template
+                 * @example 1
+                 */
+                templateId: number;
+            };
             cookie?: never;
         };
         requestBody?: never;
@@ -4689,7 +10010,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["SeedDevelopmentDataResponseBody"];
+                    "application/json": components["schemas"]["TransactionsPagedModel"];
                 };
             };
             /** @description Error */
@@ -4703,22 +10024,20 @@ export interface operations {
             };
         };
     };
-    "get-account-summary": {
+    "restore-transaction-template": {
         parameters: {
-            query: {
-                /**
-                 * @description Start date for filtering (ISO 8601 format)
-                 * @example 2024-01-01T00:00:00Z
-                 */
-                startDate: string;
+            query?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
+            path: {
                 /**
-                 * @description End date for filtering (ISO 8601 format)
-                 * @example 2024-12-31T23:59:59Z
+                 * @description Unique identifier of the transaction template
+                 * @example 1
                  */
-                endDate: string;
+                templateId: number;
             };
-            header?: never;
-            path?: never;
             cookie?: never;
         };
         requestBody?: never;
@@ -4729,7 +10048,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["SummaryAccountListModel"];
+                    "application/json": components["schemas"]["TransactionTemplateModel"];
                 };
             };
             /** @description Error */
@@ -4743,22 +10062,17 @@ export interface operations {
             };
         };
     };
-    "get-category-summary": {
+    "list-transaction-template-shares": {
         parameters: {
-            query: {
-                /**
-                 * @description Start date for filtering (ISO 8601 format)
-                 * @example 2024-01-01T00:00:00Z
-                 */
-                startDate: string;
+            query?: never;
+            header?: never;
+            path: {
                 /**
-                 * @description End date for filtering (ISO 8601 format)
-                 * @example 2024-12-31T23:59:59Z
+                 * @description Unique identifier of the transaction template
+                 * @example 1
                  */
-                endDate: string;
+                templateId: number;
             };
-            header?: never;
-            path?: never;
             cookie?: never;
         };
         requestBody?: never;
@@ -4769,7 +10083,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["SummaryCategoryListModel"];
+                    "application/json": components["schemas"]["SharesListModel"];
                 };
             };
             /** @description Error */
@@ -4783,45 +10097,24 @@ export interface operations {
             };
         };
     };
-    "get-geospatial-summary": {
+    "share-transaction-template": {
         parameters: {
-            query: {
-                /**
-                 * @description Start date for filtering (ISO 8601 format)
-                 * @example 2024-01-01T00:00:00Z
-                 */
-                startDate: string;
-                /**
-                 * @description End date for filtering (ISO 8601 format)
-                 * @example 2024-12-31T23:59:59Z
-                 */
-                endDate: string;
-                /**
-                 * @description Center latitude for geographic search
-                 * @example -6.175
-                 */
-                latitude: number;
-                /**
-                 * @description Center longitude for geographic search
-                 * @example 106.827
-                 */
-                longitude: number;
-                /**
-                 * @description Search radius in meters
-                 * @example 5000
-                 */
-                radiusMeters?: number;
+            query?: never;
+            header?: never;
+            path: {
                 /**
-                 * @description Grid cell precision (1=~11km, 2=~1.1km, 3=~110m, 4=~11m)
-                 * @example 3
+                 * @description Unique identifier of the transaction template
+                 * @example 1
                  */
-                gridPrecision?: number;
+                templateId: number;
             };
-            header?: never;
-            path?: never;
             cookie?: never;
         };
-        requestBody?: never;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["UpsertShareModel"];
+            };
+        };
         responses: {
             /** @description OK */
             200: {
@@ -4829,7 +10122,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["SummaryGeospatialListModel"];
+                    "application/json": components["schemas"]["SharesListModel"];
                 };
             };
             /** @description Error */
@@ -4843,36 +10136,32 @@ export interface operations {
             };
         };
     };
-    "get-transaction-summary": {
+    "unshare-transaction-template": {
         parameters: {
-            query: {
+            query?: never;
+            header?: never;
+            path: {
                 /**
-                 * @description Start date for filtering (ISO 8601 format)
-                 * @example 2024-01-01T00:00:00Z
+                 * @description Unique identifier of the transaction template
+                 * @example 1
                  */
-                startDate: string;
+                templateId: number;
                 /**
-                 * @description End date for filtering (ISO 8601 format)
-                 * @example 2024-12-31T23:59:59Z
+                 * @description ID of the user to revoke access from
+                 * @example 2
                  */
-                endDate: string;
-                /** @description Grouping frequency */
-                frequency?: "daily" | "weekly" | "monthly" | "yearly";
+                userId: number;
             };
-            header?: never;
-            path?: never;
             cookie?: never;
         };
         requestBody?: never;
         responses: {
-            /** @description OK */
-            200: {
+            /** @description No Content */
+            204: {
                 headers: {
                     [name: string]: unknown;
                 };
-                content: {
-                    "application/json": components["schemas"]["SummaryTransactionListModel"];
-                };
+                content?: never;
             };
             /** @description Error */
             default: {
@@ -4885,19 +10174,59 @@ export interface operations {
             };
         };
     };
-    "list-tags": {
+    "list-transactions": {
         parameters: {
             query?: {
                 /** @description Page number for pagination */
                 pageNumber?: number;
                 /** @description Number of items per page */
                 pageSize?: number;
-                /** @description Field to sort by */
-                sortBy?: "id" | "name" | "createdAt" | "updatedAt";
-                /** @description Sort order */
+                /** @description Field to sort by; relevance ranks full-text matches of q and falls back to date */
+                sortBy?: "id" | "type" | "date" | "amount" | "createdAt" | "updatedAt" | "relevance";
+                /** @description Sort order (asc or desc) */
                 sortOrder?: "asc" | "desc";
-                /** @description Search by tag name */
-                name?: string;
+                /** @description Cursor from nextCursor; returns the page after it instead of pageNumber. Requires sortBy=date */
+                after?: string;
+                /** @description Cursor from prevCursor; returns the page before it instead of pageNumber. Requires sortBy=date */
+                before?: string;
+                /** @description Count all matching transactions; false skips the count on large lists and leaves totalCount and totalPages at 0 */
+                includeTotal?: boolean;
+                /** @description Full-text search over the note, category, account and tag names; every word matches as a prefix */
+                q?: string;
+                /** @description Filter by transaction IDs */
+                id?: number[] | null;
+                /** @description Filter by transaction type */
+                type?: ("expense" | "income" | "transfer")[] | null;
+                /** @description Filter by bank status */
+                status?: ("pending" | "cleared" | "reconciled")[] | null;
+                /** @description Filter by account IDs (source or destination) */
+                accountId?: number[] | null;
+                /** @description Filter by category IDs */
+                categoryId?: number[] | null;
+                /** @description Filter by destination account IDs (transfers) */
+                destinationAccountId?: number[] | null;
+                /** @description Filter by transaction template IDs */
+                templateId?: number[] | null;
+                /** @description Filter by tag IDs */
+                tagId?: number[] | null;
+                /** @description Filter by payee IDs */
+                payeeId?: number[] | null;
+                /** @description Filter by currency codes (e.g., USD, EUR) */
+                currencyCode?: string[] | null;
+                /** @description Filter by start date (YYYY-MM-DD) */
+                startDate?: string;
+                /** @description Filter by end date (YYYY-MM-DD) */
+                endDate?: string;
+                /** @description Filter by minimum amount */
+                minAmount?: number;
+                /** @description Filter by maximum amount */
+                maxAmount?: number;
+                /** @description Latitude for geospatial search */
+                latitude?: number;
+                /** @description Longitude for geospatial search */
+                longitude?: number;
+                /** @description Search radius in meters */
+                radiusMeters?: number;
             };
             header?: never;
             path?: never;
@@ -4911,7 +10240,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["TagsPagedModel"];
+                    "application/json": components["schemas"]["TransactionsPagedModel"];
                 };
             };
             /** @description Error */
@@ -4925,16 +10254,19 @@ export interface operations {
             };
         };
     };
-    "create-tag": {
+    "create-transaction": {
         parameters: {
             query?: never;
-            header?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
             path?: never;
             cookie?: never;
         };
         requestBody: {
             content: {
-                "application/json": components["schemas"]["CreateTagModel"];
+                "application/json": components["schemas"]["CreateTransactionModel"];
             };
         };
         responses: {
@@ -4944,7 +10276,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["TagModel"];
+                    "application/json": components["schemas"]["TransactionCreatedModel"];
                 };
             };
             /** @description Error */
@@ -4958,17 +10290,11 @@ export interface operations {
             };
         };
     };
-    "get-tag": {
+    "get-transactions-bulk-draft": {
         parameters: {
             query?: never;
             header?: never;
-            path: {
-                /**
-                 * @description Unique identifier of the tag
-                 * @example 1
-                 */
-                id: number;
-            };
+            path?: never;
             cookie?: never;
         };
         requestBody?: never;
@@ -4976,10 +10302,11 @@ export interface operations {
             /** @description OK */
             200: {
                 headers: {
+                    Metadata?: components["schemas"]["BulkTransactionDraftResponseModel"];
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["TagModel"];
+                    "application/json": components["schemas"]["BulkTransactionDraftModel"];
                 };
             };
             /** @description Error */
@@ -4993,17 +10320,11 @@ export interface operations {
             };
         };
     };
-    "delete-tag": {
+    "delete-transactions-bulk-draft": {
         parameters: {
             query?: never;
             header?: never;
-            path: {
-                /**
-                 * @description Unique identifier of the tag
-                 * @example 1
-                 */
-                id: number;
-            };
+            path?: never;
             cookie?: never;
         };
         requestBody?: never;
@@ -5011,6 +10332,7 @@ export interface operations {
             /** @description No Content */
             204: {
                 headers: {
+                    StatusCode?: number;
                     [name: string]: unknown;
                 };
                 content?: never;
@@ -5026,22 +10348,16 @@ export interface operations {
             };
         };
     };
-    "update-tag": {
+    "patch-transactions-bulk-draft": {
         parameters: {
             query?: never;
             header?: never;
-            path: {
-                /**
-                 * @description Unique identifier of the tag
-                 * @example 1
-                 */
-                id: number;
-            };
+            path?: never;
             cookie?: never;
         };
         requestBody: {
             content: {
-                "application/json": components["schemas"]["UpdateTagModel"];
+                "application/json": components["schemas"]["BulkTransactionDraftModel"];
             };
         };
         responses: {
@@ -5051,7 +10367,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["TagModel"];
+                    "application/json": components["schemas"]["BulkTransactionDraftResponseModel"];
                 };
             };
             /** @description Error */
@@ -5065,27 +10381,54 @@ export interface operations {
             };
         };
     };
-    "list-transaction-templates": {
+    "post-transactions-bulk-commit": {
+        parameters: {
+            query?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    StatusCode?: number;
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["BulkTransactionCommitResponseModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "list-transaction-duplicates": {
         parameters: {
             query?: {
                 /** @description Page number for pagination */
                 pageNumber?: number;
                 /** @description Number of items per page */
                 pageSize?: number;
-                /** @description Field to sort by */
-                sortBy?: "id" | "name" | "amount" | "type" | "createdAt" | "updatedAt" | "nextDueAt";
-                /** @description Sort order */
-                sortOrder?: "asc" | "desc";
-                /** @description Search by template name */
-                name?: string;
-                /** @description Filter by transaction type */
-                type?: "expense" | "income" | "transfer";
-                /** @description Filter by source account ID */
-                accountId?: number;
-                /** @description Filter by category ID */
-                categoryId?: number;
-                /** @description Filter by destination account ID */
-                destinationAccountId?: number;
+                /** @description Only include pairs with a transaction dated at or after this time; defaults to 90 days before the end date */
+                startDate?: string;
+                /** @description Only include pairs with a transaction dated at or before this time; defaults to now */
+                endDate?: string;
+                /** @description Filter by account IDs */
+                accountId?: number[] | null;
+                /** @description Leave out pairs scoring lower */
+                minScore?: number;
             };
             header?: never;
             path?: never;
@@ -5099,7 +10442,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["TransactionTemplatesPagedModel"];
+                    "application/json": components["schemas"]["TransactionDuplicatesPagedModel"];
                 };
             };
             /** @description Error */
@@ -5113,26 +10456,29 @@ export interface operations {
             };
         };
     };
-    "create-transaction-template": {
+    "get-transaction": {
         parameters: {
             query?: never;
             header?: never;
-            path?: never;
-            cookie?: never;
-        };
-        requestBody: {
-            content: {
-                "application/json": components["schemas"]["CreateTransactionTemplateModel"];
+            path: {
+                /**
+                 * @description Unique identifier of the transaction
+                 * @example 1
+                 */
+                id: number;
             };
+            cookie?: never;
         };
+        requestBody?: never;
         responses: {
             /** @description OK */
             200: {
                 headers: {
+                    ETag?: string;
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["TransactionTemplateModel"];
+                    "application/json": components["schemas"]["TransactionModel"];
                 };
             };
             /** @description Error */
@@ -5146,28 +10492,72 @@ export interface operations {
             };
         };
     };
-    "get-transaction-template": {
+    "delete-transaction": {
         parameters: {
             query?: never;
-            header?: never;
+            header?: {
+                /** @description ETag the transaction was read with; the request fails with 412 when it has changed since */
+                "If-Match"?: string;
+            };
             path: {
                 /**
-                 * @description Unique identifier of the transaction template
+                 * @description Unique identifier of the transaction
                  * @example 1
                  */
-                templateId: number;
+                id: number;
             };
             cookie?: never;
         };
         requestBody?: never;
+        responses: {
+            /** @description No Content */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "update-transaction": {
+        parameters: {
+            query?: never;
+            header?: {
+                /** @description ETag the transaction was read with; the request fails with 412 when it has changed since */
+                "If-Match"?: string;
+            };
+            path: {
+                /**
+                 * @description Unique identifier of the transaction
+                 * @example 1
+                 */
+                id: number;
+            };
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["UpdateTransactionModel"];
+            };
+        };
         responses: {
             /** @description OK */
             200: {
                 headers: {
+                    ETag?: string;
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["TransactionTemplateModel"];
+                    "application/json": components["schemas"]["TransactionModel"];
                 };
             };
             /** @description Error */
@@ -5181,27 +10571,29 @@ export interface operations {
             };
         };
     };
-    "delete-transaction-template": {
+    "get-transaction-graph": {
         parameters: {
             query?: never;
             header?: never;
             path: {
                 /**
-                 * @description Unique identifier of the transaction template
+                 * @description Unique identifier of the transaction
                  * @example 1
                  */
-                templateId: number;
+                id: number;
             };
             cookie?: never;
         };
         requestBody?: never;
         responses: {
-            /** @description No Content */
-            204: {
+            /** @description OK */
+            200: {
                 headers: {
                     [name: string]: unknown;
                 };
-                content?: never;
+                content: {
+                    "application/json": components["schemas"]["TransactionGraphModel"];
+                };
             };
             /** @description Error */
             default: {
@@ -5214,22 +10606,25 @@ export interface operations {
             };
         };
     };
-    "update-transaction-template": {
+    "merge-transactions": {
         parameters: {
             query?: never;
-            header?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
             path: {
                 /**
-                 * @description Unique identifier of the transaction template
+                 * @description Unique identifier of the transaction to keep
                  * @example 1
                  */
-                templateId: number;
+                id: number;
             };
             cookie?: never;
         };
         requestBody: {
             content: {
-                "application/json": components["schemas"]["UpdateTransactionTemplateModel"];
+                "application/json": components["schemas"]["MergeTransactionDuplicatesModel"];
             };
         };
         responses: {
@@ -5239,7 +10634,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["TransactionTemplateModel"];
+                    "application/json": components["schemas"]["TransactionMergeResultModel"];
                 };
             };
             /** @description Error */
@@ -5253,43 +10648,19 @@ export interface operations {
             };
         };
     };
-    "list-transaction-template-related-transactions": {
+    "restore-transaction": {
         parameters: {
-            query?: {
-                /** @description Page number for pagination */
-                pageNumber?: number;
-                /** @description Number of items per page */
-                pageSize?: number;
-                /** @description Field to sort by */
-                sortBy?: "id" | "type" | "date" | "amount" | "createdAt" | "updatedAt";
-                /** @description Sort order (asc or desc) */
-                sortOrder?: "asc" | "desc";
-                /** @description Filter by transaction type */
-                type?: ("expense" | "income" | "transfer")[] | null;
-                /** @description Filter by source account IDs */
-                accountId?: number[] | null;
-                /** @description Filter by category IDs */
-                categoryId?: number[] | null;
-                /** @description Filter by destination account IDs (transfers) */
-                destinationAccountId?: number[] | null;
-                /** @description Filter by tag IDs */
-                tagId?: number[] | null;
-                /** @description Filter by start date (YYYY-MM-DD) */
-                startDate?: string;
-                /** @description Filter by end date (YYYY-MM-DD) */
-                endDate?: string;
-                /** @description Filter by minimum amount */
-                minAmount?: number;
-                /** @description Filter by maximum amount */
-                maxAmount?: number;
+            query?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
             };
-            header?: never;
             path: {
                 /**
-                 * @description Unique identifier of the transaction template
+                 * @description Unique identifier of the transaction
                  * @example 1
                  */
-                templateId: number;
+                id: number;
             };
             cookie?: never;
         };
@@ -5301,7 +10672,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["TransactionsPagedModel"];
+                    "application/json": components["schemas"]["TransactionModel"];
                 };
             };
             /** @description Error */
@@ -5315,50 +10686,19 @@ export interface operations {
             };
         };
     };
-    "list-transactions": {
+    "list-transaction-relations": {
         parameters: {
             query?: {
                 /** @description Page number for pagination */
                 pageNumber?: number;
                 /** @description Number of items per page */
                 pageSize?: number;
-                /** @description Field to sort by */
-                sortBy?: "id" | "type" | "date" | "amount" | "createdAt" | "updatedAt";
-                /** @description Sort order (asc or desc) */
-                sortOrder?: "asc" | "desc";
-                /** @description Filter by transaction IDs */
-                id?: number[] | null;
-                /** @description Filter by transaction type */
-                type?: ("expense" | "income" | "transfer")[] | null;
-                /** @description Filter by account IDs (source or destination) */
-                accountId?: number[] | null;
-                /** @description Filter by category IDs */
-                categoryId?: number[] | null;
-                /** @description Filter by destination account IDs (transfers) */
-                destinationAccountId?: number[] | null;
-                /** @description Filter by transaction template IDs */
-                templateId?: number[] | null;
-                /** @description Filter by tag IDs */
-                tagId?: number[] | null;
-                /** @description Filter by currency codes (e.g., USD, EUR) */
-                currencyCode?: string[] | null;
-                /** @description Filter by start date (YYYY-MM-DD) */
-                startDate?: string;
-                /** @description Filter by end date (YYYY-MM-DD) */
-                endDate?: string;
-                /** @description Filter by minimum amount */
-                minAmount?: number;
-                /** @description Filter by maximum amount */
-                maxAmount?: number;
-                /** @description Latitude for geospatial search */
-                latitude?: number;
-                /** @description Longitude for geospatial search */
-                longitude?: number;
-                /** @description Search radius in meters */
-                radiusMeters?: number;
             };
             header?: never;
-            path?: never;
+            path: {
+                /** @description Source transaction ID to list relations for */
+                sourceTransactionId: number;
+            };
             cookie?: never;
         };
         requestBody?: never;
@@ -5369,7 +10709,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["TransactionsPagedModel"];
+                    "application/json": components["schemas"]["TransactionRelationsPagedModel"];
                 };
             };
             /** @description Error */
@@ -5383,16 +10723,19 @@ export interface operations {
             };
         };
     };
-    "create-transaction": {
+    "create-transaction-relation": {
         parameters: {
             query?: never;
-            header?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
             path?: never;
             cookie?: never;
         };
         requestBody: {
             content: {
-                "application/json": components["schemas"]["CreateTransactionModel"];
+                "application/json": components["schemas"]["CreateTransactionRelationModel"];
             };
         };
         responses: {
@@ -5402,7 +10745,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["TransactionModel"];
+                    "application/json": components["schemas"]["TransactionRelationModel"];
                 };
             };
             /** @description Error */
@@ -5416,11 +10759,16 @@ export interface operations {
             };
         };
     };
-    "get-transactions-bulk-draft": {
+    "get-transaction-relation": {
         parameters: {
             query?: never;
             header?: never;
-            path?: never;
+            path: {
+                /** @description Source transaction ID */
+                sourceTransactionId: number;
+                /** @description Relation ID */
+                relationId: number;
+            };
             cookie?: never;
         };
         requestBody?: never;
@@ -5428,11 +10776,10 @@ export interface operations {
             /** @description OK */
             200: {
                 headers: {
-                    Metadata?: components["schemas"]["BulkTransactionDraftResponseModel"];
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["BulkTransactionDraftModel"];
+                    "application/json": components["schemas"]["TransactionRelationModel"];
                 };
             };
             /** @description Error */
@@ -5446,11 +10793,16 @@ export interface operations {
             };
         };
     };
-    "delete-transactions-bulk-draft": {
+    "delete-transaction-relation": {
         parameters: {
             query?: never;
             header?: never;
-            path?: never;
+            path: {
+                /** @description ID of the source transaction */
+                sourceTransactionId: number;
+                /** @description ID of the relation to delete */
+                relationId: number;
+            };
             cookie?: never;
         };
         requestBody?: never;
@@ -5458,7 +10810,6 @@ export interface operations {
             /** @description No Content */
             204: {
                 headers: {
-                    StatusCode?: number;
                     [name: string]: unknown;
                 };
                 content?: never;
@@ -5474,18 +10825,17 @@ export interface operations {
             };
         };
     };
-    "patch-transactions-bulk-draft": {
+    "list-transaction-attachments": {
         parameters: {
             query?: never;
             header?: never;
-            path?: never;
-            cookie?: never;
-        };
-        requestBody: {
-            content: {
-                "application/json": components["schemas"]["BulkTransactionDraftModel"];
+            path: {
+                /** @description Transaction ID */
+                transactionId: number;
             };
+            cookie?: never;
         };
+        requestBody?: never;
         responses: {
             /** @description OK */
             200: {
@@ -5493,7 +10843,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["BulkTransactionDraftResponseModel"];
+                    "application/json": components["schemas"]["TransactionAttachmentsListModel"];
                 };
             };
             /** @description Error */
@@ -5507,23 +10857,38 @@ export interface operations {
             };
         };
     };
-    "post-transactions-bulk-commit": {
+    "upload-transaction-attachment": {
         parameters: {
             query?: never;
-            header?: never;
-            path?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
+            path: {
+                /** @description Transaction ID */
+                transactionId: number;
+            };
             cookie?: never;
         };
-        requestBody?: never;
+        requestBody?: {
+            content: {
+                "multipart/form-data": {
+                    /**
+                     * Format: binary
+                     * @description Receipt or document to attach
+                     */
+                    file: string;
+                };
+            };
+        };
         responses: {
-            /** @description OK */
-            200: {
+            /** @description Created */
+            201: {
                 headers: {
-                    StatusCode?: number;
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["BulkTransactionCommitResponseModel"];
+                    "application/json": components["schemas"]["TransactionAttachmentModel"];
                 };
             };
             /** @description Error */
@@ -5537,29 +10902,26 @@ export interface operations {
             };
         };
     };
-    "get-transaction": {
+    "delete-transaction-attachment": {
         parameters: {
             query?: never;
             header?: never;
             path: {
-                /**
-                 * @description Unique identifier of the transaction
-                 * @example 1
-                 */
-                id: number;
+                /** @description Transaction ID */
+                transactionId: number;
+                /** @description Attachment ID */
+                attachmentId: number;
             };
             cookie?: never;
         };
         requestBody?: never;
         responses: {
-            /** @description OK */
-            200: {
+            /** @description No Content */
+            204: {
                 headers: {
                     [name: string]: unknown;
                 };
-                content: {
-                    "application/json": components["schemas"]["TransactionModel"];
-                };
+                content?: never;
             };
             /** @description Error */
             default: {
@@ -5572,27 +10934,28 @@ export interface operations {
             };
         };
     };
-    "delete-transaction": {
+    "download-transaction-attachment": {
         parameters: {
             query?: never;
             header?: never;
             path: {
-                /**
-                 * @description Unique identifier of the transaction
-                 * @example 1
-                 */
-                id: number;
+                /** @description Transaction ID */
+                transactionId: number;
+                /** @description Attachment ID */
+                attachmentId: number;
             };
             cookie?: never;
         };
         requestBody?: never;
         responses: {
-            /** @description No Content */
-            204: {
+            /** @description The attached file */
+            200: {
                 headers: {
                     [name: string]: unknown;
                 };
-                content?: never;
+                content: {
+                    "application/octet-stream": string;
+                };
             };
             /** @description Error */
             default: {
@@ -5605,32 +10968,27 @@ export interface operations {
             };
         };
     };
-    "update-transaction": {
+    "download-transaction-attachment-thumbnail": {
         parameters: {
             query?: never;
             header?: never;
             path: {
-                /**
-                 * @description Unique identifier of the transaction
-                 * @example 1
-                 */
-                id: number;
+                /** @description Transaction ID */
+                transactionId: number;
+                /** @description Attachment ID */
+                attachmentId: number;
             };
             cookie?: never;
         };
-        requestBody: {
-            content: {
-                "application/json": components["schemas"]["UpdateTransactionModel"];
-            };
-        };
+        requestBody?: never;
         responses: {
-            /** @description OK */
+            /** @description JPEG thumbnail of the attached image */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["TransactionModel"];
+                    "application/octet-stream": string;
                 };
             };
             /** @description Error */
@@ -5644,18 +11002,13 @@ export interface operations {
             };
         };
     };
-    "list-transaction-relations": {
+    "list-transaction-revisions": {
         parameters: {
-            query?: {
-                /** @description Page number for pagination */
-                pageNumber?: number;
-                /** @description Number of items per page */
-                pageSize?: number;
-            };
+            query?: never;
             header?: never;
             path: {
-                /** @description Source transaction ID to list relations for */
-                sourceTransactionId: number;
+                /** @description Transaction ID */
+                transactionId: number;
             };
             cookie?: never;
         };
@@ -5667,7 +11020,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["TransactionRelationsPagedModel"];
+                    "application/json": components["schemas"]["TransactionRevisionsListModel"];
                 };
             };
             /** @description Error */
@@ -5681,18 +11034,22 @@ export interface operations {
             };
         };
     };
-    "create-transaction-relation": {
+    "revert-transaction-revision": {
         parameters: {
             query?: never;
-            header?: never;
-            path?: never;
-            cookie?: never;
-        };
-        requestBody: {
-            content: {
-                "application/json": components["schemas"]["CreateTransactionRelationModel"];
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
+            path: {
+                /** @description Transaction ID */
+                transactionId: number;
+                /** @description Revision number */
+                revision: number;
             };
+            cookie?: never;
         };
+        requestBody?: never;
         responses: {
             /** @description OK */
             200: {
@@ -5700,7 +11057,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["TransactionRelationModel"];
+                    "application/json": components["schemas"]["TransactionModel"];
                 };
             };
             /** @description Error */
@@ -5714,15 +11071,13 @@ export interface operations {
             };
         };
     };
-    "get-transaction-relation": {
+    "list-transaction-splits": {
         parameters: {
             query?: never;
             header?: never;
             path: {
-                /** @description Source transaction ID */
-                sourceTransactionId: number;
-                /** @description Relation ID */
-                relationId: number;
+                /** @description Transaction ID */
+                transactionId: number;
             };
             cookie?: never;
         };
@@ -5734,7 +11089,7 @@ export interface operations {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["TransactionRelationModel"];
+                    "application/json": components["schemas"]["TransactionSplitsListModel"];
                 };
             };
             /** @description Error */
@@ -5748,26 +11103,34 @@ export interface operations {
             };
         };
     };
-    "delete-transaction-relation": {
+    "replace-transaction-splits": {
         parameters: {
             query?: never;
-            header?: never;
+            header?: {
+                /** @description ETag the transaction was read with; the request fails with 412 when it has changed since */
+                "If-Match"?: string;
+            };
             path: {
-                /** @description ID of the source transaction */
-                sourceTransactionId: number;
-                /** @description ID of the relation to delete */
-                relationId: number;
+                /** @description Transaction ID */
+                transactionId: number;
             };
             cookie?: never;
         };
-        requestBody?: never;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["ReplaceTransactionSplitsModel"];
+            };
+        };
         responses: {
-            /** @description No Content */
-            204: {
+            /** @description OK */
+            200: {
                 headers: {
+                    ETag?: string;
                     [name: string]: unknown;
                 };
-                content?: never;
+                content: {
+                    "application/json": components["schemas"]["TransactionModel"];
+                };
             };
             /** @description Error */
             default: {
@@ -5820,7 +11183,10 @@ export interface operations {
     "create-transaction-tag": {
         parameters: {
             query?: never;
-            header?: never;
+            header?: {
+                /** @description Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again */
+                "Idempotency-Key"?: string;
+            };
             path: {
                 /** @description Transaction ID */
                 transactionId: number;
@@ -5919,4 +11285,102 @@ export interface operations {
             };
         };
     };
+    "list-trash": {
+        parameters: {
+            query?: {
+                /** @description Page number for pagination */
+                pageNumber?: number;
+                /** @description Number of items per page */
+                pageSize?: number;
+                /** @description Filter by kinds of records */
+                entityType?: ("transaction" | "account" | "category" | "tag" | "payee" | "transaction_template")[] | null;
+            };
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["TrashPagedModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "create-user": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["CreateUserModel"];
+            };
+        };
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["UserModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
+    "get-current-user": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description OK */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["UserModel"];
+                };
+            };
+            /** @description Error */
+            default: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/problem+json": components["schemas"]["ErrorModel"];
+                };
+            };
+        };
+    };
 }
@@ -0,0 +1,106 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/dimasbaguspm/spenicle-api/internal/configs"
+)
+
+const defaultAttachmentStorageDir = "data/attachments"
+
+// ErrAttachmentNotFound is returned by AttachmentStorage.Open when no file is stored under the key
+var ErrAttachmentNotFound = errors.New("attachment not found in storage")
+
+// AttachmentStorage stores attachment files under opaque keys chosen by the caller
+// Keys are slash separated relative paths such as "12/3f9c..."; implementations must reject keys escaping their root
+type AttachmentStorage interface {
+	Save(ctx context.Context, key string, content io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// LocalAttachmentStorage keeps attachment files in a directory of the local filesystem
+type LocalAttachmentStorage struct {
+	root string
+}
+
+func NewAttachmentStorage() AttachmentStorage {
+	root := os.Getenv(configs.ATTACHMENT_STORAGE_DIR_ENV)
+	if root == "" {
+		root = defaultAttachmentStorageDir
+	}
+	return LocalAttachmentStorage{root: root}
+}
+
+// Save writes to a temporary file first so a failed upload never leaves a partial file under the key
+func (s LocalAttachmentStorage) Save(ctx context.Context, key string, content io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create attachment directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create attachment file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write attachment file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write attachment file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store attachment file: %w", err)
+	}
+	return nil
+}
+
+func (s LocalAttachmentStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open attachment file: %w", err)
+	}
+	return file, nil
+}
+
+// Delete removes the file under the key; a missing file is not an error
+func (s LocalAttachmentStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete attachment file: %w", err)
+	}
+	return nil
+}
+
+func (s LocalAttachmentStorage) path(key string) (string, error) {
+	local := filepath.FromSlash(key)
+	if key == "" || !filepath.IsLocal(local) {
+		return "", fmt.Errorf("invalid attachment key %q", key)
+	}
+	return filepath.Join(s.root, local), nil
+}
@@ -0,0 +1,250 @@
+package clients
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testOIDCClientID = "spenicle"
+	testOIDCKid      = "test-key"
+	testOIDCCode     = "test-code"
+)
+
+// mockIssuer is a minimal OpenID provider: it redeems a single code, checking the PKCE verifier against the
+// challenge of the authorization URL, and answers with an ID token built from claims
+type mockIssuer struct {
+	server    *httptest.Server
+	challenge string
+	claims    func(issuer string) jwt.MapClaims
+	signer    *rsa.PrivateKey
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	m := &mockIssuer{signer: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(oidcDiscovery{
+			Issuer:                m.server.URL,
+			AuthorizationEndpoint: m.server.URL + "/authorize",
+			TokenEndpoint:         m.server.URL + "/token",
+			JwksURI:               m.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string][]oidcJWK{
+			"keys": {{
+				Kid: testOIDCKid,
+				Kty: "RSA",
+				Use: "sig",
+				N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "invalid_request", http.StatusBadRequest)
+			return
+		}
+		verifier := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if r.PostForm.Get("grant_type") != "authorization_code" ||
+			r.PostForm.Get("code") != testOIDCCode ||
+			r.PostForm.Get("client_id") != testOIDCClientID ||
+			base64.RawURLEncoding.EncodeToString(verifier[:]) != m.challenge {
+			http.Error(w, "invalid_grant", http.StatusBadRequest)
+			return
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, m.claims(m.server.URL))
+		token.Header["kid"] = testOIDCKid
+		signed, err := token.SignedString(m.signer)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(oidcTokenResponse{IDToken: signed})
+	})
+
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+	return m
+}
+
+func (m *mockIssuer) client() *OIDCClient {
+	return &OIDCClient{
+		issuer:        m.server.URL,
+		clientID:      testOIDCClientID,
+		redirectURL:   "http://localhost:3000/auth/oidc/callback",
+		scopes:        defaultOIDCScopes,
+		usernameClaim: defaultOIDCUsernameClaim,
+		httpClient:    m.server.Client(),
+	}
+}
+
+// authorize follows the authorization URL the way the provider would, remembering the PKCE challenge
+func (m *mockIssuer) authorize(t *testing.T, client *OIDCClient, state string, nonce string, verifier string) url.Values {
+	t.Helper()
+
+	raw, err := client.AuthorizationURL(context.Background(), state, nonce, verifier)
+	if err != nil {
+		t.Fatalf("Failed to build authorization URL: %v", err)
+	}
+	if !strings.HasPrefix(raw, m.server.URL+"/authorize?") {
+		t.Fatalf("Expected the issuer authorization endpoint, got %s", raw)
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("Failed to parse authorization URL: %v", err)
+	}
+	query := parsed.Query()
+	m.challenge = query.Get("code_challenge")
+	return query
+}
+
+func validClaims(nonce string) func(issuer string) jwt.MapClaims {
+	return func(issuer string) jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":            issuer,
+			"aud":            testOIDCClientID,
+			"sub":            "subject-1",
+			"email":          "jane@example.com",
+			"email_verified": true,
+			"nonce":          nonce,
+			"iat":            time.Now().Unix(),
+			"exp":            time.Now().Add(time.Hour).Unix(),
+		}
+	}
+}
+
+func TestOIDCAuthorizationURL(t *testing.T) {
+	m := newMockIssuer(t)
+	query := m.authorize(t, m.client(), "state-1", "nonce-1", "verifier-1")
+
+	challenge := sha256.Sum256([]byte("verifier-1"))
+	expected := map[string]string{
+		"response_type":         "code",
+		"client_id":             testOIDCClientID,
+		"redirect_uri":          "http://localhost:3000/auth/oidc/callback",
+		"scope":                 defaultOIDCScopes,
+		"state":                 "state-1",
+		"nonce":                 "nonce-1",
+		"code_challenge":        base64.RawURLEncoding.EncodeToString(challenge[:]),
+		"code_challenge_method": "S256",
+	}
+	for name, value := range expected {
+		if got := query.Get(name); got != value {
+			t.Errorf("Expected %s %q, got %q", name, value, got)
+		}
+	}
+	if query.Has("code_verifier") {
+		t.Errorf("Expected the code verifier to stay out of the authorization URL")
+	}
+}
+
+func TestOIDCExchange(t *testing.T) {
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		verifier string
+		nonce    string
+		claims   func(claims jwt.MapClaims)
+		signer   *rsa.PrivateKey
+		valid    bool
+	}{
+		{name: "valid", verifier: "verifier-1", nonce: "nonce-1", valid: true},
+		{name: "other verifier", verifier: "verifier-2", nonce: "nonce-1"},
+		{name: "other nonce", verifier: "verifier-1", nonce: "nonce-2"},
+		{name: "missing nonce", verifier: "verifier-1", nonce: "nonce-1", claims: func(c jwt.MapClaims) { delete(c, "nonce") }},
+		{name: "other audience", verifier: "verifier-1", nonce: "nonce-1", claims: func(c jwt.MapClaims) { c["aud"] = "other-client" }},
+		{name: "other issuer", verifier: "verifier-1", nonce: "nonce-1", claims: func(c jwt.MapClaims) { c["iss"] = "https://issuer.example.com" }},
+		{name: "expired", verifier: "verifier-1", nonce: "nonce-1", claims: func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }},
+		{name: "missing sub", verifier: "verifier-1", nonce: "nonce-1", claims: func(c jwt.MapClaims) { delete(c, "sub") }},
+		{name: "other key", verifier: "verifier-1", nonce: "nonce-1", signer: otherKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMockIssuer(t)
+			client := m.client()
+			m.authorize(t, client, "state-1", "nonce-1", "verifier-1")
+
+			m.claims = func(issuer string) jwt.MapClaims {
+				claims := validClaims("nonce-1")(issuer)
+				if tt.claims != nil {
+					tt.claims(claims)
+				}
+				return claims
+			}
+			if tt.signer != nil {
+				m.signer = tt.signer
+			}
+
+			identity, err := client.Exchange(context.Background(), testOIDCCode, tt.verifier, tt.nonce)
+			if !tt.valid {
+				if err == nil {
+					t.Fatalf("Expected the exchange to fail, got %+v", identity)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected the exchange to succeed, got %v", err)
+			}
+			if identity.Subject != "subject-1" || identity.Email != "jane@example.com" || !identity.EmailVerified() {
+				t.Errorf("Unexpected identity %+v", identity)
+			}
+		})
+	}
+}
+
+func TestOIDCIdentityEmailVerified(t *testing.T) {
+	tests := []struct {
+		name     string
+		claims   jwt.MapClaims
+		verified bool
+	}{
+		{"true", jwt.MapClaims{"email_verified": true}, true},
+		{"string true", jwt.MapClaims{"email_verified": "true"}, true},
+		{"false", jwt.MapClaims{"email_verified": false}, false},
+		{"string false", jwt.MapClaims{"email_verified": "false"}, false},
+		{"missing", jwt.MapClaims{}, false},
+		{"null", jwt.MapClaims{"email_verified": nil}, false},
+		{"string yes", jwt.MapClaims{"email_verified": "yes"}, false},
+		{"string upper true", jwt.MapClaims{"email_verified": "TRUE"}, false},
+		{"number", jwt.MapClaims{"email_verified": float64(1)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity := OIDCIdentity{Claims: tt.claims}
+			if got := identity.EmailVerified(); got != tt.verified {
+				t.Errorf("Expected %v, got %v", tt.verified, got)
+			}
+		})
+	}
+}
@@ -41,6 +41,11 @@ func main() {
 		return
 	}
 
+	if err := configs.InitializeAdminUser(ctx, db, env.AdminUsername, env.AdminPassword); err != nil {
+		slog.Error("Failed to initialize admin user", "error", err)
+		return
+	}
+
 	rateLimitMgr := common.NewRateLimitManager(rdb)
 	if err := rateLimitMgr.ClearAllRateLimitData(ctx); err != nil {
 		slog.Warn("Failed to clear rate limit data on startup", "error", err)
@@ -58,7 +63,7 @@ func main() {
 
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%s", env.AppPort),
-		Handler: middleware.RateLimitMiddleware(env, rateLimitMgr)(middleware.ObservabilityMiddleware(middleware.CORS(svr))),
+		Handler: middleware.RateLimitMiddleware(env, rateLimitMgr)(middleware.ObservabilityMiddleware(middleware.ClientMiddleware(middleware.CORS(svr)))),
 	}
 
 	slog.Info("Server is running at port", "port", env.AppPort)
@@ -122,41 +122,42 @@ func canonicalJSON(data interface{}) (string, error) {
 	return string(sorted), nil
 }
 
-// BuildDetailCacheKey constructs a detail cache key in the format "entity:detail:{id}"
+// BuildDetailCacheKey constructs a detail cache key in the format "entity:detail:{userId}:{id}"
 // This matches the EntityCachePatterns format and is used by GetDetail methods
-// Example: BuildDetailCacheKey("account", 123) → "account:detail:123"
-func BuildDetailCacheKey(entity string, id int64) string {
-	return fmt.Sprintf("%s:detail:%d", entity, id)
+// The owner ID from the context keeps cached rows from leaking across users
+// Example: BuildDetailCacheKey(ctx, "account", 123) → "account:detail:1:123"
+func BuildDetailCacheKey(ctx context.Context, entity string, id int64) string {
+	return fmt.Sprintf("%s:detail:%d:%d", entity, GetUserID(ctx), id)
 }
 
-// BuildPagedCacheKey constructs a paged list cache key in the format "entity:paged:{hash}"
+// BuildPagedCacheKey constructs a paged list cache key in the format "entity:paged:{userId}:{hash}"
 // This matches the EntityCachePatterns format and is used by GetPaged methods
 // The hash is computed from search parameters to ensure unique keys for different queries
 // Uses canonical JSON encoding to ensure deterministic cache keys
-// Example: BuildPagedCacheKey("account", searchParams) → "account:paged:{json_hash}"
-func BuildPagedCacheKey(entity string, searchParams interface{}) string {
+// Example: BuildPagedCacheKey(ctx, "account", searchParams) → "account:paged:1:{json_hash}"
+func BuildPagedCacheKey(ctx context.Context, entity string, searchParams interface{}) string {
 	hash, err := canonicalJSON(searchParams)
 	if err != nil {
 		// Fallback to non-canonical if marshalling fails
 		data, _ := json.Marshal(searchParams)
 		hash = string(data)
 	}
-	return fmt.Sprintf("%s:paged:%s", entity, hash)
+	return fmt.Sprintf("%s:paged:%d:%s", entity, GetUserID(ctx), hash)
 }
 
-// BuildStatisticsCacheKey constructs a statistics cache key in format "entity:statistics:{id}:{type}:{hash}"
+// BuildStatisticsCacheKey constructs a statistics cache key in format "entity:statistics:{id}:{type}:{userId}:{hash}"
 // This matches the EntityCachePatterns format and is used by statistics service methods
 // Allows fine-grained cache invalidation by entity ID, statistic type, and parameters
 // Uses canonical JSON encoding to ensure deterministic cache keys
-// Example: BuildStatisticsCacheKey("account", 123, "category_heatmap", params) → "account:statistics:123:category_heatmap:{hash}"
-func BuildStatisticsCacheKey(entity string, entityID int64, statisticType string, searchParams interface{}) string {
+// Example: BuildStatisticsCacheKey(ctx, "account", 123, "category_heatmap", params) → "account:statistics:123:category_heatmap:1:{hash}"
+func BuildStatisticsCacheKey(ctx context.Context, entity string, entityID int64, statisticType string, searchParams interface{}) string {
 	hash, err := canonicalJSON(searchParams)
 	if err != nil {
 		// Fallback to non-canonical if marshalling fails
 		data, _ := json.Marshal(searchParams)
 		hash = string(data)
 	}
-	return fmt.Sprintf("%s:statistics:%d:%s:%s", entity, entityID, statisticType, hash)
+	return fmt.Sprintf("%s:statistics:%d:%s:%d:%s", entity, entityID, statisticType, GetUserID(ctx), hash)
 }
 
 // BuildConfigCacheKey constructs a configuration cache key in format "config:{configType}"
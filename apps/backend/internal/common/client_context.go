@@ -0,0 +1,54 @@
+package common
+
+import (
+	"context"
+	"net"
+	"strings"
+)
+
+// ClientInfo describes the device a request comes from
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+type clientContextKey string
+
+// ClientInfoKey is the context key holding the request's ClientInfo
+const ClientInfoKey clientContextKey = "client_info"
+
+// WithClientInfo returns a copy of ctx carrying the given client info
+func WithClientInfo(ctx context.Context, client ClientInfo) context.Context {
+	return context.WithValue(ctx, ClientInfoKey, client)
+}
+
+// GetClientInfo retrieves the client info of the current request
+// Returns an empty ClientInfo outside of an HTTP request
+func GetClientInfo(ctx context.Context) ClientInfo {
+	if client, ok := ctx.Value(ClientInfoKey).(ClientInfo); ok {
+		return client
+	}
+	return ClientInfo{}
+}
+
+// ClientIPFromForwardedFor resolves the client IP from an X-Forwarded-For header set by the deploy proxy
+// The proxy appends the address it received the request from, so the last entry is the one that cannot be spoofed;
+// without the header the connection's remote address is used. Ports are stripped so every connection of a client
+// resolves to the same address
+func ClientIPFromForwardedFor(forwardedFor string, remoteAddr string) string {
+	if forwardedFor != "" {
+		entries := strings.Split(forwardedFor, ",")
+		if ip := strings.TrimSpace(entries[len(entries)-1]); ip != "" {
+			return stripPort(ip)
+		}
+	}
+	return stripPort(remoteAddr)
+}
+
+// stripPort returns the host part of an address, or the address itself when it has no port
+func stripPort(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
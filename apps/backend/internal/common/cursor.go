@@ -0,0 +1,67 @@
+package common
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor was not produced by EncodeCursor
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// EncodeCursor packs the (date, id) sort key of a row into an opaque keyset pagination cursor
+// Example: EncodeCursor(date, 42) → "MjAyNi0wMS0wMlQwMzowNDowNVp8NDI"
+func EncodeCursor(date time.Time, id int64) string {
+	raw := date.UTC().Format(time.RFC3339Nano) + "|" + strconv.FormatInt(id, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor unpacks a cursor built by EncodeCursor
+func DecodeCursor(cursor string) (time.Time, int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, 0, ErrInvalidCursor
+	}
+
+	datePart, idPart, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return time.Time{}, 0, ErrInvalidCursor
+	}
+	date, err := time.Parse(time.RFC3339Nano, datePart)
+	if err != nil {
+		return time.Time{}, 0, ErrInvalidCursor
+	}
+	id, err := strconv.ParseInt(idPart, 10, 64)
+	if err != nil || id < 1 {
+		return time.Time{}, 0, ErrInvalidCursor
+	}
+	return date, id, nil
+}
+
+// ParseCursorParams checks the after and before cursors of a list sorted by sortBy and decodes the one given
+// They cannot be combined and need sortBy=date; nil is returned when neither is set
+func ParseCursorParams(sortBy string, after string, before string) (*time.Time, *int64, error) {
+	cursor := after
+	switch {
+	case after != "" && before != "":
+		return nil, nil, huma.Error400BadRequest("after and before cannot be combined")
+	case before != "":
+		cursor = before
+	}
+	if cursor == "" {
+		return nil, nil, nil
+	}
+
+	if sortBy != "date" {
+		return nil, nil, huma.Error400BadRequest("Cursor pagination requires sortBy=date")
+	}
+	date, id, err := DecodeCursor(cursor)
+	if err != nil {
+		return nil, nil, huma.Error400BadRequest("Invalid cursor")
+	}
+	return &date, &id, nil
+}
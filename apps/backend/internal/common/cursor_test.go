@@ -0,0 +1,58 @@
+package common
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+func TestParseCursorParams(t *testing.T) {
+	date := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+	cursor := EncodeCursor(date, 42)
+
+	tests := []struct {
+		name   string
+		sortBy string
+		after  string
+		before string
+		valid  bool
+		cursor bool
+	}{
+		{"none", "amount", "", "", true, false},
+		{"after", "date", cursor, "", true, true},
+		{"before", "date", "", cursor, true, true},
+		{"combined", "date", cursor, cursor, false, false},
+		{"other sort", "amount", cursor, "", false, false},
+		{"relevance", "relevance", "", cursor, false, false},
+		{"garbage", "date", "not-a-cursor", "", false, false},
+		{"zero id", "date", EncodeCursor(date, 0), "", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotDate, gotID, err := ParseCursorParams(tt.sortBy, tt.after, tt.before)
+			if !tt.valid {
+				var statusErr huma.StatusError
+				if !errors.As(err, &statusErr) || statusErr.GetStatus() != http.StatusBadRequest {
+					t.Fatalf("Expected a bad request, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !tt.cursor {
+				if gotDate != nil || gotID != nil {
+					t.Errorf("Expected no cursor, got %v %v", gotDate, gotID)
+				}
+				return
+			}
+			if gotDate == nil || !gotDate.Equal(date) || gotID == nil || *gotID != 42 {
+				t.Errorf("Expected %v and 42, got %v %v", date, gotDate, gotID)
+			}
+		})
+	}
+}
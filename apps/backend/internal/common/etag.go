@@ -0,0 +1,59 @@
+package common
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// BuildETag constructs the entity tag of a record from its last change
+// Format: "{id}-{unix microseconds of updated_at, or created_at when never updated}"
+func BuildETag(id int64, createdAt time.Time, updatedAt *time.Time) string {
+	version := createdAt
+	if updatedAt != nil {
+		version = *updatedAt
+	}
+	return fmt.Sprintf(`"%d-%d"`, id, version.UnixMicro())
+}
+
+// PreconditionFailedError is the 412 response body when a record changed since the client read it
+type PreconditionFailedError[T any] struct {
+	huma.ErrorModel
+	Current T `json:"current" doc:"Current representation of the record"`
+	etag    string
+}
+
+// GetHeaders sets ETag so clients can retry against the current representation without reading it again
+func (e *PreconditionFailedError[T]) GetHeaders() http.Header {
+	return http.Header{"ETag": []string{e.etag}}
+}
+
+// RequireETag checks an If-Match header against the current entity tag of a record
+// The header is parsed as in RFC 9110: an empty header or * always matches; otherwise it is a comma separated list
+// and one of the tags must equal the current one. If-Match uses strong comparison, so a weak tag (W/"...") never matches
+// The current representation is returned in the 412 response so the client can merge its change
+func RequireETag[T any](ifMatch string, etag string, current T) error {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" {
+		return nil
+	}
+	for _, tag := range strings.Split(ifMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || tag == etag {
+			return nil
+		}
+	}
+
+	return &PreconditionFailedError[T]{
+		ErrorModel: huma.ErrorModel{
+			Status: http.StatusPreconditionFailed,
+			Title:  http.StatusText(http.StatusPreconditionFailed),
+			Detail: "The record was changed since it was read",
+		},
+		Current: current,
+		etag:    etag,
+	}
+}
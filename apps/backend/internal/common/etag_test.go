@@ -0,0 +1,51 @@
+package common
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestRequireETag(t *testing.T) {
+	const etag = `"7-1700000000000000"`
+
+	tests := []struct {
+		name    string
+		ifMatch string
+		match   bool
+	}{
+		{"empty", "", true},
+		{"any", "*", true},
+		{"same", `"7-1700000000000000"`, true},
+		{"weak", `W/"7-1700000000000000"`, false},
+		{"list", `"7-1", "7-1700000000000000"`, true},
+		{"list with weak", `"7-1",W/"7-1700000000000000"`, false},
+		{"list with any", `"7-1", *`, true},
+		{"other", `"7-1"`, false},
+		{"other weak", `W/"7-1"`, false},
+		{"unquoted", `7-1700000000000000`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RequireETag(tt.ifMatch, etag, struct{}{})
+			if tt.match {
+				if err != nil {
+					t.Errorf("Expected %q to match, got %v", tt.ifMatch, err)
+				}
+				return
+			}
+
+			var precondition *PreconditionFailedError[struct{}]
+			if !errors.As(err, &precondition) {
+				t.Fatalf("Expected a precondition failure for %q, got %v", tt.ifMatch, err)
+			}
+			if precondition.Status != http.StatusPreconditionFailed {
+				t.Errorf("Expected status %d, got %d", http.StatusPreconditionFailed, precondition.Status)
+			}
+			if got := precondition.GetHeaders()["ETag"]; len(got) != 1 || got[0] != etag {
+				t.Errorf("Expected ETag header %s, got %v", etag, got)
+			}
+		})
+	}
+}
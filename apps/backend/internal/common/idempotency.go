@@ -0,0 +1,80 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/redis/go-redis/v9"
+)
+
+// BuildIdempotencyKey constructs the key holding the outcome of a request sent with an Idempotency-Key header
+// Format: "idempotency:{userId}:{key}"
+func BuildIdempotencyKey(userID int64, key string) string {
+	return fmt.Sprintf("%s%d:%s", constants.IdempotencyKeyPrefix, userID, key)
+}
+
+// IdempotencyRecord is what is stored for an idempotency key
+// A record that is not completed yet belongs to a request still being processed
+type IdempotencyRecord struct {
+	RequestHash string      `json:"requestHash"`
+	Completed   bool        `json:"completed"`
+	Status      int         `json:"status,omitempty"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        []byte      `json:"body,omitempty"`
+}
+
+// IdempotencyManager stores the responses of requests sent with an idempotency key
+type IdempotencyManager struct {
+	rdb *redis.Client
+}
+
+// NewIdempotencyManager creates a new idempotency manager
+func NewIdempotencyManager(rdb *redis.Client) *IdempotencyManager {
+	return &IdempotencyManager{rdb: rdb}
+}
+
+// Claim reserves the key for a request with the given hash
+// When the key is already taken, the existing record is returned instead and claimed is false
+func (m *IdempotencyManager) Claim(ctx context.Context, userID int64, key string, requestHash string) (IdempotencyRecord, bool, error) {
+	redisKey := BuildIdempotencyKey(userID, key)
+	data, err := json.Marshal(IdempotencyRecord{RequestHash: requestHash})
+	if err != nil {
+		return IdempotencyRecord{}, false, fmt.Errorf("failed to marshal idempotency record: %w", err)
+	}
+
+	// The record can expire between the two calls, so a missing record is claimed again once
+	for range 2 {
+		claimed, err := m.rdb.SetNX(ctx, redisKey, data, constants.IdempotencyLockTTL).Result()
+		if err != nil {
+			return IdempotencyRecord{}, false, fmt.Errorf("redis setnx failed: %w", err)
+		}
+		if claimed {
+			return IdempotencyRecord{RequestHash: requestHash}, true, nil
+		}
+
+		existing, err := getCache[IdempotencyRecord](ctx, m.rdb, redisKey)
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return IdempotencyRecord{}, false, fmt.Errorf("failed to get idempotency record: %w", err)
+		}
+		return existing, false, nil
+	}
+
+	return IdempotencyRecord{}, false, fmt.Errorf("idempotency key %s could not be claimed", key)
+}
+
+// Complete stores the response for a claimed key so retries replay it
+func (m *IdempotencyManager) Complete(ctx context.Context, userID int64, key string, record IdempotencyRecord) error {
+	record.Completed = true
+	return setCache(ctx, m.rdb, BuildIdempotencyKey(userID, key), record, constants.IdempotencyResponseTTL)
+}
+
+// Release frees a claimed key without storing a response, so the request can be retried
+func (m *IdempotencyManager) Release(ctx context.Context, userID int64, key string) error {
+	return m.rdb.Del(ctx, BuildIdempotencyKey(userID, key)).Err()
+}
@@ -0,0 +1,68 @@
+package common
+
+import (
+	"context"
+
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+)
+
+type userContextKey string
+
+// UserIDKey is the context key holding the authenticated user's ID
+const UserIDKey userContextKey = "user_id"
+
+// WithUserID returns a copy of ctx carrying the given user ID
+// Used by workers that act on behalf of a resource owner outside of an HTTP request
+func WithUserID(ctx context.Context, userID int64) context.Context {
+	return context.WithValue(ctx, UserIDKey, userID)
+}
+
+// GetUserID retrieves the authenticated user ID from the context
+// Returns 0 when no user is attached, which never matches an owned row
+func GetUserID(ctx context.Context) int64 {
+	if userID, ok := ctx.Value(UserIDKey).(int64); ok {
+		return userID
+	}
+	return 0
+}
+
+// TokenIDKey is the context key holding the jti of the access token used for the request
+const TokenIDKey userContextKey = "token_id"
+
+// GetTokenID retrieves the current access token ID from the context
+// Returns an empty string when the request was not authenticated with a token
+func GetTokenID(ctx context.Context) string {
+	if tokenID, ok := ctx.Value(TokenIDKey).(string); ok {
+		return tokenID
+	}
+	return ""
+}
+
+// AuditSourceKey is the context key holding where a change originates from, recorded in the audit log
+const AuditSourceKey userContextKey = "audit_source"
+
+// WithAuditSource returns a copy of ctx recording changes as made by the given source
+func WithAuditSource(ctx context.Context, source string) context.Context {
+	return context.WithValue(ctx, AuditSourceKey, source)
+}
+
+// GetAuditSource retrieves the source of changes made with ctx
+// Defaults to the API, since workers and bulk operations set their own source
+func GetAuditSource(ctx context.Context) string {
+	if source, ok := ctx.Value(AuditSourceKey).(string); ok {
+		return source
+	}
+	return constants.AuditSourceAPI
+}
+
+// SessionIDKey is the context key holding the session the request's access token belongs to
+const SessionIDKey userContextKey = "session_id"
+
+// GetSessionID retrieves the current session ID from the context
+// Returns 0 for personal access tokens and tokens issued before sessions were recorded
+func GetSessionID(ctx context.Context) int64 {
+	if sessionID, ok := ctx.Value(SessionIDKey).(int64); ok {
+		return sessionID
+	}
+	return 0
+}
@@ -22,6 +22,26 @@ const (
 	SNAP_EXCHANGE_URL_ENV = "SNAP_EXCHANGE_URL"
 )
 
+// OpenID Connect login, enabled when the issuer and client ID are set
+const (
+	OIDC_ISSUER_URL_ENV     = "OIDC_ISSUER_URL"
+	OIDC_CLIENT_ID_ENV      = "OIDC_CLIENT_ID"
+	OIDC_CLIENT_SECRET_ENV  = "OIDC_CLIENT_SECRET"  // Optional, public clients rely on PKCE alone
+	OIDC_REDIRECT_URL_ENV   = "OIDC_REDIRECT_URL"   // Frontend page receiving the code and state
+	OIDC_SCOPES_ENV         = "OIDC_SCOPES"         // Space separated, defaults to "openid email profile"
+	OIDC_USERNAME_CLAIM_ENV = "OIDC_USERNAME_CLAIM" // ID token claim matched against usernames, defaults to "email"
+)
+
+// Transaction attachments on the local filesystem
+const (
+	ATTACHMENT_STORAGE_DIR_ENV = "ATTACHMENT_STORAGE_DIR" // Defaults to "data/attachments" under the working directory
+)
+
+// Soft-deleted records stay restorable from the trash until the purge worker removes them
+const (
+	TRASH_RETENTION_DAYS_ENV = "TRASH_RETENTION_DAYS" // Defaults to 30
+)
+
 const (
 	AppStageProd = "AppStageProd"
 	AppStageDev  = "AppStageDev"
@@ -0,0 +1,25 @@
+package configs
+
+import (
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+)
+
+const DefaultTrashRetentionDays = 30
+
+// TrashRetention returns how long soft-deleted records are kept before being purged
+// Invalid or non-positive values fall back to DefaultTrashRetentionDays
+func TrashRetention() time.Duration {
+	days := DefaultTrashRetentionDays
+	if raw := os.Getenv(TRASH_RETENTION_DAYS_ENV); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			slog.Warn("Invalid trash retention, using the default", "value", raw, "default_days", DefaultTrashRetentionDays)
+		} else {
+			days = parsed
+		}
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
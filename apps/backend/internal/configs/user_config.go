@@ -0,0 +1,71 @@
+package configs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ownedTables lists every top-level table carrying a user_id owner column
+var ownedTables = []string{
+	"accounts",
+	"categories",
+	"transactions",
+	"tags",
+	"transaction_templates",
+	"budget_templates",
+	"budgets",
+}
+
+// InitializeAdminUser seeds the first user from the admin credentials on first boot.
+// When the users table is empty, it inserts the admin with a bcrypt hashed password
+// and the admin flag, which is the only account allowed to register further users,
+// then assigns every row created before multi-user support to that user.
+// On subsequent runs, it does nothing so passwords changed later are never overwritten.
+func InitializeAdminUser(ctx context.Context, db *pgxpool.Pool, username string, password string) error {
+	var count int
+	if err := db.QueryRow(ctx, "SELECT COUNT(*) FROM users").Scan(&count); err != nil {
+		return fmt.Errorf("failed to query users: %w", err)
+	}
+	if count > 0 {
+		slog.Info("✓ Users verified", "count", count)
+		return nil
+	}
+
+	if username == "" || password == "" {
+		return fmt.Errorf("no users exist and %s/%s are not set", ADMIN_USERNAME_ENV, ADMIN_PASSWORD_ENV)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash admin password: %w", err)
+	}
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var userID int64
+	err = tx.QueryRow(ctx, "INSERT INTO users (username, password_hash, is_admin) VALUES ($1, $2, TRUE) RETURNING id", username, string(hash)).Scan(&userID)
+	if err != nil {
+		return fmt.Errorf("failed to create admin user: %w", err)
+	}
+
+	for _, table := range ownedTables {
+		if _, err := tx.Exec(ctx, "UPDATE "+table+" SET user_id = $1 WHERE user_id IS NULL", userID); err != nil {
+			return fmt.Errorf("failed to assign %s to admin user: %w", table, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit admin user: %w", err)
+	}
+
+	slog.Info("Admin user initialized", "username", username, "id", userID)
+	return nil
+}
@@ -0,0 +1,17 @@
+package constants
+
+// Transaction attachment limits
+const (
+	AttachmentMaxSizeBytes  = 10 << 20   // Largest accepted file
+	AttachmentMaxPixels     = 40_000_000 // Larger images are stored without a thumbnail rather than decoded
+	AttachmentThumbnailSize = 320        // Longest side of a generated thumbnail, in pixels
+)
+
+// AttachmentContentTypes lists the accepted file types, as detected from the file content rather than the declared type
+var AttachmentContentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+}
@@ -0,0 +1,24 @@
+package constants
+
+// Operations recorded in the audit log
+const (
+	AuditOperationCreate  = "create"
+	AuditOperationUpdate  = "update"
+	AuditOperationDelete  = "delete"
+	AuditOperationRestore = "restore" // A soft-deleted record brought back from the trash
+)
+
+// Sources recorded in the audit log, telling apart changes made through the API from those made in the background
+const (
+	AuditSourceAPI                       = "api"
+	AuditSourceBulkCommit                = "bulk_commit"
+	AuditSourceBudgetTemplateWorker      = "budget_template_worker"
+	AuditSourceTransactionTemplateWorker = "transaction_template_worker"
+	AuditSourceTransactionRules          = "transaction_rules" // Transactions changed by re-applying rules to a date range
+)
+
+// EntityShare is the audit entity type of an account, budget template or transaction template share
+const EntityShare = "share"
+
+// EntityTransactionAttachment is the audit entity type of a file attached to a transaction
+const EntityTransactionAttachment = "transaction_attachment"
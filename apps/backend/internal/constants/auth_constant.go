@@ -0,0 +1,48 @@
+package constants
+
+import "time"
+
+const (
+	// Token kinds stored in the "sub" claim
+	AuthTokenTypeAccess    = "access"
+	AuthTokenTypeRefresh   = "refresh"
+	AuthTokenTypeChallenge = "challenge" // Returned by login while the second factor is pending
+
+	// Token lifetimes
+	AuthAccessTokenTTL    = 7 * 24 * time.Hour
+	AuthRefreshTokenTTL   = 30 * 24 * time.Hour
+	AuthChallengeTokenTTL = 5 * time.Minute
+	AuthOIDCStateTTL      = 10 * time.Minute // Time allowed to sign in at the identity provider
+
+	// Revocation list keys
+	AuthRevokedTokenKeyPrefix      = "auth:revoked:"            // Suffixed with the token jti, expires with the token
+	AuthRevokedBeforeKeyPrefix     = "auth:revoked_before:"     // Suffixed with the user ID, holds the logout-all unix time
+	AuthChallengeAttemptsKeyPrefix = "auth:challenge_attempts:" // Suffixed with the challenge jti
+	AuthRevokedSessionKeyPrefix    = "auth:revoked_session:"    // Suffixed with the session ID, expires with its last refresh token
+	AuthSessionSeenKeyPrefix       = "auth:session_seen:"       // Suffixed with the session ID, set while last_seen_at is fresh
+	AuthOIDCStateKeyPrefix         = "auth:oidc_state:"         // Suffixed with the OIDC state, holds the nonce and PKCE verifier
+
+	// How often a session's last_seen_at and IP address are written back while it is in use
+	AuthSessionSeenInterval = time.Minute
+)
+
+// Two-factor authentication settings
+const (
+	AuthTOTPIssuer             = "Spenicle"
+	AuthChallengeMaxAttempts   = 5  // Wrong codes allowed per challenge token before it is revoked
+	AuthRecoveryCodeCount      = 10 // Recovery codes issued when 2FA is enabled
+	AuthRecoveryCodeByteLength = 10 // 80-bit codes, rendered as base32 in groups of four
+)
+
+// Personal access token scopes, declared on each huma operation's bearer security requirement
+// Operations without a scope (auth, users, preferences) are only reachable with a session JWT
+const (
+	AuthScopeTransactionsRead  = "transactions:read"  // Accounts, categories, tags, transactions and templates
+	AuthScopeTransactionsWrite = "transactions:write" // Mutations of the above
+	AuthScopeBudgetsRead       = "budgets:read"       // Budget templates and generated budgets
+	AuthScopeBudgetsWrite      = "budgets:write"      // Mutations of the above
+	AuthScopeReportsRead       = "reports:read"       // Summaries and statistics
+
+	// Personal access tokens carry this prefix so the session middleware can tell them from JWTs
+	AuthAPITokenPrefix = "spk_"
+)
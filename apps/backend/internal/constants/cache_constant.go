@@ -12,7 +12,10 @@ const (
 	EntityBudget              = "budget"
 	EntityBudgetTemplate      = "budget_template"
 	EntityTag                 = "tag"
+	EntityPayee               = "payee"
 	EntityTransactionTemplate = "transaction_template"
+	EntityTransactionRule     = "transaction_rule"
+	EntityReimbursement       = "reimbursement"
 	EntityConfig              = "config"
 )
 
@@ -22,11 +25,12 @@ const (
 	SummaryCategory    = "summary:category"
 	SummaryTransaction = "summary:transaction"
 	SummaryGeospatial  = "summary:geospatial"
+	SummaryPayee       = "summary:payee"
 )
 
 // Cache keys for special features
 const (
-	BulkDraftKey            = "bulk_draft"            // Global bulk transaction draft key
+	BulkDraftKey            = "bulk_draft"            // Bulk transaction draft key prefix, suffixed with the user ID
 	ConfigBaseCurrencyKey   = "config:baseCurrency"   // Base currency configuration key
 	ConfigBaseCurrencyTSKey = "config:baseCurrencyTs" // Base currency with timestamp key
 )
@@ -65,12 +69,14 @@ var EntityCachePatterns = map[string][]string{
 		"account:paged:*",
 		"account:statistics:{accountId}:*:*",
 		SummaryAccount + ":*",
+		"transaction:paged:*",
 	},
 	EntityCategory: {
 		"category:detail:*",
 		"category:paged:*",
 		"category:statistics:{categoryId}:*:*",
 		SummaryCategory + ":*",
+		"transaction:paged:*",
 	},
 	EntityTransaction: {
 		"transaction:detail:*",
@@ -82,10 +88,13 @@ var EntityCachePatterns = map[string][]string{
 		"category:statistics:{categoryId}:*:*",
 		"budget:detail:*",
 		"budget:paged:*",
+		"reimbursement:detail:*",
+		"reimbursement:paged:*",
 		SummaryTransaction + ":*",
 		SummaryAccount + ":*",
 		SummaryCategory + ":*",
 		SummaryGeospatial + ":*",
+		SummaryPayee + ":*",
 	},
 	EntityTransactionTag: {
 		"transaction_tag:detail:*",
@@ -115,9 +124,32 @@ var EntityCachePatterns = map[string][]string{
 		"tag:detail:*",
 		"tag:paged:*",
 		"transaction_tag:paged:*",
+		"transaction:paged:*",
 	},
 	EntityTransactionTemplate: {
 		"transaction_template:detail:*",
 		"transaction_template:paged:*",
 	},
+	EntityPayee: {
+		"payee:detail:*",
+		"payee:paged:*",
+		"transaction:detail:*",
+		"transaction:paged:*",
+		"transaction_template:detail:*",
+		"transaction_template:paged:*",
+		SummaryPayee + ":*",
+	},
+	EntityTransactionRule: {
+		"transaction_rule:detail:*",
+		"transaction_rule:paged:*",
+	},
+	EntityReimbursement: {
+		"reimbursement:detail:*",
+		"reimbursement:paged:*",
+		SummaryTransaction + ":*",
+		SummaryAccount + ":*",
+		SummaryCategory + ":*",
+		SummaryGeospatial + ":*",
+		SummaryPayee + ":*",
+	},
 }
@@ -0,0 +1,19 @@
+package constants
+
+import "time"
+
+// Idempotency key configuration
+// A POST sent with an Idempotency-Key header is processed once per user and key; retries get the stored response
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+	IdempotencyKeyPrefix      = "idempotency:"
+	IdempotencyKeyMaxLength   = 255
+	IdempotencyMaxBodyBytes   = AttachmentMaxSizeBytes + 1<<20 // Largest request body hashed; covers attachment uploads
+	IdempotencyResponseTTL    = 24 * time.Hour                 // How long a response is replayed
+	IdempotencyLockTTL        = time.Minute                    // How long a key stays claimed by a request that never finished
+)
+
+// IdempotencyExemptMetadataKey marks operations whose requests carry credentials or whose responses return secrets
+// Set it to true in huma.Operation.Metadata; the Idempotency-Key header is then ignored and nothing is stored
+const IdempotencyExemptMetadataKey = "idempotencyExempt"
@@ -16,6 +16,22 @@ const (
 	RateLimitWindow   = time.Minute
 )
 
+// Login throttle configuration
+// Failures are counted per username+IP and, more loosely, per username across all IPs
+// Once the free attempts are used up, each further failure doubles the lockout
+const (
+	LoginThrottleKeyPrefix        = "login_throttle:"
+	LoginThrottleFailuresLabel    = "failures"
+	LoginThrottleLockLabel        = "lock"
+	LoginThrottleScopeUserIP      = "user_ip"
+	LoginThrottleScopeUser        = "user"
+	LoginThrottleUserIPFreeTries  = 5
+	LoginThrottleUserFreeTries    = 20
+	LoginThrottleBaseLockout      = 30 * time.Second
+	LoginThrottleMaxLockout       = 1 * time.Hour
+	LoginThrottleFailureRetention = 24 * time.Hour // Failure counters reset after a quiet day
+)
+
 // RateLimitCachePatterns defines wildcard patterns for bulk operations
 // Used by ClearAllRateLimitData() for startup cleanup
 var RateLimitCachePatterns = map[string][]string{
@@ -0,0 +1,17 @@
+package constants
+
+// Bank status of a transaction
+const (
+	TransactionStatusPending    = "pending"    // Recorded, not yet posted by the bank
+	TransactionStatusCleared    = "cleared"    // Posted by the bank
+	TransactionStatusReconciled = "reconciled" // Matched to a completed statement reconciliation, locked against changes
+)
+
+// Status of an account reconciliation
+const (
+	ReconciliationStatusOpen      = "open"
+	ReconciliationStatusCompleted = "completed"
+)
+
+// EntityAccountReconciliation is the audit entity type of an account reconciliation
+const EntityAccountReconciliation = "account_reconciliation"
@@ -0,0 +1,9 @@
+package constants
+
+// Roles on a shared account, budget template or transaction template
+// Resources are shared by their cache entity name (EntityAccount, EntityBudgetTemplate, EntityTransactionTemplate)
+const (
+	ShareRoleOwner  = "owner"  // Implicit role of the user who created the resource, never stored
+	ShareRoleEditor = "editor" // May change the resource and, for accounts, record transactions on it
+	ShareRoleViewer = "viewer" // Read-only access
+)
@@ -0,0 +1,19 @@
+package constants
+
+// Types of a transaction relation, read from the source transaction to the related one
+const (
+	TransactionRelationRefundOf    = "refund_of"    // Source income gives back money spent by the related expense
+	TransactionRelationFeeOf       = "fee_of"       // Source expense is a fee charged for the related transaction
+	TransactionRelationSplitOf     = "split_of"     // Source is one part of the related transaction, recorded on its own
+	TransactionRelationDuplicateOf = "duplicate_of" // Source records the same thing as the related transaction
+	TransactionRelationRelated     = "related"      // Any other link; reads the same both ways
+)
+
+// SingleTargetTransactionRelationTypes are the relation types a transaction may be the source of only once
+// Migration 000042 enforces this with a partial unique index over the same list
+var SingleTargetTransactionRelationTypes = []string{
+	TransactionRelationRefundOf,
+	TransactionRelationFeeOf,
+	TransactionRelationSplitOf,
+	TransactionRelationDuplicateOf,
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
 	"github.com/dimasbaguspm/spenicle-api/internal/middleware"
 	"github.com/dimasbaguspm/spenicle-api/internal/repositories"
 	"github.com/dimasbaguspm/spenicle-api/internal/resources"
@@ -30,21 +31,34 @@ func RegisterPublicRoutes(ctx context.Context, svr *http.ServeMux, huma huma.API
 }
 
 func RegisterPrivateRoutes(ctx context.Context, huma huma.API, db *pgxpool.Pool, rdb *redis.Client) {
-	huma.UseMiddleware(middleware.SessionMiddleware(huma))
-
 	rpts := repositories.NewRootRepository(ctx, db)
 	sevs := services.NewRootService(rpts, rdb)
 
+	huma.UseMiddleware(middleware.SessionMiddleware(huma, sevs.Ath))
+	huma.UseMiddleware(middleware.IdempotencyMiddleware(huma, common.NewIdempotencyManager(rdb)))
+	huma.OpenAPI().OnAddOperation = append(huma.OpenAPI().OnAddOperation, middleware.IdempotencyKeyParameter)
+
+	resources.NewAuthResource(sevs.Ath).PrivateRoutes(huma)
+	resources.NewAPITokenResource(sevs).Routes(huma)
+	resources.NewTwoFactorResource(sevs).Routes(huma)
+
 	resources.NewAccountResource(sevs).Routes(huma)
 	resources.NewCategoryResource(sevs).Routes(huma)
+	resources.NewAccountReconciliationResource(sevs).Routes(huma)
 	resources.NewAccountStatisticsResource(sevs).Routes(huma)
 	resources.NewCategoryStatisticsResource(sevs).Routes(huma)
 	resources.NewTransactionResource(sevs).Routes(huma)
 	resources.NewTransactionBulkResource(sevs).Routes(huma)
+	resources.NewTransactionRuleResource(sevs).Routes(huma)
 	resources.NewSummaryResource(sevs).Routes(huma)
 	resources.NewBudgetTemplateResource(sevs).Routes(huma)
 	resources.NewTagResource(sevs).Routes(huma)
+	resources.NewPayeeResource(sevs).Routes(huma)
+	resources.NewReimbursementResource(sevs).Routes(huma)
 	resources.NewPreferenceResource(sevs).Routes(huma)
+	resources.NewUserResource(sevs).Routes(huma)
+	resources.NewAuditResource(sevs).Routes(huma)
+	resources.NewTrashResource(sevs).Routes(huma)
 	resources.NewSeedResource(db, rdb).Routes(huma)
 }
 
@@ -54,16 +68,19 @@ func RegisterWorkers(ctx context.Context, db *pgxpool.Pool, rdb *redis.Client) f
 
 	ttWorker := workers.NewTransactionTemplateWorker(ctx, rpts.TsctTem, sevs.Tsct, rdb)
 	btWorker := workers.NewBudgetTemplateWorker(ctx, sevs.BudgTem, rdb)
-	gitWorker := workers.NewGeoIndexTransactionsWorker(ctx, rpts.Tsct, sevs.Tsct.GetGeoIndexManager(), rdb)
+	gitWorker := workers.NewGeoIndexTransactionsWorker(ctx, rpts.Tsct, rpts.Usr, sevs.Tsct.GetGeoIndexManager(), rdb)
+	tpWorker := workers.NewTrashPurgeWorker(ctx, sevs.Trash)
 
 	ttWorker.Start()
 	btWorker.Start()
 	gitWorker.Start()
+	tpWorker.Start()
 
 	return func() {
 		slog.Info("Stopping all workers")
 		ttWorker.Stop()
 		btWorker.Stop()
 		gitWorker.Stop()
+		tpWorker.Stop()
 	}
 }
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+)
+
+// BodyLimitMiddleware caps the request body of an operation at maxBytes
+// huma only applies MaxBodyBytes to bodies it reads itself, so multipart uploads use this per operation instead
+func BodyLimitMiddleware(api huma.API, maxBytes int64) func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		r, w := humago.Unwrap(ctx)
+
+		if r.ContentLength > maxBytes {
+			observability.GetLogger(ctx.Context()).Warn("body_too_large", "content_length", r.ContentLength, "limit", maxBytes)
+			huma.WriteErr(api, ctx, http.StatusRequestEntityTooLarge, "Request body is too large")
+			return
+		}
+
+		// Bodies without a declared length are cut off while being read
+		r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+		next(ctx)
+	}
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+)
+
+// ClientMiddleware records the client IP and user agent in the request context for session tracking
+// The IP comes from the X-Forwarded-For header set by the deploy proxy, falling back to the remote address
+func ClientMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client := common.ClientInfo{
+			IP:        common.ClientIPFromForwardedFor(r.Header.Get("X-Forwarded-For"), r.RemoteAddr),
+			UserAgent: r.UserAgent(),
+		}
+		next.ServeHTTP(w, r.WithContext(common.WithClientInfo(r.Context(), client)))
+	})
+}
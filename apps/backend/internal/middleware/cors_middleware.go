@@ -8,7 +8,8 @@ import (
 var (
 	allowedOrigins = []string{"http://localhost:3000"}
 	allowedMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
-	allowedHeaders = []string{"Content-Type", "Authorization"}
+	allowedHeaders = []string{"Content-Type", "Authorization", "Idempotency-Key", "If-Match"}
+	exposedHeaders = []string{"ETag"}
 )
 
 func CORS(next http.Handler) http.Handler {
@@ -27,6 +28,7 @@ func CORS(next http.Handler) http.Handler {
 		w.Header().Set("Access-Control-Allow-Origin", allowOrigin)
 		w.Header().Set("Access-Control-Allow-Methods", strings.Join(allowedMethods, ", "))
 		w.Header().Set("Access-Control-Allow-Headers", strings.Join(allowedHeaders, ", "))
+		w.Header().Set("Access-Control-Expose-Headers", strings.Join(exposedHeaders, ", "))
 		if allowOrigin != "*" {
 			w.Header().Set("Access-Control-Allow-Credentials", "true")
 		}
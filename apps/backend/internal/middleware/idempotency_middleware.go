@@ -0,0 +1,167 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+)
+
+// IdempotencyMiddleware processes a POST sent with an Idempotency-Key header once per user and key
+// A retry with the same method, path and body gets the stored response back; reusing the key for a different
+// request is rejected with 422, and a retry while the first request is still running with 409
+// Server errors are not stored, so the request can be retried with the same key. Operations marked with
+// constants.IdempotencyExemptMetadataKey, such as login or token creation, are never stored: their bodies hold credentials or secrets
+func IdempotencyMiddleware(api huma.API, mgr *common.IdempotencyManager) func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		key := ctx.Header(constants.IdempotencyKeyHeader)
+		if ctx.Method() != http.MethodPost || key == "" || idempotencyExempt(ctx.Operation()) {
+			next(ctx)
+			return
+		}
+
+		logger := observability.GetLogger(ctx.Context()).With("idempotency_key", key)
+		if len(key) > constants.IdempotencyKeyMaxLength {
+			huma.WriteErr(api, ctx, http.StatusBadRequest, "Idempotency-Key is too long")
+			return
+		}
+
+		r, w := humago.Unwrap(ctx)
+		body, err := io.ReadAll(io.LimitReader(r.Body, constants.IdempotencyMaxBodyBytes+1))
+		if err != nil {
+			huma.WriteErr(api, ctx, http.StatusBadRequest, "Failed to read request body")
+			return
+		}
+		if len(body) > constants.IdempotencyMaxBodyBytes {
+			huma.WriteErr(api, ctx, http.StatusRequestEntityTooLarge, "Request body is too large")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		userID := common.GetUserID(ctx.Context())
+		requestHash := hashIdempotentRequest(r, body)
+
+		record, claimed, err := mgr.Claim(ctx.Context(), userID, key, requestHash)
+		if err != nil {
+			logger.Error("idempotency_claim_failed", "error", err)
+			huma.WriteErr(api, ctx, http.StatusInternalServerError, "Idempotency key check failed")
+			return
+		}
+
+		if !claimed {
+			switch {
+			case record.RequestHash != requestHash:
+				logger.Warn("idempotency_key_reused", "status", http.StatusUnprocessableEntity)
+				huma.WriteErr(api, ctx, http.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request")
+			case !record.Completed:
+				logger.Warn("idempotency_key_in_progress", "status", http.StatusConflict)
+				huma.WriteErr(api, ctx, http.StatusConflict, "A request with this Idempotency-Key is still being processed")
+			default:
+				logger.Info("idempotency_replayed", "status", record.Status)
+				for name, values := range record.Header {
+					for _, value := range values {
+						ctx.AppendHeader(name, value)
+					}
+				}
+				ctx.SetHeader(constants.IdempotencyReplayedHeader, "true")
+				ctx.SetStatus(record.Status)
+				ctx.BodyWriter().Write(record.Body)
+			}
+			return
+		}
+
+		// Headers set before the handler, such as rate limit headers, belong to this request rather than the response
+		preset := w.Header().Clone()
+		recorder := &idempotencyRecorder{ResponseWriter: w}
+		next(humago.NewContext(ctx.Operation(), r.WithContext(ctx.Context()), recorder))
+
+		if recorder.status >= http.StatusInternalServerError {
+			if err := mgr.Release(ctx.Context(), userID, key); err != nil {
+				logger.Warn("idempotency_release_failed", "error", err)
+			}
+			return
+		}
+
+		header := http.Header{}
+		for name, values := range w.Header() {
+			if _, ok := preset[name]; !ok {
+				header[name] = values
+			}
+		}
+		record.Status = recorder.status
+		if record.Status == 0 {
+			record.Status = http.StatusOK
+		}
+		record.Header = header
+		record.Body = recorder.body.Bytes()
+		if err := mgr.Complete(ctx.Context(), userID, key, record); err != nil {
+			logger.Warn("idempotency_store_failed", "error", err)
+		}
+	}
+}
+
+// IdempotencyKeyParameter documents the Idempotency-Key header on every POST operation
+// Meant for huma.OpenAPI.OnAddOperation on the API the middleware is used with
+func IdempotencyKeyParameter(oapi *huma.OpenAPI, op *huma.Operation) {
+	if op.Method != http.MethodPost || idempotencyExempt(op) {
+		return
+	}
+	maxLength := constants.IdempotencyKeyMaxLength
+	op.Parameters = append(op.Parameters, &huma.Param{
+		Name:        constants.IdempotencyKeyHeader,
+		In:          "header",
+		Description: "Unique key for this request; retries with the same key and body within 24 hours return the first response instead of being processed again",
+		Schema:      &huma.Schema{Type: huma.TypeString, MaxLength: &maxLength},
+	})
+}
+
+// idempotencyExempt reports whether the operation opted out of the idempotency store
+func idempotencyExempt(op *huma.Operation) bool {
+	if op == nil {
+		return false
+	}
+	exempt, _ := op.Metadata[constants.IdempotencyExemptMetadataKey].(bool)
+	return exempt
+}
+
+// hashIdempotentRequest fingerprints a request so a key reused for a different request can be told apart from a retry
+func hashIdempotentRequest(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// idempotencyRecorder keeps a copy of the response while writing it to the client
+type idempotencyRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *idempotencyRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *idempotencyRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer for read deadlines and flushing
+func (r *idempotencyRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
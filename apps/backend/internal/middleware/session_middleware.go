@@ -2,31 +2,83 @@ package middleware
 
 import (
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/observability"
-	"github.com/dimasbaguspm/spenicle-api/internal/repositories"
+	"github.com/dimasbaguspm/spenicle-api/internal/services"
 )
 
-func SessionMiddleware(api huma.API) func(huma.Context, func(huma.Context)) {
+// SessionMiddleware authenticates either a session JWT or a personal access token
+// Session JWTs reach every operation; personal access tokens must hold every scope
+// the operation lists on its bearer security requirement, and operations without
+// scopes are reserved for session JWTs
+func SessionMiddleware(api huma.API, as services.AuthService) func(huma.Context, func(huma.Context)) {
 	return func(ctx huma.Context, next func(huma.Context)) {
 		logger := observability.GetLogger(ctx.Context())
 
-		ar := repositories.NewAuthRepository(ctx.Context())
-
 		atk := ctx.Header("Authorization")
 		if len(atk) > 7 && atk[:7] == "Bearer " {
 			atk = atk[7:]
 		}
 
-		_, err := ar.ParseToken(atk)
+		if strings.HasPrefix(atk, constants.AuthAPITokenPrefix) {
+			token, err := as.AuthenticateAPIToken(ctx.Context(), atk)
+			if err != nil {
+				logger.Error("auth_failed", "error", err, "status", http.StatusUnauthorized)
+				huma.WriteErr(api, ctx, http.StatusUnauthorized, "Invalid or missed auth token")
+				return
+			}
+
+			required := requiredScopes(ctx.Operation())
+			if len(required) == 0 || !hasScopes(token.Scopes, required) {
+				logger.Error("auth_forbidden", "api_token_id", token.ID, "required_scopes", required, "status", http.StatusForbidden)
+				huma.WriteErr(api, ctx, http.StatusForbidden, "API token is missing the required scope")
+				return
+			}
+
+			logger.Info("auth_granted", "user_id", token.UserID, "api_token_id", token.ID)
+			next(huma.WithValue(ctx, common.UserIDKey, token.UserID))
+			return
+		}
+
+		claims, err := as.Authenticate(ctx.Context(), atk)
 		if err != nil {
 			logger.Error("auth_failed", "error", err, "status", http.StatusUnauthorized)
 			huma.WriteErr(api, ctx, http.StatusUnauthorized, "Invalid or missed auth token")
 			return
 		}
 
-		logger.Info("auth_granted")
+		logger.Info("auth_granted", "user_id", claims.UserID, "session_id", claims.SessionID)
+		ctx = huma.WithValue(ctx, common.UserIDKey, claims.UserID)
+		ctx = huma.WithValue(ctx, common.TokenIDKey, claims.TokenID)
+		ctx = huma.WithValue(ctx, common.SessionIDKey, claims.SessionID)
+
+		// Last-seen tracking must not fail the request
+		if err := as.TouchSession(ctx.Context()); err != nil {
+			logger.Warn("session_touch_failed", "error", err, "session_id", claims.SessionID)
+		}
 		next(ctx)
 	}
 }
+
+// requiredScopes collects the scopes an operation lists on its bearer security requirement
+func requiredScopes(op *huma.Operation) []string {
+	var scopes []string
+	for _, requirement := range op.Security {
+		scopes = append(scopes, requirement["bearer"]...)
+	}
+	return scopes
+}
+
+func hasScopes(granted []string, required []string) bool {
+	for _, scope := range required {
+		if !slices.Contains(granted, scope) {
+			return false
+		}
+	}
+	return true
+}
@@ -39,7 +39,10 @@ type AccountModel struct {
 	CreatedAt      time.Time       `json:"createdAt" doc:"Creation timestamp" format:"date-time"`
 	UpdatedAt      *time.Time      `json:"updatedAt,omitempty" doc:"Last update timestamp" format:"date-time"`
 	DeletedAt      *time.Time      `json:"deletedAt,omitempty" doc:"Soft delete timestamp" format:"date-time"`
+	Role           string          `json:"role" enum:"owner,editor,viewer" doc:"Access role of the current user on this account"`
+	SharedWith     []ShareEmbedded `json:"sharedWith" doc:"Users this account is shared with, empty when it is not shared"`
 	EmbeddedBudget *EmbeddedBudget `json:"budget,omitempty" doc:"Currently active budget for this account"`
+	ETag           string          `json:"etag,omitempty" doc:"Version of the record, changed on every update; send it as If-Match to only change or delete the record as it was read"`
 }
 
 type AccountsPagedModel struct {
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+type AccountReconciliationModel struct {
+	ID               int64      `json:"id" doc:"Unique identifier"`
+	AccountID        int64      `json:"accountId" doc:"Reconciled account"`
+	StatementDate    time.Time  `json:"statementDate" doc:"End date of the bank statement; transactions up to this time are matched" format:"date-time"`
+	StatementBalance int64      `json:"statementBalance" doc:"Closing balance on the bank statement"`
+	Status           string     `json:"status" enum:"open,completed" doc:"Open while matching transactions, completed once locked"`
+	ReconciledCount  int        `json:"reconciledCount" doc:"Number of transactions locked on completion"`
+	CreatedAt        time.Time  `json:"createdAt" doc:"Creation timestamp" format:"date-time"`
+	UpdatedAt        time.Time  `json:"updatedAt" doc:"Last update timestamp" format:"date-time"`
+	CompletedAt      *time.Time `json:"completedAt,omitempty" doc:"Completion timestamp" format:"date-time"`
+}
+
+// AccountReconciliationBalanceModel compares the account with the statement
+// The cleared balance is the account balance without pending transactions and without transactions after the statement date
+type AccountReconciliationBalanceModel struct {
+	AccountBalance  int64 `json:"accountBalance" doc:"Current account balance"`
+	ClearedBalance  int64 `json:"clearedBalance" doc:"Balance of cleared and reconciled transactions up to the statement date"`
+	Difference      int64 `json:"difference" doc:"Statement balance minus cleared balance; must be zero to complete"`
+	UnclearedAmount int64 `json:"unclearedAmount" doc:"Net amount of pending transactions up to the statement date"`
+	UnclearedCount  int   `json:"unclearedCount" doc:"Number of pending transactions up to the statement date"`
+	ClearedCount    int   `json:"clearedCount" doc:"Number of cleared transactions up to the statement date, locked on completion"`
+}
+
+type AccountReconciliationDetailModel struct {
+	AccountReconciliationModel
+	Balance *AccountReconciliationBalanceModel `json:"balance,omitempty" doc:"Comparison with the statement, only while open"`
+}
+
+type AccountReconciliationsSearchModel struct {
+	PageNumber int `query:"pageNumber" default:"1" minimum:"1" doc:"Page number for pagination"`
+	PageSize   int `query:"pageSize" default:"25" minimum:"1" maximum:"100" doc:"Number of items per page"`
+}
+
+type AccountReconciliationsPagedModel struct {
+	Items      []AccountReconciliationModel `json:"items" doc:"Reconciliations of the account, newest first"`
+	PageNumber int                          `json:"pageNumber" doc:"Current page number"`
+	PageSize   int                          `json:"pageSize" doc:"Items per page"`
+	TotalCount int                          `json:"totalCount" doc:"Total number of matching items"`
+	TotalPages int                          `json:"totalPages" doc:"Total number of pages"`
+}
+
+type CreateAccountReconciliationModel struct {
+	StatementDate    time.Time `json:"statementDate" required:"true" doc:"End date of the bank statement" format:"date-time"`
+	StatementBalance int64     `json:"statementBalance" required:"true" doc:"Closing balance on the bank statement"`
+}
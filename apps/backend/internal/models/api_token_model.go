@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+type APITokenModel struct {
+	ID         int64      `json:"id" doc:"Unique identifier"`
+	Name       string     `json:"name" doc:"Label to recognise the token by"`
+	Prefix     string     `json:"prefix" doc:"First characters of the token, for identification only"`
+	Scopes     []string   `json:"scopes" doc:"Granted scopes"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty" doc:"Last time the token authenticated a request" format:"date-time"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty" doc:"Expiry timestamp (null if the token never expires)" format:"date-time"`
+	CreatedAt  time.Time  `json:"createdAt" doc:"Creation timestamp" format:"date-time"`
+}
+
+type APITokensListModel struct {
+	Items []APITokenModel `json:"items" doc:"List of active personal access tokens"`
+}
+
+type CreateAPITokenModel struct {
+	Name      string     `json:"name" required:"true" minLength:"1" maxLength:"255" doc:"Label to recognise the token by"`
+	Scopes    []string   `json:"scopes" required:"true" minItems:"1" uniqueItems:"true" enum:"transactions:read,transactions:write,budgets:read,budgets:write,reports:read" doc:"Scopes granted to the token"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" doc:"Optional expiry timestamp" format:"date-time"`
+}
+
+type CreateAPITokenResponseModel struct {
+	APITokenModel
+	Token string `json:"token" doc:"Plain token value, only returned once. Send it as 'Authorization: Bearer <token>'"`
+}
+
+// APITokenAuthModel is the internal result of authenticating a personal access token
+type APITokenAuthModel struct {
+	ID     int64
+	UserID int64
+	Scopes []string
+}
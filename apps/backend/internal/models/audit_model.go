@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+type AuditLogModel struct {
+	ID            int64     `json:"id" doc:"Unique identifier"`
+	ActorID       *int64    `json:"actorId" doc:"ID of the user who made the change"`
+	ActorUsername *string   `json:"actorUsername" doc:"Username of the user who made the change"`
+	Source        string    `json:"source" enum:"api,bulk_commit,budget_template_worker,transaction_template_worker,transaction_rules" doc:"Where the change originated from"`
+	EntityType    string    `json:"entityType" doc:"Type of the changed entity"`
+	EntityID      int64     `json:"entityId" doc:"ID of the changed entity. Transaction tag changes are recorded against the transaction ID"`
+	Operation     string    `json:"operation" enum:"create,update,delete,restore" doc:"Kind of change"`
+	Before        any       `json:"before" doc:"Snapshot of the entity before the change, null on create"`
+	After         any       `json:"after" doc:"Snapshot of the entity after the change, null on delete"`
+	CreatedAt     time.Time `json:"createdAt" doc:"When the change was made" format:"date-time"`
+}
+
+type AuditLogsSearchModel struct {
+	PageNumber int      `query:"pageNumber" default:"1" minimum:"1" doc:"Page number for pagination"`
+	PageSize   int      `query:"pageSize" default:"25" minimum:"1" maximum:"100" doc:"Number of items per page"`
+	SortOrder  string   `query:"sortOrder" default:"desc" enum:"asc,desc" doc:"Sort order by creation time"`
+	EntityType []string `query:"entityType" enum:"account,category,tag,payee,transaction,transaction_tag,transaction_relation,transaction_attachment,transaction_template,transaction_rule,reimbursement,budget_template,budget,share,account_reconciliation" doc:"Filter by entity types"`
+	EntityID   int64    `query:"entityId" minimum:"1" doc:"Filter by entity ID"`
+	ActorID    int64    `query:"actorId" minimum:"1" doc:"Filter by the user who made the change"`
+	Operation  []string `query:"operation" enum:"create,update,delete,restore" doc:"Filter by operations"`
+	StartDate  string   `query:"startDate" doc:"Only include changes made at or after this time" format:"date-time"`
+	EndDate    string   `query:"endDate" doc:"Only include changes made at or before this time" format:"date-time"`
+}
+
+type AuditLogsPagedModel struct {
+	Items      []AuditLogModel `json:"items" doc:"List of audit log entries"`
+	PageNumber int             `json:"pageNumber" doc:"Current page number"`
+	PageSize   int             `json:"pageSize" doc:"Items per page"`
+	TotalCount int             `json:"totalCount" doc:"Total number of matching items"`
+	TotalPages int             `json:"totalPages" doc:"Total number of pages"`
+}
+
+// CreateAuditLogModel is an entry appended by services; the actor and source come from the context
+type CreateAuditLogModel struct {
+	EntityType string
+	EntityID   int64
+	Operation  string
+	Before     any
+	After      any
+}
@@ -1,13 +1,41 @@
 package models
 
+import "time"
+
 type LoginRequestModel struct {
-	Username string `json:"username" minLength:"1" required:"true" doc:"Admin username"`
-	Password string `json:"password" minLength:"1" required:"true" doc:"Admin password"`
+	Username   string `json:"username" minLength:"1" required:"true" doc:"Admin username"`
+	Password   string `json:"password" minLength:"1" required:"true" doc:"Admin password"`
+	DeviceName string `json:"device_name,omitempty" maxLength:"100" doc:"Name of this device in the session list, e.g. Work laptop"`
 }
 
 type LoginResponseModel struct {
-	AccessToken  string `json:"access_token" doc:"Access token valid for 7 days"`
-	RefreshToken string `json:"refresh_token" doc:"Refresh token valid for 30 days"`
+	AccessToken       string `json:"access_token,omitempty" doc:"Access token valid for 7 days"`
+	RefreshToken      string `json:"refresh_token,omitempty" doc:"Refresh token valid for 30 days"`
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty" doc:"True when a TOTP or recovery code must be sent to /auth/login/verify"`
+	ChallengeToken    string `json:"challenge_token,omitempty" doc:"Challenge token valid for 5 minutes, exchanged for a token pair at /auth/login/verify"`
+}
+
+type LoginVerifyRequestModel struct {
+	ChallengeToken string `json:"challenge_token" minLength:"1" required:"true" doc:"Challenge token returned by /auth/login"`
+	Code           string `json:"code,omitempty" pattern:"^[0-9]{6}$" doc:"Current code from the authenticator app"`
+	RecoveryCode   string `json:"recovery_code,omitempty" doc:"Single-use recovery code, used instead of code"`
+	DeviceName     string `json:"device_name,omitempty" maxLength:"100" doc:"Name of this device in the session list, e.g. Work laptop"`
+}
+
+type OIDCAuthorizeResponseModel struct {
+	AuthorizationURL string `json:"authorization_url" doc:"Identity provider URL to send the browser to"`
+	State            string `json:"state" doc:"Opaque value the identity provider echoes back to the redirect URL, valid for 10 minutes"`
+}
+
+type OIDCCallbackRequestModel struct {
+	Code       string `json:"code" minLength:"1" required:"true" doc:"Authorization code from the redirect URL"`
+	State      string `json:"state" minLength:"1" required:"true" doc:"State from the redirect URL"`
+	DeviceName string `json:"device_name,omitempty" maxLength:"100" doc:"Name of this device in the session list, e.g. Work laptop"`
+}
+
+type ChangePasswordRequestModel struct {
+	CurrentPassword string `json:"current_password" minLength:"1" required:"true" doc:"Current password"`
+	NewPassword     string `json:"new_password" minLength:"8" maxLength:"72" required:"true" doc:"New password"`
 }
 
 type RefreshRequestModel struct {
@@ -15,5 +43,34 @@ type RefreshRequestModel struct {
 }
 
 type RefreshResponseModel struct {
-	AccessToken string `json:"access_token" doc:"New access token valid for 7 days"`
+	AccessToken  string `json:"access_token" doc:"New access token valid for 7 days"`
+	RefreshToken string `json:"refresh_token" doc:"New refresh token valid for 30 days, the submitted one is no longer usable"`
+}
+
+type TwoFactorEnrollResponseModel struct {
+	Secret          string `json:"secret" doc:"Base32 TOTP secret for manual entry"`
+	ProvisioningURI string `json:"provisioningUri" doc:"otpauth:// URI to render as a QR code"`
+}
+
+type TwoFactorCodeRequestModel struct {
+	Code         string `json:"code,omitempty" pattern:"^[0-9]{6}$" doc:"Current code from the authenticator app"`
+	RecoveryCode string `json:"recovery_code,omitempty" doc:"Single-use recovery code, used instead of code"`
+}
+
+type TwoFactorRecoveryCodesModel struct {
+	RecoveryCodes []string `json:"recoveryCodes" doc:"Single-use recovery codes, only shown once"`
+}
+
+type LogoutRequestModel struct {
+	RefreshToken string `json:"refresh_token,omitempty" doc:"Refresh token to revoke along with the current access token"`
+}
+
+// AuthTokenClaimsModel is the validated content of a signed token
+type AuthTokenClaimsModel struct {
+	TokenID   string
+	Type      string
+	UserID    int64
+	SessionID int64 // Zero for challenge tokens and tokens issued before sessions were recorded
+	IssuedAt  time.Time
+	ExpiresAt time.Time
 }
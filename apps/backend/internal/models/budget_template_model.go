@@ -4,6 +4,7 @@ import "time"
 
 type BudgetTemplateModel struct {
 	ID             int64      `json:"id" doc:"Unique identifier"`
+	UserID         int64      `json:"-"`
 	AccountID      *int64     `json:"accountId,omitempty" doc:"Account ID to filter transactions"`
 	CategoryID     *int64     `json:"categoryId,omitempty" doc:"Category ID to filter transactions"`
 	AmountLimit    int64      `json:"amountLimit" doc:"Budget limit amount in cents" minimum:"1"`
@@ -18,6 +19,7 @@ type BudgetTemplateModel struct {
 	CreatedAt      time.Time  `json:"createdAt" doc:"Creation timestamp" format:"date-time"`
 	UpdatedAt      *time.Time `json:"updatedAt,omitempty" doc:"Last update timestamp" format:"date-time"`
 	DeletedAt      *time.Time `json:"deletedAt,omitempty" doc:"Soft delete timestamp" format:"date-time"`
+	ETag           string     `json:"etag,omitempty" doc:"Version of the record, changed on every update; send it as If-Match to only change or delete the record as it was read"`
 }
 
 type BudgetTemplatesSearchModel struct {
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+type PayeeModel struct {
+	ID        int64      `json:"id" doc:"Unique identifier"`
+	Name      string     `json:"name" doc:"Payee name"`
+	Aliases   []string   `json:"aliases" doc:"Other names of the payee, such as the text printed on bank statements"`
+	CreatedAt time.Time  `json:"createdAt" doc:"Creation timestamp" format:"date-time"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty" doc:"Last update timestamp" format:"date-time"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty" doc:"Soft delete timestamp" format:"date-time"`
+}
+
+type PayeesSearchModel struct {
+	PageNumber int    `query:"pageNumber" default:"1" minimum:"1" doc:"Page number for pagination"`
+	PageSize   int    `query:"pageSize" default:"25" minimum:"1" maximum:"100" doc:"Number of items per page"`
+	SortBy     string `query:"sortBy" default:"name" enum:"id,name,createdAt,updatedAt" doc:"Field to sort by"`
+	SortOrder  string `query:"sortOrder" default:"asc" enum:"asc,desc" doc:"Sort order"`
+	Name       string `query:"name" doc:"Search by payee name or alias"`
+}
+
+type PayeesPagedModel struct {
+	Items      []PayeeModel `json:"items" doc:"List of payees"`
+	PageNumber int          `json:"pageNumber" doc:"Current page number"`
+	PageSize   int          `json:"pageSize" doc:"Items per page"`
+	TotalCount int          `json:"totalCount" doc:"Total number of matching items"`
+	TotalPages int          `json:"totalPages" doc:"Total number of pages"`
+}
+
+type CreatePayeeModel struct {
+	Name    string   `json:"name" required:"true" minLength:"1" maxLength:"100" doc:"Payee name"`
+	Aliases []string `json:"aliases,omitempty" maxItems:"50" doc:"Other names of the payee"`
+}
+
+type UpdatePayeeModel struct {
+	Name    *string  `json:"name,omitempty" minLength:"1" maxLength:"100" doc:"Payee name"`
+	Aliases []string `json:"aliases,omitempty" maxItems:"50" doc:"Other names of the payee; replaces the current list when provided"`
+}
+
+type MergePayeesModel struct {
+	SourceIDs []int64 `json:"sourceIds" required:"true" minItems:"1" maxItems:"50" doc:"Duplicate payees to merge into this one; their transactions and templates move over, their names become aliases and they are deleted"`
+}
+
+type PayeeMergeResultModel struct {
+	Payee             PayeeModel `json:"payee" doc:"The payee that was kept"`
+	MergedCount       int        `json:"mergedCount" doc:"Number of payees merged into it"`
+	TransactionsMoved int64      `json:"transactionsMoved" doc:"Number of transactions moved to it"`
+	TemplatesMoved    int64      `json:"templatesMoved" doc:"Number of transaction templates moved to it"`
+}
@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+type ReimbursementModel struct {
+	ID                int64                     `json:"id" doc:"Unique identifier"`
+	TransactionID     int64                     `json:"transactionId" doc:"Expense transaction expected to be paid back"`
+	TransactionDate   time.Time                 `json:"transactionDate" doc:"Date of the expense" format:"date-time"`
+	TransactionAmount int64                     `json:"transactionAmount" doc:"Amount of the expense in base currency"`
+	TransactionNote   *string                   `json:"transactionNote,omitempty" doc:"Notes of the expense"`
+	Counterparty      string                    `json:"counterparty" doc:"Who is expected to pay the expense back"`
+	ExpectedAmount    int64                     `json:"expectedAmount" doc:"Amount expected to be paid back in base currency"`
+	MatchedAmount     int64                     `json:"matchedAmount" doc:"Amount paid back so far by matched income"`
+	OutstandingAmount int64                     `json:"outstandingAmount" doc:"Amount still owed"`
+	Status            string                    `json:"status" enum:"open,partial,settled" doc:"open when nothing is paid back yet, partial when some is, settled when nothing is owed anymore"`
+	Note              *string                   `json:"note,omitempty" doc:"Reimbursement notes"`
+	Matches           []ReimbursementMatchModel `json:"matches,omitempty" doc:"Income transactions that paid the expense back; only included when getting a single reimbursement"`
+	CreatedAt         time.Time                 `json:"createdAt" doc:"Creation timestamp" format:"date-time"`
+	UpdatedAt         *time.Time                `json:"updatedAt,omitempty" doc:"Last update timestamp" format:"date-time"`
+}
+
+type ReimbursementMatchModel struct {
+	ID              int64     `json:"id" doc:"Unique identifier"`
+	TransactionID   int64     `json:"transactionId" doc:"Income transaction that paid the expense back"`
+	TransactionDate time.Time `json:"transactionDate" doc:"Date of the income" format:"date-time"`
+	Amount          int64     `json:"amount" doc:"Part of the income counted towards this reimbursement"`
+	CreatedAt       time.Time `json:"createdAt" doc:"Creation timestamp" format:"date-time"`
+}
+
+type ReimbursementsSearchModel struct {
+	PageNumber   int    `query:"pageNumber" default:"1" minimum:"1" doc:"Page number for pagination"`
+	PageSize     int    `query:"pageSize" default:"25" minimum:"1" maximum:"100" doc:"Number of items per page"`
+	SortBy       string `query:"sortBy" default:"transactionDate" enum:"id,transactionDate,expectedAmount,outstandingAmount,createdAt" doc:"Field to sort by"`
+	SortOrder    string `query:"sortOrder" default:"asc" enum:"asc,desc" doc:"Sort order"`
+	Status       string `query:"status" default:"outstanding" enum:"outstanding,settled,all" doc:"outstanding lists what is still owed, settled what is fully paid back"`
+	Counterparty string `query:"counterparty" doc:"Search by counterparty"`
+}
+
+type ReimbursementsPagedModel struct {
+	Items            []ReimbursementModel `json:"items" doc:"List of reimbursements"`
+	PageNumber       int                  `json:"pageNumber" doc:"Current page number"`
+	PageSize         int                  `json:"pageSize" doc:"Items per page"`
+	TotalCount       int                  `json:"totalCount" doc:"Total number of matching items"`
+	TotalPages       int                  `json:"totalPages" doc:"Total number of pages"`
+	TotalOutstanding int64                `json:"totalOutstanding" doc:"Amount still owed across all matching items"`
+}
+
+type CreateReimbursementModel struct {
+	TransactionID  int64   `json:"transactionId" required:"true" minimum:"1" doc:"Expense transaction expected to be paid back"`
+	ExpectedAmount *int64  `json:"expectedAmount,omitempty" minimum:"1" doc:"Amount expected to be paid back in base currency; defaults to the whole expense"`
+	Counterparty   string  `json:"counterparty" required:"true" minLength:"1" maxLength:"100" doc:"Who is expected to pay the expense back"`
+	Note           *string `json:"note,omitempty" doc:"Reimbursement notes"`
+}
+
+type UpdateReimbursementModel struct {
+	ExpectedAmount *int64  `json:"expectedAmount,omitempty" minimum:"1" doc:"Amount expected to be paid back in base currency"`
+	Counterparty   *string `json:"counterparty,omitempty" minLength:"1" maxLength:"100" doc:"Who is expected to pay the expense back"`
+	Note           *string `json:"note,omitempty" doc:"Reimbursement notes"`
+}
+
+type CreateReimbursementMatchModel struct {
+	TransactionID int64  `json:"transactionId" required:"true" minimum:"1" doc:"Income transaction that paid the expense back"`
+	Amount        *int64 `json:"amount,omitempty" minimum:"1" doc:"Part of the income counted towards this reimbursement; defaults to as much as both the reimbursement and the income have left"`
+}
+
+// ReimbursementTransactionModel is a transaction with the part of it that already pays back reimbursements
+type ReimbursementTransactionModel struct {
+	Type          string
+	Amount        int64
+	MatchedAmount int64
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+type SessionModel struct {
+	ID         int64     `json:"id" doc:"Unique identifier"`
+	DeviceName *string   `json:"deviceName" doc:"Device name given at login"`
+	UserAgent  *string   `json:"userAgent" doc:"User agent of the client that logged in"`
+	IPAddress  *string   `json:"ipAddress" doc:"IP address the session was last used from"`
+	Current    bool      `json:"current" doc:"True for the session making this request"`
+	CreatedAt  time.Time `json:"createdAt" doc:"Login time" format:"date-time"`
+	LastSeenAt time.Time `json:"lastSeenAt" doc:"Last time the session was used, updated at most once a minute" format:"date-time"`
+	ExpiresAt  time.Time `json:"expiresAt" doc:"When the session ends unless its refresh token is used" format:"date-time"`
+}
+
+type SessionsListModel struct {
+	Items []SessionModel `json:"items" doc:"Active sessions, most recently used first"`
+}
+
+// CreateSessionModel describes the device a login starts a session for
+type CreateSessionModel struct {
+	DeviceName string
+	UserAgent  string
+	IPAddress  string
+	ExpiresAt  time.Time
+}
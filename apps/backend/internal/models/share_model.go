@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+type ShareModel struct {
+	UserID    int64     `json:"userId" doc:"User the resource is shared with"`
+	Username  string    `json:"username" doc:"Username of the user the resource is shared with"`
+	Role      string    `json:"role" enum:"viewer,editor" doc:"Granted role"`
+	CreatedAt time.Time `json:"createdAt" doc:"Time the resource was first shared with the user" format:"date-time"`
+}
+
+type SharesListModel struct {
+	Items []ShareModel `json:"items" doc:"Users the resource is shared with"`
+}
+
+type UpsertShareModel struct {
+	Username string `json:"username" required:"true" minLength:"1" doc:"Username to share with"`
+	Role     string `json:"role" required:"true" enum:"viewer,editor" doc:"Role to grant, replaces the current role when already shared"`
+}
+
+// ShareEmbedded is the shared-with indicator embedded in shareable resources
+type ShareEmbedded struct {
+	UserID   int64  `json:"userId" doc:"User the resource is shared with"`
+	Username string `json:"username" doc:"Username of the user the resource is shared with"`
+	Role     string `json:"role" enum:"viewer,editor" doc:"Granted role"`
+}
+
+// ShareAccessModel is the internal result of resolving the current user's access to a shareable resource
+type ShareAccessModel struct {
+	OwnerID int64
+	Role    string
+}
@@ -5,6 +5,8 @@ import "time"
 type SummarySearchModel struct {
 	StartDate time.Time `query:"startDate" required:"true" doc:"Start date for filtering (ISO 8601 format)" example:"2024-01-01T00:00:00Z" format:"date-time"`
 	EndDate   time.Time `query:"endDate" required:"true" doc:"End date for filtering (ISO 8601 format)" example:"2024-12-31T23:59:59Z" format:"date-time"`
+
+	ExcludeReimbursed bool `query:"excludeReimbursed" default:"false" doc:"Leave out money paid back through reimbursements: expenses only count the part not paid back, and income that paid them back does not count"`
 }
 
 type SummaryTransactionSearchModel struct {
@@ -57,6 +59,19 @@ type SummaryCategoryListModel struct {
 	Data []SummaryCategoryModel `json:"data" doc:"Summary data grouped by category"`
 }
 
+type SummaryPayeeModel struct {
+	ID            int64  `json:"id" doc:"Payee ID" example:"1"`
+	Name          string `json:"name" doc:"Payee name" example:"Corner Coffee"`
+	TotalCount    int    `json:"totalCount" doc:"Total number of transactions" example:"42"`
+	IncomeAmount  int64  `json:"incomeAmount" doc:"Total income amount" example:"0"`
+	ExpenseAmount int64  `json:"expenseAmount" doc:"Total expense amount" example:"1260000"`
+	Net           int64  `json:"net" doc:"Net amount (income - expense)" example:"-1260000"`
+}
+
+type SummaryPayeeListModel struct {
+	Data []SummaryPayeeModel `json:"data" doc:"Summary data grouped by payee"`
+}
+
 type SummaryGeospatialSearchModel struct {
 	SummarySearchModel
 	Latitude      float64 `query:"latitude" required:"true" minimum:"-90" maximum:"90" doc:"Center latitude for geographic search" example:"-6.175"`
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+type TransactionAttachmentModel struct {
+	ID             int64     `json:"id" doc:"Unique identifier"`
+	TransactionID  int64     `json:"transactionId" doc:"Transaction the file is attached to"`
+	FileName       string    `json:"fileName" doc:"Original file name"`
+	ContentType    string    `json:"contentType" doc:"File type detected from its content"`
+	SizeBytes      int64     `json:"sizeBytes" doc:"File size in bytes"`
+	ChecksumSHA256 string    `json:"checksumSha256" doc:"Hex encoded SHA-256 of the file"`
+	HasThumbnail   bool      `json:"hasThumbnail" doc:"Whether a thumbnail is available, only for JPEG, PNG and GIF images"`
+	CreatedAt      time.Time `json:"createdAt" doc:"Upload timestamp"`
+	StorageKey     string    `json:"-"`
+	ThumbnailKey   *string   `json:"-"`
+}
+
+type TransactionAttachmentsListModel struct {
+	Items []TransactionAttachmentModel `json:"items" doc:"Attachments of the transaction, oldest first"`
+}
+
+// CreateTransactionAttachmentModel is the metadata of a file already written to the attachment storage
+type CreateTransactionAttachmentModel struct {
+	TransactionID  int64
+	FileName       string
+	ContentType    string
+	SizeBytes      int64
+	ChecksumSHA256 string
+	StorageKey     string
+	ThumbnailKey   *string
+}
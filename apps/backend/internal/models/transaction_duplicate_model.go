@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// TransactionDuplicateEntryModel is the short form of a transaction shown when reviewing duplicates
+type TransactionDuplicateEntryModel struct {
+	ID        int64     `json:"id" doc:"Transaction ID"`
+	Type      string    `json:"type" enum:"expense,income,transfer" doc:"Transaction type"`
+	Date      time.Time `json:"date" doc:"Transaction date" format:"date-time"`
+	Amount    int64     `json:"amount" doc:"Transaction amount in base currency"`
+	AccountID int64     `json:"accountId" doc:"Source account ID"`
+	Note      *string   `json:"note,omitempty" doc:"Transaction notes"`
+	CreatedAt time.Time `json:"createdAt" doc:"When the transaction was recorded" format:"date-time"`
+}
+
+type TransactionDuplicateCandidateModel struct {
+	Transaction TransactionDuplicateEntryModel `json:"transaction" doc:"Existing transaction that may be the same one"`
+	Score       int                            `json:"score" minimum:"0" maximum:"100" doc:"How likely the two are the same transaction, from 0 to 100"`
+	Reasons     []string                       `json:"reasons" doc:"What the two have in common: same_account_amount_type, same_day, within_days, same_note, similar_note, recorded_together"`
+}
+
+// TransactionCreatedModel is the created transaction with the existing ones it may duplicate
+type TransactionCreatedModel struct {
+	TransactionModel
+	PossibleDuplicates []TransactionDuplicateCandidateModel `json:"possibleDuplicates,omitempty" doc:"Warning: existing transactions this one may duplicate, most likely first. The transaction is recorded either way"`
+}
+
+type TransactionDuplicatesSearchModel struct {
+	PageNumber int    `query:"pageNumber" default:"1" minimum:"1" doc:"Page number for pagination"`
+	PageSize   int    `query:"pageSize" default:"25" minimum:"1" maximum:"100" doc:"Number of items per page"`
+	StartDate  string `query:"startDate" doc:"Only include pairs with a transaction dated at or after this time; defaults to 90 days before the end date" format:"date-time"`
+	EndDate    string `query:"endDate" doc:"Only include pairs with a transaction dated at or before this time; defaults to now" format:"date-time"`
+	AccountIDs []int  `query:"accountId" doc:"Filter by account IDs"`
+	MinScore   int    `query:"minScore" default:"60" minimum:"0" maximum:"100" doc:"Leave out pairs scoring lower"`
+}
+
+type TransactionDuplicatePairModel struct {
+	Transaction TransactionDuplicateEntryModel `json:"transaction" doc:"Transaction recorded first"`
+	Duplicate   TransactionDuplicateEntryModel `json:"duplicate" doc:"Transaction recorded later that may duplicate it"`
+	Score       int                            `json:"score" minimum:"0" maximum:"100" doc:"How likely the two are the same transaction, from 0 to 100"`
+	Reasons     []string                       `json:"reasons" doc:"What the two have in common"`
+}
+
+type TransactionDuplicatesPagedModel struct {
+	Items      []TransactionDuplicatePairModel `json:"items" doc:"Possible duplicate pairs, most likely first"`
+	PageNumber int                             `json:"pageNumber" doc:"Current page number"`
+	PageSize   int                             `json:"pageSize" doc:"Items per page"`
+	TotalCount int                             `json:"totalCount" doc:"Total number of matching pairs"`
+	TotalPages int                             `json:"totalPages" doc:"Total number of pages"`
+}
+
+type MergeTransactionDuplicatesModel struct {
+	DuplicateIDs []int64 `json:"duplicateIds" required:"true" minItems:"1" maxItems:"20" doc:"Transactions to fold into this one and delete"`
+}
+
+type TransactionMergeResultModel struct {
+	Transaction      TransactionModel `json:"transaction" doc:"Kept transaction after the merge"`
+	MergedCount      int              `json:"mergedCount" doc:"Number of duplicates deleted"`
+	TagsMoved        int64            `json:"tagsMoved" doc:"Number of tags added to the kept transaction"`
+	RelationsMoved   int64            `json:"relationsMoved" doc:"Number of relations moved to the kept transaction"`
+	AttachmentsMoved int64            `json:"attachmentsMoved" doc:"Number of attachments moved to the kept transaction"`
+}
@@ -5,15 +5,21 @@ import "time"
 type TransactionsSearchModel struct {
 	PageNumber            int      `query:"pageNumber" default:"1" minimum:"1" doc:"Page number for pagination"`
 	PageSize              int      `query:"pageSize" default:"25" minimum:"1" maximum:"100" doc:"Number of items per page"`
-	SortBy                string   `query:"sortBy" default:"date" enum:"id,type,date,amount,createdAt,updatedAt" doc:"Field to sort by"`
+	SortBy                string   `query:"sortBy" default:"date" enum:"id,type,date,amount,createdAt,updatedAt,relevance" doc:"Field to sort by; relevance ranks full-text matches of q and falls back to date"`
 	SortOrder             string   `query:"sortOrder" default:"desc" enum:"asc,desc" doc:"Sort order (asc or desc)"`
+	After                 string   `query:"after" maxLength:"100" doc:"Cursor from nextCursor; returns the page after it instead of pageNumber. Requires sortBy=date"`
+	Before                string   `query:"before" maxLength:"100" doc:"Cursor from prevCursor; returns the page before it instead of pageNumber. Requires sortBy=date"`
+	IncludeTotal          bool     `query:"includeTotal" default:"true" doc:"Count all matching transactions; false skips the count on large lists and leaves totalCount and totalPages at 0"`
+	Q                     string   `query:"q" maxLength:"200" doc:"Full-text search over the note, category, account and tag names; every word matches as a prefix"`
 	IDs                   []int    `query:"id" doc:"Filter by transaction IDs"`
 	Type                  []string `query:"type" enum:"expense,income,transfer" doc:"Filter by transaction type"`
+	Status                []string `query:"status" enum:"pending,cleared,reconciled" doc:"Filter by bank status"`
 	AccountIDs            []int    `query:"accountId" doc:"Filter by account IDs (source or destination)"`
 	CategoryIDs           []int    `query:"categoryId" doc:"Filter by category IDs"`
 	DestinationAccountIDs []int    `query:"destinationAccountId" doc:"Filter by destination account IDs (transfers)"`
 	TemplateIDs           []int    `query:"templateId" doc:"Filter by transaction template IDs"`
 	TagIDs                []int    `query:"tagId" doc:"Filter by tag IDs"`
+	PayeeIDs              []int    `query:"payeeId" doc:"Filter by payee IDs"`
 	CurrencyCodes         []string `query:"currencyCode" doc:"Filter by currency codes (e.g., USD, EUR)"`
 	StartDate             string   `query:"startDate" doc:"Filter by start date (YYYY-MM-DD)" format:"date-time"`
 	EndDate               string   `query:"endDate" doc:"Filter by end date (YYYY-MM-DD)" format:"date-time"`
@@ -46,6 +52,11 @@ type TransactionTagEmbedded struct {
 	Name string `json:"name" doc:"Tag name"`
 }
 
+type TransactionPayeeEmbedded struct {
+	ID   int64  `json:"id" doc:"Payee ID"`
+	Name string `json:"name" doc:"Payee name"`
+}
+
 type TransactionTemplateEmbedded struct {
 	ID         int64      `json:"id" doc:"Template ID"`
 	Name       string     `json:"name" doc:"Template name"`
@@ -55,11 +66,18 @@ type TransactionTemplateEmbedded struct {
 	EndDate    *time.Time `json:"endDate" doc:"Template end date" format:"date-time"`
 }
 
+type TransactionFeeEmbedded struct {
+	ID         int64 `json:"id" doc:"Fee expense transaction ID"`
+	Amount     int64 `json:"amount" doc:"Fee amount in base currency"`
+	CategoryID int64 `json:"categoryId" doc:"Expense category of the fee"`
+}
+
 type TransactionModel struct {
 	ID                 int64                        `json:"id" doc:"Unique identifier"`
 	Type               string                       `json:"type" minLength:"1" enum:"expense,income,transfer" doc:"Transaction type"`
 	Date               time.Time                    `json:"date" doc:"Transaction date" format:"date-time"`
 	Amount             int64                        `json:"amount" doc:"Transaction amount in base currency (IDR)"`
+	Status             string                       `json:"status" enum:"pending,cleared,reconciled" doc:"Bank status: pending until the bank posts it, cleared once posted, reconciled once matched to a statement. Reconciled transactions cannot be changed or deleted"`
 	AmountForeign      *int64                       `json:"amountForeign,omitempty" doc:"Foreign currency amount (as input by user). Null if transaction is in base currency."`
 	CurrencyCode       *string                      `json:"currencyCode,omitempty" doc:"ISO 4217 currency code for foreign amount (e.g., USD, EUR). Null if transaction is in base currency."`
 	ExchangeRate       *float64                     `json:"exchangeRate,omitempty" doc:"Exchange rate applied: foreign_currency → base_currency (IDR). e.g., 16500.5 for USD→IDR. Null if no conversion."`
@@ -67,14 +85,19 @@ type TransactionModel struct {
 	Account            TransactionAccountEmbedded   `json:"account" doc:"Source account details"`
 	Category           TransactionCategoryEmbedded  `json:"category" doc:"Category details"`
 	DestinationAccount *TransactionAccountEmbedded  `json:"destinationAccount,omitempty" doc:"Destination account (transfers only)"`
+	DestinationAmount  *int64                       `json:"destinationAmount,omitempty" doc:"Amount credited to the destination account in base currency, when it differs from amount (transfers only)"`
+	Fee                *TransactionFeeEmbedded      `json:"fee,omitempty" doc:"Fee taken for the transfer, recorded as a linked expense on the source account (transfers only)"`
+	Payee              *TransactionPayeeEmbedded    `json:"payee,omitempty" doc:"Merchant or person on the other side of the transaction"`
 	Latitude           *float64                     `json:"latitude,omitempty" doc:"Transaction latitude" minimum:"-90" maximum:"90"`
 	Longitude          *float64                     `json:"longitude,omitempty" doc:"Transaction longitude" minimum:"-180" maximum:"180"`
 	Tags               []TransactionTagEmbedded     `json:"tags" doc:"Transaction tags"`
+	Splits             []TransactionSplitModel      `json:"splits,omitempty" doc:"Split lines dividing the amount between categories, omitted when the transaction is not split"`
 	Template           *TransactionTemplateEmbedded `json:"template" doc:"Associated transaction template details"`
 	Note               *string                      `json:"note,omitempty" doc:"Transaction notes"`
 	CreatedAt          time.Time                    `json:"createdAt" doc:"Creation timestamp" format:"date-time"`
 	UpdatedAt          *time.Time                   `json:"updatedAt,omitempty" doc:"Last update timestamp" format:"date-time"`
 	DeletedAt          *time.Time                   `json:"deletedAt,omitempty" doc:"Soft delete timestamp" format:"date-time"`
+	ETag               string                       `json:"etag,omitempty" doc:"Version of the record, changed on every update; send it as If-Match to only change or delete the record as it was read"`
 }
 
 type TransactionsPagedModel struct {
@@ -83,6 +106,8 @@ type TransactionsPagedModel struct {
 	PageSize   int                `json:"pageSize" doc:"Items per page"`
 	TotalCount int                `json:"totalCount" doc:"Total number of matching items"`
 	TotalPages int                `json:"totalPages" doc:"Total number of pages"`
+	NextCursor *string            `json:"nextCursor,omitempty" doc:"Pass as after to get the next page; omitted on the last page and when not sorted by date"`
+	PrevCursor *string            `json:"prevCursor,omitempty" doc:"Pass as before to get the previous page; omitted on the first page and when not sorted by date"`
 }
 
 type CreateTransactionModel struct {
@@ -93,9 +118,15 @@ type CreateTransactionModel struct {
 	AccountID            int64     `json:"accountId" required:"true" minimum:"1" doc:"Source account ID"`
 	CategoryID           int64     `json:"categoryId" required:"true" minimum:"1" doc:"Category ID"`
 	DestinationAccountID *int64    `json:"destinationAccountId,omitempty" doc:"Destination account ID (transfers only)"`
+	DestinationAmount    *int64    `json:"destinationAmount,omitempty" minimum:"1" doc:"Amount credited to the destination account in base currency, e.g. after a currency conversion; defaults to the converted amount (transfers only)"`
+	FeeAmount            *int64    `json:"feeAmount,omitempty" minimum:"1" doc:"Fee taken for the transfer in base currency, recorded as an expense on the source account linked with fee_of (transfers only)"`
+	FeeCategoryID        *int64    `json:"feeCategoryId,omitempty" minimum:"1" doc:"Expense category of the fee; required with feeAmount"`
+	PayeeID              *int64    `json:"payeeId,omitempty" minimum:"1" doc:"Payee ID"`
 	Latitude             *float64  `json:"latitude,omitempty" doc:"Transaction latitude" minimum:"-90" maximum:"90"`
 	Longitude            *float64  `json:"longitude,omitempty" doc:"Transaction longitude" minimum:"-180" maximum:"180"`
 	Note                 *string   `json:"note,omitempty" doc:"Optional transaction notes"`
+	Status               *string   `json:"status,omitempty" enum:"pending,cleared" doc:"Bank status, defaults to pending"`
+	SkipRules            bool      `json:"skipRules,omitempty" doc:"Record the transaction as given, without applying transaction rules"`
 }
 
 type UpdateTransactionModel struct {
@@ -106,9 +137,14 @@ type UpdateTransactionModel struct {
 	AccountID            *int64     `json:"accountId,omitempty" minimum:"1" doc:"Source account ID"`
 	CategoryID           *int64     `json:"categoryId,omitempty" minimum:"1" doc:"Category ID"`
 	DestinationAccountID *int64     `json:"destinationAccountId,omitempty" doc:"Destination account ID (transfers only)"`
+	DestinationAmount    *int64     `json:"destinationAmount,omitempty" minimum:"0" doc:"Amount credited to the destination account in base currency; 0 credits the amount again (transfers only)"`
+	FeeAmount            *int64     `json:"feeAmount,omitempty" minimum:"0" doc:"Fee taken for the transfer in base currency; 0 removes the fee (transfers only)"`
+	FeeCategoryID        *int64     `json:"feeCategoryId,omitempty" minimum:"1" doc:"Expense category of the fee; required when adding a fee"`
+	PayeeID              *int64     `json:"payeeId,omitempty" minimum:"0" doc:"Payee ID; 0 removes the payee"`
 	Latitude             *float64   `json:"latitude,omitempty" doc:"Transaction latitude" minimum:"-90" maximum:"90"`
 	Longitude            *float64   `json:"longitude,omitempty" doc:"Transaction longitude" minimum:"-180" maximum:"180"`
 	Note                 *string    `json:"note,omitempty" doc:"Transaction notes"`
+	Status               *string    `json:"status,omitempty" enum:"pending,cleared" doc:"Bank status; reconciled is only set by completing a reconciliation"`
 }
 
 // Request model for saving draft (no draftId needed - one draft per user)
@@ -118,7 +154,8 @@ type BulkTransactionDraftModel struct {
 
 // Individual transaction update in bulk request
 type BulkTransactionUpdateItemModel struct {
-	ID int64 `json:"id" minimum:"1" doc:"Transaction ID to update"`
+	ID        int64 `json:"id" minimum:"1" doc:"Transaction ID to update"`
+	SkipRules bool  `json:"skipRules,omitempty" doc:"Apply the update as given, without filling the fields it leaves out from transaction rules"`
 	UpdateTransactionModel
 }
 
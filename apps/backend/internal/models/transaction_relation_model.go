@@ -6,7 +6,7 @@ type TransactionRelationModel struct {
 	ID                   int64      `json:"id" doc:"Unique identifier"`
 	SourceTransactionID  int64      `json:"sourceTransactionId" doc:"ID of the source transaction"`
 	RelatedTransactionID int64      `json:"relatedTransactionId" doc:"ID of the related transaction"`
-	RelationType         string     `json:"relationType" doc:"Type of relation"`
+	RelationType         string     `json:"relationType" enum:"refund_of,fee_of,split_of,duplicate_of,related" doc:"Type of relation, read from the source transaction to the related one"`
 	CreatedAt            time.Time  `json:"createdAt" doc:"Creation timestamp" format:"date-time"`
 	UpdatedAt            time.Time  `json:"updatedAt" doc:"Last update timestamp" format:"date-time" `
 	DeletedAt            *time.Time `json:"deletedAt" doc:"Deletion timestamp" format:"date-time"`
@@ -34,10 +34,30 @@ type TransactionRelationGetModel struct {
 type CreateTransactionRelationModel struct {
 	SourceTransactionID  int64  `path:"sourceTransactionId" required:"true" minimum:"1" doc:"ID of the source transaction"`
 	RelatedTransactionID int64  `json:"relatedTransactionId" required:"true" minimum:"1" doc:"ID of the related transaction"`
-	RelationType         string `json:"relationType" required:"true" doc:"Type of relation"`
+	RelationType         string `json:"relationType" required:"true" enum:"refund_of,fee_of,split_of,duplicate_of,related" doc:"Type of relation, read from the source transaction to the related one. refund_of: an income refunding one expense. fee_of: an expense charged as a fee for one transaction. split_of: one part of a transaction of the same type. duplicate_of: a transaction recording the same thing as one of the same type. related: any other link, both ways"`
 }
 
 type DeleteTransactionRelationModel struct {
 	SourceTransactionID int64 `path:"sourceTransactionId" minimum:"1" doc:"ID of the source transaction"`
 	RelationID          int64 `path:"relationId" minimum:"1" doc:"ID of the relation to delete"`
 }
+
+// TransactionGraphNodeModel is a transaction in a graph of related transactions
+type TransactionGraphNodeModel struct {
+	ID        int64     `json:"id" doc:"Transaction ID"`
+	Type      string    `json:"type" enum:"expense,income,transfer" doc:"Transaction type"`
+	Date      time.Time `json:"date" doc:"Transaction date" format:"date-time"`
+	Amount    int64     `json:"amount" doc:"Transaction amount in base currency"`
+	AccountID int64     `json:"accountId" doc:"Source account ID"`
+	Note      *string   `json:"note,omitempty" doc:"Transaction notes"`
+}
+
+type TransactionGraphModel struct {
+	TransactionID int64                       `json:"transactionId" doc:"Transaction the graph was requested for"`
+	Nodes         []TransactionGraphNodeModel `json:"nodes" doc:"Transactions connected to it through relations in either direction, itself included, oldest first"`
+	Edges         []TransactionRelationModel  `json:"edges" doc:"Relations between those transactions"`
+	IncomeAmount  int64                       `json:"incomeAmount" doc:"Total of the income transactions"`
+	ExpenseAmount int64                       `json:"expenseAmount" doc:"Total of the expense transactions"`
+	Net           int64                       `json:"net" doc:"Net amount (income - expense); transfers move money between accounts and do not count"`
+	Truncated     bool                        `json:"truncated" doc:"Whether the graph has more transactions than were returned"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// TransactionRevisionValuesModel holds the values of the fields a revision changed; unchanged fields are omitted
+type TransactionRevisionValuesModel struct {
+	Type                 *string    `json:"type,omitempty" enum:"expense,income,transfer" doc:"Transaction type"`
+	Date                 *time.Time `json:"date,omitempty" doc:"Transaction date" format:"date-time"`
+	Amount               *int64     `json:"amount,omitempty" doc:"Transaction amount in base currency"`
+	Status               *string    `json:"status,omitempty" enum:"pending,cleared,reconciled" doc:"Bank status"`
+	AccountID            *int64     `json:"accountId,omitempty" doc:"Source account ID"`
+	CategoryID           *int64     `json:"categoryId,omitempty" doc:"Category ID"`
+	DestinationAccountID *int64     `json:"destinationAccountId,omitempty" doc:"Destination account ID"`
+	DestinationAmount    *int64     `json:"destinationAmount,omitempty" doc:"Amount credited to the destination account"`
+	PayeeID              *int64     `json:"payeeId,omitempty" doc:"Payee ID"`
+	Note                 *string    `json:"note,omitempty" doc:"Transaction notes"`
+	Latitude             *float64   `json:"latitude,omitempty" doc:"Transaction latitude"`
+	Longitude            *float64   `json:"longitude,omitempty" doc:"Transaction longitude"`
+}
+
+type TransactionRevisionBalanceDeltaModel struct {
+	AccountID int64 `json:"accountId" doc:"Account whose balance changed"`
+	Amount    int64 `json:"amount" doc:"Change applied to the account balance by the update"`
+}
+
+type TransactionRevisionModel struct {
+	ID            int64                                  `json:"id" doc:"Unique identifier"`
+	TransactionID int64                                  `json:"transactionId" doc:"Revised transaction"`
+	Revision      int                                    `json:"revision" doc:"Revision number, counting the updates of the transaction from 1"`
+	ActorID       *int64                                 `json:"actorId" doc:"ID of the user who made the update"`
+	ChangedFields []string                               `json:"changedFields" doc:"Names of the changed fields; a field listed here but missing from before or after was empty"`
+	Before        TransactionRevisionValuesModel         `json:"before" doc:"Values of the changed fields before the update"`
+	After         TransactionRevisionValuesModel         `json:"after" doc:"Values of the changed fields after the update"`
+	BalanceDeltas []TransactionRevisionBalanceDeltaModel `json:"balanceDeltas" doc:"Net change to each account balance, omitted for accounts left unchanged"`
+	CreatedAt     time.Time                              `json:"createdAt" doc:"When the update was made" format:"date-time"`
+}
+
+type TransactionRevisionsListModel struct {
+	Items []TransactionRevisionModel `json:"items" doc:"Revisions of the transaction, newest first"`
+}
+
+// CreateTransactionRevisionModel is a revision recorded by TransactionService; the actor comes from the context
+type CreateTransactionRevisionModel struct {
+	TransactionID int64
+	ChangedFields []string
+	Before        TransactionRevisionValuesModel
+	After         TransactionRevisionValuesModel
+	BalanceDeltas []TransactionRevisionBalanceDeltaModel
+}
@@ -0,0 +1,125 @@
+package models
+
+import "time"
+
+// TransactionRuleConditionsModel lists what a transaction must meet for the rule to apply; empty conditions match anything
+type TransactionRuleConditionsModel struct {
+	NotePattern  *string  `json:"notePattern,omitempty" maxLength:"200" doc:"Regular expression (RE2 syntax) the note must match; prefix with (?i) to ignore case" example:"(?i)coffee|espresso"`
+	MinAmount    *int64   `json:"minAmount,omitempty" minimum:"1" doc:"Minimum amount in base currency, inclusive"`
+	MaxAmount    *int64   `json:"maxAmount,omitempty" minimum:"1" doc:"Maximum amount in base currency, inclusive"`
+	AccountID    *int64   `json:"accountId,omitempty" minimum:"1" doc:"Source account of the transaction"`
+	Type         *string  `json:"type,omitempty" enum:"expense,income,transfer" doc:"Transaction type"`
+	CurrencyCode *string  `json:"currencyCode,omitempty" minLength:"3" maxLength:"3" doc:"Currency the transaction was entered in; transactions without one are in the base currency"`
+	Latitude     *float64 `json:"latitude,omitempty" minimum:"-90" maximum:"90" doc:"Latitude of the center of the location condition"`
+	Longitude    *float64 `json:"longitude,omitempty" minimum:"-180" maximum:"180" doc:"Longitude of the center of the location condition"`
+	RadiusMeters *int     `json:"radiusMeters,omitempty" minimum:"1" maximum:"100000" doc:"Radius of the location condition; transactions without coordinates never match it"`
+}
+
+// TransactionRuleActionsModel lists the changes a rule makes to the transactions it matches
+type TransactionRuleActionsModel struct {
+	CategoryID           *int64  `json:"categoryId,omitempty" minimum:"1" doc:"Category to set; the rule needs a type condition matching the category type"`
+	TagIDs               []int64 `json:"tagIds,omitempty" maxItems:"20" doc:"Tags to add"`
+	Note                 *string `json:"note,omitempty" maxLength:"500" doc:"Note to set, replacing the current one"`
+	DestinationAccountID *int64  `json:"destinationAccountId,omitempty" minimum:"1" doc:"Destination account to set; the rule needs the transfer type condition"`
+}
+
+type TransactionRuleModel struct {
+	ID         int64                          `json:"id" doc:"Unique identifier"`
+	Name       string                         `json:"name" doc:"Rule name"`
+	Priority   int                            `json:"priority" doc:"Evaluation order, lowest first; ties are broken by ID"`
+	Enabled    bool                           `json:"enabled" doc:"Whether the rule runs on new transactions and range re-applies"`
+	Conditions TransactionRuleConditionsModel `json:"conditions" doc:"Conditions a transaction must all meet"`
+	Actions    TransactionRuleActionsModel    `json:"actions" doc:"Changes made to matching transactions"`
+	CreatedAt  time.Time                      `json:"createdAt" doc:"Creation timestamp" format:"date-time"`
+	UpdatedAt  *time.Time                     `json:"updatedAt,omitempty" doc:"Last update timestamp" format:"date-time"`
+}
+
+type TransactionRulesSearchModel struct {
+	PageNumber int    `query:"pageNumber" default:"1" minimum:"1" doc:"Page number for pagination"`
+	PageSize   int    `query:"pageSize" default:"25" minimum:"1" maximum:"100" doc:"Number of items per page"`
+	Name       string `query:"name" doc:"Search by rule name"`
+}
+
+type TransactionRulesPagedModel struct {
+	Items      []TransactionRuleModel `json:"items" doc:"List of rules in evaluation order"`
+	PageNumber int                    `json:"pageNumber" doc:"Current page number"`
+	PageSize   int                    `json:"pageSize" doc:"Items per page"`
+	TotalCount int                    `json:"totalCount" doc:"Total number of matching items"`
+	TotalPages int                    `json:"totalPages" doc:"Total number of pages"`
+}
+
+type CreateTransactionRuleModel struct {
+	Name       string                         `json:"name" required:"true" minLength:"1" maxLength:"100" doc:"Rule name"`
+	Priority   int                            `json:"priority,omitempty" default:"100" doc:"Evaluation order, lowest first"`
+	Enabled    *bool                          `json:"enabled,omitempty" doc:"Whether the rule runs; defaults to true"`
+	Conditions TransactionRuleConditionsModel `json:"conditions" doc:"Conditions a transaction must all meet"`
+	Actions    TransactionRuleActionsModel    `json:"actions" required:"true" doc:"Changes made to matching transactions; at least one is required"`
+}
+
+type UpdateTransactionRuleModel struct {
+	Name       *string                         `json:"name,omitempty" minLength:"1" maxLength:"100" doc:"Rule name"`
+	Priority   *int                            `json:"priority,omitempty" doc:"Evaluation order, lowest first"`
+	Enabled    *bool                           `json:"enabled,omitempty" doc:"Whether the rule runs"`
+	Conditions *TransactionRuleConditionsModel `json:"conditions,omitempty" doc:"Conditions; replaces all current conditions when provided"`
+	Actions    *TransactionRuleActionsModel    `json:"actions,omitempty" doc:"Actions; replaces all current actions when provided"`
+}
+
+type TransactionRuleRangeModel struct {
+	StartDate time.Time `json:"startDate" query:"startDate" required:"true" doc:"Start of the range of transaction dates" format:"date-time"`
+	EndDate   time.Time `json:"endDate" query:"endDate" required:"true" doc:"End of the range of transaction dates" format:"date-time"`
+}
+
+// TransactionRuleValuesModel holds the fields rules can change; unchanged fields are omitted
+type TransactionRuleValuesModel struct {
+	CategoryID           *int64  `json:"categoryId,omitempty" doc:"Category ID"`
+	Note                 *string `json:"note,omitempty" doc:"Transaction notes"`
+	DestinationAccountID *int64  `json:"destinationAccountId,omitempty" doc:"Destination account ID"`
+}
+
+type TransactionRuleChangeModel struct {
+	TransactionID int64                      `json:"transactionId" doc:"Transaction that would change"`
+	Date          time.Time                  `json:"date" doc:"Transaction date" format:"date-time"`
+	ChangedFields []string                   `json:"changedFields" doc:"Names of the fields that would change"`
+	Before        TransactionRuleValuesModel `json:"before" doc:"Current values of the changed fields"`
+	After         TransactionRuleValuesModel `json:"after" doc:"Values the rules would set"`
+	AddedTagIDs   []int64                    `json:"addedTagIds" doc:"Tags that would be added"`
+	RuleIDs       []int64                    `json:"ruleIds" doc:"Rules that matched the transaction, in evaluation order"`
+}
+
+type TransactionRuleDryRunModel struct {
+	CheckedCount int                          `json:"checkedCount" doc:"Number of transactions in the range that were evaluated"`
+	Items        []TransactionRuleChangeModel `json:"items" doc:"Transactions the rule would change, oldest first"`
+}
+
+type TransactionRuleApplyResultModel struct {
+	CheckedCount int     `json:"checkedCount" doc:"Number of transactions in the range that were evaluated"`
+	UpdatedIDs   []int64 `json:"updatedIds" doc:"Transactions changed by the rules"`
+	DurationMs   int64   `json:"durationMs" doc:"Total processing time in milliseconds"`
+}
+
+// TransactionRuleSubjectModel is the view of a transaction that rules are evaluated against
+// CurrencyCode is always set, falling back to the base currency
+type TransactionRuleSubjectModel struct {
+	ID                   int64
+	Date                 time.Time
+	Type                 string
+	Amount               int64
+	AccountID            int64
+	CategoryID           int64
+	DestinationAccountID *int64
+	CurrencyCode         string
+	Note                 *string
+	Latitude             *float64
+	Longitude            *float64
+	TagIDs               []int64
+}
+
+// TransactionRuleOutcomeModel is the combined result of the rules matching a transaction
+// A field is set by the first matching rule that has the action; tags of all matching rules are added
+type TransactionRuleOutcomeModel struct {
+	RuleIDs              []int64
+	CategoryID           *int64
+	TagIDs               []int64
+	Note                 *string
+	DestinationAccountID *int64
+}
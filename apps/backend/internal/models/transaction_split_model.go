@@ -0,0 +1,24 @@
+package models
+
+type TransactionSplitModel struct {
+	ID       int64                       `json:"id" doc:"Unique identifier"`
+	Category TransactionCategoryEmbedded `json:"category" doc:"Category of this line"`
+	Amount   int64                       `json:"amount" doc:"Line amount in base currency"`
+	Note     *string                     `json:"note,omitempty" doc:"Line notes"`
+	Tags     []TransactionTagEmbedded    `json:"tags" doc:"Line tags"`
+}
+
+type TransactionSplitsListModel struct {
+	Items []TransactionSplitModel `json:"items" doc:"Split lines of the transaction, empty when it is not split"`
+}
+
+type TransactionSplitLineModel struct {
+	CategoryID int64   `json:"categoryId" required:"true" minimum:"1" doc:"Category ID, its type must match the transaction type"`
+	Amount     int64   `json:"amount" required:"true" minimum:"1" doc:"Line amount in base currency"`
+	Note       *string `json:"note,omitempty" doc:"Optional line notes"`
+	TagIDs     []int64 `json:"tagIds,omitempty" doc:"Tag IDs for this line"`
+}
+
+type ReplaceTransactionSplitsModel struct {
+	Lines []TransactionSplitLineModel `json:"lines" required:"true" maxItems:"50" doc:"Split lines replacing the current ones; their amounts must sum to the transaction amount. An empty list removes the split"`
+}
@@ -10,6 +10,7 @@ type TransactionTemplateRecurringStats struct {
 
 type TransactionTemplateModel struct {
 	ID                 int64                             `json:"id" doc:"Unique identifier"`
+	UserID             int64                             `json:"-"`
 	Name               string                            `json:"name" doc:"Template name"`
 	Type               string                            `json:"type" minLength:"1" enum:"expense,income,transfer" doc:"Transaction type"`
 	Amount             int64                             `json:"amount" doc:"Template amount in base currency (IDR)"`
@@ -17,6 +18,7 @@ type TransactionTemplateModel struct {
 	Account            TransactionAccountEmbedded        `json:"account" doc:"Source account details"`
 	Category           TransactionCategoryEmbedded       `json:"category" doc:"Category details"`
 	DestinationAccount *TransactionAccountEmbedded       `json:"destinationAccount,omitempty" doc:"Destination account details (transfers only)"`
+	Payee              *TransactionPayeeEmbedded         `json:"payee,omitempty" doc:"Payee given to the transactions created from the template"`
 	Note               *string                           `json:"note,omitempty" doc:"Template notes"`
 	Recurrence         string                            `json:"recurrence" enum:"none,weekly,monthly,yearly" doc:"Recurrence pattern"`
 	StartDate          time.Time                         `json:"startDate" doc:"Template start date" format:"date-time"`
@@ -57,6 +59,7 @@ type CreateTransactionTemplateModel struct {
 	AccountID            int64      `json:"accountId" required:"true" minimum:"1" doc:"Source account ID"`
 	CategoryID           int64      `json:"categoryId" required:"true" minimum:"1" doc:"Category ID"`
 	DestinationAccountID *int64     `json:"destinationAccountId,omitempty" doc:"Destination account ID (transfers only)"`
+	PayeeID              *int64     `json:"payeeId,omitempty" minimum:"1" doc:"Payee ID"`
 	Note                 *string    `json:"note,omitempty" doc:"Template notes"`
 	Recurrence           string     `json:"recurrence" required:"true" enum:"none,weekly,monthly,yearly" doc:"Recurrence pattern"`
 	StartDate            time.Time  `json:"startDate" required:"true" doc:"Template start date" format:"date-time"`
@@ -71,6 +74,7 @@ type UpdateTransactionTemplateModel struct {
 	AccountID            *int64     `json:"accountId,omitempty" minimum:"1" doc:"Source account ID"`
 	CategoryID           *int64     `json:"categoryId,omitempty" minimum:"1" doc:"Category ID"`
 	DestinationAccountID *int64     `json:"destinationAccountId,omitempty" doc:"Destination account ID (transfers only)"`
+	PayeeID              *int64     `json:"payeeId,omitempty" minimum:"0" doc:"Payee ID; 0 removes the payee"`
 	Note                 *string    `json:"note,omitempty" doc:"Template notes"`
 	Recurrence           *string    `json:"recurrence,omitempty" enum:"none,weekly,monthly,yearly" doc:"Recurrence pattern"`
 	EndDate              *time.Time `json:"endDate,omitempty" doc:"Template end date" format:"date-time"`
@@ -81,6 +85,9 @@ type TransactionTemplateRelatedTransactionsSearchModel struct {
 	PageSize              int      `query:"pageSize" default:"25" minimum:"1" maximum:"100" doc:"Number of items per page"`
 	SortBy                string   `query:"sortBy" default:"date" enum:"id,type,date,amount,createdAt,updatedAt" doc:"Field to sort by"`
 	SortOrder             string   `query:"sortOrder" default:"desc" enum:"asc,desc" doc:"Sort order (asc or desc)"`
+	After                 string   `query:"after" maxLength:"100" doc:"Cursor from nextCursor; returns the page after it instead of pageNumber. Requires sortBy=date"`
+	Before                string   `query:"before" maxLength:"100" doc:"Cursor from prevCursor; returns the page before it instead of pageNumber. Requires sortBy=date"`
+	IncludeTotal          bool     `query:"includeTotal" default:"true" doc:"Count all matching transactions; false skips the count and leaves totalCount and totalPages at 0"`
 	Type                  []string `query:"type" enum:"expense,income,transfer" doc:"Filter by transaction type"`
 	AccountIDs            []int    `query:"accountId" doc:"Filter by source account IDs"`
 	CategoryIDs           []int    `query:"categoryId" doc:"Filter by category IDs"`
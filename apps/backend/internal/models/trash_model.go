@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+type TrashItemModel struct {
+	EntityType string    `json:"entityType" enum:"transaction,account,category,tag,payee,transaction_template" doc:"Kind of deleted record"`
+	ID         int64     `json:"id" doc:"Identifier of the deleted record, used by its restore endpoint"`
+	Name       string    `json:"name" doc:"Name of the record; the note or type for transactions"`
+	Amount     *int64    `json:"amount,omitempty" doc:"Amount of deleted transactions and templates"`
+	DeletedAt  time.Time `json:"deletedAt" doc:"Deletion timestamp" format:"date-time"`
+	PurgeAt    time.Time `json:"purgeAt" doc:"Earliest time the record is permanently removed; accounts, categories and payees still used by other records are kept longer" format:"date-time"`
+}
+
+type TrashSearchModel struct {
+	PageNumber int      `query:"pageNumber" default:"1" minimum:"1" doc:"Page number for pagination"`
+	PageSize   int      `query:"pageSize" default:"25" minimum:"1" maximum:"100" doc:"Number of items per page"`
+	EntityType []string `query:"entityType" enum:"transaction,account,category,tag,payee,transaction_template" doc:"Filter by kinds of records"`
+}
+
+type TrashPagedModel struct {
+	Items      []TrashItemModel `json:"items" doc:"Deleted records, most recently deleted first"`
+	PageNumber int              `json:"pageNumber" doc:"Current page number"`
+	PageSize   int              `json:"pageSize" doc:"Items per page"`
+	TotalCount int              `json:"totalCount" doc:"Total number of matching items"`
+	TotalPages int              `json:"totalPages" doc:"Total number of pages"`
+}
+
+// TrashPurgeResultModel counts the records removed by a purge run
+// StorageKeys lists the files of purged attachments, deleted from storage once the purge is committed
+type TrashPurgeResultModel struct {
+	Attachments     int64
+	Transactions    int64
+	Templates       int64
+	Tags            int64
+	Budgets         int64
+	BudgetTemplates int64
+	Payees          int64
+	Categories      int64
+	Accounts        int64
+	StorageKeys     []string
+}
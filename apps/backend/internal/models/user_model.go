@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+type UserModel struct {
+	ID        int64      `json:"id" doc:"Unique identifier"`
+	Username  string     `json:"username" doc:"Login username"`
+	TwoFactor bool       `json:"twoFactorEnabled" doc:"Whether TOTP two-factor authentication is enabled"`
+	IsAdmin   bool       `json:"isAdmin" doc:"Whether the user may register other users"`
+	CreatedAt time.Time  `json:"createdAt" doc:"Creation timestamp" format:"date-time"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty" doc:"Last update timestamp" format:"date-time"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty" doc:"Soft delete timestamp" format:"date-time"`
+}
+
+// UserCredentialsModel is the internal view of a user used to verify a login
+type UserCredentialsModel struct {
+	ID           int64
+	Username     string
+	PasswordHash string
+}
+
+// UserTOTPModel is the internal two-factor state of a user
+type UserTOTPModel struct {
+	Username  string
+	Secret    *string
+	EnabledAt *time.Time
+	LastStep  *int64
+}
+
+type CreateUserModel struct {
+	Username string `json:"username" required:"true" minLength:"3" maxLength:"255" doc:"Login username"`
+	Password string `json:"password" required:"true" minLength:"8" maxLength:"72" doc:"Login password"`
+}
@@ -189,6 +189,23 @@ var (
 		},
 		[]string{"status"}, // "hit", "miss", "error"
 	)
+
+	// Login throttle metrics - used for: Brute-force monitoring next to the request rate limiter
+	RateLimitLoginAttemptsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "spenicle_rate_limit_login_attempts_total",
+			Help: "Total login attempts seen by the login throttle (Panel: Line chart showing success vs failure vs locked)",
+		},
+		[]string{"result"}, // "success", "failure", "locked"
+	)
+
+	RateLimitLoginLockoutsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "spenicle_rate_limit_login_lockouts_total",
+			Help: "Total login lockouts started by the login throttle (Panel: Stat card with alert on spikes)",
+		},
+		[]string{"scope"}, // "user_ip" or "user"
+	)
 )
 
 // RecordHTTPError records an HTTP error metric
@@ -0,0 +1,243 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/jackc/pgx/v5"
+)
+
+const accountReconciliationColumnsSQL = `r.id, r.account_id, r.statement_date, r.statement_balance, r.status, r.reconciled_count, r.created_at, r.updated_at, r.completed_at`
+
+type AccountReconciliationRepository struct {
+	db DBQuerier
+}
+
+func NewAccountReconciliationRepository(db DBQuerier) AccountReconciliationRepository {
+	return AccountReconciliationRepository{db}
+}
+
+func scanAccountReconciliation(row pgx.Row) (models.AccountReconciliationModel, error) {
+	var item models.AccountReconciliationModel
+	err := row.Scan(&item.ID, &item.AccountID, &item.StatementDate, &item.StatementBalance, &item.Status, &item.ReconciledCount, &item.CreatedAt, &item.UpdatedAt, &item.CompletedAt)
+	return item, err
+}
+
+// GetPaged lists the reconciliations of an account; callers check the account is visible
+func (arr AccountReconciliationRepository) GetPaged(ctx context.Context, accountID int64, query models.AccountReconciliationsSearchModel) (models.AccountReconciliationsPagedModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	offset := (query.PageNumber - 1) * query.PageSize
+	sql := `SELECT ` + accountReconciliationColumnsSQL + `, COUNT(*) OVER() as total_count
+			FROM account_reconciliations r
+			WHERE r.account_id = $1 AND r.deleted_at IS NULL
+			ORDER BY r.created_at DESC, r.id DESC
+			LIMIT $2 OFFSET $3`
+
+	queryStart := time.Now()
+	rows, err := arr.db.Query(ctx, sql, accountID, query.PageSize, offset)
+	if err != nil {
+		observability.RecordError("database")
+		return models.AccountReconciliationsPagedModel{}, huma.Error500InternalServerError("Unable to query reconciliations", err)
+	}
+	defer rows.Close()
+	observability.RecordQueryDuration("SELECT", "account_reconciliations", time.Since(queryStart).Seconds())
+
+	items := []models.AccountReconciliationModel{}
+	var totalCount int
+	for rows.Next() {
+		var item models.AccountReconciliationModel
+		if err := rows.Scan(&item.ID, &item.AccountID, &item.StatementDate, &item.StatementBalance, &item.Status, &item.ReconciledCount, &item.CreatedAt, &item.UpdatedAt, &item.CompletedAt, &totalCount); err != nil {
+			return models.AccountReconciliationsPagedModel{}, huma.Error500InternalServerError("Unable to scan reconciliation data", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.AccountReconciliationsPagedModel{}, huma.Error500InternalServerError("Error reading reconciliation rows", err)
+	}
+
+	totalPages := 0
+	if totalCount > 0 {
+		totalPages = (totalCount + query.PageSize - 1) / query.PageSize
+	}
+
+	return models.AccountReconciliationsPagedModel{
+		Items:      items,
+		PageNumber: query.PageNumber,
+		PageSize:   query.PageSize,
+		TotalCount: totalCount,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (arr AccountReconciliationRepository) GetDetail(ctx context.Context, accountID int64, id int64) (models.AccountReconciliationModel, error) {
+	return arr.getDetail(ctx, accountID, id, "")
+}
+
+// GetDetailForUpdate locks the reconciliation and its account until the surrounding database transaction ends
+// Transaction changes update the account balance, so they wait for the lock and cannot slip in between the balance check and completion
+func (arr AccountReconciliationRepository) GetDetailForUpdate(ctx context.Context, accountID int64, id int64) (models.AccountReconciliationModel, error) {
+	return arr.getDetail(ctx, accountID, id, " FOR UPDATE")
+}
+
+func (arr AccountReconciliationRepository) getDetail(ctx context.Context, accountID int64, id int64, lock string) (models.AccountReconciliationModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `SELECT ` + accountReconciliationColumnsSQL + `
+			FROM account_reconciliations r
+			INNER JOIN accounts a ON r.account_id = a.id
+			WHERE r.id = $1 AND r.account_id = $2 AND r.deleted_at IS NULL` + lock
+
+	queryStart := time.Now()
+	item, err := scanAccountReconciliation(arr.db.QueryRow(ctx, sql, id, accountID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.AccountReconciliationModel{}, huma.Error404NotFound("Reconciliation not found")
+	}
+	if err != nil {
+		observability.RecordError("database")
+		return models.AccountReconciliationModel{}, huma.Error500InternalServerError("Unable to query reconciliation", err)
+	}
+	observability.RecordQueryDuration("SELECT", "account_reconciliations", time.Since(queryStart).Seconds())
+
+	return item, nil
+}
+
+// GetBalance compares an account with a statement
+// Each transaction moves the account by its signed amount: income and incoming transfers add, expenses and outgoing transfers subtract.
+// The cleared balance removes from the account balance the moves of pending transactions and of any transaction after the statement date,
+// so opening balances recorded on the account itself are accounted for
+func (arr AccountReconciliationRepository) GetBalance(ctx context.Context, accountID int64, statementDate time.Time, statementBalance int64) (models.AccountReconciliationBalanceModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		WITH moves AS (
+			SELECT t.status, t.date,
+				CASE
+					WHEN t.type = 'income' THEN t.amount
+					WHEN t.type = 'transfer' AND t.destination_account_id = $1 THEN COALESCE(t.destination_amount, t.amount)
+					ELSE -t.amount
+				END as amount
+			FROM transactions t
+			WHERE t.deleted_at IS NULL
+				AND (t.account_id = $1 OR (t.type = 'transfer' AND t.destination_account_id = $1))
+		)
+		SELECT a.amount,
+			COALESCE((SELECT SUM(amount) FROM moves WHERE status = 'pending' OR date > $2), 0),
+			COALESCE((SELECT SUM(amount) FROM moves WHERE status = 'pending' AND date <= $2), 0),
+			(SELECT COUNT(*) FROM moves WHERE status = 'pending' AND date <= $2),
+			(SELECT COUNT(*) FROM moves WHERE status = 'cleared' AND date <= $2)
+		FROM accounts a
+		WHERE a.id = $1 AND a.deleted_at IS NULL`
+
+	var item models.AccountReconciliationBalanceModel
+	var excludedAmount int64
+
+	queryStart := time.Now()
+	err := arr.db.QueryRow(ctx, sql, accountID, statementDate).Scan(&item.AccountBalance, &excludedAmount, &item.UnclearedAmount, &item.UnclearedCount, &item.ClearedCount)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.AccountReconciliationBalanceModel{}, huma.Error404NotFound("Account not found")
+	}
+	if err != nil {
+		observability.RecordError("database")
+		return models.AccountReconciliationBalanceModel{}, huma.Error500InternalServerError("Unable to compute reconciliation balance", err)
+	}
+	observability.RecordQueryDuration("SELECT", "transactions", time.Since(queryStart).Seconds())
+
+	item.ClearedBalance = item.AccountBalance - excludedAmount
+	item.Difference = statementBalance - item.ClearedBalance
+	return item, nil
+}
+
+// Create opens a reconciliation; an account has at most one open reconciliation
+func (arr AccountReconciliationRepository) Create(ctx context.Context, accountID int64, p models.CreateAccountReconciliationModel) (models.AccountReconciliationModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `INSERT INTO account_reconciliations AS r (account_id, user_id, statement_date, statement_balance)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (account_id) WHERE status = 'open' AND deleted_at IS NULL DO NOTHING
+			RETURNING ` + accountReconciliationColumnsSQL
+
+	queryStart := time.Now()
+	item, err := scanAccountReconciliation(arr.db.QueryRow(ctx, sql, accountID, common.GetUserID(ctx), p.StatementDate, p.StatementBalance))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.AccountReconciliationModel{}, huma.Error409Conflict("The account already has an open reconciliation")
+	}
+	if err != nil {
+		observability.RecordError("database")
+		return models.AccountReconciliationModel{}, huma.Error500InternalServerError("Unable to create reconciliation", err)
+	}
+	observability.RecordQueryDuration("INSERT", "account_reconciliations", time.Since(queryStart).Seconds())
+
+	return item, nil
+}
+
+// Complete locks the cleared transactions of the account up to the statement date and closes the reconciliation
+// Callers hold the row lock from GetDetailForUpdate and have checked the balance
+func (arr AccountReconciliationRepository) Complete(ctx context.Context, reconciliation models.AccountReconciliationModel) (models.AccountReconciliationModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	lockSQL := `UPDATE transactions
+			SET status = 'reconciled', reconciliation_id = $1, updated_at = CURRENT_TIMESTAMP
+			WHERE deleted_at IS NULL
+				AND status = 'cleared'
+				AND date <= $3
+				AND (account_id = $2 OR (type = 'transfer' AND destination_account_id = $2))`
+
+	queryStart := time.Now()
+	cmdTag, err := arr.db.Exec(ctx, lockSQL, reconciliation.ID, reconciliation.AccountID, reconciliation.StatementDate)
+	if err != nil {
+		observability.RecordError("database")
+		return models.AccountReconciliationModel{}, huma.Error500InternalServerError("Unable to reconcile transactions", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "transactions", time.Since(queryStart).Seconds())
+
+	sql := `UPDATE account_reconciliations r
+			SET status = 'completed', reconciled_count = $2, completed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+			WHERE r.id = $1
+			RETURNING ` + accountReconciliationColumnsSQL
+
+	queryStart = time.Now()
+	item, err := scanAccountReconciliation(arr.db.QueryRow(ctx, sql, reconciliation.ID, cmdTag.RowsAffected()))
+	if err != nil {
+		observability.RecordError("database")
+		return models.AccountReconciliationModel{}, huma.Error500InternalServerError("Unable to complete reconciliation", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "account_reconciliations", time.Since(queryStart).Seconds())
+
+	return item, nil
+}
+
+// Delete discards an open reconciliation; completed ones stay as the record of what was locked
+func (arr AccountReconciliationRepository) Delete(ctx context.Context, accountID int64, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `UPDATE account_reconciliations
+			SET deleted_at = CURRENT_TIMESTAMP
+			WHERE id = $1 AND account_id = $2 AND status = 'open' AND deleted_at IS NULL`
+
+	queryStart := time.Now()
+	cmdTag, err := arr.db.Exec(ctx, sql, id, accountID)
+	if err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to delete reconciliation", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return huma.Error404NotFound("Open reconciliation not found")
+	}
+	observability.RecordQueryDuration("UPDATE", "account_reconciliations", time.Since(queryStart).Seconds())
+
+	return nil
+}
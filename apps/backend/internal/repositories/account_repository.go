@@ -2,17 +2,31 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
 	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
 	"github.com/dimasbaguspm/spenicle-api/internal/observability"
 	"github.com/jackc/pgx/v5"
 )
 
+// accountAccessColumnsSQL selects the role of the user bound to userParam on account a, and who the account is shared with
+func accountAccessColumnsSQL(userParam string) string {
+	return `CASE WHEN a.user_id = ` + userParam + ` THEN '` + constants.ShareRoleOwner + `'
+					ELSE (SELECT sh.role FROM shares sh WHERE sh.resource_type = '` + constants.EntityAccount + `' AND sh.resource_id = a.id AND sh.user_id = ` + userParam + ` AND sh.deleted_at IS NULL)
+				END as role,
+				(SELECT COALESCE(JSON_AGG(JSON_BUILD_OBJECT('userId', u.id, 'username', u.username, 'role', sh.role) ORDER BY u.username), '[]'::json)
+					FROM shares sh
+					JOIN users u ON u.id = sh.user_id
+					WHERE sh.resource_type = '` + constants.EntityAccount + `' AND sh.resource_id = a.id AND sh.deleted_at IS NULL
+				) as shared_with`
+}
+
 type AccountRepository struct {
 	db DBQuerier
 }
@@ -58,6 +72,7 @@ func (ar AccountRepository) GetPaged(ctx context.Context, query models.AccountsS
 		filtered_accounts AS (
 			SELECT
 				a.id, a.name, a.type, a.note, a.amount, a.icon, a.icon_color, a.display_order, a.archived_at, a.created_at, a.updated_at,
+				` + accountAccessColumnsSQL("$7") + `,
 				b.id as budget_id, b.template_id, b.account_id, b.category_id, b.period_start, b.period_end, b.amount_limit,
 				b.actual_amount,
 				b.period_type, b.name as budget_name,
@@ -65,6 +80,7 @@ func (ar AccountRepository) GetPaged(ctx context.Context, query models.AccountsS
 			FROM accounts a
 			LEFT JOIN ranked_budgets b ON b.account_id = a.id AND b.rn = 1
 			WHERE a.deleted_at IS NULL
+				AND (a.user_id = $7 OR ` + sharedWithUserSQL(constants.EntityAccount, "a.id", "$7") + `)
 				AND (array_length($3::int8[], 1) IS NULL OR a.id = ANY($3::int8[]))
 				AND ($5::text IS NULL OR $5::text = '' OR a.name ILIKE '%' || $5::text || '%')
 				AND (array_length($4::text[], 1) IS NULL OR a.type = ANY($4::text[]))
@@ -88,6 +104,8 @@ func (ar AccountRepository) GetPaged(ctx context.Context, query models.AccountsS
 			archived_at,
 			created_at,
 			updated_at,
+			role,
+			shared_with,
 			budget_id,
 			template_id,
 			account_id,
@@ -118,7 +136,7 @@ func (ar AccountRepository) GetPaged(ctx context.Context, query models.AccountsS
 	}
 
 	queryStart := time.Now()
-	rows, err := ar.db.Query(ctx, sql, query.PageSize, offset, ids, types, query.Name, query.Archived)
+	rows, err := ar.db.Query(ctx, sql, query.PageSize, offset, ids, types, query.Name, query.Archived, common.GetUserID(ctx))
 	observability.RecordQueryDuration("SELECT", "accounts", time.Since(queryStart).Seconds())
 	if err != nil {
 		observability.RecordError("database")
@@ -141,10 +159,15 @@ func (ar AccountRepository) GetPaged(ctx context.Context, query models.AccountsS
 		var actualAmount *int64
 		var periodType *string
 		var budgetName *string
-		err := rows.Scan(&item.ID, &item.Name, &item.Type, &item.Note, &item.Amount, &item.Icon, &item.IconColor, &item.DisplayOrder, &item.ArchivedAt, &item.CreatedAt, &item.UpdatedAt, &budgetID, &templateID, &accountID, &categoryID, &periodStart, &periodEnd, &amountLimit, &actualAmount, &periodType, &budgetName, &totalCount)
+		var sharedWithJSON []byte
+		err := rows.Scan(&item.ID, &item.Name, &item.Type, &item.Note, &item.Amount, &item.Icon, &item.IconColor, &item.DisplayOrder, &item.ArchivedAt, &item.CreatedAt, &item.UpdatedAt, &item.Role, &sharedWithJSON, &budgetID, &templateID, &accountID, &categoryID, &periodStart, &periodEnd, &amountLimit, &actualAmount, &periodType, &budgetName, &totalCount)
 		if err != nil {
 			return models.AccountsPagedModel{}, huma.Error500InternalServerError("Unable to scan account data", err)
 		}
+		item.ETag = common.BuildETag(item.ID, item.CreatedAt, item.UpdatedAt)
+		if err := json.Unmarshal(sharedWithJSON, &item.SharedWith); err != nil {
+			return models.AccountsPagedModel{}, huma.Error500InternalServerError("Unable to parse shared-with data", err)
+		}
 		if budgetID != nil {
 			item.EmbeddedBudget = &models.EmbeddedBudget{
 				ID:           *budgetID,
@@ -199,9 +222,11 @@ func (ar AccountRepository) GetDetail(ctx context.Context, id int64) (models.Acc
 	var actualAmount *int64
 	var periodType *string
 	var budgetName *string
+	var sharedWithJSON []byte
 
 	sql := `SELECT
 			a.id, a.name, a.type, a.note, a.amount, a.icon, a.icon_color, a.display_order, a.archived_at, a.created_at, a.updated_at, a.deleted_at,
+			` + accountAccessColumnsSQL("$2") + `,
 			b.budget_id, b.template_id, b.account_id, b.category_id, b.period_start, b.period_end, b.amount_limit,
 			b.actual_amount,
 			b.period_type, b.budget_name
@@ -227,10 +252,12 @@ func (ar AccountRepository) GetDetail(ctx context.Context, id int64) (models.Acc
 			ORDER BY b.id DESC
 			LIMIT 1
 		) b ON true
-		WHERE a.id = $1 AND a.deleted_at IS NULL`
+		WHERE a.id = $1
+			AND (a.user_id = $2 OR ` + sharedWithUserSQL(constants.EntityAccount, "a.id", "$2") + `)
+			AND a.deleted_at IS NULL`
 
 	queryStart := time.Now()
-	err := ar.db.QueryRow(ctx, sql, id).Scan(&data.ID, &data.Name, &data.Type, &data.Note, &data.Amount, &data.Icon, &data.IconColor, &data.DisplayOrder, &data.ArchivedAt, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt, &budgetID, &templateID, &accountID, &categoryID, &periodStart, &periodEnd, &amountLimit, &actualAmount, &periodType, &budgetName)
+	err := ar.db.QueryRow(ctx, sql, id, common.GetUserID(ctx)).Scan(&data.ID, &data.Name, &data.Type, &data.Note, &data.Amount, &data.Icon, &data.IconColor, &data.DisplayOrder, &data.ArchivedAt, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt, &data.Role, &sharedWithJSON, &budgetID, &templateID, &accountID, &categoryID, &periodStart, &periodEnd, &amountLimit, &actualAmount, &periodType, &budgetName)
 	observability.RecordQueryDuration("SELECT", "accounts", time.Since(queryStart).Seconds())
 
 	if err != nil {
@@ -240,6 +267,11 @@ func (ar AccountRepository) GetDetail(ctx context.Context, id int64) (models.Acc
 		observability.RecordError("database")
 		return models.AccountModel{}, huma.Error500InternalServerError("Unable to query account", err)
 	}
+	data.ETag = common.BuildETag(data.ID, data.CreatedAt, data.UpdatedAt)
+
+	if err := json.Unmarshal(sharedWithJSON, &data.SharedWith); err != nil {
+		return models.AccountModel{}, huma.Error500InternalServerError("Unable to parse shared-with data", err)
+	}
 
 	if budgetID != nil {
 		data.EmbeddedBudget = &models.EmbeddedBudget{
@@ -259,6 +291,33 @@ func (ar AccountRepository) GetDetail(ctx context.Context, id int64) (models.Acc
 	return data, nil
 }
 
+// GetDetailForUpdate locks the account until the surrounding database transaction ends, so its version cannot
+// change between checking If-Match and writing
+func (ar AccountRepository) GetDetailForUpdate(ctx context.Context, id int64) (models.AccountModel, error) {
+	lockCtx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `SELECT a.id FROM accounts a
+		WHERE a.id = $1
+			AND (a.user_id = $2 OR ` + sharedWithUserSQL(constants.EntityAccount, "a.id", "$2") + `)
+			AND a.deleted_at IS NULL
+		FOR UPDATE OF a`
+
+	queryStart := time.Now()
+	var ID int64
+	err := ar.db.QueryRow(lockCtx, sql, id, common.GetUserID(ctx)).Scan(&ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.AccountModel{}, huma.Error404NotFound("Account not found")
+		}
+		observability.RecordError("database")
+		return models.AccountModel{}, huma.Error500InternalServerError("Unable to lock account", err)
+	}
+	observability.RecordQueryDuration("SELECT", "accounts", time.Since(queryStart).Seconds())
+
+	return ar.GetDetail(ctx, ID)
+}
+
 func (ar AccountRepository) Create(ctx context.Context, payload models.CreateAccountModel) (models.AccountModel, error) {
 	var ID int64
 
@@ -266,13 +325,13 @@ func (ar AccountRepository) Create(ctx context.Context, payload models.CreateAcc
 	defer cancel()
 
 	sql := `
-		INSERT INTO accounts (name, type, note, icon, icon_color, display_order)
-		VALUES ($1, $2, $3, $4, $5,  COALESCE((SELECT MAX(display_order) + 1 FROM accounts WHERE deleted_at IS NULL), 0))
+		INSERT INTO accounts (name, type, note, icon, icon_color, user_id, display_order)
+		VALUES ($1, $2, $3, $4, $5, $6, COALESCE((SELECT MAX(display_order) + 1 FROM accounts WHERE user_id = $6 AND deleted_at IS NULL), 0))
 		RETURNING id
 	`
 
 	queryStart := time.Now()
-	err := ar.db.QueryRow(ctx, sql, payload.Name, payload.Type, payload.Note, payload.Icon, payload.IconColor).Scan(&ID)
+	err := ar.db.QueryRow(ctx, sql, payload.Name, payload.Type, payload.Note, payload.Icon, payload.IconColor, common.GetUserID(ctx)).Scan(&ID)
 	observability.RecordQueryDuration("INSERT", "accounts", time.Since(queryStart).Seconds())
 
 	if err != nil {
@@ -301,11 +360,13 @@ func (ar AccountRepository) Update(ctx context.Context, id int64, payload models
 					ELSE archived_at
 				END,
 				updated_at = CURRENT_TIMESTAMP
-			WHERE id = $7 AND deleted_at IS NULL
+			WHERE id = $7
+				AND (user_id = $8 OR ` + sharedWithUserSQL(constants.EntityAccount, "accounts.id", "$8", constants.ShareRoleEditor) + `)
+				AND deleted_at IS NULL
 			RETURNING id`
 
 	queryStart := time.Now()
-	err := ar.db.QueryRow(ctx, sql, payload.Name, payload.Type, payload.Note, payload.Icon, payload.IconColor, payload.ArchivedAt, id).Scan(&ID)
+	err := ar.db.QueryRow(ctx, sql, payload.Name, payload.Type, payload.Note, payload.Icon, payload.IconColor, payload.ArchivedAt, id, common.GetUserID(ctx)).Scan(&ID)
 	observability.RecordQueryDuration("UPDATE", "accounts", time.Since(queryStart).Seconds())
 
 	if err != nil {
@@ -327,9 +388,11 @@ func (ar AccountRepository) ValidateIDsExist(ctx context.Context, ids []int64) e
 		return huma.Error400BadRequest("No account IDs provided")
 	}
 
+	userID := common.GetUserID(ctx)
+
 	var matched int
-	sql := `SELECT COUNT(1) FROM accounts WHERE id = ANY($1::int8[]) AND deleted_at IS NULL`
-	if err := ar.db.QueryRow(ctx, sql, ids).Scan(&matched); err != nil {
+	sql := `SELECT COUNT(1) FROM accounts WHERE id = ANY($1::int8[]) AND user_id = $2 AND deleted_at IS NULL`
+	if err := ar.db.QueryRow(ctx, sql, ids, userID).Scan(&matched); err != nil {
 		return huma.Error500InternalServerError("Unable to validate accounts", err)
 	}
 	if matched != len(ids) {
@@ -337,7 +400,7 @@ func (ar AccountRepository) ValidateIDsExist(ctx context.Context, ids []int64) e
 	}
 
 	var totalActive int
-	if err := ar.db.QueryRow(ctx, `SELECT COUNT(1) FROM accounts WHERE deleted_at IS NULL`).Scan(&totalActive); err != nil {
+	if err := ar.db.QueryRow(ctx, `SELECT COUNT(1) FROM accounts WHERE user_id = $1 AND deleted_at IS NULL`, userID).Scan(&totalActive); err != nil {
 		return huma.Error500InternalServerError("Unable to validate account count", err)
 	}
 	if totalActive != len(ids) {
@@ -351,9 +414,9 @@ func (ar AccountRepository) Delete(ctx context.Context, id int64) error {
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
 
-	delSQL := `UPDATE accounts SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL`
+	delSQL := `UPDATE accounts SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`
 	queryStart := time.Now()
-	cmdTag, err := ar.db.Exec(ctx, delSQL, id)
+	cmdTag, err := ar.db.Exec(ctx, delSQL, id, common.GetUserID(ctx))
 	observability.RecordQueryDuration("DELETE", "accounts", time.Since(queryStart).Seconds())
 	if err != nil {
 		observability.RecordError("database")
@@ -365,11 +428,35 @@ func (ar AccountRepository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// Restore brings a deleted account back from the trash at the end of the display order
+func (ar AccountRepository) Restore(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		UPDATE accounts
+		SET deleted_at = NULL,
+			updated_at = CURRENT_TIMESTAMP,
+			display_order = COALESCE((SELECT MAX(display_order) + 1 FROM accounts WHERE user_id = $2 AND deleted_at IS NULL), 0)
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL`
+	queryStart := time.Now()
+	cmdTag, err := ar.db.Exec(ctx, sql, id, common.GetUserID(ctx))
+	observability.RecordQueryDuration("UPDATE", "accounts", time.Since(queryStart).Seconds())
+	if err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to restore account", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return huma.Error404NotFound("Deleted account not found")
+	}
+	return nil
+}
+
 func (ar AccountRepository) GetActiveIDsOrdered(ctx context.Context) ([]int64, error) {
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
 
-	rows, err := ar.db.Query(ctx, `SELECT id FROM accounts WHERE deleted_at IS NULL ORDER BY display_order ASC, id ASC`)
+	rows, err := ar.db.Query(ctx, `SELECT id FROM accounts WHERE user_id = $1 AND deleted_at IS NULL ORDER BY display_order ASC, id ASC`, common.GetUserID(ctx))
 	if err != nil {
 		return nil, huma.Error500InternalServerError("Unable to query account ids", err)
 	}
@@ -399,9 +486,9 @@ func (ar AccountRepository) Reorder(ctx context.Context, ids []int64) error {
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
 
-	reorderSQL := `UPDATE accounts SET display_order = v.new_order, updated_at = CURRENT_TIMESTAMP FROM (SELECT id::bigint AS id, ord - 1 AS new_order FROM unnest($1::int8[]) WITH ORDINALITY AS t(id, ord)) v WHERE accounts.id = v.id AND deleted_at IS NULL`
+	reorderSQL := `UPDATE accounts SET display_order = v.new_order, updated_at = CURRENT_TIMESTAMP FROM (SELECT id::bigint AS id, ord - 1 AS new_order FROM unnest($1::int8[]) WITH ORDINALITY AS t(id, ord)) v WHERE accounts.id = v.id AND accounts.user_id = $2 AND deleted_at IS NULL`
 	queryStart := time.Now()
-	if _, err := ar.db.Exec(ctx, reorderSQL, ids); err != nil {
+	if _, err := ar.db.Exec(ctx, reorderSQL, ids, common.GetUserID(ctx)); err != nil {
 		observability.RecordError("database")
 		return huma.Error500InternalServerError("Unable to reorder accounts", err)
 	}
@@ -409,6 +496,7 @@ func (ar AccountRepository) Reorder(ctx context.Context, ids []int64) error {
 	return nil
 }
 
+// UpdateBalance applies a balance delta to an account the current user owns or may edit
 func (ar AccountRepository) UpdateBalance(ctx context.Context, accountID int64, deltaAmount int64) error {
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
@@ -416,15 +504,26 @@ func (ar AccountRepository) UpdateBalance(ctx context.Context, accountID int64,
 	sql := `UPDATE accounts
 			SET amount = amount + $1,
 				updated_at = CURRENT_TIMESTAMP
-			WHERE id = $2 AND deleted_at IS NULL`
-
-	cmdTag, err := ar.db.Exec(ctx, sql, deltaAmount, accountID)
+			WHERE id = $2
+				AND (user_id = $3 OR ` + sharedWithUserSQL(constants.EntityAccount, "accounts.id", "$3", constants.ShareRoleEditor) + `)
+				AND deleted_at IS NULL
+			RETURNING amount`
+
+	var amount int64
+	err := ar.db.QueryRow(ctx, sql, deltaAmount, accountID, common.GetUserID(ctx)).Scan(&amount)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("account not found")
+	}
 	if err != nil {
 		return fmt.Errorf("unable to update account balance: %w", err)
 	}
-	if cmdTag.RowsAffected() == 0 {
-		return fmt.Errorf("account not found")
-	}
 
-	return nil
+	// Balances move with every transaction change, so each move is audited on the same querier
+	return NewAuditRepository(ar.db).Create(ctx, models.CreateAuditLogModel{
+		EntityType: constants.EntityAccount,
+		EntityID:   accountID,
+		Operation:  constants.AuditOperationUpdate,
+		Before:     map[string]int64{"amount": amount - deltaAmount},
+		After:      map[string]int64{"amount": amount},
+	})
 }
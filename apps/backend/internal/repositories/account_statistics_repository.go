@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
 	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
 	"github.com/dimasbaguspm/spenicle-api/internal/observability"
@@ -21,11 +22,29 @@ func NewAccountStatisticsRepository(db DBQuerier) AccountStatisticsRepository {
 	return AccountStatisticsRepository{db}
 }
 
+// ensureOwned verifies the account belongs to the user on the context before computing statistics
+func (sr AccountStatisticsRepository) ensureOwned(ctx context.Context, accountID int64) error {
+	var exists bool
+	sql := `SELECT EXISTS (SELECT 1 FROM accounts WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL)`
+	if err := sr.db.QueryRow(ctx, sql, accountID, common.GetUserID(ctx)).Scan(&exists); err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to query account", err)
+	}
+	if !exists {
+		return huma.Error404NotFound("Account not found")
+	}
+	return nil
+}
+
 // GetCategoryHeatmap returns spending distribution by category
 func (sr AccountStatisticsRepository) GetCategoryHeatmap(ctx context.Context, accountID int64, p models.AccountStatisticsSearchModel) (models.AccountStatisticsCategoryHeatmapModel, error) {
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
 
+	if err := sr.ensureOwned(ctx, accountID); err != nil {
+		return models.AccountStatisticsCategoryHeatmapModel{}, err
+	}
+
 	sql := `
 		WITH category_spending AS (
 			SELECT
@@ -34,7 +53,7 @@ func (sr AccountStatisticsRepository) GetCategoryHeatmap(ctx context.Context, ac
 				COUNT(t.id) as total_count,
 				COALESCE(SUM(t.amount), 0) as total_amount
 			FROM categories c
-			LEFT JOIN transactions t ON t.category_id = c.id
+			LEFT JOIN transaction_category_amounts t ON t.category_id = c.id
 				AND t.account_id = $1
 				AND t.type = 'expense'
 				AND t.deleted_at IS NULL
@@ -114,6 +133,10 @@ func (sr AccountStatisticsRepository) GetMonthlyVelocity(ctx context.Context, ac
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
 
+	if err := sr.ensureOwned(ctx, accountID); err != nil {
+		return models.AccountStatisticsMonthlyVelocityModel{}, err
+	}
+
 	sql := `
 		WITH transaction_impacts AS (
 			SELECT
@@ -124,12 +147,15 @@ func (sr AccountStatisticsRepository) GetMonthlyVelocity(ctx context.Context, ac
 					WHEN type = 'transfer' AND account_id = $1 THEN 'transfer_out'
 					WHEN type = 'transfer' AND destination_account_id = $1 THEN 'transfer_in'
 				END as impact_type,
-				amount,
+				CASE
+					WHEN type = 'transfer' AND account_id <> $1 THEN COALESCE(destination_amount, amount)
+					ELSE amount
+				END as amount,
 				CASE
 					WHEN type = 'income' THEN amount
 					WHEN type = 'expense' THEN -amount
 					WHEN type = 'transfer' AND account_id = $1 THEN -amount
-					WHEN type = 'transfer' AND destination_account_id = $1 THEN amount
+					WHEN type = 'transfer' AND destination_account_id = $1 THEN COALESCE(destination_amount, amount)
 					ELSE 0
 				END as net_impact,
 				DATE_PART('days', (date_trunc('month', date) + INTERVAL '1 month' - INTERVAL '1 day')::date::timestamp) as days_in_month
@@ -236,6 +262,10 @@ func (sr AccountStatisticsRepository) GetTimeFrequencyHeatmap(ctx context.Contex
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
 
+	if err := sr.ensureOwned(ctx, accountID); err != nil {
+		return models.AccountStatisticsTimeFrequencyHeatmapModel{}, err
+	}
+
 	// First, get total transaction count
 	var totalTx int
 	err := sr.db.QueryRow(ctx, `
@@ -333,6 +363,10 @@ func (sr AccountStatisticsRepository) GetCashFlowPulse(ctx context.Context, acco
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
 
+	if err := sr.ensureOwned(ctx, accountID); err != nil {
+		return models.AccountStatisticsCashFlowPulseModel{}, err
+	}
+
 	// Get current balance and calculate balance at period start
 	var currentBalance int64
 	balanceStart := time.Now()
@@ -352,7 +386,7 @@ func (sr AccountStatisticsRepository) GetCashFlowPulse(ctx context.Context, acco
 					WHEN type = 'income' THEN amount
 					WHEN type = 'expense' THEN -amount
 					WHEN type = 'transfer' AND account_id = $1 THEN -amount
-					WHEN type = 'transfer' AND destination_account_id = $1 THEN amount
+					WHEN type = 'transfer' AND destination_account_id = $1 THEN COALESCE(destination_amount, amount)
 					ELSE 0
 				END
 			), 0) as net_flow
@@ -384,7 +418,7 @@ func (sr AccountStatisticsRepository) GetCashFlowPulse(ctx context.Context, acco
 					WHEN t.type = 'income' THEN t.amount
 					WHEN t.type = 'expense' THEN -t.amount
 					WHEN t.type = 'transfer' AND t.account_id = $1 THEN -t.amount
-					WHEN t.type = 'transfer' AND t.destination_account_id = $1 THEN t.amount
+					WHEN t.type = 'transfer' AND t.destination_account_id = $1 THEN COALESCE(t.destination_amount, t.amount)
 					ELSE 0
 				END as amount_impact
 			FROM transactions t
@@ -479,6 +513,10 @@ func (sr AccountStatisticsRepository) GetBurnRate(ctx context.Context, accountID
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
 
+	if err := sr.ensureOwned(ctx, accountID); err != nil {
+		return models.AccountStatisticsBurnRateModel{}, err
+	}
+
 	sql := `
 		SELECT
 			COUNT(DISTINCT DATE(t.date)) as spending_days,
@@ -586,6 +624,10 @@ func (sr AccountStatisticsRepository) GetBudgetHealth(ctx context.Context, accou
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
 
+	if err := sr.ensureOwned(ctx, accountID); err != nil {
+		return models.AccountStatisticsBudgetHealthModel{}, err
+	}
+
 	// Get active budgets
 	activeBudgetSQL := `
 		SELECT 
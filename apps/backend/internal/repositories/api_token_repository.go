@@ -0,0 +1,188 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/jackc/pgx/v5"
+)
+
+type APITokenRepository struct {
+	db DBQuerier
+}
+
+func NewAPITokenRepository(db DBQuerier) APITokenRepository {
+	return APITokenRepository{db}
+}
+
+func (ar APITokenRepository) GetList(ctx context.Context) (models.APITokensListModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		SELECT
+			id, name, token_prefix, scopes, last_used_at, expires_at, created_at
+		FROM api_tokens
+		WHERE user_id = $1
+			AND deleted_at IS NULL
+		ORDER BY created_at DESC`
+
+	queryStart := time.Now()
+	rows, err := ar.db.Query(ctx, sql, common.GetUserID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return models.APITokensListModel{}, huma.Error500InternalServerError("Unable to query API tokens", err)
+	}
+	defer rows.Close()
+	observability.RecordQueryDuration("SELECT", "api_tokens", time.Since(queryStart).Seconds())
+
+	items := []models.APITokenModel{}
+	for rows.Next() {
+		var item models.APITokenModel
+		if err := rows.Scan(&item.ID, &item.Name, &item.Prefix, &item.Scopes, &item.LastUsedAt, &item.ExpiresAt, &item.CreatedAt); err != nil {
+			return models.APITokensListModel{}, huma.Error500InternalServerError("Unable to scan API token data", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.APITokensListModel{}, huma.Error500InternalServerError("Error reading API token rows", err)
+	}
+
+	return models.APITokensListModel{Items: items}, nil
+}
+
+func (ar APITokenRepository) GetDetail(ctx context.Context, id int64) (models.APITokenModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	var data models.APITokenModel
+
+	sql := `
+		SELECT
+			id, name, token_prefix, scopes, last_used_at, expires_at, created_at
+		FROM api_tokens
+		WHERE id = $1
+			AND user_id = $2
+			AND deleted_at IS NULL`
+
+	queryStart := time.Now()
+	err := ar.db.QueryRow(ctx, sql, id, common.GetUserID(ctx)).Scan(&data.ID, &data.Name, &data.Prefix, &data.Scopes, &data.LastUsedAt, &data.ExpiresAt, &data.CreatedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.APITokenModel{}, huma.Error404NotFound("API token not found")
+		}
+		observability.RecordError("database")
+		return models.APITokenModel{}, huma.Error500InternalServerError("Unable to query API token", err)
+	}
+	observability.RecordQueryDuration("SELECT", "api_tokens", time.Since(queryStart).Seconds())
+
+	return data, nil
+}
+
+// Create stores a token by its hash; the caller generates the plain value and returns it to the user once
+func (ar APITokenRepository) Create(ctx context.Context, payload models.CreateAPITokenModel, prefix string, tokenHash string) (models.APITokenModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	var ID int64
+
+	sql := `INSERT INTO api_tokens (user_id, name, token_prefix, token_hash, scopes, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id`
+
+	queryStart := time.Now()
+	err := ar.db.QueryRow(ctx, sql, common.GetUserID(ctx), payload.Name, prefix, tokenHash, payload.Scopes, payload.ExpiresAt).Scan(&ID)
+
+	if err != nil {
+		observability.RecordError("database")
+		return models.APITokenModel{}, huma.Error500InternalServerError("Unable to create API token", err)
+	}
+	observability.RecordQueryDuration("INSERT", "api_tokens", time.Since(queryStart).Seconds())
+
+	return ar.GetDetail(ctx, ID)
+}
+
+func (ar APITokenRepository) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		UPDATE api_tokens
+		SET deleted_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+			AND user_id = $2
+			AND deleted_at IS NULL`
+
+	queryStart := time.Now()
+	cmdTag, err := ar.db.Exec(ctx, sql, id, common.GetUserID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to revoke API token", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return huma.Error404NotFound("API token not found")
+	}
+	observability.RecordQueryDuration("DELETE", "api_tokens", time.Since(queryStart).Seconds())
+
+	return nil
+}
+
+// DeleteAll revokes every active token of the current user
+func (ar APITokenRepository) DeleteAll(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		UPDATE api_tokens
+		SET deleted_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1
+			AND deleted_at IS NULL`
+
+	queryStart := time.Now()
+	if _, err := ar.db.Exec(ctx, sql, common.GetUserID(ctx)); err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to revoke API tokens", err)
+	}
+	observability.RecordQueryDuration("DELETE", "api_tokens", time.Since(queryStart).Seconds())
+
+	return nil
+}
+
+// Authenticate looks up an active, unexpired token by hash and records its use
+// Runs before a user is known, so it is not scoped by the context user
+func (ar APITokenRepository) Authenticate(ctx context.Context, tokenHash string) (models.APITokenAuthModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	var data models.APITokenAuthModel
+
+	sql := `
+		UPDATE api_tokens
+		SET last_used_at = CURRENT_TIMESTAMP
+		WHERE token_hash = $1
+			AND deleted_at IS NULL
+			AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+		RETURNING id, user_id, scopes`
+
+	queryStart := time.Now()
+	err := ar.db.QueryRow(ctx, sql, tokenHash).Scan(&data.ID, &data.UserID, &data.Scopes)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.APITokenAuthModel{}, huma.Error401Unauthorized("Invalid or expired API token")
+		}
+		observability.RecordError("database")
+		return models.APITokenAuthModel{}, huma.Error500InternalServerError("Unable to query API token", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "api_tokens", time.Since(queryStart).Seconds())
+
+	return data, nil
+}
@@ -0,0 +1,185 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+)
+
+// auditOwnerSQL looks up the owner of an audited entity from its ID bound to $4
+// Entries of entities missing here, or whose row is gone, belong to the actor
+var auditOwnerSQL = map[string]string{
+	constants.EntityAccount:             `SELECT user_id FROM accounts WHERE id = $4`,
+	constants.EntityCategory:            `SELECT user_id FROM categories WHERE id = $4`,
+	constants.EntityTag:                 `SELECT user_id FROM tags WHERE id = $4`,
+	constants.EntityTransaction:         `SELECT user_id FROM transactions WHERE id = $4`,
+	constants.EntityTransactionTag:      `SELECT user_id FROM transactions WHERE id = $4`,
+	constants.EntityTransactionRelation: `SELECT t.user_id FROM transaction_relations tr JOIN transactions t ON t.id = tr.source_transaction_id WHERE tr.id = $4`,
+	constants.EntityTransactionTemplate: `SELECT user_id FROM transaction_templates WHERE id = $4`,
+	constants.EntityBudgetTemplate:      `SELECT user_id FROM budget_templates WHERE id = $4`,
+	constants.EntityBudget:              `SELECT user_id FROM budgets WHERE id = $4`,
+	constants.EntityShare:               `SELECT owner_id FROM shares WHERE id = $4`,
+}
+
+type AuditRepository struct {
+	db DBQuerier
+}
+
+func NewAuditRepository(db DBQuerier) AuditRepository {
+	return AuditRepository{db}
+}
+
+// GetPaged lists audit entries of data owned by, or changes made by, the current user
+func (ar AuditRepository) GetPaged(ctx context.Context, query models.AuditLogsSearchModel) (models.AuditLogsPagedModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sortOrderMap := map[string]string{
+		"asc":  "ASC",
+		"desc": "DESC",
+	}
+	sortOrder := sortOrderMap[query.SortOrder]
+	offset := (query.PageNumber - 1) * query.PageSize
+
+	sql := `
+		WITH filtered_logs AS (
+			SELECT
+				al.id, al.actor_id, u.username, al.source, al.entity_type, al.entity_id,
+				al.operation, al.before, al.after, al.created_at,
+				COUNT(*) OVER() as total_count
+			FROM audit_logs al
+			LEFT JOIN users u ON u.id = al.actor_id
+			WHERE (al.user_id = $1 OR al.actor_id = $1)
+				AND ($2::text[] IS NULL OR al.entity_type = ANY($2::text[]))
+				AND ($3::bigint = 0 OR al.entity_id = $3)
+				AND ($4::bigint = 0 OR al.actor_id = $4)
+				AND ($5::text[] IS NULL OR al.operation = ANY($5::text[]))
+				AND ($6::timestamptz IS NULL OR al.created_at >= $6::timestamptz)
+				AND ($7::timestamptz IS NULL OR al.created_at <= $7::timestamptz)
+			ORDER BY al.created_at ` + sortOrder + `, al.id ` + sortOrder + `
+			LIMIT $8 OFFSET $9
+		)
+		SELECT
+			id, actor_id, username, source, entity_type, entity_id,
+			operation, before, after, created_at, total_count
+		FROM filtered_logs
+		ORDER BY created_at ` + sortOrder + `, id ` + sortOrder
+
+	var entityTypes, operations []string
+	if len(query.EntityType) > 0 {
+		entityTypes = query.EntityType
+	}
+	if len(query.Operation) > 0 {
+		operations = query.Operation
+	}
+	var startDate, endDate *string
+	if query.StartDate != "" {
+		startDate = &query.StartDate
+	}
+	if query.EndDate != "" {
+		endDate = &query.EndDate
+	}
+
+	queryStart := time.Now()
+	rows, err := ar.db.Query(ctx, sql,
+		common.GetUserID(ctx), entityTypes, query.EntityID, query.ActorID, operations,
+		startDate, endDate, query.PageSize, offset)
+	if err != nil {
+		observability.RecordError("database")
+		return models.AuditLogsPagedModel{}, huma.Error500InternalServerError("Unable to query audit logs", err)
+	}
+	defer rows.Close()
+	observability.RecordQueryDuration("SELECT", "audit_logs", time.Since(queryStart).Seconds())
+
+	items := []models.AuditLogModel{}
+	var totalCount int
+	for rows.Next() {
+		var item models.AuditLogModel
+		var before, after []byte
+		if err := rows.Scan(&item.ID, &item.ActorID, &item.ActorUsername, &item.Source, &item.EntityType, &item.EntityID,
+			&item.Operation, &before, &after, &item.CreatedAt, &totalCount); err != nil {
+			return models.AuditLogsPagedModel{}, huma.Error500InternalServerError("Unable to scan audit log data", err)
+		}
+		if before != nil {
+			if err := json.Unmarshal(before, &item.Before); err != nil {
+				return models.AuditLogsPagedModel{}, huma.Error500InternalServerError("Unable to decode audit log snapshot", err)
+			}
+		}
+		if after != nil {
+			if err := json.Unmarshal(after, &item.After); err != nil {
+				return models.AuditLogsPagedModel{}, huma.Error500InternalServerError("Unable to decode audit log snapshot", err)
+			}
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.AuditLogsPagedModel{}, huma.Error500InternalServerError("Error reading audit log rows", err)
+	}
+
+	totalPages := 0
+	if totalCount > 0 {
+		totalPages = (totalCount + query.PageSize - 1) / query.PageSize
+	}
+
+	return models.AuditLogsPagedModel{
+		Items:      items,
+		PageNumber: query.PageNumber,
+		PageSize:   query.PageSize,
+		TotalCount: totalCount,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// Create appends an entry made by the current user
+// It runs on the repository's querier, so entries written inside a database transaction commit or roll back with the change
+func (ar AuditRepository) Create(ctx context.Context, p models.CreateAuditLogModel) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	before, err := marshalAuditSnapshot(p.Before)
+	if err != nil {
+		return huma.Error500InternalServerError("Unable to encode audit log snapshot", err)
+	}
+	after, err := marshalAuditSnapshot(p.After)
+	if err != nil {
+		return huma.Error500InternalServerError("Unable to encode audit log snapshot", err)
+	}
+
+	owner := `$1::bigint`
+	if ownerSQL, ok := auditOwnerSQL[p.EntityType]; ok {
+		owner = `COALESCE((` + ownerSQL + `), $1::bigint)`
+	}
+
+	sql := `
+		INSERT INTO audit_logs (user_id, actor_id, source, entity_type, entity_id, operation, before, after)
+		VALUES (` + owner + `, $1, $2, $3, $4, $5, $6, $7)`
+
+	var actorID *int64
+	if userID := common.GetUserID(ctx); userID != 0 {
+		actorID = &userID
+	}
+
+	queryStart := time.Now()
+	if _, err := ar.db.Exec(ctx, sql, actorID, common.GetAuditSource(ctx), p.EntityType, p.EntityID, p.Operation, before, after); err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to write audit log", err)
+	}
+	observability.RecordQueryDuration("INSERT", "audit_logs", time.Since(queryStart).Seconds())
+
+	return nil
+}
+
+// marshalAuditSnapshot encodes a snapshot as JSON, keeping a missing one as NULL
+func marshalAuditSnapshot(snapshot any) ([]byte, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+	return json.Marshal(snapshot)
+}
@@ -2,11 +2,14 @@ package repositories
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"os"
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/dimasbaguspm/spenicle-api/internal/configs"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -19,13 +22,14 @@ func NewAuthRepository(ctx context.Context) AuthRepository {
 	return AuthRepository{ctx: ctx}
 }
 
-func (aR AuthRepository) Login(d models.LoginRequestModel) (models.LoginResponseModel, error) {
-	aTk, err := aR.createToken("access", time.Now().Add(7*24*time.Hour))
+// Login issues an access and refresh token pair for the authenticated user, bound to their session
+func (aR AuthRepository) Login(userID int64, sessionID int64) (models.LoginResponseModel, error) {
+	aTk, err := aR.createToken(constants.AuthTokenTypeAccess, userID, sessionID, constants.AuthAccessTokenTTL)
 	if err != nil {
 		return models.LoginResponseModel{}, err
 	}
 
-	rTk, err := aR.createToken("refresh", time.Now().Add(30*24*time.Hour))
+	rTk, err := aR.createToken(constants.AuthTokenTypeRefresh, userID, sessionID, constants.AuthRefreshTokenTTL)
 	if err != nil {
 		return models.LoginResponseModel{}, err
 	}
@@ -36,44 +40,91 @@ func (aR AuthRepository) Login(d models.LoginRequestModel) (models.LoginResponse
 	}, nil
 }
 
-func (aR AuthRepository) Refresh(d models.RefreshRequestModel) (models.RefreshResponseModel, error) {
-	_, err := aR.ParseToken(d.RefreshToken)
-	if err != nil {
-		return models.RefreshResponseModel{}, err
-	}
+// Challenge issues a short-lived token proving the password step of a two-factor login
+func (aR AuthRepository) Challenge(userID int64) (string, error) {
+	return aR.createToken(constants.AuthTokenTypeChallenge, userID, 0, constants.AuthChallengeTokenTTL)
+}
 
-	aTk, err := aR.createToken("access", time.Now().Add(7*24*time.Hour))
+// createToken signs a token of the given kind; the "sid" claim is left out when there is no session
+func (aR AuthRepository) createToken(sub string, userID int64, sessionID int64, ttl time.Duration) (string, error) {
+	jti, err := newTokenID()
 	if err != nil {
-		return models.RefreshResponseModel{}, err
+		return "", err
 	}
 
-	return models.RefreshResponseModel{
-		AccessToken: aTk,
-	}, nil
-
-}
-
-func (aR AuthRepository) createToken(sub string, time time.Time) (string, error) {
+	now := time.Now()
 	tokenClaims := jwt.MapClaims{
-		"exp": jwt.NewNumericDate(time),
-		"iat": jwt.NewNumericDate(time),
+		"exp": jwt.NewNumericDate(now.Add(ttl)),
+		"iat": jwt.NewNumericDate(now),
+		"jti": jti,
 		"sub": sub,
+		"uid": userID,
+	}
+	if sessionID != 0 {
+		tokenClaims["sid"] = sessionID
 	}
 	accessTokenObj := jwt.NewWithClaims(jwt.SigningMethodHS256, tokenClaims)
 	return accessTokenObj.SignedString([]byte(os.Getenv(configs.JWT_SECRET_ENV)))
 }
 
-func (aR AuthRepository) ParseToken(tk string) (bool, error) {
+// ParseToken validates the token signature, expiry and kind, and returns its claims
+// A refresh token is rejected where an access token is expected and vice versa
+func (aR AuthRepository) ParseToken(tk string, tokenType string) (models.AuthTokenClaimsModel, error) {
 	token, err := jwt.Parse(tk, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, huma.Error401Unauthorized("Invalid sign in method")
 		}
 		return []byte(os.Getenv(configs.JWT_SECRET_ENV)), nil
-	})
+	}, jwt.WithSubject(tokenType), jwt.WithIssuedAt(), jwt.WithExpirationRequired())
 
 	if err != nil || !token.Valid {
-		return false, huma.Error400BadRequest("Invalid or expired token")
+		return models.AuthTokenClaimsModel{}, huma.Error401Unauthorized("Invalid or expired token")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return models.AuthTokenClaimsModel{}, huma.Error401Unauthorized("Invalid token claims")
+	}
+
+	// JSON numbers decode as float64 in MapClaims
+	uid, ok := claims["uid"].(float64)
+	if !ok || uid <= 0 {
+		return models.AuthTokenClaimsModel{}, huma.Error401Unauthorized("Token is not bound to a user")
 	}
 
-	return true, nil
+	jti, ok := claims["jti"].(string)
+	if !ok || jti == "" {
+		return models.AuthTokenClaimsModel{}, huma.Error401Unauthorized("Token has no identifier")
+	}
+
+	iat, err := claims.GetIssuedAt()
+	if err != nil || iat == nil {
+		return models.AuthTokenClaimsModel{}, huma.Error401Unauthorized("Token has no issue time")
+	}
+
+	exp, err := claims.GetExpirationTime()
+	if err != nil || exp == nil {
+		return models.AuthTokenClaimsModel{}, huma.Error401Unauthorized("Token has no expiry")
+	}
+
+	// Tokens issued before sessions were recorded have no sid and stay valid until they expire
+	sid, _ := claims["sid"].(float64)
+
+	return models.AuthTokenClaimsModel{
+		TokenID:   jti,
+		Type:      tokenType,
+		UserID:    int64(uid),
+		SessionID: int64(sid),
+		IssuedAt:  iat.Time,
+		ExpiresAt: exp.Time,
+	}, nil
+}
+
+// newTokenID returns a random 128-bit identifier for the jti claim
+func newTokenID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", huma.Error500InternalServerError("Unable to generate token ID", err)
+	}
+	return hex.EncodeToString(bytes), nil
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
 	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
 	"github.com/dimasbaguspm/spenicle-api/internal/observability"
@@ -71,6 +72,7 @@ func (btr BudgetTemplateRepository) GetPaged(ctx context.Context, query models.B
 				AND (array_length($4::int8[], 1) IS NULL OR b.account_id = ANY($4::int8[]))
 				AND (array_length($5::int8[], 1) IS NULL OR b.category_id = ANY($5::int8[]))
 				AND ($6::text = '' OR b.recurrence = $6::text)
+				AND (b.user_id = $7 OR ` + sharedWithUserSQL(constants.EntityBudgetTemplate, "b.id", "$7") + `)
 			ORDER BY b.` + sortColumn + ` ` + sortOrder + `
 			LIMIT $1 OFFSET $2
 		)
@@ -112,7 +114,7 @@ func (btr BudgetTemplateRepository) GetPaged(ctx context.Context, query models.B
 	}
 
 	queryStart := time.Now()
-	rows, err := btr.db.Query(ctx, sql, query.PageSize, offset, ids, accountIDs, categoryIDs, query.Recurrence)
+	rows, err := btr.db.Query(ctx, sql, query.PageSize, offset, ids, accountIDs, categoryIDs, query.Recurrence, common.GetUserID(ctx))
 	if err != nil {
 		observability.RecordError("database")
 		return models.BudgetTemplatesPagedModel{}, huma.Error400BadRequest("Unable to query budget templates", err)
@@ -129,6 +131,7 @@ func (btr BudgetTemplateRepository) GetPaged(ctx context.Context, query models.B
 		if err != nil {
 			return models.BudgetTemplatesPagedModel{}, huma.Error400BadRequest("Unable to scan budget template data", err)
 		}
+		item.ETag = common.BuildETag(item.ID, item.CreatedAt, item.UpdatedAt)
 		items = append(items, item)
 	}
 
@@ -163,10 +166,11 @@ func (btr BudgetTemplateRepository) GetDetail(ctx context.Context, id int64) (mo
 		SELECT id, account_id, category_id, amount_limit, recurrence, start_date, end_date, name, active, next_run_at, last_executed_at, note, created_at, updated_at, deleted_at
 		FROM budget_templates
 		WHERE id = $1
+			AND (user_id = $2 OR ` + sharedWithUserSQL(constants.EntityBudgetTemplate, "budget_templates.id", "$2") + `)
 			AND deleted_at IS NULL`
 
 	queryStart := time.Now()
-	err := btr.db.QueryRow(ctx, query, id).Scan(
+	err := btr.db.QueryRow(ctx, query, id, common.GetUserID(ctx)).Scan(
 		&data.ID, &data.AccountID, &data.CategoryID, &data.AmountLimit, &data.Recurrence,
 		&data.StartDate, &data.EndDate, &data.Name, &data.Active, &data.NextRunAt, &data.LastExecutedAt, &data.Note, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt,
 	)
@@ -180,9 +184,39 @@ func (btr BudgetTemplateRepository) GetDetail(ctx context.Context, id int64) (mo
 	}
 	observability.RecordQueryDuration("SELECT", "budget_templates", time.Since(queryStart).Seconds())
 
+	data.ETag = common.BuildETag(data.ID, data.CreatedAt, data.UpdatedAt)
 	return data, nil
 }
 
+// GetDetailForUpdate locks the budget template until the surrounding database transaction ends, so its version cannot
+// change between checking If-Match and writing
+func (btr BudgetTemplateRepository) GetDetailForUpdate(ctx context.Context, id int64) (models.BudgetTemplateModel, error) {
+	lockCtx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	query := `
+		SELECT id
+		FROM budget_templates
+		WHERE id = $1
+			AND (user_id = $2 OR ` + sharedWithUserSQL(constants.EntityBudgetTemplate, "budget_templates.id", "$2") + `)
+			AND deleted_at IS NULL
+		FOR UPDATE`
+
+	queryStart := time.Now()
+	var ID int64
+	err := btr.db.QueryRow(lockCtx, query, id, common.GetUserID(ctx)).Scan(&ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.BudgetTemplateModel{}, huma.Error404NotFound("Budget template not found")
+	}
+	if err != nil {
+		observability.RecordError("database")
+		return models.BudgetTemplateModel{}, huma.Error500InternalServerError("Unable to lock budget template", err)
+	}
+	observability.RecordQueryDuration("SELECT", "budget_templates", time.Since(queryStart).Seconds())
+
+	return btr.GetDetail(ctx, ID)
+}
+
 func (btr BudgetTemplateRepository) Create(ctx context.Context, p models.CreateBudgetTemplateModel) (models.BudgetTemplateModel, error) {
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
@@ -195,8 +229,10 @@ func (btr BudgetTemplateRepository) Create(ctx context.Context, p models.CreateB
 	}
 
 	query := `
-		INSERT INTO budget_templates (account_id, category_id, amount_limit, recurrence, start_date, end_date, name, active, next_run_at, note)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO budget_templates (account_id, category_id, amount_limit, recurrence, start_date, end_date, name, active, next_run_at, note, user_id)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+		WHERE ($1::int8 IS NULL OR EXISTS (SELECT 1 FROM accounts WHERE id = $1 AND user_id = $11 AND deleted_at IS NULL))
+			AND ($2::int8 IS NULL OR EXISTS (SELECT 1 FROM categories WHERE id = $2 AND user_id = $11 AND deleted_at IS NULL))
 		RETURNING id`
 
 	queryStart := time.Now()
@@ -213,8 +249,12 @@ func (btr BudgetTemplateRepository) Create(ctx context.Context, p models.CreateB
 		p.Active,
 		nextRunAt,
 		p.Note,
+		common.GetUserID(ctx),
 	).Scan(&ID)
 
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.BudgetTemplateModel{}, huma.Error404NotFound("Account or category not found")
+	}
 	if err != nil {
 		observability.RecordError("database")
 		return models.BudgetTemplateModel{}, huma.Error500InternalServerError("Unable to create budget template", err)
@@ -236,7 +276,9 @@ func (btr BudgetTemplateRepository) Update(ctx context.Context, id int64, p mode
 		    active = COALESCE($3, active),
 		    amount_limit = COALESCE($4, amount_limit),
 		    updated_at = CURRENT_TIMESTAMP
-		WHERE id = $5 AND deleted_at IS NULL
+		WHERE id = $5
+			AND (user_id = $6 OR ` + sharedWithUserSQL(constants.EntityBudgetTemplate, "budget_templates.id", "$6", constants.ShareRoleEditor) + `)
+			AND deleted_at IS NULL
 		RETURNING id`
 
 	queryStart := time.Now()
@@ -248,6 +290,7 @@ func (btr BudgetTemplateRepository) Update(ctx context.Context, id int64, p mode
 		p.Active,
 		p.AmountLimit,
 		id,
+		common.GetUserID(ctx),
 	).Scan(&ID)
 
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -266,6 +309,8 @@ func (btr BudgetTemplateRepository) Delete(ctx context.Context, id int64) error
 	return huma.Error405MethodNotAllowed("Budget templates cannot be deleted. Use PATCH to deactivate if you want to pause budget generation.")
 }
 
+// GetDueTemplates returns due templates across all users; each item carries its owner's UserID
+// so the worker can act on the owner's behalf
 func (btr BudgetTemplateRepository) GetDueTemplates(ctx context.Context) ([]models.BudgetTemplateModel, error) {
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
@@ -274,6 +319,7 @@ func (btr BudgetTemplateRepository) GetDueTemplates(ctx context.Context) ([]mode
 		WITH active_templates AS (
 			SELECT
 				id,
+				user_id,
 				account_id,
 				category_id,
 				amount_limit,
@@ -289,6 +335,7 @@ func (btr BudgetTemplateRepository) GetDueTemplates(ctx context.Context) ([]mode
 				deleted_at
 			FROM budget_templates
 			WHERE deleted_at IS NULL
+				AND user_id IS NOT NULL
 				AND active = true
 				AND recurrence != 'none'
 				AND start_date <= CURRENT_DATE
@@ -301,6 +348,7 @@ func (btr BudgetTemplateRepository) GetDueTemplates(ctx context.Context) ([]mode
 		)
 		SELECT
 			id,
+			user_id,
 			account_id,
 			category_id,
 			amount_limit,
@@ -330,7 +378,7 @@ func (btr BudgetTemplateRepository) GetDueTemplates(ctx context.Context) ([]mode
 	for rows.Next() {
 		var item models.BudgetTemplateModel
 		if err := rows.Scan(
-			&item.ID, &item.AccountID, &item.CategoryID, &item.AmountLimit, &item.Recurrence,
+			&item.ID, &item.UserID, &item.AccountID, &item.CategoryID, &item.AmountLimit, &item.Recurrence,
 			&item.StartDate, &item.EndDate, &item.Name, &item.NextRunAt, &item.LastExecutedAt, &item.Note, &item.CreatedAt, &item.UpdatedAt, &item.DeletedAt,
 		); err != nil {
 			return nil, huma.Error500InternalServerError("Unable to scan due budget template data", err)
@@ -363,9 +411,9 @@ func (btr BudgetTemplateRepository) UpdateLastExecuted(ctx context.Context, id i
 		        ELSE next_run_at
 		    END,
 		    updated_at = NOW()
-		WHERE id = $1 AND deleted_at IS NULL`
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`
 
-	_, err := btr.db.Exec(ctx, sql, id)
+	_, err := btr.db.Exec(ctx, sql, id, common.GetUserID(ctx))
 	if err != nil {
 		return huma.Error500InternalServerError("Unable to update budget template execution time", err)
 	}
@@ -386,15 +434,16 @@ func (btr BudgetTemplateRepository) ValidateUniqueBudgetTemplate(
 		SELECT COUNT(1)
 		FROM budget_templates
 		WHERE deleted_at IS NULL
+			AND user_id = $3
 			AND (
 				(account_id IS NOT DISTINCT FROM $1 AND $1 IS NOT NULL)
 				OR (category_id IS NOT DISTINCT FROM $2 AND $2 IS NOT NULL)
 			)`
 
-	args := []interface{}{accountID, categoryID}
+	args := []interface{}{accountID, categoryID, common.GetUserID(ctx)}
 
 	if templateID != nil {
-		query += ` AND id != $3`
+		query += ` AND id != $4`
 		args = append(args, *templateID)
 	}
 
@@ -425,10 +474,11 @@ func (btr BudgetTemplateRepository) GetRelatedBudgets(ctx context.Context, templ
 		JOIN budget_template_relations r ON b.id = r.budget_id
 		WHERE b.deleted_at IS NULL
 			AND r.template_id = $1
+			AND b.user_id = $2
 		ORDER BY b.id
 	`
 
-	rows, err := btr.db.Query(ctx, sql, templateID)
+	rows, err := btr.db.Query(ctx, sql, templateID, common.GetUserID(ctx))
 	if err != nil {
 		return nil, huma.Error500InternalServerError("Unable to query related budget IDs", err)
 	}
@@ -454,9 +504,12 @@ func (btr BudgetTemplateRepository) CreateRelation(ctx context.Context, budgetID
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
 
-	sql := `INSERT INTO budget_template_relations (budget_id, template_id) VALUES ($1, $2) ON CONFLICT (budget_id) DO NOTHING`
+	sql := `INSERT INTO budget_template_relations (budget_id, template_id)
+			SELECT $1, $2
+			WHERE EXISTS (SELECT 1 FROM budget_templates WHERE id = $2 AND user_id = $3)
+			ON CONFLICT (budget_id) DO NOTHING`
 
-	_, err := btr.db.Exec(ctx, sql, budgetID, templateID)
+	_, err := btr.db.Exec(ctx, sql, budgetID, templateID, common.GetUserID(ctx))
 	if err != nil {
 		return huma.Error500InternalServerError("Unable to create budget template relation", err)
 	}
@@ -529,7 +582,7 @@ func (btr BudgetTemplateRepository) GetBudgetsPaged(ctx context.Context, query m
 				b.name,
 				COALESCE((
 					SELECT SUM(t.amount)
-					FROM transactions t
+					FROM transaction_category_amounts t
 					WHERE t.deleted_at IS NULL
 						AND t.date >= b.period_start
 						AND t.date <= b.period_end
@@ -549,6 +602,7 @@ func (btr BudgetTemplateRepository) GetBudgetsPaged(ctx context.Context, query m
 					AND (array_length($6::int8[], 1) IS NULL OR b.category_id = ANY($6::int8[]))
 					AND ($7::text IS NULL OR $7::text = '' OR b.status = $7::text)
 					AND ($8::text IS NULL OR $8::text = '' OR b.name ILIKE '%' || $8::text || '%')
+					AND b.user_id = $9
 			ORDER BY b.` + sortColumn + ` ` + sortOrder + `
 			LIMIT $1 OFFSET $2
 		)
@@ -594,7 +648,7 @@ func (btr BudgetTemplateRepository) GetBudgetsPaged(ctx context.Context, query m
 	}
 
 	queryStart := time.Now()
-	rows, err := btr.db.Query(ctx, sql, query.PageSize, offset, ids, templateIDs, accountIDs, categoryIDs, query.Status, query.Name)
+	rows, err := btr.db.Query(ctx, sql, query.PageSize, offset, ids, templateIDs, accountIDs, categoryIDs, query.Status, query.Name, common.GetUserID(ctx))
 	if err != nil {
 		observability.RecordError("database")
 		return models.BudgetsPagedModel{}, huma.Error400BadRequest("Unable to query budgets", err)
@@ -673,7 +727,7 @@ func (btr BudgetTemplateRepository) GetBudgetDetail(ctx context.Context, id int6
 			b.name,
 			COALESCE((
 				SELECT SUM(t.amount)
-				FROM transactions t
+				FROM transaction_category_amounts t
 				WHERE t.deleted_at IS NULL
 					AND t.date >= b.period_start
 					AND t.date <= b.period_end
@@ -686,10 +740,11 @@ func (btr BudgetTemplateRepository) GetBudgetDetail(ctx context.Context, id int6
 			b.deleted_at
 		FROM budgets b
 		WHERE b.id = $1
+			AND b.user_id = $2
 			AND b.deleted_at IS NULL`
 
 	queryStart := time.Now()
-	err := btr.db.QueryRow(ctx, query, id).Scan(
+	err := btr.db.QueryRow(ctx, query, id, common.GetUserID(ctx)).Scan(
 		&data.ID, &data.TemplateID, &data.AccountID, &data.CategoryID, &data.PeriodStart, &data.PeriodEnd,
 		&data.AmountLimit, &data.Status, &data.PeriodType, &data.Name, &data.ActualAmount, &data.Note, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt,
 	)
@@ -742,8 +797,9 @@ func (btr BudgetTemplateRepository) CreateBudget(ctx context.Context, p models.C
 			status,
 			period_type,
 			name,
-			note)
-		VALUES ($1, $2, $3, $4, $5, $6, 'active', $7, $8, $9)
+			note,
+			user_id)
+		VALUES ($1, $2, $3, $4, $5, $6, 'active', $7, $8, $9, $10)
 		RETURNING id`
 
 	var ID int64
@@ -760,6 +816,7 @@ func (btr BudgetTemplateRepository) CreateBudget(ctx context.Context, p models.C
 		periodType,
 		p.Name,
 		p.Note,
+		common.GetUserID(ctx),
 	).Scan(&ID)
 	observability.RecordQueryDuration("INSERT", "budgets", time.Since(queryStart).Seconds())
 
@@ -823,7 +880,7 @@ func (btr BudgetTemplateRepository) UpdateBudget(ctx context.Context, id int64,
 		    name = COALESCE($6, name),
 		    note = COALESCE($7, note),
 		    updated_at = CURRENT_TIMESTAMP
-		WHERE id = $8 AND deleted_at IS NULL
+		WHERE id = $8 AND user_id = $9 AND deleted_at IS NULL
 		RETURNING id`
 
 	var ID int64
@@ -839,6 +896,7 @@ func (btr BudgetTemplateRepository) UpdateBudget(ctx context.Context, id int64,
 		p.Name,
 		p.Note,
 		id,
+		common.GetUserID(ctx),
 	).Scan(&ID)
 	observability.RecordQueryDuration("UPDATE", "budgets", time.Since(queryStart).Seconds())
 
@@ -857,10 +915,10 @@ func (btr BudgetTemplateRepository) DeleteBudget(ctx context.Context, id int64)
 	sql := `
 		UPDATE budgets
 		SET deleted_at = CURRENT_TIMESTAMP
-		WHERE id = $1 AND deleted_at IS NULL`
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`
 
 	queryStart := time.Now()
-	cmdTag, err := btr.db.Exec(ctx, sql, id)
+	cmdTag, err := btr.db.Exec(ctx, sql, id, common.GetUserID(ctx))
 	observability.RecordQueryDuration("DELETE", "budgets", time.Since(queryStart).Seconds())
 	if err != nil {
 		observability.RecordError("database")
@@ -885,10 +943,11 @@ func (btr BudgetTemplateRepository) validateUniqueActiveBudget(ctx context.Conte
 			AND status = 'active'
 			AND account_id IS NOT DISTINCT FROM $1
 			AND category_id IS NOT DISTINCT FROM $2
-			AND period_type = $3`
+			AND period_type = $3
+			AND user_id = $4`
 
 	var count int
-	err := btr.db.QueryRow(ctx, query, accountID, categoryID, periodType).Scan(&count)
+	err := btr.db.QueryRow(ctx, query, accountID, categoryID, periodType, common.GetUserID(ctx)).Scan(&count)
 	if err != nil {
 		return huma.Error500InternalServerError("Unable to validate budget uniqueness", err)
 	}
@@ -900,7 +959,7 @@ func (btr BudgetTemplateRepository) validateUniqueActiveBudget(ctx context.Conte
 	return nil
 }
 
-func (btr BudgetTemplateRepository) DeactivateExistingActiveBudgets(ctx context.Context, accountID, categoryID *int64, periodType string) error {
+func (btr BudgetTemplateRepository) DeactivateExistingActiveBudgets(ctx context.Context, accountID, categoryID *int64, periodType string) ([]int64, error) {
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
 
@@ -911,15 +970,23 @@ func (btr BudgetTemplateRepository) DeactivateExistingActiveBudgets(ctx context.
 			AND status = 'active'
 			AND account_id IS NOT DISTINCT FROM $1
 			AND category_id IS NOT DISTINCT FROM $2
-			AND period_type = $3`
+			AND period_type = $3
+			AND user_id = $4
+		RETURNING id`
 
 	queryStart := time.Now()
-	_, err := btr.db.Exec(ctx, query, accountID, categoryID, periodType)
+	rows, err := btr.db.Query(ctx, query, accountID, categoryID, periodType, common.GetUserID(ctx))
 	observability.RecordQueryDuration("UPDATE", "budgets", time.Since(queryStart).Seconds())
 	if err != nil {
 		observability.RecordError("database")
-		return huma.Error500InternalServerError("Unable to deactivate budgets", err)
+		return nil, huma.Error500InternalServerError("Unable to deactivate budgets", err)
 	}
 
-	return nil
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		observability.RecordError("database")
+		return nil, huma.Error500InternalServerError("Unable to deactivate budgets", err)
+	}
+
+	return ids, nil
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
 	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
 	"github.com/dimasbaguspm/spenicle-api/internal/observability"
@@ -41,7 +42,7 @@ func (cr CategoryRepository) GetPaged(ctx context.Context, query models.Categori
 		WITH ranked_budgets AS (
 			SELECT
 				b.*,
-				COALESCE((SELECT SUM(t.amount) FROM transactions t WHERE t.category_id = b.category_id AND t.date >= b.period_start AND t.date <= b.period_end AND t.deleted_at IS NULL), 0) as actual_amount,
+				COALESCE((SELECT SUM(t.amount) FROM transaction_category_amounts t WHERE t.category_id = b.category_id AND t.date >= b.period_start AND t.date <= b.period_end AND t.deleted_at IS NULL), 0) as actual_amount,
 				ROW_NUMBER() OVER (PARTITION BY b.category_id ORDER BY b.id DESC) as rn
 			FROM budgets b
 			WHERE b.status = 'active'
@@ -59,6 +60,7 @@ func (cr CategoryRepository) GetPaged(ctx context.Context, query models.Categori
 			FROM categories c
 			LEFT JOIN ranked_budgets b ON b.category_id = c.id AND b.rn = 1
 			WHERE c.deleted_at IS NULL
+					AND c.user_id = $7
 					AND (array_length($3::int8[], 1) IS NULL OR c.id = ANY($3::int8[]))
 					AND ($5::text IS NULL OR $5::text = '' OR c.name ILIKE '%' || $5::text || '%')
 					AND (array_length($4::text[], 1) IS NULL OR c.type = ANY($4::text[]))
@@ -110,7 +112,7 @@ func (cr CategoryRepository) GetPaged(ctx context.Context, query models.Categori
 		types = query.Type
 	}
 
-	rows, err := cr.db.Query(ctx, sql, query.PageSize, offset, ids, types, query.Name, query.Archived)
+	rows, err := cr.db.Query(ctx, sql, query.PageSize, offset, ids, types, query.Name, query.Archived, common.GetUserID(ctx))
 	if err != nil {
 		observability.RecordError("database")
 		return models.CategoriesPagedModel{}, huma.Error500InternalServerError("Unable to query categories", err)
@@ -228,7 +230,7 @@ func (cr CategoryRepository) GetDetail(ctx context.Context, id int64) (models.Ca
 				b.amount_limit,
 				b.account_id,
 				b.category_id,
-				COALESCE((SELECT SUM(t.amount) FROM transactions t WHERE t.category_id = c.id AND t.date >= b.period_start AND t.date <= b.period_end AND t.deleted_at IS NULL), 0) as actual_amount,
+				COALESCE((SELECT SUM(t.amount) FROM transaction_category_amounts t WHERE t.category_id = c.id AND t.date >= b.period_start AND t.date <= b.period_end AND t.deleted_at IS NULL), 0) as actual_amount,
 				b.period_type,
 				b.name as budget_name
 			FROM budgets b
@@ -240,10 +242,10 @@ func (cr CategoryRepository) GetDetail(ctx context.Context, id int64) (models.Ca
 			ORDER BY b.id DESC
 			LIMIT 1
 		) b ON true
-		WHERE c.id = $1 AND c.deleted_at IS NULL
+		WHERE c.id = $1 AND c.user_id = $2 AND c.deleted_at IS NULL
 	`
 	queryStart := time.Now()
-	err := cr.db.QueryRow(ctx, sql, id).Scan(&data.ID, &data.Name, &data.Type, &data.Note, &data.Icon, &data.IconColor, &data.DisplayOrder, &data.ArchivedAt, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt, &budgetID, &periodStart, &periodEnd, &templateID, &amountLimit, &accountID, &categoryID, &actualAmount, &periodType, &budgetName)
+	err := cr.db.QueryRow(ctx, sql, id, common.GetUserID(ctx)).Scan(&data.ID, &data.Name, &data.Type, &data.Note, &data.Icon, &data.IconColor, &data.DisplayOrder, &data.ArchivedAt, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt, &budgetID, &periodStart, &periodEnd, &templateID, &amountLimit, &accountID, &categoryID, &actualAmount, &periodType, &budgetName)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -280,8 +282,8 @@ func (cr CategoryRepository) Create(ctx context.Context, payload models.CreateCa
 
 	sql := `
 		INSERT INTO categories
-			(name, type, note, icon, icon_color, display_order)
-		VALUES ($1, $2, $3, $4, $5, COALESCE((SELECT MAX(display_order) + 1 FROM categories WHERE deleted_at IS NULL), 0))
+			(name, type, note, icon, icon_color, user_id, display_order)
+		VALUES ($1, $2, $3, $4, $5, $6, COALESCE((SELECT MAX(display_order) + 1 FROM categories WHERE user_id = $6 AND deleted_at IS NULL), 0))
 		RETURNING id
 	`
 	queryStart := time.Now()
@@ -290,7 +292,8 @@ func (cr CategoryRepository) Create(ctx context.Context, payload models.CreateCa
 		payload.Type,
 		payload.Note,
 		payload.Icon,
-		payload.IconColor).Scan(&ID)
+		payload.IconColor,
+		common.GetUserID(ctx)).Scan(&ID)
 
 	if err != nil {
 		observability.RecordError("database")
@@ -320,7 +323,7 @@ func (cr CategoryRepository) Update(ctx context.Context, id int64, payload model
 				ELSE archived_at
 			END,
 			updated_at = CURRENT_TIMESTAMP
-		WHERE id = $7 AND deleted_at IS NULL
+		WHERE id = $7 AND user_id = $8 AND deleted_at IS NULL
 		RETURNING id
 	`
 	queryStart := time.Now()
@@ -333,7 +336,8 @@ func (cr CategoryRepository) Update(ctx context.Context, id int64, payload model
 		payload.Icon,
 		payload.IconColor,
 		payload.ArchivedAt,
-		id).Scan(&ID)
+		id,
+		common.GetUserID(ctx)).Scan(&ID)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -355,10 +359,11 @@ func (cr CategoryRepository) Delete(ctx context.Context, id int64) error {
 		UPDATE categories
 		SET deleted_at = CURRENT_TIMESTAMP
 		WHERE id = $1
+			AND user_id = $2
 			AND deleted_at IS NULL
 	`
 	queryStart := time.Now()
-	cmdTag, err := cr.db.Exec(ctx, sql, id)
+	cmdTag, err := cr.db.Exec(ctx, sql, id, common.GetUserID(ctx))
 	if err != nil {
 		observability.RecordError("database")
 		return huma.Error500InternalServerError("Unable to delete category", err)
@@ -371,6 +376,34 @@ func (cr CategoryRepository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// Restore brings a deleted category back from the trash at the end of the display order
+func (cr CategoryRepository) Restore(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		UPDATE categories
+		SET deleted_at = NULL,
+			updated_at = CURRENT_TIMESTAMP,
+			display_order = COALESCE((SELECT MAX(display_order) + 1 FROM categories WHERE user_id = $2 AND deleted_at IS NULL), 0)
+		WHERE id = $1
+			AND user_id = $2
+			AND deleted_at IS NOT NULL
+	`
+	queryStart := time.Now()
+	cmdTag, err := cr.db.Exec(ctx, sql, id, common.GetUserID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to restore category", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return huma.Error404NotFound("Deleted category not found")
+	}
+	observability.RecordQueryDuration("UPDATE", "categories", time.Since(queryStart).Seconds())
+
+	return nil
+}
+
 func (cr CategoryRepository) Reorder(ctx context.Context, ids []int64) error {
 	if len(ids) == 0 {
 		return nil
@@ -387,10 +420,11 @@ func (cr CategoryRepository) Reorder(ctx context.Context, ids []int64) error {
                 FROM unnest($1::int8[]) WITH ORDINALITY AS t(id, ord)
             ) v
             WHERE categories.id = v.id
+                AND categories.user_id = $2
                 AND deleted_at IS NULL`
 
 	queryStart := time.Now()
-	_, err := cr.db.Exec(ctx, sql, ids)
+	_, err := cr.db.Exec(ctx, sql, ids, common.GetUserID(ctx))
 	observability.RecordQueryDuration("UPDATE", "categories", time.Since(queryStart).Seconds())
 	if err != nil {
 		observability.RecordError("database")
@@ -409,9 +443,11 @@ func (cr CategoryRepository) ValidateIDsExist(ctx context.Context, ids []int64)
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
 
+	userID := common.GetUserID(ctx)
+
 	var matched int
-	sql := `SELECT COUNT(1) FROM categories WHERE id = ANY($1::int8[]) AND deleted_at IS NULL`
-	if err := cr.db.QueryRow(ctx, sql, ids).Scan(&matched); err != nil {
+	sql := `SELECT COUNT(1) FROM categories WHERE id = ANY($1::int8[]) AND user_id = $2 AND deleted_at IS NULL`
+	if err := cr.db.QueryRow(ctx, sql, ids, userID).Scan(&matched); err != nil {
 		return huma.Error500InternalServerError("Unable to validate categories", err)
 	}
 	if matched != len(ids) {
@@ -419,7 +455,7 @@ func (cr CategoryRepository) ValidateIDsExist(ctx context.Context, ids []int64)
 	}
 
 	var totalActive int
-	if err := cr.db.QueryRow(ctx, `SELECT COUNT(1) FROM categories WHERE deleted_at IS NULL`).Scan(&totalActive); err != nil {
+	if err := cr.db.QueryRow(ctx, `SELECT COUNT(1) FROM categories WHERE user_id = $1 AND deleted_at IS NULL`, userID).Scan(&totalActive); err != nil {
 		return huma.Error500InternalServerError("Unable to validate category count", err)
 	}
 	if totalActive != len(ids) {
@@ -436,7 +472,7 @@ func (cr CategoryRepository) GetActiveIDsOrdered(ctx context.Context) ([]int64,
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
 
-	rows, err := cr.db.Query(ctx, `SELECT id FROM categories WHERE deleted_at IS NULL ORDER BY display_order ASC, id ASC`)
+	rows, err := cr.db.Query(ctx, `SELECT id FROM categories WHERE user_id = $1 AND deleted_at IS NULL ORDER BY display_order ASC, id ASC`, common.GetUserID(ctx))
 	if err != nil {
 		return nil, huma.Error500InternalServerError("Unable to query category ids", err)
 	}
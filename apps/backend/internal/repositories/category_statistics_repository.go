@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
 	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
 	"github.com/dimasbaguspm/spenicle-api/internal/observability"
@@ -21,6 +22,20 @@ func NewCategoryStatisticsRepository(db DBQuerier) CategoryStatisticsRepository
 	return CategoryStatisticsRepository{db}
 }
 
+// ensureOwned verifies the category belongs to the user on the context before computing statistics
+func (sr CategoryStatisticsRepository) ensureOwned(ctx context.Context, categoryID int64) error {
+	var exists bool
+	sql := `SELECT EXISTS (SELECT 1 FROM categories WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL)`
+	if err := sr.db.QueryRow(ctx, sql, categoryID, common.GetUserID(ctx)).Scan(&exists); err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to query category", err)
+	}
+	if !exists {
+		return huma.Error404NotFound("Category not found")
+	}
+	return nil
+}
+
 // calculatePercentage returns percentage as float64, handling division by zero
 func calculatePercentage(part, total int64) float64 {
 	if total == 0 {
@@ -34,6 +49,10 @@ func (sr CategoryStatisticsRepository) GetSpendingVelocity(ctx context.Context,
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
 
+	if err := sr.ensureOwned(ctx, categoryID); err != nil {
+		return models.CategoryStatisticSpendingVelocityModel{}, err
+	}
+
 	sql := `
 		WITH transaction_impacts AS (
 			SELECT
@@ -45,7 +64,7 @@ func (sr CategoryStatisticsRepository) GetSpendingVelocity(ctx context.Context,
 					WHEN type = 'expense' THEN -amount
 					ELSE 0
 				END as net_impact
-			FROM transactions
+			FROM transaction_category_amounts
 			WHERE category_id = $1
 				AND deleted_at IS NULL
 				AND date >= $2::timestamptz
@@ -145,6 +164,10 @@ func (sr CategoryStatisticsRepository) GetAccountDistribution(ctx context.Contex
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
 
+	if err := sr.ensureOwned(ctx, categoryID); err != nil {
+		return models.CategoryStatisticAccountDistributionModel{}, err
+	}
+
 	sql := `
 		WITH account_spending AS (
 			SELECT
@@ -152,7 +175,7 @@ func (sr CategoryStatisticsRepository) GetAccountDistribution(ctx context.Contex
 				a.name as account_name,
 				COALESCE(SUM(t.amount), 0) as total_amount
 			FROM accounts a
-			LEFT JOIN transactions t ON t.account_id = a.id
+			LEFT JOIN transaction_category_amounts t ON t.account_id = a.id
 				AND t.category_id = $1
 				AND t.deleted_at IS NULL
 				AND t.type = 'expense'
@@ -215,6 +238,10 @@ func (sr CategoryStatisticsRepository) GetAverageTransactionSize(ctx context.Con
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
 
+	if err := sr.ensureOwned(ctx, categoryID); err != nil {
+		return models.CategoryStatisticAverageTransactionSizeModel{}, err
+	}
+
 	sql := `
 		SELECT
 			COUNT(*) FILTER (WHERE type = 'expense') as expense_count,
@@ -227,7 +254,7 @@ func (sr CategoryStatisticsRepository) GetAverageTransactionSize(ctx context.Con
 			COALESCE(MIN(amount) FILTER (WHERE type = 'income'), 0) as min_income,
 			COALESCE(MAX(amount) FILTER (WHERE type = 'income'), 0) as max_income,
 			COALESCE(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY amount) FILTER (WHERE type = 'income'), 0)::bigint as median_income
-		FROM transactions t
+		FROM transaction_category_amounts t
 		WHERE t.category_id = $1
 			AND t.deleted_at IS NULL
 			AND t.date >= $2::timestamptz
@@ -275,6 +302,10 @@ func (sr CategoryStatisticsRepository) GetDayOfWeekPattern(ctx context.Context,
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
 
+	if err := sr.ensureOwned(ctx, categoryID); err != nil {
+		return models.CategoryStatisticDayOfWeekPatternModel{}, err
+	}
+
 	sql := `
 		WITH daily_spending AS (
 			SELECT
@@ -286,7 +317,7 @@ func (sr CategoryStatisticsRepository) GetDayOfWeekPattern(ctx context.Context,
 				COALESCE(SUM(amount) FILTER (WHERE type = 'income'), 0) as income_total,
 				COALESCE(AVG(amount) FILTER (WHERE type = 'expense'), 0)::bigint as expense_avg,
 				COALESCE(AVG(amount) FILTER (WHERE type = 'income'), 0)::bigint as income_avg
-			FROM transactions t
+			FROM transaction_category_amounts t
 			WHERE t.category_id = $1
 				AND t.deleted_at IS NULL
 				AND t.date >= $2::timestamptz
@@ -377,6 +408,10 @@ func (sr CategoryStatisticsRepository) GetBudgetUtilization(ctx context.Context,
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
 
+	if err := sr.ensureOwned(ctx, categoryID); err != nil {
+		return models.CategoryStatisticBudgetUtilizationModel{}, err
+	}
+
 	// Get active budgets for this category
 	sql := `
 		SELECT
@@ -387,7 +422,7 @@ func (sr CategoryStatisticsRepository) GetBudgetUtilization(ctx context.Context,
 			b.period_end,
 			COALESCE(SUM(t.amount), 0) as spent_amount
 		FROM budgets b
-		LEFT JOIN transactions t ON t.category_id = b.category_id
+		LEFT JOIN transaction_category_amounts t ON t.category_id = b.category_id
 			AND (b.account_id IS NULL OR t.account_id = b.account_id)
 			AND t.type = 'expense'
 			AND t.deleted_at IS NULL
@@ -0,0 +1,272 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/jackc/pgx/v5"
+)
+
+type PayeeRepository struct {
+	db DBQuerier
+}
+
+func NewPayeeRepository(db DBQuerier) PayeeRepository {
+	return PayeeRepository{db}
+}
+
+func (pr PayeeRepository) GetPaged(ctx context.Context, query models.PayeesSearchModel) (models.PayeesPagedModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sortByMap := map[string]string{
+		"id":        "id",
+		"name":      "name",
+		"createdAt": "created_at",
+		"updatedAt": "updated_at",
+	}
+	sortOrderMap := map[string]string{
+		"asc":  "ASC",
+		"desc": "DESC",
+	}
+
+	sortColumn := sortByMap[query.SortBy]
+	sortOrder := sortOrderMap[query.SortOrder]
+	offset := (query.PageNumber - 1) * query.PageSize
+
+	sql := `
+		WITH filtered_payees AS (
+			SELECT
+				id, name, aliases, created_at, updated_at, deleted_at,
+				COUNT(*) OVER() as total_count
+			FROM payees
+			WHERE deleted_at IS NULL
+				AND user_id = $4
+				AND ($1::text IS NULL OR $1::text = ''
+					OR name ILIKE '%' || $1::text || '%'
+					OR EXISTS (SELECT 1 FROM unnest(aliases) alias WHERE alias ILIKE '%' || $1::text || '%'))
+			ORDER BY ` + sortColumn + ` ` + sortOrder + `
+			LIMIT $2 OFFSET $3
+		)
+		SELECT
+			id,
+			name,
+			aliases,
+			created_at,
+			updated_at,
+			deleted_at,
+			total_count
+		FROM filtered_payees
+		ORDER BY ` + sortColumn + ` ` + sortOrder + `
+		`
+
+	queryStart := time.Now()
+	rows, err := pr.db.Query(ctx, sql, query.Name, query.PageSize, offset, common.GetUserID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return models.PayeesPagedModel{}, huma.Error400BadRequest("Unable to query payees", err)
+	}
+	defer rows.Close()
+	observability.RecordQueryDuration("SELECT", "payees", time.Since(queryStart).Seconds())
+
+	var items []models.PayeeModel
+	var totalCount int
+	for rows.Next() {
+		var item models.PayeeModel
+		err := rows.Scan(&item.ID, &item.Name, &item.Aliases, &item.CreatedAt, &item.UpdatedAt, &item.DeletedAt, &totalCount)
+		if err != nil {
+			return models.PayeesPagedModel{}, huma.Error400BadRequest("Unable to scan payee data", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.PayeesPagedModel{}, huma.Error400BadRequest("Error reading payee rows", err)
+	}
+
+	if items == nil {
+		items = []models.PayeeModel{}
+	}
+
+	totalPages := 0
+	if totalCount > 0 {
+		totalPages = (totalCount + query.PageSize - 1) / query.PageSize
+	}
+
+	return models.PayeesPagedModel{
+		Items:      items,
+		PageNumber: query.PageNumber,
+		PageSize:   query.PageSize,
+		TotalCount: totalCount,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (pr PayeeRepository) GetDetail(ctx context.Context, id int64) (models.PayeeModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	var data models.PayeeModel
+
+	sql := `
+		SELECT
+			id, name, aliases, created_at, updated_at, deleted_at
+		FROM payees
+		WHERE id = $1
+			AND user_id = $2
+			AND deleted_at IS NULL`
+
+	queryStart := time.Now()
+	err := pr.db.QueryRow(ctx, sql, id, common.GetUserID(ctx)).Scan(&data.ID, &data.Name, &data.Aliases, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.PayeeModel{}, huma.Error404NotFound("Payee not found")
+		}
+		observability.RecordError("database")
+		return models.PayeeModel{}, huma.Error500InternalServerError("Unable to query payee", err)
+	}
+	observability.RecordQueryDuration("SELECT", "payees", time.Since(queryStart).Seconds())
+
+	return data, nil
+}
+
+func (pr PayeeRepository) Create(ctx context.Context, payload models.CreatePayeeModel) (models.PayeeModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	var ID int64
+
+	aliases := payload.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+
+	sql := `INSERT INTO payees (name, aliases, user_id)
+			VALUES ($1, $2, $3)
+			RETURNING id`
+
+	queryStart := time.Now()
+	err := pr.db.QueryRow(ctx, sql, payload.Name, aliases, common.GetUserID(ctx)).Scan(&ID)
+
+	if err != nil {
+		observability.RecordError("database")
+		return models.PayeeModel{}, huma.Error500InternalServerError("Unable to create payee", err)
+	}
+	observability.RecordQueryDuration("INSERT", "payees", time.Since(queryStart).Seconds())
+
+	return pr.GetDetail(ctx, ID)
+}
+
+func (pr PayeeRepository) Update(ctx context.Context, id int64, payload models.UpdatePayeeModel) (models.PayeeModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	var ID int64
+
+	sql := `
+		UPDATE payees
+		SET name = COALESCE($1, name),
+			aliases = COALESCE($2, aliases),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3 AND user_id = $4 AND deleted_at IS NULL
+		RETURNING id
+	`
+
+	queryStart := time.Now()
+	err := pr.db.QueryRow(ctx, sql, payload.Name, payload.Aliases, id, common.GetUserID(ctx)).Scan(&ID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.PayeeModel{}, huma.Error404NotFound("Payee not found")
+		}
+		observability.RecordError("database")
+		return models.PayeeModel{}, huma.Error500InternalServerError("Unable to update payee", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "payees", time.Since(queryStart).Seconds())
+
+	return pr.GetDetail(ctx, ID)
+}
+
+func (pr PayeeRepository) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		UPDATE payees
+		SET deleted_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+			AND user_id = $2
+			AND deleted_at IS NULL`
+
+	queryStart := time.Now()
+	cmdTag, err := pr.db.Exec(ctx, sql, id, common.GetUserID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to delete payee", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return huma.Error404NotFound("Payee not found")
+	}
+	observability.RecordQueryDuration("DELETE", "payees", time.Since(queryStart).Seconds())
+
+	return nil
+}
+
+// Restore brings a deleted payee back from the trash; transactions kept pointing at it
+func (pr PayeeRepository) Restore(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		UPDATE payees
+		SET deleted_at = NULL,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+			AND user_id = $2
+			AND deleted_at IS NOT NULL`
+
+	queryStart := time.Now()
+	cmdTag, err := pr.db.Exec(ctx, sql, id, common.GetUserID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to restore payee", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return huma.Error404NotFound("Deleted payee not found")
+	}
+	observability.RecordQueryDuration("UPDATE", "payees", time.Since(queryStart).Seconds())
+
+	return nil
+}
+
+// Reassign points the transactions and templates of the source payees at the target payee
+// It returns how many transactions and templates were moved
+func (pr PayeeRepository) Reassign(ctx context.Context, sourceIDs []int64, targetID int64) (int64, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	queryStart := time.Now()
+	txTag, err := pr.db.Exec(ctx, `UPDATE transactions SET payee_id = $1, updated_at = CURRENT_TIMESTAMP WHERE payee_id = ANY($2::int8[])`, targetID, sourceIDs)
+	if err != nil {
+		observability.RecordError("database")
+		return 0, 0, huma.Error500InternalServerError("Unable to move transactions to payee", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "transactions", time.Since(queryStart).Seconds())
+
+	queryStart = time.Now()
+	ttTag, err := pr.db.Exec(ctx, `UPDATE transaction_templates SET payee_id = $1, updated_at = CURRENT_TIMESTAMP WHERE payee_id = ANY($2::int8[])`, targetID, sourceIDs)
+	if err != nil {
+		observability.RecordError("database")
+		return 0, 0, huma.Error500InternalServerError("Unable to move transaction templates to payee", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "transaction_templates", time.Since(queryStart).Seconds())
+
+	return txTag.RowsAffected(), ttTag.RowsAffected(), nil
+}
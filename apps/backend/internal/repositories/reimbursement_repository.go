@@ -0,0 +1,398 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/jackc/pgx/v5"
+)
+
+// reimbursementBalancesSQL selects the reimbursements of live expenses with how much is paid back and still owed
+// Only matches of live income count, as in transaction_reimbursed_amounts; the filter is added to the WHERE clause
+func reimbursementBalancesSQL(filter string) string {
+	return `
+			SELECT
+				r.id,
+				r.transaction_id,
+				t.date as transaction_date,
+				t.amount as transaction_amount,
+				t.note as transaction_note,
+				r.counterparty,
+				r.expected_amount,
+				COALESCE(m.matched_amount, 0) as matched_amount,
+				GREATEST(r.expected_amount - COALESCE(m.matched_amount, 0), 0) as outstanding_amount,
+				CASE
+					WHEN COALESCE(m.matched_amount, 0) = 0 THEN 'open'
+					WHEN r.expected_amount > COALESCE(m.matched_amount, 0) THEN 'partial'
+					ELSE 'settled'
+				END as status,
+				r.note,
+				r.created_at,
+				r.updated_at
+			FROM reimbursements r
+			INNER JOIN transactions t ON t.id = r.transaction_id AND t.deleted_at IS NULL
+			LEFT JOIN LATERAL (
+				SELECT SUM(rm.amount) as matched_amount
+				FROM reimbursement_matches rm
+				INNER JOIN transactions i ON i.id = rm.transaction_id AND i.type = 'income' AND i.deleted_at IS NULL
+				WHERE rm.reimbursement_id = r.id
+			) m ON TRUE
+			WHERE ` + filter
+}
+
+type ReimbursementRepository struct {
+	db DBQuerier
+}
+
+func NewReimbursementRepository(db DBQuerier) ReimbursementRepository {
+	return ReimbursementRepository{db}
+}
+
+func (rr ReimbursementRepository) GetPaged(ctx context.Context, query models.ReimbursementsSearchModel) (models.ReimbursementsPagedModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sortByMap := map[string]string{
+		"id":                "id",
+		"transactionDate":   "transaction_date",
+		"expectedAmount":    "expected_amount",
+		"outstandingAmount": "outstanding_amount",
+		"createdAt":         "created_at",
+	}
+	sortOrderMap := map[string]string{
+		"asc":  "ASC",
+		"desc": "DESC",
+	}
+
+	sortColumn := sortByMap[query.SortBy]
+	sortOrder := sortOrderMap[query.SortOrder]
+	offset := (query.PageNumber - 1) * query.PageSize
+
+	sql := `
+		WITH balances AS (` + reimbursementBalancesSQL("r.user_id = $5") + `
+		),
+		filtered_reimbursements AS (
+			SELECT
+				*,
+				COUNT(*) OVER() as total_count,
+				SUM(outstanding_amount) OVER() as total_outstanding
+			FROM balances
+			WHERE ($1::text = 'all'
+					OR ($1::text = 'outstanding' AND outstanding_amount > 0)
+					OR ($1::text = 'settled' AND outstanding_amount = 0))
+				AND ($2::text IS NULL OR $2::text = '' OR counterparty ILIKE '%' || $2::text || '%')
+			ORDER BY ` + sortColumn + ` ` + sortOrder + `, id ` + sortOrder + `
+			LIMIT $3 OFFSET $4
+		)
+		SELECT
+			id,
+			transaction_id,
+			transaction_date,
+			transaction_amount,
+			transaction_note,
+			counterparty,
+			expected_amount,
+			matched_amount,
+			outstanding_amount,
+			status,
+			note,
+			created_at,
+			updated_at,
+			total_count,
+			total_outstanding
+		FROM filtered_reimbursements
+		ORDER BY ` + sortColumn + ` ` + sortOrder + `, id ` + sortOrder + `
+		`
+
+	queryStart := time.Now()
+	rows, err := rr.db.Query(ctx, sql, query.Status, query.Counterparty, query.PageSize, offset, common.GetUserID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return models.ReimbursementsPagedModel{}, huma.Error500InternalServerError("Unable to query reimbursements", err)
+	}
+	defer rows.Close()
+	observability.RecordQueryDuration("SELECT", "reimbursements", time.Since(queryStart).Seconds())
+
+	var items []models.ReimbursementModel
+	var totalCount int
+	var totalOutstanding int64
+	for rows.Next() {
+		var item models.ReimbursementModel
+		if err := rows.Scan(
+			&item.ID, &item.TransactionID, &item.TransactionDate, &item.TransactionAmount, &item.TransactionNote,
+			&item.Counterparty, &item.ExpectedAmount, &item.MatchedAmount, &item.OutstandingAmount, &item.Status,
+			&item.Note, &item.CreatedAt, &item.UpdatedAt,
+			&totalCount, &totalOutstanding,
+		); err != nil {
+			return models.ReimbursementsPagedModel{}, huma.Error500InternalServerError("Unable to scan reimbursement data", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.ReimbursementsPagedModel{}, huma.Error500InternalServerError("Error reading reimbursement rows", err)
+	}
+
+	if items == nil {
+		items = []models.ReimbursementModel{}
+	}
+
+	totalPages := 0
+	if totalCount > 0 {
+		totalPages = (totalCount + query.PageSize - 1) / query.PageSize
+	}
+
+	return models.ReimbursementsPagedModel{
+		Items:            items,
+		PageNumber:       query.PageNumber,
+		PageSize:         query.PageSize,
+		TotalCount:       totalCount,
+		TotalPages:       totalPages,
+		TotalOutstanding: totalOutstanding,
+	}, nil
+}
+
+// GetDetail returns a reimbursement with the income matched against it, oldest first
+func (rr ReimbursementRepository) GetDetail(ctx context.Context, id int64) (models.ReimbursementModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	var item models.ReimbursementModel
+
+	sql := reimbursementBalancesSQL("r.id = $1 AND r.user_id = $2")
+
+	queryStart := time.Now()
+	err := rr.db.QueryRow(ctx, sql, id, common.GetUserID(ctx)).Scan(
+		&item.ID, &item.TransactionID, &item.TransactionDate, &item.TransactionAmount, &item.TransactionNote,
+		&item.Counterparty, &item.ExpectedAmount, &item.MatchedAmount, &item.OutstandingAmount, &item.Status,
+		&item.Note, &item.CreatedAt, &item.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ReimbursementModel{}, huma.Error404NotFound("Reimbursement not found")
+		}
+		observability.RecordError("database")
+		return models.ReimbursementModel{}, huma.Error500InternalServerError("Unable to query reimbursement", err)
+	}
+	observability.RecordQueryDuration("SELECT", "reimbursements", time.Since(queryStart).Seconds())
+
+	matchesSQL := `
+		SELECT m.id, m.transaction_id, i.date, m.amount, m.created_at
+		FROM reimbursement_matches m
+		INNER JOIN transactions i ON i.id = m.transaction_id AND i.deleted_at IS NULL
+		WHERE m.reimbursement_id = $1
+		ORDER BY i.date ASC, m.id ASC`
+
+	queryStart = time.Now()
+	rows, err := rr.db.Query(ctx, matchesSQL, id)
+	if err != nil {
+		observability.RecordError("database")
+		return models.ReimbursementModel{}, huma.Error500InternalServerError("Unable to query reimbursement matches", err)
+	}
+	defer rows.Close()
+	observability.RecordQueryDuration("SELECT", "reimbursement_matches", time.Since(queryStart).Seconds())
+
+	item.Matches = []models.ReimbursementMatchModel{}
+	for rows.Next() {
+		var match models.ReimbursementMatchModel
+		if err := rows.Scan(&match.ID, &match.TransactionID, &match.TransactionDate, &match.Amount, &match.CreatedAt); err != nil {
+			return models.ReimbursementModel{}, huma.Error500InternalServerError("Unable to scan reimbursement match data", err)
+		}
+		item.Matches = append(item.Matches, match)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.ReimbursementModel{}, huma.Error500InternalServerError("Error reading reimbursement match rows", err)
+	}
+
+	return item, nil
+}
+
+// GetDetailForUpdate locks the reimbursement until the surrounding database transaction ends, so concurrent matches
+// cannot together pay back more than is expected
+func (rr ReimbursementRepository) GetDetailForUpdate(ctx context.Context, id int64) (models.ReimbursementModel, error) {
+	lockCtx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `SELECT id FROM reimbursements WHERE id = $1 AND user_id = $2 FOR UPDATE`
+
+	queryStart := time.Now()
+	var ID int64
+	err := rr.db.QueryRow(lockCtx, sql, id, common.GetUserID(ctx)).Scan(&ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ReimbursementModel{}, huma.Error404NotFound("Reimbursement not found")
+		}
+		observability.RecordError("database")
+		return models.ReimbursementModel{}, huma.Error500InternalServerError("Unable to lock reimbursement", err)
+	}
+	observability.RecordQueryDuration("SELECT", "reimbursements", time.Since(queryStart).Seconds())
+
+	return rr.GetDetail(ctx, ID)
+}
+
+// GetTransactionForUpdate locks a transaction of the current user and returns how much of it already pays back reimbursements
+// The lock keeps concurrent matches from together counting more of an income than there is
+func (rr ReimbursementRepository) GetTransactionForUpdate(ctx context.Context, transactionID int64) (models.ReimbursementTransactionModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		WITH tx AS (
+			SELECT id, type, amount
+			FROM transactions
+			WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
+			FOR UPDATE
+		)
+		SELECT
+			i.type,
+			i.amount,
+			COALESCE((
+				SELECT SUM(m.amount)
+				FROM reimbursement_matches m
+				INNER JOIN reimbursements r ON r.id = m.reimbursement_id
+				INNER JOIN transactions e ON e.id = r.transaction_id AND e.type = 'expense' AND e.deleted_at IS NULL
+				WHERE m.transaction_id = i.id
+			), 0)
+		FROM tx i`
+
+	queryStart := time.Now()
+	var item models.ReimbursementTransactionModel
+	err := rr.db.QueryRow(ctx, sql, transactionID, common.GetUserID(ctx)).Scan(&item.Type, &item.Amount, &item.MatchedAmount)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ReimbursementTransactionModel{}, huma.Error404NotFound("Transaction not found")
+		}
+		observability.RecordError("database")
+		return models.ReimbursementTransactionModel{}, huma.Error500InternalServerError("Unable to query income transaction", err)
+	}
+	observability.RecordQueryDuration("SELECT", "transactions", time.Since(queryStart).Seconds())
+
+	return item, nil
+}
+
+func (rr ReimbursementRepository) Create(ctx context.Context, payload models.CreateReimbursementModel) (models.ReimbursementModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	var ID int64
+
+	sql := `INSERT INTO reimbursements (transaction_id, expected_amount, counterparty, note, user_id)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (transaction_id) DO NOTHING
+			RETURNING id`
+
+	queryStart := time.Now()
+	err := rr.db.QueryRow(ctx, sql, payload.TransactionID, payload.ExpectedAmount, payload.Counterparty, payload.Note, common.GetUserID(ctx)).Scan(&ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ReimbursementModel{}, huma.Error409Conflict("Expense is already marked for reimbursement")
+		}
+		observability.RecordError("database")
+		return models.ReimbursementModel{}, huma.Error500InternalServerError("Unable to create reimbursement", err)
+	}
+	observability.RecordQueryDuration("INSERT", "reimbursements", time.Since(queryStart).Seconds())
+
+	return rr.GetDetail(ctx, ID)
+}
+
+func (rr ReimbursementRepository) Update(ctx context.Context, id int64, payload models.UpdateReimbursementModel) (models.ReimbursementModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	var ID int64
+
+	sql := `
+		UPDATE reimbursements
+		SET expected_amount = COALESCE($1, expected_amount),
+			counterparty = COALESCE($2, counterparty),
+			note = COALESCE($3, note),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $4 AND user_id = $5
+		RETURNING id
+	`
+
+	queryStart := time.Now()
+	err := rr.db.QueryRow(ctx, sql, payload.ExpectedAmount, payload.Counterparty, payload.Note, id, common.GetUserID(ctx)).Scan(&ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ReimbursementModel{}, huma.Error404NotFound("Reimbursement not found")
+		}
+		observability.RecordError("database")
+		return models.ReimbursementModel{}, huma.Error500InternalServerError("Unable to update reimbursement", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "reimbursements", time.Since(queryStart).Seconds())
+
+	return rr.GetDetail(ctx, ID)
+}
+
+// Delete removes a reimbursement for good together with its matches, leaving the expense as plain spending
+func (rr ReimbursementRepository) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `DELETE FROM reimbursements WHERE id = $1 AND user_id = $2`
+
+	queryStart := time.Now()
+	cmdTag, err := rr.db.Exec(ctx, sql, id, common.GetUserID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to delete reimbursement", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return huma.Error404NotFound("Reimbursement not found")
+	}
+	observability.RecordQueryDuration("DELETE", "reimbursements", time.Since(queryStart).Seconds())
+
+	return nil
+}
+
+// AddMatch counts part of an income towards a reimbursement; matching the same income again adds to its amount
+func (rr ReimbursementRepository) AddMatch(ctx context.Context, id int64, transactionID int64, amount int64) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		INSERT INTO reimbursement_matches (reimbursement_id, transaction_id, amount)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (reimbursement_id, transaction_id) DO UPDATE SET amount = reimbursement_matches.amount + EXCLUDED.amount`
+
+	queryStart := time.Now()
+	if _, err := rr.db.Exec(ctx, sql, id, transactionID, amount); err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to create reimbursement match", err)
+	}
+	observability.RecordQueryDuration("INSERT", "reimbursement_matches", time.Since(queryStart).Seconds())
+
+	return nil
+}
+
+func (rr ReimbursementRepository) DeleteMatch(ctx context.Context, id int64, matchID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		DELETE FROM reimbursement_matches
+		WHERE id = $1
+			AND reimbursement_id = $2
+			AND reimbursement_id IN (SELECT id FROM reimbursements WHERE user_id = $3)`
+
+	queryStart := time.Now()
+	cmdTag, err := rr.db.Exec(ctx, sql, matchID, id, common.GetUserID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to delete reimbursement match", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return huma.Error404NotFound("Reimbursement match not found")
+	}
+	observability.RecordQueryDuration("DELETE", "reimbursement_matches", time.Since(queryStart).Seconds())
+
+	return nil
+}
@@ -18,18 +18,32 @@ type RootRepository struct {
 	Pool      *pgxpool.Pool
 	db        DBQuerier
 	Acc       AccountRepository
+	AccRecon  AccountReconciliationRepository
+	APITok    APITokenRepository
 	Ath       AuthRepository
+	Aud       AuditRepository
 	BudgTem   BudgetTemplateRepository
 	Cat       CategoryRepository
 	AccStat   AccountStatisticsRepository
 	CatStat   CategoryStatisticsRepository
 	CurConfig CurrencyConfigRepository
+	Payee     PayeeRepository
+	Reimb     ReimbursementRepository
+	Sess      SessionRepository
+	Shr       ShareRepository
 	Sum       SummaryRepository
 	Tag       TagRepository
+	Trash     TrashRepository
 	Tsct      TransactionRepository
+	TsctAtt   TransactionAttachmentRepository
+	TsctDup   TransactionDuplicateRepository
 	TsctRel   TransactionRelationRepository
+	TsctRev   TransactionRevisionRepository
+	TsctRule  TransactionRuleRepository
+	TsctSplit TransactionSplitRepository
 	TsctTag   TransactionTagRepository
 	TsctTem   TransactionTemplateRepository
+	Usr       UserRepository
 }
 
 func NewRootRepository(ctx context.Context, pgx *pgxpool.Pool) RootRepository {
@@ -38,18 +52,32 @@ func NewRootRepository(ctx context.Context, pgx *pgxpool.Pool) RootRepository {
 		Pool:      pgx,
 		db:        db,
 		Acc:       NewAccountRepository(db),
+		AccRecon:  NewAccountReconciliationRepository(db),
+		APITok:    NewAPITokenRepository(db),
 		Ath:       NewAuthRepository(ctx),
+		Aud:       NewAuditRepository(db),
 		BudgTem:   NewBudgetTemplateRepository(db),
 		Cat:       NewCategoryRepository(db),
 		AccStat:   NewAccountStatisticsRepository(db),
 		CatStat:   NewCategoryStatisticsRepository(db),
 		CurConfig: NewCurrencyConfigRepository(db),
+		Payee:     NewPayeeRepository(db),
+		Reimb:     NewReimbursementRepository(db),
+		Sess:      NewSessionRepository(db),
+		Shr:       NewShareRepository(db),
 		Sum:       NewSummaryRepository(db),
 		Tag:       NewTagRepository(db),
+		Trash:     NewTrashRepository(db),
 		Tsct:      NewTransactionRepository(db),
+		TsctAtt:   NewTransactionAttachmentRepository(db),
+		TsctDup:   NewTransactionDuplicateRepository(db),
 		TsctRel:   NewTransactionRelationRepository(db),
+		TsctRev:   NewTransactionRevisionRepository(db),
+		TsctRule:  NewTransactionRuleRepository(db),
+		TsctSplit: NewTransactionSplitRepository(db),
 		TsctTag:   NewTransactionTagRepository(db),
 		TsctTem:   NewTransactionTemplateRepository(db),
+		Usr:       NewUserRepository(db),
 	}
 }
 
@@ -58,17 +86,31 @@ func (r RootRepository) WithTx(ctx context.Context, tx pgx.Tx) RootRepository {
 		Pool:      r.Pool,
 		db:        tx,
 		Acc:       NewAccountRepository(tx),
+		AccRecon:  NewAccountReconciliationRepository(tx),
+		APITok:    NewAPITokenRepository(tx),
 		Ath:       NewAuthRepository(ctx),
+		Aud:       NewAuditRepository(tx),
 		BudgTem:   NewBudgetTemplateRepository(tx),
 		Cat:       NewCategoryRepository(tx),
 		AccStat:   NewAccountStatisticsRepository(tx),
 		CatStat:   NewCategoryStatisticsRepository(tx),
 		CurConfig: NewCurrencyConfigRepository(tx),
+		Payee:     NewPayeeRepository(tx),
+		Reimb:     NewReimbursementRepository(tx),
+		Sess:      NewSessionRepository(tx),
+		Shr:       NewShareRepository(tx),
 		Sum:       NewSummaryRepository(tx),
 		Tag:       NewTagRepository(tx),
+		Trash:     NewTrashRepository(tx),
 		Tsct:      NewTransactionRepository(tx),
+		TsctAtt:   NewTransactionAttachmentRepository(tx),
+		TsctDup:   NewTransactionDuplicateRepository(tx),
 		TsctRel:   NewTransactionRelationRepository(tx),
+		TsctRev:   NewTransactionRevisionRepository(tx),
+		TsctRule:  NewTransactionRuleRepository(tx),
+		TsctSplit: NewTransactionSplitRepository(tx),
 		TsctTag:   NewTransactionTagRepository(tx),
 		TsctTem:   NewTransactionTemplateRepository(tx),
+		Usr:       NewUserRepository(tx),
 	}
 }
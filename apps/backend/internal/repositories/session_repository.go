@@ -0,0 +1,199 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/jackc/pgx/v5"
+)
+
+type SessionRepository struct {
+	db DBQuerier
+}
+
+func NewSessionRepository(db DBQuerier) SessionRepository {
+	return SessionRepository{db}
+}
+
+// GetList returns the current user's active sessions, flagging the one making the request
+func (sr SessionRepository) GetList(ctx context.Context) (models.SessionsListModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		SELECT id, device_name, user_agent, ip_address, id = $2, created_at, last_seen_at, expires_at
+		FROM sessions
+		WHERE user_id = $1
+			AND revoked_at IS NULL
+			AND expires_at > NOW()
+		ORDER BY last_seen_at DESC`
+
+	queryStart := time.Now()
+	rows, err := sr.db.Query(ctx, sql, common.GetUserID(ctx), common.GetSessionID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return models.SessionsListModel{}, huma.Error500InternalServerError("Unable to query sessions", err)
+	}
+	defer rows.Close()
+	observability.RecordQueryDuration("SELECT", "sessions", time.Since(queryStart).Seconds())
+
+	items := []models.SessionModel{}
+	for rows.Next() {
+		var item models.SessionModel
+		if err := rows.Scan(&item.ID, &item.DeviceName, &item.UserAgent, &item.IPAddress, &item.Current, &item.CreatedAt, &item.LastSeenAt, &item.ExpiresAt); err != nil {
+			return models.SessionsListModel{}, huma.Error500InternalServerError("Unable to scan session data", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.SessionsListModel{}, huma.Error500InternalServerError("Error reading session rows", err)
+	}
+
+	return models.SessionsListModel{Items: items}, nil
+}
+
+// Create starts a session for the current user and returns its ID
+func (sr SessionRepository) Create(ctx context.Context, p models.CreateSessionModel) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		INSERT INTO sessions (user_id, device_name, user_agent, ip_address, expires_at)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), NULLIF($4, ''), $5)
+		RETURNING id`
+
+	var id int64
+	queryStart := time.Now()
+	if err := sr.db.QueryRow(ctx, sql, common.GetUserID(ctx), p.DeviceName, p.UserAgent, p.IPAddress, p.ExpiresAt).Scan(&id); err != nil {
+		observability.RecordError("database")
+		return 0, huma.Error500InternalServerError("Unable to create session", err)
+	}
+	observability.RecordQueryDuration("INSERT", "sessions", time.Since(queryStart).Seconds())
+
+	return id, nil
+}
+
+// Extend moves the expiry of an active session of the current user when its refresh token is rotated
+// Returns 401 when the session has been revoked or has expired
+func (sr SessionRepository) Extend(ctx context.Context, id int64, expiresAt time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		UPDATE sessions
+		SET expires_at = $3, last_seen_at = NOW()
+		WHERE id = $1
+			AND user_id = $2
+			AND revoked_at IS NULL
+			AND expires_at > NOW()
+		RETURNING id`
+
+	queryStart := time.Now()
+	err := sr.db.QueryRow(ctx, sql, id, common.GetUserID(ctx), expiresAt).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return huma.Error401Unauthorized("Session has ended, please log in again")
+	}
+	if err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to extend session", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "sessions", time.Since(queryStart).Seconds())
+
+	return nil
+}
+
+// Touch records that a session of the current user was just used, and from which IP address
+func (sr SessionRepository) Touch(ctx context.Context, id int64, ipAddress string) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		UPDATE sessions
+		SET last_seen_at = NOW(), ip_address = COALESCE(NULLIF($3, ''), ip_address)
+		WHERE id = $1
+			AND user_id = $2
+			AND revoked_at IS NULL`
+
+	queryStart := time.Now()
+	if _, err := sr.db.Exec(ctx, sql, id, common.GetUserID(ctx), ipAddress); err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to update session", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "sessions", time.Since(queryStart).Seconds())
+
+	return nil
+}
+
+// Revoke ends an active session of the current user and returns when it would have expired
+func (sr SessionRepository) Revoke(ctx context.Context, id int64) (time.Time, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		UPDATE sessions
+		SET revoked_at = NOW()
+		WHERE id = $1
+			AND user_id = $2
+			AND revoked_at IS NULL
+			AND expires_at > NOW()
+		RETURNING expires_at`
+
+	var expiresAt time.Time
+	queryStart := time.Now()
+	err := sr.db.QueryRow(ctx, sql, id, common.GetUserID(ctx)).Scan(&expiresAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return time.Time{}, huma.Error404NotFound("Session not found")
+	}
+	if err != nil {
+		observability.RecordError("database")
+		return time.Time{}, huma.Error500InternalServerError("Unable to revoke session", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "sessions", time.Since(queryStart).Seconds())
+
+	return expiresAt, nil
+}
+
+// RevokeAll ends every active session of the current user and returns when each would have expired, keyed by session ID
+func (sr SessionRepository) RevokeAll(ctx context.Context) (map[int64]time.Time, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		UPDATE sessions
+		SET revoked_at = NOW()
+		WHERE user_id = $1
+			AND revoked_at IS NULL
+		RETURNING id, expires_at`
+
+	queryStart := time.Now()
+	rows, err := sr.db.Query(ctx, sql, common.GetUserID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return nil, huma.Error500InternalServerError("Unable to revoke sessions", err)
+	}
+	defer rows.Close()
+
+	revoked := map[int64]time.Time{}
+	for rows.Next() {
+		var id int64
+		var expiresAt time.Time
+		if err := rows.Scan(&id, &expiresAt); err != nil {
+			return nil, huma.Error500InternalServerError("Unable to scan revoked session", err)
+		}
+		revoked[id] = expiresAt
+	}
+	if err := rows.Err(); err != nil {
+		observability.RecordError("database")
+		return nil, huma.Error500InternalServerError("Unable to revoke sessions", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "sessions", time.Since(queryStart).Seconds())
+
+	return revoked, nil
+}
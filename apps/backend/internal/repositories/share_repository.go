@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/jackc/pgx/v5"
+)
+
+// shareableTables maps each shareable resource type to its table and display name
+var shareableTables = map[string]struct {
+	table string
+	label string
+}{
+	constants.EntityAccount:             {"accounts", "Account"},
+	constants.EntityBudgetTemplate:      {"budget_templates", "Budget template"},
+	constants.EntityTransactionTemplate: {"transaction_templates", "Transaction template"},
+}
+
+// sharedWithUserSQL returns a condition matching rows of a resource type shared with the user bound to userParam
+// When roles are given, only shares granting one of them match; callers pass constants so the SQL stays static
+func sharedWithUserSQL(resourceType string, idColumn string, userParam string, roles ...string) string {
+	sql := `EXISTS (SELECT 1 FROM shares sh WHERE sh.resource_type = '` + resourceType + `' AND sh.resource_id = ` + idColumn + ` AND sh.user_id = ` + userParam + ` AND sh.deleted_at IS NULL`
+	if len(roles) > 0 {
+		sql += ` AND sh.role IN (`
+		for i, role := range roles {
+			if i > 0 {
+				sql += `, `
+			}
+			sql += `'` + role + `'`
+		}
+		sql += `)`
+	}
+	return sql + `)`
+}
+
+// accessibleAccountIDsSQL returns a subquery of account IDs the user bound to userParam owns or has been shared
+func accessibleAccountIDsSQL(userParam string) string {
+	return `SELECT id FROM accounts WHERE user_id = ` + userParam + `
+			UNION SELECT resource_id FROM shares WHERE resource_type = '` + constants.EntityAccount + `' AND user_id = ` + userParam + ` AND deleted_at IS NULL`
+}
+
+type ShareRepository struct {
+	db DBQuerier
+}
+
+func NewShareRepository(db DBQuerier) ShareRepository {
+	return ShareRepository{db}
+}
+
+// GetAccess resolves the current user's role on a resource: owner, or the role of an active share
+// Resources neither owned nor shared with the user are reported as not found
+func (sr ShareRepository) GetAccess(ctx context.Context, resourceType string, resourceID int64) (models.ShareAccessModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	shareable, ok := shareableTables[resourceType]
+	if !ok {
+		return models.ShareAccessModel{}, huma.Error400BadRequest("Resource type cannot be shared")
+	}
+
+	var data models.ShareAccessModel
+
+	sql := `
+		SELECT
+			r.user_id,
+			CASE WHEN r.user_id = $2 THEN '` + constants.ShareRoleOwner + `' ELSE sh.role END
+		FROM ` + shareable.table + ` r
+		LEFT JOIN shares sh ON sh.resource_type = $3
+			AND sh.resource_id = r.id
+			AND sh.user_id = $2
+			AND sh.deleted_at IS NULL
+		WHERE r.id = $1
+			AND r.deleted_at IS NULL
+			AND (r.user_id = $2 OR sh.id IS NOT NULL)`
+
+	queryStart := time.Now()
+	err := sr.db.QueryRow(ctx, sql, resourceID, common.GetUserID(ctx), resourceType).Scan(&data.OwnerID, &data.Role)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ShareAccessModel{}, huma.Error404NotFound(shareable.label + " not found")
+		}
+		observability.RecordError("database")
+		return models.ShareAccessModel{}, huma.Error500InternalServerError("Unable to query share access", err)
+	}
+	observability.RecordQueryDuration("SELECT", "shares", time.Since(queryStart).Seconds())
+
+	return data, nil
+}
+
+// GetList returns the active shares of a resource owned by the current user
+func (sr ShareRepository) GetList(ctx context.Context, resourceType string, resourceID int64) (models.SharesListModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		SELECT
+			sh.user_id, u.username, sh.role, sh.created_at
+		FROM shares sh
+		JOIN users u ON u.id = sh.user_id
+		WHERE sh.resource_type = $1
+			AND sh.resource_id = $2
+			AND sh.owner_id = $3
+			AND sh.deleted_at IS NULL
+		ORDER BY u.username ASC`
+
+	queryStart := time.Now()
+	rows, err := sr.db.Query(ctx, sql, resourceType, resourceID, common.GetUserID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return models.SharesListModel{}, huma.Error500InternalServerError("Unable to query shares", err)
+	}
+	defer rows.Close()
+	observability.RecordQueryDuration("SELECT", "shares", time.Since(queryStart).Seconds())
+
+	items := []models.ShareModel{}
+	for rows.Next() {
+		var item models.ShareModel
+		if err := rows.Scan(&item.UserID, &item.Username, &item.Role, &item.CreatedAt); err != nil {
+			return models.SharesListModel{}, huma.Error500InternalServerError("Unable to scan share data", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.SharesListModel{}, huma.Error500InternalServerError("Error reading share rows", err)
+	}
+
+	return models.SharesListModel{Items: items}, nil
+}
+
+// Upsert shares a resource owned by the current user, or changes the role of an existing share
+func (sr ShareRepository) Upsert(ctx context.Context, resourceType string, resourceID int64, userID int64, role string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		INSERT INTO shares (resource_type, resource_id, owner_id, user_id, role)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (resource_type, resource_id, user_id) WHERE deleted_at IS NULL
+		DO UPDATE SET role = EXCLUDED.role, updated_at = CURRENT_TIMESTAMP
+		RETURNING id`
+
+	var id int64
+	queryStart := time.Now()
+	if err := sr.db.QueryRow(ctx, sql, resourceType, resourceID, common.GetUserID(ctx), userID, role).Scan(&id); err != nil {
+		observability.RecordError("database")
+		return 0, huma.Error500InternalServerError("Unable to share resource", err)
+	}
+	observability.RecordQueryDuration("INSERT", "shares", time.Since(queryStart).Seconds())
+
+	return id, nil
+}
+
+// Delete revokes a user's access to a resource owned by the current user
+func (sr ShareRepository) Delete(ctx context.Context, resourceType string, resourceID int64, userID int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		UPDATE shares
+		SET deleted_at = CURRENT_TIMESTAMP
+		WHERE resource_type = $1
+			AND resource_id = $2
+			AND owner_id = $3
+			AND user_id = $4
+			AND deleted_at IS NULL
+		RETURNING id`
+
+	var id int64
+	queryStart := time.Now()
+	err := sr.db.QueryRow(ctx, sql, resourceType, resourceID, common.GetUserID(ctx), userID).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, huma.Error404NotFound("Share not found")
+	}
+	if err != nil {
+		observability.RecordError("database")
+		return 0, huma.Error500InternalServerError("Unable to revoke share", err)
+	}
+	observability.RecordQueryDuration("DELETE", "shares", time.Since(queryStart).Seconds())
+
+	return id, nil
+}
@@ -5,11 +5,21 @@ import (
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
 	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
 	"github.com/dimasbaguspm/spenicle-api/internal/observability"
 )
 
+// summaryAmountSQL is the amount a transaction t counts for in a summary
+// When reimbursed spending is excluded, transaction_reimbursed_amounts ra is joined and the part paid back is left out
+const summaryAmountSQL = `t.amount - COALESCE(ra.amount, 0)`
+
+// summaryCategoryAmountSQL is summaryAmountSQL for a row of transaction_category_amounts
+// A split line gives up its share of the part paid back, so the lines of a transaction still sum to what it counts for;
+// st is the split transaction, joined only when something of it was paid back
+const summaryCategoryAmountSQL = `t.amount - COALESCE(CASE WHEN st.id IS NULL THEN ra.amount ELSE ra.amount * t.amount / st.amount END, 0)`
+
 type SummaryRepository struct {
 	db DBQuerier
 }
@@ -58,8 +68,12 @@ func (sr SummaryRepository) GetTransactionSummary(ctx context.Context, p models.
 				COALESCE(SUM(amount) FILTER (WHERE type = 'expense'), 0) as expense_amount,
 				COALESCE(SUM(amount) FILTER (WHERE type = 'transfer'), 0) as transfer_amount,
 				COALESCE(SUM(amount) FILTER (WHERE type = 'income'), 0) - COALESCE(SUM(amount) FILTER (WHERE type = 'expense'), 0) as net
-			FROM transactions
-			WHERE deleted_at IS NULL AND date >= $1::timestamptz AND date <= $2::timestamptz
+			FROM (
+				SELECT t.date, t.type, ` + summaryAmountSQL + ` as amount
+				FROM transactions t
+				LEFT JOIN transaction_reimbursed_amounts ra ON ra.transaction_id = t.id AND $4::boolean
+				WHERE t.deleted_at IS NULL AND t.user_id = $3 AND t.date >= $1::timestamptz AND t.date <= $2::timestamptz
+			) t
 			GROUP BY period
 		)
 		SELECT
@@ -78,7 +92,7 @@ func (sr SummaryRepository) GetTransactionSummary(ctx context.Context, p models.
 	`
 
 	queryStart := time.Now()
-	rows, err := sr.db.Query(ctx, sql, p.StartDate, p.EndDate)
+	rows, err := sr.db.Query(ctx, sql, p.StartDate, p.EndDate, common.GetUserID(ctx), p.ExcludeReimbursed)
 	if err != nil {
 		observability.RecordError("database")
 		return models.SummaryTransactionListModel{}, huma.Error500InternalServerError("query transaction summary: %w", err)
@@ -123,15 +137,17 @@ func (sr SummaryRepository) GetAccountSummary(ctx context.Context, p models.Summ
 		WITH accounts_cte AS (
 			SELECT id, name, type
 			FROM accounts
-			WHERE deleted_at IS NULL
+			WHERE deleted_at IS NULL AND user_id = $3
 		),
 		txs AS (
-			SELECT account_id, type, amount
-			FROM transactions
-			WHERE deleted_at IS NULL
-				AND type != 'transfer'
-				AND ($1::timestamptz IS NULL OR date >= $1::timestamptz)
-				AND ($2::timestamptz IS NULL OR date <= $2::timestamptz)
+			SELECT t.account_id, t.type, ` + summaryAmountSQL + ` as amount
+			FROM transactions t
+			LEFT JOIN transaction_reimbursed_amounts ra ON ra.transaction_id = t.id AND $4::boolean
+			WHERE t.deleted_at IS NULL
+				AND t.user_id = $3
+				AND t.type != 'transfer'
+				AND ($1::timestamptz IS NULL OR t.date >= $1::timestamptz)
+				AND ($2::timestamptz IS NULL OR t.date <= $2::timestamptz)
 		),
 		summary AS (
 			SELECT
@@ -159,7 +175,7 @@ func (sr SummaryRepository) GetAccountSummary(ctx context.Context, p models.Summ
 	`
 
 	queryStart := time.Now()
-	rows, err := sr.db.Query(ctx, sql, p.StartDate, p.EndDate)
+	rows, err := sr.db.Query(ctx, sql, p.StartDate, p.EndDate, common.GetUserID(ctx), p.ExcludeReimbursed)
 	if err != nil {
 		observability.RecordError("database")
 		return models.SummaryAccountListModel{}, huma.Error500InternalServerError("query account summary: %w", err)
@@ -201,15 +217,18 @@ func (sr SummaryRepository) GetCategorySummary(ctx context.Context, p models.Sum
 		WITH categories_cte AS (
 			SELECT id, name, type
 			FROM categories
-			WHERE deleted_at IS NULL
+			WHERE deleted_at IS NULL AND user_id = $3
 		),
 		txs AS (
-			SELECT category_id, type, amount
-			FROM transactions
-			WHERE deleted_at IS NULL
-				AND type != 'transfer'
-				AND ($1::timestamptz IS NULL OR date >= $1::timestamptz)
-				AND ($2::timestamptz IS NULL OR date <= $2::timestamptz)
+			SELECT t.category_id, t.type, ` + summaryCategoryAmountSQL + ` as amount
+			FROM transaction_category_amounts t
+			LEFT JOIN transaction_reimbursed_amounts ra ON ra.transaction_id = t.id AND $4::boolean
+			LEFT JOIN transactions st ON st.id = t.id AND t.split_id IS NOT NULL AND ra.amount IS NOT NULL
+			WHERE t.deleted_at IS NULL
+				AND t.user_id = $3
+				AND t.type != 'transfer'
+				AND ($1::timestamptz IS NULL OR t.date >= $1::timestamptz)
+				AND ($2::timestamptz IS NULL OR t.date <= $2::timestamptz)
 		),
 		summary AS (
 			SELECT
@@ -237,7 +256,7 @@ func (sr SummaryRepository) GetCategorySummary(ctx context.Context, p models.Sum
 	`
 
 	queryStart := time.Now()
-	rows, err := sr.db.Query(ctx, sql, p.StartDate, p.EndDate)
+	rows, err := sr.db.Query(ctx, sql, p.StartDate, p.EndDate, common.GetUserID(ctx), p.ExcludeReimbursed)
 	if err != nil {
 		observability.RecordError("database")
 		return models.SummaryCategoryListModel{}, huma.Error500InternalServerError("query category summary: %w", err)
@@ -271,6 +290,85 @@ func (sr SummaryRepository) GetCategorySummary(ctx context.Context, p models.Sum
 	}, nil
 }
 
+// GetPayeeSummary totals the transactions of each payee; payees without transactions in the range are left out
+func (sr SummaryRepository) GetPayeeSummary(ctx context.Context, p models.SummarySearchModel) (models.SummaryPayeeListModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		WITH payees_cte AS (
+			SELECT id, name
+			FROM payees
+			WHERE deleted_at IS NULL AND user_id = $3
+		),
+		txs AS (
+			SELECT t.payee_id, t.type, ` + summaryAmountSQL + ` as amount
+			FROM transactions t
+			LEFT JOIN transaction_reimbursed_amounts ra ON ra.transaction_id = t.id AND $4::boolean
+			WHERE t.deleted_at IS NULL
+				AND t.user_id = $3
+				AND t.payee_id IS NOT NULL
+				AND t.type != 'transfer'
+				AND ($1::timestamptz IS NULL OR t.date >= $1::timestamptz)
+				AND ($2::timestamptz IS NULL OR t.date <= $2::timestamptz)
+		),
+		summary AS (
+			SELECT
+				py.id as payee_id,
+				py.name as payee_name,
+				COUNT(t.payee_id) as total_count,
+				COALESCE(SUM(t.amount) FILTER (WHERE t.type = 'income'), 0) as income_amount,
+				COALESCE(SUM(t.amount) FILTER (WHERE t.type = 'expense'), 0) as expense_amount,
+				COALESCE(SUM(t.amount) FILTER (WHERE t.type = 'income'), 0) - COALESCE(SUM(t.amount) FILTER (WHERE t.type = 'expense'), 0) as net
+			FROM payees_cte py
+			INNER JOIN txs t ON t.payee_id = py.id
+			GROUP BY py.id, py.name
+		)
+		SELECT
+			payee_id,
+			payee_name,
+			total_count,
+			income_amount,
+			expense_amount,
+			net
+		FROM summary
+		ORDER BY expense_amount DESC, total_count DESC
+	`
+
+	queryStart := time.Now()
+	rows, err := sr.db.Query(ctx, sql, p.StartDate, p.EndDate, common.GetUserID(ctx), p.ExcludeReimbursed)
+	if err != nil {
+		observability.RecordError("database")
+		return models.SummaryPayeeListModel{}, huma.Error500InternalServerError("query payee summary: %w", err)
+	}
+	defer rows.Close()
+	observability.RecordQueryDuration("SELECT", "payees", time.Since(queryStart).Seconds())
+
+	var items []models.SummaryPayeeModel
+	for rows.Next() {
+		var item models.SummaryPayeeModel
+		if err := rows.Scan(
+			&item.ID,
+			&item.Name,
+			&item.TotalCount,
+			&item.IncomeAmount,
+			&item.ExpenseAmount,
+			&item.Net,
+		); err != nil {
+			return models.SummaryPayeeListModel{}, huma.Error500InternalServerError("scan payee summary: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if items == nil {
+		items = []models.SummaryPayeeModel{}
+	}
+
+	return models.SummaryPayeeListModel{
+		Data: items,
+	}, nil
+}
+
 func (sr SummaryRepository) GetGeospatialSummary(ctx context.Context, p models.SummaryGeospatialSearchModel) (models.SummaryGeospatialListModel, error) {
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
@@ -280,19 +378,21 @@ func (sr SummaryRepository) GetGeospatialSummary(ctx context.Context, p models.S
 		-- Step 1: Round all transactions to grid cells
 		geo_transactions AS (
 			SELECT
-				id,
-				type,
-				amount,
-				latitude,
-				longitude,
-				ROUND(latitude::numeric, $6) as grid_lat,
-				ROUND(longitude::numeric, $6) as grid_lon
-			FROM transactions
-			WHERE deleted_at IS NULL
-				AND latitude IS NOT NULL
-				AND longitude IS NOT NULL
-				AND date >= $3::timestamptz
-				AND date <= $4::timestamptz
+				t.id,
+				t.type,
+				` + summaryAmountSQL + ` as amount,
+				t.latitude,
+				t.longitude,
+				ROUND(t.latitude::numeric, $6) as grid_lat,
+				ROUND(t.longitude::numeric, $6) as grid_lon
+			FROM transactions t
+			LEFT JOIN transaction_reimbursed_amounts ra ON ra.transaction_id = t.id AND $8::boolean
+			WHERE t.deleted_at IS NULL
+				AND t.user_id = $7
+				AND t.latitude IS NOT NULL
+				AND t.longitude IS NOT NULL
+				AND t.date >= $3::timestamptz
+				AND t.date <= $4::timestamptz
 		),
 
 		-- Step 2: Calculate distance from center to GRID CELL CENTER (not individual transactions)
@@ -350,7 +450,7 @@ func (sr SummaryRepository) GetGeospatialSummary(ctx context.Context, p models.S
 	`
 
 	queryStart := time.Now()
-	rows, err := sr.db.Query(ctx, sql, p.Latitude, p.Longitude, p.StartDate, p.EndDate, p.RadiusMeters, p.GridPrecision)
+	rows, err := sr.db.Query(ctx, sql, p.Latitude, p.Longitude, p.StartDate, p.EndDate, p.RadiusMeters, p.GridPrecision, common.GetUserID(ctx), p.ExcludeReimbursed)
 	if err != nil {
 		observability.RecordError("database")
 		return models.SummaryGeospatialListModel{}, huma.Error500InternalServerError("query geospatial summary: %w", err)
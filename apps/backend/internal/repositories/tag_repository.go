@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
 	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
 	"github.com/dimasbaguspm/spenicle-api/internal/observability"
@@ -46,6 +47,7 @@ func (tr TagRepository) GetPaged(ctx context.Context, query models.TagsSearchMod
 				COUNT(*) OVER() as total_count
 			FROM tags
 			WHERE deleted_at IS NULL
+				AND user_id = $4
 				AND ($1::text IS NULL OR $1::text = '' OR name ILIKE '%' || $1::text || '%')
 			ORDER BY ` + sortColumn + ` ` + sortOrder + `
 			LIMIT $2 OFFSET $3
@@ -63,7 +65,7 @@ func (tr TagRepository) GetPaged(ctx context.Context, query models.TagsSearchMod
 		`
 
 	queryStart := time.Now()
-	rows, err := tr.db.Query(ctx, sql, query.Name, query.PageSize, offset)
+	rows, err := tr.db.Query(ctx, sql, query.Name, query.PageSize, offset, common.GetUserID(ctx))
 	if err != nil {
 		observability.RecordError("database")
 		return models.TagsPagedModel{}, huma.Error400BadRequest("Unable to query tags", err)
@@ -115,10 +117,11 @@ func (tr TagRepository) GetDetail(ctx context.Context, id int64) (models.TagMode
 			id, name, color, created_at, updated_at, deleted_at
 		FROM tags
 		WHERE id = $1
+			AND user_id = $2
 			AND deleted_at IS NULL`
 
 	queryStart := time.Now()
-	err := tr.db.QueryRow(ctx, sql, id).Scan(&data.ID, &data.Name, &data.Color, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt)
+	err := tr.db.QueryRow(ctx, sql, id, common.GetUserID(ctx)).Scan(&data.ID, &data.Name, &data.Color, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -138,12 +141,12 @@ func (tr TagRepository) Create(ctx context.Context, payload models.CreateTagMode
 
 	var ID int64
 
-	sql := `INSERT INTO tags (name, color)
-			VALUES ($1, $2)
+	sql := `INSERT INTO tags (name, color, user_id)
+			VALUES ($1, $2, $3)
 			RETURNING id`
 
 	queryStart := time.Now()
-	err := tr.db.QueryRow(ctx, sql, payload.Name, payload.Color).Scan(&ID)
+	err := tr.db.QueryRow(ctx, sql, payload.Name, payload.Color, common.GetUserID(ctx)).Scan(&ID)
 
 	if err != nil {
 		observability.RecordError("database")
@@ -165,12 +168,12 @@ func (tr TagRepository) Update(ctx context.Context, id int64, payload models.Upd
 		SET name = COALESCE($1, name),
 			color = COALESCE($2, color),
 			updated_at = CURRENT_TIMESTAMP
-		WHERE id = $3 AND deleted_at IS NULL
+		WHERE id = $3 AND user_id = $4 AND deleted_at IS NULL
 		RETURNING id
 	`
 
 	queryStart := time.Now()
-	err := tr.db.QueryRow(ctx, sql, payload.Name, payload.Color, id).Scan(&ID)
+	err := tr.db.QueryRow(ctx, sql, payload.Name, payload.Color, id, common.GetUserID(ctx)).Scan(&ID)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -192,10 +195,11 @@ func (tr TagRepository) Delete(ctx context.Context, id int64) error {
 		UPDATE tags
 		SET deleted_at = CURRENT_TIMESTAMP
 		WHERE id = $1
+			AND user_id = $2
 			AND deleted_at IS NULL`
 
 	queryStart := time.Now()
-	cmdTag, err := tr.db.Exec(ctx, sql, id)
+	cmdTag, err := tr.db.Exec(ctx, sql, id, common.GetUserID(ctx))
 	if err != nil {
 		observability.RecordError("database")
 		return huma.Error500InternalServerError("Unable to delete tag", err)
@@ -207,3 +211,30 @@ func (tr TagRepository) Delete(ctx context.Context, id int64) error {
 
 	return nil
 }
+
+// Restore brings a deleted tag back from the trash; its links to transactions were kept
+func (tr TagRepository) Restore(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		UPDATE tags
+		SET deleted_at = NULL,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+			AND user_id = $2
+			AND deleted_at IS NOT NULL`
+
+	queryStart := time.Now()
+	cmdTag, err := tr.db.Exec(ctx, sql, id, common.GetUserID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to restore tag", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return huma.Error404NotFound("Deleted tag not found")
+	}
+	observability.RecordQueryDuration("UPDATE", "tags", time.Since(queryStart).Seconds())
+
+	return nil
+}
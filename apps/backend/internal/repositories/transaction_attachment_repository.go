@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/jackc/pgx/v5"
+)
+
+const transactionAttachmentColumnsSQL = `a.id, a.transaction_id, a.file_name, a.content_type, a.size_bytes, a.checksum_sha256, a.storage_key, a.thumbnail_key, a.created_at`
+
+type TransactionAttachmentRepository struct {
+	db DBQuerier
+}
+
+func NewTransactionAttachmentRepository(db DBQuerier) TransactionAttachmentRepository {
+	return TransactionAttachmentRepository{db}
+}
+
+func scanTransactionAttachment(row pgx.Row) (models.TransactionAttachmentModel, error) {
+	var item models.TransactionAttachmentModel
+	err := row.Scan(&item.ID, &item.TransactionID, &item.FileName, &item.ContentType, &item.SizeBytes, &item.ChecksumSHA256, &item.StorageKey, &item.ThumbnailKey, &item.CreatedAt)
+	item.HasThumbnail = item.ThumbnailKey != nil
+	return item, err
+}
+
+func (tar TransactionAttachmentRepository) GetList(ctx context.Context, transactionID int64) (models.TransactionAttachmentsListModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `SELECT ` + transactionAttachmentColumnsSQL + `
+			FROM transaction_attachments a
+			INNER JOIN transactions t ON a.transaction_id = t.id
+			WHERE a.transaction_id = $1
+				AND a.deleted_at IS NULL
+				AND t.deleted_at IS NULL
+				AND ` + transactionVisibleSQL("t.", "$2") + `
+			ORDER BY a.created_at ASC, a.id ASC`
+
+	queryStart := time.Now()
+	rows, err := tar.db.Query(ctx, sql, transactionID, common.GetUserID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return models.TransactionAttachmentsListModel{}, huma.Error500InternalServerError("Unable to query transaction attachments", err)
+	}
+	defer rows.Close()
+	observability.RecordQueryDuration("SELECT", "transaction_attachments", time.Since(queryStart).Seconds())
+
+	items := []models.TransactionAttachmentModel{}
+	for rows.Next() {
+		item, err := scanTransactionAttachment(rows)
+		if err != nil {
+			return models.TransactionAttachmentsListModel{}, huma.Error500InternalServerError("Unable to scan transaction attachment data", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.TransactionAttachmentsListModel{}, huma.Error500InternalServerError("Error reading transaction attachment rows", err)
+	}
+
+	return models.TransactionAttachmentsListModel{Items: items}, nil
+}
+
+func (tar TransactionAttachmentRepository) GetDetail(ctx context.Context, transactionID int64, id int64) (models.TransactionAttachmentModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `SELECT ` + transactionAttachmentColumnsSQL + `
+			FROM transaction_attachments a
+			INNER JOIN transactions t ON a.transaction_id = t.id
+			WHERE a.id = $1
+				AND a.transaction_id = $2
+				AND a.deleted_at IS NULL
+				AND t.deleted_at IS NULL
+				AND ` + transactionVisibleSQL("t.", "$3")
+
+	queryStart := time.Now()
+	item, err := scanTransactionAttachment(tar.db.QueryRow(ctx, sql, id, transactionID, common.GetUserID(ctx)))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.TransactionAttachmentModel{}, huma.Error404NotFound("Attachment not found")
+	}
+	if err != nil {
+		observability.RecordError("database")
+		return models.TransactionAttachmentModel{}, huma.Error500InternalServerError("Unable to query transaction attachment", err)
+	}
+	observability.RecordQueryDuration("SELECT", "transaction_attachments", time.Since(queryStart).Seconds())
+
+	return item, nil
+}
+
+// Create records the metadata of an uploaded file; callers check the transaction is editable first
+func (tar TransactionAttachmentRepository) Create(ctx context.Context, p models.CreateTransactionAttachmentModel) (models.TransactionAttachmentModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `INSERT INTO transaction_attachments AS a (transaction_id, user_id, file_name, content_type, size_bytes, checksum_sha256, storage_key, thumbnail_key)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING ` + transactionAttachmentColumnsSQL
+
+	queryStart := time.Now()
+	item, err := scanTransactionAttachment(tar.db.QueryRow(ctx, sql,
+		p.TransactionID, common.GetUserID(ctx), p.FileName, p.ContentType, p.SizeBytes, p.ChecksumSHA256, p.StorageKey, p.ThumbnailKey,
+	))
+	if err != nil {
+		observability.RecordError("database")
+		return models.TransactionAttachmentModel{}, huma.Error500InternalServerError("Unable to create transaction attachment", err)
+	}
+	observability.RecordQueryDuration("INSERT", "transaction_attachments", time.Since(queryStart).Seconds())
+
+	return item, nil
+}
+
+// Delete soft deletes an attachment; its stored files are kept
+func (tar TransactionAttachmentRepository) Delete(ctx context.Context, transactionID int64, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `UPDATE transaction_attachments
+			SET deleted_at = CURRENT_TIMESTAMP
+			WHERE id = $1 AND transaction_id = $2 AND deleted_at IS NULL`
+
+	queryStart := time.Now()
+	cmdTag, err := tar.db.Exec(ctx, sql, id, transactionID)
+	if err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to delete transaction attachment", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return huma.Error404NotFound("Attachment not found")
+	}
+	observability.RecordQueryDuration("UPDATE", "transaction_attachments", time.Since(queryStart).Seconds())
+
+	return nil
+}
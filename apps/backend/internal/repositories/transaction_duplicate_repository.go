@@ -0,0 +1,238 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+)
+
+type TransactionDuplicateRepository struct {
+	db DBQuerier
+}
+
+func NewTransactionDuplicateRepository(db DBQuerier) TransactionDuplicateRepository {
+	return TransactionDuplicateRepository{db}
+}
+
+// FindMatches returns the current user's other transactions on the same account with the same type and amount,
+// dated within windowDays of the given transaction, newest first
+func (tdr TransactionDuplicateRepository) FindMatches(ctx context.Context, entry models.TransactionDuplicateEntryModel, windowDays int, limit int) ([]models.TransactionDuplicateEntryModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		SELECT id, type, date, amount, account_id, note, created_at
+		FROM transactions
+		WHERE user_id = $1
+			AND deleted_at IS NULL
+			AND id <> $2
+			AND account_id = $3
+			AND type = $4
+			AND amount = $5
+			AND date BETWEEN $6::timestamp - make_interval(days => $7::int) AND $6::timestamp + make_interval(days => $7::int)
+		ORDER BY date DESC, id DESC
+		LIMIT $8`
+
+	queryStart := time.Now()
+	rows, err := tdr.db.Query(ctx, sql, common.GetUserID(ctx), entry.ID, entry.AccountID, entry.Type, entry.Amount, entry.Date, windowDays, limit)
+	if err != nil {
+		observability.RecordError("database")
+		return nil, huma.Error500InternalServerError("Unable to query duplicate transactions", err)
+	}
+	defer rows.Close()
+	observability.RecordQueryDuration("SELECT", "transactions", time.Since(queryStart).Seconds())
+
+	var items []models.TransactionDuplicateEntryModel
+	for rows.Next() {
+		var item models.TransactionDuplicateEntryModel
+		if err := rows.Scan(&item.ID, &item.Type, &item.Date, &item.Amount, &item.AccountID, &item.Note, &item.CreatedAt); err != nil {
+			return nil, huma.Error500InternalServerError("Unable to scan transaction data", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, huma.Error500InternalServerError("Error reading transaction rows", err)
+	}
+
+	return items, nil
+}
+
+// GetPairs returns pairs of the current user's transactions on the same account with the same type and amount,
+// dated within windowDays of each other; the first transaction of a pair is dated within the range
+// Pairs are unscored, the later recorded transaction comes second, and at most limit pairs are returned
+func (tdr TransactionDuplicateRepository) GetPairs(ctx context.Context, startDate, endDate time.Time, accountIDs []int, windowDays int, limit int) ([]models.TransactionDuplicatePairModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		SELECT
+			a.id, a.type, a.date, a.amount, a.account_id, a.note, a.created_at,
+			b.id, b.type, b.date, b.amount, b.account_id, b.note, b.created_at
+		FROM transactions a
+		INNER JOIN transactions b ON b.user_id = a.user_id
+			AND b.account_id = a.account_id
+			AND b.type = a.type
+			AND b.amount = a.amount
+			AND b.id > a.id
+			AND b.deleted_at IS NULL
+			AND b.date BETWEEN a.date - make_interval(days => $4::int) AND a.date + make_interval(days => $4::int)
+		WHERE a.user_id = $1
+			AND a.deleted_at IS NULL
+			AND a.date >= $2
+			AND a.date <= $3
+			AND (array_length($5::int8[], 1) IS NULL OR a.account_id = ANY($5::int8[]))
+		ORDER BY b.date DESC, b.id DESC
+		LIMIT $6`
+
+	queryStart := time.Now()
+	rows, err := tdr.db.Query(ctx, sql, common.GetUserID(ctx), startDate, endDate, windowDays, accountIDs, limit)
+	if err != nil {
+		observability.RecordError("database")
+		return nil, huma.Error500InternalServerError("Unable to query duplicate transactions", err)
+	}
+	defer rows.Close()
+	observability.RecordQueryDuration("SELECT", "transactions", time.Since(queryStart).Seconds())
+
+	var items []models.TransactionDuplicatePairModel
+	for rows.Next() {
+		var item models.TransactionDuplicatePairModel
+		a, b := &item.Transaction, &item.Duplicate
+		if err := rows.Scan(
+			&a.ID, &a.Type, &a.Date, &a.Amount, &a.AccountID, &a.Note, &a.CreatedAt,
+			&b.ID, &b.Type, &b.Date, &b.Amount, &b.AccountID, &b.Note, &b.CreatedAt,
+		); err != nil {
+			return nil, huma.Error500InternalServerError("Unable to scan transaction data", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, huma.Error500InternalServerError("Error reading transaction rows", err)
+	}
+
+	return items, nil
+}
+
+// MoveReferences gives the tags, relations and attachments of a duplicate to the transaction it is merged into
+// Relations between the two, ones the kept transaction already has and single-target relations of a type the kept
+// transaction is already the source of are deleted instead of moved
+// Split lines, the reimbursement and reimbursement matches move too unless the kept transaction already has its own;
+// those stay with the deleted duplicate, where they no longer count
+// It returns how many tags, relations and attachments were moved
+func (tdr TransactionDuplicateRepository) MoveReferences(ctx context.Context, duplicateID, keptID int64) (int64, int64, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	queryStart := time.Now()
+	tagTag, err := tdr.db.Exec(ctx, `
+		INSERT INTO transaction_tags (transaction_id, tag_id)
+		SELECT $2, tag_id FROM transaction_tags WHERE transaction_id = $1
+		ON CONFLICT (transaction_id, tag_id) DO NOTHING`, duplicateID, keptID)
+	if err != nil {
+		observability.RecordError("database")
+		return 0, 0, 0, huma.Error500InternalServerError("Unable to move transaction tags", err)
+	}
+	observability.RecordQueryDuration("INSERT", "transaction_tags", time.Since(queryStart).Seconds())
+
+	queryStart = time.Now()
+	_, err = tdr.db.Exec(ctx, `
+		UPDATE transaction_relations r
+		SET deleted_at = CURRENT_TIMESTAMP,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE r.deleted_at IS NULL
+			AND (
+				(r.source_transaction_id = $1 AND r.related_transaction_id = $2)
+				OR (r.source_transaction_id = $2 AND r.related_transaction_id = $1)
+				OR (r.source_transaction_id = $1 AND EXISTS (
+					SELECT 1 FROM transaction_relations k
+					WHERE k.deleted_at IS NULL AND k.source_transaction_id = $2 AND k.related_transaction_id = r.related_transaction_id
+				))
+				OR (r.related_transaction_id = $1 AND EXISTS (
+					SELECT 1 FROM transaction_relations k
+					WHERE k.deleted_at IS NULL AND k.related_transaction_id = $2 AND k.source_transaction_id = r.source_transaction_id
+				))
+				OR (r.source_transaction_id = $1 AND r.relation_type = ANY($3::text[]) AND EXISTS (
+					SELECT 1 FROM transaction_relations k
+					WHERE k.deleted_at IS NULL AND k.source_transaction_id = $2 AND k.relation_type = r.relation_type
+				))
+			)`, duplicateID, keptID, constants.SingleTargetTransactionRelationTypes)
+	if err != nil {
+		observability.RecordError("database")
+		return 0, 0, 0, huma.Error500InternalServerError("Unable to delete overlapping transaction relations", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "transaction_relations", time.Since(queryStart).Seconds())
+
+	queryStart = time.Now()
+	sourceTag, err := tdr.db.Exec(ctx, `
+		UPDATE transaction_relations
+		SET source_transaction_id = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE source_transaction_id = $1 AND deleted_at IS NULL`, duplicateID, keptID)
+	if err != nil {
+		observability.RecordError("database")
+		return 0, 0, 0, huma.Error500InternalServerError("Unable to move transaction relations", err)
+	}
+	relatedTag, err := tdr.db.Exec(ctx, `
+		UPDATE transaction_relations
+		SET related_transaction_id = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE related_transaction_id = $1 AND deleted_at IS NULL`, duplicateID, keptID)
+	if err != nil {
+		observability.RecordError("database")
+		return 0, 0, 0, huma.Error500InternalServerError("Unable to move transaction relations", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "transaction_relations", time.Since(queryStart).Seconds())
+
+	queryStart = time.Now()
+	attachmentTag, err := tdr.db.Exec(ctx, `
+		UPDATE transaction_attachments
+		SET transaction_id = $2
+		WHERE transaction_id = $1 AND deleted_at IS NULL`, duplicateID, keptID)
+	if err != nil {
+		observability.RecordError("database")
+		return 0, 0, 0, huma.Error500InternalServerError("Unable to move transaction attachments", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "transaction_attachments", time.Since(queryStart).Seconds())
+
+	queryStart = time.Now()
+	if _, err := tdr.db.Exec(ctx, `
+		UPDATE transaction_splits
+		SET transaction_id = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE transaction_id = $1 AND deleted_at IS NULL
+			AND NOT EXISTS (SELECT 1 FROM transaction_splits WHERE transaction_id = $2 AND deleted_at IS NULL)`, duplicateID, keptID); err != nil {
+		observability.RecordError("database")
+		return 0, 0, 0, huma.Error500InternalServerError("Unable to move transaction split lines", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "transaction_splits", time.Since(queryStart).Seconds())
+
+	queryStart = time.Now()
+	if _, err := tdr.db.Exec(ctx, `
+		UPDATE reimbursements
+		SET transaction_id = $2, updated_at = NOW()
+		WHERE transaction_id = $1
+			AND NOT EXISTS (SELECT 1 FROM reimbursements WHERE transaction_id = $2)`, duplicateID, keptID); err != nil {
+		observability.RecordError("database")
+		return 0, 0, 0, huma.Error500InternalServerError("Unable to move reimbursement", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "reimbursements", time.Since(queryStart).Seconds())
+
+	queryStart = time.Now()
+	if _, err := tdr.db.Exec(ctx, `
+		UPDATE reimbursement_matches m
+		SET transaction_id = $2
+		WHERE m.transaction_id = $1
+			AND NOT EXISTS (
+				SELECT 1 FROM reimbursement_matches k
+				WHERE k.reimbursement_id = m.reimbursement_id AND k.transaction_id = $2
+			)`, duplicateID, keptID); err != nil {
+		observability.RecordError("database")
+		return 0, 0, 0, huma.Error500InternalServerError("Unable to move reimbursement matches", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "reimbursement_matches", time.Since(queryStart).Seconds())
+
+	return tagTag.RowsAffected(), sourceTag.RowsAffected() + relatedTag.RowsAffected(), attachmentTag.RowsAffected(), nil
+}
@@ -3,13 +3,16 @@ package repositories
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
 	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
 	"github.com/dimasbaguspm/spenicle-api/internal/observability"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type TransactionRelationRepository struct {
@@ -31,6 +34,7 @@ func (trr TransactionRelationRepository) GetPaged(ctx context.Context, q models.
 			SELECT id, source_transaction_id, related_transaction_id, relation_type, created_at, updated_at, deleted_at
 			FROM transaction_relations
 			WHERE source_transaction_id = $1 AND deleted_at IS NULL
+				AND source_transaction_id IN (SELECT id FROM transactions WHERE user_id = $4)
 		),
 		counted AS (
 			SELECT COUNT(*) AS total_count FROM filtered
@@ -51,7 +55,7 @@ func (trr TransactionRelationRepository) GetPaged(ctx context.Context, q models.
 	`
 
 	queryStart := time.Now()
-	rows, err := trr.db.Query(ctx, sql, q.SourceTransactionID, q.PageSize, offset)
+	rows, err := trr.db.Query(ctx, sql, q.SourceTransactionID, q.PageSize, offset, common.GetUserID(ctx))
 	if err != nil {
 		observability.RecordError("database")
 		return models.TransactionRelationsPagedModel{}, huma.Error500InternalServerError("Unable to query transaction relations", err)
@@ -103,12 +107,13 @@ func (trr TransactionRelationRepository) GetDetail(ctx context.Context, p models
 		FROM transaction_relations
 		WHERE id = $1 
 		AND source_transaction_id = $2
+		AND source_transaction_id IN (SELECT id FROM transactions WHERE user_id = $3)
 		AND deleted_at IS NULL
 		`
 
 	queryStart := time.Now()
 	var item models.TransactionRelationModel
-	err := trr.db.QueryRow(ctx, query, p.RelationID, p.SourceTransactionID).Scan(
+	err := trr.db.QueryRow(ctx, query, p.RelationID, p.SourceTransactionID, common.GetUserID(ctx)).Scan(
 		&item.ID, &item.SourceTransactionID, &item.RelatedTransactionID, &item.RelationType, &item.CreatedAt, &item.UpdatedAt, &item.DeletedAt,
 	)
 
@@ -130,10 +135,10 @@ func (trr TransactionRelationRepository) Create(ctx context.Context, p models.Cr
 
 	sql := `
 		WITH source_check AS (
-			SELECT 1 FROM transactions WHERE id = $1 AND deleted_at IS NULL
+			SELECT 1 FROM transactions WHERE id = $1 AND user_id = $3 AND deleted_at IS NULL
 		),
 		related_check AS (
-			SELECT 1 FROM transactions WHERE id = $2 AND deleted_at IS NULL
+			SELECT 1 FROM transactions WHERE id = $2 AND user_id = $3 AND deleted_at IS NULL
 		),
 		duplicate_check AS (
 			SELECT 1 FROM transaction_relations
@@ -153,7 +158,7 @@ func (trr TransactionRelationRepository) Create(ctx context.Context, p models.Cr
 
 	queryStart := time.Now()
 	var validationStatus string
-	err := trr.db.QueryRow(ctx, sql, p.SourceTransactionID, p.RelatedTransactionID).Scan(&validationStatus)
+	err := trr.db.QueryRow(ctx, sql, p.SourceTransactionID, p.RelatedTransactionID, common.GetUserID(ctx)).Scan(&validationStatus)
 	if err != nil {
 		observability.RecordError("database")
 		return models.TransactionRelationModel{}, huma.Error500InternalServerError("Unable to validate transaction relation", err)
@@ -186,6 +191,10 @@ func (trr TransactionRelationRepository) Create(ctx context.Context, p models.Cr
 	).Scan(&ID, &srcID)
 
 	if insertErr != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(insertErr, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "idx_transaction_relations_single_target" {
+			return models.TransactionRelationModel{}, huma.Error409Conflict(fmt.Sprintf("Transaction is already the source of a %s relation", p.RelationType))
+		}
 		observability.RecordError("database")
 		return models.TransactionRelationModel{}, huma.Error500InternalServerError("Unable to create transaction relation", insertErr)
 	}
@@ -204,10 +213,11 @@ func (trr TransactionRelationRepository) Delete(ctx context.Context, p models.De
 	sql := `UPDATE transaction_relations
 		SET deleted_at = CURRENT_TIMESTAMP,
 			updated_at = CURRENT_TIMESTAMP
-		WHERE id = $1 AND source_transaction_id = $2 AND deleted_at IS NULL`
+		WHERE id = $1 AND source_transaction_id = $2 AND deleted_at IS NULL
+			AND source_transaction_id IN (SELECT id FROM transactions WHERE user_id = $3)`
 
 	queryStart := time.Now()
-	cmdTag, err := trr.db.Exec(ctx, sql, p.RelationID, p.SourceTransactionID)
+	cmdTag, err := trr.db.Exec(ctx, sql, p.RelationID, p.SourceTransactionID, common.GetUserID(ctx))
 	if err != nil {
 		observability.RecordError("database")
 		return huma.Error500InternalServerError("Unable to delete transaction relation", err)
@@ -219,3 +229,159 @@ func (trr TransactionRelationRepository) Delete(ctx context.Context, p models.De
 
 	return nil
 }
+
+// CountBySource returns how many live relations of the type the transaction is the source of
+func (trr TransactionRelationRepository) CountBySource(ctx context.Context, sourceTransactionID int64, relationType string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		SELECT COUNT(*)
+		FROM transaction_relations
+		WHERE source_transaction_id = $1 AND relation_type = $2 AND deleted_at IS NULL`
+
+	queryStart := time.Now()
+	var count int
+	if err := trr.db.QueryRow(ctx, sql, sourceTransactionID, relationType).Scan(&count); err != nil {
+		observability.RecordError("database")
+		return 0, huma.Error500InternalServerError("Unable to count transaction relations", err)
+	}
+	observability.RecordQueryDuration("SELECT", "transaction_relations", time.Since(queryStart).Seconds())
+
+	return count, nil
+}
+
+// Exists reports whether a live relation of the type leads from the source transaction to the related one
+func (trr TransactionRelationRepository) Exists(ctx context.Context, sourceTransactionID, relatedTransactionID int64, relationType string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		SELECT EXISTS (
+			SELECT 1 FROM transaction_relations
+			WHERE source_transaction_id = $1 AND related_transaction_id = $2 AND relation_type = $3 AND deleted_at IS NULL
+		)`
+
+	queryStart := time.Now()
+	var exists bool
+	if err := trr.db.QueryRow(ctx, sql, sourceTransactionID, relatedTransactionID, relationType).Scan(&exists); err != nil {
+		observability.RecordError("database")
+		return false, huma.Error500InternalServerError("Unable to query transaction relations", err)
+	}
+	observability.RecordQueryDuration("SELECT", "transaction_relations", time.Since(queryStart).Seconds())
+
+	return exists, nil
+}
+
+// HasPath reports whether following live relations from source to related leads from one transaction to the other
+// Relations of the undirected types are not followed; relations to deleted transactions are, as those may be restored
+func (trr TransactionRelationRepository) HasPath(ctx context.Context, fromTransactionID, toTransactionID int64, undirectedTypes []string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		WITH RECURSIVE reachable(id) AS (
+			SELECT $1::bigint
+			UNION
+			SELECT r.related_transaction_id
+			FROM reachable
+			INNER JOIN transaction_relations r ON r.source_transaction_id = reachable.id
+			WHERE r.deleted_at IS NULL
+				AND r.relation_type <> ALL($3::text[])
+		)
+		SELECT EXISTS (SELECT 1 FROM reachable WHERE id = $2)`
+
+	queryStart := time.Now()
+	var exists bool
+	if err := trr.db.QueryRow(ctx, sql, fromTransactionID, toTransactionID, undirectedTypes).Scan(&exists); err != nil {
+		observability.RecordError("database")
+		return false, huma.Error500InternalServerError("Unable to query transaction relations", err)
+	}
+	observability.RecordQueryDuration("SELECT", "transaction_relations", time.Since(queryStart).Seconds())
+
+	return exists, nil
+}
+
+// GetGraph returns the live transactions visible to the current user connected to the given one through live relations in
+// either direction, oldest first and at most limit of them, with the relations between them
+func (trr TransactionRelationRepository) GetGraph(ctx context.Context, transactionID int64, limit int) ([]models.TransactionGraphNodeModel, []models.TransactionRelationModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		WITH RECURSIVE component(id) AS (
+			SELECT id FROM transactions WHERE id = $1 AND ` + transactionVisibleSQL("", "$2") + ` AND deleted_at IS NULL
+			UNION
+			SELECT t.id
+			FROM component c
+			INNER JOIN transaction_relations r ON r.deleted_at IS NULL
+				AND (r.source_transaction_id = c.id OR r.related_transaction_id = c.id)
+			INNER JOIN transactions t ON t.deleted_at IS NULL
+				AND ` + transactionVisibleSQL("t.", "$2") + `
+				AND t.id = CASE WHEN r.source_transaction_id = c.id THEN r.related_transaction_id ELSE r.source_transaction_id END
+		)
+		SELECT t.id, t.type, t.date, t.amount, t.account_id, t.note
+		FROM transactions t
+		WHERE t.id IN (SELECT id FROM component)
+		ORDER BY t.date ASC, t.id ASC
+		LIMIT $3`
+
+	queryStart := time.Now()
+	rows, err := trr.db.Query(ctx, sql, transactionID, common.GetUserID(ctx), limit)
+	if err != nil {
+		observability.RecordError("database")
+		return nil, nil, huma.Error500InternalServerError("Unable to query transaction graph", err)
+	}
+	defer rows.Close()
+	observability.RecordQueryDuration("SELECT", "transaction_relations", time.Since(queryStart).Seconds())
+
+	var nodes []models.TransactionGraphNodeModel
+	var ids []int64
+	for rows.Next() {
+		var node models.TransactionGraphNodeModel
+		if err := rows.Scan(&node.ID, &node.Type, &node.Date, &node.Amount, &node.AccountID, &node.Note); err != nil {
+			return nil, nil, huma.Error500InternalServerError("Unable to scan transaction data", err)
+		}
+		nodes = append(nodes, node)
+		ids = append(ids, node.ID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, huma.Error500InternalServerError("Error reading transaction rows", err)
+	}
+	if len(nodes) == 0 {
+		return nil, nil, huma.Error404NotFound("Transaction not found")
+	}
+
+	edgesSQL := `
+		SELECT id, source_transaction_id, related_transaction_id, relation_type, created_at, updated_at, deleted_at
+		FROM transaction_relations
+		WHERE deleted_at IS NULL
+			AND source_transaction_id = ANY($1::int8[])
+			AND related_transaction_id = ANY($1::int8[])
+		ORDER BY id ASC`
+
+	queryStart = time.Now()
+	edgeRows, err := trr.db.Query(ctx, edgesSQL, ids)
+	if err != nil {
+		observability.RecordError("database")
+		return nil, nil, huma.Error500InternalServerError("Unable to query transaction relations", err)
+	}
+	defer edgeRows.Close()
+	observability.RecordQueryDuration("SELECT", "transaction_relations", time.Since(queryStart).Seconds())
+
+	edges := []models.TransactionRelationModel{}
+	for edgeRows.Next() {
+		var edge models.TransactionRelationModel
+		if err := edgeRows.Scan(&edge.ID, &edge.SourceTransactionID, &edge.RelatedTransactionID, &edge.RelationType, &edge.CreatedAt, &edge.UpdatedAt, &edge.DeletedAt); err != nil {
+			return nil, nil, huma.Error500InternalServerError("Unable to scan transaction relation data", err)
+		}
+		edges = append(edges, edge)
+	}
+
+	if err := edgeRows.Err(); err != nil {
+		return nil, nil, huma.Error500InternalServerError("Error reading transaction relation rows", err)
+	}
+
+	return nodes, edges, nil
+}
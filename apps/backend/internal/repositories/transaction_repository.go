@@ -4,15 +4,43 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
 	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
 	"github.com/dimasbaguspm/spenicle-api/internal/observability"
 	"github.com/jackc/pgx/v5"
 )
 
+// transactionVisibleSQL matches transactions the user bound to userParam recorded, or that touch an account they own or that is shared with them
+// prefix qualifies the transaction columns, e.g. "t." inside a joined query
+func transactionVisibleSQL(prefix string, userParam string) string {
+	return `(` + prefix + `user_id = ` + userParam + `
+				OR ` + prefix + `account_id IN (` + accessibleAccountIDsSQL(userParam) + `)
+				OR ` + prefix + `destination_account_id IN (` + accessibleAccountIDsSQL(userParam) + `))`
+}
+
+// transactionSearchQuery turns free text into a prefix tsquery where every word must match, e.g. "coff sho" becomes "coff:* & sho:*"
+// Only letters and digits are kept so user input never reaches the tsquery syntax; nil is returned when nothing is left
+func transactionSearchQuery(q string) *string {
+	words := strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return nil
+	}
+
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	query := strings.Join(words, " & ")
+	return &query
+}
+
 type TransactionRepository struct {
 	db DBQuerier
 }
@@ -32,6 +60,7 @@ func (tr TransactionRepository) GetPaged(ctx context.Context, p models.Transacti
 		"amount":    "amount",
 		"createdAt": "created_at",
 		"updatedAt": "updated_at",
+		"relevance": "search_rank",
 	}
 	sortOrderMap := map[string]string{
 		"asc":  "ASC",
@@ -42,26 +71,72 @@ func (tr TransactionRepository) GetPaged(ctx context.Context, p models.Transacti
 	sortOrder := sortOrderMap[p.SortOrder]
 	offset := (p.PageNumber - 1) * p.PageSize
 
+	// Cursors point at a (date, id) sort key; after and before replace the page number
+	cursorDate, cursorID, err := common.ParseCursorParams(p.SortBy, p.After, p.Before)
+	if err != nil {
+		return models.TransactionsPagedModel{}, err
+	}
+	if cursorDate != nil {
+		offset = 0
+	}
+
+	// search_rank is computed in the CTE rather than read from t, and ties are broken by date
+	innerOrder := `t.` + sortColumn + ` ` + sortOrder
+	outerOrder := `ft.` + sortColumn + ` ` + sortOrder
+	if sortColumn == "search_rank" {
+		innerOrder = `search_rank ` + sortOrder + `, t.date DESC`
+		outerOrder = `ft.search_rank ` + sortOrder + `, ft.date DESC`
+	}
+
+	// Dates are made unique by the id so cursors never skip or repeat rows
+	// A before cursor walks backwards from it, and the page is put back in order by the outer query
+	cursorOperator := "<"
+	if sortColumn == "date" {
+		walkOrder := sortOrder
+		if p.Before != "" {
+			walkOrder = map[string]string{"ASC": "DESC", "DESC": "ASC"}[sortOrder]
+		}
+		if walkOrder == "ASC" {
+			cursorOperator = ">"
+		}
+		innerOrder = `t.date ` + walkOrder + `, t.id ` + walkOrder
+		outerOrder = `ft.date ` + sortOrder + `, ft.id ` + sortOrder
+	}
+
+	totalCountSQL := `COUNT(*) OVER()`
+	if !p.IncludeTotal {
+		totalCountSQL = `0::bigint`
+	}
+
 	sql := `
-		WITH filtered_transactions AS (
+		WITH matched_transactions AS (
 			SELECT 
-				t.id, t.type, t.date, t.amount, t.amount_foreign, t.currency_code, t.exchange_rate, t.exchange_at, t.note, t.latitude, t.longitude, t.created_at, t.updated_at, t.deleted_at,
+				t.id, t.type, t.date, t.amount, t.status, t.amount_foreign, t.currency_code, t.exchange_rate, t.exchange_at, t.note, t.latitude, t.longitude, t.created_at, t.updated_at, t.deleted_at,
 				tt.id as template_id, tt.name as template_name, tt.amount as template_amount, tt.recurrence as template_recurrence, tt.start_date as template_start_date, tt.end_date as template_end_date,
 				a.id as account_id, a.name as account_name, a.type as account_type, a.amount as account_amount, a.icon as account_icon, a.icon_color as account_color,
 				c.id as category_id, c.name as category_name, c.type as category_type, c.icon as category_icon, c.icon_color as category_color,
 				da.id as dest_account_id, da.name as dest_account_name, da.type as dest_account_type, da.amount as dest_account_amount, da.icon as dest_account_icon, da.icon_color as dest_account_color,
-				COUNT(*) OVER() as total_count
+				py.id as payee_id, py.name as payee_name,
+				t.destination_amount, fee.id as fee_id, fee.amount as fee_amount, fee.category_id as fee_category_id,
+				CASE WHEN $16::text IS NULL THEN 0 ELSE ts_rank(t.search_vector, to_tsquery('simple', $16::text)) END as search_rank,
+				` + totalCountSQL + ` as total_count
 			FROM transactions t
 			LEFT JOIN transaction_template_relations r ON r.transaction_id = t.id
 			LEFT JOIN transaction_templates tt ON r.template_id = tt.id
 			LEFT JOIN accounts a ON t.account_id = a.id
 			LEFT JOIN categories c ON t.category_id = c.id
 			LEFT JOIN accounts da ON t.destination_account_id = da.id
+			LEFT JOIN payees py ON t.payee_id = py.id
+			LEFT JOIN transactions fee ON fee.id = t.fee_transaction_id AND fee.deleted_at IS NULL
 			WHERE t.deleted_at IS NULL
 				AND (array_length($3::int8[], 1) IS NULL OR t.id = ANY($3::int8[]))
 				AND (array_length($4::text[], 1) IS NULL OR t.type = ANY($4::text[]))
 				AND (array_length($5::int8[], 1) IS NULL OR t.account_id = ANY($5::int8[]) OR t.destination_account_id = ANY($5::int8[]))
-				AND (array_length($6::int8[], 1) IS NULL OR t.category_id = ANY($6::int8[]))
+				AND (array_length($6::int8[], 1) IS NULL OR t.category_id = ANY($6::int8[]) OR t.id IN (
+						SELECT ts.transaction_id
+						FROM transaction_splits ts
+						WHERE ts.category_id = ANY($6::int8[]) AND ts.deleted_at IS NULL
+					))
 				AND (array_length($7::int8[], 1) IS NULL OR t.destination_account_id = ANY($7::int8[]) OR t.destination_account_id IS NULL)
 				AND ($8::int8 IS NULL OR t.amount >= $8::int8)
 				AND ($9::int8 IS NULL OR t.amount <= $9::int8)
@@ -74,7 +149,16 @@ func (tr TransactionRepository) GetPaged(ctx context.Context, p models.Transacti
 						WHERE tt.tag_id = ANY($13::int8[])
 					))
 				AND (array_length($14::text[], 1) IS NULL OR t.currency_code = ANY($14::text[]))
-			ORDER BY t.` + sortColumn + ` ` + sortOrder + `
+				AND ($16::text IS NULL OR t.search_vector @@ to_tsquery('simple', $16::text))
+				AND (array_length($17::text[], 1) IS NULL OR t.status = ANY($17::text[]))
+				AND (array_length($20::int8[], 1) IS NULL OR t.payee_id = ANY($20::int8[]))
+				AND ` + transactionVisibleSQL("t.", "$15") + `
+		),
+		filtered_transactions AS (
+			SELECT *
+			FROM matched_transactions t
+			WHERE $18::timestamp IS NULL OR (t.date, t.id) ` + cursorOperator + ` ($18::timestamp, $19::int8)
+			ORDER BY ` + innerOrder + `
 			LIMIT $1 OFFSET $2
 		),
 		tags_agg AS (
@@ -84,18 +168,24 @@ func (tr TransactionRepository) GetPaged(ctx context.Context, p models.Transacti
 			INNER JOIN tags t ON tt.tag_id = t.id
 			WHERE tt.transaction_id IN (SELECT id FROM filtered_transactions)
 			GROUP BY tt.transaction_id
+		),
+		splits_agg AS (` + transactionSplitsAggSQL("IN (SELECT id FROM filtered_transactions)") + `
 		)
 		SELECT
-			ft.id, ft.type, ft.date, ft.amount, ft.amount_foreign, ft.currency_code, ft.exchange_rate, ft.exchange_at, ft.note, ft.latitude, ft.longitude, ft.created_at, ft.updated_at, ft.deleted_at,
+			ft.id, ft.type, ft.date, ft.amount, ft.status, ft.amount_foreign, ft.currency_code, ft.exchange_rate, ft.exchange_at, ft.note, ft.latitude, ft.longitude, ft.created_at, ft.updated_at, ft.deleted_at,
 			ft.template_id, ft.template_name, ft.template_amount, ft.template_recurrence, ft.template_start_date, ft.template_end_date,
 			ft.account_id, ft.account_name, ft.account_type, ft.account_amount, ft.account_icon, ft.account_color,
 			ft.category_id, ft.category_name, ft.category_type, ft.category_icon, ft.category_color,
 			ft.dest_account_id, ft.dest_account_name, ft.dest_account_type, ft.dest_account_amount, ft.dest_account_icon, ft.dest_account_color,
+			ft.payee_id, ft.payee_name,
+			ft.destination_amount, ft.fee_id, ft.fee_amount, ft.fee_category_id,
 			COALESCE(ta.tags_json, '[]'::json) as tags_json,
+			sa.splits_json,
 			ft.total_count
 		FROM filtered_transactions ft
 		LEFT JOIN tags_agg ta ON ft.id = ta.transaction_id
-		ORDER BY ft.` + sortColumn + ` ` + sortOrder + `
+		LEFT JOIN splits_agg sa ON ft.id = sa.transaction_id
+		ORDER BY ` + outerOrder + `
 	`
 
 	var (
@@ -106,6 +196,7 @@ func (tr TransactionRepository) GetPaged(ctx context.Context, p models.Transacti
 		destAccountIDs []int64
 		templateIDs    []int64
 		tagIDs         []int64
+		payeeIDs       []int64
 		currencyCodes  []string
 		minAmountParam *int64
 		maxAmountParam *int64
@@ -146,6 +237,11 @@ func (tr TransactionRepository) GetPaged(ctx context.Context, p models.Transacti
 			tagIDs = append(tagIDs, int64(id))
 		}
 	}
+	if len(p.PayeeIDs) > 0 {
+		for _, id := range p.PayeeIDs {
+			payeeIDs = append(payeeIDs, int64(id))
+		}
+	}
 	if len(p.CurrencyCodes) > 0 {
 		currencyCodes = p.CurrencyCodes
 	}
@@ -163,12 +259,18 @@ func (tr TransactionRepository) GetPaged(ctx context.Context, p models.Transacti
 	}
 
 	queryStart := time.Now()
+	// One extra row tells whether another page follows in the direction of travel
 	rows, err := tr.db.Query(ctx, sql,
-		p.PageSize, offset,
+		p.PageSize+1, offset,
 		ids, types, accountIDs, categoryIDs, destAccountIDs,
 		minAmountParam, maxAmountParam,
 		startDateParam, endDateParam,
 		templateIDs, tagIDs, currencyCodes,
+		common.GetUserID(ctx),
+		transactionSearchQuery(p.Q),
+		p.Status,
+		cursorDate, cursorID,
+		payeeIDs,
 	)
 	if err != nil {
 		observability.RecordError("database")
@@ -191,7 +293,13 @@ func (tr TransactionRepository) GetPaged(ctx context.Context, p models.Transacti
 		var destAccountAmount *int64
 		var destAccountIcon *string
 		var destAccountColor *string
+		var payeeID *int64
+		var payeeName *string
+		var feeID *int64
+		var feeAmount *int64
+		var feeCategoryID *int64
 		var tagsJSON []byte
+		var splitsJSON []byte
 		var templateID *int64
 		var templateName *string
 		var templateAmount *int64
@@ -204,18 +312,22 @@ func (tr TransactionRepository) GetPaged(ctx context.Context, p models.Transacti
 		var exchangeAt *time.Time
 
 		err := rows.Scan(
-			&item.ID, &item.Type, &item.Date, &item.Amount, &amountForeign, &currencyCode, &exchangeRate, &exchangeAt, &item.Note, &item.Latitude, &item.Longitude, &item.CreatedAt, &item.UpdatedAt, &item.DeletedAt,
+			&item.ID, &item.Type, &item.Date, &item.Amount, &item.Status, &amountForeign, &currencyCode, &exchangeRate, &exchangeAt, &item.Note, &item.Latitude, &item.Longitude, &item.CreatedAt, &item.UpdatedAt, &item.DeletedAt,
 			&templateID, &templateName, &templateAmount, &templateRecurrence, &templateStartDate, &templateEndDate,
 			&account.ID, &account.Name, &account.Type, &account.Amount, &account.Icon, &account.IconColor,
 			&category.ID, &category.Name, &category.Type, &category.Icon, &category.IconColor,
 			&destAccountID, &destAccountName, &destAccountType, &destAccountAmount, &destAccountIcon, &destAccountColor,
+			&payeeID, &payeeName,
+			&item.DestinationAmount, &feeID, &feeAmount, &feeCategoryID,
 			&tagsJSON,
+			&splitsJSON,
 			&totalCount,
 		)
 		if err != nil {
 			return models.TransactionsPagedModel{}, huma.Error500InternalServerError("Unable to scan transaction data", err)
 		}
 
+		item.ETag = common.BuildETag(item.ID, item.CreatedAt, item.UpdatedAt)
 		item.Account = account
 		item.Category = category
 
@@ -231,6 +343,14 @@ func (tr TransactionRepository) GetPaged(ctx context.Context, p models.Transacti
 			item.DestinationAccount = destAccount
 		}
 
+		if payeeID != nil {
+			item.Payee = &models.TransactionPayeeEmbedded{ID: *payeeID, Name: *payeeName}
+		}
+
+		if feeID != nil {
+			item.Fee = &models.TransactionFeeEmbedded{ID: *feeID, Amount: *feeAmount, CategoryID: *feeCategoryID}
+		}
+
 		item.Tags = []models.TransactionTagEmbedded{}
 		if len(tagsJSON) > 0 {
 			if err := json.Unmarshal(tagsJSON, &item.Tags); err != nil {
@@ -238,6 +358,12 @@ func (tr TransactionRepository) GetPaged(ctx context.Context, p models.Transacti
 			}
 		}
 
+		if len(splitsJSON) > 0 {
+			if err := json.Unmarshal(splitsJSON, &item.Splits); err != nil {
+				return models.TransactionsPagedModel{}, huma.Error500InternalServerError("Unable to parse splits data", err)
+			}
+		}
+
 		if templateID != nil {
 			item.Template = &models.TransactionTemplateEmbedded{
 				ID:         *templateID,
@@ -266,18 +392,44 @@ func (tr TransactionRepository) GetPaged(ctx context.Context, p models.Transacti
 		items = []models.TransactionModel{}
 	}
 
+	// The extra row is the last one going forward, and the first one when walking back from a before cursor
+	hasNext, hasPrev := false, offset > 0 || p.After != ""
+	if p.Before != "" {
+		hasNext = true
+		if len(items) > p.PageSize {
+			hasPrev = true
+			items = items[1:]
+		}
+	} else if len(items) > p.PageSize {
+		hasNext = true
+		items = items[:p.PageSize]
+	}
+
 	totalPages := 0
 	if totalCount > 0 {
 		totalPages = (totalCount + p.PageSize - 1) / p.PageSize
 	}
 
-	return models.TransactionsPagedModel{
+	result := models.TransactionsPagedModel{
 		Items:      items,
 		PageNumber: p.PageNumber,
 		PageSize:   p.PageSize,
 		TotalCount: totalCount,
 		TotalPages: totalPages,
-	}, nil
+	}
+	if sortColumn == "date" && len(items) > 0 {
+		if hasNext {
+			last := items[len(items)-1]
+			nextCursor := common.EncodeCursor(last.Date, last.ID)
+			result.NextCursor = &nextCursor
+		}
+		if hasPrev {
+			first := items[0]
+			prevCursor := common.EncodeCursor(first.Date, first.ID)
+			result.PrevCursor = &prevCursor
+		}
+	}
+	return result, nil
 }
 
 func (tr TransactionRepository) GetDetail(ctx context.Context, id int64) (models.TransactionModel, error) {
@@ -294,7 +446,13 @@ func (tr TransactionRepository) GetDetail(ctx context.Context, id int64) (models
 	var destAccountAmount *int64
 	var destAccountIcon *string
 	var destAccountColor *string
+	var payeeID *int64
+	var payeeName *string
+	var feeID *int64
+	var feeAmount *int64
+	var feeCategoryID *int64
 	var tagsJSON []byte
+	var splitsJSON []byte
 	var templateID *int64
 	var templateName *string
 	var templateAmount *int64
@@ -308,18 +466,22 @@ func (tr TransactionRepository) GetDetail(ctx context.Context, id int64) (models
 
 	sql := `
 		WITH transaction_detail AS (
-			SELECT t.id, t.type, t.date, t.amount, t.amount_foreign, t.currency_code, t.exchange_rate, t.exchange_at, t.note, t.latitude, t.longitude, t.created_at, t.updated_at, t.deleted_at,
+			SELECT t.id, t.type, t.date, t.amount, t.status, t.amount_foreign, t.currency_code, t.exchange_rate, t.exchange_at, t.note, t.latitude, t.longitude, t.created_at, t.updated_at, t.deleted_at,
 				tt.id as template_id, tt.name as template_name, tt.amount as template_amount, tt.recurrence as template_recurrence, tt.start_date as template_start_date, tt.end_date as template_end_date,
 				a.id as account_id, a.name as account_name, a.type as account_type, a.amount as account_amount, a.icon as account_icon, a.icon_color as account_color,
 				c.id as category_id, c.name as category_name, c.type as category_type, c.icon as category_icon, c.icon_color as category_color,
-				da.id as dest_account_id, da.name as dest_account_name, da.type as dest_account_type, da.amount as dest_account_amount, da.icon as dest_account_icon, da.icon_color as dest_account_color
+				da.id as dest_account_id, da.name as dest_account_name, da.type as dest_account_type, da.amount as dest_account_amount, da.icon as dest_account_icon, da.icon_color as dest_account_color,
+				py.id as payee_id, py.name as payee_name,
+				t.destination_amount, fee.id as fee_id, fee.amount as fee_amount, fee.category_id as fee_category_id
 			FROM transactions t
 			LEFT JOIN transaction_template_relations r ON r.transaction_id = t.id
 			LEFT JOIN transaction_templates tt ON r.template_id = tt.id
 			LEFT JOIN accounts a ON t.account_id = a.id
 			LEFT JOIN categories c ON t.category_id = c.id
 			LEFT JOIN accounts da ON t.destination_account_id = da.id
-			WHERE t.id = $1 AND t.deleted_at IS NULL
+			LEFT JOIN payees py ON t.payee_id = py.id
+			LEFT JOIN transactions fee ON fee.id = t.fee_transaction_id AND fee.deleted_at IS NULL
+			WHERE t.id = $1 AND ` + transactionVisibleSQL("t.", "$2") + ` AND t.deleted_at IS NULL
 		),
 		tags_agg AS (
 			SELECT tt.transaction_id,
@@ -328,25 +490,34 @@ func (tr TransactionRepository) GetDetail(ctx context.Context, id int64) (models
 			INNER JOIN tags t ON tt.tag_id = t.id
 			WHERE tt.transaction_id = $1
 			GROUP BY tt.transaction_id
+		),
+		splits_agg AS (` + transactionSplitsAggSQL("= $1") + `
 		)
 		SELECT
-			td.id, td.type, td.date, td.amount, td.amount_foreign, td.currency_code, td.exchange_rate, td.exchange_at, td.note, td.latitude, td.longitude, td.created_at, td.updated_at, td.deleted_at,
+			td.id, td.type, td.date, td.amount, td.status, td.amount_foreign, td.currency_code, td.exchange_rate, td.exchange_at, td.note, td.latitude, td.longitude, td.created_at, td.updated_at, td.deleted_at,
 			td.template_id, td.template_name, td.template_amount, td.template_recurrence, td.template_start_date, td.template_end_date,
 			td.account_id, td.account_name, td.account_type, td.account_amount, td.account_icon, td.account_color,
 			td.category_id, td.category_name, td.category_type, td.category_icon, td.category_color,
 			td.dest_account_id, td.dest_account_name, td.dest_account_type, td.dest_account_amount, td.dest_account_icon, td.dest_account_color,
-			COALESCE(ta.tags_json, '[]'::json) as tags_json
+			td.payee_id, td.payee_name,
+			td.destination_amount, td.fee_id, td.fee_amount, td.fee_category_id,
+			COALESCE(ta.tags_json, '[]'::json) as tags_json,
+			sa.splits_json
 		FROM transaction_detail td
-		LEFT JOIN tags_agg ta ON td.id = ta.transaction_id`
+		LEFT JOIN tags_agg ta ON td.id = ta.transaction_id
+		LEFT JOIN splits_agg sa ON td.id = sa.transaction_id`
 
 	queryStart := time.Now()
-	err := tr.db.QueryRow(ctx, sql, id).Scan(
-		&item.ID, &item.Type, &item.Date, &item.Amount, &amountForeign, &currencyCode, &exchangeRate, &exchangeAt, &item.Note, &item.Latitude, &item.Longitude, &item.CreatedAt, &item.UpdatedAt, &item.DeletedAt,
+	err := tr.db.QueryRow(ctx, sql, id, common.GetUserID(ctx)).Scan(
+		&item.ID, &item.Type, &item.Date, &item.Amount, &item.Status, &amountForeign, &currencyCode, &exchangeRate, &exchangeAt, &item.Note, &item.Latitude, &item.Longitude, &item.CreatedAt, &item.UpdatedAt, &item.DeletedAt,
 		&templateID, &templateName, &templateAmount, &templateRecurrence, &templateStartDate, &templateEndDate,
 		&account.ID, &account.Name, &account.Type, &account.Amount, &account.Icon, &account.IconColor,
 		&category.ID, &category.Name, &category.Type, &category.Icon, &category.IconColor,
 		&destAccountID, &destAccountName, &destAccountType, &destAccountAmount, &destAccountIcon, &destAccountColor,
+		&payeeID, &payeeName,
+		&item.DestinationAmount, &feeID, &feeAmount, &feeCategoryID,
 		&tagsJSON,
+		&splitsJSON,
 	)
 
 	if err != nil {
@@ -358,6 +529,7 @@ func (tr TransactionRepository) GetDetail(ctx context.Context, id int64) (models
 	}
 	observability.RecordQueryDuration("SELECT", "transactions", time.Since(queryStart).Seconds())
 
+	item.ETag = common.BuildETag(item.ID, item.CreatedAt, item.UpdatedAt)
 	item.Account = account
 	item.Category = category
 
@@ -373,6 +545,14 @@ func (tr TransactionRepository) GetDetail(ctx context.Context, id int64) (models
 		item.DestinationAccount = destAccount
 	}
 
+	if payeeID != nil {
+		item.Payee = &models.TransactionPayeeEmbedded{ID: *payeeID, Name: *payeeName}
+	}
+
+	if feeID != nil {
+		item.Fee = &models.TransactionFeeEmbedded{ID: *feeID, Amount: *feeAmount, CategoryID: *feeCategoryID}
+	}
+
 	item.Tags = []models.TransactionTagEmbedded{}
 	if len(tagsJSON) > 0 {
 		if err := json.Unmarshal(tagsJSON, &item.Tags); err != nil {
@@ -380,6 +560,12 @@ func (tr TransactionRepository) GetDetail(ctx context.Context, id int64) (models
 		}
 	}
 
+	if len(splitsJSON) > 0 {
+		if err := json.Unmarshal(splitsJSON, &item.Splits); err != nil {
+			return models.TransactionModel{}, huma.Error500InternalServerError("Unable to parse splits data", err)
+		}
+	}
+
 	if templateID != nil {
 		item.Template = &models.TransactionTemplateEmbedded{
 			ID:         *templateID,
@@ -400,18 +586,41 @@ func (tr TransactionRepository) GetDetail(ctx context.Context, id int64) (models
 	return item, nil
 }
 
+// GetDetailForUpdate locks the transaction until the surrounding database transaction ends, so its version cannot
+// change between checking If-Match and writing
+func (tr TransactionRepository) GetDetailForUpdate(ctx context.Context, id int64) (models.TransactionModel, error) {
+	lockCtx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `SELECT t.id FROM transactions t WHERE t.id = $1 AND ` + transactionVisibleSQL("t.", "$2") + ` AND t.deleted_at IS NULL FOR UPDATE OF t`
+
+	queryStart := time.Now()
+	var ID int64
+	err := tr.db.QueryRow(lockCtx, sql, id, common.GetUserID(ctx)).Scan(&ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.TransactionModel{}, huma.Error404NotFound("Transaction not found")
+		}
+		observability.RecordError("database")
+		return models.TransactionModel{}, huma.Error500InternalServerError("Unable to lock transaction", err)
+	}
+	observability.RecordQueryDuration("SELECT", "transactions", time.Since(queryStart).Seconds())
+
+	return tr.GetDetail(ctx, ID)
+}
+
 func (tr TransactionRepository) Create(ctx context.Context, p models.CreateTransactionModel, amountForeign *int64, exchangeRate *float64, exchangeAt *time.Time) (models.TransactionModel, error) {
 	var id int64
 
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
 
-	sql := `INSERT INTO transactions (type, date, amount, amount_foreign, currency_code, exchange_rate, exchange_at, account_id, category_id, destination_account_id, note, latitude, longitude)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	sql := `INSERT INTO transactions (type, date, amount, amount_foreign, currency_code, exchange_rate, exchange_at, account_id, category_id, destination_account_id, note, latitude, longitude, user_id, status, payee_id, destination_amount)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, COALESCE($15, 'pending'), $16, $17)
 			RETURNING id`
 
 	queryStart := time.Now()
-	err := tr.db.QueryRow(ctx, sql, p.Type, p.Date, p.Amount, amountForeign, p.CurrencyCode, exchangeRate, exchangeAt, p.AccountID, p.CategoryID, p.DestinationAccountID, p.Note, p.Latitude, p.Longitude).Scan(&id)
+	err := tr.db.QueryRow(ctx, sql, p.Type, p.Date, p.Amount, amountForeign, p.CurrencyCode, exchangeRate, exchangeAt, p.AccountID, p.CategoryID, p.DestinationAccountID, p.Note, p.Latitude, p.Longitude, common.GetUserID(ctx), p.Status, p.PayeeID, p.DestinationAmount).Scan(&id)
 
 	if err != nil {
 		observability.RecordError("database")
@@ -436,11 +645,14 @@ func (tr TransactionRepository) Update(ctx context.Context, id int64, p models.U
 				note = COALESCE($7, note),
 				latitude = COALESCE($8, latitude),
 				longitude = COALESCE($9, longitude),
+				status = COALESCE($12, status),
+				payee_id = CASE WHEN $13::int8 IS NULL THEN payee_id ELSE NULLIF($13::int8, 0) END,
+				destination_amount = CASE WHEN $14::int8 IS NULL THEN destination_amount ELSE NULLIF($14::int8, 0) END,
 				updated_at = CURRENT_TIMESTAMP
-			WHERE id = $10 AND deleted_at IS NULL`
+			WHERE id = $10 AND ` + transactionVisibleSQL("", "$11") + ` AND deleted_at IS NULL`
 
 	queryStart := time.Now()
-	cmdTag, err := tr.db.Exec(ctx, sql, p.Type, p.Date, p.Amount, p.AccountID, p.CategoryID, p.DestinationAccountID, p.Note, p.Latitude, p.Longitude, id)
+	cmdTag, err := tr.db.Exec(ctx, sql, p.Type, p.Date, p.Amount, p.AccountID, p.CategoryID, p.DestinationAccountID, p.Note, p.Latitude, p.Longitude, id, common.GetUserID(ctx), p.Status, p.PayeeID, p.DestinationAmount)
 
 	if err != nil {
 		observability.RecordError("database")
@@ -460,10 +672,10 @@ func (tr TransactionRepository) Delete(ctx context.Context, id int64) error {
 	defer cancel()
 	sql := `UPDATE transactions
 			SET deleted_at = CURRENT_TIMESTAMP
-			WHERE id = $1 AND deleted_at IS NULL`
+			WHERE id = $1 AND ` + transactionVisibleSQL("", "$2") + ` AND deleted_at IS NULL`
 
 	queryStart := time.Now()
-	cmdTag, err := tr.db.Exec(ctx, sql, id)
+	cmdTag, err := tr.db.Exec(ctx, sql, id, common.GetUserID(ctx))
 	if err != nil {
 		observability.RecordError("database")
 		return huma.Error500InternalServerError("Unable to delete transaction", err)
@@ -473,9 +685,127 @@ func (tr TransactionRepository) Delete(ctx context.Context, id int64) error {
 	}
 	observability.RecordQueryDuration("DELETE", "transactions", time.Since(queryStart).Seconds())
 
+	// Attachments share the deletion timestamp of their transaction
+	attachmentsSQL := `UPDATE transaction_attachments
+			SET deleted_at = (SELECT deleted_at FROM transactions WHERE id = $1)
+			WHERE transaction_id = $1 AND deleted_at IS NULL`
+
+	queryStart = time.Now()
+	if _, err := tr.db.Exec(ctx, attachmentsSQL, id); err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to delete transaction attachments", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "transaction_attachments", time.Since(queryStart).Seconds())
+
+	return nil
+}
+
+// Restore brings a deleted transaction back from the trash together with the attachments deleted along with it
+// Balances are not touched; callers reapply them
+func (tr TransactionRepository) Restore(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	// The deleted_at check on the updated row keeps concurrent restores from both succeeding
+	sql := `UPDATE transactions t
+			SET deleted_at = NULL, updated_at = CURRENT_TIMESTAMP
+			FROM (
+				SELECT id, deleted_at FROM transactions
+				WHERE id = $1 AND deleted_at IS NOT NULL AND ` + transactionVisibleSQL("", "$2") + `
+			) old
+			WHERE t.id = old.id AND t.deleted_at IS NOT NULL
+			RETURNING old.deleted_at`
+
+	var deletedAt time.Time
+	queryStart := time.Now()
+	err := tr.db.QueryRow(ctx, sql, id, common.GetUserID(ctx)).Scan(&deletedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return huma.Error404NotFound("Deleted transaction not found")
+	}
+	if err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to restore transaction", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "transactions", time.Since(queryStart).Seconds())
+
+	attachmentsSQL := `UPDATE transaction_attachments
+			SET deleted_at = NULL
+			WHERE transaction_id = $1 AND deleted_at = $2`
+
+	queryStart = time.Now()
+	if _, err := tr.db.Exec(ctx, attachmentsSQL, id, deletedAt); err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to restore transaction attachments", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "transaction_attachments", time.Since(queryStart).Seconds())
+
 	return nil
 }
 
+// SetFeeTransaction links the expense recording the fee of a transfer, or unlinks it when feeID is nil
+func (tr TransactionRepository) SetFeeTransaction(ctx context.Context, id int64, feeID *int64) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `UPDATE transactions
+			SET fee_transaction_id = $1, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $2 AND ` + transactionVisibleSQL("", "$3") + ` AND deleted_at IS NULL`
+
+	queryStart := time.Now()
+	cmdTag, err := tr.db.Exec(ctx, sql, feeID, id, common.GetUserID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to link transfer fee", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return huma.Error404NotFound("Transaction not found")
+	}
+	observability.RecordQueryDuration("UPDATE", "transactions", time.Since(queryStart).Seconds())
+
+	return nil
+}
+
+// RestoreFee brings back the fee of a deleted transfer when it was deleted together with the transfer,
+// along with its attachments; call it before restoring the transfer itself
+// Returns the ID of the restored fee, or nil when there was none to restore. Balances are not touched
+func (tr TransactionRepository) RestoreFee(ctx context.Context, id int64) (*int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `UPDATE transactions f
+			SET deleted_at = NULL, updated_at = CURRENT_TIMESTAMP
+			FROM transactions t
+			WHERE t.id = $1 AND t.deleted_at IS NOT NULL AND ` + transactionVisibleSQL("t.", "$2") + `
+				AND f.id = t.fee_transaction_id AND f.deleted_at = t.deleted_at
+			RETURNING f.id, t.deleted_at`
+
+	var feeID int64
+	var deletedAt time.Time
+	queryStart := time.Now()
+	err := tr.db.QueryRow(ctx, sql, id, common.GetUserID(ctx)).Scan(&feeID, &deletedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		observability.RecordError("database")
+		return nil, huma.Error500InternalServerError("Unable to restore transfer fee", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "transactions", time.Since(queryStart).Seconds())
+
+	attachmentsSQL := `UPDATE transaction_attachments
+			SET deleted_at = NULL
+			WHERE transaction_id = $1 AND deleted_at = $2`
+
+	queryStart = time.Now()
+	if _, err := tr.db.Exec(ctx, attachmentsSQL, feeID, deletedAt); err != nil {
+		observability.RecordError("database")
+		return nil, huma.Error500InternalServerError("Unable to restore transfer fee attachments", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "transaction_attachments", time.Since(queryStart).Seconds())
+
+	return &feeID, nil
+}
+
 // GetGeotaggedTransactions fetches transactions with geolocation data, ordered by distance if coordinates provided
 func (tr TransactionRepository) GetGeotaggedTransactions(ctx context.Context, latitude *float64, longitude *float64, limit int) ([]models.TransactionModel, error) {
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
@@ -486,7 +816,7 @@ func (tr TransactionRepository) GetGeotaggedTransactions(ctx context.Context, la
 
 	baseSQL := `
 		SELECT 
-			t.id, t.type, t.date, t.amount, t.note, t.latitude, t.longitude, t.created_at, t.updated_at, t.deleted_at,
+			t.id, t.type, t.date, t.amount, t.status, t.note, t.latitude, t.longitude, t.created_at, t.updated_at, t.deleted_at,
 			tt.id as template_id, tt.name as template_name, tt.amount as template_amount, tt.recurrence as template_recurrence, tt.start_date as template_start_date, tt.end_date as template_end_date,
 			a.id as account_id, a.name as account_name, a.type as account_type, a.amount as account_amount, a.icon as account_icon, a.icon_color as account_color,
 			c.id as category_id, c.name as category_name, c.type as category_type, c.icon as category_icon, c.icon_color as category_color,
@@ -497,18 +827,18 @@ func (tr TransactionRepository) GetGeotaggedTransactions(ctx context.Context, la
 		LEFT JOIN accounts a ON t.account_id = a.id
 		LEFT JOIN categories c ON t.category_id = c.id
 		LEFT JOIN accounts da ON t.destination_account_id = da.id
-		WHERE t.deleted_at IS NULL AND t.latitude IS NOT NULL AND t.longitude IS NOT NULL
+		WHERE t.deleted_at IS NULL AND t.latitude IS NOT NULL AND t.longitude IS NOT NULL AND ` + transactionVisibleSQL("t.", "$1") + `
 	`
 
 	if latitude != nil && longitude != nil {
 		// Order by approximate distance using Pythagorean theorem
 		// This is a simple approximation and not accurate for long distances
-		sql = baseSQL + ` ORDER BY SQRT(POW(t.latitude - $1, 2) + POW(t.longitude - $2, 2)) ASC LIMIT $3`
-		args = []any{*latitude, *longitude, limit}
+		sql = baseSQL + ` ORDER BY SQRT(POW(t.latitude - $2, 2) + POW(t.longitude - $3, 2)) ASC LIMIT $4`
+		args = []any{common.GetUserID(ctx), *latitude, *longitude, limit}
 	} else {
 		// Default order by creation date descending
-		sql = baseSQL + ` ORDER BY t.created_at DESC LIMIT $1`
-		args = []any{limit}
+		sql = baseSQL + ` ORDER BY t.created_at DESC LIMIT $2`
+		args = []any{common.GetUserID(ctx), limit}
 	}
 
 	rows, err := tr.db.Query(ctx, sql, args...)
@@ -534,7 +864,7 @@ func (tr TransactionRepository) GetGeotaggedTransactions(ctx context.Context, la
 		var destAccountColor *string
 
 		err := rows.Scan(
-			&item.ID, &item.Type, &item.Date, &item.Amount, &item.Note, &item.Latitude, &item.Longitude, &item.CreatedAt, &item.UpdatedAt, &item.DeletedAt,
+			&item.ID, &item.Type, &item.Date, &item.Amount, &item.Status, &item.Note, &item.Latitude, &item.Longitude, &item.CreatedAt, &item.UpdatedAt, &item.DeletedAt,
 			&templateID, &templateName, &templateAmount, &templateRecurrence, &templateStartDate, &templateEndDate,
 			&item.Account.ID, &item.Account.Name, &item.Account.Type, &item.Account.Amount, &item.Account.Icon, &item.Account.IconColor,
 			&item.Category.ID, &item.Category.Name, &item.Category.Type, &item.Category.Icon, &item.Category.IconColor,
@@ -582,9 +912,9 @@ func (tr TransactionRepository) UpdateAccountBalance(ctx context.Context, accoun
 
 	sql := `UPDATE accounts
 			SET amount = amount + $1
-			WHERE id = $2 AND deleted_at IS NULL`
+			WHERE id = $2 AND user_id = $3 AND deleted_at IS NULL`
 
-	cmdTag, err := tr.db.Exec(ctx, sql, deltaAmount, accountID)
+	cmdTag, err := tr.db.Exec(ctx, sql, deltaAmount, accountID, common.GetUserID(ctx))
 	if err != nil {
 		return huma.Error500InternalServerError("Unable to update account balance", err)
 	}
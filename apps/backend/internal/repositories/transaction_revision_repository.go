@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/jackc/pgx/v5"
+)
+
+const transactionRevisionColumnsSQL = `r.id, r.transaction_id, r.revision, r.actor_id, r.changed_fields, r.before, r.after, r.balance_deltas, r.created_at`
+
+type TransactionRevisionRepository struct {
+	db DBQuerier
+}
+
+func NewTransactionRevisionRepository(db DBQuerier) TransactionRevisionRepository {
+	return TransactionRevisionRepository{db}
+}
+
+func scanTransactionRevision(row pgx.Row) (models.TransactionRevisionModel, error) {
+	var item models.TransactionRevisionModel
+	err := row.Scan(&item.ID, &item.TransactionID, &item.Revision, &item.ActorID, &item.ChangedFields, &item.Before, &item.After, &item.BalanceDeltas, &item.CreatedAt)
+	return item, err
+}
+
+func (trr TransactionRevisionRepository) GetList(ctx context.Context, transactionID int64) (models.TransactionRevisionsListModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `SELECT ` + transactionRevisionColumnsSQL + `
+			FROM transaction_revisions r
+			INNER JOIN transactions t ON r.transaction_id = t.id
+			WHERE r.transaction_id = $1
+				AND t.deleted_at IS NULL
+				AND ` + transactionVisibleSQL("t.", "$2") + `
+			ORDER BY r.revision DESC`
+
+	queryStart := time.Now()
+	rows, err := trr.db.Query(ctx, sql, transactionID, common.GetUserID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return models.TransactionRevisionsListModel{}, huma.Error500InternalServerError("Unable to query transaction revisions", err)
+	}
+	defer rows.Close()
+	observability.RecordQueryDuration("SELECT", "transaction_revisions", time.Since(queryStart).Seconds())
+
+	items := []models.TransactionRevisionModel{}
+	for rows.Next() {
+		item, err := scanTransactionRevision(rows)
+		if err != nil {
+			return models.TransactionRevisionsListModel{}, huma.Error500InternalServerError("Unable to scan transaction revision data", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.TransactionRevisionsListModel{}, huma.Error500InternalServerError("Error reading transaction revision rows", err)
+	}
+
+	return models.TransactionRevisionsListModel{Items: items}, nil
+}
+
+func (trr TransactionRevisionRepository) GetDetail(ctx context.Context, transactionID int64, revision int) (models.TransactionRevisionModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `SELECT ` + transactionRevisionColumnsSQL + `
+			FROM transaction_revisions r
+			INNER JOIN transactions t ON r.transaction_id = t.id
+			WHERE r.transaction_id = $1
+				AND r.revision = $2
+				AND t.deleted_at IS NULL
+				AND ` + transactionVisibleSQL("t.", "$3")
+
+	queryStart := time.Now()
+	item, err := scanTransactionRevision(trr.db.QueryRow(ctx, sql, transactionID, revision, common.GetUserID(ctx)))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.TransactionRevisionModel{}, huma.Error404NotFound("Revision not found")
+	}
+	if err != nil {
+		observability.RecordError("database")
+		return models.TransactionRevisionModel{}, huma.Error500InternalServerError("Unable to query transaction revision", err)
+	}
+	observability.RecordQueryDuration("SELECT", "transaction_revisions", time.Since(queryStart).Seconds())
+
+	return item, nil
+}
+
+// Create appends the next revision of a transaction
+// Callers have just updated the transaction in the same database transaction, so its row lock orders concurrent revisions
+func (trr TransactionRevisionRepository) Create(ctx context.Context, p models.CreateTransactionRevisionModel) (models.TransactionRevisionModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `INSERT INTO transaction_revisions AS r (transaction_id, revision, actor_id, changed_fields, before, after, balance_deltas)
+			VALUES ($1, COALESCE((SELECT MAX(revision) FROM transaction_revisions WHERE transaction_id = $1), 0) + 1, $2, $3, $4, $5, $6)
+			RETURNING ` + transactionRevisionColumnsSQL
+
+	var actorID *int64
+	if userID := common.GetUserID(ctx); userID != 0 {
+		actorID = &userID
+	}
+	balanceDeltas := p.BalanceDeltas
+	if balanceDeltas == nil {
+		balanceDeltas = []models.TransactionRevisionBalanceDeltaModel{}
+	}
+
+	queryStart := time.Now()
+	item, err := scanTransactionRevision(trr.db.QueryRow(ctx, sql, p.TransactionID, actorID, p.ChangedFields, p.Before, p.After, balanceDeltas))
+	if err != nil {
+		observability.RecordError("database")
+		return models.TransactionRevisionModel{}, huma.Error500InternalServerError("Unable to record transaction revision", err)
+	}
+	observability.RecordQueryDuration("INSERT", "transaction_revisions", time.Since(queryStart).Seconds())
+
+	return item, nil
+}
@@ -0,0 +1,399 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/jackc/pgx/v5"
+)
+
+type TransactionRuleRepository struct {
+	db DBQuerier
+}
+
+func NewTransactionRuleRepository(db DBQuerier) TransactionRuleRepository {
+	return TransactionRuleRepository{db}
+}
+
+const transactionRuleColumnsSQL = `
+	r.id, r.name, r.priority, r.enabled,
+	r.note_pattern, r.min_amount, r.max_amount, r.account_id, r.type, r.currency_code,
+	r.latitude, r.longitude, r.radius_meters,
+	r.set_category_id, r.add_tag_ids, r.set_note, r.set_destination_account_id,
+	r.created_at, r.updated_at`
+
+// transactionRuleEvaluableColumnsSQL selects the same columns but drops actions pointing at deleted categories, tags or accounts
+const transactionRuleEvaluableColumnsSQL = `
+	r.id, r.name, r.priority, r.enabled,
+	r.note_pattern, r.min_amount, r.max_amount, r.account_id, r.type, r.currency_code,
+	r.latitude, r.longitude, r.radius_meters,
+	c.id,
+	ARRAY(SELECT tg.id FROM tags tg WHERE tg.id = ANY(r.add_tag_ids) AND tg.user_id = r.user_id AND tg.deleted_at IS NULL ORDER BY tg.id),
+	r.set_note, da.id,
+	r.created_at, r.updated_at`
+
+func scanTransactionRule(row pgx.Row) (models.TransactionRuleModel, error) {
+	var item models.TransactionRuleModel
+	err := row.Scan(
+		&item.ID, &item.Name, &item.Priority, &item.Enabled,
+		&item.Conditions.NotePattern, &item.Conditions.MinAmount, &item.Conditions.MaxAmount, &item.Conditions.AccountID,
+		&item.Conditions.Type, &item.Conditions.CurrencyCode,
+		&item.Conditions.Latitude, &item.Conditions.Longitude, &item.Conditions.RadiusMeters,
+		&item.Actions.CategoryID, &item.Actions.TagIDs, &item.Actions.Note, &item.Actions.DestinationAccountID,
+		&item.CreatedAt, &item.UpdatedAt,
+	)
+	if len(item.Actions.TagIDs) == 0 {
+		item.Actions.TagIDs = nil
+	}
+	return item, err
+}
+
+func (trr TransactionRuleRepository) GetPaged(ctx context.Context, query models.TransactionRulesSearchModel) (models.TransactionRulesPagedModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	offset := (query.PageNumber - 1) * query.PageSize
+
+	sql := `
+		SELECT ` + transactionRuleColumnsSQL + `,
+			COUNT(*) OVER() as total_count
+		FROM transaction_rules r
+		WHERE r.user_id = $4
+			AND ($1::text IS NULL OR $1::text = '' OR r.name ILIKE '%' || $1::text || '%')
+		ORDER BY r.priority ASC, r.id ASC
+		LIMIT $2 OFFSET $3`
+
+	queryStart := time.Now()
+	rows, err := trr.db.Query(ctx, sql, query.Name, query.PageSize, offset, common.GetUserID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return models.TransactionRulesPagedModel{}, huma.Error400BadRequest("Unable to query transaction rules", err)
+	}
+	defer rows.Close()
+	observability.RecordQueryDuration("SELECT", "transaction_rules", time.Since(queryStart).Seconds())
+
+	var items []models.TransactionRuleModel
+	var totalCount int
+	for rows.Next() {
+		var item models.TransactionRuleModel
+		err := rows.Scan(
+			&item.ID, &item.Name, &item.Priority, &item.Enabled,
+			&item.Conditions.NotePattern, &item.Conditions.MinAmount, &item.Conditions.MaxAmount, &item.Conditions.AccountID,
+			&item.Conditions.Type, &item.Conditions.CurrencyCode,
+			&item.Conditions.Latitude, &item.Conditions.Longitude, &item.Conditions.RadiusMeters,
+			&item.Actions.CategoryID, &item.Actions.TagIDs, &item.Actions.Note, &item.Actions.DestinationAccountID,
+			&item.CreatedAt, &item.UpdatedAt, &totalCount,
+		)
+		if err != nil {
+			return models.TransactionRulesPagedModel{}, huma.Error400BadRequest("Unable to scan transaction rule data", err)
+		}
+		if len(item.Actions.TagIDs) == 0 {
+			item.Actions.TagIDs = nil
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.TransactionRulesPagedModel{}, huma.Error400BadRequest("Error reading transaction rule rows", err)
+	}
+
+	if items == nil {
+		items = []models.TransactionRuleModel{}
+	}
+
+	totalPages := 0
+	if totalCount > 0 {
+		totalPages = (totalCount + query.PageSize - 1) / query.PageSize
+	}
+
+	return models.TransactionRulesPagedModel{
+		Items:      items,
+		PageNumber: query.PageNumber,
+		PageSize:   query.PageSize,
+		TotalCount: totalCount,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (trr TransactionRuleRepository) GetDetail(ctx context.Context, id int64) (models.TransactionRuleModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		SELECT ` + transactionRuleColumnsSQL + `
+		FROM transaction_rules r
+		WHERE r.id = $1
+			AND r.user_id = $2`
+
+	queryStart := time.Now()
+	data, err := scanTransactionRule(trr.db.QueryRow(ctx, sql, id, common.GetUserID(ctx)))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.TransactionRuleModel{}, huma.Error404NotFound("Transaction rule not found")
+		}
+		observability.RecordError("database")
+		return models.TransactionRuleModel{}, huma.Error500InternalServerError("Unable to query transaction rule", err)
+	}
+	observability.RecordQueryDuration("SELECT", "transaction_rules", time.Since(queryStart).Seconds())
+
+	return data, nil
+}
+
+// GetEvaluable returns the rules to evaluate in priority order: all enabled rules, or only the given one even when disabled
+// Actions pointing at categories, tags or accounts that were deleted since the rule was saved are left out
+func (trr TransactionRuleRepository) GetEvaluable(ctx context.Context, ruleID *int64) ([]models.TransactionRuleModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		SELECT ` + transactionRuleEvaluableColumnsSQL + `
+		FROM transaction_rules r
+		LEFT JOIN categories c ON c.id = r.set_category_id AND c.deleted_at IS NULL
+		LEFT JOIN accounts da ON da.id = r.set_destination_account_id AND da.deleted_at IS NULL
+		WHERE r.user_id = $1
+			AND (($2::int8 IS NULL AND r.enabled) OR r.id = $2::int8)
+		ORDER BY r.priority ASC, r.id ASC`
+
+	queryStart := time.Now()
+	rows, err := trr.db.Query(ctx, sql, common.GetUserID(ctx), ruleID)
+	if err != nil {
+		observability.RecordError("database")
+		return nil, huma.Error500InternalServerError("Unable to query transaction rules", err)
+	}
+	defer rows.Close()
+	observability.RecordQueryDuration("SELECT", "transaction_rules", time.Since(queryStart).Seconds())
+
+	var items []models.TransactionRuleModel
+	for rows.Next() {
+		item, err := scanTransactionRule(rows)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Unable to scan transaction rule data", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, huma.Error500InternalServerError("Error reading transaction rule rows", err)
+	}
+
+	return items, nil
+}
+
+func (trr TransactionRuleRepository) Create(ctx context.Context, payload models.CreateTransactionRuleModel) (models.TransactionRuleModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	var ID int64
+
+	enabled := true
+	if payload.Enabled != nil {
+		enabled = *payload.Enabled
+	}
+	tagIDs := payload.Actions.TagIDs
+	if tagIDs == nil {
+		tagIDs = []int64{}
+	}
+
+	sql := `
+		INSERT INTO transaction_rules (
+			name, priority, enabled,
+			note_pattern, min_amount, max_amount, account_id, type, currency_code,
+			latitude, longitude, radius_meters,
+			set_category_id, add_tag_ids, set_note, set_destination_account_id,
+			user_id
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+		RETURNING id`
+
+	c := payload.Conditions
+	a := payload.Actions
+	queryStart := time.Now()
+	err := trr.db.QueryRow(ctx, sql,
+		payload.Name, payload.Priority, enabled,
+		c.NotePattern, c.MinAmount, c.MaxAmount, c.AccountID, c.Type, c.CurrencyCode,
+		c.Latitude, c.Longitude, c.RadiusMeters,
+		a.CategoryID, tagIDs, a.Note, a.DestinationAccountID,
+		common.GetUserID(ctx),
+	).Scan(&ID)
+
+	if err != nil {
+		observability.RecordError("database")
+		return models.TransactionRuleModel{}, huma.Error500InternalServerError("Unable to create transaction rule", err)
+	}
+	observability.RecordQueryDuration("INSERT", "transaction_rules", time.Since(queryStart).Seconds())
+
+	return trr.GetDetail(ctx, ID)
+}
+
+// Update changes the given fields; conditions and actions are replaced as a whole when provided
+func (trr TransactionRuleRepository) Update(ctx context.Context, id int64, payload models.UpdateTransactionRuleModel) (models.TransactionRuleModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	var ID int64
+
+	var c models.TransactionRuleConditionsModel
+	if payload.Conditions != nil {
+		c = *payload.Conditions
+	}
+	var a models.TransactionRuleActionsModel
+	if payload.Actions != nil {
+		a = *payload.Actions
+	}
+	tagIDs := a.TagIDs
+	if tagIDs == nil {
+		tagIDs = []int64{}
+	}
+
+	sql := `
+		UPDATE transaction_rules
+		SET name = COALESCE($1, name),
+			priority = COALESCE($2, priority),
+			enabled = COALESCE($3, enabled),
+			note_pattern = CASE WHEN $4::boolean THEN $5 ELSE note_pattern END,
+			min_amount = CASE WHEN $4::boolean THEN $6 ELSE min_amount END,
+			max_amount = CASE WHEN $4::boolean THEN $7 ELSE max_amount END,
+			account_id = CASE WHEN $4::boolean THEN $8 ELSE account_id END,
+			type = CASE WHEN $4::boolean THEN $9 ELSE type END,
+			currency_code = CASE WHEN $4::boolean THEN $10 ELSE currency_code END,
+			latitude = CASE WHEN $4::boolean THEN $11 ELSE latitude END,
+			longitude = CASE WHEN $4::boolean THEN $12 ELSE longitude END,
+			radius_meters = CASE WHEN $4::boolean THEN $13 ELSE radius_meters END,
+			set_category_id = CASE WHEN $14::boolean THEN $15 ELSE set_category_id END,
+			add_tag_ids = CASE WHEN $14::boolean THEN $16 ELSE add_tag_ids END,
+			set_note = CASE WHEN $14::boolean THEN $17 ELSE set_note END,
+			set_destination_account_id = CASE WHEN $14::boolean THEN $18 ELSE set_destination_account_id END,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $19 AND user_id = $20
+		RETURNING id`
+
+	queryStart := time.Now()
+	err := trr.db.QueryRow(ctx, sql,
+		payload.Name, payload.Priority, payload.Enabled,
+		payload.Conditions != nil, c.NotePattern, c.MinAmount, c.MaxAmount, c.AccountID, c.Type, c.CurrencyCode,
+		c.Latitude, c.Longitude, c.RadiusMeters,
+		payload.Actions != nil, a.CategoryID, tagIDs, a.Note, a.DestinationAccountID,
+		id, common.GetUserID(ctx),
+	).Scan(&ID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.TransactionRuleModel{}, huma.Error404NotFound("Transaction rule not found")
+		}
+		observability.RecordError("database")
+		return models.TransactionRuleModel{}, huma.Error500InternalServerError("Unable to update transaction rule", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "transaction_rules", time.Since(queryStart).Seconds())
+
+	return trr.GetDetail(ctx, ID)
+}
+
+// Delete removes a rule for good; rules have no trash since transactions keep the changes they made
+func (trr TransactionRuleRepository) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `DELETE FROM transaction_rules WHERE id = $1 AND user_id = $2`
+
+	queryStart := time.Now()
+	cmdTag, err := trr.db.Exec(ctx, sql, id, common.GetUserID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to delete transaction rule", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return huma.Error404NotFound("Transaction rule not found")
+	}
+	observability.RecordQueryDuration("DELETE", "transaction_rules", time.Since(queryStart).Seconds())
+
+	return nil
+}
+
+// GetSubjects returns the current user's transactions dated within the range that rules may still change, oldest first
+// Reconciled transactions are locked and left out; at most limit rows are returned
+func (trr TransactionRuleRepository) GetSubjects(ctx context.Context, startDate, endDate time.Time, limit int) ([]models.TransactionRuleSubjectModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		SELECT
+			t.id, t.date, t.type, t.amount, t.account_id, t.category_id, t.destination_account_id,
+			COALESCE(t.currency_code, (SELECT currency_code FROM base_currency_config LIMIT 1)),
+			t.note, t.latitude, t.longitude,
+			ARRAY(
+				SELECT tt.tag_id FROM transaction_tags tt
+				INNER JOIN tags tg ON tg.id = tt.tag_id AND tg.deleted_at IS NULL
+				WHERE tt.transaction_id = t.id
+				ORDER BY tt.tag_id
+			)
+		FROM transactions t
+		WHERE t.user_id = $1
+			AND t.deleted_at IS NULL
+			AND t.status <> $2
+			AND t.date >= $3
+			AND t.date <= $4
+		ORDER BY t.date ASC, t.id ASC
+		LIMIT $5`
+
+	queryStart := time.Now()
+	rows, err := trr.db.Query(ctx, sql, common.GetUserID(ctx), constants.TransactionStatusReconciled, startDate, endDate, limit)
+	if err != nil {
+		observability.RecordError("database")
+		return nil, huma.Error500InternalServerError("Unable to query transactions", err)
+	}
+	defer rows.Close()
+	observability.RecordQueryDuration("SELECT", "transactions", time.Since(queryStart).Seconds())
+
+	var items []models.TransactionRuleSubjectModel
+	for rows.Next() {
+		var item models.TransactionRuleSubjectModel
+		err := rows.Scan(
+			&item.ID, &item.Date, &item.Type, &item.Amount, &item.AccountID, &item.CategoryID, &item.DestinationAccountID,
+			&item.CurrencyCode, &item.Note, &item.Latitude, &item.Longitude, &item.TagIDs,
+		)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Unable to scan transaction data", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, huma.Error500InternalServerError("Error reading transaction rows", err)
+	}
+
+	return items, nil
+}
+
+// AddTags attaches tags to a transaction, skipping tags it already has and tags the current user does not own
+func (trr TransactionRuleRepository) AddTags(ctx context.Context, transactionID int64, tagIDs []int64) error {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		INSERT INTO transaction_tags (transaction_id, tag_id)
+		SELECT $1, tg.id
+		FROM tags tg
+		WHERE tg.id = ANY($2::int8[])
+			AND tg.user_id = $3
+			AND tg.deleted_at IS NULL
+		ON CONFLICT (transaction_id, tag_id) DO NOTHING`
+
+	queryStart := time.Now()
+	if _, err := trr.db.Exec(ctx, sql, transactionID, tagIDs, common.GetUserID(ctx)); err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to add tags to transaction", err)
+	}
+	observability.RecordQueryDuration("INSERT", "transaction_tags", time.Since(queryStart).Seconds())
+
+	return nil
+}
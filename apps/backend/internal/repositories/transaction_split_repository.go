@@ -0,0 +1,143 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+)
+
+// transactionSplitsAggSQL aggregates the active split lines of the transactions matched by transactionFilter
+// into one JSON array per transaction, shaped like models.TransactionSplitModel
+func transactionSplitsAggSQL(transactionFilter string) string {
+	return `
+			SELECT s.transaction_id,
+				JSON_AGG(JSON_BUILD_OBJECT(
+					'id', s.id,
+					'category', JSON_BUILD_OBJECT('id', c.id, 'name', c.name, 'type', c.type, 'icon', c.icon, 'iconColor', c.icon_color),
+					'amount', s.amount,
+					'note', s.note,
+					'tags', COALESCE((
+						SELECT JSON_AGG(JSON_BUILD_OBJECT('id', tg.id, 'name', tg.name) ORDER BY tg.name)
+						FROM transaction_split_tags st
+						INNER JOIN tags tg ON st.tag_id = tg.id
+						WHERE st.split_id = s.id
+					), '[]'::json)
+				) ORDER BY s.id) as splits_json
+			FROM transaction_splits s
+			INNER JOIN categories c ON s.category_id = c.id
+			WHERE s.deleted_at IS NULL
+				AND s.transaction_id ` + transactionFilter + `
+			GROUP BY s.transaction_id`
+}
+
+type TransactionSplitRepository struct {
+	db DBQuerier
+}
+
+func NewTransactionSplitRepository(db DBQuerier) TransactionSplitRepository {
+	return TransactionSplitRepository{db}
+}
+
+func (tsr TransactionSplitRepository) GetList(ctx context.Context, transactionID int64) (models.TransactionSplitsListModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := transactionSplitsAggSQL(`= $1
+				AND s.transaction_id IN (SELECT id FROM transactions WHERE ` + transactionVisibleSQL("", "$2") + `)`)
+
+	queryStart := time.Now()
+	rows, err := tsr.db.Query(ctx, sql, transactionID, common.GetUserID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return models.TransactionSplitsListModel{}, huma.Error500InternalServerError("Unable to query transaction splits", err)
+	}
+	defer rows.Close()
+	observability.RecordQueryDuration("SELECT", "transaction_splits", time.Since(queryStart).Seconds())
+
+	items := []models.TransactionSplitModel{}
+	for rows.Next() {
+		var id int64
+		var splitsJSON []byte
+		if err := rows.Scan(&id, &splitsJSON); err != nil {
+			return models.TransactionSplitsListModel{}, huma.Error500InternalServerError("Unable to scan transaction split data", err)
+		}
+		if err := json.Unmarshal(splitsJSON, &items); err != nil {
+			return models.TransactionSplitsListModel{}, huma.Error500InternalServerError("Unable to parse transaction split data", err)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.TransactionSplitsListModel{}, huma.Error500InternalServerError("Error reading transaction split rows", err)
+	}
+
+	return models.TransactionSplitsListModel{Items: items}, nil
+}
+
+// Replace swaps the split lines of a transaction for the given ones; the previous lines are soft deleted
+// Callers validate the lines and run this inside a database transaction; the transaction's updated_at is bumped too
+func (tsr TransactionSplitRepository) Replace(ctx context.Context, transactionID int64, lines []models.TransactionSplitLineModel) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	deleteSQL := `UPDATE transaction_splits
+		SET deleted_at = CURRENT_TIMESTAMP,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE transaction_id = $1 AND deleted_at IS NULL`
+
+	queryStart := time.Now()
+	if _, err := tsr.db.Exec(ctx, deleteSQL, transactionID); err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to delete transaction splits", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "transaction_splits", time.Since(queryStart).Seconds())
+
+	insertSQL := `INSERT INTO transaction_splits (transaction_id, category_id, amount, note)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id`
+
+	tagSQL := `INSERT INTO transaction_split_tags (split_id, tag_id)
+			SELECT $1, UNNEST($2::int8[])
+			ON CONFLICT (split_id, tag_id) DO NOTHING`
+
+	for _, line := range lines {
+		var splitID int64
+
+		queryStart := time.Now()
+		if err := tsr.db.QueryRow(ctx, insertSQL, transactionID, line.CategoryID, line.Amount, line.Note).Scan(&splitID); err != nil {
+			observability.RecordError("database")
+			return huma.Error500InternalServerError("Unable to create transaction split", err)
+		}
+		observability.RecordQueryDuration("INSERT", "transaction_splits", time.Since(queryStart).Seconds())
+
+		if len(line.TagIDs) == 0 {
+			continue
+		}
+
+		queryStart = time.Now()
+		if _, err := tsr.db.Exec(ctx, tagSQL, splitID, line.TagIDs); err != nil {
+			observability.RecordError("database")
+			return huma.Error500InternalServerError("Unable to tag transaction split", err)
+		}
+		observability.RecordQueryDuration("INSERT", "transaction_split_tags", time.Since(queryStart).Seconds())
+	}
+
+	// The lines are part of the transaction, so replacing them changes its ETag
+	touchSQL := `UPDATE transactions
+		SET updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1`
+
+	queryStart = time.Now()
+	if _, err := tsr.db.Exec(ctx, touchSQL, transactionID); err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to update transaction", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "transactions", time.Since(queryStart).Seconds())
+
+	return nil
+}
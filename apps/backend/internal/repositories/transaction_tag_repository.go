@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
 	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
 	"github.com/dimasbaguspm/spenicle-api/internal/observability"
@@ -32,6 +33,7 @@ func (ttr TransactionTagRepository) GetPaged(ctx context.Context, q models.Trans
 			FROM transaction_tags tt
 			INNER JOIN tags t ON tt.tag_id = t.id
 			WHERE tt.transaction_id = $1
+				AND t.user_id = $4
 				AND t.deleted_at IS NULL
 		),
 		counted AS (
@@ -51,7 +53,7 @@ func (ttr TransactionTagRepository) GetPaged(ctx context.Context, q models.Trans
 	`
 
 	queryStart := time.Now()
-	rows, err := ttr.db.Query(ctx, sql, q.TransactionID, q.PageSize, offset)
+	rows, err := ttr.db.Query(ctx, sql, q.TransactionID, q.PageSize, offset, common.GetUserID(ctx))
 	if err != nil {
 		observability.RecordError("database")
 		return models.TransactionTagsPagedModel{}, huma.Error500InternalServerError("Unable to query transaction tags", err)
@@ -107,10 +109,10 @@ func (ttr TransactionTagRepository) GetDetail(ctx context.Context, ID int64) (mo
 			tt.created_at
 		FROM transaction_tags tt
 		INNER JOIN tags t ON tt.tag_id = t.id
-		WHERE tt.id = $1 AND t.deleted_at IS NULL`
+		WHERE tt.id = $1 AND t.user_id = $2 AND t.deleted_at IS NULL`
 
 	queryStart := time.Now()
-	err := ttr.db.QueryRow(ctx, sql, ID).Scan(&data.ID, &data.TransactionID, &data.TagID, &data.TagName, &data.CreatedAt)
+	err := ttr.db.QueryRow(ctx, sql, ID, common.GetUserID(ctx)).Scan(&data.ID, &data.TransactionID, &data.TagID, &data.TagName, &data.CreatedAt)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -129,27 +131,33 @@ func (ttr TransactionTagRepository) Create(ctx context.Context, p models.CreateT
 	defer cancel()
 
 	var ID int64
+	userID := common.GetUserID(ctx)
 
 	insertSQL := `
 		INSERT INTO transaction_tags (
 			transaction_id,
 			tag_id
 		)
-		VALUES ($1, $2)
+		SELECT $1, $2
+		WHERE EXISTS (SELECT 1 FROM transactions WHERE id = $1 AND user_id = $3 AND deleted_at IS NULL)
+			AND EXISTS (SELECT 1 FROM tags WHERE id = $2 AND user_id = $3 AND deleted_at IS NULL)
 		ON CONFLICT (transaction_id, tag_id) DO NOTHING`
 
 	queryStart := time.Now()
-	_, err := ttr.db.Exec(ctx, insertSQL, p.TransactionID, p.TagID)
+	_, err := ttr.db.Exec(ctx, insertSQL, p.TransactionID, p.TagID, userID)
 	if err != nil {
 		observability.RecordError("database")
 		return models.TransactionTagModel{}, huma.Error500InternalServerError("Unable to add tag to transaction", err)
 	}
 	observability.RecordQueryDuration("INSERT", "transaction_tags", time.Since(queryStart).Seconds())
 
-	selectSQL := `SELECT id FROM transaction_tags WHERE transaction_id = $1 AND tag_id = $2`
-	err = ttr.db.QueryRow(ctx, selectSQL, p.TransactionID, p.TagID).Scan(&ID)
+	selectSQL := `SELECT tt.id FROM transaction_tags tt INNER JOIN tags t ON tt.tag_id = t.id WHERE tt.transaction_id = $1 AND tt.tag_id = $2 AND t.user_id = $3`
+	err = ttr.db.QueryRow(ctx, selectSQL, p.TransactionID, p.TagID, userID).Scan(&ID)
 
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.TransactionTagModel{}, huma.Error404NotFound("Transaction or tag not found")
+		}
 		observability.RecordError("database")
 		return models.TransactionTagModel{}, huma.Error500InternalServerError("Unable to add tag to transaction", err)
 	}
@@ -163,10 +171,11 @@ func (ttr TransactionTagRepository) Delete(ctx context.Context, transactionID, t
 
 	sql := `
 		DELETE FROM transaction_tags
-		WHERE transaction_id = $1 AND tag_id = $2`
+		WHERE transaction_id = $1 AND tag_id = $2
+			AND tag_id IN (SELECT id FROM tags WHERE user_id = $3)`
 
 	queryStart := time.Now()
-	cmdTag, err := ttr.db.Exec(ctx, sql, transactionID, tagID)
+	cmdTag, err := ttr.db.Exec(ctx, sql, transactionID, tagID, common.GetUserID(ctx))
 	if err != nil {
 		observability.RecordError("database")
 		return huma.Error500InternalServerError("Unable to remove tag from transaction", err)
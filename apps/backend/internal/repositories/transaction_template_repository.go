@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
 	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
 	"github.com/dimasbaguspm/spenicle-api/internal/observability"
@@ -69,6 +70,8 @@ func (ttr TransactionTemplateRepository) GetPaged(ctx context.Context, p models.
 				da.amount as dest_account_amount,
 				da.icon as dest_account_icon,
 				da.icon_color as dest_account_icon_color,
+				py.id as payee_id,
+				py.name as payee_name,
 				tt.note,
 				tt.recurrence,
 				tt.start_date,
@@ -93,6 +96,7 @@ func (ttr TransactionTemplateRepository) GetPaged(ctx context.Context, p models.
 			JOIN accounts a ON tt.account_id = a.id
 			JOIN categories c ON tt.category_id = c.id
 			LEFT JOIN accounts da ON tt.destination_account_id = da.id
+			LEFT JOIN payees py ON tt.payee_id = py.id
 			LEFT JOIN transaction_template_relations r ON r.template_id = tt.id
 			LEFT JOIN transactions t ON r.transaction_id = t.id AND t.deleted_at IS NULL
 			WHERE tt.deleted_at IS NULL
@@ -104,7 +108,8 @@ func (ttr TransactionTemplateRepository) GetPaged(ctx context.Context, p models.
 				AND ($3::int8 = 0 OR tt.account_id = $3::int8)
 				AND ($4::int8 = 0 OR tt.category_id = $4::int8)
 				AND ($5::int8 = 0 OR tt.destination_account_id = $5::int8)
-			GROUP BY tt.id, a.id, a.name, a.type, a.amount, a.icon, a.icon_color, c.id, c.name, c.type, c.icon, c.icon_color, da.id, da.name, da.type, da.amount, da.icon, da.icon_color, tt.name, tt.type, tt.amount, tt.currency_code, tt.note, tt.recurrence, tt.start_date, tt.end_date, tt.next_due_at, tt.last_executed_at, tt.created_at, tt.updated_at, tt.deleted_at
+				AND (tt.user_id = $8 OR ` + sharedWithUserSQL(constants.EntityTransactionTemplate, "tt.id", "$8") + `)
+			GROUP BY tt.id, a.id, a.name, a.type, a.amount, a.icon, a.icon_color, c.id, c.name, c.type, c.icon, c.icon_color, da.id, da.name, da.type, da.amount, da.icon, da.icon_color, py.id, py.name, tt.name, tt.type, tt.amount, tt.currency_code, tt.note, tt.recurrence, tt.start_date, tt.end_date, tt.next_due_at, tt.last_executed_at, tt.created_at, tt.updated_at, tt.deleted_at
 		)
 		SELECT
 			id,
@@ -129,6 +134,8 @@ func (ttr TransactionTemplateRepository) GetPaged(ctx context.Context, p models.
 			dest_account_amount,
 			dest_account_icon,
 			dest_account_icon_color,
+			payee_id,
+			payee_name,
 			note,
 			recurrence,
 			start_date,
@@ -148,7 +155,7 @@ func (ttr TransactionTemplateRepository) GetPaged(ctx context.Context, p models.
 	`
 
 	queryStart := time.Now()
-	rows, err := ttr.db.Query(ctx, sql, searchPattern, p.Type, p.AccountID, p.CategoryID, p.DestinationAccountID, p.PageSize, offset)
+	rows, err := ttr.db.Query(ctx, sql, searchPattern, p.Type, p.AccountID, p.CategoryID, p.DestinationAccountID, p.PageSize, offset, common.GetUserID(ctx))
 	if err != nil {
 		observability.RecordError("database")
 		return models.TransactionTemplatesPagedModel{}, huma.Error500InternalServerError("Unable to query transaction templates", err)
@@ -166,6 +173,8 @@ func (ttr TransactionTemplateRepository) GetPaged(ctx context.Context, p models.
 		var destAccountAmount *int64
 		var destAccountIcon *string
 		var destAccountIconColor *string
+		var payeeID *int64
+		var payeeName *string
 
 		if err := rows.Scan(
 			&item.ID, &item.Name, &item.Type, &item.Amount,
@@ -174,6 +183,7 @@ func (ttr TransactionTemplateRepository) GetPaged(ctx context.Context, p models.
 			&item.Account.Icon, &item.Account.IconColor,
 			&item.Category.ID, &item.Category.Name, &item.Category.Type, &item.Category.Icon, &item.Category.IconColor,
 			&destAccountID, &destAccountName, &destAccountType, &destAccountAmount, &destAccountIcon, &destAccountIconColor,
+			&payeeID, &payeeName,
 			&item.Note, &item.Recurrence, &item.StartDate, &item.EndDate, &item.NextDueAt, &item.LastExecutedAt,
 			&item.CreatedAt, &item.UpdatedAt, &item.DeletedAt,
 			&item.RecurringStats.Occurrences, &item.RecurringStats.TotalSpent, &item.RecurringStats.Remaining, &totalCount,
@@ -193,6 +203,10 @@ func (ttr TransactionTemplateRepository) GetPaged(ctx context.Context, p models.
 			}
 		}
 
+		if payeeID != nil {
+			item.Payee = &models.TransactionPayeeEmbedded{ID: *payeeID, Name: *payeeName}
+		}
+
 		items = append(items, item)
 	}
 
@@ -229,6 +243,8 @@ func (ttr TransactionTemplateRepository) GetDetail(ctx context.Context, id int64
 	var destAccountAmount *int64
 	var destAccountIcon *string
 	var destAccountIconColor *string
+	var payeeID *int64
+	var payeeName *string
 
 	sql := `
 		SELECT
@@ -254,6 +270,8 @@ func (ttr TransactionTemplateRepository) GetDetail(ctx context.Context, id int64
 			da.amount,
 			da.icon,
 			da.icon_color,
+			py.id,
+			py.name,
 			tt.note,
 			tt.recurrence,
 			tt.start_date,
@@ -267,20 +285,24 @@ func (ttr TransactionTemplateRepository) GetDetail(ctx context.Context, id int64
 		JOIN accounts a ON tt.account_id = a.id
 		JOIN categories c ON tt.category_id = c.id
 		LEFT JOIN accounts da ON tt.destination_account_id = da.id
-		WHERE tt.id = $1 AND tt.deleted_at IS NULL
+		LEFT JOIN payees py ON tt.payee_id = py.id
+		WHERE tt.id = $1
+			AND (tt.user_id = $2 OR ` + sharedWithUserSQL(constants.EntityTransactionTemplate, "tt.id", "$2") + `)
+			AND tt.deleted_at IS NULL
 			AND a.deleted_at IS NULL
 			AND c.deleted_at IS NULL
 			AND (da.deleted_at IS NULL OR da.id IS NULL)
 	`
 
 	queryStart := time.Now()
-	err := ttr.db.QueryRow(ctx, sql, id).Scan(
+	err := ttr.db.QueryRow(ctx, sql, id, common.GetUserID(ctx)).Scan(
 		&data.ID, &data.Name, &data.Type, &data.Amount,
 		&data.CurrencyCode,
 		&data.Account.ID, &data.Account.Name, &data.Account.Type, &data.Account.Amount,
 		&data.Account.Icon, &data.Account.IconColor,
 		&data.Category.ID, &data.Category.Name, &data.Category.Type, &data.Category.Icon, &data.Category.IconColor,
 		&destAccountID, &destAccountName, &destAccountType, &destAccountAmount, &destAccountIcon, &destAccountIconColor,
+		&payeeID, &payeeName,
 		&data.Note, &data.Recurrence, &data.StartDate, &data.EndDate, &data.NextDueAt, &data.LastExecutedAt,
 		&data.CreatedAt, &data.UpdatedAt, &data.DeletedAt,
 	)
@@ -306,6 +328,10 @@ func (ttr TransactionTemplateRepository) GetDetail(ctx context.Context, id int64
 		}
 	}
 
+	if payeeID != nil {
+		data.Payee = &models.TransactionPayeeEmbedded{ID: *payeeID, Name: *payeeName}
+	}
+
 	// Calculate recurring stats
 	statsSQL := `
 		SELECT
@@ -378,8 +404,8 @@ func (ttr TransactionTemplateRepository) Create(ctx context.Context, payload mod
 	}
 
 	sql := `
-		INSERT INTO transaction_templates (name, type, amount, currency_code, account_id, category_id, destination_account_id, note, recurrence, start_date, end_date, next_due_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		INSERT INTO transaction_templates (name, type, amount, currency_code, account_id, category_id, destination_account_id, note, recurrence, start_date, end_date, next_due_at, user_id, payee_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 		RETURNING id
 	`
 
@@ -399,6 +425,8 @@ func (ttr TransactionTemplateRepository) Create(ctx context.Context, payload mod
 		payload.StartDate,
 		payload.EndDate,
 		nextDueAt,
+		common.GetUserID(ctx),
+		payload.PayeeID,
 	).Scan(&ID)
 
 	if err != nil {
@@ -425,6 +453,7 @@ func (ttr TransactionTemplateRepository) Update(ctx context.Context, id int64, p
 			category_id = COALESCE($5, category_id),
 			destination_account_id = COALESCE($6, destination_account_id),
 			note = COALESCE($7, note),
+			payee_id = CASE WHEN $13::int8 IS NULL THEN payee_id ELSE NULLIF($13::int8, 0) END,
 			recurrence = COALESCE($8, recurrence),
 			end_date = COALESCE($9, end_date),
 			next_due_at = CASE 
@@ -445,7 +474,9 @@ func (ttr TransactionTemplateRepository) Update(ctx context.Context, id int64, p
 				ELSE next_due_at
 			END,
 			updated_at = CURRENT_TIMESTAMP
-		WHERE id = $11 AND deleted_at IS NULL
+		WHERE id = $11
+			AND (user_id = $12 OR ` + sharedWithUserSQL(constants.EntityTransactionTemplate, "transaction_templates.id", "$12", constants.ShareRoleEditor) + `)
+			AND deleted_at IS NULL
 		RETURNING id
 	`
 
@@ -465,6 +496,8 @@ func (ttr TransactionTemplateRepository) Update(ctx context.Context, id int64, p
 		payload.EndDate,
 		payload.CurrencyCode,
 		id,
+		common.GetUserID(ctx),
+		payload.PayeeID,
 	).Scan(&returnedID)
 
 	if err != nil {
@@ -485,10 +518,10 @@ func (ttr TransactionTemplateRepository) Delete(ctx context.Context, id int64) e
 
 	sql := `UPDATE transaction_templates
 			SET deleted_at = CURRENT_TIMESTAMP
-			WHERE id = $1 AND deleted_at IS NULL`
+			WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`
 
 	queryStart := time.Now()
-	cmdTag, err := ttr.db.Exec(ctx, sql, id)
+	cmdTag, err := ttr.db.Exec(ctx, sql, id, common.GetUserID(ctx))
 	if err != nil {
 		observability.RecordError("database")
 		return huma.Error500InternalServerError("Unable to delete transaction template", err)
@@ -500,6 +533,30 @@ func (ttr TransactionTemplateRepository) Delete(ctx context.Context, id int64) e
 	return nil
 }
 
+// Restore brings a deleted template back from the trash; it resumes from its stored next due date
+func (ttr TransactionTemplateRepository) Restore(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `UPDATE transaction_templates
+			SET deleted_at = NULL, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL`
+
+	queryStart := time.Now()
+	cmdTag, err := ttr.db.Exec(ctx, sql, id, common.GetUserID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to restore transaction template", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return huma.Error404NotFound("Deleted transaction template not found")
+	}
+	observability.RecordQueryDuration("UPDATE", "transaction_templates", time.Since(queryStart).Seconds())
+	return nil
+}
+
+// GetDueTemplates returns due templates across all users; each item carries its owner's UserID
+// so the worker can act on the owner's behalf. A deleted payee is left out rather than blocking the template
 func (ttr TransactionTemplateRepository) GetDueTemplates(ctx context.Context) ([]models.TransactionTemplateModel, error) {
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
@@ -507,6 +564,7 @@ func (ttr TransactionTemplateRepository) GetDueTemplates(ctx context.Context) ([
 	sql := `
 		SELECT
 			tt.id,
+			tt.user_id,
 			a.id,
 			a.name,
 			a.type,
@@ -524,6 +582,8 @@ func (ttr TransactionTemplateRepository) GetDueTemplates(ctx context.Context) ([
 			da.amount,
 			da.icon,
 			da.icon_color,
+			py.id,
+			py.name,
 			tt.name,
 			tt.type,
 			tt.amount,
@@ -551,14 +611,16 @@ func (ttr TransactionTemplateRepository) GetDueTemplates(ctx context.Context) ([
 		JOIN accounts a ON tt.account_id = a.id
 		JOIN categories c ON tt.category_id = c.id
 		LEFT JOIN accounts da ON tt.destination_account_id = da.id
+		LEFT JOIN payees py ON tt.payee_id = py.id AND py.deleted_at IS NULL
 		LEFT JOIN transaction_template_relations r ON r.template_id = tt.id
 		LEFT JOIN transactions t ON r.transaction_id = t.id AND t.deleted_at IS NULL
 		WHERE tt.deleted_at IS NULL
+			AND tt.user_id IS NOT NULL
 			AND tt.next_due_at <= CURRENT_DATE
 			AND a.deleted_at IS NULL
 			AND c.deleted_at IS NULL
 			AND (da.deleted_at IS NULL OR da.id IS NULL)
-		GROUP BY tt.id, a.id, a.name, a.type, a.amount, a.icon, a.icon_color, c.id, c.name, c.type, c.icon, c.icon_color, da.id, da.name, da.type, da.amount, da.icon, da.icon_color, tt.name, tt.type, tt.amount, tt.currency_code, tt.note, tt.recurrence, tt.start_date, tt.end_date, tt.next_due_at, tt.last_executed_at, tt.created_at, tt.updated_at, tt.deleted_at
+		GROUP BY tt.id, tt.user_id, a.id, a.name, a.type, a.amount, a.icon, a.icon_color, c.id, c.name, c.type, c.icon, c.icon_color, da.id, da.name, da.type, da.amount, da.icon, da.icon_color, py.id, py.name, tt.name, tt.type, tt.amount, tt.currency_code, tt.note, tt.recurrence, tt.start_date, tt.end_date, tt.next_due_at, tt.last_executed_at, tt.created_at, tt.updated_at, tt.deleted_at
 		ORDER BY tt.next_due_at ASC
 	`
 
@@ -580,13 +642,17 @@ func (ttr TransactionTemplateRepository) GetDueTemplates(ctx context.Context) ([
 		var destAccountAmount *int64
 		var destAccountIcon *string
 		var destAccountIconColor *string
+		var payeeID *int64
+		var payeeName *string
 
 		if err := rows.Scan(
 			&item.ID,
+			&item.UserID,
 			&item.Account.ID, &item.Account.Name, &item.Account.Type, &item.Account.Amount,
 			&item.Account.Icon, &item.Account.IconColor,
 			&item.Category.ID, &item.Category.Name, &item.Category.Type, &item.Category.Icon, &item.Category.IconColor,
 			&destAccountID, &destAccountName, &destAccountType, &destAccountAmount, &destAccountIcon, &destAccountIconColor,
+			&payeeID, &payeeName,
 			&item.Name, &item.Type, &item.Amount, &item.CurrencyCode,
 			&item.Note, &item.Recurrence, &item.StartDate, &item.EndDate, &item.NextDueAt, &item.LastExecutedAt,
 			&item.CreatedAt, &item.UpdatedAt, &item.DeletedAt,
@@ -606,6 +672,10 @@ func (ttr TransactionTemplateRepository) GetDueTemplates(ctx context.Context) ([
 			}
 		}
 
+		if payeeID != nil {
+			item.Payee = &models.TransactionPayeeEmbedded{ID: *payeeID, Name: *payeeName}
+		}
+
 		items = append(items, item)
 	}
 
@@ -634,9 +704,9 @@ func (ttr TransactionTemplateRepository) UpdateLastExecuted(ctx context.Context,
 		        ELSE next_due_at
 		    END,
 		    updated_at = NOW()
-		WHERE id = $1 AND deleted_at IS NULL`
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`
 
-	_, err := ttr.db.Exec(ctx, sql, id)
+	_, err := ttr.db.Exec(ctx, sql, id, common.GetUserID(ctx))
 	if err != nil {
 		return huma.Error500InternalServerError("Unable to update template execution time", err)
 	}
@@ -647,9 +717,12 @@ func (ttr TransactionTemplateRepository) CreateRelation(ctx context.Context, tra
 	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
 	defer cancel()
 
-	sql := `INSERT INTO transaction_template_relations (transaction_id, template_id) VALUES ($1, $2) ON CONFLICT (transaction_id) DO NOTHING`
+	sql := `INSERT INTO transaction_template_relations (transaction_id, template_id)
+			SELECT $1, $2
+			WHERE EXISTS (SELECT 1 FROM transaction_templates WHERE id = $2 AND user_id = $3)
+			ON CONFLICT (transaction_id) DO NOTHING`
 
-	_, err := ttr.db.Exec(ctx, sql, transactionID, templateID)
+	_, err := ttr.db.Exec(ctx, sql, transactionID, templateID, common.GetUserID(ctx))
 	if err != nil {
 		return huma.Error500InternalServerError("Unable to create transaction template relation", err)
 	}
@@ -666,10 +739,11 @@ func (ttr TransactionTemplateRepository) GetRelatedTransactions(ctx context.Cont
 		JOIN transaction_template_relations r ON t.id = r.transaction_id
 		WHERE t.deleted_at IS NULL
 			AND r.template_id = $1
+			AND t.user_id = $2
 		ORDER BY t.id
 	`
 
-	rows, err := ttr.db.Query(ctx, sql, templateID)
+	rows, err := ttr.db.Query(ctx, sql, templateID, common.GetUserID(ctx))
 	if err != nil {
 		return nil, huma.Error500InternalServerError("Unable to query related transaction IDs", err)
 	}
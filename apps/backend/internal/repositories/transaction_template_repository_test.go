@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+)
+
+// pageIDs returns the IDs of a page of transactions in order
+func pageIDs(page models.TransactionsPagedModel) []int64 {
+	ids := make([]int64, 0, len(page.Items))
+	for _, item := range page.Items {
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
+
+// Related transactions are listed through TransactionRepository.GetPaged filtered by the template's transaction IDs,
+// the same way TransactionTemplateService.GetRelatedTransactions does
+func TestRelatedTransactionsPageWithCursors(t *testing.T) {
+	tx := newTestTx(t)
+
+	userID := insertID(t, tx, "INSERT INTO users (username, password_hash) VALUES ($1, 'x') RETURNING id", fmt.Sprintf("cursor-test-%d", time.Now().UnixNano()))
+	accountID := insertID(t, tx, "INSERT INTO accounts (user_id, name, type) VALUES ($1, 'Cash', 'expense') RETURNING id", userID)
+	categoryID := insertID(t, tx, "INSERT INTO categories (user_id, name, type) VALUES ($1, 'Rent', 'expense') RETURNING id", userID)
+	templateID := insertID(t, tx, "INSERT INTO transaction_templates (user_id, name, type, amount, account_id, category_id, recurrence, start_date) VALUES ($1, 'Rent', 'expense', 100, $2, $3, 'monthly', NOW()) RETURNING id", userID, accountID, categoryID)
+	ctx := common.WithUserID(context.Background(), userID)
+
+	insertTransaction := func(date time.Time, related bool) int64 {
+		id := insertID(t, tx, "INSERT INTO transactions (user_id, type, date, amount, account_id, category_id) VALUES ($1, 'expense', $2, 100, $3, $4) RETURNING id", userID, date, accountID, categoryID)
+		if related {
+			insertID(t, tx, "INSERT INTO transaction_template_relations (transaction_id, template_id) VALUES ($1, $2) RETURNING id", id, templateID)
+		}
+		return id
+	}
+
+	// Two related transactions share a date so the id has to break the tie; the last one is not related
+	start := time.Now().Add(-30 * 24 * time.Hour).UTC().Truncate(time.Second)
+	var related []int64
+	for _, day := range []int{0, 0, 1, 2} {
+		related = append(related, insertTransaction(start.AddDate(0, 0, day), true))
+	}
+	insertTransaction(start.AddDate(0, 0, 3), false)
+	slices.Reverse(related)
+
+	templates := NewTransactionTemplateRepository(tx)
+	transactions := NewTransactionRepository(tx)
+
+	page := func(after string, before string) models.TransactionsPagedModel {
+		t.Helper()
+
+		ids, err := templates.GetRelatedTransactions(ctx, templateID, models.TransactionTemplateRelatedTransactionsSearchModel{})
+		if err != nil {
+			t.Fatalf("Failed to list related transaction IDs: %v", err)
+		}
+		var intIDs []int
+		for _, id := range ids {
+			intIDs = append(intIDs, int(id))
+		}
+
+		result, err := transactions.GetPaged(ctx, models.TransactionsSearchModel{
+			PageNumber:   1,
+			PageSize:     3,
+			SortBy:       "date",
+			SortOrder:    "desc",
+			After:        after,
+			Before:       before,
+			IncludeTotal: true,
+			IDs:          intIDs,
+		})
+		if err != nil {
+			t.Fatalf("Failed to page related transactions: %v", err)
+		}
+		return result
+	}
+
+	first := page("", "")
+	if got := pageIDs(first); !slices.Equal(got, related[:3]) {
+		t.Fatalf("Expected first page %v, got %v", related[:3], got)
+	}
+	if first.TotalCount != 4 || first.NextCursor == nil || first.PrevCursor != nil {
+		t.Fatalf("Expected 4 related transactions with only a next cursor, got %+v", first)
+	}
+
+	// A transaction recorded from the template after the first page was read does not shift the next one
+	insertTransaction(time.Now(), true)
+
+	second := page(*first.NextCursor, "")
+	if got := pageIDs(second); !slices.Equal(got, related[3:]) {
+		t.Fatalf("Expected second page %v, got %v", related[3:], got)
+	}
+	if second.NextCursor != nil || second.PrevCursor == nil {
+		t.Fatalf("Expected only a previous cursor on the last page, got %+v", second)
+	}
+
+	back := page("", *second.PrevCursor)
+	if got := pageIDs(back); !slices.Equal(got, related[:3]) {
+		t.Errorf("Expected to page back to %v, got %v", related[:3], got)
+	}
+	if back.PrevCursor == nil {
+		t.Errorf("Expected a previous cursor towards the newly recorded transaction")
+	}
+}
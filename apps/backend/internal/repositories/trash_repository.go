@@ -0,0 +1,211 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+)
+
+// Accounts, categories and payees are only purged once nothing references them anymore, deleted or not;
+// they wait in the trash until the transactions, templates, budgets and rules using them are gone
+// Every foreign key to accounts, categories and payees has a guard here; account_reconciliations and shares are
+// removed together with their account instead. TestPurgeKeepsReferencedRecords checks the list against the schema
+const (
+	purgeableBudgetTemplateSQL = `bt.deleted_at < $1
+			AND NOT EXISTS (SELECT 1 FROM budgets b WHERE b.template_id = bt.id)`
+	purgeableAccountSQL = `a.deleted_at < $1
+			AND NOT EXISTS (SELECT 1 FROM transactions t WHERE t.account_id = a.id OR t.destination_account_id = a.id)
+			AND NOT EXISTS (SELECT 1 FROM transaction_templates tt WHERE tt.account_id = a.id OR tt.destination_account_id = a.id)
+			AND NOT EXISTS (SELECT 1 FROM budget_templates bt WHERE bt.account_id = a.id)
+			AND NOT EXISTS (SELECT 1 FROM budgets b WHERE b.account_id = a.id)
+			AND NOT EXISTS (SELECT 1 FROM transaction_rules r WHERE r.account_id = a.id OR r.set_destination_account_id = a.id)`
+	purgeableCategorySQL = `c.deleted_at < $1
+			AND NOT EXISTS (SELECT 1 FROM transactions t WHERE t.category_id = c.id)
+			AND NOT EXISTS (SELECT 1 FROM transaction_splits ts WHERE ts.category_id = c.id)
+			AND NOT EXISTS (SELECT 1 FROM transaction_templates tt WHERE tt.category_id = c.id)
+			AND NOT EXISTS (SELECT 1 FROM budget_templates bt WHERE bt.category_id = c.id)
+			AND NOT EXISTS (SELECT 1 FROM budgets b WHERE b.category_id = c.id)
+			AND NOT EXISTS (SELECT 1 FROM transaction_rules r WHERE r.set_category_id = c.id)`
+	purgeablePayeeSQL = `p.deleted_at < $1
+			AND NOT EXISTS (SELECT 1 FROM transactions t WHERE t.payee_id = p.id)
+			AND NOT EXISTS (SELECT 1 FROM transaction_templates tt WHERE tt.payee_id = p.id)`
+)
+
+type TrashRepository struct {
+	db DBQuerier
+}
+
+func NewTrashRepository(db DBQuerier) TrashRepository {
+	return TrashRepository{db}
+}
+
+// GetPaged lists the soft-deleted records of the current user, most recently deleted first
+// Deleted transactions are listed for every user who can see them, like live ones
+func (tr TrashRepository) GetPaged(ctx context.Context, query models.TrashSearchModel) (models.TrashPagedModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	offset := (query.PageNumber - 1) * query.PageSize
+	sql := `
+		WITH trash AS (
+			SELECT '` + constants.EntityTransaction + `' as entity_type, t.id, COALESCE(NULLIF(t.note, ''), t.type) as name, t.amount, t.deleted_at
+			FROM transactions t
+			WHERE t.deleted_at IS NOT NULL AND ` + transactionVisibleSQL("t.", "$1") + `
+			UNION ALL
+			SELECT '` + constants.EntityAccount + `', a.id, a.name, NULL::bigint, a.deleted_at
+			FROM accounts a
+			WHERE a.deleted_at IS NOT NULL AND a.user_id = $1
+			UNION ALL
+			SELECT '` + constants.EntityCategory + `', c.id, c.name, NULL::bigint, c.deleted_at
+			FROM categories c
+			WHERE c.deleted_at IS NOT NULL AND c.user_id = $1
+			UNION ALL
+			SELECT '` + constants.EntityTag + `', tg.id, tg.name, NULL::bigint, tg.deleted_at
+			FROM tags tg
+			WHERE tg.deleted_at IS NOT NULL AND tg.user_id = $1
+			UNION ALL
+			SELECT '` + constants.EntityPayee + `', p.id, p.name, NULL::bigint, p.deleted_at
+			FROM payees p
+			WHERE p.deleted_at IS NOT NULL AND p.user_id = $1
+			UNION ALL
+			SELECT '` + constants.EntityTransactionTemplate + `', tt.id, tt.name, tt.amount, tt.deleted_at
+			FROM transaction_templates tt
+			WHERE tt.deleted_at IS NOT NULL AND tt.user_id = $1
+		)
+		SELECT entity_type, id, name, amount, deleted_at, COUNT(*) OVER() as total_count
+		FROM trash
+		WHERE $2::text[] IS NULL OR entity_type = ANY($2::text[])
+		ORDER BY deleted_at DESC, entity_type ASC, id DESC
+		LIMIT $3 OFFSET $4`
+
+	var entityTypes []string
+	if len(query.EntityType) > 0 {
+		entityTypes = query.EntityType
+	}
+
+	queryStart := time.Now()
+	rows, err := tr.db.Query(ctx, sql, common.GetUserID(ctx), entityTypes, query.PageSize, offset)
+	if err != nil {
+		observability.RecordError("database")
+		return models.TrashPagedModel{}, huma.Error500InternalServerError("Unable to query trash", err)
+	}
+	defer rows.Close()
+	observability.RecordQueryDuration("SELECT", "trash", time.Since(queryStart).Seconds())
+
+	items := []models.TrashItemModel{}
+	var totalCount int
+	for rows.Next() {
+		var item models.TrashItemModel
+		if err := rows.Scan(&item.EntityType, &item.ID, &item.Name, &item.Amount, &item.DeletedAt, &totalCount); err != nil {
+			return models.TrashPagedModel{}, huma.Error500InternalServerError("Unable to scan trash data", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.TrashPagedModel{}, huma.Error500InternalServerError("Error reading trash rows", err)
+	}
+
+	totalPages := 0
+	if totalCount > 0 {
+		totalPages = (totalCount + query.PageSize - 1) / query.PageSize
+	}
+
+	return models.TrashPagedModel{
+		Items:      items,
+		PageNumber: query.PageNumber,
+		PageSize:   query.PageSize,
+		TotalCount: totalCount,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// Purge permanently removes the records of every user deleted before the cutoff, along with the rows that only existed for them
+// It runs several statements and is meant to be called inside a database transaction
+func (tr TrashRepository) Purge(ctx context.Context, before time.Time) (models.TrashPurgeResultModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	var result models.TrashPurgeResultModel
+	purgedTransactionsSQL := `SELECT id FROM transactions WHERE deleted_at < $1`
+
+	// Attachments share the deletion timestamp of their transaction, so they normally go in the same run
+	attachmentsSQL := `DELETE FROM transaction_attachments
+			WHERE deleted_at < $1 OR transaction_id IN (` + purgedTransactionsSQL + `)
+			RETURNING storage_key, thumbnail_key`
+
+	queryStart := time.Now()
+	rows, err := tr.db.Query(ctx, attachmentsSQL, before)
+	if err != nil {
+		observability.RecordError("database")
+		return result, huma.Error500InternalServerError("Unable to purge transaction attachments", err)
+	}
+	for rows.Next() {
+		var storageKey string
+		var thumbnailKey *string
+		if err := rows.Scan(&storageKey, &thumbnailKey); err != nil {
+			rows.Close()
+			return result, huma.Error500InternalServerError("Unable to scan purged attachment", err)
+		}
+		result.Attachments++
+		result.StorageKeys = append(result.StorageKeys, storageKey)
+		if thumbnailKey != nil {
+			result.StorageKeys = append(result.StorageKeys, *thumbnailKey)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		observability.RecordError("database")
+		return result, huma.Error500InternalServerError("Unable to purge transaction attachments", err)
+	}
+	observability.RecordQueryDuration("DELETE", "transaction_attachments", time.Since(queryStart).Seconds())
+
+	steps := []struct {
+		table  string
+		sql    string
+		result *int64
+	}{
+		{"transaction_split_tags", `DELETE FROM transaction_split_tags WHERE split_id IN (SELECT id FROM transaction_splits WHERE transaction_id IN (` + purgedTransactionsSQL + `))`, nil},
+		{"transaction_splits", `DELETE FROM transaction_splits WHERE transaction_id IN (` + purgedTransactionsSQL + `)`, nil},
+		{"transaction_tags", `DELETE FROM transaction_tags WHERE transaction_id IN (` + purgedTransactionsSQL + `)`, nil},
+		{"transaction_relations", `DELETE FROM transaction_relations WHERE source_transaction_id IN (` + purgedTransactionsSQL + `) OR related_transaction_id IN (` + purgedTransactionsSQL + `)`, nil},
+		{"transaction_revisions", `DELETE FROM transaction_revisions WHERE transaction_id IN (` + purgedTransactionsSQL + `)`, nil},
+		{"reimbursement_matches", `DELETE FROM reimbursement_matches WHERE transaction_id IN (` + purgedTransactionsSQL + `)`, nil},
+		{"reimbursements", `DELETE FROM reimbursements WHERE transaction_id IN (` + purgedTransactionsSQL + `)`, nil},
+		{"transactions", `DELETE FROM transactions WHERE deleted_at < $1`, &result.Transactions},
+		{"shares", `DELETE FROM shares WHERE resource_type = '` + constants.EntityTransactionTemplate + `' AND resource_id IN (SELECT id FROM transaction_templates WHERE deleted_at < $1)`, nil},
+		{"transaction_templates", `DELETE FROM transaction_templates WHERE deleted_at < $1`, &result.Templates},
+		{"transaction_split_tags", `DELETE FROM transaction_split_tags WHERE tag_id IN (SELECT id FROM tags WHERE deleted_at < $1)`, nil},
+		{"transaction_tags", `DELETE FROM transaction_tags WHERE tag_id IN (SELECT id FROM tags WHERE deleted_at < $1)`, nil},
+		{"tags", `DELETE FROM tags WHERE deleted_at < $1`, &result.Tags},
+		{"budgets", `DELETE FROM budgets WHERE deleted_at < $1`, &result.Budgets},
+		{"shares", `DELETE FROM shares WHERE resource_type = '` + constants.EntityBudgetTemplate + `' AND resource_id IN (SELECT bt.id FROM budget_templates bt WHERE ` + purgeableBudgetTemplateSQL + `)`, nil},
+		{"budget_templates", `DELETE FROM budget_templates bt WHERE ` + purgeableBudgetTemplateSQL, &result.BudgetTemplates},
+		{"payees", `DELETE FROM payees p WHERE ` + purgeablePayeeSQL, &result.Payees},
+		{"categories", `DELETE FROM categories c WHERE ` + purgeableCategorySQL, &result.Categories},
+		{"account_reconciliations", `DELETE FROM account_reconciliations WHERE account_id IN (SELECT a.id FROM accounts a WHERE ` + purgeableAccountSQL + `)`, nil},
+		{"shares", `DELETE FROM shares WHERE resource_type = '` + constants.EntityAccount + `' AND resource_id IN (SELECT a.id FROM accounts a WHERE ` + purgeableAccountSQL + `)`, nil},
+		{"accounts", `DELETE FROM accounts a WHERE ` + purgeableAccountSQL, &result.Accounts},
+	}
+
+	for _, step := range steps {
+		queryStart := time.Now()
+		cmdTag, err := tr.db.Exec(ctx, step.sql, before)
+		if err != nil {
+			observability.RecordError("database")
+			return result, huma.Error500InternalServerError("Unable to purge "+step.table, err)
+		}
+		observability.RecordQueryDuration("DELETE", step.table, time.Since(queryStart).Seconds())
+
+		if step.result != nil {
+			*step.result = cmdTag.RowsAffected()
+		}
+	}
+
+	return result, nil
+}
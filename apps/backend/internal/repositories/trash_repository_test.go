@@ -0,0 +1,278 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TEST_DATABASE_URL points at a disposable PostgreSQL database; the tests migrate it and roll back everything they write
+const testDatabaseURLEnv = "TEST_DATABASE_URL"
+
+func newTestTx(t *testing.T) pgx.Tx {
+	t.Helper()
+
+	url := os.Getenv(testDatabaseURLEnv)
+	if url == "" {
+		t.Skipf("%s is not set", testDatabaseURLEnv)
+	}
+
+	migrator, err := migrate.New("file://../../migrations", url)
+	if err != nil {
+		t.Fatalf("Failed to create migrator: %v", err)
+	}
+	defer migrator.Close()
+	if err := migrator.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, url)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+	t.Cleanup(func() { tx.Rollback(context.Background()) })
+
+	return tx
+}
+
+// purgeFixture holds live records the referencing rows need besides the purged one
+type purgeFixture struct {
+	userID     int64
+	accountID  int64
+	categoryID int64
+}
+
+func insertID(t *testing.T, tx pgx.Tx, sql string, args ...any) int64 {
+	t.Helper()
+
+	var id int64
+	if err := tx.QueryRow(context.Background(), sql, args...).Scan(&id); err != nil {
+		t.Fatalf("Failed to insert with %q: %v", sql, err)
+	}
+	return id
+}
+
+func exists(t *testing.T, tx pgx.Tx, table string, id int64) bool {
+	t.Helper()
+
+	var found bool
+	if err := tx.QueryRow(context.Background(), "SELECT EXISTS (SELECT 1 FROM "+table+" WHERE id = $1)", id).Scan(&found); err != nil {
+		t.Fatalf("Failed to look up %s %d: %v", table, id, err)
+	}
+	return found
+}
+
+func TestPurgeKeepsReferencedRecords(t *testing.T) {
+	tx := newTestTx(t)
+	ctx := context.Background()
+
+	deletedAt := time.Now().Add(-48 * time.Hour)
+	before := time.Now().Add(-24 * time.Hour)
+
+	var f purgeFixture
+	f.userID = insertID(t, tx, "INSERT INTO users (username, password_hash) VALUES ($1, 'x') RETURNING id", fmt.Sprintf("purge-test-%d", time.Now().UnixNano()))
+	f.accountID = insertID(t, tx, "INSERT INTO accounts (user_id, name, type) VALUES ($1, 'Live', 'expense') RETURNING id", f.userID)
+	f.categoryID = insertID(t, tx, "INSERT INTO categories (user_id, name, type) VALUES ($1, 'Live', 'expense') RETURNING id", f.userID)
+
+	deleted := map[string]string{
+		"accounts":   "INSERT INTO accounts (user_id, name, type, deleted_at) VALUES ($1, 'Deleted', 'expense', $2) RETURNING id",
+		"categories": "INSERT INTO categories (user_id, name, type, deleted_at) VALUES ($1, 'Deleted', 'expense', $2) RETURNING id",
+		"payees":     "INSERT INTO payees (user_id, name, deleted_at) VALUES ($1, 'Deleted', $2) RETURNING id",
+	}
+
+	// Foreign keys whose rows go away together with the record instead of keeping it
+	purgedWithRecord := map[string]bool{
+		"account_reconciliations.account_id": true,
+	}
+
+	tests := []struct {
+		table  string
+		column string
+		target string
+		sql    string
+		args   func(target int64) []any
+	}{
+		{
+			"transactions", "account_id", "accounts",
+			"INSERT INTO transactions (user_id, type, date, amount, account_id, category_id) VALUES ($1, 'expense', NOW(), 100, $2, $3) RETURNING id",
+			func(target int64) []any { return []any{f.userID, target, f.categoryID} },
+		},
+		{
+			"transactions", "destination_account_id", "accounts",
+			"INSERT INTO transactions (user_id, type, date, amount, account_id, category_id, destination_account_id) VALUES ($1, 'transfer', NOW(), 100, $2, $3, $4) RETURNING id",
+			func(target int64) []any { return []any{f.userID, f.accountID, f.categoryID, target} },
+		},
+		{
+			"transactions", "category_id", "categories",
+			"INSERT INTO transactions (user_id, type, date, amount, account_id, category_id) VALUES ($1, 'expense', NOW(), 100, $2, $3) RETURNING id",
+			func(target int64) []any { return []any{f.userID, f.accountID, target} },
+		},
+		{
+			"transactions", "payee_id", "payees",
+			"INSERT INTO transactions (user_id, type, date, amount, account_id, category_id, payee_id) VALUES ($1, 'expense', NOW(), 100, $2, $3, $4) RETURNING id",
+			func(target int64) []any { return []any{f.userID, f.accountID, f.categoryID, target} },
+		},
+		{
+			"transaction_splits", "category_id", "categories",
+			`WITH t AS (
+				INSERT INTO transactions (user_id, type, date, amount, account_id, category_id) VALUES ($1, 'expense', NOW(), 100, $2, $3) RETURNING id
+			)
+			INSERT INTO transaction_splits (transaction_id, category_id, amount) SELECT id, $4, 100 FROM t RETURNING id`,
+			func(target int64) []any { return []any{f.userID, f.accountID, f.categoryID, target} },
+		},
+		{
+			"transaction_templates", "account_id", "accounts",
+			"INSERT INTO transaction_templates (user_id, name, type, amount, account_id, category_id, recurrence, start_date) VALUES ($1, 'Rent', 'expense', 100, $2, $3, 'none', NOW()) RETURNING id",
+			func(target int64) []any { return []any{f.userID, target, f.categoryID} },
+		},
+		{
+			"transaction_templates", "destination_account_id", "accounts",
+			"INSERT INTO transaction_templates (user_id, name, type, amount, account_id, category_id, destination_account_id, recurrence, start_date) VALUES ($1, 'Savings', 'transfer', 100, $2, $3, $4, 'none', NOW()) RETURNING id",
+			func(target int64) []any { return []any{f.userID, f.accountID, f.categoryID, target} },
+		},
+		{
+			"transaction_templates", "category_id", "categories",
+			"INSERT INTO transaction_templates (user_id, name, type, amount, account_id, category_id, recurrence, start_date) VALUES ($1, 'Rent', 'expense', 100, $2, $3, 'none', NOW()) RETURNING id",
+			func(target int64) []any { return []any{f.userID, f.accountID, target} },
+		},
+		{
+			"transaction_templates", "payee_id", "payees",
+			"INSERT INTO transaction_templates (user_id, name, type, amount, account_id, category_id, payee_id, recurrence, start_date) VALUES ($1, 'Rent', 'expense', 100, $2, $3, $4, 'none', NOW()) RETURNING id",
+			func(target int64) []any { return []any{f.userID, f.accountID, f.categoryID, target} },
+		},
+		{
+			"budget_templates", "account_id", "accounts",
+			"INSERT INTO budget_templates (user_id, account_id, amount_limit, recurrence, start_date) VALUES ($1, $2, 100, 'none', CURRENT_DATE) RETURNING id",
+			func(target int64) []any { return []any{f.userID, target} },
+		},
+		{
+			"budget_templates", "category_id", "categories",
+			"INSERT INTO budget_templates (user_id, category_id, amount_limit, recurrence, start_date) VALUES ($1, $2, 100, 'none', CURRENT_DATE) RETURNING id",
+			func(target int64) []any { return []any{f.userID, target} },
+		},
+		{
+			"budgets", "account_id", "accounts",
+			"INSERT INTO budgets (user_id, account_id, period_start, period_end, amount_limit) VALUES ($1, $2, CURRENT_DATE, CURRENT_DATE, 100) RETURNING id",
+			func(target int64) []any { return []any{f.userID, target} },
+		},
+		{
+			"budgets", "category_id", "categories",
+			"INSERT INTO budgets (user_id, category_id, period_start, period_end, amount_limit) VALUES ($1, $2, CURRENT_DATE, CURRENT_DATE, 100) RETURNING id",
+			func(target int64) []any { return []any{f.userID, target} },
+		},
+		{
+			"transaction_rules", "account_id", "accounts",
+			"INSERT INTO transaction_rules (user_id, name, account_id) VALUES ($1, 'Rule', $2) RETURNING id",
+			func(target int64) []any { return []any{f.userID, target} },
+		},
+		{
+			"transaction_rules", "set_destination_account_id", "accounts",
+			"INSERT INTO transaction_rules (user_id, name, set_destination_account_id) VALUES ($1, 'Rule', $2) RETURNING id",
+			func(target int64) []any { return []any{f.userID, target} },
+		},
+		{
+			"transaction_rules", "set_category_id", "categories",
+			"INSERT INTO transaction_rules (user_id, name, set_category_id) VALUES ($1, 'Rule', $2) RETURNING id",
+			func(target int64) []any { return []any{f.userID, target} },
+		},
+	}
+
+	// Every foreign key to a purgeable record needs a case, so a new reference cannot slip past the guards
+	rows, err := tx.Query(ctx, `
+		SELECT src.relname, a.attname, dst.relname
+		FROM pg_constraint c
+		INNER JOIN pg_class src ON src.oid = c.conrelid
+		INNER JOIN pg_class dst ON dst.oid = c.confrelid
+		INNER JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = ANY(c.conkey)
+		WHERE c.contype = 'f' AND dst.relname IN ('accounts', 'categories', 'payees')`)
+	if err != nil {
+		t.Fatalf("Failed to list foreign keys: %v", err)
+	}
+	covered := make(map[string]bool)
+	for _, tt := range tests {
+		covered[tt.table+"."+tt.column+"->"+tt.target] = true
+	}
+	for rows.Next() {
+		var table, column, target string
+		if err := rows.Scan(&table, &column, &target); err != nil {
+			t.Fatalf("Failed to scan foreign key: %v", err)
+		}
+		if !covered[table+"."+column+"->"+target] && !purgedWithRecord[table+"."+column] {
+			t.Errorf("Foreign key %s.%s to %s has no purge guard case", table, column, target)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		t.Fatalf("Failed to list foreign keys: %v", err)
+	}
+
+	trash := NewTrashRepository(tx)
+	for _, tt := range tests {
+		t.Run(tt.table+"."+tt.column, func(t *testing.T) {
+			target := insertID(t, tx, deleted[tt.target], f.userID, deletedAt)
+			insertID(t, tx, tt.sql, tt.args(target)...)
+
+			if _, err := trash.Purge(ctx, before); err != nil {
+				t.Fatalf("Purge failed: %v", err)
+			}
+			if !exists(t, tx, tt.target, target) {
+				t.Errorf("Expected %s %d referenced from %s.%s to be kept", tt.target, target, tt.table, tt.column)
+			}
+		})
+	}
+}
+
+func TestPurgeRemovesDeletedBudgets(t *testing.T) {
+	tx := newTestTx(t)
+	ctx := context.Background()
+
+	deletedAt := time.Now().Add(-48 * time.Hour)
+	before := time.Now().Add(-24 * time.Hour)
+
+	userID := insertID(t, tx, "INSERT INTO users (username, password_hash) VALUES ($1, 'x') RETURNING id", fmt.Sprintf("purge-test-%d", time.Now().UnixNano()))
+	accountID := insertID(t, tx, "INSERT INTO accounts (user_id, name, type, deleted_at) VALUES ($1, 'Deleted', 'expense', $2) RETURNING id", userID, deletedAt)
+	templateID := insertID(t, tx, "INSERT INTO budget_templates (user_id, account_id, amount_limit, recurrence, start_date, deleted_at) VALUES ($1, $2, 100, 'monthly', CURRENT_DATE, $3) RETURNING id", userID, accountID, deletedAt)
+	budgetID := insertID(t, tx, "INSERT INTO budgets (user_id, template_id, account_id, period_start, period_end, amount_limit, deleted_at) VALUES ($1, $2, $3, CURRENT_DATE, CURRENT_DATE, 100, $4) RETURNING id", userID, templateID, accountID, deletedAt)
+	insertID(t, tx, "INSERT INTO budget_template_relations (budget_id, template_id) VALUES ($1, $2) RETURNING id", budgetID, templateID)
+
+	// A deleted template is kept while a budget generated from it is still live
+	keptTemplateID := insertID(t, tx, "INSERT INTO budget_templates (user_id, amount_limit, recurrence, start_date, deleted_at) VALUES ($1, 100, 'monthly', CURRENT_DATE, $2) RETURNING id", userID, deletedAt)
+	insertID(t, tx, "INSERT INTO budgets (user_id, template_id, period_start, period_end, amount_limit) VALUES ($1, $2, CURRENT_DATE, CURRENT_DATE, 100) RETURNING id", userID, keptTemplateID)
+
+	result, err := NewTrashRepository(tx).Purge(ctx, before)
+	if err != nil {
+		t.Fatalf("Purge failed: %v", err)
+	}
+
+	if exists(t, tx, "budgets", budgetID) {
+		t.Errorf("Expected budget %d to be purged", budgetID)
+	}
+	if exists(t, tx, "budget_templates", templateID) {
+		t.Errorf("Expected budget template %d to be purged", templateID)
+	}
+	if exists(t, tx, "accounts", accountID) {
+		t.Errorf("Expected account %d to be purged once its budgets are gone", accountID)
+	}
+	if !exists(t, tx, "budget_templates", keptTemplateID) {
+		t.Errorf("Expected budget template %d with a live budget to be kept", keptTemplateID)
+	}
+	if result.Budgets < 1 || result.BudgetTemplates < 1 || result.Accounts < 1 {
+		t.Errorf("Expected purged counts for budgets, budget templates and accounts, got %+v", result)
+	}
+}
@@ -0,0 +1,394 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/jackc/pgx/v5"
+)
+
+type UserRepository struct {
+	db DBQuerier
+}
+
+func NewUserRepository(db DBQuerier) UserRepository {
+	return UserRepository{db}
+}
+
+func (ur UserRepository) GetDetail(ctx context.Context, id int64) (models.UserModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	var data models.UserModel
+
+	sql := `
+		SELECT
+			id, username, totp_enabled_at IS NOT NULL, is_admin, created_at, updated_at, deleted_at
+		FROM users
+		WHERE id = $1
+			AND deleted_at IS NULL`
+
+	queryStart := time.Now()
+	err := ur.db.QueryRow(ctx, sql, id).Scan(&data.ID, &data.Username, &data.TwoFactor, &data.IsAdmin, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.UserModel{}, huma.Error404NotFound("User not found")
+		}
+		observability.RecordError("database")
+		return models.UserModel{}, huma.Error500InternalServerError("Unable to query user", err)
+	}
+	observability.RecordQueryDuration("SELECT", "users", time.Since(queryStart).Seconds())
+
+	return data, nil
+}
+
+// GetIDByUsername resolves another user by username, e.g. to share a resource with them
+func (ur UserRepository) GetIDByUsername(ctx context.Context, username string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	var id int64
+
+	sql := `
+		SELECT id
+		FROM users
+		WHERE username = $1
+			AND deleted_at IS NULL`
+
+	queryStart := time.Now()
+	err := ur.db.QueryRow(ctx, sql, username).Scan(&id)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, huma.Error404NotFound("User not found")
+		}
+		observability.RecordError("database")
+		return 0, huma.Error500InternalServerError("Unable to query user", err)
+	}
+	observability.RecordQueryDuration("SELECT", "users", time.Since(queryStart).Seconds())
+
+	return id, nil
+}
+
+// GetCredentials returns the stored password hash for a username so the caller can verify a login
+func (ur UserRepository) GetCredentials(ctx context.Context, username string) (models.UserCredentialsModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	var data models.UserCredentialsModel
+
+	sql := `
+		SELECT
+			id, username, password_hash
+		FROM users
+		WHERE username = $1
+			AND deleted_at IS NULL`
+
+	queryStart := time.Now()
+	err := ur.db.QueryRow(ctx, sql, username).Scan(&data.ID, &data.Username, &data.PasswordHash)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.UserCredentialsModel{}, huma.Error404NotFound("User not found")
+		}
+		observability.RecordError("database")
+		return models.UserCredentialsModel{}, huma.Error500InternalServerError("Unable to query user", err)
+	}
+	observability.RecordQueryDuration("SELECT", "users", time.Since(queryStart).Seconds())
+
+	return data, nil
+}
+
+// Create inserts a user with an already hashed password
+func (ur UserRepository) Create(ctx context.Context, username string, passwordHash string) (models.UserModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	var ID int64
+
+	sql := `INSERT INTO users (username, password_hash)
+			VALUES ($1, $2)
+			RETURNING id`
+
+	queryStart := time.Now()
+	err := ur.db.QueryRow(ctx, sql, username, passwordHash).Scan(&ID)
+
+	if err != nil {
+		observability.RecordError("database")
+		return models.UserModel{}, huma.Error500InternalServerError("Unable to create user", err)
+	}
+	observability.RecordQueryDuration("INSERT", "users", time.Since(queryStart).Seconds())
+
+	return ur.GetDetail(ctx, ID)
+}
+
+// GetIDByIdentity resolves the user linked to an OpenID Connect subject and records the login
+func (ur UserRepository) GetIDByIdentity(ctx context.Context, issuer string, subject string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	var id int64
+
+	sql := `
+		UPDATE user_identities ui
+		SET last_login_at = NOW()
+		FROM users u
+		WHERE ui.user_id = u.id
+			AND ui.issuer = $1
+			AND ui.subject = $2
+			AND u.deleted_at IS NULL
+		RETURNING ui.user_id`
+
+	queryStart := time.Now()
+	err := ur.db.QueryRow(ctx, sql, issuer, subject).Scan(&id)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, huma.Error404NotFound("User identity not found")
+		}
+		observability.RecordError("database")
+		return 0, huma.Error500InternalServerError("Unable to query user identity", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "user_identities", time.Since(queryStart).Seconds())
+
+	return id, nil
+}
+
+// LinkIdentity links an OpenID Connect subject to a user, so later logins no longer depend on the matched claim
+func (ur UserRepository) LinkIdentity(ctx context.Context, userID int64, issuer string, subject string, email string) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `INSERT INTO user_identities (user_id, issuer, subject, email)
+			VALUES ($1, $2, $3, NULLIF($4, ''))
+			ON CONFLICT (issuer, subject) DO NOTHING`
+
+	queryStart := time.Now()
+	if _, err := ur.db.Exec(ctx, sql, userID, issuer, subject, email); err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to link user identity", err)
+	}
+	observability.RecordQueryDuration("INSERT", "user_identities", time.Since(queryStart).Seconds())
+
+	return nil
+}
+
+// UpdatePassword replaces the password hash of the context user
+func (ur UserRepository) UpdatePassword(ctx context.Context, passwordHash string) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		UPDATE users
+		SET password_hash = $1,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2
+			AND deleted_at IS NULL`
+
+	queryStart := time.Now()
+	cmdTag, err := ur.db.Exec(ctx, sql, passwordHash, common.GetUserID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to update password", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return huma.Error404NotFound("User not found")
+	}
+	observability.RecordQueryDuration("UPDATE", "users", time.Since(queryStart).Seconds())
+
+	return nil
+}
+
+// GetActiveIDs returns the IDs of all active users, used by workers iterating per owner
+func (ur UserRepository) GetActiveIDs(ctx context.Context) ([]int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `SELECT id FROM users WHERE deleted_at IS NULL ORDER BY id`
+
+	queryStart := time.Now()
+	rows, err := ur.db.Query(ctx, sql)
+	if err != nil {
+		observability.RecordError("database")
+		return nil, huma.Error500InternalServerError("Unable to query users", err)
+	}
+	defer rows.Close()
+	observability.RecordQueryDuration("SELECT", "users", time.Since(queryStart).Seconds())
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, huma.Error500InternalServerError("Unable to scan user data", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, huma.Error500InternalServerError("Error reading user rows", err)
+	}
+
+	return ids, nil
+}
+
+// GetTOTP returns the two-factor state of the context user
+func (ur UserRepository) GetTOTP(ctx context.Context) (models.UserTOTPModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	var data models.UserTOTPModel
+
+	sql := `
+		SELECT
+			username, totp_secret, totp_enabled_at, totp_last_step
+		FROM users
+		WHERE id = $1
+			AND deleted_at IS NULL`
+
+	queryStart := time.Now()
+	err := ur.db.QueryRow(ctx, sql, common.GetUserID(ctx)).Scan(&data.Username, &data.Secret, &data.EnabledAt, &data.LastStep)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.UserTOTPModel{}, huma.Error404NotFound("User not found")
+		}
+		observability.RecordError("database")
+		return models.UserTOTPModel{}, huma.Error500InternalServerError("Unable to query user", err)
+	}
+	observability.RecordQueryDuration("SELECT", "users", time.Since(queryStart).Seconds())
+
+	return data, nil
+}
+
+// SetPendingTOTP stores a new secret for the context user; it is not enforced until EnableTOTP
+// Passing nil clears two-factor authentication entirely
+func (ur UserRepository) SetPendingTOTP(ctx context.Context, secret *string) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		UPDATE users
+		SET totp_secret = $1,
+			totp_enabled_at = NULL,
+			totp_last_step = NULL,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2
+			AND deleted_at IS NULL`
+
+	queryStart := time.Now()
+	cmdTag, err := ur.db.Exec(ctx, sql, secret, common.GetUserID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to update two-factor settings", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return huma.Error404NotFound("User not found")
+	}
+	observability.RecordQueryDuration("UPDATE", "users", time.Since(queryStart).Seconds())
+
+	return nil
+}
+
+// EnableTOTP turns on the pending secret of the context user, recording the verified time step
+func (ur UserRepository) EnableTOTP(ctx context.Context, step int64) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		UPDATE users
+		SET totp_enabled_at = CURRENT_TIMESTAMP,
+			totp_last_step = $1,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2
+			AND totp_secret IS NOT NULL
+			AND deleted_at IS NULL`
+
+	queryStart := time.Now()
+	cmdTag, err := ur.db.Exec(ctx, sql, step, common.GetUserID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to enable two-factor authentication", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return huma.Error404NotFound("No pending two-factor enrollment")
+	}
+	observability.RecordQueryDuration("UPDATE", "users", time.Since(queryStart).Seconds())
+
+	return nil
+}
+
+// AdvanceTOTPStep records a used time step; returns false when the step was already used
+// The conditional update makes concurrent submissions of the same code succeed only once
+func (ur UserRepository) AdvanceTOTPStep(ctx context.Context, step int64) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		UPDATE users
+		SET totp_last_step = $1
+		WHERE id = $2
+			AND (totp_last_step IS NULL OR totp_last_step < $1)
+			AND deleted_at IS NULL`
+
+	queryStart := time.Now()
+	cmdTag, err := ur.db.Exec(ctx, sql, step, common.GetUserID(ctx))
+	if err != nil {
+		observability.RecordError("database")
+		return false, huma.Error500InternalServerError("Unable to record two-factor code", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "users", time.Since(queryStart).Seconds())
+
+	return cmdTag.RowsAffected() > 0, nil
+}
+
+// ReplaceRecoveryCodes discards the context user's recovery codes and stores the given hashes
+func (ur UserRepository) ReplaceRecoveryCodes(ctx context.Context, codeHashes []string) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		WITH removed AS (
+			DELETE FROM user_recovery_codes WHERE user_id = $1
+		)
+		INSERT INTO user_recovery_codes (user_id, code_hash)
+		SELECT $1, hash FROM unnest($2::text[]) AS hash`
+
+	queryStart := time.Now()
+	if _, err := ur.db.Exec(ctx, sql, common.GetUserID(ctx), codeHashes); err != nil {
+		observability.RecordError("database")
+		return huma.Error500InternalServerError("Unable to store recovery codes", err)
+	}
+	observability.RecordQueryDuration("INSERT", "user_recovery_codes", time.Since(queryStart).Seconds())
+
+	return nil
+}
+
+// UseRecoveryCode marks a matching unused recovery code as used; returns false when none matched
+func (ur UserRepository) UseRecoveryCode(ctx context.Context, codeHash string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	sql := `
+		UPDATE user_recovery_codes
+		SET used_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1
+			AND code_hash = $2
+			AND used_at IS NULL`
+
+	queryStart := time.Now()
+	cmdTag, err := ur.db.Exec(ctx, sql, common.GetUserID(ctx), codeHash)
+	if err != nil {
+		observability.RecordError("database")
+		return false, huma.Error500InternalServerError("Unable to check recovery code", err)
+	}
+	observability.RecordQueryDuration("UPDATE", "user_recovery_codes", time.Since(queryStart).Seconds())
+
+	return cmdTag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,193 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/dimasbaguspm/spenicle-api/internal/services"
+)
+
+type AccountReconciliationResource struct {
+	sevs services.RootService
+}
+
+func NewAccountReconciliationResource(sevs services.RootService) AccountReconciliationResource {
+	return AccountReconciliationResource{sevs}
+}
+
+// Routes registers the statement reconciliation routes of an account
+func (arr AccountReconciliationResource) Routes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-account-reconciliations",
+		Method:      http.MethodGet,
+		Path:        "/accounts/{accountId}/reconciliations",
+		Summary:     "List account reconciliations",
+		Description: "Get the statement reconciliations of an account, newest first",
+		Tags:        []string{"Account Reconciliations"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsRead}},
+		},
+	}, arr.GetPaged)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-account-reconciliation",
+		Method:        http.MethodPost,
+		Path:          "/accounts/{accountId}/reconciliations",
+		Summary:       "Start account reconciliation",
+		Description:   "Open a reconciliation against a bank statement end date and closing balance. The response shows how far the cleared balance is from the statement. An account has at most one open reconciliation",
+		Tags:          []string{"Account Reconciliations"},
+		DefaultStatus: http.StatusCreated,
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, arr.Create)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-account-reconciliation",
+		Method:      http.MethodGet,
+		Path:        "/accounts/{accountId}/reconciliations/{reconciliationId}",
+		Summary:     "Get account reconciliation",
+		Description: "Get a reconciliation; while it is open, the response includes the cleared balance, the difference with the statement and the pending transactions up to the statement date",
+		Tags:        []string{"Account Reconciliations"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsRead}},
+		},
+	}, arr.GetDetail)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "complete-account-reconciliation",
+		Method:      http.MethodPost,
+		Path:        "/accounts/{accountId}/reconciliations/{reconciliationId}/complete",
+		Summary:     "Complete account reconciliation",
+		Description: "Mark the cleared transactions up to the statement date as reconciled. Fails with 409 while the cleared balance differs from the statement balance. Reconciled transactions can no longer be changed or deleted",
+		Tags:        []string{"Account Reconciliations"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, arr.Complete)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-account-reconciliation",
+		Method:      http.MethodDelete,
+		Path:        "/accounts/{accountId}/reconciliations/{reconciliationId}",
+		Summary:     "Discard account reconciliation",
+		Description: "Discard an open reconciliation; transaction statuses are kept. Completed reconciliations cannot be deleted",
+		Tags:        []string{"Account Reconciliations"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, arr.Delete)
+}
+
+func (arr AccountReconciliationResource) GetPaged(ctx context.Context, input *struct {
+	AccountID int64 `path:"accountId" minimum:"1" doc:"Account ID"`
+	models.AccountReconciliationsSearchModel
+}) (*struct {
+	Body models.AccountReconciliationsPagedModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("accounts", "GET", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "AccountReconciliationResource.GetPaged", "account_id", input.AccountID)
+	logger.Info("start")
+	resp, err := arr.sevs.AccRecon.GetPaged(ctx, input.AccountID, input.AccountReconciliationsSearchModel)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success", "count", len(resp.Items))
+	return &struct {
+		Body models.AccountReconciliationsPagedModel
+	}{
+		Body: resp,
+	}, nil
+}
+
+func (arr AccountReconciliationResource) GetDetail(ctx context.Context, input *struct {
+	AccountID        int64 `path:"accountId" minimum:"1" doc:"Account ID"`
+	ReconciliationID int64 `path:"reconciliationId" minimum:"1" doc:"Reconciliation ID"`
+}) (*struct {
+	Body models.AccountReconciliationDetailModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("accounts", "GET", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "AccountReconciliationResource.GetDetail", "account_id", input.AccountID, "reconciliation_id", input.ReconciliationID)
+	logger.Info("start")
+	resp, err := arr.sevs.AccRecon.GetDetail(ctx, input.AccountID, input.ReconciliationID)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct {
+		Body models.AccountReconciliationDetailModel
+	}{
+		Body: resp,
+	}, nil
+}
+
+func (arr AccountReconciliationResource) Create(ctx context.Context, input *struct {
+	AccountID int64 `path:"accountId" minimum:"1" doc:"Account ID"`
+	Body      models.CreateAccountReconciliationModel
+}) (*struct {
+	Body models.AccountReconciliationDetailModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("accounts", "POST", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "AccountReconciliationResource.Create", "account_id", input.AccountID)
+	logger.Info("start")
+	resp, err := arr.sevs.AccRecon.Create(ctx, input.AccountID, input.Body)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success", "reconciliation_id", resp.ID)
+	return &struct {
+		Body models.AccountReconciliationDetailModel
+	}{
+		Body: resp,
+	}, nil
+}
+
+func (arr AccountReconciliationResource) Complete(ctx context.Context, input *struct {
+	AccountID        int64 `path:"accountId" minimum:"1" doc:"Account ID"`
+	ReconciliationID int64 `path:"reconciliationId" minimum:"1" doc:"Reconciliation ID"`
+}) (*struct {
+	Body models.AccountReconciliationDetailModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("accounts", "POST", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "AccountReconciliationResource.Complete", "account_id", input.AccountID, "reconciliation_id", input.ReconciliationID)
+	logger.Info("start")
+	resp, err := arr.sevs.AccRecon.Complete(ctx, input.AccountID, input.ReconciliationID)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success", "reconciled_count", resp.ReconciledCount)
+	return &struct {
+		Body models.AccountReconciliationDetailModel
+	}{
+		Body: resp,
+	}, nil
+}
+
+func (arr AccountReconciliationResource) Delete(ctx context.Context, input *struct {
+	AccountID        int64 `path:"accountId" minimum:"1" doc:"Account ID"`
+	ReconciliationID int64 `path:"reconciliationId" minimum:"1" doc:"Reconciliation ID"`
+}) (*struct{}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("accounts", "DELETE", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "AccountReconciliationResource.Delete", "account_id", input.AccountID, "reconciliation_id", input.ReconciliationID)
+	logger.Info("start")
+	if err := arr.sevs.AccRecon.Delete(ctx, input.AccountID, input.ReconciliationID); err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct{}{}, nil
+}
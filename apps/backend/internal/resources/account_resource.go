@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
 	"github.com/dimasbaguspm/spenicle-api/internal/observability"
 	"github.com/dimasbaguspm/spenicle-api/internal/services"
@@ -26,7 +27,7 @@ func (ar AccountResource) Routes(api huma.API) {
 		Description: "Get a paginated list of accounts with optional search",
 		Tags:        []string{"Accounts"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsRead}},
 		},
 	}, ar.List)
 	huma.Register(api, huma.Operation{
@@ -37,7 +38,7 @@ func (ar AccountResource) Routes(api huma.API) {
 		Description: "Create a new account",
 		Tags:        []string{"Accounts"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
 		},
 	}, ar.Create)
 	huma.Register(api, huma.Operation{
@@ -48,7 +49,7 @@ func (ar AccountResource) Routes(api huma.API) {
 		Description: "Get a single account by ID",
 		Tags:        []string{"Accounts"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsRead}},
 		},
 	}, ar.Get)
 	huma.Register(api, huma.Operation{
@@ -56,10 +57,10 @@ func (ar AccountResource) Routes(api huma.API) {
 		Method:      "PATCH",
 		Path:        "/accounts/{id}",
 		Summary:     "Update account",
-		Description: "Update an existing account",
+		Description: "Update an existing account. With If-Match, it only succeeds while the account still has that ETag, and fails with 412 carrying the current account otherwise",
 		Tags:        []string{"Accounts"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
 		},
 	}, ar.Update)
 	huma.Register(api, huma.Operation{
@@ -67,12 +68,56 @@ func (ar AccountResource) Routes(api huma.API) {
 		Method:      "DELETE",
 		Path:        "/accounts/{id}",
 		Summary:     "Delete account",
-		Description: "Delete an account",
+		Description: "Delete an account. With If-Match, it only succeeds while the account still has that ETag, and fails with 412 carrying the current account otherwise",
 		Tags:        []string{"Accounts"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
 		},
 	}, ar.Delete)
+	huma.Register(api, huma.Operation{
+		OperationID: "restore-account",
+		Method:      "POST",
+		Path:        "/accounts/{id}/restore",
+		Summary:     "Restore account",
+		Description: "Bring a deleted account back from the trash, with the balance it had when it was deleted. Only the owner can restore an account",
+		Tags:        []string{"Accounts"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, ar.Restore)
+	huma.Register(api, huma.Operation{
+		OperationID: "list-account-shares",
+		Method:      "GET",
+		Path:        "/accounts/{id}/shares",
+		Summary:     "List account shares",
+		Description: "List the users a account is shared with and their roles. Only the owner can see this list",
+		Tags:        []string{"Accounts"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsRead}},
+		},
+	}, ar.ListShares)
+	huma.Register(api, huma.Operation{
+		OperationID: "share-account",
+		Method:      "PUT",
+		Path:        "/accounts/{id}/shares",
+		Summary:     "Share account",
+		Description: "Share a account with another user as viewer or editor, or change the role of an existing share. Viewers can read it, editors can also change it. Only the owner can manage shares",
+		Tags:        []string{"Accounts"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, ar.Share)
+	huma.Register(api, huma.Operation{
+		OperationID: "unshare-account",
+		Method:      "DELETE",
+		Path:        "/accounts/{id}/shares/{userId}",
+		Summary:     "Unshare account",
+		Description: "Revoke a user's access to a account",
+		Tags:        []string{"Accounts"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, ar.Unshare)
 	huma.Register(api, huma.Operation{
 		OperationID: "reorder-accounts",
 		Method:      "POST",
@@ -81,7 +126,7 @@ func (ar AccountResource) Routes(api huma.API) {
 		Description: "Update display order for multiple accounts",
 		Tags:        []string{"Accounts"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
 		},
 	}, ar.Reorder)
 }
@@ -109,6 +154,7 @@ func (ar AccountResource) List(ctx context.Context, input *struct {
 func (ar AccountResource) Get(ctx context.Context, input *struct {
 	ID int64 `path:"id" minimum:"1" doc:"Unique identifier of the account" example:"1"`
 }) (*struct {
+	ETag string `header:"ETag"`
 	Body models.AccountModel
 }, error) {
 	start := time.Now()
@@ -122,8 +168,10 @@ func (ar AccountResource) Get(ctx context.Context, input *struct {
 	}
 	logger.Info("success")
 	return &struct {
+		ETag string `header:"ETag"`
 		Body models.AccountModel
 	}{
+		ETag: resp.ETag,
 		Body: resp,
 	}, nil
 }
@@ -149,35 +197,40 @@ func (ar AccountResource) Create(ctx context.Context, input *struct {
 	}, nil
 }
 func (ar AccountResource) Update(ctx context.Context, input *struct {
-	ID   int64 `path:"id" minimum:"1" doc:"Unique identifier of the account" example:"1"`
-	Body models.UpdateAccountModel
+	ID      int64  `path:"id" minimum:"1" doc:"Unique identifier of the account" example:"1"`
+	IfMatch string `header:"If-Match" doc:"ETag the account was read with; the request fails with 412 when it has changed since"`
+	Body    models.UpdateAccountModel
 }) (*struct {
+	ETag string `header:"ETag"`
 	Body models.AccountModel
 }, error) {
 	start := time.Now()
 	defer func() { observability.RecordServiceOperation("accounts", "PATCH", time.Since(start).Seconds()) }()
 	logger := observability.GetLogger(ctx).With("resource", "AccountResource.Update", "account_id", input.ID)
 	logger.Info("start")
-	resp, err := ar.sevs.Acc.Update(ctx, input.ID, input.Body)
+	resp, err := ar.sevs.Acc.Update(ctx, input.ID, input.Body, input.IfMatch)
 	if err != nil {
 		logger.Error("error", "error", err)
 		return nil, err
 	}
 	logger.Info("success")
 	return &struct {
+		ETag string `header:"ETag"`
 		Body models.AccountModel
 	}{
+		ETag: resp.ETag,
 		Body: resp,
 	}, nil
 }
 func (ar AccountResource) Delete(ctx context.Context, input *struct {
-	ID int64 `path:"id" minimum:"1" doc:"Unique identifier of the account" example:"1"`
+	ID      int64  `path:"id" minimum:"1" doc:"Unique identifier of the account" example:"1"`
+	IfMatch string `header:"If-Match" doc:"ETag the account was read with; the request fails with 412 when it has changed since"`
 }) (*struct{}, error) {
 	start := time.Now()
 	defer func() { observability.RecordServiceOperation("accounts", "DELETE", time.Since(start).Seconds()) }()
 	logger := observability.GetLogger(ctx).With("resource", "AccountResource.Delete", "account_id", input.ID)
 	logger.Info("start")
-	err := ar.sevs.Acc.Delete(ctx, input.ID)
+	err := ar.sevs.Acc.Delete(ctx, input.ID, input.IfMatch)
 	if err != nil {
 		logger.Error("error", "error", err)
 		return nil, err
@@ -185,6 +238,27 @@ func (ar AccountResource) Delete(ctx context.Context, input *struct {
 	logger.Info("success")
 	return nil, nil
 }
+func (ar AccountResource) Restore(ctx context.Context, input *struct {
+	ID int64 `path:"id" minimum:"1" doc:"Unique identifier of the account" example:"1"`
+}) (*struct {
+	Body models.AccountModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("accounts", "POST", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "AccountResource.Restore", "account_id", input.ID)
+	logger.Info("start")
+	resp, err := ar.sevs.Acc.Restore(ctx, input.ID)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct {
+		Body models.AccountModel
+	}{
+		Body: resp,
+	}, nil
+}
 func (ar AccountResource) Reorder(ctx context.Context, input *struct {
 	Body models.ReorderAccountsModel
 }) (*struct{}, error) {
@@ -200,3 +274,61 @@ func (ar AccountResource) Reorder(ctx context.Context, input *struct {
 	logger.Info("success")
 	return &struct{}{}, nil
 }
+func (ar AccountResource) ListShares(ctx context.Context, input *struct {
+	ID int64 `path:"id" minimum:"1" doc:"Unique identifier of the account" example:"1"`
+}) (*struct {
+	Body models.SharesListModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("accounts", "GET", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "AccountResource.ListShares", "account_id", input.ID)
+	logger.Info("start")
+	resp, err := ar.sevs.Shr.GetList(ctx, constants.EntityAccount, input.ID)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct {
+		Body models.SharesListModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (ar AccountResource) Share(ctx context.Context, input *struct {
+	ID   int64 `path:"id" minimum:"1" doc:"Unique identifier of the account" example:"1"`
+	Body models.UpsertShareModel
+}) (*struct {
+	Body models.SharesListModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("accounts", "PUT", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "AccountResource.Share", "account_id", input.ID)
+	logger.Info("start", "role", input.Body.Role)
+	resp, err := ar.sevs.Shr.Upsert(ctx, constants.EntityAccount, input.ID, input.Body)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct {
+		Body models.SharesListModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (ar AccountResource) Unshare(ctx context.Context, input *struct {
+	ID     int64 `path:"id" minimum:"1" doc:"Unique identifier of the account" example:"1"`
+	UserID int64 `path:"userId" minimum:"1" doc:"ID of the user to revoke access from" example:"2"`
+}) (*struct{}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("accounts", "DELETE", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "AccountResource.Unshare", "account_id", input.ID, "user_id", input.UserID)
+	logger.Info("start")
+	if err := ar.sevs.Shr.Delete(ctx, constants.EntityAccount, input.ID, input.UserID); err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return nil, nil
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
 	"github.com/dimasbaguspm/spenicle-api/internal/observability"
 	"github.com/dimasbaguspm/spenicle-api/internal/services"
@@ -32,7 +33,7 @@ func (sr AccountStatisticsResource) Routes(api huma.API) {
 		Description: "Returns all account statistics including category heatmap, monthly velocity, time frequency distribution, cash flow pulse (balance trend), burn rate (spending analysis), and budget health metrics",
 		Tags:        []string{"Accounts"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeReportsRead}},
 		},
 	}, sr.GetAccountStatistics)
 
@@ -44,7 +45,7 @@ func (sr AccountStatisticsResource) Routes(api huma.API) {
 		Description: "Returns spending distribution by category for the specified time period",
 		Tags:        []string{"Accounts"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeReportsRead}},
 		},
 	}, sr.GetCategoryHeatmap)
 
@@ -56,7 +57,7 @@ func (sr AccountStatisticsResource) Routes(api huma.API) {
 		Description: "Returns month-over-month spending trends and velocity metrics",
 		Tags:        []string{"Accounts"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeReportsRead}},
 		},
 	}, sr.GetMonthlyVelocity)
 
@@ -68,7 +69,7 @@ func (sr AccountStatisticsResource) Routes(api huma.API) {
 		Description: "Returns frequency distribution of transactions (daily, weekly, monthly, irregular)",
 		Tags:        []string{"Accounts"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeReportsRead}},
 		},
 	}, sr.GetTimeFrequencyHeatmap)
 
@@ -80,7 +81,7 @@ func (sr AccountStatisticsResource) Routes(api huma.API) {
 		Description: "Returns daily balance trend over the specified period for visualizing cash flow patterns",
 		Tags:        []string{"Accounts"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeReportsRead}},
 		},
 	}, sr.GetCashFlowPulse)
 
@@ -92,7 +93,7 @@ func (sr AccountStatisticsResource) Routes(api huma.API) {
 		Description: "Returns daily/weekly/monthly average spending and budget projection estimates",
 		Tags:        []string{"Accounts"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeReportsRead}},
 		},
 	}, sr.GetBurnRate)
 
@@ -104,7 +105,7 @@ func (sr AccountStatisticsResource) Routes(api huma.API) {
 		Description: "Returns health status of active and past budgets for this account",
 		Tags:        []string{"Accounts"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeReportsRead}},
 		},
 	}, sr.GetBudgetHealth)
 }
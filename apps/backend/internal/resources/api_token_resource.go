@@ -0,0 +1,108 @@
+package resources
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/dimasbaguspm/spenicle-api/internal/services"
+)
+
+type APITokenResource struct {
+	sevs services.RootService
+}
+
+func NewAPITokenResource(sevs services.RootService) APITokenResource {
+	return APITokenResource{sevs}
+}
+
+// Routes registers token management without a scope, so personal access tokens cannot mint or revoke tokens
+func (atr APITokenResource) Routes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-api-tokens",
+		Method:      "GET",
+		Path:        "/auth/tokens",
+		Summary:     "List personal access tokens",
+		Description: "Get the active personal access tokens of the current user",
+		Tags:        []string{"Auth"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, atr.List)
+	huma.Register(api, huma.Operation{
+		OperationID: "create-api-token",
+		Method:      "POST",
+		Path:        "/auth/tokens",
+		Summary:     "Create personal access token",
+		Description: "Create a long-lived scoped token for scripts and integrations. The token value is only returned once",
+		Tags:        []string{"Auth"},
+		Metadata:    map[string]any{constants.IdempotencyExemptMetadataKey: true},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, atr.Create)
+	huma.Register(api, huma.Operation{
+		OperationID: "revoke-api-token",
+		Method:      "DELETE",
+		Path:        "/auth/tokens/{id}",
+		Summary:     "Revoke personal access token",
+		Description: "Revoke a personal access token, it stops working immediately",
+		Tags:        []string{"Auth"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, atr.Delete)
+}
+func (atr APITokenResource) List(ctx context.Context, input *struct{}) (*struct{ Body models.APITokensListModel }, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("api_tokens", "GET", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "APITokenResource.List")
+	logger.Info("start")
+	resp, err := atr.sevs.APITok.GetList(ctx)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct{ Body models.APITokensListModel }{
+		Body: resp,
+	}, nil
+}
+func (atr APITokenResource) Create(ctx context.Context, input *struct {
+	Body models.CreateAPITokenModel
+}) (*struct {
+	Body models.CreateAPITokenResponseModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("api_tokens", "POST", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "APITokenResource.Create")
+	logger.Info("start")
+	resp, err := atr.sevs.APITok.Create(ctx, input.Body)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success", "api_token_id", resp.ID)
+	return &struct {
+		Body models.CreateAPITokenResponseModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (atr APITokenResource) Delete(ctx context.Context, input *struct {
+	ID int64 `path:"id" minimum:"1" doc:"Unique identifier of the token" example:"1"`
+}) (*struct{}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("api_tokens", "DELETE", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "APITokenResource.Delete")
+	logger.Info("start", "api_token_id", input.ID)
+	if err := atr.sevs.APITok.Delete(ctx, input.ID); err != nil {
+		logger.Error("error", "api_token_id", input.ID, "error", err)
+		return nil, err
+	}
+	logger.Info("success", "api_token_id", input.ID)
+	return nil, nil
+}
@@ -0,0 +1,53 @@
+package resources
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/dimasbaguspm/spenicle-api/internal/services"
+)
+
+type AuditResource struct {
+	sevs services.RootService
+}
+
+func NewAuditResource(sevs services.RootService) AuditResource {
+	return AuditResource{sevs}
+}
+func (ar AuditResource) Routes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-audit-logs",
+		Method:      "GET",
+		Path:        "/audit",
+		Summary:     "List audit logs",
+		Description: "Get a paginated, append-only history of every create, update and delete, with before/after snapshots. Includes changes made by the current user and changes made by others to data the current user owns",
+		Tags:        []string{"Audit"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, ar.List)
+}
+func (ar AuditResource) List(ctx context.Context, input *struct {
+	models.AuditLogsSearchModel
+}) (*struct {
+	Body models.AuditLogsPagedModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("audit", "GET", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "AuditResource.List")
+	logger.Info("start")
+	resp, err := ar.sevs.Aud.GetPaged(ctx, input.AuditLogsSearchModel)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success", "count", len(resp.Items))
+	return &struct {
+		Body models.AuditLogsPagedModel
+	}{
+		Body: resp,
+	}, nil
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
 	"github.com/dimasbaguspm/spenicle-api/internal/observability"
 	"github.com/dimasbaguspm/spenicle-api/internal/services"
@@ -23,24 +24,131 @@ func (ar AuthResource) Routes(api huma.API) {
 		Method:      "POST",
 		Path:        "/auth/login",
 		Summary:     "Login",
-		Description: "Authenticate and receive access and refresh tokens",
+		Description: "Authenticate and receive access and refresh tokens, or a challenge token when two-factor authentication is enabled. Repeated failures lock the username with an exponential backoff; while locked, 429 is returned with lockedUntil in the body",
 		Tags:        []string{"Auth"},
+		Metadata:    map[string]any{constants.IdempotencyExemptMetadataKey: true},
 	}, ar.Login)
+	huma.Register(api, huma.Operation{
+		OperationID: "login-verify",
+		Method:      "POST",
+		Path:        "/auth/login/verify",
+		Summary:     "Verify two-factor login",
+		Description: "Exchange the challenge token from login and a TOTP or recovery code for access and refresh tokens",
+		Tags:        []string{"Auth"},
+		Metadata:    map[string]any{constants.IdempotencyExemptMetadataKey: true},
+	}, ar.VerifyLogin)
 	huma.Register(api, huma.Operation{
 		OperationID: "refresh",
 		Method:      "POST",
 		Path:        "/auth/refresh",
 		Summary:     "Refresh token",
-		Description: "Get new access token using refresh token",
+		Description: "Exchange a refresh token for a new token pair, the used refresh token is revoked",
 		Tags:        []string{"Auth"},
+		Metadata:    map[string]any{constants.IdempotencyExemptMetadataKey: true},
 	}, ar.Refresh)
+	huma.Register(api, huma.Operation{
+		OperationID: "oidc-authorize",
+		Method:      "GET",
+		Path:        "/auth/oidc/authorize",
+		Summary:     "Start OpenID Connect login",
+		Description: "Return the identity provider URL for an authorization code login with PKCE. After signing in, the provider redirects to the configured redirect URL with code and state, which are sent to /auth/oidc/callback. Returns 404 when no issuer is configured",
+		Tags:        []string{"Auth"},
+	}, ar.OIDCAuthorize)
+	huma.Register(api, huma.Operation{
+		OperationID: "oidc-callback",
+		Method:      "POST",
+		Path:        "/auth/oidc/callback",
+		Summary:     "Complete OpenID Connect login",
+		Description: "Exchange the code and state from the identity provider redirect for access and refresh tokens. The issuer's subject is linked to the local user whose username matches the configured claim (email by default) on first login",
+		Tags:        []string{"Auth"},
+		Metadata:    map[string]any{constants.IdempotencyExemptMetadataKey: true},
+	}, ar.OIDCCallback)
+}
+
+// PrivateRoutes registers the auth operations that require a valid access token
+func (ar AuthResource) PrivateRoutes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "logout",
+		Method:      "POST",
+		Path:        "/auth/logout",
+		Summary:     "Logout",
+		Description: "Revoke the current access token and optionally its refresh token",
+		Tags:        []string{"Auth"},
+		Metadata:    map[string]any{constants.IdempotencyExemptMetadataKey: true},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, ar.Logout)
+	huma.Register(api, huma.Operation{
+		OperationID: "logout-all",
+		Method:      "POST",
+		Path:        "/auth/logout-all",
+		Summary:     "Logout everywhere",
+		Description: "Revoke every access and refresh token issued to the current user",
+		Tags:        []string{"Auth"},
+		Metadata:    map[string]any{constants.IdempotencyExemptMetadataKey: true},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, ar.LogoutAll)
+	huma.Register(api, huma.Operation{
+		OperationID: "change-password",
+		Method:      "POST",
+		Path:        "/auth/change-password",
+		Summary:     "Change password",
+		Description: "Replace the password of the current user. Every access and refresh token issued so far is revoked, including the one used for this request, so all devices have to log in again. Personal access tokens are revoked too. Wrong current passwords count towards the login throttle",
+		Tags:        []string{"Auth"},
+		Metadata:    map[string]any{constants.IdempotencyExemptMetadataKey: true},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, ar.ChangePassword)
+	huma.Register(api, huma.Operation{
+		OperationID: "list-sessions",
+		Method:      "GET",
+		Path:        "/auth/sessions",
+		Summary:     "List sessions",
+		Description: "List every device currently holding a token, with its device name, user agent, IP address and last-seen time",
+		Tags:        []string{"Auth"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, ar.ListSessions)
+	huma.Register(api, huma.Operation{
+		OperationID: "revoke-session",
+		Method:      "DELETE",
+		Path:        "/auth/sessions/{id}",
+		Summary:     "Revoke session",
+		Description: "End a session, immediately invalidating its access and refresh tokens, e.g. for a lost device",
+		Tags:        []string{"Auth"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, ar.RevokeSession)
 }
 func (ar AuthResource) Login(ctx context.Context, input *struct{ Body models.LoginRequestModel }) (*struct{ Body models.LoginResponseModel }, error) {
 	start := time.Now()
 	defer func() { observability.RecordServiceOperation("auth", "POST", time.Since(start).Seconds()) }()
 	logger := observability.GetLogger(ctx).With("resource", "AuthResource.Login")
 	logger.Info("start")
-	resp, err := ar.as.Login(input.Body)
+	resp, err := ar.as.Login(ctx, input.Body)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct{ Body models.LoginResponseModel }{
+		Body: resp,
+	}, nil
+}
+func (ar AuthResource) VerifyLogin(ctx context.Context, input *struct {
+	Body models.LoginVerifyRequestModel
+}) (*struct{ Body models.LoginResponseModel }, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("auth", "POST", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "AuthResource.VerifyLogin")
+	logger.Info("start")
+	resp, err := ar.as.VerifyLogin(ctx, input.Body)
 	if err != nil {
 		logger.Error("error", "error", err)
 		return nil, err
@@ -55,7 +163,7 @@ func (ar AuthResource) Refresh(ctx context.Context, input *struct{ Body models.R
 	defer func() { observability.RecordServiceOperation("auth", "POST", time.Since(start).Seconds()) }()
 	logger := observability.GetLogger(ctx).With("resource", "AuthResource.Refresh")
 	logger.Info("start")
-	resp, err := ar.as.Refresh(input.Body)
+	resp, err := ar.as.Refresh(ctx, input.Body)
 	if err != nil {
 		logger.Error("error", "error", err)
 		return nil, err
@@ -65,3 +173,110 @@ func (ar AuthResource) Refresh(ctx context.Context, input *struct{ Body models.R
 		Body: resp,
 	}, nil
 }
+func (ar AuthResource) OIDCAuthorize(ctx context.Context, input *struct{}) (*struct {
+	Body models.OIDCAuthorizeResponseModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("auth", "GET", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "AuthResource.OIDCAuthorize")
+	logger.Info("start")
+	resp, err := ar.as.OIDCAuthorize(ctx)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct {
+		Body models.OIDCAuthorizeResponseModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (ar AuthResource) OIDCCallback(ctx context.Context, input *struct {
+	Body models.OIDCCallbackRequestModel
+}) (*struct{ Body models.LoginResponseModel }, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("auth", "POST", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "AuthResource.OIDCCallback")
+	logger.Info("start")
+	resp, err := ar.as.OIDCCallback(ctx, input.Body)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct{ Body models.LoginResponseModel }{
+		Body: resp,
+	}, nil
+}
+func (ar AuthResource) Logout(ctx context.Context, input *struct{ Body *models.LogoutRequestModel }) (*struct{}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("auth", "POST", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "AuthResource.Logout")
+	logger.Info("start")
+	var body models.LogoutRequestModel
+	if input.Body != nil {
+		body = *input.Body
+	}
+	if err := ar.as.Logout(ctx, body); err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return nil, nil
+}
+func (ar AuthResource) LogoutAll(ctx context.Context, input *struct{}) (*struct{}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("auth", "POST", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "AuthResource.LogoutAll")
+	logger.Info("start")
+	if err := ar.as.LogoutAll(ctx); err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return nil, nil
+}
+func (ar AuthResource) ChangePassword(ctx context.Context, input *struct {
+	Body models.ChangePasswordRequestModel
+}) (*struct{}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("auth", "POST", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "AuthResource.ChangePassword")
+	logger.Info("start")
+	if err := ar.as.ChangePassword(ctx, input.Body); err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return nil, nil
+}
+func (ar AuthResource) ListSessions(ctx context.Context, input *struct{}) (*struct{ Body models.SessionsListModel }, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("auth", "GET", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "AuthResource.ListSessions")
+	logger.Info("start")
+	resp, err := ar.as.GetSessions(ctx)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success", "count", len(resp.Items))
+	return &struct{ Body models.SessionsListModel }{
+		Body: resp,
+	}, nil
+}
+func (ar AuthResource) RevokeSession(ctx context.Context, input *struct {
+	ID int64 `path:"id" minimum:"1" doc:"Unique identifier of the session" example:"1"`
+}) (*struct{}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("auth", "DELETE", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "AuthResource.RevokeSession", "session_id", input.ID)
+	logger.Info("start")
+	if err := ar.as.RevokeSession(ctx, input.ID); err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return nil, nil
+}
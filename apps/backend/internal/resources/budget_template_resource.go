@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
 	"github.com/dimasbaguspm/spenicle-api/internal/observability"
 	"github.com/dimasbaguspm/spenicle-api/internal/services"
@@ -27,7 +28,7 @@ func (btr BudgetTemplateResource) Routes(api huma.API) {
 		Description: "Get a paginated list of budget templates",
 		Tags:        []string{"Budget Templates"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeBudgetsRead}},
 		},
 	}, btr.GetPaged)
 	huma.Register(api, huma.Operation{
@@ -38,7 +39,7 @@ func (btr BudgetTemplateResource) Routes(api huma.API) {
 		Description: "Create a new budget template",
 		Tags:        []string{"Budget Templates"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeBudgetsWrite}},
 		},
 	}, btr.Create)
 	huma.Register(api, huma.Operation{
@@ -49,7 +50,7 @@ func (btr BudgetTemplateResource) Routes(api huma.API) {
 		Description: "Get a single budget template by ID",
 		Tags:        []string{"Budget Templates"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeBudgetsRead}},
 		},
 	}, btr.GetDetail)
 	huma.Register(api, huma.Operation{
@@ -57,10 +58,10 @@ func (btr BudgetTemplateResource) Routes(api huma.API) {
 		Method:      http.MethodPatch,
 		Path:        "/budgets/{id}",
 		Summary:     "Update budget template",
-		Description: "Update an existing budget template (name, note, active status only)",
+		Description: "Update an existing budget template (name, note, active status only). With If-Match, it only succeeds while the template still has that ETag, and fails with 412 carrying the current template otherwise",
 		Tags:        []string{"Budget Templates"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeBudgetsWrite}},
 		},
 	}, btr.Update)
 	huma.Register(api, huma.Operation{
@@ -71,7 +72,7 @@ func (btr BudgetTemplateResource) Routes(api huma.API) {
 		Description: "Get budgets generated from a budget template with pagination",
 		Tags:        []string{"Budget Templates"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeBudgetsRead}},
 		},
 	}, btr.GetRelatedBudgets)
 	huma.Register(api, huma.Operation{
@@ -82,7 +83,7 @@ func (btr BudgetTemplateResource) Routes(api huma.API) {
 		Description: "Update an individual budget's amount limit only (does not affect template or future budgets)",
 		Tags:        []string{"Budget Templates"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeBudgetsWrite}},
 		},
 	}, btr.UpdateBudget)
 	huma.Register(api, huma.Operation{
@@ -93,9 +94,42 @@ func (btr BudgetTemplateResource) Routes(api huma.API) {
 		Description: "Get a single budget generated from a budget template by ID",
 		Tags:        []string{"Budget Templates"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeBudgetsRead}},
 		},
 	}, btr.GetGeneratedBudgetDetail)
+	huma.Register(api, huma.Operation{
+		OperationID: "list-budget-template-shares",
+		Method:      http.MethodGet,
+		Path:        "/budgets/{id}/shares",
+		Summary:     "List budget template shares",
+		Description: "List the users a budget template is shared with and their roles. Only the owner can see this list",
+		Tags:        []string{"Budget Templates"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeBudgetsRead}},
+		},
+	}, btr.ListShares)
+	huma.Register(api, huma.Operation{
+		OperationID: "share-budget-template",
+		Method:      http.MethodPut,
+		Path:        "/budgets/{id}/shares",
+		Summary:     "Share budget template",
+		Description: "Share a budget template with another user as viewer or editor, or change the role of an existing share. Viewers can read it, editors can also change it. Only the owner can manage shares",
+		Tags:        []string{"Budget Templates"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeBudgetsWrite}},
+		},
+	}, btr.Share)
+	huma.Register(api, huma.Operation{
+		OperationID: "unshare-budget-template",
+		Method:      http.MethodDelete,
+		Path:        "/budgets/{id}/shares/{userId}",
+		Summary:     "Unshare budget template",
+		Description: "Revoke a user's access to a budget template",
+		Tags:        []string{"Budget Templates"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeBudgetsWrite}},
+		},
+	}, btr.Unshare)
 
 }
 func (btr BudgetTemplateResource) GetPaged(ctx context.Context, input *struct {
@@ -120,6 +154,7 @@ func (btr BudgetTemplateResource) GetPaged(ctx context.Context, input *struct {
 func (btr BudgetTemplateResource) GetDetail(ctx context.Context, input *struct {
 	ID int64 `path:"id" minimum:"1" doc:"Budget Template ID"`
 }) (*struct {
+	ETag string `header:"ETag"`
 	Body models.BudgetTemplateModel
 }, error) {
 	start := time.Now()
@@ -133,8 +168,9 @@ func (btr BudgetTemplateResource) GetDetail(ctx context.Context, input *struct {
 	}
 	logger.Info("start", "template_id", input.ID)
 	return &struct {
+		ETag string `header:"ETag"`
 		Body models.BudgetTemplateModel
-	}{Body: item}, nil
+	}{ETag: item.ETag, Body: item}, nil
 }
 func (btr BudgetTemplateResource) Create(ctx context.Context, input *struct {
 	Body models.CreateBudgetTemplateModel
@@ -156,9 +192,11 @@ func (btr BudgetTemplateResource) Create(ctx context.Context, input *struct {
 	}{Body: resp}, nil
 }
 func (btr BudgetTemplateResource) Update(ctx context.Context, input *struct {
-	ID   int64 `path:"id" minimum:"1" doc:"Budget Template ID"`
-	Body models.UpdateBudgetTemplateModel
+	ID      int64  `path:"id" minimum:"1" doc:"Budget Template ID"`
+	IfMatch string `header:"If-Match" doc:"ETag the budget template was read with; the request fails with 412 when it has changed since"`
+	Body    models.UpdateBudgetTemplateModel
 }) (*struct {
+	ETag string `header:"ETag"`
 	Body models.BudgetTemplateModel
 }, error) {
 	start := time.Now()
@@ -172,15 +210,16 @@ func (btr BudgetTemplateResource) Update(ctx context.Context, input *struct {
 		return nil, huma.Error400BadRequest("At least one of name, note, or active must be provided")
 	}
 
-	resp, err := btr.sevs.BudgTem.Update(ctx, input.ID, input.Body)
+	resp, err := btr.sevs.BudgTem.Update(ctx, input.ID, input.Body, input.IfMatch)
 	if err != nil {
 		logger.Error("error", "template_id", input.ID, "error", err)
 		return nil, err
 	}
 	logger.Info("success", "template_id", input.ID)
 	return &struct {
+		ETag string `header:"ETag"`
 		Body models.BudgetTemplateModel
-	}{Body: resp}, nil
+	}{ETag: resp.ETag, Body: resp}, nil
 }
 
 func (btr BudgetTemplateResource) GetRelatedBudgets(ctx context.Context, input *struct {
@@ -216,13 +255,7 @@ func (btr BudgetTemplateResource) UpdateBudget(ctx context.Context, input *struc
 	logger := observability.GetLogger(ctx).With("resource", "BudgetTemplateResource.UpdateBudget")
 	logger.Info("start", "template_id", input.TemplateID, "budget_id", input.BudgetID)
 
-	// Validate that template exists and budget belongs to it
-	if err := btr.sevs.BudgTem.ValidateBudgetBelongsToTemplate(ctx, input.TemplateID, input.BudgetID); err != nil {
-		logger.Error("error", "template_id", input.TemplateID, "budget_id", input.BudgetID, "error", err)
-		return nil, err
-	}
-
-	resp, err := btr.sevs.BudgTem.UpdateBudget(ctx, input.BudgetID, input.Body)
+	resp, err := btr.sevs.BudgTem.UpdateTemplateBudget(ctx, input.TemplateID, input.BudgetID, input.Body)
 	if err != nil {
 		logger.Error("error", "template_id", input.TemplateID, "budget_id", input.BudgetID, "error", err)
 		return nil, err
@@ -245,13 +278,7 @@ func (btr BudgetTemplateResource) GetGeneratedBudgetDetail(ctx context.Context,
 	logger := observability.GetLogger(ctx).With("resource", "BudgetTemplateResource.GetGeneratedBudgetDetail")
 	logger.Info("start", "template_id", input.TemplateID, "budget_id", input.BudgetID)
 
-	// Validate that template exists and budget belongs to it
-	if err := btr.sevs.BudgTem.ValidateBudgetBelongsToTemplate(ctx, input.TemplateID, input.BudgetID); err != nil {
-		logger.Error("error", "template_id", input.TemplateID, "budget_id", input.BudgetID, "error", err)
-		return nil, err
-	}
-
-	resp, err := btr.sevs.BudgTem.GetBudgetDetail(ctx, input.BudgetID)
+	resp, err := btr.sevs.BudgTem.GetTemplateBudget(ctx, input.TemplateID, input.BudgetID)
 	if err != nil {
 		logger.Error("error", "template_id", input.TemplateID, "budget_id", input.BudgetID, "error", err)
 		return nil, err
@@ -262,3 +289,64 @@ func (btr BudgetTemplateResource) GetGeneratedBudgetDetail(ctx context.Context,
 		Body models.BudgetModel
 	}{Body: resp}, nil
 }
+
+func (btr BudgetTemplateResource) ListShares(ctx context.Context, input *struct {
+	ID int64 `path:"id" minimum:"1" doc:"Budget Template ID" example:"1"`
+}) (*struct {
+	Body models.SharesListModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("budgets", "GET", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "BudgetTemplateResource.ListShares", "template_id", input.ID)
+	logger.Info("start")
+	resp, err := btr.sevs.Shr.GetList(ctx, constants.EntityBudgetTemplate, input.ID)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct {
+		Body models.SharesListModel
+	}{
+		Body: resp,
+	}, nil
+}
+
+func (btr BudgetTemplateResource) Share(ctx context.Context, input *struct {
+	ID   int64 `path:"id" minimum:"1" doc:"Budget Template ID" example:"1"`
+	Body models.UpsertShareModel
+}) (*struct {
+	Body models.SharesListModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("budgets", "PUT", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "BudgetTemplateResource.Share", "template_id", input.ID)
+	logger.Info("start", "role", input.Body.Role)
+	resp, err := btr.sevs.Shr.Upsert(ctx, constants.EntityBudgetTemplate, input.ID, input.Body)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct {
+		Body models.SharesListModel
+	}{
+		Body: resp,
+	}, nil
+}
+
+func (btr BudgetTemplateResource) Unshare(ctx context.Context, input *struct {
+	ID     int64 `path:"id" minimum:"1" doc:"Budget Template ID" example:"1"`
+	UserID int64 `path:"userId" minimum:"1" doc:"ID of the user to revoke access from" example:"2"`
+}) (*struct{}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("budgets", "DELETE", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "BudgetTemplateResource.Unshare", "template_id", input.ID, "user_id", input.UserID)
+	logger.Info("start")
+	if err := btr.sevs.Shr.Delete(ctx, constants.EntityBudgetTemplate, input.ID, input.UserID); err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return nil, nil
+}
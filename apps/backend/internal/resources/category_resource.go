@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
 	"github.com/dimasbaguspm/spenicle-api/internal/observability"
 	"github.com/dimasbaguspm/spenicle-api/internal/services"
@@ -26,7 +27,7 @@ func (cr CategoryResource) Routes(api huma.API) {
 		Description: "Get a paginated list of categories with optional search",
 		Tags:        []string{"Categories"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsRead}},
 		},
 	}, cr.List)
 	huma.Register(api, huma.Operation{
@@ -37,7 +38,7 @@ func (cr CategoryResource) Routes(api huma.API) {
 		Description: "Create a new category",
 		Tags:        []string{"Categories"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
 		},
 	}, cr.Create)
 	huma.Register(api, huma.Operation{
@@ -48,7 +49,7 @@ func (cr CategoryResource) Routes(api huma.API) {
 		Description: "Get a single category by ID",
 		Tags:        []string{"Categories"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsRead}},
 		},
 	}, cr.Get)
 	huma.Register(api, huma.Operation{
@@ -59,7 +60,7 @@ func (cr CategoryResource) Routes(api huma.API) {
 		Description: "Update an existing category",
 		Tags:        []string{"Categories"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
 		},
 	}, cr.Update)
 	huma.Register(api, huma.Operation{
@@ -70,9 +71,20 @@ func (cr CategoryResource) Routes(api huma.API) {
 		Description: "Delete a category",
 		Tags:        []string{"Categories"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
 		},
 	}, cr.Delete)
+	huma.Register(api, huma.Operation{
+		OperationID: "restore-category",
+		Method:      "POST",
+		Path:        "/categories/{id}/restore",
+		Summary:     "Restore category",
+		Description: "Bring a deleted category back from the trash, placed at the end of the display order",
+		Tags:        []string{"Categories"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, cr.Restore)
 	huma.Register(api, huma.Operation{
 		OperationID: "reorder-categories",
 		Method:      "POST",
@@ -81,7 +93,7 @@ func (cr CategoryResource) Routes(api huma.API) {
 		Description: "Update display order for multiple categories",
 		Tags:        []string{"Categories"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
 		},
 	}, cr.Reorder)
 }
@@ -185,6 +197,27 @@ func (cr CategoryResource) Delete(ctx context.Context, input *struct {
 	logger.Info("start", "category_id", input.ID)
 	return &struct{}{}, nil
 }
+func (cr CategoryResource) Restore(ctx context.Context, input *struct {
+	ID int64 `path:"id" minimum:"1" doc:"Unique identifier of the category" example:"1"`
+}) (*struct {
+	Body models.CategoryModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("categories", "POST", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "CategoryResource.Restore", "category_id", input.ID)
+	logger.Info("start")
+	resp, err := cr.sevs.Cat.Restore(ctx, input.ID)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct {
+		Body models.CategoryModel
+	}{
+		Body: resp,
+	}, nil
+}
 func (cr CategoryResource) Reorder(ctx context.Context, input *struct {
 	Body models.ReorderCategoriesModel
 }) (*struct{}, error) {
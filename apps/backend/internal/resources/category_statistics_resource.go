@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
 	"github.com/dimasbaguspm/spenicle-api/internal/observability"
 	"github.com/dimasbaguspm/spenicle-api/internal/services"
@@ -32,7 +33,7 @@ func (sr CategoryStatisticsResource) Routes(api huma.API) {
 		Description: "Returns all lifestyle spending metrics for a category including spending velocity, account distribution, average transaction size, day-of-week patterns, and budget utilization",
 		Tags:        []string{"Categories"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeReportsRead}},
 		},
 	}, sr.GetCategoryStatistics)
 
@@ -44,7 +45,7 @@ func (sr CategoryStatisticsResource) Routes(api huma.API) {
 		Description: "Returns monthly spending trend over the specified period (line chart data)",
 		Tags:        []string{"Categories"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeReportsRead}},
 		},
 	}, sr.GetSpendingVelocity)
 
@@ -56,7 +57,7 @@ func (sr CategoryStatisticsResource) Routes(api huma.API) {
 		Description: "Returns which accounts pay for this category (donut chart data)",
 		Tags:        []string{"Categories"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeReportsRead}},
 		},
 	}, sr.GetAccountDistribution)
 
@@ -68,7 +69,7 @@ func (sr CategoryStatisticsResource) Routes(api huma.API) {
 		Description: "Returns typical transaction amounts, including min, max, median, and average",
 		Tags:        []string{"Categories"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeReportsRead}},
 		},
 	}, sr.GetAverageTransactionSize)
 
@@ -80,7 +81,7 @@ func (sr CategoryStatisticsResource) Routes(api huma.API) {
 		Description: "Returns spending patterns by day of week to show behavioral patterns",
 		Tags:        []string{"Categories"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeReportsRead}},
 		},
 	}, sr.GetDayOfWeekPattern)
 
@@ -92,7 +93,7 @@ func (sr CategoryStatisticsResource) Routes(api huma.API) {
 		Description: "Returns budget progress and remaining amounts for active budgets tied to this category",
 		Tags:        []string{"Categories"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeReportsRead}},
 		},
 	}, sr.GetBudgetUtilization)
 }
@@ -0,0 +1,238 @@
+package resources
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/dimasbaguspm/spenicle-api/internal/services"
+)
+
+type PayeeResource struct {
+	sevs services.RootService
+}
+
+func NewPayeeResource(sevs services.RootService) PayeeResource {
+	return PayeeResource{sevs}
+}
+func (tr PayeeResource) Routes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-payees",
+		Method:      "GET",
+		Path:        "/payees",
+		Summary:     "List payees",
+		Description: "Get a paginated list of payees, searching by name or alias",
+		Tags:        []string{"Payees"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsRead}},
+		},
+	}, tr.List)
+	huma.Register(api, huma.Operation{
+		OperationID: "create-payee",
+		Method:      "POST",
+		Path:        "/payees",
+		Summary:     "Create payee",
+		Description: "Create a new payee",
+		Tags:        []string{"Payees"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, tr.Create)
+	huma.Register(api, huma.Operation{
+		OperationID: "get-payee",
+		Method:      "GET",
+		Path:        "/payees/{id}",
+		Summary:     "Get payee",
+		Description: "Get a single payee by ID",
+		Tags:        []string{"Payees"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsRead}},
+		},
+	}, tr.Get)
+	huma.Register(api, huma.Operation{
+		OperationID: "update-payee",
+		Method:      "PATCH",
+		Path:        "/payees/{id}",
+		Summary:     "Update payee",
+		Description: "Update an existing payee",
+		Tags:        []string{"Payees"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, tr.Update)
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-payee",
+		Method:      "DELETE",
+		Path:        "/payees/{id}",
+		Summary:     "Delete payee",
+		Description: "Delete a payee; transactions keep pointing at it until it is purged from the trash",
+		Tags:        []string{"Payees"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, tr.Delete)
+	huma.Register(api, huma.Operation{
+		OperationID: "restore-payee",
+		Method:      "POST",
+		Path:        "/payees/{id}/restore",
+		Summary:     "Restore payee",
+		Description: "Bring a deleted payee back from the trash",
+		Tags:        []string{"Payees"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, tr.Restore)
+	huma.Register(api, huma.Operation{
+		OperationID: "merge-payees",
+		Method:      "POST",
+		Path:        "/payees/{id}/merge",
+		Summary:     "Merge payees",
+		Description: "Merge duplicate payees into this one. Their transactions and templates move to it, their names and aliases become its aliases, and they are deleted",
+		Tags:        []string{"Payees"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, tr.Merge)
+}
+func (tr PayeeResource) List(ctx context.Context, input *struct {
+	models.PayeesSearchModel
+}) (*struct {
+	Body models.PayeesPagedModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("payees", "GET", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "Resource")
+	logger.Info("start")
+	resp, err := tr.sevs.Payee.GetPaged(ctx, input.PayeesSearchModel)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("start")
+	return &struct {
+		Body models.PayeesPagedModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (tr PayeeResource) Get(ctx context.Context, input *struct {
+	ID int64 `path:"id" minimum:"1" doc:"Unique identifier of the payee" example:"1"`
+}) (*struct{ Body models.PayeeModel }, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("payees", "GET", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "Resource")
+	logger.Info("start", "payee_id", input.ID)
+	resp, err := tr.sevs.Payee.GetDetail(ctx, input.ID)
+	if err != nil {
+		logger.Error("error", "payee_id", input.ID, "error", err)
+		return nil, err
+	}
+	logger.Info("start", "payee_id", input.ID)
+	return &struct{ Body models.PayeeModel }{
+		Body: resp,
+	}, nil
+}
+func (tr PayeeResource) Create(ctx context.Context, input *struct {
+	Body models.CreatePayeeModel
+}) (*struct {
+	Body models.PayeeModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("payees", "POST", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "Resource")
+	logger.Info("start")
+	resp, err := tr.sevs.Payee.Create(ctx, input.Body)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("start")
+	return &struct {
+		Body models.PayeeModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (tr PayeeResource) Update(ctx context.Context, input *struct {
+	ID   int64 `path:"id" minimum:"1" doc:"Unique identifier of the payee" example:"1"`
+	Body models.UpdatePayeeModel
+}) (*struct {
+	Body models.PayeeModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("payees", "PATCH", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "Resource")
+	logger.Info("start", "payee_id", input.ID)
+	resp, err := tr.sevs.Payee.Update(ctx, input.ID, input.Body)
+	if err != nil {
+		logger.Error("error", "payee_id", input.ID, "error", err)
+		return nil, err
+	}
+	logger.Info("start", "payee_id", input.ID)
+	return &struct {
+		Body models.PayeeModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (tr PayeeResource) Delete(ctx context.Context, input *struct {
+	ID int64 `path:"id" minimum:"1" doc:"Unique identifier of the payee" example:"1"`
+}) (*struct{}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("payees", "DELETE", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "Resource")
+	logger.Info("start", "payee_id", input.ID)
+	err := tr.sevs.Payee.Delete(ctx, input.ID)
+	if err != nil {
+		logger.Error("error", "payee_id", input.ID, "error", err)
+		return nil, err
+	}
+	logger.Info("start", "payee_id", input.ID)
+	return nil, nil
+}
+func (tr PayeeResource) Restore(ctx context.Context, input *struct {
+	ID int64 `path:"id" minimum:"1" doc:"Unique identifier of the payee" example:"1"`
+}) (*struct {
+	Body models.PayeeModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("payees", "POST", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "PayeeResource.Restore", "payee_id", input.ID)
+	logger.Info("start")
+	resp, err := tr.sevs.Payee.Restore(ctx, input.ID)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct {
+		Body models.PayeeModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (tr PayeeResource) Merge(ctx context.Context, input *struct {
+	ID   int64 `path:"id" minimum:"1" doc:"Unique identifier of the payee to keep" example:"1"`
+	Body models.MergePayeesModel
+}) (*struct {
+	Body models.PayeeMergeResultModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("payees", "POST", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "PayeeResource.Merge", "payee_id", input.ID)
+	logger.Info("start")
+	resp, err := tr.sevs.Payee.Merge(ctx, input.ID, input.Body)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success", "merged_count", resp.MergedCount)
+	return &struct {
+		Body models.PayeeMergeResultModel
+	}{
+		Body: resp,
+	}, nil
+}
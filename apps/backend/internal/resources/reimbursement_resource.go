@@ -0,0 +1,253 @@
+package resources
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/dimasbaguspm/spenicle-api/internal/services"
+)
+
+type ReimbursementResource struct {
+	sevs services.RootService
+}
+
+func NewReimbursementResource(sevs services.RootService) ReimbursementResource {
+	return ReimbursementResource{sevs}
+}
+func (rr ReimbursementResource) Routes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-reimbursements",
+		Method:      "GET",
+		Path:        "/reimbursements",
+		Summary:     "List reimbursements",
+		Description: "Get a paginated list of expenses expected to be paid back. By default only those still owed are listed, with the total still owed across them",
+		Tags:        []string{"Reimbursements"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsRead}},
+		},
+	}, rr.List)
+	huma.Register(api, huma.Operation{
+		OperationID: "create-reimbursement",
+		Method:      "POST",
+		Path:        "/reimbursements",
+		Summary:     "Mark expense for reimbursement",
+		Description: "Mark an expense as expected to be paid back by a counterparty, in full or in part",
+		Tags:        []string{"Reimbursements"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, rr.Create)
+	huma.Register(api, huma.Operation{
+		OperationID: "get-reimbursement",
+		Method:      "GET",
+		Path:        "/reimbursements/{id}",
+		Summary:     "Get reimbursement",
+		Description: "Get a single reimbursement by ID with the income matched against it",
+		Tags:        []string{"Reimbursements"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsRead}},
+		},
+	}, rr.Get)
+	huma.Register(api, huma.Operation{
+		OperationID: "update-reimbursement",
+		Method:      "PATCH",
+		Path:        "/reimbursements/{id}",
+		Summary:     "Update reimbursement",
+		Description: "Update the expected amount, counterparty or notes of a reimbursement. The expected amount cannot drop below what is already paid back",
+		Tags:        []string{"Reimbursements"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, rr.Update)
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-reimbursement",
+		Method:      "DELETE",
+		Path:        "/reimbursements/{id}",
+		Summary:     "Unmark expense for reimbursement",
+		Description: "Delete a reimbursement and its matches; the expense counts as plain spending again and the matched income is free to match other reimbursements",
+		Tags:        []string{"Reimbursements"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, rr.Delete)
+	huma.Register(api, huma.Operation{
+		OperationID: "match-reimbursement",
+		Method:      "POST",
+		Path:        "/reimbursements/{id}/matches",
+		Summary:     "Match income to reimbursement",
+		Description: "Count part or all of an income transaction as paying back the expense. One income can be split across several reimbursements, but never for more than its amount",
+		Tags:        []string{"Reimbursements"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, rr.Match)
+	huma.Register(api, huma.Operation{
+		OperationID: "unmatch-reimbursement",
+		Method:      "DELETE",
+		Path:        "/reimbursements/{id}/matches/{matchId}",
+		Summary:     "Unmatch income from reimbursement",
+		Description: "Remove a match, so that part of the expense is owed again",
+		Tags:        []string{"Reimbursements"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, rr.Unmatch)
+}
+func (rr ReimbursementResource) List(ctx context.Context, input *struct {
+	models.ReimbursementsSearchModel
+}) (*struct {
+	Body models.ReimbursementsPagedModel
+}, error) {
+	start := time.Now()
+	defer func() {
+		observability.RecordServiceOperation("reimbursements", "GET", time.Since(start).Seconds())
+	}()
+	logger := observability.GetLogger(ctx).With("resource", "ReimbursementResource.List")
+	logger.Info("start")
+	resp, err := rr.sevs.Reimb.GetPaged(ctx, input.ReimbursementsSearchModel)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct {
+		Body models.ReimbursementsPagedModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (rr ReimbursementResource) Get(ctx context.Context, input *struct {
+	ID int64 `path:"id" minimum:"1" doc:"Unique identifier of the reimbursement" example:"1"`
+}) (*struct{ Body models.ReimbursementModel }, error) {
+	start := time.Now()
+	defer func() {
+		observability.RecordServiceOperation("reimbursements", "GET", time.Since(start).Seconds())
+	}()
+	logger := observability.GetLogger(ctx).With("resource", "ReimbursementResource.Get", "reimbursement_id", input.ID)
+	logger.Info("start")
+	resp, err := rr.sevs.Reimb.GetDetail(ctx, input.ID)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct{ Body models.ReimbursementModel }{
+		Body: resp,
+	}, nil
+}
+func (rr ReimbursementResource) Create(ctx context.Context, input *struct {
+	Body models.CreateReimbursementModel
+}) (*struct {
+	Body models.ReimbursementModel
+}, error) {
+	start := time.Now()
+	defer func() {
+		observability.RecordServiceOperation("reimbursements", "POST", time.Since(start).Seconds())
+	}()
+	logger := observability.GetLogger(ctx).With("resource", "ReimbursementResource.Create")
+	logger.Info("start")
+	resp, err := rr.sevs.Reimb.Create(ctx, input.Body)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success", "reimbursement_id", resp.ID)
+	return &struct {
+		Body models.ReimbursementModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (rr ReimbursementResource) Update(ctx context.Context, input *struct {
+	ID   int64 `path:"id" minimum:"1" doc:"Unique identifier of the reimbursement" example:"1"`
+	Body models.UpdateReimbursementModel
+}) (*struct {
+	Body models.ReimbursementModel
+}, error) {
+	start := time.Now()
+	defer func() {
+		observability.RecordServiceOperation("reimbursements", "PATCH", time.Since(start).Seconds())
+	}()
+	logger := observability.GetLogger(ctx).With("resource", "ReimbursementResource.Update", "reimbursement_id", input.ID)
+	logger.Info("start")
+	resp, err := rr.sevs.Reimb.Update(ctx, input.ID, input.Body)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct {
+		Body models.ReimbursementModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (rr ReimbursementResource) Delete(ctx context.Context, input *struct {
+	ID int64 `path:"id" minimum:"1" doc:"Unique identifier of the reimbursement" example:"1"`
+}) (*struct{}, error) {
+	start := time.Now()
+	defer func() {
+		observability.RecordServiceOperation("reimbursements", "DELETE", time.Since(start).Seconds())
+	}()
+	logger := observability.GetLogger(ctx).With("resource", "ReimbursementResource.Delete", "reimbursement_id", input.ID)
+	logger.Info("start")
+	err := rr.sevs.Reimb.Delete(ctx, input.ID)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return nil, nil
+}
+func (rr ReimbursementResource) Match(ctx context.Context, input *struct {
+	ID   int64 `path:"id" minimum:"1" doc:"Unique identifier of the reimbursement" example:"1"`
+	Body models.CreateReimbursementMatchModel
+}) (*struct {
+	Body models.ReimbursementModel
+}, error) {
+	start := time.Now()
+	defer func() {
+		observability.RecordServiceOperation("reimbursements", "POST", time.Since(start).Seconds())
+	}()
+	logger := observability.GetLogger(ctx).With("resource", "ReimbursementResource.Match", "reimbursement_id", input.ID)
+	logger.Info("start")
+	resp, err := rr.sevs.Reimb.Match(ctx, input.ID, input.Body)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success", "status", resp.Status)
+	return &struct {
+		Body models.ReimbursementModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (rr ReimbursementResource) Unmatch(ctx context.Context, input *struct {
+	ID      int64 `path:"id" minimum:"1" doc:"Unique identifier of the reimbursement" example:"1"`
+	MatchID int64 `path:"matchId" minimum:"1" doc:"Unique identifier of the match" example:"1"`
+}) (*struct {
+	Body models.ReimbursementModel
+}, error) {
+	start := time.Now()
+	defer func() {
+		observability.RecordServiceOperation("reimbursements", "DELETE", time.Since(start).Seconds())
+	}()
+	logger := observability.GetLogger(ctx).With("resource", "ReimbursementResource.Unmatch", "reimbursement_id", input.ID)
+	logger.Info("start")
+	resp, err := rr.sevs.Reimb.Unmatch(ctx, input.ID, input.MatchID)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success", "status", resp.Status)
+	return &struct {
+		Body models.ReimbursementModel
+	}{
+		Body: resp,
+	}, nil
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
 	"github.com/dimasbaguspm/spenicle-api/internal/observability"
 	"github.com/dimasbaguspm/spenicle-api/internal/services"
@@ -29,7 +30,7 @@ func (sr SummaryResource) Routes(api huma.API) {
 		Description: "Returns transaction summary grouped by frequency (daily, weekly, monthly, yearly)",
 		Tags:        []string{"Summary"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeReportsRead}},
 		},
 	}, sr.GetTransactionSummary)
 	huma.Register(api, huma.Operation{
@@ -40,7 +41,7 @@ func (sr SummaryResource) Routes(api huma.API) {
 		Description: "Returns transaction summary grouped by account",
 		Tags:        []string{"Summary"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeReportsRead}},
 		},
 	}, sr.GetAccountSummary)
 	huma.Register(api, huma.Operation{
@@ -51,9 +52,20 @@ func (sr SummaryResource) Routes(api huma.API) {
 		Description: "Returns transaction summary grouped by category",
 		Tags:        []string{"Summary"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeReportsRead}},
 		},
 	}, sr.GetCategorySummary)
+	huma.Register(api, huma.Operation{
+		OperationID: "get-payee-summary",
+		Method:      http.MethodGet,
+		Path:        "/summary/payees",
+		Summary:     "Get payee summary",
+		Description: "Returns transaction summary grouped by payee",
+		Tags:        []string{"Summary"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeReportsRead}},
+		},
+	}, sr.GetPayeeSummary)
 	huma.Register(api, huma.Operation{
 		OperationID: "get-geospatial-summary",
 		Method:      http.MethodGet,
@@ -62,7 +74,7 @@ func (sr SummaryResource) Routes(api huma.API) {
 		Description: "Returns transaction summary aggregated by geographic grid cells within a radius",
 		Tags:        []string{"Summary"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeReportsRead}},
 		},
 	}, sr.GetGeospatialSummary)
 }
@@ -130,6 +142,28 @@ func (sr SummaryResource) GetCategorySummary(ctx context.Context, input *struct
 	}, nil
 }
 
+func (sr SummaryResource) GetPayeeSummary(ctx context.Context, input *struct {
+	models.SummarySearchModel
+}) (*struct {
+	Body models.SummaryPayeeListModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("summary", "GET", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "Resource")
+	logger.Info("start")
+	resp, err := sr.sevs.Sum.GetPayeeSummary(ctx, input.SummarySearchModel)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("start")
+	return &struct {
+		Body models.SummaryPayeeListModel
+	}{
+		Body: resp,
+	}, nil
+}
+
 func (sr SummaryResource) GetGeospatialSummary(ctx context.Context, input *struct {
 	models.SummaryGeospatialSearchModel
 }) (*struct {
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
 	"github.com/dimasbaguspm/spenicle-api/internal/observability"
 	"github.com/dimasbaguspm/spenicle-api/internal/services"
@@ -26,7 +27,7 @@ func (tr TagResource) Routes(api huma.API) {
 		Description: "Get a paginated list of tags with optional search",
 		Tags:        []string{"Tags"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsRead}},
 		},
 	}, tr.List)
 	huma.Register(api, huma.Operation{
@@ -37,7 +38,7 @@ func (tr TagResource) Routes(api huma.API) {
 		Description: "Create a new tag",
 		Tags:        []string{"Tags"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
 		},
 	}, tr.Create)
 	huma.Register(api, huma.Operation{
@@ -48,7 +49,7 @@ func (tr TagResource) Routes(api huma.API) {
 		Description: "Get a single tag by ID",
 		Tags:        []string{"Tags"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsRead}},
 		},
 	}, tr.Get)
 	huma.Register(api, huma.Operation{
@@ -59,7 +60,7 @@ func (tr TagResource) Routes(api huma.API) {
 		Description: "Update an existing tag",
 		Tags:        []string{"Tags"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
 		},
 	}, tr.Update)
 	huma.Register(api, huma.Operation{
@@ -70,9 +71,20 @@ func (tr TagResource) Routes(api huma.API) {
 		Description: "Delete a tag",
 		Tags:        []string{"Tags"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
 		},
 	}, tr.Delete)
+	huma.Register(api, huma.Operation{
+		OperationID: "restore-tag",
+		Method:      "POST",
+		Path:        "/tags/{id}/restore",
+		Summary:     "Restore tag",
+		Description: "Bring a deleted tag back from the trash together with its links to transactions",
+		Tags:        []string{"Tags"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, tr.Restore)
 }
 func (tr TagResource) List(ctx context.Context, input *struct {
 	models.TagsSearchModel
@@ -170,3 +182,24 @@ func (tr TagResource) Delete(ctx context.Context, input *struct {
 	logger.Info("start", "tag_id", input.ID)
 	return nil, nil
 }
+func (tr TagResource) Restore(ctx context.Context, input *struct {
+	ID int64 `path:"id" minimum:"1" doc:"Unique identifier of the tag" example:"1"`
+}) (*struct {
+	Body models.TagModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("tags", "POST", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "TagResource.Restore", "tag_id", input.ID)
+	logger.Info("start")
+	resp, err := tr.sevs.Tag.Restore(ctx, input.ID)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct {
+		Body models.TagModel
+	}{
+		Body: resp,
+	}, nil
+}
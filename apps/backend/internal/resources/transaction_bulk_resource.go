@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
 	"github.com/dimasbaguspm/spenicle-api/internal/observability"
 	"github.com/dimasbaguspm/spenicle-api/internal/services"
@@ -26,6 +27,9 @@ func (tbr TransactionBulkResource) Routes(api huma.API) {
 		Summary:     "Save bulk transaction updates as draft",
 		Description: "Saves pending transaction updates to Redis as a draft/savepoint. One draft per user - overwrites existing draft. Expires after 24 hours.",
 		Tags:        []string{"Transactions"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
 	}, tbr.SaveDraft)
 
 	huma.Register(api, huma.Operation{
@@ -35,6 +39,9 @@ func (tbr TransactionBulkResource) Routes(api huma.API) {
 		Summary:     "Retrieve bulk transaction draft",
 		Description: "Retrieves saved draft from Redis to resume editing. One draft per user.",
 		Tags:        []string{"Transactions"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsRead}},
+		},
 	}, tbr.GetDraft)
 
 	huma.Register(api, huma.Operation{
@@ -44,6 +51,9 @@ func (tbr TransactionBulkResource) Routes(api huma.API) {
 		Summary:     "Commit bulk transaction updates atomically",
 		Description: "Applies all draft changes to database in a single transaction (all-or-nothing). Invalidates caches and deletes draft.",
 		Tags:        []string{"Transactions"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
 	}, tbr.CommitDraft)
 
 	huma.Register(api, huma.Operation{
@@ -53,6 +63,9 @@ func (tbr TransactionBulkResource) Routes(api huma.API) {
 		Summary:     "Delete draft without committing",
 		Description: "Discards pending draft changes without applying them to database.",
 		Tags:        []string{"Transactions"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
 	}, tbr.DeleteDraft)
 }
 
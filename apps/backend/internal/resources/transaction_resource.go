@@ -2,9 +2,15 @@ package resources
 
 import (
 	"context"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/middleware"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
 	"github.com/dimasbaguspm/spenicle-api/internal/observability"
 	"github.com/dimasbaguspm/spenicle-api/internal/services"
@@ -23,10 +29,10 @@ func (tr TransactionResource) Routes(api huma.API) {
 		Method:      "GET",
 		Path:        "/transactions",
 		Summary:     "List transactions",
-		Description: "Get a paginated list of transactions with optional filters",
+		Description: "Get a paginated list of transactions with optional filters and full-text search",
 		Tags:        []string{"Transactions"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsRead}},
 		},
 	}, tr.List)
 	huma.Register(api, huma.Operation{
@@ -34,10 +40,10 @@ func (tr TransactionResource) Routes(api huma.API) {
 		Method:      "POST",
 		Path:        "/transactions",
 		Summary:     "Create transaction",
-		Description: "Create a new transaction",
+		Description: "Create a new transaction. A transfer can credit a different destinationAmount to its destination account, and a feeAmount with feeCategoryId records the fee as an expense on the source account linked to the transfer",
 		Tags:        []string{"Transactions"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
 		},
 	}, tr.Create)
 	huma.Register(api, huma.Operation{
@@ -48,7 +54,7 @@ func (tr TransactionResource) Routes(api huma.API) {
 		Description: "Get a single transaction by ID",
 		Tags:        []string{"Transactions"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsRead}},
 		},
 	}, tr.Get)
 	huma.Register(api, huma.Operation{
@@ -56,10 +62,10 @@ func (tr TransactionResource) Routes(api huma.API) {
 		Method:      "PATCH",
 		Path:        "/transactions/{id}",
 		Summary:     "Update transaction",
-		Description: "Update an existing transaction",
+		Description: "Update an existing transaction. With If-Match, it only succeeds while the transaction still has that ETag, and fails with 412 carrying the current transaction otherwise",
 		Tags:        []string{"Transactions"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
 		},
 	}, tr.Update)
 	huma.Register(api, huma.Operation{
@@ -67,12 +73,45 @@ func (tr TransactionResource) Routes(api huma.API) {
 		Method:      "DELETE",
 		Path:        "/transactions/{id}",
 		Summary:     "Delete transaction",
-		Description: "Delete a transaction",
+		Description: "Delete a transaction. With If-Match, it only succeeds while the transaction still has that ETag, and fails with 412 carrying the current transaction otherwise",
 		Tags:        []string{"Transactions"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
 		},
 	}, tr.Delete)
+	huma.Register(api, huma.Operation{
+		OperationID: "restore-transaction",
+		Method:      "POST",
+		Path:        "/transactions/{id}/restore",
+		Summary:     "Restore transaction",
+		Description: "Bring a deleted transaction back from the trash and reapply it to the account balances, together with the attachments and transfer fee deleted along with it. Its accounts and category must not be deleted",
+		Tags:        []string{"Transactions"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, tr.Restore)
+	huma.Register(api, huma.Operation{
+		OperationID: "list-transaction-duplicates",
+		Method:      "GET",
+		Path:        "/transactions/duplicates",
+		Summary:     "List possible duplicates",
+		Description: "Get pairs of transactions on the same account with the same type and amount, dated within three days of each other, scored by how likely they are the same transaction. Dates, note similarity and being recorded together raise the score",
+		Tags:        []string{"Transactions"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsRead}},
+		},
+	}, tr.ListDuplicates)
+	huma.Register(api, huma.Operation{
+		OperationID: "merge-transactions",
+		Method:      "POST",
+		Path:        "/transactions/{id}/merge",
+		Summary:     "Merge duplicate transactions",
+		Description: "Keep this transaction and delete its duplicates, which must have the same account, type and amount (400 otherwise). Their tags, relations, attachments, split lines and reimbursements move to it, and deleting them takes their amounts and transfer fees off the account balances",
+		Tags:        []string{"Transactions"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, tr.Merge)
 	huma.Register(api, huma.Operation{
 		OperationID: "list-transaction-templates",
 		Method:      "GET",
@@ -81,7 +120,7 @@ func (tr TransactionResource) Routes(api huma.API) {
 		Description: "Get a paginated list of transaction templates",
 		Tags:        []string{"Transaction Templates"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsRead}},
 		},
 	}, tr.ListTemplates)
 	huma.Register(api, huma.Operation{
@@ -92,7 +131,7 @@ func (tr TransactionResource) Routes(api huma.API) {
 		Description: "Create a new transaction template for recurring/installment payments",
 		Tags:        []string{"Transaction Templates"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
 		},
 	}, tr.CreateTemplate)
 	huma.Register(api, huma.Operation{
@@ -103,7 +142,7 @@ func (tr TransactionResource) Routes(api huma.API) {
 		Description: "Get a single transaction template by ID",
 		Tags:        []string{"Transaction Templates"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsRead}},
 		},
 	}, tr.GetTemplate)
 	huma.Register(api, huma.Operation{
@@ -114,7 +153,7 @@ func (tr TransactionResource) Routes(api huma.API) {
 		Description: "Update an existing transaction template",
 		Tags:        []string{"Transaction Templates"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
 		},
 	}, tr.UpdateTemplate)
 	huma.Register(api, huma.Operation{
@@ -125,9 +164,20 @@ func (tr TransactionResource) Routes(api huma.API) {
 		Description: "Delete a transaction template",
 		Tags:        []string{"Transaction Templates"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
 		},
 	}, tr.DeleteTemplate)
+	huma.Register(api, huma.Operation{
+		OperationID: "restore-transaction-template",
+		Method:      "POST",
+		Path:        "/transaction-templates/{templateId}/restore",
+		Summary:     "Restore transaction template",
+		Description: "Bring a deleted transaction template back from the trash; it resumes from its next due date. Only the owner can restore a template",
+		Tags:        []string{"Transaction Templates"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, tr.RestoreTemplate)
 	huma.Register(api, huma.Operation{
 		OperationID: "list-transaction-template-related-transactions",
 		Method:      "GET",
@@ -136,9 +186,42 @@ func (tr TransactionResource) Routes(api huma.API) {
 		Description: "Get a paginated list of transactions related to a template",
 		Tags:        []string{"Transaction Templates"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsRead}},
 		},
 	}, tr.ListTemplateRelatedTransactions)
+	huma.Register(api, huma.Operation{
+		OperationID: "list-transaction-template-shares",
+		Method:      "GET",
+		Path:        "/transaction-templates/{templateId}/shares",
+		Summary:     "List transaction template shares",
+		Description: "List the users a transaction template is shared with and their roles. Only the owner can see this list",
+		Tags:        []string{"Transaction Templates"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsRead}},
+		},
+	}, tr.ListTemplateShares)
+	huma.Register(api, huma.Operation{
+		OperationID: "share-transaction-template",
+		Method:      "PUT",
+		Path:        "/transaction-templates/{templateId}/shares",
+		Summary:     "Share transaction template",
+		Description: "Share a transaction template with another user as viewer or editor, or change the role of an existing share. Viewers can read it, editors can also change it. Only the owner can manage shares",
+		Tags:        []string{"Transaction Templates"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, tr.ShareTemplate)
+	huma.Register(api, huma.Operation{
+		OperationID: "unshare-transaction-template",
+		Method:      "DELETE",
+		Path:        "/transaction-templates/{templateId}/shares/{userId}",
+		Summary:     "Unshare transaction template",
+		Description: "Revoke a user's access to a transaction template",
+		Tags:        []string{"Transaction Templates"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, tr.UnshareTemplate)
 	huma.Register(api, huma.Operation{
 		OperationID: "list-transaction-relations",
 		Method:      "GET",
@@ -147,7 +230,7 @@ func (tr TransactionResource) Routes(api huma.API) {
 		Description: "Get a paginated list of relations for a transaction",
 		Tags:        []string{"Transaction Relations"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsRead}},
 		},
 	}, tr.ListRelations)
 	huma.Register(api, huma.Operation{
@@ -158,7 +241,7 @@ func (tr TransactionResource) Routes(api huma.API) {
 		Description: "Create a relation between two transactions",
 		Tags:        []string{"Transaction Relations"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
 		},
 	}, tr.CreateRelation)
 	huma.Register(api, huma.Operation{
@@ -169,7 +252,7 @@ func (tr TransactionResource) Routes(api huma.API) {
 		Description: "Get a specific relation between transactions",
 		Tags:        []string{"Transaction Relations"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsRead}},
 		},
 	}, tr.GetRelation)
 	huma.Register(api, huma.Operation{
@@ -180,9 +263,20 @@ func (tr TransactionResource) Routes(api huma.API) {
 		Description: "Delete a relation between transactions",
 		Tags:        []string{"Transaction Relations"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
 		},
 	}, tr.DeleteRelation)
+	huma.Register(api, huma.Operation{
+		OperationID: "get-transaction-graph",
+		Method:      "GET",
+		Path:        "/transactions/{id}/graph",
+		Summary:     "Get transaction graph",
+		Description: "Get every transaction connected to this one through relations in either direction, the relations between them, and their income, expense and net totals",
+		Tags:        []string{"Transaction Relations"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsRead}},
+		},
+	}, tr.GetGraph)
 	// Transaction Tags
 	huma.Register(api, huma.Operation{
 		OperationID: "list-transaction-tags",
@@ -192,7 +286,7 @@ func (tr TransactionResource) Routes(api huma.API) {
 		Description: "Get a paginated list of tags for a transaction",
 		Tags:        []string{"Transaction Tags"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsRead}},
 		},
 	}, tr.ListTags)
 	huma.Register(api, huma.Operation{
@@ -203,7 +297,7 @@ func (tr TransactionResource) Routes(api huma.API) {
 		Description: "Add a tag to a transaction",
 		Tags:        []string{"Transaction Tags"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
 		},
 	}, tr.CreateTag)
 	huma.Register(api, huma.Operation{
@@ -214,7 +308,7 @@ func (tr TransactionResource) Routes(api huma.API) {
 		Description: "Get a specific tag on a transaction",
 		Tags:        []string{"Transaction Tags"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsRead}},
 		},
 	}, tr.GetTag)
 	huma.Register(api, huma.Operation{
@@ -225,9 +319,127 @@ func (tr TransactionResource) Routes(api huma.API) {
 		Description: "Remove a tag from a transaction",
 		Tags:        []string{"Transaction Tags"},
 		Security: []map[string][]string{
-			{"bearer": {}},
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
 		},
 	}, tr.DeleteTag)
+	// Transaction Splits
+	huma.Register(api, huma.Operation{
+		OperationID: "list-transaction-splits",
+		Method:      "GET",
+		Path:        "/transactions/{transactionId}/splits",
+		Summary:     "List transaction split lines",
+		Description: "Get the lines dividing a transaction between categories, empty when it is not split",
+		Tags:        []string{"Transaction Splits"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsRead}},
+		},
+	}, tr.ListSplits)
+	huma.Register(api, huma.Operation{
+		OperationID: "replace-transaction-splits",
+		Method:      "PUT",
+		Path:        "/transactions/{transactionId}/splits",
+		Summary:     "Replace transaction split lines",
+		Description: "Replace the split lines of an income or expense transaction. Each line has its own category, amount, note and tags, and the amounts must sum to the transaction amount. Category summaries, category statistics and budgets count the line amounts. Send an empty list to remove the split. While split, the transaction amount and type can only change after updating or removing its lines. Lines of a reconciled transaction cannot be replaced, and If-Match is checked against the transaction ETag",
+		Tags:        []string{"Transaction Splits"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, tr.ReplaceSplits)
+	// Transaction Attachments
+	huma.Register(api, huma.Operation{
+		OperationID: "list-transaction-attachments",
+		Method:      "GET",
+		Path:        "/transactions/{transactionId}/attachments",
+		Summary:     "List transaction attachments",
+		Description: "Get the receipts and documents attached to a transaction",
+		Tags:        []string{"Transaction Attachments"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsRead}},
+		},
+	}, tr.ListAttachments)
+	huma.Register(api, huma.Operation{
+		OperationID:   "upload-transaction-attachment",
+		Method:        "POST",
+		Path:          "/transactions/{transactionId}/attachments",
+		Summary:       "Upload transaction attachment",
+		Description:   "Attach a JPEG, PNG, GIF, WebP or PDF file of up to 10 MB to a transaction, sent as the file field of a multipart form. The type is detected from the file content, and JPEG, PNG and GIF images get a thumbnail",
+		Tags:          []string{"Transaction Attachments"},
+		DefaultStatus: http.StatusCreated,
+		Middlewares:   huma.Middlewares{middleware.BodyLimitMiddleware(api, constants.AttachmentMaxSizeBytes+1<<20)},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, tr.UploadAttachment)
+	huma.Register(api, huma.Operation{
+		OperationID: "download-transaction-attachment",
+		Method:      "GET",
+		Path:        "/transactions/{transactionId}/attachments/{attachmentId}/content",
+		Summary:     "Download transaction attachment",
+		Description: "Download the original file of an attachment",
+		Tags:        []string{"Transaction Attachments"},
+		Responses:   attachmentContentResponses("The attached file"),
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsRead}},
+		},
+	}, tr.DownloadAttachment)
+	huma.Register(api, huma.Operation{
+		OperationID: "download-transaction-attachment-thumbnail",
+		Method:      "GET",
+		Path:        "/transactions/{transactionId}/attachments/{attachmentId}/thumbnail",
+		Summary:     "Download transaction attachment thumbnail",
+		Description: "Download the JPEG thumbnail of an image attachment; 404 when the attachment has none",
+		Tags:        []string{"Transaction Attachments"},
+		Responses:   attachmentContentResponses("JPEG thumbnail of the attached image"),
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsRead}},
+		},
+	}, tr.DownloadAttachmentThumbnail)
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-transaction-attachment",
+		Method:      "DELETE",
+		Path:        "/transactions/{transactionId}/attachments/{attachmentId}",
+		Summary:     "Delete transaction attachment",
+		Description: "Remove an attachment from a transaction",
+		Tags:        []string{"Transaction Attachments"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, tr.DeleteAttachment)
+	// Transaction Revisions
+	huma.Register(api, huma.Operation{
+		OperationID: "list-transaction-revisions",
+		Method:      "GET",
+		Path:        "/transactions/{transactionId}/revisions",
+		Summary:     "List transaction revisions",
+		Description: "Get the change history of a transaction, newest first. Each revision lists the changed fields with their values before and after, and the balance change applied to each account",
+		Tags:        []string{"Transaction Revisions"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsRead}},
+		},
+	}, tr.ListRevisions)
+	huma.Register(api, huma.Operation{
+		OperationID: "revert-transaction-revision",
+		Method:      "POST",
+		Path:        "/transactions/{transactionId}/revisions/{revision}/revert",
+		Summary:     "Revert transaction revision",
+		Description: "Set the fields changed by a revision back to their previous values. The revert goes through the regular update rules, balances included, and is recorded as a new revision",
+		Tags:        []string{"Transaction Revisions"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, tr.RevertRevision)
+}
+
+// attachmentContentResponses documents a binary download, since streamed responses carry no schema
+func attachmentContentResponses(description string) map[string]*huma.Response {
+	return map[string]*huma.Response{
+		"200": {
+			Description: description,
+			Content: map[string]*huma.MediaType{
+				"application/octet-stream": {Schema: &huma.Schema{Type: "string", Format: "binary"}},
+			},
+		},
+	}
 }
 func (tr TransactionResource) List(ctx context.Context, input *struct {
 	models.TransactionsSearchModel
@@ -253,6 +465,7 @@ func (tr TransactionResource) List(ctx context.Context, input *struct {
 func (tr TransactionResource) Get(ctx context.Context, input *struct {
 	ID int64 `path:"id" minimum:"1" doc:"Unique identifier of the transaction" example:"1"`
 }) (*struct {
+	ETag string `header:"ETag"`
 	Body models.TransactionModel
 }, error) {
 	start := time.Now()
@@ -266,15 +479,17 @@ func (tr TransactionResource) Get(ctx context.Context, input *struct {
 	}
 	logger.Info("start", "transaction_id", input.ID)
 	return &struct {
+		ETag string `header:"ETag"`
 		Body models.TransactionModel
 	}{
+		ETag: resp.ETag,
 		Body: resp,
 	}, nil
 }
 func (tr TransactionResource) Create(ctx context.Context, input *struct {
 	Body models.CreateTransactionModel
 }) (*struct {
-	Body models.TransactionModel
+	Body models.TransactionCreatedModel
 }, error) {
 	start := time.Now()
 	defer func() { observability.RecordServiceOperation("transactions", "POST", time.Since(start).Seconds()) }()
@@ -285,43 +500,56 @@ func (tr TransactionResource) Create(ctx context.Context, input *struct {
 		logger.Error("error", "error", err)
 		return nil, err
 	}
+	// The transaction is already recorded, so a failed duplicate check only loses the warning
+	duplicates, err := tr.sevs.Tsct.FindPossibleDuplicates(ctx, resp)
+	if err != nil {
+		logger.Warn("duplicate check failed", "transaction_id", resp.ID, "error", err)
+	}
 	logger.Info("start")
 	return &struct {
-		Body models.TransactionModel
+		Body models.TransactionCreatedModel
 	}{
-		Body: resp,
+		Body: models.TransactionCreatedModel{
+			TransactionModel:   resp,
+			PossibleDuplicates: duplicates,
+		},
 	}, nil
 }
 func (tr TransactionResource) Update(ctx context.Context, input *struct {
-	ID   int64 `path:"id" minimum:"1" doc:"Unique identifier of the transaction" example:"1"`
-	Body models.UpdateTransactionModel
+	ID      int64  `path:"id" minimum:"1" doc:"Unique identifier of the transaction" example:"1"`
+	IfMatch string `header:"If-Match" doc:"ETag the transaction was read with; the request fails with 412 when it has changed since"`
+	Body    models.UpdateTransactionModel
 }) (*struct {
+	ETag string `header:"ETag"`
 	Body models.TransactionModel
 }, error) {
 	start := time.Now()
 	defer func() { observability.RecordServiceOperation("transactions", "PATCH", time.Since(start).Seconds()) }()
 	logger := observability.GetLogger(ctx).With("resource", "Resource")
 	logger.Info("start", "transaction_id", input.ID)
-	resp, err := tr.sevs.Tsct.Update(ctx, input.ID, input.Body)
+	resp, err := tr.sevs.Tsct.Update(ctx, input.ID, input.Body, input.IfMatch)
 	if err != nil {
 		logger.Error("error", "transaction_id", input.ID, "error", err)
 		return nil, err
 	}
 	logger.Info("start", "transaction_id", input.ID)
 	return &struct {
+		ETag string `header:"ETag"`
 		Body models.TransactionModel
 	}{
+		ETag: resp.ETag,
 		Body: resp,
 	}, nil
 }
 func (tr TransactionResource) Delete(ctx context.Context, input *struct {
-	ID int64 `path:"id" minimum:"1" doc:"Unique identifier of the transaction" example:"1"`
+	ID      int64  `path:"id" minimum:"1" doc:"Unique identifier of the transaction" example:"1"`
+	IfMatch string `header:"If-Match" doc:"ETag the transaction was read with; the request fails with 412 when it has changed since"`
 }) (*struct{}, error) {
 	start := time.Now()
 	defer func() { observability.RecordServiceOperation("transactions", "DELETE", time.Since(start).Seconds()) }()
 	logger := observability.GetLogger(ctx).With("resource", "Resource")
 	logger.Info("start", "transaction_id", input.ID)
-	err := tr.sevs.Tsct.Delete(ctx, input.ID)
+	err := tr.sevs.Tsct.Delete(ctx, input.ID, input.IfMatch)
 	if err != nil {
 		logger.Error("error", "transaction_id", input.ID, "error", err)
 		return nil, err
@@ -329,6 +557,70 @@ func (tr TransactionResource) Delete(ctx context.Context, input *struct {
 	logger.Info("start", "transaction_id", input.ID)
 	return &struct{}{}, nil
 }
+func (tr TransactionResource) Restore(ctx context.Context, input *struct {
+	ID int64 `path:"id" minimum:"1" doc:"Unique identifier of the transaction" example:"1"`
+}) (*struct {
+	Body models.TransactionModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("transactions", "POST", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "TransactionResource.Restore", "transaction_id", input.ID)
+	logger.Info("start")
+	resp, err := tr.sevs.Tsct.Restore(ctx, input.ID)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct {
+		Body models.TransactionModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (tr TransactionResource) ListDuplicates(ctx context.Context, input *struct {
+	models.TransactionDuplicatesSearchModel
+}) (*struct {
+	Body models.TransactionDuplicatesPagedModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("transactions", "GET", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "TransactionResource.ListDuplicates")
+	logger.Info("start")
+	resp, err := tr.sevs.TsctDup.GetPaged(ctx, input.TransactionDuplicatesSearchModel)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success", "total_count", resp.TotalCount)
+	return &struct {
+		Body models.TransactionDuplicatesPagedModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (tr TransactionResource) Merge(ctx context.Context, input *struct {
+	ID   int64 `path:"id" minimum:"1" doc:"Unique identifier of the transaction to keep" example:"1"`
+	Body models.MergeTransactionDuplicatesModel
+}) (*struct {
+	Body models.TransactionMergeResultModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("transactions", "POST", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "TransactionResource.Merge", "transaction_id", input.ID)
+	logger.Info("start")
+	resp, err := tr.sevs.TsctDup.Merge(ctx, input.ID, input.Body)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success", "merged_count", resp.MergedCount)
+	return &struct {
+		Body models.TransactionMergeResultModel
+	}{
+		Body: resp,
+	}, nil
+}
 
 // Transaction Relation Handlers
 func (tr TransactionResource) ListRelations(ctx context.Context, input *struct {
@@ -400,6 +692,27 @@ func (tr TransactionResource) DeleteRelation(ctx context.Context, input *struct
 	logger.Info("start", "source_transaction_id", input.SourceTransactionID, "relation_id", input.RelationID)
 	return nil, nil
 }
+func (tr TransactionResource) GetGraph(ctx context.Context, input *struct {
+	ID int64 `path:"id" minimum:"1" doc:"Unique identifier of the transaction" example:"1"`
+}) (*struct {
+	Body models.TransactionGraphModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("transactions", "GET", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "TransactionResource.GetGraph", "transaction_id", input.ID)
+	logger.Info("start")
+	resp, err := tr.sevs.TsctRel.GetGraph(ctx, input.ID)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success", "node_count", len(resp.Nodes), "edge_count", len(resp.Edges))
+	return &struct {
+		Body models.TransactionGraphModel
+	}{
+		Body: resp,
+	}, nil
+}
 
 // Transaction Tag Handlers
 func (tr TransactionResource) ListTags(ctx context.Context, input *struct {
@@ -475,6 +788,206 @@ func (tr TransactionResource) DeleteTag(ctx context.Context, input *struct {
 	logger.Info("start", "transaction_id", input.TransactionID, "tag_id", input.TagID)
 	return &struct{}{}, nil
 }
+func (tr TransactionResource) ListSplits(ctx context.Context, input *struct {
+	TransactionID int64 `path:"transactionId" minimum:"1" doc:"Transaction ID"`
+}) (*struct {
+	Body models.TransactionSplitsListModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("transactions", "GET", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "TransactionResource.ListSplits", "transaction_id", input.TransactionID)
+	logger.Info("start")
+	resp, err := tr.sevs.TsctSplit.GetList(ctx, input.TransactionID)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success", "count", len(resp.Items))
+	return &struct {
+		Body models.TransactionSplitsListModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (tr TransactionResource) ReplaceSplits(ctx context.Context, input *struct {
+	TransactionID int64  `path:"transactionId" minimum:"1" doc:"Transaction ID"`
+	IfMatch       string `header:"If-Match" doc:"ETag the transaction was read with; the request fails with 412 when it has changed since"`
+	Body          models.ReplaceTransactionSplitsModel
+}) (*struct {
+	ETag string `header:"ETag"`
+	Body models.TransactionModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("transactions", "PUT", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "TransactionResource.ReplaceSplits", "transaction_id", input.TransactionID)
+	logger.Info("start", "line_count", len(input.Body.Lines))
+	resp, err := tr.sevs.TsctSplit.Replace(ctx, input.TransactionID, input.Body, input.IfMatch)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct {
+		ETag string `header:"ETag"`
+		Body models.TransactionModel
+	}{
+		ETag: resp.ETag,
+		Body: resp,
+	}, nil
+}
+
+func (tr TransactionResource) ListAttachments(ctx context.Context, input *struct {
+	TransactionID int64 `path:"transactionId" minimum:"1" doc:"Transaction ID"`
+}) (*struct {
+	Body models.TransactionAttachmentsListModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("transactions", "GET", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "TransactionResource.ListAttachments", "transaction_id", input.TransactionID)
+	logger.Info("start")
+	resp, err := tr.sevs.TsctAtt.GetList(ctx, input.TransactionID)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success", "count", len(resp.Items))
+	return &struct {
+		Body models.TransactionAttachmentsListModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (tr TransactionResource) UploadAttachment(ctx context.Context, input *struct {
+	TransactionID int64 `path:"transactionId" minimum:"1" doc:"Transaction ID"`
+	RawBody       huma.MultipartFormFiles[struct {
+		File huma.FormFile `form:"file" required:"true" doc:"Receipt or document to attach"`
+	}]
+}) (*struct {
+	Body models.TransactionAttachmentModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("transactions", "POST", time.Since(start).Seconds()) }()
+	file := input.RawBody.Data().File
+	defer file.Close()
+	logger := observability.GetLogger(ctx).With("resource", "TransactionResource.UploadAttachment", "transaction_id", input.TransactionID)
+	logger.Info("start", "size_bytes", file.Size)
+	resp, err := tr.sevs.TsctAtt.Upload(ctx, input.TransactionID, file.Filename, file)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success", "attachment_id", resp.ID, "content_type", resp.ContentType)
+	return &struct {
+		Body models.TransactionAttachmentModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (tr TransactionResource) DownloadAttachment(ctx context.Context, input *struct {
+	TransactionID int64 `path:"transactionId" minimum:"1" doc:"Transaction ID"`
+	AttachmentID  int64 `path:"attachmentId" minimum:"1" doc:"Attachment ID"`
+}) (*huma.StreamResponse, error) {
+	return tr.streamAttachment(ctx, "TransactionResource.DownloadAttachment", input.TransactionID, input.AttachmentID, false)
+}
+func (tr TransactionResource) DownloadAttachmentThumbnail(ctx context.Context, input *struct {
+	TransactionID int64 `path:"transactionId" minimum:"1" doc:"Transaction ID"`
+	AttachmentID  int64 `path:"attachmentId" minimum:"1" doc:"Attachment ID"`
+}) (*huma.StreamResponse, error) {
+	return tr.streamAttachment(ctx, "TransactionResource.DownloadAttachmentThumbnail", input.TransactionID, input.AttachmentID, true)
+}
+func (tr TransactionResource) streamAttachment(ctx context.Context, resource string, transactionID int64, attachmentID int64, thumbnail bool) (*huma.StreamResponse, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("transactions", "GET", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", resource, "transaction_id", transactionID, "attachment_id", attachmentID)
+	logger.Info("start")
+	attachment, content, err := tr.sevs.TsctAtt.Open(ctx, transactionID, attachmentID, thumbnail)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+
+	contentType := attachment.ContentType
+	disposition := "attachment"
+	if thumbnail {
+		contentType = "image/jpeg"
+		disposition = "inline"
+	}
+	return &huma.StreamResponse{
+		Body: func(hctx huma.Context) {
+			defer content.Close()
+			hctx.SetHeader("Content-Type", contentType)
+			hctx.SetHeader("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": attachment.FileName}))
+			hctx.SetHeader("X-Content-Type-Options", "nosniff")
+			if !thumbnail {
+				hctx.SetHeader("Content-Length", strconv.FormatInt(attachment.SizeBytes, 10))
+			}
+			if _, err := io.Copy(hctx.BodyWriter(), content); err != nil {
+				logger.Error("stream_failed", "error", err)
+			}
+		},
+	}, nil
+}
+func (tr TransactionResource) DeleteAttachment(ctx context.Context, input *struct {
+	TransactionID int64 `path:"transactionId" minimum:"1" doc:"Transaction ID"`
+	AttachmentID  int64 `path:"attachmentId" minimum:"1" doc:"Attachment ID"`
+}) (*struct{}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("transactions", "DELETE", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "TransactionResource.DeleteAttachment", "transaction_id", input.TransactionID, "attachment_id", input.AttachmentID)
+	logger.Info("start")
+	if err := tr.sevs.TsctAtt.Delete(ctx, input.TransactionID, input.AttachmentID); err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct{}{}, nil
+}
+
+// Transaction Revision Handlers
+func (tr TransactionResource) ListRevisions(ctx context.Context, input *struct {
+	TransactionID int64 `path:"transactionId" minimum:"1" doc:"Transaction ID"`
+}) (*struct {
+	Body models.TransactionRevisionsListModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("transactions", "GET", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "TransactionResource.ListRevisions", "transaction_id", input.TransactionID)
+	logger.Info("start")
+	resp, err := tr.sevs.TsctRev.GetList(ctx, input.TransactionID)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success", "count", len(resp.Items))
+	return &struct {
+		Body models.TransactionRevisionsListModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (tr TransactionResource) RevertRevision(ctx context.Context, input *struct {
+	TransactionID int64 `path:"transactionId" minimum:"1" doc:"Transaction ID"`
+	Revision      int   `path:"revision" minimum:"1" doc:"Revision number"`
+}) (*struct {
+	Body models.TransactionModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("transactions", "POST", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "TransactionResource.RevertRevision", "transaction_id", input.TransactionID, "revision", input.Revision)
+	logger.Info("start")
+	resp, err := tr.sevs.TsctRev.Revert(ctx, input.TransactionID, input.Revision)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct {
+		Body models.TransactionModel
+	}{
+		Body: resp,
+	}, nil
+}
 
 // Transaction Template Handlers
 func (tr TransactionResource) ListTemplates(ctx context.Context, input *struct {
@@ -567,6 +1080,27 @@ func (tr TransactionResource) DeleteTemplate(ctx context.Context, input *struct
 	logger.Info("start", "template_id", input.TemplateID)
 	return nil, nil
 }
+func (tr TransactionResource) RestoreTemplate(ctx context.Context, input *struct {
+	TemplateID int64 `path:"templateId" minimum:"1" doc:"Unique identifier of the transaction template" example:"1"`
+}) (*struct {
+	Body models.TransactionTemplateModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("transactions", "POST", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "TransactionResource.RestoreTemplate", "template_id", input.TemplateID)
+	logger.Info("start")
+	resp, err := tr.sevs.TsctTem.Restore(ctx, input.TemplateID)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct {
+		Body models.TransactionTemplateModel
+	}{
+		Body: resp,
+	}, nil
+}
 func (tr TransactionResource) ListTemplateRelatedTransactions(ctx context.Context, input *struct {
 	TemplateID int64 `path:"templateId" minimum:"1" doc:"Unique identifier of the transaction template" example:"1"`
 	models.TransactionTemplateRelatedTransactionsSearchModel
@@ -587,3 +1121,61 @@ func (tr TransactionResource) ListTemplateRelatedTransactions(ctx context.Contex
 		Body: result,
 	}, nil
 }
+func (tr TransactionResource) ListTemplateShares(ctx context.Context, input *struct {
+	TemplateID int64 `path:"templateId" minimum:"1" doc:"Unique identifier of the transaction template" example:"1"`
+}) (*struct {
+	Body models.SharesListModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("transactions", "GET", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "TransactionResource.ListTemplateShares", "template_id", input.TemplateID)
+	logger.Info("start")
+	resp, err := tr.sevs.Shr.GetList(ctx, constants.EntityTransactionTemplate, input.TemplateID)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct {
+		Body models.SharesListModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (tr TransactionResource) ShareTemplate(ctx context.Context, input *struct {
+	TemplateID int64 `path:"templateId" minimum:"1" doc:"Unique identifier of the transaction template" example:"1"`
+	Body       models.UpsertShareModel
+}) (*struct {
+	Body models.SharesListModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("transactions", "PUT", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "TransactionResource.ShareTemplate", "template_id", input.TemplateID)
+	logger.Info("start", "role", input.Body.Role)
+	resp, err := tr.sevs.Shr.Upsert(ctx, constants.EntityTransactionTemplate, input.TemplateID, input.Body)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct {
+		Body models.SharesListModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (tr TransactionResource) UnshareTemplate(ctx context.Context, input *struct {
+	TemplateID int64 `path:"templateId" minimum:"1" doc:"Unique identifier of the transaction template" example:"1"`
+	UserID     int64 `path:"userId" minimum:"1" doc:"ID of the user to revoke access from" example:"2"`
+}) (*struct{}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("transactions", "DELETE", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "TransactionResource.UnshareTemplate", "template_id", input.TemplateID, "user_id", input.UserID)
+	logger.Info("start")
+	if err := tr.sevs.Shr.Delete(ctx, constants.EntityTransactionTemplate, input.TemplateID, input.UserID); err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return nil, nil
+}
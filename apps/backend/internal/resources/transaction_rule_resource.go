@@ -0,0 +1,252 @@
+package resources
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/dimasbaguspm/spenicle-api/internal/services"
+)
+
+type TransactionRuleResource struct {
+	sevs services.RootService
+}
+
+func NewTransactionRuleResource(sevs services.RootService) TransactionRuleResource {
+	return TransactionRuleResource{sevs}
+}
+func (trr TransactionRuleResource) Routes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-transaction-rules",
+		Method:      "GET",
+		Path:        "/transaction-rules",
+		Summary:     "List transaction rules",
+		Description: "Get a paginated list of transaction rules in the order they are evaluated",
+		Tags:        []string{"Transaction Rules"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsRead}},
+		},
+	}, trr.List)
+	huma.Register(api, huma.Operation{
+		OperationID: "create-transaction-rule",
+		Method:      "POST",
+		Path:        "/transaction-rules",
+		Summary:     "Create transaction rule",
+		Description: "Create a rule applied to new transactions that meet all of its conditions. Rules run in ascending priority; the first matching rule to set a field wins and tags of all matching rules are added",
+		Tags:        []string{"Transaction Rules"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, trr.Create)
+	huma.Register(api, huma.Operation{
+		OperationID: "apply-transaction-rules",
+		Method:      "POST",
+		Path:        "/transaction-rules/apply",
+		Summary:     "Re-apply rules to range",
+		Description: "Apply all enabled rules to the existing transactions dated within the range in one database transaction. Reconciled transactions are skipped, and each changed transaction gets a revision that can be reverted",
+		Tags:        []string{"Transaction Rules"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, trr.Apply)
+	huma.Register(api, huma.Operation{
+		OperationID: "get-transaction-rule",
+		Method:      "GET",
+		Path:        "/transaction-rules/{id}",
+		Summary:     "Get transaction rule",
+		Description: "Get a single transaction rule by ID",
+		Tags:        []string{"Transaction Rules"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsRead}},
+		},
+	}, trr.Get)
+	huma.Register(api, huma.Operation{
+		OperationID: "update-transaction-rule",
+		Method:      "PATCH",
+		Path:        "/transaction-rules/{id}",
+		Summary:     "Update transaction rule",
+		Description: "Update an existing transaction rule; conditions and actions are replaced as a whole when provided",
+		Tags:        []string{"Transaction Rules"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, trr.Update)
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-transaction-rule",
+		Method:      "DELETE",
+		Path:        "/transaction-rules/{id}",
+		Summary:     "Delete transaction rule",
+		Description: "Delete a transaction rule; transactions keep the changes it already made",
+		Tags:        []string{"Transaction Rules"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsWrite}},
+		},
+	}, trr.Delete)
+	huma.Register(api, huma.Operation{
+		OperationID: "dry-run-transaction-rule",
+		Method:      "GET",
+		Path:        "/transaction-rules/{id}/dry-run",
+		Summary:     "Dry run transaction rule",
+		Description: "Show which existing transactions dated within the range this rule would change, and how, without changing them. Disabled rules can be tried out too",
+		Tags:        []string{"Transaction Rules"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsRead}},
+		},
+	}, trr.DryRun)
+}
+func (trr TransactionRuleResource) List(ctx context.Context, input *struct {
+	models.TransactionRulesSearchModel
+}) (*struct {
+	Body models.TransactionRulesPagedModel
+}, error) {
+	start := time.Now()
+	defer func() {
+		observability.RecordServiceOperation("transaction_rules", "GET", time.Since(start).Seconds())
+	}()
+	logger := observability.GetLogger(ctx).With("resource", "TransactionRuleResource.List")
+	logger.Info("start")
+	resp, err := trr.sevs.TsctRule.GetPaged(ctx, input.TransactionRulesSearchModel)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct {
+		Body models.TransactionRulesPagedModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (trr TransactionRuleResource) Get(ctx context.Context, input *struct {
+	ID int64 `path:"id" minimum:"1" doc:"Unique identifier of the transaction rule" example:"1"`
+}) (*struct{ Body models.TransactionRuleModel }, error) {
+	start := time.Now()
+	defer func() {
+		observability.RecordServiceOperation("transaction_rules", "GET", time.Since(start).Seconds())
+	}()
+	logger := observability.GetLogger(ctx).With("resource", "TransactionRuleResource.Get", "transaction_rule_id", input.ID)
+	logger.Info("start")
+	resp, err := trr.sevs.TsctRule.GetDetail(ctx, input.ID)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct{ Body models.TransactionRuleModel }{
+		Body: resp,
+	}, nil
+}
+func (trr TransactionRuleResource) Create(ctx context.Context, input *struct {
+	Body models.CreateTransactionRuleModel
+}) (*struct {
+	Body models.TransactionRuleModel
+}, error) {
+	start := time.Now()
+	defer func() {
+		observability.RecordServiceOperation("transaction_rules", "POST", time.Since(start).Seconds())
+	}()
+	logger := observability.GetLogger(ctx).With("resource", "TransactionRuleResource.Create")
+	logger.Info("start")
+	resp, err := trr.sevs.TsctRule.Create(ctx, input.Body)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success", "transaction_rule_id", resp.ID)
+	return &struct {
+		Body models.TransactionRuleModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (trr TransactionRuleResource) Update(ctx context.Context, input *struct {
+	ID   int64 `path:"id" minimum:"1" doc:"Unique identifier of the transaction rule" example:"1"`
+	Body models.UpdateTransactionRuleModel
+}) (*struct {
+	Body models.TransactionRuleModel
+}, error) {
+	start := time.Now()
+	defer func() {
+		observability.RecordServiceOperation("transaction_rules", "PATCH", time.Since(start).Seconds())
+	}()
+	logger := observability.GetLogger(ctx).With("resource", "TransactionRuleResource.Update", "transaction_rule_id", input.ID)
+	logger.Info("start")
+	resp, err := trr.sevs.TsctRule.Update(ctx, input.ID, input.Body)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct {
+		Body models.TransactionRuleModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (trr TransactionRuleResource) Delete(ctx context.Context, input *struct {
+	ID int64 `path:"id" minimum:"1" doc:"Unique identifier of the transaction rule" example:"1"`
+}) (*struct{}, error) {
+	start := time.Now()
+	defer func() {
+		observability.RecordServiceOperation("transaction_rules", "DELETE", time.Since(start).Seconds())
+	}()
+	logger := observability.GetLogger(ctx).With("resource", "TransactionRuleResource.Delete", "transaction_rule_id", input.ID)
+	logger.Info("start")
+	err := trr.sevs.TsctRule.Delete(ctx, input.ID)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return nil, nil
+}
+func (trr TransactionRuleResource) DryRun(ctx context.Context, input *struct {
+	ID int64 `path:"id" minimum:"1" doc:"Unique identifier of the transaction rule" example:"1"`
+	models.TransactionRuleRangeModel
+}) (*struct {
+	Body models.TransactionRuleDryRunModel
+}, error) {
+	start := time.Now()
+	defer func() {
+		observability.RecordServiceOperation("transaction_rules", "GET", time.Since(start).Seconds())
+	}()
+	logger := observability.GetLogger(ctx).With("resource", "TransactionRuleResource.DryRun", "transaction_rule_id", input.ID)
+	logger.Info("start")
+	resp, err := trr.sevs.TsctRule.DryRun(ctx, input.ID, input.TransactionRuleRangeModel)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success", "checked_count", resp.CheckedCount, "change_count", len(resp.Items))
+	return &struct {
+		Body models.TransactionRuleDryRunModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (trr TransactionRuleResource) Apply(ctx context.Context, input *struct {
+	Body models.TransactionRuleRangeModel
+}) (*struct {
+	Body models.TransactionRuleApplyResultModel
+}, error) {
+	start := time.Now()
+	defer func() {
+		observability.RecordServiceOperation("transaction_rules", "POST", time.Since(start).Seconds())
+	}()
+	logger := observability.GetLogger(ctx).With("resource", "TransactionRuleResource.Apply")
+	logger.Info("start")
+	resp, err := trr.sevs.TsctRule.Apply(ctx, input.Body)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success", "checked_count", resp.CheckedCount, "updated_count", len(resp.UpdatedIDs))
+	return &struct {
+		Body models.TransactionRuleApplyResultModel
+	}{
+		Body: resp,
+	}, nil
+}
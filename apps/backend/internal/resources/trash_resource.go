@@ -0,0 +1,54 @@
+package resources
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/dimasbaguspm/spenicle-api/internal/services"
+)
+
+type TrashResource struct {
+	sevs services.RootService
+}
+
+func NewTrashResource(sevs services.RootService) TrashResource {
+	return TrashResource{sevs}
+}
+func (tr TrashResource) Routes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-trash",
+		Method:      "GET",
+		Path:        "/trash",
+		Summary:     "List trash",
+		Description: "Get the deleted transactions, accounts, categories, tags, payees and transaction templates that can still be restored, most recently deleted first. Each kind has its own restore endpoint; records are permanently removed once the retention period has passed",
+		Tags:        []string{"Trash"},
+		Security: []map[string][]string{
+			{"bearer": {constants.AuthScopeTransactionsRead}},
+		},
+	}, tr.List)
+}
+func (tr TrashResource) List(ctx context.Context, input *struct {
+	models.TrashSearchModel
+}) (*struct {
+	Body models.TrashPagedModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("trash", "GET", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "TrashResource.List")
+	logger.Info("start")
+	resp, err := tr.sevs.Trash.GetPaged(ctx, input.TrashSearchModel)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success", "count", len(resp.Items))
+	return &struct {
+		Body models.TrashPagedModel
+	}{
+		Body: resp,
+	}, nil
+}
@@ -0,0 +1,145 @@
+package resources
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/dimasbaguspm/spenicle-api/internal/services"
+)
+
+type TwoFactorResource struct {
+	sevs services.RootService
+}
+
+func NewTwoFactorResource(sevs services.RootService) TwoFactorResource {
+	return TwoFactorResource{sevs}
+}
+func (tfr TwoFactorResource) Routes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "enroll-two-factor",
+		Method:      "POST",
+		Path:        "/auth/2fa/enroll",
+		Summary:     "Start two-factor enrollment",
+		Description: "Generate a TOTP secret and provisioning URI. Two-factor authentication is enabled once a code is verified",
+		Tags:        []string{"Auth"},
+		Metadata:    map[string]any{constants.IdempotencyExemptMetadataKey: true},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, tfr.Enroll)
+	huma.Register(api, huma.Operation{
+		OperationID: "verify-two-factor",
+		Method:      "POST",
+		Path:        "/auth/2fa/verify",
+		Summary:     "Verify two-factor enrollment",
+		Description: "Confirm the pending secret with a code from the authenticator app, enable two-factor authentication and receive recovery codes",
+		Tags:        []string{"Auth"},
+		Metadata:    map[string]any{constants.IdempotencyExemptMetadataKey: true},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, tfr.Verify)
+	huma.Register(api, huma.Operation{
+		OperationID: "regenerate-two-factor-recovery-codes",
+		Method:      "POST",
+		Path:        "/auth/2fa/recovery-codes",
+		Summary:     "Regenerate recovery codes",
+		Description: "Replace all recovery codes after confirming a TOTP or recovery code",
+		Tags:        []string{"Auth"},
+		Metadata:    map[string]any{constants.IdempotencyExemptMetadataKey: true},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, tfr.RegenerateRecoveryCodes)
+	huma.Register(api, huma.Operation{
+		OperationID: "disable-two-factor",
+		Method:      "POST",
+		Path:        "/auth/2fa/disable",
+		Summary:     "Disable two-factor authentication",
+		Description: "Turn off two-factor authentication after confirming a TOTP or recovery code",
+		Tags:        []string{"Auth"},
+		Metadata:    map[string]any{constants.IdempotencyExemptMetadataKey: true},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, tfr.Disable)
+}
+func (tfr TwoFactorResource) Enroll(ctx context.Context, input *struct{}) (*struct {
+	Body models.TwoFactorEnrollResponseModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("two_factor", "POST", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "TwoFactorResource.Enroll")
+	logger.Info("start")
+	resp, err := tfr.sevs.TwoFa.Enroll(ctx)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct {
+		Body models.TwoFactorEnrollResponseModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (tfr TwoFactorResource) Verify(ctx context.Context, input *struct {
+	Body models.TwoFactorCodeRequestModel
+}) (*struct {
+	Body models.TwoFactorRecoveryCodesModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("two_factor", "POST", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "TwoFactorResource.Verify")
+	logger.Info("start")
+	resp, err := tfr.sevs.TwoFa.Verify(ctx, input.Body)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct {
+		Body models.TwoFactorRecoveryCodesModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (tfr TwoFactorResource) RegenerateRecoveryCodes(ctx context.Context, input *struct {
+	Body models.TwoFactorCodeRequestModel
+}) (*struct {
+	Body models.TwoFactorRecoveryCodesModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("two_factor", "POST", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "TwoFactorResource.RegenerateRecoveryCodes")
+	logger.Info("start")
+	resp, err := tfr.sevs.TwoFa.RegenerateRecoveryCodes(ctx, input.Body)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct {
+		Body models.TwoFactorRecoveryCodesModel
+	}{
+		Body: resp,
+	}, nil
+}
+func (tfr TwoFactorResource) Disable(ctx context.Context, input *struct {
+	Body models.TwoFactorCodeRequestModel
+}) (*struct{}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("two_factor", "POST", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "TwoFactorResource.Disable")
+	logger.Info("start")
+	if err := tfr.sevs.TwoFa.Disable(ctx, input.Body); err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return nil, nil
+}
@@ -0,0 +1,81 @@
+package resources
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/dimasbaguspm/spenicle-api/internal/services"
+)
+
+type UserResource struct {
+	sevs services.RootService
+}
+
+func NewUserResource(sevs services.RootService) UserResource {
+	return UserResource{sevs}
+}
+func (ur UserResource) Routes(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-current-user",
+		Method:      "GET",
+		Path:        "/users/me",
+		Summary:     "Get current user",
+		Description: "Get the user the auth token was issued for",
+		Tags:        []string{"Users"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, ur.GetMe)
+	huma.Register(api, huma.Operation{
+		OperationID: "create-user",
+		Method:      "POST",
+		Path:        "/users",
+		Summary:     "Create user",
+		Description: "Create another user with its own isolated accounts, categories and transactions. Only an admin can create users",
+		Tags:        []string{"Users"},
+		Metadata:    map[string]any{constants.IdempotencyExemptMetadataKey: true},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, ur.Create)
+}
+func (ur UserResource) GetMe(ctx context.Context, input *struct{}) (*struct{ Body models.UserModel }, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("users", "GET", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "UserResource.GetMe")
+	logger.Info("start")
+	resp, err := ur.sevs.Usr.GetMe(ctx)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success")
+	return &struct{ Body models.UserModel }{
+		Body: resp,
+	}, nil
+}
+func (ur UserResource) Create(ctx context.Context, input *struct {
+	Body models.CreateUserModel
+}) (*struct {
+	Body models.UserModel
+}, error) {
+	start := time.Now()
+	defer func() { observability.RecordServiceOperation("users", "POST", time.Since(start).Seconds()) }()
+	logger := observability.GetLogger(ctx).With("resource", "UserResource.Create")
+	logger.Info("start")
+	resp, err := ur.sevs.Usr.Create(ctx, input.Body)
+	if err != nil {
+		logger.Error("error", "error", err)
+		return nil, err
+	}
+	logger.Info("success", "user_id", resp.ID)
+	return &struct {
+		Body models.UserModel
+	}{
+		Body: resp,
+	}, nil
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
 	"github.com/dimasbaguspm/spenicle-api/internal/repositories"
 	"github.com/dimasbaguspm/spenicle-api/internal/services"
@@ -1050,23 +1051,23 @@ func SeedDevelopmentData(ctx context.Context, pool *pgxpool.Pool, rdb *redis.Cli
 		RelationType         string
 	}{
 		// Link salary to savings transfer
-		{templateTransactionIDs[0], transactionIDs[8], "salary_to_savings"},  // First salary to first transfer
-		{templateTransactionIDs[1], transactionIDs[13], "salary_to_savings"}, // Second salary to second transfer
+		{templateTransactionIDs[0], transactionIDs[8], constants.TransactionRelationRelated},  // First salary to first transfer
+		{templateTransactionIDs[1], transactionIDs[13], constants.TransactionRelationRelated}, // Second salary to second transfer
 
 		// Link laptop purchase to installments
-		{transactionIDs[3], templateTransactionIDs[9], "purchase_to_installment"},  // Laptop purchase to first installment
-		{transactionIDs[3], templateTransactionIDs[10], "purchase_to_installment"}, // Laptop purchase to second installment
+		{transactionIDs[3], templateTransactionIDs[9], constants.TransactionRelationRelated},  // Laptop purchase to first installment
+		{transactionIDs[3], templateTransactionIDs[10], constants.TransactionRelationRelated}, // Laptop purchase to second installment
 
 		// Link bill payments to their templates
-		{templateTransactionIDs[2], templateIDs[1], "bill_to_template"}, // Internet bill to template
-		{templateTransactionIDs[3], templateIDs[1], "bill_to_template"}, // Internet bill to template
-		{templateTransactionIDs[7], templateIDs[3], "bill_to_template"}, // Electricity bill to template
-		{templateTransactionIDs[8], templateIDs[3], "bill_to_template"}, // Electricity bill to template
+		{templateTransactionIDs[2], templateIDs[1], constants.TransactionRelationRelated}, // Internet bill to template
+		{templateTransactionIDs[3], templateIDs[1], constants.TransactionRelationRelated}, // Internet bill to template
+		{templateTransactionIDs[7], templateIDs[3], constants.TransactionRelationRelated}, // Electricity bill to template
+		{templateTransactionIDs[8], templateIDs[3], constants.TransactionRelationRelated}, // Electricity bill to template
 
 		// Link grocery transactions to template
-		{templateTransactionIDs[4], templateIDs[2], "shopping_to_template"}, // Grocery shopping to template
-		{templateTransactionIDs[5], templateIDs[2], "shopping_to_template"}, // Grocery shopping to template
-		{templateTransactionIDs[6], templateIDs[2], "shopping_to_template"}, // Grocery shopping to template
+		{templateTransactionIDs[4], templateIDs[2], constants.TransactionRelationRelated}, // Grocery shopping to template
+		{templateTransactionIDs[5], templateIDs[2], constants.TransactionRelationRelated}, // Grocery shopping to template
+		{templateTransactionIDs[6], templateIDs[2], constants.TransactionRelationRelated}, // Grocery shopping to template
 	}
 
 	for _, relation := range transactionRelations {
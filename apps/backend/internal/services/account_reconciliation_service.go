@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/dimasbaguspm/spenicle-api/internal/repositories"
+	"github.com/redis/go-redis/v9"
+)
+
+type AccountReconciliationService struct {
+	rpts *repositories.RootRepository
+	rdb  *redis.Client
+}
+
+func NewAccountReconciliationService(rpts *repositories.RootRepository, rdb *redis.Client) AccountReconciliationService {
+	return AccountReconciliationService{rpts, rdb}
+}
+
+// GetPaged lists the reconciliations of an account; it is not cached since completion changes many transactions at once
+func (ars AccountReconciliationService) GetPaged(ctx context.Context, accountID int64, query models.AccountReconciliationsSearchModel) (models.AccountReconciliationsPagedModel, error) {
+	if _, err := requireAccess(ctx, ars.rpts, constants.EntityAccount, accountID, constants.ShareRoleOwner, constants.ShareRoleEditor, constants.ShareRoleViewer); err != nil {
+		return models.AccountReconciliationsPagedModel{}, err
+	}
+	return ars.rpts.AccRecon.GetPaged(ctx, accountID, query)
+}
+
+// GetDetail returns a reconciliation and, while it is open, how far the cleared balance is from the statement
+func (ars AccountReconciliationService) GetDetail(ctx context.Context, accountID int64, id int64) (models.AccountReconciliationDetailModel, error) {
+	if _, err := requireAccess(ctx, ars.rpts, constants.EntityAccount, accountID, constants.ShareRoleOwner, constants.ShareRoleEditor, constants.ShareRoleViewer); err != nil {
+		return models.AccountReconciliationDetailModel{}, err
+	}
+
+	reconciliation, err := ars.rpts.AccRecon.GetDetail(ctx, accountID, id)
+	if err != nil {
+		return models.AccountReconciliationDetailModel{}, err
+	}
+	return ars.withBalance(ctx, reconciliation)
+}
+
+// Create opens a reconciliation against a bank statement; an account has at most one open reconciliation
+func (ars AccountReconciliationService) Create(ctx context.Context, accountID int64, p models.CreateAccountReconciliationModel) (models.AccountReconciliationDetailModel, error) {
+	if _, err := requireAccess(ctx, ars.rpts, constants.EntityAccount, accountID, constants.ShareRoleOwner, constants.ShareRoleEditor); err != nil {
+		return models.AccountReconciliationDetailModel{}, err
+	}
+
+	tx, err := ars.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.AccountReconciliationDetailModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := ars.rpts.WithTx(ctx, tx)
+	reconciliation, err := rootTx.AccRecon.Create(ctx, accountID, p)
+	if err != nil {
+		return models.AccountReconciliationDetailModel{}, err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityAccountReconciliation, reconciliation.ID, constants.AuditOperationCreate, nil, reconciliation); err != nil {
+		return models.AccountReconciliationDetailModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.AccountReconciliationDetailModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
+	return ars.withBalance(ctx, reconciliation)
+}
+
+// Complete locks the cleared transactions up to the statement date once the cleared balance matches the statement
+func (ars AccountReconciliationService) Complete(ctx context.Context, accountID int64, id int64) (models.AccountReconciliationDetailModel, error) {
+	if _, err := requireAccess(ctx, ars.rpts, constants.EntityAccount, accountID, constants.ShareRoleOwner, constants.ShareRoleEditor); err != nil {
+		return models.AccountReconciliationDetailModel{}, err
+	}
+
+	tx, err := ars.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.AccountReconciliationDetailModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := ars.rpts.WithTx(ctx, tx)
+	existing, err := rootTx.AccRecon.GetDetailForUpdate(ctx, accountID, id)
+	if err != nil {
+		return models.AccountReconciliationDetailModel{}, err
+	}
+	if existing.Status != constants.ReconciliationStatusOpen {
+		return models.AccountReconciliationDetailModel{}, huma.Error409Conflict("Reconciliation is already completed")
+	}
+
+	balance, err := rootTx.AccRecon.GetBalance(ctx, accountID, existing.StatementDate, existing.StatementBalance)
+	if err != nil {
+		return models.AccountReconciliationDetailModel{}, err
+	}
+	if balance.Difference != 0 {
+		return models.AccountReconciliationDetailModel{}, huma.Error409Conflict(fmt.Sprintf("Cleared balance %d differs from the statement balance by %d", balance.ClearedBalance, balance.Difference))
+	}
+
+	reconciliation, err := rootTx.AccRecon.Complete(ctx, existing)
+	if err != nil {
+		return models.AccountReconciliationDetailModel{}, err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityAccountReconciliation, id, constants.AuditOperationUpdate, existing, reconciliation); err != nil {
+		return models.AccountReconciliationDetailModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.AccountReconciliationDetailModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
+	// The status of many transactions changed; they are cached per detail and page
+	if err := common.InvalidateCacheForEntity(ctx, ars.rdb, constants.EntityTransaction, map[string]interface{}{
+		"accountId": accountID,
+	}); err != nil {
+		observability.NewLogger("service", "AccountReconciliationService").Warn("cache invalidation failed", "error", err)
+	}
+
+	return models.AccountReconciliationDetailModel{AccountReconciliationModel: reconciliation}, nil
+}
+
+// Delete discards an open reconciliation; transaction statuses are left as they are
+func (ars AccountReconciliationService) Delete(ctx context.Context, accountID int64, id int64) error {
+	if _, err := requireAccess(ctx, ars.rpts, constants.EntityAccount, accountID, constants.ShareRoleOwner, constants.ShareRoleEditor); err != nil {
+		return err
+	}
+
+	existing, err := ars.rpts.AccRecon.GetDetail(ctx, accountID, id)
+	if err != nil {
+		return err
+	}
+	if existing.Status != constants.ReconciliationStatusOpen {
+		return huma.Error409Conflict("Completed reconciliations cannot be deleted")
+	}
+
+	tx, err := ars.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := ars.rpts.WithTx(ctx, tx)
+	if err := rootTx.AccRecon.Delete(ctx, accountID, id); err != nil {
+		return err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityAccountReconciliation, id, constants.AuditOperationDelete, existing, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+	return nil
+}
+
+func (ars AccountReconciliationService) withBalance(ctx context.Context, reconciliation models.AccountReconciliationModel) (models.AccountReconciliationDetailModel, error) {
+	detail := models.AccountReconciliationDetailModel{AccountReconciliationModel: reconciliation}
+	if reconciliation.Status != constants.ReconciliationStatusOpen {
+		return detail, nil
+	}
+
+	balance, err := ars.rpts.AccRecon.GetBalance(ctx, reconciliation.AccountID, reconciliation.StatementDate, reconciliation.StatementBalance)
+	if err != nil {
+		return models.AccountReconciliationDetailModel{}, err
+	}
+	detail.Balance = &balance
+	return detail, nil
+}
@@ -25,25 +25,40 @@ func NewAccountService(rpts *repositories.RootRepository, rdb *redis.Client) Acc
 }
 
 func (as AccountService) GetPaged(ctx context.Context, p models.AccountsSearchModel) (models.AccountsPagedModel, error) {
-	cacheKey := common.BuildPagedCacheKey(constants.EntityAccount, p)
+	cacheKey := common.BuildPagedCacheKey(ctx, constants.EntityAccount, p)
 	return common.FetchWithCache(ctx, as.rdb, cacheKey, constants.CacheTTLPaged, func(ctx context.Context) (models.AccountsPagedModel, error) {
 		return as.rpts.Acc.GetPaged(ctx, p)
 	}, "account")
 }
 
 func (as AccountService) GetDetail(ctx context.Context, id int64) (models.AccountModel, error) {
-	cacheKey := common.BuildDetailCacheKey(constants.EntityAccount, id)
+	cacheKey := common.BuildDetailCacheKey(ctx, constants.EntityAccount, id)
 	return common.FetchWithCache(ctx, as.rdb, cacheKey, constants.CacheTTLDetail, func(ctx context.Context) (models.AccountModel, error) {
 		return as.rpts.Acc.GetDetail(ctx, id)
 	}, "account")
 }
 
 func (as AccountService) Create(ctx context.Context, p models.CreateAccountModel) (models.AccountModel, error) {
-	account, err := as.rpts.Acc.Create(ctx, p)
+	tx, err := as.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.AccountModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := as.rpts.WithTx(ctx, tx)
+	account, err := rootTx.Acc.Create(ctx, p)
 	if err != nil {
 		return account, err
 	}
 
+	if err := recordAudit(ctx, rootTx, constants.EntityAccount, account.ID, constants.AuditOperationCreate, nil, account); err != nil {
+		return models.AccountModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.AccountModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
 	if err := common.InvalidateCacheForEntity(ctx, as.rdb, constants.EntityAccount, map[string]interface{}{"accountId": account.ID}); err != nil {
 		observability.NewLogger("service", "AccountService").Warn("cache invalidation failed", "error", err)
 	}
@@ -51,19 +66,53 @@ func (as AccountService) Create(ctx context.Context, p models.CreateAccountModel
 	return account, nil
 }
 
-func (as AccountService) Update(ctx context.Context, id int64, p models.UpdateAccountModel) (models.AccountModel, error) {
-	account, err := as.rpts.Acc.Update(ctx, id, p)
+// Update changes an account; with ifMatch set, only while its ETag still matches
+func (as AccountService) Update(ctx context.Context, id int64, p models.UpdateAccountModel, ifMatch string) (models.AccountModel, error) {
+	if _, err := requireAccess(ctx, as.rpts, constants.EntityAccount, id, constants.ShareRoleOwner, constants.ShareRoleEditor); err != nil {
+		return models.AccountModel{}, err
+	}
+
+	tx, err := as.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.AccountModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := as.rpts.WithTx(ctx, tx)
+	existing, err := rootTx.Acc.GetDetailForUpdate(ctx, id)
+	if err != nil {
+		return models.AccountModel{}, err
+	}
+
+	if err := common.RequireETag(ifMatch, existing.ETag, existing); err != nil {
+		return models.AccountModel{}, err
+	}
+
+	account, err := rootTx.Acc.Update(ctx, id, p)
 	if err != nil {
 		return account, err
 	}
 
+	if err := recordAudit(ctx, rootTx, constants.EntityAccount, id, constants.AuditOperationUpdate, existing, account); err != nil {
+		return models.AccountModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.AccountModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
 	if err := common.InvalidateCacheForEntity(ctx, as.rdb, constants.EntityAccount, map[string]interface{}{"accountId": id}); err != nil {
 		observability.NewLogger("service", "AccountService").Warn("cache invalidation failed", "error", err)
 	}
 	return account, nil
 }
 
-func (as AccountService) Delete(ctx context.Context, id int64) error {
+// Delete moves an account to the trash; with ifMatch set, only while its ETag still matches
+func (as AccountService) Delete(ctx context.Context, id int64, ifMatch string) error {
+	if _, err := requireAccess(ctx, as.rpts, constants.EntityAccount, id, constants.ShareRoleOwner); err != nil {
+		return err
+	}
+
 	tx, err := as.rpts.Pool.Begin(ctx)
 	if err != nil {
 		return huma.Error400BadRequest("Unable to start transaction", err)
@@ -75,10 +124,23 @@ func (as AccountService) Delete(ctx context.Context, id int64) error {
 	}()
 
 	rootTx := as.rpts.WithTx(ctx, tx)
+	existing, err := rootTx.Acc.GetDetailForUpdate(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := common.RequireETag(ifMatch, existing.ETag, existing); err != nil {
+		return err
+	}
+
 	if err := rootTx.Acc.Delete(ctx, id); err != nil {
 		return err
 	}
 
+	if err := recordAudit(ctx, rootTx, constants.EntityAccount, id, constants.AuditOperationDelete, existing, nil); err != nil {
+		return err
+	}
+
 	ids, err := rootTx.Acc.GetActiveIDsOrdered(ctx)
 	if err != nil {
 		return err
@@ -98,6 +160,42 @@ func (as AccountService) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// Restore brings an account back from the trash; its balance was never reverted, so it comes back as it was
+func (as AccountService) Restore(ctx context.Context, id int64) (models.AccountModel, error) {
+	tx, err := as.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.AccountModel{}, huma.Error400BadRequest("Unable to start transaction", err)
+	}
+	defer func() {
+		if tx != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	rootTx := as.rpts.WithTx(ctx, tx)
+	if err := rootTx.Acc.Restore(ctx, id); err != nil {
+		return models.AccountModel{}, err
+	}
+
+	account, err := rootTx.Acc.GetDetail(ctx, id)
+	if err != nil {
+		return models.AccountModel{}, err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityAccount, id, constants.AuditOperationRestore, nil, account); err != nil {
+		return models.AccountModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.AccountModel{}, huma.Error400BadRequest("Unable to commit transaction", err)
+	}
+	tx = nil
+
+	common.InvalidateCacheForEntity(ctx, as.rdb, constants.EntityAccount, map[string]interface{}{"accountId": id})
+
+	return account, nil
+}
+
 func (as AccountService) Reorder(ctx context.Context, p models.ReorderAccountsModel) error {
 	if len(p.Data) == 0 {
 		return huma.Error400BadRequest("No account IDs provided for reordering")
@@ -92,7 +92,7 @@ func (ss AccountStatisticsService) GetAccountStatistics(ctx context.Context, acc
 
 // GetCategoryHeatmap returns category spending distribution
 func (ss AccountStatisticsService) GetCategoryHeatmap(ctx context.Context, accountID int64, p models.AccountStatisticsSearchModel) (models.AccountStatisticsCategoryHeatmapModel, error) {
-	cacheKey := common.BuildStatisticsCacheKey(constants.EntityAccount, accountID, constants.AccountStatisticsCategoryHeatmapSuffix, p)
+	cacheKey := common.BuildStatisticsCacheKey(ctx, constants.EntityAccount, accountID, constants.AccountStatisticsCategoryHeatmapSuffix, p)
 	return common.FetchWithCache(ctx, ss.rdb, cacheKey, constants.CacheTTLStatistics, func(ctx context.Context) (models.AccountStatisticsCategoryHeatmapModel, error) {
 		return ss.rpts.AccStat.GetCategoryHeatmap(ctx, accountID, p)
 	}, "account_statistics")
@@ -100,7 +100,7 @@ func (ss AccountStatisticsService) GetCategoryHeatmap(ctx context.Context, accou
 
 // GetMonthlyVelocity returns monthly spending trends
 func (ss AccountStatisticsService) GetMonthlyVelocity(ctx context.Context, accountID int64, p models.AccountStatisticsSearchModel) (models.AccountStatisticsMonthlyVelocityModel, error) {
-	cacheKey := common.BuildStatisticsCacheKey(constants.EntityAccount, accountID, constants.AccountStatisticsMonthlyVelocitySuffix, p)
+	cacheKey := common.BuildStatisticsCacheKey(ctx, constants.EntityAccount, accountID, constants.AccountStatisticsMonthlyVelocitySuffix, p)
 	return common.FetchWithCache(ctx, ss.rdb, cacheKey, constants.CacheTTLStatistics, func(ctx context.Context) (models.AccountStatisticsMonthlyVelocityModel, error) {
 		return ss.rpts.AccStat.GetMonthlyVelocity(ctx, accountID, p)
 	}, "account_statistics")
@@ -108,7 +108,7 @@ func (ss AccountStatisticsService) GetMonthlyVelocity(ctx context.Context, accou
 
 // GetTimeFrequencyHeatmap returns transaction frequency distribution
 func (ss AccountStatisticsService) GetTimeFrequencyHeatmap(ctx context.Context, accountID int64, p models.AccountStatisticsSearchModel) (models.AccountStatisticsTimeFrequencyHeatmapModel, error) {
-	cacheKey := common.BuildStatisticsCacheKey(constants.EntityAccount, accountID, constants.AccountStatisticsTimeFrequencySuffix, p)
+	cacheKey := common.BuildStatisticsCacheKey(ctx, constants.EntityAccount, accountID, constants.AccountStatisticsTimeFrequencySuffix, p)
 	return common.FetchWithCache(ctx, ss.rdb, cacheKey, constants.CacheTTLStatistics, func(ctx context.Context) (models.AccountStatisticsTimeFrequencyHeatmapModel, error) {
 		return ss.rpts.AccStat.GetTimeFrequencyHeatmap(ctx, accountID, p)
 	}, "account_statistics")
@@ -116,7 +116,7 @@ func (ss AccountStatisticsService) GetTimeFrequencyHeatmap(ctx context.Context,
 
 // GetCashFlowPulse returns daily balance trend
 func (ss AccountStatisticsService) GetCashFlowPulse(ctx context.Context, accountID int64, p models.AccountStatisticsSearchModel) (models.AccountStatisticsCashFlowPulseModel, error) {
-	cacheKey := common.BuildStatisticsCacheKey(constants.EntityAccount, accountID, constants.AccountStatisticsCashFlowPulseSuffix, p)
+	cacheKey := common.BuildStatisticsCacheKey(ctx, constants.EntityAccount, accountID, constants.AccountStatisticsCashFlowPulseSuffix, p)
 	return common.FetchWithCache(ctx, ss.rdb, cacheKey, constants.CacheTTLStatistics, func(ctx context.Context) (models.AccountStatisticsCashFlowPulseModel, error) {
 		return ss.rpts.AccStat.GetCashFlowPulse(ctx, accountID, p)
 	}, "account_statistics")
@@ -124,7 +124,7 @@ func (ss AccountStatisticsService) GetCashFlowPulse(ctx context.Context, account
 
 // GetBurnRate returns spending rate analysis
 func (ss AccountStatisticsService) GetBurnRate(ctx context.Context, accountID int64, p models.AccountStatisticsSearchModel) (models.AccountStatisticsBurnRateModel, error) {
-	cacheKey := common.BuildStatisticsCacheKey(constants.EntityAccount, accountID, constants.AccountStatisticsBurnRateSuffix, p)
+	cacheKey := common.BuildStatisticsCacheKey(ctx, constants.EntityAccount, accountID, constants.AccountStatisticsBurnRateSuffix, p)
 	return common.FetchWithCache(ctx, ss.rdb, cacheKey, constants.CacheTTLStatistics, func(ctx context.Context) (models.AccountStatisticsBurnRateModel, error) {
 		return ss.rpts.AccStat.GetBurnRate(ctx, accountID, p)
 	}, "account_statistics")
@@ -132,7 +132,7 @@ func (ss AccountStatisticsService) GetBurnRate(ctx context.Context, accountID in
 
 // GetBudgetHealth returns budget health metrics
 func (ss AccountStatisticsService) GetBudgetHealth(ctx context.Context, accountID int64, p models.AccountStatisticsSearchModel) (models.AccountStatisticsBudgetHealthModel, error) {
-	cacheKey := common.BuildStatisticsCacheKey(constants.EntityAccount, accountID, constants.AccountStatisticsBudgetHealthSuffix, p)
+	cacheKey := common.BuildStatisticsCacheKey(ctx, constants.EntityAccount, accountID, constants.AccountStatisticsBudgetHealthSuffix, p)
 	return common.FetchWithCache(ctx, ss.rdb, cacheKey, constants.CacheTTLStatistics, func(ctx context.Context) (models.AccountStatisticsBudgetHealthModel, error) {
 		return ss.rpts.AccStat.GetBudgetHealth(ctx, accountID, p)
 	}, "account_statistics")
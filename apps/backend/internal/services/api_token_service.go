@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/repositories"
+)
+
+// apiTokenDisplayLength is how many leading characters are kept to identify a token in listings
+const apiTokenDisplayLength = 12
+
+type APITokenService struct {
+	rpts *repositories.RootRepository
+}
+
+func NewAPITokenService(rpts *repositories.RootRepository) APITokenService {
+	return APITokenService{rpts}
+}
+
+func (ats APITokenService) GetList(ctx context.Context) (models.APITokensListModel, error) {
+	return ats.rpts.APITok.GetList(ctx)
+}
+
+// Create generates a random token, stores its hash and returns the plain value once
+func (ats APITokenService) Create(ctx context.Context, payload models.CreateAPITokenModel) (models.CreateAPITokenResponseModel, error) {
+	if payload.ExpiresAt != nil && !payload.ExpiresAt.After(time.Now()) {
+		return models.CreateAPITokenResponseModel{}, huma.Error400BadRequest("Expiry must be in the future")
+	}
+
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return models.CreateAPITokenResponseModel{}, huma.Error500InternalServerError("Unable to generate API token", err)
+	}
+	token := constants.AuthAPITokenPrefix + hex.EncodeToString(bytes)
+
+	created, err := ats.rpts.APITok.Create(ctx, payload, token[:apiTokenDisplayLength], hashAPIToken(token))
+	if err != nil {
+		return models.CreateAPITokenResponseModel{}, err
+	}
+
+	return models.CreateAPITokenResponseModel{
+		APITokenModel: created,
+		Token:         token,
+	}, nil
+}
+
+func (ats APITokenService) Delete(ctx context.Context, id int64) error {
+	return ats.rpts.APITok.Delete(ctx, id)
+}
+
+// hashAPIToken returns the hex SHA-256 digest stored in place of the token
+// Tokens carry 256 bits of entropy, so a fast unsalted hash is sufficient
+func hashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/repositories"
+)
+
+// recordAudit appends a change to the audit log through the given repositories
+// Pass the transaction-bound repositories when the change runs in a database transaction, so the entry commits with it
+func recordAudit(ctx context.Context, rpts repositories.RootRepository, entityType string, entityID int64, operation string, before, after any) error {
+	return rpts.Aud.Create(ctx, models.CreateAuditLogModel{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Operation:  operation,
+		Before:     before,
+		After:      after,
+	})
+}
+
+type AuditService struct {
+	rpts *repositories.RootRepository
+}
+
+func NewAuditService(rpts *repositories.RootRepository) AuditService {
+	return AuditService{rpts}
+}
+
+// GetPaged lists audit entries; it is not cached since every mutation appends to it
+func (as AuditService) GetPaged(ctx context.Context, query models.AuditLogsSearchModel) (models.AuditLogsPagedModel, error) {
+	return as.rpts.Aud.GetPaged(ctx, query)
+}
@@ -1,32 +1,522 @@
 package services
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
 	"github.com/danielgtaylor/huma/v2"
-	"github.com/dimasbaguspm/spenicle-api/internal/configs"
+	"github.com/dimasbaguspm/spenicle-api/clients"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
 	"github.com/dimasbaguspm/spenicle-api/internal/repositories"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/crypto/bcrypt"
 )
 
 type AuthService struct {
-	rpts *repositories.RootRepository
+	rpts     *repositories.RootRepository
+	rdb      *redis.Client
+	throttle *common.LoginThrottleManager
+	oidc     *clients.OIDCClient
 }
 
-func NewAuthService(rpts *repositories.RootRepository) AuthService {
+// dummyPasswordHash is compared against when a username does not exist,
+// so an unknown username takes as long to reject as a wrong password
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("spenicle-unknown-user"), bcrypt.DefaultCost)
+
+// oidcLoginState is kept in Redis between the authorize redirect and the callback, keyed by the state value
+type oidcLoginState struct {
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"codeVerifier"`
+}
+
+func NewAuthService(rpts *repositories.RootRepository, rdb *redis.Client) AuthService {
 	return AuthService{
 		rpts,
+		rdb,
+		common.NewLoginThrottleManager(rdb),
+		clients.NewOIDCClient(),
+	}
+}
+
+// Login checks the credentials and issues a token pair, or a challenge when two-factor is enabled
+// Failed attempts are throttled per username and client IP; a locked login returns 429 with the locked-until time
+func (s AuthService) Login(ctx context.Context, p models.LoginRequestModel) (models.LoginResponseModel, error) {
+	ip := common.GetClientInfo(ctx).IP
+	if err := s.throttle.Check(ctx, p.Username, ip); err != nil {
+		return models.LoginResponseModel{}, err
+	}
+
+	user, err := s.rpts.Usr.GetCredentials(ctx, p.Username)
+	if err != nil {
+		var statusErr huma.StatusError
+		if errors.As(err, &statusErr) && statusErr.GetStatus() == http.StatusNotFound {
+			bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(p.Password))
+			return models.LoginResponseModel{}, s.loginFailed(ctx, p.Username, ip)
+		}
+		return models.LoginResponseModel{}, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(p.Password)); err != nil {
+		return models.LoginResponseModel{}, s.loginFailed(ctx, p.Username, ip)
+	}
+
+	state, err := s.rpts.Usr.GetTOTP(common.WithUserID(ctx, user.ID))
+	if err != nil {
+		return models.LoginResponseModel{}, err
+	}
+	if state.EnabledAt != nil {
+		// The failure count is only reset once the second factor is verified, so wrong codes
+		// across fresh challenges keep counting towards the same lockout
+		challenge, err := s.rpts.Ath.Challenge(user.ID)
+		if err != nil {
+			return models.LoginResponseModel{}, err
+		}
+		return models.LoginResponseModel{
+			TwoFactorRequired: true,
+			ChallengeToken:    challenge,
+		}, nil
+	}
+
+	s.throttle.RecordSuccess(ctx, p.Username, ip)
+	return s.startSession(ctx, user.ID, p.DeviceName)
+}
+
+// loginFailed records a failed attempt and returns the error for the client
+// Unknown usernames are counted the same way so the response does not reveal which usernames exist
+func (s AuthService) loginFailed(ctx context.Context, username string, ip string) error {
+	if err := s.throttle.RecordFailure(ctx, username, ip); err != nil {
+		return err
+	}
+	return huma.Error401Unauthorized("Invalid credentials")
+}
+
+// VerifyLogin completes a two-factor login by exchanging a challenge token and a code for a token pair
+// Each challenge allows a limited number of wrong codes and can only be exchanged once;
+// wrong codes also count towards the login throttle of the user, so fresh challenges do not allow more guesses
+func (s AuthService) VerifyLogin(ctx context.Context, p models.LoginVerifyRequestModel) (models.LoginResponseModel, error) {
+	claims, err := s.validate(ctx, p.ChallengeToken, constants.AuthTokenTypeChallenge)
+	if err != nil {
+		return models.LoginResponseModel{}, err
+	}
+
+	user, err := s.rpts.Usr.GetDetail(ctx, claims.UserID)
+	if err != nil {
+		return models.LoginResponseModel{}, err
+	}
+	ip := common.GetClientInfo(ctx).IP
+	if err := s.throttle.Check(ctx, user.Username, ip); err != nil {
+		return models.LoginResponseModel{}, err
+	}
+
+	attemptsKey := constants.AuthChallengeAttemptsKeyPrefix + claims.TokenID
+	attempts, err := s.rdb.Incr(ctx, attemptsKey).Result()
+	if err != nil {
+		observability.RecordError("redis")
+		return models.LoginResponseModel{}, huma.Error500InternalServerError("Unable to verify challenge", err)
+	}
+	if attempts == 1 {
+		s.rdb.Expire(ctx, attemptsKey, constants.AuthChallengeTokenTTL)
+	}
+	if attempts > constants.AuthChallengeMaxAttempts {
+		s.revoke(ctx, claims.TokenID, time.Until(claims.ExpiresAt))
+		return models.LoginResponseModel{}, huma.Error401Unauthorized("Too many invalid codes, please log in again")
+	}
+
+	userCtx := common.WithUserID(ctx, claims.UserID)
+	state, err := s.rpts.Usr.GetTOTP(userCtx)
+	if err != nil {
+		return models.LoginResponseModel{}, err
+	}
+
+	ok, err := verifySecondFactor(userCtx, s.rpts, state, p.Code, p.RecoveryCode)
+	if err != nil {
+		return models.LoginResponseModel{}, err
+	}
+	if !ok {
+		if err := s.throttle.RecordFailure(ctx, user.Username, ip); err != nil {
+			return models.LoginResponseModel{}, err
+		}
+		return models.LoginResponseModel{}, huma.Error401Unauthorized("Invalid two-factor code")
+	}
+
+	revoked, err := s.revoke(ctx, claims.TokenID, time.Until(claims.ExpiresAt))
+	if err != nil {
+		return models.LoginResponseModel{}, err
+	}
+	if !revoked {
+		return models.LoginResponseModel{}, huma.Error401Unauthorized("Challenge has already been used")
 	}
+	s.throttle.RecordSuccess(ctx, user.Username, ip)
+
+	return s.startSession(ctx, claims.UserID, p.DeviceName)
+}
+
+// OIDCAuthorize starts an OpenID Connect login: it returns the identity provider URL carrying a fresh state,
+// nonce and PKCE challenge, and keeps the matching verifier server side until the callback
+func (s AuthService) OIDCAuthorize(ctx context.Context) (models.OIDCAuthorizeResponseModel, error) {
+	if !s.oidc.Enabled() {
+		return models.OIDCAuthorizeResponseModel{}, huma.Error404NotFound("OpenID Connect login is not configured")
+	}
+
+	values := make([]string, 3)
+	for i := range values {
+		bytes := make([]byte, 32)
+		if _, err := rand.Read(bytes); err != nil {
+			return models.OIDCAuthorizeResponseModel{}, huma.Error500InternalServerError("Unable to start login", err)
+		}
+		values[i] = base64.RawURLEncoding.EncodeToString(bytes)
+	}
+	state, login := values[0], oidcLoginState{Nonce: values[1], CodeVerifier: values[2]}
+
+	authorizationURL, err := s.oidc.AuthorizationURL(ctx, state, login.Nonce, login.CodeVerifier)
+	if err != nil {
+		return models.OIDCAuthorizeResponseModel{}, huma.Error502BadGateway("Unable to reach the identity provider", err)
+	}
+
+	data, err := json.Marshal(login)
+	if err != nil {
+		return models.OIDCAuthorizeResponseModel{}, huma.Error500InternalServerError("Unable to start login", err)
+	}
+	if err := s.rdb.Set(ctx, constants.AuthOIDCStateKeyPrefix+state, data, constants.AuthOIDCStateTTL).Err(); err != nil {
+		observability.RecordError("redis")
+		return models.OIDCAuthorizeResponseModel{}, huma.Error500InternalServerError("Unable to start login", err)
+	}
+
+	return models.OIDCAuthorizeResponseModel{
+		AuthorizationURL: authorizationURL,
+		State:            state,
+	}, nil
+}
+
+// OIDCCallback completes an OpenID Connect login and issues a token pair
+// A state can only be used once. The issuer's subject is looked up first; on the first login the configured
+// claim (email by default) must match a local username, and the subject is then linked to that user.
+// Two-factor is left to the identity provider, so no local challenge is issued here
+func (s AuthService) OIDCCallback(ctx context.Context, p models.OIDCCallbackRequestModel) (models.LoginResponseModel, error) {
+	if !s.oidc.Enabled() {
+		return models.LoginResponseModel{}, huma.Error404NotFound("OpenID Connect login is not configured")
+	}
+
+	data, err := s.rdb.GetDel(ctx, constants.AuthOIDCStateKeyPrefix+p.State).Result()
+	if err == redis.Nil {
+		return models.LoginResponseModel{}, huma.Error401Unauthorized("Login state is invalid or has expired")
+	}
+	if err != nil {
+		observability.RecordError("redis")
+		return models.LoginResponseModel{}, huma.Error500InternalServerError("Unable to verify login state", err)
+	}
+
+	var login oidcLoginState
+	if err := json.Unmarshal([]byte(data), &login); err != nil {
+		return models.LoginResponseModel{}, huma.Error500InternalServerError("Unable to verify login state", err)
+	}
+
+	identity, err := s.oidc.Exchange(ctx, p.Code, login.CodeVerifier, login.Nonce)
+	if err != nil {
+		observability.GetLogger(ctx).Warn("oidc exchange failed", "error", err)
+		return models.LoginResponseModel{}, huma.Error401Unauthorized("Unable to verify the identity provider response")
+	}
+
+	userID, err := s.rpts.Usr.GetIDByIdentity(ctx, s.oidc.Issuer(), identity.Subject)
+	if err != nil {
+		var statusErr huma.StatusError
+		if !errors.As(err, &statusErr) || statusErr.GetStatus() != http.StatusNotFound {
+			return models.LoginResponseModel{}, err
+		}
+
+		userID, err = s.matchOIDCUser(ctx, identity)
+		if err != nil {
+			return models.LoginResponseModel{}, err
+		}
+		if err := s.rpts.Usr.LinkIdentity(ctx, userID, s.oidc.Issuer(), identity.Subject, identity.Email); err != nil {
+			return models.LoginResponseModel{}, err
+		}
+	}
+
+	return s.startSession(ctx, userID, p.DeviceName)
 }
 
-func (s AuthService) Login(p models.LoginRequestModel) (models.LoginResponseModel, error) {
-	env := configs.NewEnvironment()
+// matchOIDCUser finds the local user whose username equals the configured claim of an unlinked identity
+func (s AuthService) matchOIDCUser(ctx context.Context, identity clients.OIDCIdentity) (int64, error) {
+	claim := s.oidc.UsernameClaim()
+	username := identity.Claim(claim)
+	if username == "" {
+		return 0, huma.Error403Forbidden(fmt.Sprintf("Identity provider did not return the %s claim", claim))
+	}
+	if claim == "email" && !identity.EmailVerified() {
+		return 0, huma.Error403Forbidden("Email address is not verified by the identity provider")
+	}
+
+	userID, err := s.rpts.Usr.GetIDByUsername(ctx, username)
+	if err != nil {
+		var statusErr huma.StatusError
+		if errors.As(err, &statusErr) && statusErr.GetStatus() == http.StatusNotFound {
+			return 0, huma.Error403Forbidden("No user matches this identity")
+		}
+		return 0, err
+	}
+	return userID, nil
+}
 
-	if p.Username != env.AdminUsername || p.Password != env.AdminPassword {
-		return models.LoginResponseModel{}, huma.Error401Unauthorized("Invalid credentials")
+// startSession records the device logging in and issues a token pair bound to the new session
+func (s AuthService) startSession(ctx context.Context, userID int64, deviceName string) (models.LoginResponseModel, error) {
+	client := common.GetClientInfo(ctx)
+	sessionID, err := s.rpts.Sess.Create(common.WithUserID(ctx, userID), models.CreateSessionModel{
+		DeviceName: deviceName,
+		UserAgent:  client.UserAgent,
+		IPAddress:  client.IP,
+		ExpiresAt:  time.Now().Add(constants.AuthRefreshTokenTTL),
+	})
+	if err != nil {
+		return models.LoginResponseModel{}, err
 	}
 
-	return s.rpts.Ath.Login(p)
+	return s.rpts.Ath.Login(userID, sessionID)
 }
 
-func (s AuthService) Refresh(p models.RefreshRequestModel) (models.RefreshResponseModel, error) {
-	return s.rpts.Ath.Refresh(p)
+// Refresh rotates a refresh token: the submitted token is revoked and a new pair is issued
+// Revocation uses SETNX so a refresh token replayed concurrently only succeeds once
+func (s AuthService) Refresh(ctx context.Context, p models.RefreshRequestModel) (models.RefreshResponseModel, error) {
+	claims, err := s.validate(ctx, p.RefreshToken, constants.AuthTokenTypeRefresh)
+	if err != nil {
+		return models.RefreshResponseModel{}, err
+	}
+
+	revoked, err := s.revoke(ctx, claims.TokenID, time.Until(claims.ExpiresAt))
+	if err != nil {
+		return models.RefreshResponseModel{}, err
+	}
+	if !revoked {
+		return models.RefreshResponseModel{}, huma.Error401Unauthorized("Refresh token has already been used")
+	}
+
+	// The new refresh token keeps the session alive for another full refresh token lifetime
+	if claims.SessionID != 0 {
+		if err := s.rpts.Sess.Extend(common.WithUserID(ctx, claims.UserID), claims.SessionID, time.Now().Add(constants.AuthRefreshTokenTTL)); err != nil {
+			return models.RefreshResponseModel{}, err
+		}
+	}
+
+	pair, err := s.rpts.Ath.Login(claims.UserID, claims.SessionID)
+	if err != nil {
+		return models.RefreshResponseModel{}, err
+	}
+
+	return models.RefreshResponseModel{
+		AccessToken:  pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+	}, nil
+}
+
+// Authenticate validates an access token and checks it against the revocation list
+func (s AuthService) Authenticate(ctx context.Context, token string) (models.AuthTokenClaimsModel, error) {
+	return s.validate(ctx, token, constants.AuthTokenTypeAccess)
+}
+
+// AuthenticateAPIToken validates a personal access token and returns its owner and scopes
+func (s AuthService) AuthenticateAPIToken(ctx context.Context, token string) (models.APITokenAuthModel, error) {
+	return s.rpts.APITok.Authenticate(ctx, hashAPIToken(token))
+}
+
+// Logout revokes the access token of the current request and, when given, the matching refresh token
+// The current session ends too, which also invalidates its refresh token
+func (s AuthService) Logout(ctx context.Context, p models.LogoutRequestModel) error {
+	if _, err := s.revoke(ctx, common.GetTokenID(ctx), constants.AuthAccessTokenTTL); err != nil {
+		return err
+	}
+
+	if sessionID := common.GetSessionID(ctx); sessionID != 0 {
+		if err := s.RevokeSession(ctx, sessionID); err != nil {
+			return err
+		}
+	}
+
+	if p.RefreshToken == "" {
+		return nil
+	}
+
+	claims, err := s.rpts.Ath.ParseToken(p.RefreshToken, constants.AuthTokenTypeRefresh)
+	if err != nil {
+		return err
+	}
+	if claims.UserID != common.GetUserID(ctx) {
+		return huma.Error403Forbidden("Refresh token belongs to another user")
+	}
+
+	_, err = s.revoke(ctx, claims.TokenID, time.Until(claims.ExpiresAt))
+	return err
+}
+
+// LogoutAll revokes every token issued to the current user up to now
+// Each session is revoked on its own too, which covers tokens issued earlier in the same second as the logout
+func (s AuthService) LogoutAll(ctx context.Context) error {
+	key := fmt.Sprintf("%s%d", constants.AuthRevokedBeforeKeyPrefix, common.GetUserID(ctx))
+	if err := s.rdb.Set(ctx, key, time.Now().Unix(), constants.AuthRefreshTokenTTL).Err(); err != nil {
+		observability.RecordError("redis")
+		return huma.Error500InternalServerError("Unable to revoke tokens", err)
+	}
+
+	revoked, err := s.rpts.Sess.RevokeAll(ctx)
+	if err != nil {
+		return err
+	}
+	for id, expiresAt := range revoked {
+		ttl := time.Until(expiresAt)
+		if ttl <= 0 {
+			ttl = time.Second
+		}
+		if err := s.rdb.Set(ctx, fmt.Sprintf("%s%d", constants.AuthRevokedSessionKeyPrefix, id), 1, ttl).Err(); err != nil {
+			observability.RecordError("redis")
+			return huma.Error500InternalServerError("Unable to revoke session", err)
+		}
+	}
+	return nil
+}
+
+// ChangePassword replaces the password of the current user after checking the current one
+// Every token issued so far is revoked, including the caller's and all personal access tokens, so all devices have to log in again
+// Wrong current passwords count towards the same throttle as failed logins
+func (s AuthService) ChangePassword(ctx context.Context, p models.ChangePasswordRequestModel) error {
+	user, err := s.rpts.Usr.GetDetail(ctx, common.GetUserID(ctx))
+	if err != nil {
+		return err
+	}
+
+	ip := common.GetClientInfo(ctx).IP
+	if err := s.throttle.Check(ctx, user.Username, ip); err != nil {
+		return err
+	}
+
+	credentials, err := s.rpts.Usr.GetCredentials(ctx, user.Username)
+	if err != nil {
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(credentials.PasswordHash), []byte(p.CurrentPassword)); err != nil {
+		if err := s.throttle.RecordFailure(ctx, user.Username, ip); err != nil {
+			return err
+		}
+		return huma.Error403Forbidden("Current password is incorrect")
+	}
+	s.throttle.RecordSuccess(ctx, user.Username, ip)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(p.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return huma.Error500InternalServerError("Unable to hash password", err)
+	}
+	if err := s.rpts.Usr.UpdatePassword(ctx, string(hash)); err != nil {
+		return err
+	}
+	if err := s.rpts.APITok.DeleteAll(ctx); err != nil {
+		return err
+	}
+
+	return s.LogoutAll(ctx)
+}
+
+// GetSessions lists the devices currently holding a token of the current user
+func (s AuthService) GetSessions(ctx context.Context) (models.SessionsListModel, error) {
+	return s.rpts.Sess.GetList(ctx)
+}
+
+// RevokeSession ends a session of the current user; its access and refresh tokens stop working immediately
+func (s AuthService) RevokeSession(ctx context.Context, id int64) error {
+	expiresAt, err := s.rpts.Sess.Revoke(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		ttl = time.Second
+	}
+	if err := s.rdb.Set(ctx, fmt.Sprintf("%s%d", constants.AuthRevokedSessionKeyPrefix, id), 1, ttl).Err(); err != nil {
+		observability.RecordError("redis")
+		return huma.Error500InternalServerError("Unable to revoke session", err)
+	}
+	return nil
+}
+
+// TouchSession records that the current session is in use, and from which IP address
+// Writes are spaced by AuthSessionSeenInterval so busy clients do not update the row on every request
+func (s AuthService) TouchSession(ctx context.Context) error {
+	sessionID := common.GetSessionID(ctx)
+	if sessionID == 0 {
+		return nil
+	}
+
+	due, err := s.rdb.SetNX(ctx, fmt.Sprintf("%s%d", constants.AuthSessionSeenKeyPrefix, sessionID), 1, constants.AuthSessionSeenInterval).Result()
+	if err != nil {
+		observability.RecordError("redis")
+		return huma.Error500InternalServerError("Unable to update session", err)
+	}
+	if !due {
+		return nil
+	}
+
+	return s.rpts.Sess.Touch(ctx, sessionID, common.GetClientInfo(ctx).IP)
+}
+
+func (s AuthService) validate(ctx context.Context, token string, tokenType string) (models.AuthTokenClaimsModel, error) {
+	claims, err := s.rpts.Ath.ParseToken(token, tokenType)
+	if err != nil {
+		return models.AuthTokenClaimsModel{}, err
+	}
+
+	revokedKeys := []string{constants.AuthRevokedTokenKeyPrefix + claims.TokenID}
+	if claims.SessionID != 0 {
+		revokedKeys = append(revokedKeys, fmt.Sprintf("%s%d", constants.AuthRevokedSessionKeyPrefix, claims.SessionID))
+	}
+
+	exists, err := s.rdb.Exists(ctx, revokedKeys...).Result()
+	if err != nil {
+		observability.RecordError("redis")
+		return models.AuthTokenClaimsModel{}, huma.Error500InternalServerError("Unable to check token revocation", err)
+	}
+	if exists > 0 {
+		return models.AuthTokenClaimsModel{}, huma.Error401Unauthorized("Token has been revoked")
+	}
+
+	revokedBefore, err := s.rdb.Get(ctx, fmt.Sprintf("%s%d", constants.AuthRevokedBeforeKeyPrefix, claims.UserID)).Result()
+	if err != nil && err != redis.Nil {
+		observability.RecordError("redis")
+		return models.AuthTokenClaimsModel{}, huma.Error500InternalServerError("Unable to check token revocation", err)
+	}
+	if err == nil {
+		// iat has second precision, so tokens issued in the same second as the logout stay valid and a login right after it works
+		// Older tokens of that second belong to sessions revoked along with the logout, so they are rejected by the session check
+		revokedAt, _ := strconv.ParseInt(revokedBefore, 10, 64)
+		if claims.IssuedAt.Unix() < revokedAt {
+			return models.AuthTokenClaimsModel{}, huma.Error401Unauthorized("Token has been revoked")
+		}
+	}
+
+	return claims, nil
+}
+
+// revoke adds a token ID to the revocation list until the token would have expired anyway
+// Returns false when the token was already revoked
+func (s AuthService) revoke(ctx context.Context, tokenID string, ttl time.Duration) (bool, error) {
+	if tokenID == "" {
+		return false, nil
+	}
+	if ttl <= 0 {
+		ttl = time.Second
+	}
+
+	ok, err := s.rdb.SetNX(ctx, constants.AuthRevokedTokenKeyPrefix+tokenID, 1, ttl).Result()
+	if err != nil {
+		observability.RecordError("redis")
+		return false, huma.Error500InternalServerError("Unable to revoke token", err)
+	}
+	return ok, nil
 }
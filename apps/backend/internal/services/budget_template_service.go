@@ -24,14 +24,14 @@ func NewBudgetTemplateService(rpts *repositories.RootRepository, rdb *redis.Clie
 }
 
 func (bts BudgetTemplateService) GetPaged(ctx context.Context, p models.BudgetTemplatesSearchModel) (models.BudgetTemplatesPagedModel, error) {
-	cacheKey := common.BuildPagedCacheKey(constants.EntityBudgetTemplate, p)
+	cacheKey := common.BuildPagedCacheKey(ctx, constants.EntityBudgetTemplate, p)
 	return common.FetchWithCache(ctx, bts.rdb, cacheKey, constants.CacheTTLPaged, func(ctx context.Context) (models.BudgetTemplatesPagedModel, error) {
 		return bts.Rpts.BudgTem.GetPaged(ctx, p)
 	}, "budget_template")
 }
 
 func (bts BudgetTemplateService) GetDetail(ctx context.Context, id int64) (models.BudgetTemplateModel, error) {
-	cacheKey := common.BuildDetailCacheKey(constants.EntityBudgetTemplate, id)
+	cacheKey := common.BuildDetailCacheKey(ctx, constants.EntityBudgetTemplate, id)
 	return common.FetchWithCache(ctx, bts.rdb, cacheKey, constants.CacheTTLDetail, func(ctx context.Context) (models.BudgetTemplateModel, error) {
 		return bts.Rpts.BudgTem.GetDetail(ctx, id)
 	}, "budget_template")
@@ -80,11 +80,26 @@ func (bts BudgetTemplateService) Create(ctx context.Context, p models.CreateBudg
 		return models.BudgetTemplateModel{}, err
 	}
 
-	template, err := bts.Rpts.BudgTem.Create(ctx, p)
+	tx, err := bts.Rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.BudgetTemplateModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := bts.Rpts.WithTx(ctx, tx)
+	template, err := rootTx.BudgTem.Create(ctx, p)
 	if err != nil {
 		return template, err
 	}
 
+	if err := recordAudit(ctx, rootTx, constants.EntityBudgetTemplate, template.ID, constants.AuditOperationCreate, nil, template); err != nil {
+		return models.BudgetTemplateModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.BudgetTemplateModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
 	if err := common.InvalidateCacheForEntity(ctx, bts.rdb, constants.EntityBudgetTemplate, map[string]interface{}{"templateId": template.ID}); err != nil {
 		observability.NewLogger("service", "BudgetTemplateService").Warn("cache invalidation failed", "error", err)
 	}
@@ -100,17 +115,46 @@ func (bts BudgetTemplateService) Create(ctx context.Context, p models.CreateBudg
 	return template, nil
 }
 
-func (bts BudgetTemplateService) Update(ctx context.Context, id int64, p models.UpdateBudgetTemplateModel) (models.BudgetTemplateModel, error) {
+// Update changes a budget template; with ifMatch set, only while its ETag still matches
+func (bts BudgetTemplateService) Update(ctx context.Context, id int64, p models.UpdateBudgetTemplateModel, ifMatch string) (models.BudgetTemplateModel, error) {
 	// Strict validation: only allow updating name, note, active status, and amountLimit
 	if p.Name == nil && p.Note == nil && p.Active == nil && p.AmountLimit == nil {
 		return models.BudgetTemplateModel{}, huma.Error400BadRequest("At least one of name, note, active, or amountLimit must be provided")
 	}
 
-	template, err := bts.Rpts.BudgTem.Update(ctx, id, p)
+	if _, err := requireAccess(ctx, bts.Rpts, constants.EntityBudgetTemplate, id, constants.ShareRoleOwner, constants.ShareRoleEditor); err != nil {
+		return models.BudgetTemplateModel{}, err
+	}
+
+	tx, err := bts.Rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.BudgetTemplateModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := bts.Rpts.WithTx(ctx, tx)
+	existing, err := rootTx.BudgTem.GetDetailForUpdate(ctx, id)
+	if err != nil {
+		return models.BudgetTemplateModel{}, err
+	}
+
+	if err := common.RequireETag(ifMatch, existing.ETag, existing); err != nil {
+		return models.BudgetTemplateModel{}, err
+	}
+
+	template, err := rootTx.BudgTem.Update(ctx, id, p)
 	if err != nil {
 		return template, err
 	}
 
+	if err := recordAudit(ctx, rootTx, constants.EntityBudgetTemplate, id, constants.AuditOperationUpdate, existing, template); err != nil {
+		return models.BudgetTemplateModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.BudgetTemplateModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
 	if err := common.InvalidateCacheForEntity(ctx, bts.rdb, constants.EntityBudgetTemplate, map[string]interface{}{"templateId": id}); err != nil {
 		observability.NewLogger("service", "BudgetTemplateService").Warn("cache invalidation failed", "error", err)
 	}
@@ -123,9 +167,14 @@ func (bts BudgetTemplateService) Delete(ctx context.Context, id int64) error {
 }
 
 func (bts BudgetTemplateService) GetRelatedBudgets(ctx context.Context, templateID int64, query models.BudgetTemplateRelatedBudgetsSearchModel) (models.BudgetsPagedModel, error) {
+	ownerCtx, err := bts.asTemplateOwner(ctx, templateID, constants.ShareRoleOwner, constants.ShareRoleEditor, constants.ShareRoleViewer)
+	if err != nil {
+		return models.BudgetsPagedModel{}, err
+	}
+
 	// Build cache key for related budgets list - scoped by template ID in entity name
-	cacheKey := common.BuildPagedCacheKey(fmt.Sprintf("%s:%d:budgets", constants.EntityBudgetTemplate, templateID), query)
-	return common.FetchWithCache(ctx, bts.rdb, cacheKey, constants.CacheTTLPaged, func(ctx context.Context) (models.BudgetsPagedModel, error) {
+	cacheKey := common.BuildPagedCacheKey(ctx, fmt.Sprintf("%s:%d:budgets", constants.EntityBudgetTemplate, templateID), query)
+	return common.FetchWithCache(ownerCtx, bts.rdb, cacheKey, constants.CacheTTLPaged, func(ctx context.Context) (models.BudgetsPagedModel, error) {
 		ids, err := bts.Rpts.BudgTem.GetRelatedBudgets(ctx, templateID, query)
 		if err != nil {
 			return models.BudgetsPagedModel{}, err
@@ -158,11 +207,49 @@ func (bts BudgetTemplateService) GetRelatedBudgets(ctx context.Context, template
 	}, "budget_template_related")
 }
 
+// GetTemplateBudget returns a budget generated from a template the current user owns or has been shared
+func (bts BudgetTemplateService) GetTemplateBudget(ctx context.Context, templateID, budgetID int64) (models.BudgetModel, error) {
+	ownerCtx, err := bts.asTemplateOwner(ctx, templateID, constants.ShareRoleOwner, constants.ShareRoleEditor, constants.ShareRoleViewer)
+	if err != nil {
+		return models.BudgetModel{}, err
+	}
+
+	if err := bts.ValidateBudgetBelongsToTemplate(ownerCtx, templateID, budgetID); err != nil {
+		return models.BudgetModel{}, err
+	}
+
+	return bts.GetBudgetDetail(ownerCtx, budgetID)
+}
+
+// UpdateTemplateBudget updates a budget generated from a template the current user owns or may edit
+func (bts BudgetTemplateService) UpdateTemplateBudget(ctx context.Context, templateID, budgetID int64, p models.UpdateBudgetRequestModel) (models.BudgetModel, error) {
+	ownerCtx, err := bts.asTemplateOwner(ctx, templateID, constants.ShareRoleOwner, constants.ShareRoleEditor)
+	if err != nil {
+		return models.BudgetModel{}, err
+	}
+
+	if err := bts.ValidateBudgetBelongsToTemplate(ownerCtx, templateID, budgetID); err != nil {
+		return models.BudgetModel{}, err
+	}
+
+	return bts.UpdateBudget(ownerCtx, budgetID, p)
+}
+
+// asTemplateOwner checks the current user's role on a template and returns a context acting as the template owner
+// Budgets generated from a shared template belong to its owner, like the ones created by the worker
+func (bts BudgetTemplateService) asTemplateOwner(ctx context.Context, templateID int64, roles ...string) (context.Context, error) {
+	access, err := requireAccess(ctx, bts.Rpts, constants.EntityBudgetTemplate, templateID, roles...)
+	if err != nil {
+		return ctx, err
+	}
+	return common.WithUserID(ctx, access.OwnerID), nil
+}
+
 // Budget-related methods (internal use - called by worker and template operations)
 
 // GetBudgetsPaged retrieves generated budgets with optional filtering (internal use)
 func (bts BudgetTemplateService) GetBudgetsPaged(ctx context.Context, p models.BudgetsSearchModel) (models.BudgetsPagedModel, error) {
-	cacheKey := common.BuildPagedCacheKey(constants.EntityBudget, p)
+	cacheKey := common.BuildPagedCacheKey(ctx, constants.EntityBudget, p)
 	return common.FetchWithCache(ctx, bts.rdb, cacheKey, constants.CacheTTLPaged, func(ctx context.Context) (models.BudgetsPagedModel, error) {
 		return bts.Rpts.BudgTem.GetBudgetsPaged(ctx, p)
 	}, "budget")
@@ -170,50 +257,60 @@ func (bts BudgetTemplateService) GetBudgetsPaged(ctx context.Context, p models.B
 
 // GetBudgetDetail retrieves a single generated budget with calculated actual amount (internal use)
 func (bts BudgetTemplateService) GetBudgetDetail(ctx context.Context, id int64) (models.BudgetModel, error) {
-	cacheKey := common.BuildDetailCacheKey(constants.EntityBudget, id)
+	cacheKey := common.BuildDetailCacheKey(ctx, constants.EntityBudget, id)
 	return common.FetchWithCache(ctx, bts.rdb, cacheKey, constants.CacheTTLDetail, func(ctx context.Context) (models.BudgetModel, error) {
 		return bts.Rpts.BudgTem.GetBudgetDetail(ctx, id)
 	}, "budget")
 }
 
-// CreateBudget generates a new budget from a template (internal use - called by worker)
-func (bts BudgetTemplateService) CreateBudget(ctx context.Context, p models.CreateBudgetModel) (models.BudgetModel, error) {
-	budget, err := bts.Rpts.BudgTem.CreateBudget(ctx, p)
+// createBudget records a new budget generated from a template along with its audit entry
+func (bts BudgetTemplateService) createBudget(ctx context.Context, rootTx repositories.RootRepository, p models.CreateBudgetModel) (models.BudgetModel, error) {
+	budget, err := rootTx.BudgTem.CreateBudget(ctx, p)
 	if err != nil {
 		return budget, err
 	}
 
-	// Invalidate budget and related caches
-	common.InvalidateCacheForEntity(ctx, bts.rdb, constants.EntityBudget, map[string]interface{}{})
-	if p.TemplateID != nil {
-		common.InvalidateCacheForEntity(ctx, bts.rdb, constants.EntityBudgetTemplate, map[string]interface{}{"templateId": *p.TemplateID})
+	if err := recordAudit(ctx, rootTx, constants.EntityBudget, budget.ID, constants.AuditOperationCreate, nil, budget); err != nil {
+		return models.BudgetModel{}, err
 	}
 
 	return budget, nil
 }
 
-// DeactivateExistingActiveBudgets deactivates active budgets for a given account/category/period (internal use - called by worker before creating new budget)
-func (bts BudgetTemplateService) DeactivateExistingActiveBudgets(ctx context.Context, accountID, categoryID *int64, periodType string) error {
-	err := bts.Rpts.BudgTem.DeactivateExistingActiveBudgets(ctx, accountID, categoryID, periodType)
+// deactivateExistingActiveBudgets deactivates active budgets for a given account/category/period before a new one is created
+func (bts BudgetTemplateService) deactivateExistingActiveBudgets(ctx context.Context, rootTx repositories.RootRepository, accountID, categoryID *int64, periodType string) error {
+	ids, err := rootTx.BudgTem.DeactivateExistingActiveBudgets(ctx, accountID, categoryID, periodType)
 	if err != nil {
 		return err
 	}
 
-	// Invalidate budget and related account/category caches
-	common.InvalidateCacheForEntity(ctx, bts.rdb, constants.EntityBudget, map[string]interface{}{})
+	for _, id := range ids {
+		if err := recordAudit(ctx, rootTx, constants.EntityBudget, id, constants.AuditOperationUpdate, map[string]string{"status": "active"}, map[string]string{"status": "inactive"}); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
 // GenerateBudgetFromTemplate creates a budget from a template, handling the full lifecycle:
 // deactivate existing budgets, create the new budget, create the relation, update execution timestamps, and invalidate caches.
+// Every step and its audit entry is written in one transaction, so a failed run leaves nothing behind for the worker to retry
 func (bts BudgetTemplateService) GenerateBudgetFromTemplate(ctx context.Context, template models.BudgetTemplateModel) (models.BudgetModel, error) {
 	periodStart, periodEnd := CalculateBudgetPeriod(template.Recurrence)
 	periodType := CalculatePeriodType(periodStart, periodEnd)
 
+	tx, err := bts.Rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.BudgetModel{}, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := bts.Rpts.WithTx(ctx, tx)
+
 	// For recurring templates, deactivate any existing active budgets for the same account/category/period type
 	if template.Recurrence != "none" {
-		if err := bts.DeactivateExistingActiveBudgets(ctx, template.AccountID, template.CategoryID, periodType); err != nil {
+		if err := bts.deactivateExistingActiveBudgets(ctx, rootTx, template.AccountID, template.CategoryID, periodType); err != nil {
 			return models.BudgetModel{}, fmt.Errorf("failed to deactivate existing budgets: %w", err)
 		}
 	}
@@ -229,22 +326,35 @@ func (bts BudgetTemplateService) GenerateBudgetFromTemplate(ctx context.Context,
 		Note:        template.Note,
 	}
 
-	budget, err := bts.CreateBudget(ctx, budgetRequest)
+	budget, err := bts.createBudget(ctx, rootTx, budgetRequest)
 	if err != nil {
 		return models.BudgetModel{}, fmt.Errorf("failed to create budget: %w", err)
 	}
 
 	// Create budget-template relation
-	if err := bts.Rpts.BudgTem.CreateRelation(ctx, budget.ID, template.ID); err != nil {
-		return budget, fmt.Errorf("failed to create relation: %w", err)
+	if err := rootTx.BudgTem.CreateRelation(ctx, budget.ID, template.ID); err != nil {
+		return models.BudgetModel{}, fmt.Errorf("failed to create relation: %w", err)
 	}
 
 	// Update last_executed_at and next_run_at
-	if err := bts.Rpts.BudgTem.UpdateLastExecuted(ctx, template.ID); err != nil {
-		return budget, fmt.Errorf("failed to update execution time: %w", err)
+	if err := rootTx.BudgTem.UpdateLastExecuted(ctx, template.ID); err != nil {
+		return models.BudgetModel{}, fmt.Errorf("failed to update execution time: %w", err)
+	}
+
+	executed, err := rootTx.BudgTem.GetDetail(ctx, template.ID)
+	if err != nil {
+		return models.BudgetModel{}, fmt.Errorf("failed to read executed template: %w", err)
+	}
+	if err := recordAudit(ctx, rootTx, constants.EntityBudgetTemplate, template.ID, constants.AuditOperationUpdate, template, executed); err != nil {
+		return models.BudgetModel{}, fmt.Errorf("failed to record audit: %w", err)
 	}
 
-	// Invalidate template caches after updating execution timestamps
+	if err := tx.Commit(ctx); err != nil {
+		return models.BudgetModel{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	// Invalidate budget and template caches after the budget and execution timestamps are stored
+	common.InvalidateCacheForEntity(ctx, bts.rdb, constants.EntityBudget, map[string]interface{}{})
 	common.InvalidateCacheForEntity(ctx, bts.rdb, constants.EntityBudgetTemplate, map[string]interface{}{"templateId": template.ID})
 
 	return budget, nil
@@ -262,11 +372,31 @@ func (bts BudgetTemplateService) UpdateBudget(ctx context.Context, id int64, p m
 		AmountLimit: p.AmountLimit,
 	}
 
-	budget, err := bts.Rpts.BudgTem.UpdateBudget(ctx, id, internalUpdate)
+	tx, err := bts.Rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.BudgetModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := bts.Rpts.WithTx(ctx, tx)
+	existing, err := rootTx.BudgTem.GetBudgetDetail(ctx, id)
+	if err != nil {
+		return models.BudgetModel{}, err
+	}
+
+	budget, err := rootTx.BudgTem.UpdateBudget(ctx, id, internalUpdate)
 	if err != nil {
 		return budget, err
 	}
 
+	if err := recordAudit(ctx, rootTx, constants.EntityBudget, id, constants.AuditOperationUpdate, existing, budget); err != nil {
+		return models.BudgetModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.BudgetModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
 	// Invalidate budget caches
 	common.InvalidateCacheForEntity(ctx, bts.rdb, constants.EntityBudget, map[string]interface{}{"budgetId": id})
 	if budget.TemplateID != nil {
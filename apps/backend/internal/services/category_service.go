@@ -25,25 +25,40 @@ func NewCategoryService(rpts *repositories.RootRepository, rdb *redis.Client) Ca
 }
 
 func (cs CategoryService) GetPaged(ctx context.Context, p models.CategoriesSearchModel) (models.CategoriesPagedModel, error) {
-	cacheKey := common.BuildPagedCacheKey(constants.EntityCategory, p)
+	cacheKey := common.BuildPagedCacheKey(ctx, constants.EntityCategory, p)
 	return common.FetchWithCache(ctx, cs.rdb, cacheKey, constants.CacheTTLPaged, func(ctx context.Context) (models.CategoriesPagedModel, error) {
 		return cs.rpts.Cat.GetPaged(ctx, p)
 	}, "category")
 }
 
 func (cs CategoryService) GetDetail(ctx context.Context, id int64) (models.CategoryModel, error) {
-	cacheKey := common.BuildDetailCacheKey(constants.EntityCategory, id)
+	cacheKey := common.BuildDetailCacheKey(ctx, constants.EntityCategory, id)
 	return common.FetchWithCache(ctx, cs.rdb, cacheKey, constants.CacheTTLDetail, func(ctx context.Context) (models.CategoryModel, error) {
 		return cs.rpts.Cat.GetDetail(ctx, id)
 	}, "category")
 }
 
 func (cs CategoryService) Create(ctx context.Context, p models.CreateCategoryModel) (models.CategoryModel, error) {
-	category, err := cs.rpts.Cat.Create(ctx, p)
+	tx, err := cs.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.CategoryModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := cs.rpts.WithTx(ctx, tx)
+	category, err := rootTx.Cat.Create(ctx, p)
 	if err != nil {
 		return category, err
 	}
 
+	if err := recordAudit(ctx, rootTx, constants.EntityCategory, category.ID, constants.AuditOperationCreate, nil, category); err != nil {
+		return models.CategoryModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.CategoryModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
 	if err := common.InvalidateCacheForEntity(ctx, cs.rdb, constants.EntityCategory, map[string]interface{}{"categoryId": category.ID}); err != nil {
 		observability.NewLogger("service", "CategoryService").Warn("cache invalidation failed", "error", err)
 	}
@@ -52,11 +67,31 @@ func (cs CategoryService) Create(ctx context.Context, p models.CreateCategoryMod
 }
 
 func (cs CategoryService) Update(ctx context.Context, id int64, p models.UpdateCategoryModel) (models.CategoryModel, error) {
-	category, err := cs.rpts.Cat.Update(ctx, id, p)
+	tx, err := cs.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.CategoryModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := cs.rpts.WithTx(ctx, tx)
+	existing, err := rootTx.Cat.GetDetail(ctx, id)
+	if err != nil {
+		return models.CategoryModel{}, err
+	}
+
+	category, err := rootTx.Cat.Update(ctx, id, p)
 	if err != nil {
 		return category, err
 	}
 
+	if err := recordAudit(ctx, rootTx, constants.EntityCategory, id, constants.AuditOperationUpdate, existing, category); err != nil {
+		return models.CategoryModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.CategoryModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
 	if err := common.InvalidateCacheForEntity(ctx, cs.rdb, constants.EntityCategory, map[string]interface{}{"categoryId": id}); err != nil {
 		observability.NewLogger("service", "CategoryService").Warn("cache invalidation failed", "error", err)
 	}
@@ -76,10 +111,19 @@ func (cs CategoryService) Delete(ctx context.Context, id int64) error {
 	}()
 
 	rootTx := cs.rpts.WithTx(ctx, tx)
+	existing, err := rootTx.Cat.GetDetail(ctx, id)
+	if err != nil {
+		return err
+	}
+
 	if err := rootTx.Cat.Delete(ctx, id); err != nil {
 		return err
 	}
 
+	if err := recordAudit(ctx, rootTx, constants.EntityCategory, id, constants.AuditOperationDelete, existing, nil); err != nil {
+		return err
+	}
+
 	ids, err := rootTx.Cat.GetActiveIDsOrdered(ctx)
 	if err != nil {
 		return err
@@ -99,6 +143,42 @@ func (cs CategoryService) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// Restore brings a category back from the trash at the end of the display order
+func (cs CategoryService) Restore(ctx context.Context, id int64) (models.CategoryModel, error) {
+	tx, err := cs.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.CategoryModel{}, huma.Error400BadRequest("Unable to start transaction", err)
+	}
+	defer func() {
+		if tx != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	rootTx := cs.rpts.WithTx(ctx, tx)
+	if err := rootTx.Cat.Restore(ctx, id); err != nil {
+		return models.CategoryModel{}, err
+	}
+
+	category, err := rootTx.Cat.GetDetail(ctx, id)
+	if err != nil {
+		return models.CategoryModel{}, err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityCategory, id, constants.AuditOperationRestore, nil, category); err != nil {
+		return models.CategoryModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.CategoryModel{}, huma.Error400BadRequest("Unable to commit transaction", err)
+	}
+	tx = nil
+
+	common.InvalidateCacheForEntity(ctx, cs.rdb, constants.EntityCategory, map[string]interface{}{"categoryId": id})
+
+	return category, nil
+}
+
 func (cs CategoryService) Reorder(ctx context.Context, p models.ReorderCategoriesModel) error {
 	if len(p.Items) == 0 {
 		return huma.Error400BadRequest("No category IDs provided for reordering")
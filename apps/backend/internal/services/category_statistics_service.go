@@ -82,7 +82,7 @@ func (ss CategoryStatisticsService) GetCategoryStatistics(ctx context.Context, c
 
 // GetSpendingVelocity returns spending trend with Redis caching
 func (ss CategoryStatisticsService) GetSpendingVelocity(ctx context.Context, categoryID int64, p models.CategoryStatisticsSearchModel) (models.CategoryStatisticSpendingVelocityModel, error) {
-	cacheKey := common.BuildStatisticsCacheKey(constants.EntityCategory, categoryID, constants.CategoryStatisticsSpendingVelocitySuffix, p)
+	cacheKey := common.BuildStatisticsCacheKey(ctx, constants.EntityCategory, categoryID, constants.CategoryStatisticsSpendingVelocitySuffix, p)
 	return common.FetchWithCache(ctx, ss.rdb, cacheKey, constants.CacheTTLStatistics, func(ctx context.Context) (models.CategoryStatisticSpendingVelocityModel, error) {
 		return ss.rpts.CatStat.GetSpendingVelocity(ctx, categoryID, p)
 	}, "category_statistics")
@@ -90,7 +90,7 @@ func (ss CategoryStatisticsService) GetSpendingVelocity(ctx context.Context, cat
 
 // GetAccountDistribution returns account distribution with Redis caching
 func (ss CategoryStatisticsService) GetAccountDistribution(ctx context.Context, categoryID int64, p models.CategoryStatisticsSearchModel) (models.CategoryStatisticAccountDistributionModel, error) {
-	cacheKey := common.BuildStatisticsCacheKey(constants.EntityCategory, categoryID, constants.CategoryStatisticsAccountDistributionSuffix, p)
+	cacheKey := common.BuildStatisticsCacheKey(ctx, constants.EntityCategory, categoryID, constants.CategoryStatisticsAccountDistributionSuffix, p)
 	return common.FetchWithCache(ctx, ss.rdb, cacheKey, constants.CacheTTLStatistics, func(ctx context.Context) (models.CategoryStatisticAccountDistributionModel, error) {
 		return ss.rpts.CatStat.GetAccountDistribution(ctx, categoryID, p)
 	}, "category_statistics")
@@ -98,7 +98,7 @@ func (ss CategoryStatisticsService) GetAccountDistribution(ctx context.Context,
 
 // GetAverageTransactionSize returns average transaction size with Redis caching
 func (ss CategoryStatisticsService) GetAverageTransactionSize(ctx context.Context, categoryID int64, p models.CategoryStatisticsSearchModel) (models.CategoryStatisticAverageTransactionSizeModel, error) {
-	cacheKey := common.BuildStatisticsCacheKey(constants.EntityCategory, categoryID, constants.CategoryStatisticsTransactionSizeSuffix, p)
+	cacheKey := common.BuildStatisticsCacheKey(ctx, constants.EntityCategory, categoryID, constants.CategoryStatisticsTransactionSizeSuffix, p)
 	return common.FetchWithCache(ctx, ss.rdb, cacheKey, constants.CacheTTLStatistics, func(ctx context.Context) (models.CategoryStatisticAverageTransactionSizeModel, error) {
 		return ss.rpts.CatStat.GetAverageTransactionSize(ctx, categoryID, p)
 	}, "category_statistics")
@@ -106,7 +106,7 @@ func (ss CategoryStatisticsService) GetAverageTransactionSize(ctx context.Contex
 
 // GetDayOfWeekPattern returns day of week pattern with Redis caching
 func (ss CategoryStatisticsService) GetDayOfWeekPattern(ctx context.Context, categoryID int64, p models.CategoryStatisticsSearchModel) (models.CategoryStatisticDayOfWeekPatternModel, error) {
-	cacheKey := common.BuildStatisticsCacheKey(constants.EntityCategory, categoryID, constants.CategoryStatisticsDayOfWeekPatternSuffix, p)
+	cacheKey := common.BuildStatisticsCacheKey(ctx, constants.EntityCategory, categoryID, constants.CategoryStatisticsDayOfWeekPatternSuffix, p)
 	return common.FetchWithCache(ctx, ss.rdb, cacheKey, constants.CacheTTLStatistics, func(ctx context.Context) (models.CategoryStatisticDayOfWeekPatternModel, error) {
 		return ss.rpts.CatStat.GetDayOfWeekPattern(ctx, categoryID, p)
 	}, "category_statistics")
@@ -114,7 +114,7 @@ func (ss CategoryStatisticsService) GetDayOfWeekPattern(ctx context.Context, cat
 
 // GetBudgetUtilization returns budget utilization with Redis caching
 func (ss CategoryStatisticsService) GetBudgetUtilization(ctx context.Context, categoryID int64, p models.CategoryStatisticsSearchModel) (models.CategoryStatisticBudgetUtilizationModel, error) {
-	cacheKey := common.BuildStatisticsCacheKey(constants.EntityCategory, categoryID, constants.CategoryStatisticsBudgetUtilizationSuffix, p)
+	cacheKey := common.BuildStatisticsCacheKey(ctx, constants.EntityCategory, categoryID, constants.CategoryStatisticsBudgetUtilizationSuffix, p)
 	return common.FetchWithCache(ctx, ss.rdb, cacheKey, constants.CacheTTLStatistics, func(ctx context.Context) (models.CategoryStatisticBudgetUtilizationModel, error) {
 		return ss.rpts.CatStat.GetBudgetUtilization(ctx, categoryID, p)
 	}, "category_statistics")
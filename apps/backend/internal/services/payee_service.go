@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/dimasbaguspm/spenicle-api/internal/repositories"
+	"github.com/redis/go-redis/v9"
+)
+
+type PayeeService struct {
+	rpts *repositories.RootRepository
+	rdb  *redis.Client
+}
+
+func NewPayeeService(rpts *repositories.RootRepository, rdb *redis.Client) PayeeService {
+	return PayeeService{rpts, rdb}
+}
+
+func (ps PayeeService) GetPaged(ctx context.Context, query models.PayeesSearchModel) (models.PayeesPagedModel, error) {
+	cacheKey := common.BuildPagedCacheKey(ctx, constants.EntityPayee, query)
+	return common.FetchWithCache(ctx, ps.rdb, cacheKey, constants.CacheTTLPaged, func(ctx context.Context) (models.PayeesPagedModel, error) {
+		return ps.rpts.Payee.GetPaged(ctx, query)
+	}, "payee")
+}
+
+func (ps PayeeService) GetDetail(ctx context.Context, id int64) (models.PayeeModel, error) {
+	cacheKey := common.BuildDetailCacheKey(ctx, constants.EntityPayee, id)
+	return common.FetchWithCache(ctx, ps.rdb, cacheKey, constants.CacheTTLDetail, func(ctx context.Context) (models.PayeeModel, error) {
+		return ps.rpts.Payee.GetDetail(ctx, id)
+	}, "payee")
+}
+
+func (ps PayeeService) Create(ctx context.Context, payload models.CreatePayeeModel) (models.PayeeModel, error) {
+	payload.Aliases = normalizePayeeAliases(payload.Name, payload.Aliases)
+
+	tx, err := ps.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.PayeeModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := ps.rpts.WithTx(ctx, tx)
+	payee, err := rootTx.Payee.Create(ctx, payload)
+	if err != nil {
+		return payee, err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityPayee, payee.ID, constants.AuditOperationCreate, nil, payee); err != nil {
+		return models.PayeeModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.PayeeModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
+	if err := common.InvalidateCacheForEntity(ctx, ps.rdb, constants.EntityPayee, map[string]interface{}{"payeeId": payee.ID}); err != nil {
+		observability.NewLogger("service", "PayeeService").Warn("cache invalidation failed", "error", err)
+	}
+
+	return payee, nil
+}
+
+func (ps PayeeService) Update(ctx context.Context, id int64, payload models.UpdatePayeeModel) (models.PayeeModel, error) {
+	tx, err := ps.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.PayeeModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := ps.rpts.WithTx(ctx, tx)
+	existing, err := rootTx.Payee.GetDetail(ctx, id)
+	if err != nil {
+		return models.PayeeModel{}, err
+	}
+
+	if payload.Aliases != nil {
+		name := existing.Name
+		if payload.Name != nil {
+			name = *payload.Name
+		}
+		payload.Aliases = normalizePayeeAliases(name, payload.Aliases)
+	}
+
+	payee, err := rootTx.Payee.Update(ctx, id, payload)
+	if err != nil {
+		return payee, err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityPayee, id, constants.AuditOperationUpdate, existing, payee); err != nil {
+		return models.PayeeModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.PayeeModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
+	if err := common.InvalidateCacheForEntity(ctx, ps.rdb, constants.EntityPayee, map[string]interface{}{"payeeId": id}); err != nil {
+		observability.NewLogger("service", "PayeeService").Warn("cache invalidation failed", "error", err)
+	}
+
+	return payee, nil
+}
+
+func (ps PayeeService) Delete(ctx context.Context, id int64) error {
+	tx, err := ps.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := ps.rpts.WithTx(ctx, tx)
+	existing, err := rootTx.Payee.GetDetail(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := rootTx.Payee.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityPayee, id, constants.AuditOperationDelete, existing, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
+	if err := common.InvalidateCacheForEntity(ctx, ps.rdb, constants.EntityPayee, map[string]interface{}{"payeeId": id}); err != nil {
+		observability.NewLogger("service", "PayeeService").Warn("cache invalidation failed", "error", err)
+	}
+
+	return nil
+}
+
+// Restore brings a payee back from the trash; the transactions that named it show it again in reports
+func (ps PayeeService) Restore(ctx context.Context, id int64) (models.PayeeModel, error) {
+	tx, err := ps.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.PayeeModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := ps.rpts.WithTx(ctx, tx)
+	if err := rootTx.Payee.Restore(ctx, id); err != nil {
+		return models.PayeeModel{}, err
+	}
+
+	payee, err := rootTx.Payee.GetDetail(ctx, id)
+	if err != nil {
+		return models.PayeeModel{}, err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityPayee, id, constants.AuditOperationRestore, nil, payee); err != nil {
+		return models.PayeeModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.PayeeModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
+	if err := common.InvalidateCacheForEntity(ctx, ps.rdb, constants.EntityPayee, map[string]interface{}{"payeeId": id}); err != nil {
+		observability.NewLogger("service", "PayeeService").Warn("cache invalidation failed", "error", err)
+	}
+
+	return payee, nil
+}
+
+// Merge folds duplicate payees into the target one in a single database transaction
+// Their transactions and templates move to the target, their names and aliases become aliases of it, and they are deleted
+func (ps PayeeService) Merge(ctx context.Context, id int64, payload models.MergePayeesModel) (models.PayeeMergeResultModel, error) {
+	tx, err := ps.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.PayeeMergeResultModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := ps.rpts.WithTx(ctx, tx)
+	existing, err := rootTx.Payee.GetDetail(ctx, id)
+	if err != nil {
+		return models.PayeeMergeResultModel{}, err
+	}
+
+	var sourceIDs []int64
+	var sources []models.PayeeModel
+	aliases := slices.Clone(existing.Aliases)
+	for _, sourceID := range payload.SourceIDs {
+		if sourceID == id {
+			return models.PayeeMergeResultModel{}, huma.Error400BadRequest("A payee cannot be merged into itself")
+		}
+		if slices.Contains(sourceIDs, sourceID) {
+			continue
+		}
+		source, err := rootTx.Payee.GetDetail(ctx, sourceID)
+		if err != nil {
+			return models.PayeeMergeResultModel{}, huma.Error400BadRequest("Payee to merge not found", err)
+		}
+		sourceIDs = append(sourceIDs, sourceID)
+		sources = append(sources, source)
+		aliases = append(aliases, source.Name)
+		aliases = append(aliases, source.Aliases...)
+	}
+
+	payee, err := rootTx.Payee.Update(ctx, id, models.UpdatePayeeModel{Aliases: normalizePayeeAliases(existing.Name, aliases)})
+	if err != nil {
+		return models.PayeeMergeResultModel{}, err
+	}
+
+	transactionsMoved, templatesMoved, err := rootTx.Payee.Reassign(ctx, sourceIDs, id)
+	if err != nil {
+		return models.PayeeMergeResultModel{}, err
+	}
+
+	for _, source := range sources {
+		if err := rootTx.Payee.Delete(ctx, source.ID); err != nil {
+			return models.PayeeMergeResultModel{}, err
+		}
+		if err := recordAudit(ctx, rootTx, constants.EntityPayee, source.ID, constants.AuditOperationDelete, source, nil); err != nil {
+			return models.PayeeMergeResultModel{}, err
+		}
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityPayee, id, constants.AuditOperationUpdate, existing, payee); err != nil {
+		return models.PayeeMergeResultModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.PayeeMergeResultModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
+	if err := common.InvalidateCacheForEntity(ctx, ps.rdb, constants.EntityPayee, map[string]interface{}{"payeeId": id}); err != nil {
+		observability.NewLogger("service", "PayeeService").Warn("cache invalidation failed", "error", err)
+	}
+
+	return models.PayeeMergeResultModel{
+		Payee:             payee,
+		MergedCount:       len(sources),
+		TransactionsMoved: transactionsMoved,
+		TemplatesMoved:    templatesMoved,
+	}, nil
+}
+
+// requirePayee rejects a payee the current user does not have; nil and 0 mean no payee
+func requirePayee(ctx context.Context, rpts *repositories.RootRepository, payeeID *int64) error {
+	if payeeID == nil || *payeeID == 0 {
+		return nil
+	}
+	if _, err := rpts.Payee.GetDetail(ctx, *payeeID); err != nil {
+		return huma.Error400BadRequest("Payee not found", err)
+	}
+	return nil
+}
+
+// normalizePayeeAliases trims aliases and drops empty ones, duplicates ignoring case, and ones equal to the payee name
+func normalizePayeeAliases(name string, aliases []string) []string {
+	seen := map[string]bool{strings.ToLower(strings.TrimSpace(name)): true}
+	result := []string{}
+	for _, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		key := strings.ToLower(alias)
+		if alias == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, alias)
+	}
+	return result
+}
@@ -29,7 +29,7 @@ func (ps PreferenceService) RefreshGeoCache(ctx context.Context, latitude *float
 	logger.Info("background job started")
 
 	go func() {
-		bgCtx := context.Background()
+		bgCtx := common.WithUserID(context.Background(), common.GetUserID(ctx))
 		transactions, err := ps.rpts.Tsct.GetGeotaggedTransactions(bgCtx, latitude, longitude, 100)
 		if err != nil {
 			logger.Error("failed to fetch geotagged transactions", "error", err)
@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/dimasbaguspm/spenicle-api/internal/repositories"
+	"github.com/redis/go-redis/v9"
+)
+
+type ReimbursementService struct {
+	rpts *repositories.RootRepository
+	rdb  *redis.Client
+}
+
+func NewReimbursementService(rpts *repositories.RootRepository, rdb *redis.Client) ReimbursementService {
+	return ReimbursementService{rpts, rdb}
+}
+
+func (rs ReimbursementService) GetPaged(ctx context.Context, query models.ReimbursementsSearchModel) (models.ReimbursementsPagedModel, error) {
+	cacheKey := common.BuildPagedCacheKey(ctx, constants.EntityReimbursement, query)
+	return common.FetchWithCache(ctx, rs.rdb, cacheKey, constants.CacheTTLPaged, func(ctx context.Context) (models.ReimbursementsPagedModel, error) {
+		return rs.rpts.Reimb.GetPaged(ctx, query)
+	}, "reimbursement")
+}
+
+func (rs ReimbursementService) GetDetail(ctx context.Context, id int64) (models.ReimbursementModel, error) {
+	cacheKey := common.BuildDetailCacheKey(ctx, constants.EntityReimbursement, id)
+	return common.FetchWithCache(ctx, rs.rdb, cacheKey, constants.CacheTTLDetail, func(ctx context.Context) (models.ReimbursementModel, error) {
+		return rs.rpts.Reimb.GetDetail(ctx, id)
+	}, "reimbursement")
+}
+
+// Create marks an expense as expected to be paid back; without an expected amount the whole expense is
+func (rs ReimbursementService) Create(ctx context.Context, payload models.CreateReimbursementModel) (models.ReimbursementModel, error) {
+	tx, err := rs.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.ReimbursementModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := rs.rpts.WithTx(ctx, tx)
+	expense, err := rootTx.Reimb.GetTransactionForUpdate(ctx, payload.TransactionID)
+	if err != nil {
+		return models.ReimbursementModel{}, huma.Error400BadRequest("Expense transaction not found", err)
+	}
+	if expense.Type != "expense" {
+		return models.ReimbursementModel{}, huma.Error400BadRequest("Only expenses can be marked for reimbursement")
+	}
+
+	if payload.ExpectedAmount == nil {
+		payload.ExpectedAmount = &expense.Amount
+	}
+	if *payload.ExpectedAmount > expense.Amount {
+		return models.ReimbursementModel{}, huma.Error400BadRequest(fmt.Sprintf("Expected amount cannot exceed the expense amount of %d", expense.Amount))
+	}
+
+	reimbursement, err := rootTx.Reimb.Create(ctx, payload)
+	if err != nil {
+		return models.ReimbursementModel{}, err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityReimbursement, reimbursement.ID, constants.AuditOperationCreate, nil, reimbursement); err != nil {
+		return models.ReimbursementModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.ReimbursementModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
+	rs.invalidate(ctx, reimbursement.ID)
+	return reimbursement, nil
+}
+
+// Update changes what is expected to be paid back; the expected amount stays between what is already paid back and the expense amount
+func (rs ReimbursementService) Update(ctx context.Context, id int64, payload models.UpdateReimbursementModel) (models.ReimbursementModel, error) {
+	tx, err := rs.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.ReimbursementModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := rs.rpts.WithTx(ctx, tx)
+	existing, err := rootTx.Reimb.GetDetailForUpdate(ctx, id)
+	if err != nil {
+		return models.ReimbursementModel{}, err
+	}
+
+	if payload.ExpectedAmount != nil {
+		if *payload.ExpectedAmount > existing.TransactionAmount {
+			return models.ReimbursementModel{}, huma.Error400BadRequest(fmt.Sprintf("Expected amount cannot exceed the expense amount of %d", existing.TransactionAmount))
+		}
+		if *payload.ExpectedAmount < existing.MatchedAmount {
+			return models.ReimbursementModel{}, huma.Error400BadRequest(fmt.Sprintf("Expected amount cannot be less than the %d already paid back", existing.MatchedAmount))
+		}
+	}
+
+	reimbursement, err := rootTx.Reimb.Update(ctx, id, payload)
+	if err != nil {
+		return models.ReimbursementModel{}, err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityReimbursement, id, constants.AuditOperationUpdate, existing, reimbursement); err != nil {
+		return models.ReimbursementModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.ReimbursementModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
+	rs.invalidate(ctx, id)
+	return reimbursement, nil
+}
+
+// Delete unmarks the expense; its matches go with it and the income they used is free to match other reimbursements
+func (rs ReimbursementService) Delete(ctx context.Context, id int64) error {
+	tx, err := rs.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := rs.rpts.WithTx(ctx, tx)
+	existing, err := rootTx.Reimb.GetDetail(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := rootTx.Reimb.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityReimbursement, id, constants.AuditOperationDelete, existing, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
+	rs.invalidate(ctx, id)
+	return nil
+}
+
+// Match counts part of an income towards the reimbursement
+// Neither the reimbursement nor the income can be used for more than it has left; without an amount as much as both have left is used
+func (rs ReimbursementService) Match(ctx context.Context, id int64, payload models.CreateReimbursementMatchModel) (models.ReimbursementModel, error) {
+	tx, err := rs.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.ReimbursementModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := rs.rpts.WithTx(ctx, tx)
+	existing, err := rootTx.Reimb.GetDetailForUpdate(ctx, id)
+	if err != nil {
+		return models.ReimbursementModel{}, err
+	}
+
+	income, err := rootTx.Reimb.GetTransactionForUpdate(ctx, payload.TransactionID)
+	if err != nil {
+		return models.ReimbursementModel{}, huma.Error400BadRequest("Income transaction not found", err)
+	}
+	if income.Type != "income" {
+		return models.ReimbursementModel{}, huma.Error400BadRequest("Only income can pay back a reimbursement")
+	}
+
+	available := income.Amount - income.MatchedAmount
+	amount := min(existing.OutstandingAmount, available)
+	if payload.Amount != nil {
+		amount = *payload.Amount
+	}
+	switch {
+	case existing.OutstandingAmount == 0:
+		return models.ReimbursementModel{}, huma.Error409Conflict("Reimbursement is already settled")
+	case available <= 0:
+		return models.ReimbursementModel{}, huma.Error409Conflict("Income is already fully matched to reimbursements")
+	case amount > existing.OutstandingAmount:
+		return models.ReimbursementModel{}, huma.Error400BadRequest(fmt.Sprintf("Amount exceeds the %d still owed", existing.OutstandingAmount))
+	case amount > available:
+		return models.ReimbursementModel{}, huma.Error400BadRequest(fmt.Sprintf("Only %d of the income is left to match", available))
+	}
+
+	if err := rootTx.Reimb.AddMatch(ctx, id, payload.TransactionID, amount); err != nil {
+		return models.ReimbursementModel{}, err
+	}
+
+	reimbursement, err := rootTx.Reimb.GetDetail(ctx, id)
+	if err != nil {
+		return models.ReimbursementModel{}, err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityReimbursement, id, constants.AuditOperationUpdate, existing, reimbursement); err != nil {
+		return models.ReimbursementModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.ReimbursementModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
+	rs.invalidate(ctx, id)
+	return reimbursement, nil
+}
+
+// Unmatch removes a match, so the expense is owed that part again
+func (rs ReimbursementService) Unmatch(ctx context.Context, id int64, matchID int64) (models.ReimbursementModel, error) {
+	tx, err := rs.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.ReimbursementModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := rs.rpts.WithTx(ctx, tx)
+	existing, err := rootTx.Reimb.GetDetailForUpdate(ctx, id)
+	if err != nil {
+		return models.ReimbursementModel{}, err
+	}
+
+	if err := rootTx.Reimb.DeleteMatch(ctx, id, matchID); err != nil {
+		return models.ReimbursementModel{}, err
+	}
+
+	reimbursement, err := rootTx.Reimb.GetDetail(ctx, id)
+	if err != nil {
+		return models.ReimbursementModel{}, err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityReimbursement, id, constants.AuditOperationUpdate, existing, reimbursement); err != nil {
+		return models.ReimbursementModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.ReimbursementModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
+	rs.invalidate(ctx, id)
+	return reimbursement, nil
+}
+
+// invalidate drops cached reimbursements and the summaries that leave reimbursed amounts out
+func (rs ReimbursementService) invalidate(ctx context.Context, id int64) {
+	if err := common.InvalidateCacheForEntity(ctx, rs.rdb, constants.EntityReimbursement, map[string]interface{}{"reimbursementId": id}); err != nil {
+		observability.NewLogger("service", "ReimbursementService").Warn("cache invalidation failed", "error", err)
+	}
+}
@@ -1,45 +1,75 @@
 package services
 
 import (
+	"github.com/dimasbaguspm/spenicle-api/clients"
 	"github.com/dimasbaguspm/spenicle-api/internal/repositories"
 	"github.com/redis/go-redis/v9"
 )
 
 type RootService struct {
-	Acc      AccountService
-	AccStat  AccountStatisticsService
-	Ath      AuthService
-	BudgTem  BudgetTemplateService
-	Cat      CategoryService
-	CatStat  CategoryStatisticsService
-	Cfg      ConfigService
-	Pref     PreferenceService
-	Sum      SummaryService
-	Tag      TagService
-	Tsct     TransactionService
-	TsctBulk TransactionBulkService
-	TsctRel  TransactionRelationService
-	TsctTag  TransactionTagService
-	TsctTem  TransactionTemplateService
+	Acc       AccountService
+	AccRecon  AccountReconciliationService
+	APITok    APITokenService
+	AccStat   AccountStatisticsService
+	Ath       AuthService
+	Aud       AuditService
+	BudgTem   BudgetTemplateService
+	Cat       CategoryService
+	CatStat   CategoryStatisticsService
+	Cfg       ConfigService
+	Payee     PayeeService
+	Pref      PreferenceService
+	Reimb     ReimbursementService
+	Shr       ShareService
+	Sum       SummaryService
+	Tag       TagService
+	Trash     TrashService
+	Tsct      TransactionService
+	TsctAtt   TransactionAttachmentService
+	TsctBulk  TransactionBulkService
+	TsctDup   TransactionDuplicateService
+	TsctRel   TransactionRelationService
+	TsctRev   TransactionRevisionService
+	TsctRule  TransactionRuleService
+	TsctSplit TransactionSplitService
+	TsctTag   TransactionTagService
+	TsctTem   TransactionTemplateService
+	TwoFa     TwoFactorService
+	Usr       UserService
 }
 
 func NewRootService(repos repositories.RootRepository, rdb *redis.Client) RootService {
 	tsctService := NewTransactionService(&repos, rdb)
+	attachmentStorage := clients.NewAttachmentStorage()
 	return RootService{
-		Acc:      NewAccountService(&repos, rdb),
-		AccStat:  NewAccountStatisticsService(&repos, rdb),
-		Ath:      NewAuthService(&repos),
-		BudgTem:  NewBudgetTemplateService(&repos, rdb),
-		Cat:      NewCategoryService(&repos, rdb),
-		CatStat:  NewCategoryStatisticsService(&repos, rdb),
-		Cfg:      NewConfigService(&repos, rdb),
-		Pref:     NewPreferenceService(&repos, tsctService.GetGeoIndexManager()),
-		Sum:      NewSummaryService(&repos, rdb),
-		Tag:      NewTagService(&repos, rdb),
-		Tsct:     tsctService,
-		TsctBulk: NewTransactionBulkService(&repos, rdb, tsctService),
-		TsctRel:  NewTransactionRelationService(&repos, rdb),
-		TsctTag:  NewTransactionTagService(&repos, rdb),
-		TsctTem:  NewTransactionTemplateService(&repos, rdb),
+		Acc:       NewAccountService(&repos, rdb),
+		AccRecon:  NewAccountReconciliationService(&repos, rdb),
+		APITok:    NewAPITokenService(&repos),
+		AccStat:   NewAccountStatisticsService(&repos, rdb),
+		Ath:       NewAuthService(&repos, rdb),
+		Aud:       NewAuditService(&repos),
+		BudgTem:   NewBudgetTemplateService(&repos, rdb),
+		Cat:       NewCategoryService(&repos, rdb),
+		CatStat:   NewCategoryStatisticsService(&repos, rdb),
+		Cfg:       NewConfigService(&repos, rdb),
+		Payee:     NewPayeeService(&repos, rdb),
+		Pref:      NewPreferenceService(&repos, tsctService.GetGeoIndexManager()),
+		Reimb:     NewReimbursementService(&repos, rdb),
+		Shr:       NewShareService(&repos, rdb),
+		Sum:       NewSummaryService(&repos, rdb),
+		Tag:       NewTagService(&repos, rdb),
+		Trash:     NewTrashService(&repos, attachmentStorage),
+		Tsct:      tsctService,
+		TsctAtt:   NewTransactionAttachmentService(&repos, attachmentStorage, tsctService),
+		TsctBulk:  NewTransactionBulkService(&repos, rdb, tsctService),
+		TsctDup:   NewTransactionDuplicateService(&repos, rdb, tsctService),
+		TsctRel:   NewTransactionRelationService(&repos, rdb),
+		TsctRev:   NewTransactionRevisionService(&repos, tsctService),
+		TsctRule:  NewTransactionRuleService(&repos, rdb, tsctService),
+		TsctSplit: NewTransactionSplitService(&repos, rdb, tsctService),
+		TsctTag:   NewTransactionTagService(&repos, rdb),
+		TsctTem:   NewTransactionTemplateService(&repos, rdb),
+		TwoFa:     NewTwoFactorService(&repos),
+		Usr:       NewUserService(&repos),
 	}
 }
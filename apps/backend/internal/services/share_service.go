@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/dimasbaguspm/spenicle-api/internal/repositories"
+	"github.com/redis/go-redis/v9"
+)
+
+// requireAccess resolves the current user's role on a shareable resource and rejects roles outside the allowed ones
+// Resources neither owned nor shared with the user are reported as not found
+func requireAccess(ctx context.Context, rpts *repositories.RootRepository, resourceType string, id int64, roles ...string) (models.ShareAccessModel, error) {
+	access, err := rpts.Shr.GetAccess(ctx, resourceType, id)
+	if err != nil {
+		return access, err
+	}
+	if !slices.Contains(roles, access.Role) {
+		return access, huma.Error403Forbidden("This action requires the " + strings.Join(roles, " or ") + " role, you are a " + access.Role)
+	}
+	return access, nil
+}
+
+type ShareService struct {
+	rpts *repositories.RootRepository
+	rdb  *redis.Client
+}
+
+func NewShareService(rpts *repositories.RootRepository, rdb *redis.Client) ShareService {
+	return ShareService{rpts, rdb}
+}
+
+// GetList returns who a resource is shared with; only the owner can see it
+func (ss ShareService) GetList(ctx context.Context, resourceType string, id int64) (models.SharesListModel, error) {
+	if _, err := requireAccess(ctx, ss.rpts, resourceType, id, constants.ShareRoleOwner); err != nil {
+		return models.SharesListModel{}, err
+	}
+	return ss.rpts.Shr.GetList(ctx, resourceType, id)
+}
+
+// Upsert shares a resource with another user, or changes their role when it is already shared
+func (ss ShareService) Upsert(ctx context.Context, resourceType string, id int64, p models.UpsertShareModel) (models.SharesListModel, error) {
+	if _, err := requireAccess(ctx, ss.rpts, resourceType, id, constants.ShareRoleOwner); err != nil {
+		return models.SharesListModel{}, err
+	}
+
+	userID, err := ss.rpts.Usr.GetIDByUsername(ctx, p.Username)
+	if err != nil {
+		return models.SharesListModel{}, err
+	}
+	if userID == common.GetUserID(ctx) {
+		return models.SharesListModel{}, huma.Error400BadRequest("Resources cannot be shared with their owner")
+	}
+
+	tx, err := ss.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.SharesListModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := ss.rpts.WithTx(ctx, tx)
+	existing, err := rootTx.Shr.GetList(ctx, resourceType, id)
+	if err != nil {
+		return models.SharesListModel{}, err
+	}
+
+	shareID, err := rootTx.Shr.Upsert(ctx, resourceType, id, userID, p.Role)
+	if err != nil {
+		return models.SharesListModel{}, err
+	}
+
+	operation, before := constants.AuditOperationCreate, any(nil)
+	for _, share := range existing.Items {
+		if share.UserID == userID {
+			operation, before = constants.AuditOperationUpdate, shareSnapshot(resourceType, id, userID, share.Role)
+		}
+	}
+	if err := recordAudit(ctx, rootTx, constants.EntityShare, shareID, operation, before, shareSnapshot(resourceType, id, userID, p.Role)); err != nil {
+		return models.SharesListModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.SharesListModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+	ss.invalidate(ctx, resourceType, id)
+
+	return ss.rpts.Shr.GetList(ctx, resourceType, id)
+}
+
+// Delete revokes a user's access to a resource
+func (ss ShareService) Delete(ctx context.Context, resourceType string, id int64, userID int64) error {
+	if _, err := requireAccess(ctx, ss.rpts, resourceType, id, constants.ShareRoleOwner); err != nil {
+		return err
+	}
+
+	tx, err := ss.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := ss.rpts.WithTx(ctx, tx)
+	existing, err := rootTx.Shr.GetList(ctx, resourceType, id)
+	if err != nil {
+		return err
+	}
+
+	shareID, err := rootTx.Shr.Delete(ctx, resourceType, id, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, share := range existing.Items {
+		if share.UserID == userID {
+			if err := recordAudit(ctx, rootTx, constants.EntityShare, shareID, constants.AuditOperationDelete, shareSnapshot(resourceType, id, userID, share.Role), nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+	ss.invalidate(ctx, resourceType, id)
+
+	return nil
+}
+
+// shareSnapshot is the audit log representation of a share
+func shareSnapshot(resourceType string, resourceID int64, userID int64, role string) map[string]any {
+	return map[string]any{"resourceType": resourceType, "resourceId": resourceID, "userId": userID, "role": role}
+}
+
+// invalidate drops cached lists and details that include the resource, so grantees see the change immediately
+// Sharing an account also changes which transactions a grantee can see
+func (ss ShareService) invalidate(ctx context.Context, resourceType string, id int64) {
+	params := map[string]interface{}{"accountId": id, "templateId": id}
+	if err := common.InvalidateCacheForEntity(ctx, ss.rdb, resourceType, params); err != nil {
+		observability.NewLogger("service", "ShareService").Warn("cache invalidation failed", "error", err)
+	}
+	if resourceType == constants.EntityAccount {
+		if err := common.InvalidateCacheForEntity(ctx, ss.rdb, constants.EntityTransaction, params); err != nil {
+			observability.NewLogger("service", "ShareService").Warn("cache invalidation failed", "error", err)
+		}
+	}
+}
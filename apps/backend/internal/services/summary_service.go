@@ -25,7 +25,7 @@ func (ss SummaryService) GetTransactionSummary(ctx context.Context, p models.Sum
 		return models.SummaryTransactionListModel{}, huma.Error400BadRequest("endDate must be after or equal to startDate")
 	}
 
-	cacheKey := common.BuildPagedCacheKey(constants.SummaryTransaction, p)
+	cacheKey := common.BuildPagedCacheKey(ctx, constants.SummaryTransaction, p)
 	return common.FetchWithCache(ctx, ss.rdb, cacheKey, constants.CacheTTLSummary, func(ctx context.Context) (models.SummaryTransactionListModel, error) {
 		return ss.rpts.Sum.GetTransactionSummary(ctx, p)
 	}, "summary")
@@ -36,7 +36,7 @@ func (ss SummaryService) GetAccountSummary(ctx context.Context, p models.Summary
 		return models.SummaryAccountListModel{}, huma.Error400BadRequest("endDate must be after or equal to startDate")
 	}
 
-	cacheKey := common.BuildPagedCacheKey(constants.SummaryAccount, p)
+	cacheKey := common.BuildPagedCacheKey(ctx, constants.SummaryAccount, p)
 	return common.FetchWithCache(ctx, ss.rdb, cacheKey, constants.CacheTTLSummary, func(ctx context.Context) (models.SummaryAccountListModel, error) {
 		return ss.rpts.Sum.GetAccountSummary(ctx, p)
 	}, "summary")
@@ -47,18 +47,29 @@ func (ss SummaryService) GetCategorySummary(ctx context.Context, p models.Summar
 		return models.SummaryCategoryListModel{}, huma.Error400BadRequest("endDate must be after or equal to startDate")
 	}
 
-	cacheKey := common.BuildPagedCacheKey(constants.SummaryCategory, p)
+	cacheKey := common.BuildPagedCacheKey(ctx, constants.SummaryCategory, p)
 	return common.FetchWithCache(ctx, ss.rdb, cacheKey, constants.CacheTTLSummary, func(ctx context.Context) (models.SummaryCategoryListModel, error) {
 		return ss.rpts.Sum.GetCategorySummary(ctx, p)
 	}, "summary")
 }
 
+func (ss SummaryService) GetPayeeSummary(ctx context.Context, p models.SummarySearchModel) (models.SummaryPayeeListModel, error) {
+	if p.EndDate.Before(p.StartDate) {
+		return models.SummaryPayeeListModel{}, huma.Error400BadRequest("endDate must be after or equal to startDate")
+	}
+
+	cacheKey := common.BuildPagedCacheKey(ctx, constants.SummaryPayee, p)
+	return common.FetchWithCache(ctx, ss.rdb, cacheKey, constants.CacheTTLSummary, func(ctx context.Context) (models.SummaryPayeeListModel, error) {
+		return ss.rpts.Sum.GetPayeeSummary(ctx, p)
+	}, "summary")
+}
+
 func (ss SummaryService) GetGeospatialSummary(ctx context.Context, p models.SummaryGeospatialSearchModel) (models.SummaryGeospatialListModel, error) {
 	if p.EndDate.Before(p.StartDate) {
 		return models.SummaryGeospatialListModel{}, huma.Error400BadRequest("endDate must be after or equal to startDate")
 	}
 
-	cacheKey := common.BuildPagedCacheKey(constants.SummaryGeospatial, p)
+	cacheKey := common.BuildPagedCacheKey(ctx, constants.SummaryGeospatial, p)
 	return common.FetchWithCache(ctx, ss.rdb, cacheKey, constants.CacheTTLSummary, func(ctx context.Context) (models.SummaryGeospatialListModel, error) {
 		return ss.rpts.Sum.GetGeospatialSummary(ctx, p)
 	}, "summary")
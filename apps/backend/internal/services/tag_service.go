@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 
+	"github.com/danielgtaylor/huma/v2"
 	"github.com/dimasbaguspm/spenicle-api/internal/common"
 	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
@@ -21,25 +22,40 @@ func NewTagService(rpts *repositories.RootRepository, rdb *redis.Client) TagServ
 }
 
 func (ts TagService) GetPaged(ctx context.Context, query models.TagsSearchModel) (models.TagsPagedModel, error) {
-	cacheKey := common.BuildPagedCacheKey(constants.EntityTag, query)
+	cacheKey := common.BuildPagedCacheKey(ctx, constants.EntityTag, query)
 	return common.FetchWithCache(ctx, ts.rdb, cacheKey, constants.CacheTTLPaged, func(ctx context.Context) (models.TagsPagedModel, error) {
 		return ts.rpts.Tag.GetPaged(ctx, query)
 	}, "tag")
 }
 
 func (ts TagService) GetDetail(ctx context.Context, id int64) (models.TagModel, error) {
-	cacheKey := common.BuildDetailCacheKey(constants.EntityTag, id)
+	cacheKey := common.BuildDetailCacheKey(ctx, constants.EntityTag, id)
 	return common.FetchWithCache(ctx, ts.rdb, cacheKey, constants.CacheTTLDetail, func(ctx context.Context) (models.TagModel, error) {
 		return ts.rpts.Tag.GetDetail(ctx, id)
 	}, "tag")
 }
 
 func (ts TagService) Create(ctx context.Context, payload models.CreateTagModel) (models.TagModel, error) {
-	tag, err := ts.rpts.Tag.Create(ctx, payload)
+	tx, err := ts.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.TagModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := ts.rpts.WithTx(ctx, tx)
+	tag, err := rootTx.Tag.Create(ctx, payload)
 	if err != nil {
 		return tag, err
 	}
 
+	if err := recordAudit(ctx, rootTx, constants.EntityTag, tag.ID, constants.AuditOperationCreate, nil, tag); err != nil {
+		return models.TagModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.TagModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
 	if err := common.InvalidateCacheForEntity(ctx, ts.rdb, constants.EntityTag, map[string]interface{}{"tagId": tag.ID}); err != nil {
 		observability.NewLogger("service", "TagService").Warn("cache invalidation failed", "error", err)
 	}
@@ -48,11 +64,31 @@ func (ts TagService) Create(ctx context.Context, payload models.CreateTagModel)
 }
 
 func (ts TagService) Update(ctx context.Context, id int64, payload models.UpdateTagModel) (models.TagModel, error) {
-	tag, err := ts.rpts.Tag.Update(ctx, id, payload)
+	tx, err := ts.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.TagModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := ts.rpts.WithTx(ctx, tx)
+	existing, err := rootTx.Tag.GetDetail(ctx, id)
+	if err != nil {
+		return models.TagModel{}, err
+	}
+
+	tag, err := rootTx.Tag.Update(ctx, id, payload)
 	if err != nil {
 		return tag, err
 	}
 
+	if err := recordAudit(ctx, rootTx, constants.EntityTag, id, constants.AuditOperationUpdate, existing, tag); err != nil {
+		return models.TagModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.TagModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
 	if err := common.InvalidateCacheForEntity(ctx, ts.rdb, "tag", map[string]interface{}{"tagId": id}); err != nil {
 		observability.NewLogger("service", "TagService").Warn("cache invalidation failed", "error", err)
 	}
@@ -61,14 +97,66 @@ func (ts TagService) Update(ctx context.Context, id int64, payload models.Update
 }
 
 func (ts TagService) Delete(ctx context.Context, id int64) error {
-	err := ts.rpts.Tag.Delete(ctx, id)
+	tx, err := ts.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := ts.rpts.WithTx(ctx, tx)
+	existing, err := rootTx.Tag.GetDetail(ctx, id)
 	if err != nil {
 		return err
 	}
 
+	if err := rootTx.Tag.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityTag, id, constants.AuditOperationDelete, existing, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
 	if err := common.InvalidateCacheForEntity(ctx, ts.rdb, constants.EntityTag, map[string]interface{}{"tagId": id}); err != nil {
 		observability.NewLogger("service", "TagService").Warn("cache invalidation failed", "error", err)
 	}
 
 	return nil
 }
+
+// Restore brings a tag back from the trash along with its links to transactions
+func (ts TagService) Restore(ctx context.Context, id int64) (models.TagModel, error) {
+	tx, err := ts.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.TagModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := ts.rpts.WithTx(ctx, tx)
+	if err := rootTx.Tag.Restore(ctx, id); err != nil {
+		return models.TagModel{}, err
+	}
+
+	tag, err := rootTx.Tag.GetDetail(ctx, id)
+	if err != nil {
+		return models.TagModel{}, err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityTag, id, constants.AuditOperationRestore, nil, tag); err != nil {
+		return models.TagModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.TagModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
+	if err := common.InvalidateCacheForEntity(ctx, ts.rdb, constants.EntityTag, map[string]interface{}{"tagId": id}); err != nil {
+		observability.NewLogger("service", "TagService").Warn("cache invalidation failed", "error", err)
+	}
+
+	return tag, nil
+}
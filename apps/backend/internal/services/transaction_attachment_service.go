@@ -0,0 +1,255 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/clients"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/dimasbaguspm/spenicle-api/internal/repositories"
+)
+
+// Detected types a thumbnail is generated for; WebP and PDF files are served without one
+var thumbnailContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+type TransactionAttachmentService struct {
+	rpts    *repositories.RootRepository
+	storage clients.AttachmentStorage
+	tsvc    TransactionService
+}
+
+func NewTransactionAttachmentService(rpts *repositories.RootRepository, storage clients.AttachmentStorage, tsvc TransactionService) TransactionAttachmentService {
+	return TransactionAttachmentService{
+		rpts:    rpts,
+		storage: storage,
+		tsvc:    tsvc,
+	}
+}
+
+func (tas TransactionAttachmentService) GetList(ctx context.Context, transactionID int64) (models.TransactionAttachmentsListModel, error) {
+	if _, err := tas.tsvc.GetDetail(ctx, transactionID); err != nil {
+		return models.TransactionAttachmentsListModel{}, err
+	}
+	return tas.rpts.TsctAtt.GetList(ctx, transactionID)
+}
+
+// Upload stores a file and attaches it to a transaction
+// The type is sniffed from the content and must be one of constants.AttachmentContentTypes; images also get a JPEG thumbnail
+func (tas TransactionAttachmentService) Upload(ctx context.Context, transactionID int64, fileName string, file io.Reader) (models.TransactionAttachmentModel, error) {
+	existing, err := tas.rpts.Tsct.GetDetail(ctx, transactionID)
+	if err != nil {
+		return models.TransactionAttachmentModel{}, err
+	}
+
+	var destAccountID *int64
+	if existing.DestinationAccount != nil {
+		destAccountID = &existing.DestinationAccount.ID
+	}
+	if err := tas.tsvc.RequireEditableAccounts(ctx, existing.Account.ID, destAccountID); err != nil {
+		return models.TransactionAttachmentModel{}, err
+	}
+
+	content, err := io.ReadAll(io.LimitReader(file, constants.AttachmentMaxSizeBytes+1))
+	if err != nil {
+		return models.TransactionAttachmentModel{}, huma.Error400BadRequest("Unable to read the uploaded file", err)
+	}
+	if len(content) == 0 {
+		return models.TransactionAttachmentModel{}, huma.Error400BadRequest("The uploaded file is empty")
+	}
+	if len(content) > constants.AttachmentMaxSizeBytes {
+		return models.TransactionAttachmentModel{}, huma.NewError(http.StatusRequestEntityTooLarge, fmt.Sprintf("Attachments are limited to %d MB", constants.AttachmentMaxSizeBytes>>20))
+	}
+
+	contentType, _, _ := strings.Cut(http.DetectContentType(content), ";")
+	if !constants.AttachmentContentTypes[contentType] {
+		return models.TransactionAttachmentModel{}, huma.Error415UnsupportedMediaType(fmt.Sprintf("Unsupported file type %s, attach a JPEG, PNG, GIF, WebP or PDF file", contentType))
+	}
+
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return models.TransactionAttachmentModel{}, huma.Error500InternalServerError("Unable to generate attachment key", err)
+	}
+	storageKey := fmt.Sprintf("%d/%s", common.GetUserID(ctx), hex.EncodeToString(token))
+	checksum := sha256.Sum256(content)
+
+	if err := tas.storage.Save(ctx, storageKey, bytes.NewReader(content)); err != nil {
+		observability.RecordError("storage")
+		return models.TransactionAttachmentModel{}, huma.Error500InternalServerError("Unable to store attachment", err)
+	}
+	storedKeys := []string{storageKey}
+
+	var thumbnailKey *string
+	if thumbnailContentTypes[contentType] {
+		if key, err := tas.saveThumbnail(ctx, storageKey, content); err != nil {
+			observability.NewLogger("service", "TransactionAttachmentService").Warn("thumbnail generation failed", "storage_key", storageKey, "error", err)
+		} else {
+			thumbnailKey = &key
+			storedKeys = append(storedKeys, key)
+		}
+	}
+
+	attachment, err := tas.create(ctx, models.CreateTransactionAttachmentModel{
+		TransactionID:  transactionID,
+		FileName:       sanitizeAttachmentFileName(fileName),
+		ContentType:    contentType,
+		SizeBytes:      int64(len(content)),
+		ChecksumSHA256: hex.EncodeToString(checksum[:]),
+		StorageKey:     storageKey,
+		ThumbnailKey:   thumbnailKey,
+	})
+	if err != nil {
+		// Nothing references the files yet, so they are removed right away
+		for _, key := range storedKeys {
+			if err := tas.storage.Delete(ctx, key); err != nil {
+				observability.NewLogger("service", "TransactionAttachmentService").Warn("orphaned attachment cleanup failed", "storage_key", key, "error", err)
+			}
+		}
+		return models.TransactionAttachmentModel{}, err
+	}
+
+	return attachment, nil
+}
+
+func (tas TransactionAttachmentService) create(ctx context.Context, p models.CreateTransactionAttachmentModel) (models.TransactionAttachmentModel, error) {
+	tx, err := tas.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.TransactionAttachmentModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := tas.rpts.WithTx(ctx, tx)
+	attachment, err := rootTx.TsctAtt.Create(ctx, p)
+	if err != nil {
+		return models.TransactionAttachmentModel{}, err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityTransactionAttachment, attachment.ID, constants.AuditOperationCreate, nil, attachment); err != nil {
+		return models.TransactionAttachmentModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.TransactionAttachmentModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+	return attachment, nil
+}
+
+func (tas TransactionAttachmentService) saveThumbnail(ctx context.Context, storageKey string, content []byte) (string, error) {
+	thumbnail, err := common.GenerateThumbnail(content, constants.AttachmentThumbnailSize, constants.AttachmentMaxPixels)
+	if err != nil {
+		return "", err
+	}
+
+	key := storageKey + "_thumb.jpg"
+	if err := tas.storage.Save(ctx, key, bytes.NewReader(thumbnail)); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// Open returns an attachment with a reader over its file, or over its thumbnail when asked for
+// Callers close the reader
+func (tas TransactionAttachmentService) Open(ctx context.Context, transactionID int64, id int64, thumbnail bool) (models.TransactionAttachmentModel, io.ReadCloser, error) {
+	attachment, err := tas.rpts.TsctAtt.GetDetail(ctx, transactionID, id)
+	if err != nil {
+		return models.TransactionAttachmentModel{}, nil, err
+	}
+
+	key := attachment.StorageKey
+	if thumbnail {
+		if attachment.ThumbnailKey == nil {
+			return models.TransactionAttachmentModel{}, nil, huma.Error404NotFound("Attachment has no thumbnail")
+		}
+		key = *attachment.ThumbnailKey
+	}
+
+	content, err := tas.storage.Open(ctx, key)
+	if errors.Is(err, clients.ErrAttachmentNotFound) {
+		return models.TransactionAttachmentModel{}, nil, huma.Error404NotFound("Attachment file is missing from storage")
+	}
+	if err != nil {
+		observability.RecordError("storage")
+		return models.TransactionAttachmentModel{}, nil, huma.Error500InternalServerError("Unable to read attachment", err)
+	}
+
+	return attachment, content, nil
+}
+
+// Delete soft deletes an attachment; like its transaction, the stored files are kept
+func (tas TransactionAttachmentService) Delete(ctx context.Context, transactionID int64, id int64) error {
+	existing, err := tas.rpts.Tsct.GetDetail(ctx, transactionID)
+	if err != nil {
+		return err
+	}
+
+	var destAccountID *int64
+	if existing.DestinationAccount != nil {
+		destAccountID = &existing.DestinationAccount.ID
+	}
+	if err := tas.tsvc.RequireEditableAccounts(ctx, existing.Account.ID, destAccountID); err != nil {
+		return err
+	}
+
+	attachment, err := tas.rpts.TsctAtt.GetDetail(ctx, transactionID, id)
+	if err != nil {
+		return err
+	}
+
+	tx, err := tas.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := tas.rpts.WithTx(ctx, tx)
+	if err := rootTx.TsctAtt.Delete(ctx, transactionID, id); err != nil {
+		return err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityTransactionAttachment, id, constants.AuditOperationDelete, attachment, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+	return nil
+}
+
+// sanitizeAttachmentFileName keeps the base name of an uploaded file without control characters, capped at 255 bytes
+func sanitizeAttachmentFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, filepath.Base(strings.ReplaceAll(name, `\`, "/")))
+	name = strings.TrimSpace(name)
+
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	for len(name) > 255 {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
@@ -45,7 +45,12 @@ func NewTransactionBulkService(rpts *repositories.RootRepository, rdb *redis.Cli
 	}
 }
 
-// SaveDraft saves pending transaction updates to Redis (single global draft)
+// bulkDraftKey scopes the draft savepoint to the authenticated user
+func bulkDraftKey(ctx context.Context) string {
+	return fmt.Sprintf("%s:%d", constants.BulkDraftKey, common.GetUserID(ctx))
+}
+
+// SaveDraft saves pending transaction updates to Redis (single draft per user)
 func (tbs TransactionBulkService) SaveDraft(ctx context.Context, draft models.BulkTransactionDraftModel) (models.BulkTransactionDraftResponseModel, error) {
 	if len(draft.Updates) > maxBulkDraftSize {
 		return models.BulkTransactionDraftResponseModel{}, huma.Error400BadRequest(
@@ -64,7 +69,7 @@ func (tbs TransactionBulkService) SaveDraft(ctx context.Context, draft models.Bu
 	}
 
 	// Check if draft exists to preserve createdAt
-	if existing, err := tbs.rdb.Get(ctx, constants.BulkDraftKey).Result(); err == nil {
+	if existing, err := tbs.rdb.Get(ctx, bulkDraftKey(ctx)).Result(); err == nil {
 		var existingData bulkTransactionDraftData
 		if json.Unmarshal([]byte(existing), &existingData) == nil {
 			draftData.Metadata.CreatedAt = existingData.Metadata.CreatedAt
@@ -76,7 +81,7 @@ func (tbs TransactionBulkService) SaveDraft(ctx context.Context, draft models.Bu
 		return models.BulkTransactionDraftResponseModel{}, huma.Error500InternalServerError("Failed to serialize draft", err)
 	}
 
-	if err := tbs.rdb.Set(ctx, constants.BulkDraftKey, jsonData, constants.CacheTTLBulkDraft).Err(); err != nil {
+	if err := tbs.rdb.Set(ctx, bulkDraftKey(ctx), jsonData, constants.CacheTTLBulkDraft).Err(); err != nil {
 		observability.RecordError("redis")
 		return models.BulkTransactionDraftResponseModel{}, huma.Error500InternalServerError("Failed to save draft", err)
 	}
@@ -91,7 +96,7 @@ func (tbs TransactionBulkService) SaveDraft(ctx context.Context, draft models.Bu
 
 // GetDraft retrieves saved draft from Redis
 func (tbs TransactionBulkService) GetDraft(ctx context.Context) (models.BulkTransactionDraftModel, models.BulkTransactionDraftResponseModel, error) {
-	jsonData, err := tbs.rdb.Get(ctx, constants.BulkDraftKey).Result()
+	jsonData, err := tbs.rdb.Get(ctx, bulkDraftKey(ctx)).Result()
 	if err == redis.Nil {
 		return models.BulkTransactionDraftModel{}, models.BulkTransactionDraftResponseModel{}, huma.Error404NotFound("Draft not found or expired")
 	}
@@ -105,7 +110,7 @@ func (tbs TransactionBulkService) GetDraft(ctx context.Context) (models.BulkTran
 		return models.BulkTransactionDraftModel{}, models.BulkTransactionDraftResponseModel{}, huma.Error500InternalServerError("Failed to parse draft", err)
 	}
 
-	ttl, _ := tbs.rdb.TTL(ctx, constants.BulkDraftKey).Result()
+	ttl, _ := tbs.rdb.TTL(ctx, bulkDraftKey(ctx)).Result()
 
 	return models.BulkTransactionDraftModel{
 			Updates: draftData.Updates,
@@ -120,6 +125,7 @@ func (tbs TransactionBulkService) GetDraft(ctx context.Context) (models.BulkTran
 // CommitDraft applies all draft changes atomically to database
 func (tbs TransactionBulkService) CommitDraft(ctx context.Context) (models.BulkTransactionCommitResponseModel, error) {
 	startTime := time.Now()
+	ctx = common.WithAuditSource(ctx, constants.AuditSourceBulkCommit)
 
 	// 1. Retrieve draft from Redis
 	draft, _, err := tbs.GetDraft(ctx)
@@ -136,6 +142,17 @@ func (tbs TransactionBulkService) CommitDraft(ctx context.Context) (models.BulkT
 
 	rootTx := tbs.rpts.WithTx(ctx, tx)
 
+	// Rules fill the fields an update leaves out, so they are loaded once for the whole draft
+	rules, err := rootTx.TsctRule.GetEvaluable(ctx, nil)
+	if err != nil {
+		return models.BulkTransactionCommitResponseModel{}, err
+	}
+	ruleMatchers := newTransactionRuleMatchers(rules)
+	baseCurrency, err := rootTx.CurConfig.GetBaseCurrency(ctx)
+	if err != nil {
+		return models.BulkTransactionCommitResponseModel{}, huma.Error500InternalServerError("Failed to retrieve base currency config")
+	}
+
 	// 3. Process each transaction update
 	updatedIDs := make([]int64, 0, len(draft.Updates))
 	affectedAccounts := make(map[int64]bool)
@@ -165,12 +182,27 @@ func (tbs TransactionBulkService) CommitDraft(ctx context.Context) (models.BulkT
 			)
 		}
 
-		// Determine new values (use existing if not provided)
 		newType := existing.Type
 		if update.Type != nil {
 			newType = *update.Type
 		}
 
+		var ruleTagIDs []int64
+		if !update.SkipRules && len(ruleMatchers) > 0 {
+			outcome := evaluateTransactionRules(ruleMatchers, bulkTransactionRuleSubject(existing, update.UpdateTransactionModel, baseCurrency))
+			if update.CategoryID == nil {
+				update.CategoryID = outcome.CategoryID
+			}
+			if update.Note == nil {
+				update.Note = outcome.Note
+			}
+			if update.DestinationAccountID == nil && newType == "transfer" {
+				update.DestinationAccountID = outcome.DestinationAccountID
+			}
+			ruleTagIDs = outcome.TagIDs
+		}
+
+		// Determine new values (use existing if not provided)
 		newAmount := existing.Amount
 		if update.Amount != nil {
 			newAmount = *update.Amount
@@ -200,6 +232,26 @@ func (tbs TransactionBulkService) CommitDraft(ctx context.Context) (models.BulkT
 			}
 		}
 
+		if err := validateTransferFields(newType, update.DestinationAmount, update.FeeAmount, update.FeeCategoryID); err != nil {
+			return models.BulkTransactionCommitResponseModel{}, huma.Error400BadRequest(
+				fmt.Sprintf("Transaction %d: %s", update.ID, err.Error()),
+			)
+		}
+
+		// A destination amount of 0 credits the amount again, and only transfers keep one
+		newDestAmount := existing.DestinationAmount
+		if update.DestinationAmount != nil {
+			newDestAmount = update.DestinationAmount
+			if *newDestAmount == 0 {
+				newDestAmount = nil
+			}
+		}
+		if newType != "transfer" && existing.DestinationAmount != nil {
+			none := int64(0)
+			update.DestinationAmount = &none
+			newDestAmount = nil
+		}
+
 		// Get old destination account ID
 		var oldDestAccountID *int64
 		if existing.DestinationAccount != nil {
@@ -207,8 +259,19 @@ func (tbs TransactionBulkService) CommitDraft(ctx context.Context) (models.BulkT
 			oldDestAccountID = &oldDestID
 		}
 
+		if err := tbs.tsvc.RequireUnlocked(existing); err != nil {
+			return models.BulkTransactionCommitResponseModel{}, huma.Error409Conflict(
+				fmt.Sprintf("Transaction %d: %s", update.ID, err.Error()),
+			)
+		}
+
+		// Shared accounts need the owner or editor role, before and after the change
+		if err := tbs.tsvc.RequireEditableAccounts(ctx, existing.Account.ID, oldDestAccountID); err != nil {
+			return models.BulkTransactionCommitResponseModel{}, err
+		}
+
 		// Revert old balance changes
-		if err := tbs.tsvc.RevertBalanceChanges(ctx, rootTx, existing.Type, existing.Amount, existing.Account.ID, oldDestAccountID); err != nil {
+		if err := tbs.tsvc.RevertBalanceChanges(ctx, rootTx, existing.Type, existing.Amount, existing.Account.ID, oldDestAccountID, existing.DestinationAmount); err != nil {
 			return models.BulkTransactionCommitResponseModel{}, err
 		}
 
@@ -218,9 +281,17 @@ func (tbs TransactionBulkService) CommitDraft(ctx context.Context) (models.BulkT
 				fmt.Sprintf("Transaction %d: %s", update.ID, err.Error()),
 			)
 		}
+		if err := tbs.tsvc.ValidateSplits(existing, newType, newAmount); err != nil {
+			return models.BulkTransactionCommitResponseModel{}, huma.Error400BadRequest(
+				fmt.Sprintf("Transaction %d: %s", update.ID, err.Error()),
+			)
+		}
+		if err := tbs.tsvc.RequireEditableAccounts(ctx, newAccountID, newDestAccountID); err != nil {
+			return models.BulkTransactionCommitResponseModel{}, err
+		}
 
 		// Apply new balance changes
-		if err := tbs.tsvc.ApplyBalanceChanges(ctx, rootTx, newType, newAmount, newAccountID, newDestAccountID); err != nil {
+		if err := tbs.tsvc.ApplyBalanceChanges(ctx, rootTx, newType, newAmount, newAccountID, newDestAccountID, newDestAmount); err != nil {
 			return models.BulkTransactionCommitResponseModel{}, err
 		}
 
@@ -232,16 +303,45 @@ func (tbs TransactionBulkService) CommitDraft(ctx context.Context) (models.BulkT
 			AccountID:            update.AccountID,
 			CategoryID:           update.CategoryID,
 			DestinationAccountID: update.DestinationAccountID,
+			DestinationAmount:    update.DestinationAmount,
 			Note:                 update.Note,
 			Latitude:             update.Latitude,
 			Longitude:            update.Longitude,
+			Status:               update.Status,
 		}
 
-		_, err = rootTx.Tsct.Update(ctx, update.ID, updateModel)
+		updated, err := rootTx.Tsct.Update(ctx, update.ID, updateModel)
 		if err != nil {
 			return models.BulkTransactionCommitResponseModel{}, err
 		}
 
+		if updated, err = tbs.tsvc.SyncTransferFee(ctx, rootTx, updated, update.FeeAmount, update.FeeCategoryID); err != nil {
+			return models.BulkTransactionCommitResponseModel{}, err
+		}
+		if updated.Fee != nil {
+			affectedCategories[updated.Fee.CategoryID] = true
+		}
+		if existing.Fee != nil {
+			affectedCategories[existing.Fee.CategoryID] = true
+		}
+
+		if len(ruleTagIDs) > 0 {
+			if err := rootTx.TsctRule.AddTags(ctx, update.ID, ruleTagIDs); err != nil {
+				return models.BulkTransactionCommitResponseModel{}, err
+			}
+			if updated, err = rootTx.Tsct.GetDetail(ctx, update.ID); err != nil {
+				return models.BulkTransactionCommitResponseModel{}, err
+			}
+		}
+
+		if err := tbs.tsvc.RecordRevision(ctx, rootTx, existing, updated); err != nil {
+			return models.BulkTransactionCommitResponseModel{}, err
+		}
+
+		if err := recordAudit(ctx, rootTx, constants.EntityTransaction, update.ID, constants.AuditOperationUpdate, existing, updated); err != nil {
+			return models.BulkTransactionCommitResponseModel{}, err
+		}
+
 		updatedIDs = append(updatedIDs, update.ID)
 	}
 
@@ -272,7 +372,7 @@ func (tbs TransactionBulkService) CommitDraft(ctx context.Context) (models.BulkT
 		}
 	}()
 
-	tbs.rdb.Del(ctx, constants.BulkDraftKey)
+	tbs.rdb.Del(ctx, bulkDraftKey(ctx))
 
 	return models.BulkTransactionCommitResponseModel{
 		SuccessCount: len(updatedIDs),
@@ -281,9 +381,64 @@ func (tbs TransactionBulkService) CommitDraft(ctx context.Context) (models.BulkT
 	}, nil
 }
 
+// bulkTransactionRuleSubject describes a transaction as it will be once the draft update is applied, for evaluating rules
+func bulkTransactionRuleSubject(existing models.TransactionModel, update models.UpdateTransactionModel, baseCurrency string) models.TransactionRuleSubjectModel {
+	subject := models.TransactionRuleSubjectModel{
+		ID:           existing.ID,
+		Date:         existing.Date,
+		Type:         existing.Type,
+		Amount:       existing.Amount,
+		AccountID:    existing.Account.ID,
+		CategoryID:   existing.Category.ID,
+		CurrencyCode: baseCurrency,
+		Note:         existing.Note,
+		Latitude:     existing.Latitude,
+		Longitude:    existing.Longitude,
+	}
+	if existing.DestinationAccount != nil {
+		subject.DestinationAccountID = &existing.DestinationAccount.ID
+	}
+	if existing.CurrencyCode != nil {
+		subject.CurrencyCode = *existing.CurrencyCode
+	}
+	for _, tag := range existing.Tags {
+		subject.TagIDs = append(subject.TagIDs, tag.ID)
+	}
+
+	if update.Date != nil {
+		subject.Date = *update.Date
+	}
+	if update.Type != nil {
+		subject.Type = *update.Type
+	}
+	if update.Amount != nil {
+		subject.Amount = *update.Amount
+	}
+	if update.AccountID != nil {
+		subject.AccountID = *update.AccountID
+	}
+	if update.CategoryID != nil {
+		subject.CategoryID = *update.CategoryID
+	}
+	if update.DestinationAccountID != nil {
+		subject.DestinationAccountID = update.DestinationAccountID
+	}
+	if update.CurrencyCode != nil && *update.CurrencyCode != "" {
+		subject.CurrencyCode = *update.CurrencyCode
+	}
+	if update.Note != nil {
+		subject.Note = update.Note
+	}
+	if update.Latitude != nil && update.Longitude != nil {
+		subject.Latitude = update.Latitude
+		subject.Longitude = update.Longitude
+	}
+	return subject
+}
+
 // DeleteDraft removes draft from Redis without committing
 func (tbs TransactionBulkService) DeleteDraft(ctx context.Context) error {
-	result, err := tbs.rdb.Del(ctx, constants.BulkDraftKey).Result()
+	result, err := tbs.rdb.Del(ctx, bulkDraftKey(ctx)).Result()
 	if err != nil {
 		observability.RecordError("redis")
 		return huma.Error500InternalServerError("Failed to delete draft", err)
@@ -0,0 +1,299 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/dimasbaguspm/spenicle-api/internal/repositories"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	duplicateWindowDays      = 3                   // Transactions further apart than this are never duplicates
+	duplicateWarningMinScore = 60                  // Lowest score reported when a transaction is created
+	duplicateReviewLimit     = 2000                // Pairs scored by one review request
+	duplicateRecordedWindow  = 2 * time.Minute     // Transactions recorded this close together look like a double submit
+	duplicateReviewRange     = 90 * 24 * time.Hour // Dates reviewed when no start date is given
+)
+
+type TransactionDuplicateService struct {
+	rpts *repositories.RootRepository
+	rdb  *redis.Client
+	tsvc TransactionService
+}
+
+func NewTransactionDuplicateService(rpts *repositories.RootRepository, rdb *redis.Client, tsvc TransactionService) TransactionDuplicateService {
+	return TransactionDuplicateService{rpts, rdb, tsvc}
+}
+
+// GetPaged lists pairs of transactions that may be the same one, most likely first
+func (tds TransactionDuplicateService) GetPaged(ctx context.Context, query models.TransactionDuplicatesSearchModel) (models.TransactionDuplicatesPagedModel, error) {
+	endDate := time.Now()
+	if query.EndDate != "" {
+		parsed, err := time.Parse(time.RFC3339, query.EndDate)
+		if err != nil {
+			return models.TransactionDuplicatesPagedModel{}, huma.Error400BadRequest("Invalid end date", err)
+		}
+		endDate = parsed
+	}
+	startDate := endDate.Add(-duplicateReviewRange)
+	if query.StartDate != "" {
+		parsed, err := time.Parse(time.RFC3339, query.StartDate)
+		if err != nil {
+			return models.TransactionDuplicatesPagedModel{}, huma.Error400BadRequest("Invalid start date", err)
+		}
+		startDate = parsed
+	}
+
+	pairs, err := tds.rpts.TsctDup.GetPairs(ctx, startDate, endDate, query.AccountIDs, duplicateWindowDays, duplicateReviewLimit)
+	if err != nil {
+		return models.TransactionDuplicatesPagedModel{}, err
+	}
+
+	items := []models.TransactionDuplicatePairModel{}
+	for _, pair := range pairs {
+		pair.Score, pair.Reasons = scoreTransactionDuplicate(pair.Transaction, pair.Duplicate)
+		if pair.Score >= query.MinScore {
+			items = append(items, pair)
+		}
+	}
+	slices.SortStableFunc(items, func(a, b models.TransactionDuplicatePairModel) int {
+		return b.Score - a.Score
+	})
+
+	totalCount := len(items)
+	offset := min((query.PageNumber-1)*query.PageSize, totalCount)
+	end := min(offset+query.PageSize, totalCount)
+
+	totalPages := 0
+	if totalCount > 0 {
+		totalPages = (totalCount + query.PageSize - 1) / query.PageSize
+	}
+
+	return models.TransactionDuplicatesPagedModel{
+		Items:      items[offset:end],
+		PageNumber: query.PageNumber,
+		PageSize:   query.PageSize,
+		TotalCount: totalCount,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// Merge keeps the transaction and deletes its duplicates in a single database transaction
+// Duplicates must be on the same account with the same type and amount. Their tags, relations, attachments, split lines and
+// reimbursements move to the kept transaction, and they are deleted the way TransactionService.Delete does, fee included
+func (tds TransactionDuplicateService) Merge(ctx context.Context, id int64, payload models.MergeTransactionDuplicatesModel) (models.TransactionMergeResultModel, error) {
+	tx, err := tds.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.TransactionMergeResultModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := tds.rpts.WithTx(ctx, tx)
+	existing, err := rootTx.Tsct.GetDetailForUpdate(ctx, id)
+	if err != nil {
+		return models.TransactionMergeResultModel{}, err
+	}
+
+	var keptDestAccountID *int64
+	if existing.DestinationAccount != nil {
+		keptDestAccountID = &existing.DestinationAccount.ID
+	}
+	if err := tds.tsvc.RequireEditableAccounts(ctx, existing.Account.ID, keptDestAccountID); err != nil {
+		return models.TransactionMergeResultModel{}, err
+	}
+
+	result := models.TransactionMergeResultModel{}
+	var merged []models.TransactionModel
+	for _, duplicateID := range payload.DuplicateIDs {
+		if duplicateID == id {
+			return models.TransactionMergeResultModel{}, huma.Error400BadRequest("A transaction cannot be merged into itself")
+		}
+		if slices.ContainsFunc(merged, func(t models.TransactionModel) bool { return t.ID == duplicateID }) {
+			continue
+		}
+
+		duplicate, err := rootTx.Tsct.GetDetailForUpdate(ctx, duplicateID)
+		if err != nil {
+			return models.TransactionMergeResultModel{}, huma.Error400BadRequest(fmt.Sprintf("Transaction %d to merge not found", duplicateID), err)
+		}
+		if duplicate.Account.ID != existing.Account.ID || duplicate.Type != existing.Type || duplicate.Amount != existing.Amount {
+			return models.TransactionMergeResultModel{}, huma.Error400BadRequest(
+				fmt.Sprintf("Transaction %d does not have the account, type and amount of the transaction it is merged into", duplicateID),
+			)
+		}
+		if err := tds.tsvc.RequireUnlocked(duplicate); err != nil {
+			return models.TransactionMergeResultModel{}, err
+		}
+
+		tagsMoved, relationsMoved, attachmentsMoved, err := rootTx.TsctDup.MoveReferences(ctx, duplicateID, id)
+		if err != nil {
+			return models.TransactionMergeResultModel{}, err
+		}
+		result.TagsMoved += tagsMoved
+		result.RelationsMoved += relationsMoved
+		result.AttachmentsMoved += attachmentsMoved
+
+		if err := tds.tsvc.Trash(ctx, rootTx, duplicate); err != nil {
+			return models.TransactionMergeResultModel{}, err
+		}
+
+		merged = append(merged, duplicate)
+	}
+
+	transaction, err := rootTx.Tsct.GetDetail(ctx, id)
+	if err != nil {
+		return models.TransactionMergeResultModel{}, err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityTransaction, id, constants.AuditOperationUpdate, existing, transaction); err != nil {
+		return models.TransactionMergeResultModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.TransactionMergeResultModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
+	geoIndexMgr := tds.tsvc.GetGeoIndexManager()
+	for _, duplicate := range merged {
+		if duplicate.Latitude != nil && duplicate.Longitude != nil {
+			go func(id int64) {
+				geoIndexMgr.Remove(context.Background(), id)
+			}(duplicate.ID)
+		}
+
+		if err := common.InvalidateCacheForEntity(ctx, tds.rdb, constants.EntityTransaction, map[string]interface{}{
+			"transactionId": duplicate.ID,
+			"accountId":     duplicate.Account.ID,
+			"categoryId":    duplicate.Category.ID,
+		}); err != nil {
+			observability.NewLogger("service", "TransactionDuplicateService").Warn("cache invalidation failed", "error", err)
+		}
+	}
+
+	if err := common.InvalidateCacheForEntity(ctx, tds.rdb, constants.EntityTransaction, map[string]interface{}{
+		"transactionId": id,
+		"accountId":     transaction.Account.ID,
+		"categoryId":    transaction.Category.ID,
+	}); err != nil {
+		observability.NewLogger("service", "TransactionDuplicateService").Warn("cache invalidation failed", "error", err)
+	}
+
+	result.Transaction = transaction
+	result.MergedCount = len(merged)
+	return result, nil
+}
+
+// findTransactionDuplicates scores the existing transactions that may be the same as the given one, most likely first
+// Candidates below minScore are left out
+func findTransactionDuplicates(ctx context.Context, rpts repositories.RootRepository, transaction models.TransactionModel, minScore int) ([]models.TransactionDuplicateCandidateModel, error) {
+	entry := models.TransactionDuplicateEntryModel{
+		ID:        transaction.ID,
+		Type:      transaction.Type,
+		Date:      transaction.Date,
+		Amount:    transaction.Amount,
+		AccountID: transaction.Account.ID,
+		Note:      transaction.Note,
+		CreatedAt: transaction.CreatedAt,
+	}
+
+	matches, err := rpts.TsctDup.FindMatches(ctx, entry, duplicateWindowDays, 20)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []models.TransactionDuplicateCandidateModel
+	for _, match := range matches {
+		score, reasons := scoreTransactionDuplicate(match, entry)
+		if score < minScore {
+			continue
+		}
+		candidates = append(candidates, models.TransactionDuplicateCandidateModel{
+			Transaction: match,
+			Score:       score,
+			Reasons:     reasons,
+		})
+	}
+	slices.SortStableFunc(candidates, func(a, b models.TransactionDuplicateCandidateModel) int {
+		return b.Score - a.Score
+	})
+	return candidates, nil
+}
+
+// scoreTransactionDuplicate rates from 0 to 100 how likely two transactions on the same account with the same type
+// and amount are the same one
+// The match itself is worth 40, dates up to 30 (same day 30, one day apart 20, two days 10), notes up to 20 by word
+// overlap, and being recorded within two minutes of each other, as with a double submit, another 10
+func scoreTransactionDuplicate(a, b models.TransactionDuplicateEntryModel) (int, []string) {
+	score := 40
+	reasons := []string{"same_account_amount_type"}
+
+	daysApart := int(a.Date.Sub(b.Date).Abs().Hours() / 24)
+	if a.Date.Format(time.DateOnly) == b.Date.Format(time.DateOnly) {
+		daysApart = 0
+	}
+	switch {
+	case daysApart == 0:
+		score += 30
+		reasons = append(reasons, "same_day")
+	case daysApart < duplicateWindowDays:
+		score += 30 - 10*daysApart
+		reasons = append(reasons, "within_days")
+	}
+
+	similarity := noteSimilarity(a.Note, b.Note)
+	score += int(similarity * 20)
+	switch {
+	case similarity == 1:
+		reasons = append(reasons, "same_note")
+	case similarity >= 0.5:
+		reasons = append(reasons, "similar_note")
+	}
+
+	if a.CreatedAt.Sub(b.CreatedAt).Abs() <= duplicateRecordedWindow {
+		score += 10
+		reasons = append(reasons, "recorded_together")
+	}
+
+	return score, reasons
+}
+
+// noteSimilarity compares the words of two notes ignoring case and punctuation, from 0 (none shared) to 1 (same words)
+// Two missing notes count as the same
+func noteSimilarity(a, b *string) float64 {
+	wordsA := noteWords(a)
+	wordsB := noteWords(b)
+	if len(wordsA) == 0 && len(wordsB) == 0 {
+		return 1
+	}
+
+	shared := 0
+	for word := range wordsA {
+		if wordsB[word] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(wordsA)+len(wordsB)-shared)
+}
+
+func noteWords(note *string) map[string]bool {
+	words := map[string]bool{}
+	if note == nil {
+		return words
+	}
+	for _, word := range strings.FieldsFunc(strings.ToLower(*note), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		words[word] = true
+	}
+	return words
+}
@@ -2,7 +2,10 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
+	"github.com/danielgtaylor/huma/v2"
 	"github.com/dimasbaguspm/spenicle-api/internal/common"
 	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
@@ -11,6 +14,27 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// maxTransactionGraphSize caps the transactions returned by one graph request
+const maxTransactionGraphSize = 500
+
+// transactionRelationRule says which transactions a relation type may link
+type transactionRelationRule struct {
+	sourceTypes  []string // Types the source transaction may have; empty allows any
+	relatedTypes []string // Types the related transaction may have; empty allows any
+	sameType     bool     // Both transactions must have the same type
+	singleTarget bool     // A transaction may be the source of only one relation of the type
+	undirected   bool     // Reads the same both ways, so it is never followed when looking for cycles
+}
+
+// transactionRelationRules is the registry of relation types; types missing from it are rejected
+var transactionRelationRules = map[string]transactionRelationRule{
+	constants.TransactionRelationRefundOf:    {sourceTypes: []string{"income"}, relatedTypes: []string{"expense"}, singleTarget: true},
+	constants.TransactionRelationFeeOf:       {sourceTypes: []string{"expense"}, singleTarget: true},
+	constants.TransactionRelationSplitOf:     {sameType: true, singleTarget: true},
+	constants.TransactionRelationDuplicateOf: {sameType: true, singleTarget: true},
+	constants.TransactionRelationRelated:     {undirected: true},
+}
+
 type TransactionRelationService struct {
 	rpts *repositories.RootRepository
 	rdb  *redis.Client
@@ -21,25 +45,46 @@ func NewTransactionRelationService(rpts *repositories.RootRepository, rdb *redis
 }
 
 func (trs TransactionRelationService) GetPaged(ctx context.Context, q models.TransactionRelationsSearchModel) (models.TransactionRelationsPagedModel, error) {
-	cacheKey := common.BuildPagedCacheKey(constants.EntityTransactionRelation, q)
+	cacheKey := common.BuildPagedCacheKey(ctx, constants.EntityTransactionRelation, q)
 	return common.FetchWithCache(ctx, trs.rdb, cacheKey, constants.CacheTTLPaged, func(ctx context.Context) (models.TransactionRelationsPagedModel, error) {
 		return trs.rpts.TsctRel.GetPaged(ctx, q)
 	}, "transaction_relation")
 }
 
 func (trs TransactionRelationService) GetDetail(ctx context.Context, p models.TransactionRelationGetModel) (models.TransactionRelationModel, error) {
-	cacheKey := common.BuildPagedCacheKey(constants.EntityTransactionRelation, p)
+	cacheKey := common.BuildPagedCacheKey(ctx, constants.EntityTransactionRelation, p)
 	return common.FetchWithCache(ctx, trs.rdb, cacheKey, constants.CacheTTLPaged, func(ctx context.Context) (models.TransactionRelationModel, error) {
 		return trs.rpts.TsctRel.GetDetail(ctx, p)
 	}, "transaction_relation")
 }
 
+// Create links two transactions once the relation type's rules allow it
+// Directed relations may not close a cycle, and an undirected one may not repeat the reverse of an existing one
 func (trs TransactionRelationService) Create(ctx context.Context, p models.CreateTransactionRelationModel) (models.TransactionRelationModel, error) {
-	relation, err := trs.rpts.TsctRel.Create(ctx, p)
+	if err := trs.validateRelation(ctx, p); err != nil {
+		return models.TransactionRelationModel{}, err
+	}
+
+	tx, err := trs.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.TransactionRelationModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := trs.rpts.WithTx(ctx, tx)
+	relation, err := rootTx.TsctRel.Create(ctx, p)
 	if err != nil {
 		return relation, err
 	}
 
+	if err := recordAudit(ctx, rootTx, constants.EntityTransactionRelation, relation.ID, constants.AuditOperationCreate, nil, relation); err != nil {
+		return models.TransactionRelationModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.TransactionRelationModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
 	if err := common.InvalidateCacheForEntity(ctx, trs.rdb, constants.EntityTransactionRelation, map[string]interface{}{"relationId": relation.ID}); err != nil {
 		observability.NewLogger("service", "TransactionRelationService").Warn("cache invalidation failed", "error", err)
 	}
@@ -48,8 +93,15 @@ func (trs TransactionRelationService) Create(ctx context.Context, p models.Creat
 }
 
 func (trs TransactionRelationService) Delete(ctx context.Context, p models.DeleteTransactionRelationModel) error {
+	tx, err := trs.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
 	// Get relation details before deletion to know which transaction caches to invalidate
-	_, err := trs.rpts.TsctRel.GetDetail(ctx, models.TransactionRelationGetModel{
+	rootTx := trs.rpts.WithTx(ctx, tx)
+	existing, err := rootTx.TsctRel.GetDetail(ctx, models.TransactionRelationGetModel{
 		SourceTransactionID: p.SourceTransactionID,
 		RelationID:          p.RelationID,
 	})
@@ -57,14 +109,128 @@ func (trs TransactionRelationService) Delete(ctx context.Context, p models.Delet
 		return err
 	}
 
-	err = trs.rpts.TsctRel.Delete(ctx, p)
-	if err != nil {
+	if err := rootTx.TsctRel.Delete(ctx, p); err != nil {
+		return err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityTransactionRelation, p.RelationID, constants.AuditOperationDelete, existing, nil); err != nil {
 		return err
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
 	if err := common.InvalidateCacheForEntity(ctx, trs.rdb, constants.EntityTransactionRelation, map[string]interface{}{"relationId": p.RelationID}); err != nil {
 		observability.NewLogger("service", "TransactionRelationService").Warn("cache invalidation failed", "error", err)
 	}
 
 	return nil
 }
+
+// GetGraph returns the transactions connected to the given one through relations in either direction, with their totals
+func (trs TransactionRelationService) GetGraph(ctx context.Context, transactionID int64) (models.TransactionGraphModel, error) {
+	nodes, edges, err := trs.rpts.TsctRel.GetGraph(ctx, transactionID, maxTransactionGraphSize+1)
+	if err != nil {
+		return models.TransactionGraphModel{}, err
+	}
+
+	graph := models.TransactionGraphModel{
+		TransactionID: transactionID,
+		Nodes:         nodes,
+		Edges:         edges,
+	}
+	if len(nodes) > maxTransactionGraphSize {
+		graph.Nodes = nodes[:maxTransactionGraphSize]
+		graph.Truncated = true
+
+		// Drop the relations leading to the transactions cut off the graph
+		kept := make(map[int64]bool, len(graph.Nodes))
+		for _, node := range graph.Nodes {
+			kept[node.ID] = true
+		}
+		graph.Edges = slices.DeleteFunc(edges, func(edge models.TransactionRelationModel) bool {
+			return !kept[edge.SourceTransactionID] || !kept[edge.RelatedTransactionID]
+		})
+	}
+
+	for _, node := range graph.Nodes {
+		switch node.Type {
+		case "income":
+			graph.IncomeAmount += node.Amount
+		case "expense":
+			graph.ExpenseAmount += node.Amount
+		}
+	}
+	graph.Net = graph.IncomeAmount - graph.ExpenseAmount
+
+	return graph, nil
+}
+
+func (trs TransactionRelationService) validateRelation(ctx context.Context, p models.CreateTransactionRelationModel) error {
+	rule, ok := transactionRelationRules[p.RelationType]
+	if !ok {
+		return huma.Error400BadRequest(fmt.Sprintf("Unknown relation type %q", p.RelationType))
+	}
+	if p.SourceTransactionID == p.RelatedTransactionID {
+		return huma.Error400BadRequest("A transaction cannot be related to itself")
+	}
+
+	source, err := trs.rpts.Tsct.GetDetail(ctx, p.SourceTransactionID)
+	if err != nil {
+		return err
+	}
+	related, err := trs.rpts.Tsct.GetDetail(ctx, p.RelatedTransactionID)
+	if err != nil {
+		return huma.Error404NotFound("Related transaction not found")
+	}
+
+	if len(rule.sourceTypes) > 0 && !slices.Contains(rule.sourceTypes, source.Type) {
+		return huma.Error400BadRequest(fmt.Sprintf("The source of a %s relation must be of type %v, not %s", p.RelationType, rule.sourceTypes, source.Type))
+	}
+	if len(rule.relatedTypes) > 0 && !slices.Contains(rule.relatedTypes, related.Type) {
+		return huma.Error400BadRequest(fmt.Sprintf("The related transaction of a %s relation must be of type %v, not %s", p.RelationType, rule.relatedTypes, related.Type))
+	}
+	if rule.sameType && source.Type != related.Type {
+		return huma.Error400BadRequest(fmt.Sprintf("Both transactions of a %s relation must have the same type", p.RelationType))
+	}
+
+	// The partial unique index idx_transaction_relations_single_target settles concurrent inserts; this check gives the
+	// usual case a clear error before the insert
+	if rule.singleTarget {
+		count, err := trs.rpts.TsctRel.CountBySource(ctx, p.SourceTransactionID, p.RelationType)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return huma.Error409Conflict(fmt.Sprintf("Transaction is already the source of a %s relation", p.RelationType))
+		}
+	}
+
+	if rule.undirected {
+		exists, err := trs.rpts.TsctRel.Exists(ctx, p.RelatedTransactionID, p.SourceTransactionID, p.RelationType)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return huma.Error409Conflict("Transaction relation already exists")
+		}
+		return nil
+	}
+
+	var undirectedTypes []string
+	for relationType, r := range transactionRelationRules {
+		if r.undirected {
+			undirectedTypes = append(undirectedTypes, relationType)
+		}
+	}
+	cycle, err := trs.rpts.TsctRel.HasPath(ctx, p.RelatedTransactionID, p.SourceTransactionID, undirectedTypes)
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return huma.Error409Conflict("Relation would create a cycle: the related transaction already leads back to the source")
+	}
+
+	return nil
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"slices"
+
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/repositories"
+)
+
+type TransactionRevisionService struct {
+	rpts *repositories.RootRepository
+	tsvc TransactionService
+}
+
+func NewTransactionRevisionService(rpts *repositories.RootRepository, tsvc TransactionService) TransactionRevisionService {
+	return TransactionRevisionService{rpts, tsvc}
+}
+
+func (trs TransactionRevisionService) GetList(ctx context.Context, transactionID int64) (models.TransactionRevisionsListModel, error) {
+	if _, err := trs.tsvc.GetDetail(ctx, transactionID); err != nil {
+		return models.TransactionRevisionsListModel{}, err
+	}
+	return trs.rpts.TsctRev.GetList(ctx, transactionID)
+}
+
+// Revert sets the fields changed by a revision back to their values before it, through TransactionService.Update
+// The revert is itself recorded as a new revision. Updates cannot empty a field, so a destination account or coordinates
+// that were empty before the revision are left as they are; an empty note is restored as an empty string, and an empty payee
+// or destination amount is removed
+func (trs TransactionRevisionService) Revert(ctx context.Context, transactionID int64, revision int) (models.TransactionModel, error) {
+	existing, err := trs.rpts.Tsct.GetDetail(ctx, transactionID)
+	if err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	rev, err := trs.rpts.TsctRev.GetDetail(ctx, transactionID, revision)
+	if err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	var p models.UpdateTransactionModel
+	for _, field := range rev.ChangedFields {
+		switch field {
+		case "type":
+			p.Type = rev.Before.Type
+		case "date":
+			p.Date = rev.Before.Date
+		case "amount":
+			p.Amount = rev.Before.Amount
+		case "status":
+			p.Status = rev.Before.Status
+		case "accountId":
+			p.AccountID = rev.Before.AccountID
+		case "categoryId":
+			p.CategoryID = rev.Before.CategoryID
+		case "destinationAccountId":
+			p.DestinationAccountID = rev.Before.DestinationAccountID
+		case "destinationAmount":
+			none := int64(0)
+			p.DestinationAmount = &none
+			if rev.Before.DestinationAmount != nil {
+				p.DestinationAmount = rev.Before.DestinationAmount
+			}
+		case "payeeId":
+			none := int64(0)
+			p.PayeeID = &none
+			if rev.Before.PayeeID != nil {
+				p.PayeeID = rev.Before.PayeeID
+			}
+		case "note":
+			empty := ""
+			p.Note = &empty
+			if rev.Before.Note != nil {
+				p.Note = rev.Before.Note
+			}
+		case "latitude":
+			p.Latitude = rev.Before.Latitude
+		case "longitude":
+			p.Longitude = rev.Before.Longitude
+		}
+	}
+
+	// Coordinates are updated in pairs
+	if p.Latitude != nil && p.Longitude == nil {
+		p.Longitude = existing.Longitude
+	}
+	if p.Longitude != nil && p.Latitude == nil {
+		p.Latitude = existing.Latitude
+	}
+
+	return trs.tsvc.Update(ctx, transactionID, p, "")
+}
+
+// diffTransactionRevision lists the fields that differ between two versions of a transaction with their values on each side
+func diffTransactionRevision(before, after models.TransactionModel) ([]string, models.TransactionRevisionValuesModel, models.TransactionRevisionValuesModel) {
+	var fields []string
+	var from, to models.TransactionRevisionValuesModel
+
+	if before.Type != after.Type {
+		fields = append(fields, "type")
+		from.Type, to.Type = &before.Type, &after.Type
+	}
+	if !before.Date.Equal(after.Date) {
+		fields = append(fields, "date")
+		from.Date, to.Date = &before.Date, &after.Date
+	}
+	if before.Amount != after.Amount {
+		fields = append(fields, "amount")
+		from.Amount, to.Amount = &before.Amount, &after.Amount
+	}
+	if before.Status != after.Status {
+		fields = append(fields, "status")
+		from.Status, to.Status = &before.Status, &after.Status
+	}
+	if before.Account.ID != after.Account.ID {
+		fields = append(fields, "accountId")
+		from.AccountID, to.AccountID = &before.Account.ID, &after.Account.ID
+	}
+	if before.Category.ID != after.Category.ID {
+		fields = append(fields, "categoryId")
+		from.CategoryID, to.CategoryID = &before.Category.ID, &after.Category.ID
+	}
+
+	var beforeDest, afterDest *int64
+	if before.DestinationAccount != nil {
+		beforeDest = &before.DestinationAccount.ID
+	}
+	if after.DestinationAccount != nil {
+		afterDest = &after.DestinationAccount.ID
+	}
+	if !equalPtr(beforeDest, afterDest) {
+		fields = append(fields, "destinationAccountId")
+		from.DestinationAccountID, to.DestinationAccountID = beforeDest, afterDest
+	}
+	if !equalPtr(before.DestinationAmount, after.DestinationAmount) {
+		fields = append(fields, "destinationAmount")
+		from.DestinationAmount, to.DestinationAmount = before.DestinationAmount, after.DestinationAmount
+	}
+
+	var beforePayee, afterPayee *int64
+	if before.Payee != nil {
+		beforePayee = &before.Payee.ID
+	}
+	if after.Payee != nil {
+		afterPayee = &after.Payee.ID
+	}
+	if !equalPtr(beforePayee, afterPayee) {
+		fields = append(fields, "payeeId")
+		from.PayeeID, to.PayeeID = beforePayee, afterPayee
+	}
+
+	if !equalPtr(before.Note, after.Note) {
+		fields = append(fields, "note")
+		from.Note, to.Note = before.Note, after.Note
+	}
+	if !equalPtr(before.Latitude, after.Latitude) {
+		fields = append(fields, "latitude")
+		from.Latitude, to.Latitude = before.Latitude, after.Latitude
+	}
+	if !equalPtr(before.Longitude, after.Longitude) {
+		fields = append(fields, "longitude")
+		from.Longitude, to.Longitude = before.Longitude, after.Longitude
+	}
+
+	return fields, from, to
+}
+
+// transactionBalanceDeltas is the net change to each account balance when a transaction goes from before to after,
+// following the rules of TransactionService.ApplyBalanceChanges
+func transactionBalanceDeltas(before, after models.TransactionModel) []models.TransactionRevisionBalanceDeltaModel {
+	deltas := map[int64]int64{}
+	for account, amount := range transactionBalanceEffect(after) {
+		deltas[account] += amount
+	}
+	for account, amount := range transactionBalanceEffect(before) {
+		deltas[account] -= amount
+	}
+
+	items := []models.TransactionRevisionBalanceDeltaModel{}
+	for account, amount := range deltas {
+		if amount != 0 {
+			items = append(items, models.TransactionRevisionBalanceDeltaModel{AccountID: account, Amount: amount})
+		}
+	}
+	slices.SortFunc(items, func(a, b models.TransactionRevisionBalanceDeltaModel) int {
+		return int(a.AccountID - b.AccountID)
+	})
+	return items
+}
+
+func transactionBalanceEffect(t models.TransactionModel) map[int64]int64 {
+	effect := map[int64]int64{}
+	switch t.Type {
+	case "transfer":
+		if t.DestinationAccount != nil {
+			effect[t.Account.ID] -= t.Amount
+			effect[t.DestinationAccount.ID] += transferCredit(t.Amount, t.DestinationAmount)
+		}
+	case "income":
+		effect[t.Account.ID] += t.Amount
+	case "expense":
+		effect[t.Account.ID] -= t.Amount
+	}
+	return effect
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,561 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/dimasbaguspm/spenicle-api/internal/repositories"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	maxTransactionRuleRangeSize = 5000 // Transactions evaluated by one dry run or range re-apply
+)
+
+type TransactionRuleService struct {
+	rpts *repositories.RootRepository
+	rdb  *redis.Client
+	tsvc TransactionService
+}
+
+func NewTransactionRuleService(rpts *repositories.RootRepository, rdb *redis.Client, tsvc TransactionService) TransactionRuleService {
+	return TransactionRuleService{rpts, rdb, tsvc}
+}
+
+func (trs TransactionRuleService) GetPaged(ctx context.Context, query models.TransactionRulesSearchModel) (models.TransactionRulesPagedModel, error) {
+	cacheKey := common.BuildPagedCacheKey(ctx, constants.EntityTransactionRule, query)
+	return common.FetchWithCache(ctx, trs.rdb, cacheKey, constants.CacheTTLPaged, func(ctx context.Context) (models.TransactionRulesPagedModel, error) {
+		return trs.rpts.TsctRule.GetPaged(ctx, query)
+	}, "transaction_rule")
+}
+
+func (trs TransactionRuleService) GetDetail(ctx context.Context, id int64) (models.TransactionRuleModel, error) {
+	cacheKey := common.BuildDetailCacheKey(ctx, constants.EntityTransactionRule, id)
+	return common.FetchWithCache(ctx, trs.rdb, cacheKey, constants.CacheTTLDetail, func(ctx context.Context) (models.TransactionRuleModel, error) {
+		return trs.rpts.TsctRule.GetDetail(ctx, id)
+	}, "transaction_rule")
+}
+
+func (trs TransactionRuleService) Create(ctx context.Context, payload models.CreateTransactionRuleModel) (models.TransactionRuleModel, error) {
+	if err := trs.validateRule(ctx, payload.Conditions, payload.Actions); err != nil {
+		return models.TransactionRuleModel{}, err
+	}
+
+	tx, err := trs.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.TransactionRuleModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := trs.rpts.WithTx(ctx, tx)
+	rule, err := rootTx.TsctRule.Create(ctx, payload)
+	if err != nil {
+		return rule, err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityTransactionRule, rule.ID, constants.AuditOperationCreate, nil, rule); err != nil {
+		return models.TransactionRuleModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.TransactionRuleModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
+	if err := common.InvalidateCacheForEntity(ctx, trs.rdb, constants.EntityTransactionRule, map[string]interface{}{"transactionRuleId": rule.ID}); err != nil {
+		observability.NewLogger("service", "TransactionRuleService").Warn("cache invalidation failed", "error", err)
+	}
+
+	return rule, nil
+}
+
+func (trs TransactionRuleService) Update(ctx context.Context, id int64, payload models.UpdateTransactionRuleModel) (models.TransactionRuleModel, error) {
+	tx, err := trs.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.TransactionRuleModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := trs.rpts.WithTx(ctx, tx)
+	existing, err := rootTx.TsctRule.GetDetail(ctx, id)
+	if err != nil {
+		return models.TransactionRuleModel{}, err
+	}
+
+	conditions := existing.Conditions
+	if payload.Conditions != nil {
+		conditions = *payload.Conditions
+	}
+	actions := existing.Actions
+	if payload.Actions != nil {
+		actions = *payload.Actions
+	}
+	if err := trs.validateRule(ctx, conditions, actions); err != nil {
+		return models.TransactionRuleModel{}, err
+	}
+
+	rule, err := rootTx.TsctRule.Update(ctx, id, payload)
+	if err != nil {
+		return rule, err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityTransactionRule, id, constants.AuditOperationUpdate, existing, rule); err != nil {
+		return models.TransactionRuleModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.TransactionRuleModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
+	if err := common.InvalidateCacheForEntity(ctx, trs.rdb, constants.EntityTransactionRule, map[string]interface{}{"transactionRuleId": id}); err != nil {
+		observability.NewLogger("service", "TransactionRuleService").Warn("cache invalidation failed", "error", err)
+	}
+
+	return rule, nil
+}
+
+func (trs TransactionRuleService) Delete(ctx context.Context, id int64) error {
+	tx, err := trs.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := trs.rpts.WithTx(ctx, tx)
+	existing, err := rootTx.TsctRule.GetDetail(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := rootTx.TsctRule.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityTransactionRule, id, constants.AuditOperationDelete, existing, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
+	if err := common.InvalidateCacheForEntity(ctx, trs.rdb, constants.EntityTransactionRule, map[string]interface{}{"transactionRuleId": id}); err != nil {
+		observability.NewLogger("service", "TransactionRuleService").Warn("cache invalidation failed", "error", err)
+	}
+
+	return nil
+}
+
+// DryRun evaluates a single rule, even a disabled one, against the transactions in the range without changing them
+// Other rules are ignored, so the result shows what this rule alone would do
+func (trs TransactionRuleService) DryRun(ctx context.Context, id int64, query models.TransactionRuleRangeModel) (models.TransactionRuleDryRunModel, error) {
+	if _, err := trs.rpts.TsctRule.GetDetail(ctx, id); err != nil {
+		return models.TransactionRuleDryRunModel{}, err
+	}
+
+	rules, err := trs.rpts.TsctRule.GetEvaluable(ctx, &id)
+	if err != nil {
+		return models.TransactionRuleDryRunModel{}, err
+	}
+
+	subjects, err := trs.getRangeSubjects(ctx, *trs.rpts, query)
+	if err != nil {
+		return models.TransactionRuleDryRunModel{}, err
+	}
+
+	matchers := newTransactionRuleMatchers(rules)
+	items := []models.TransactionRuleChangeModel{}
+	for _, subject := range subjects {
+		if change, ok := transactionRuleChange(subject, evaluateTransactionRules(matchers, subject)); ok {
+			items = append(items, change)
+		}
+	}
+
+	return models.TransactionRuleDryRunModel{
+		CheckedCount: len(subjects),
+		Items:        items,
+	}, nil
+}
+
+// Apply re-applies all enabled rules to the transactions in the range in a single database transaction
+// Each changed transaction gets a revision and an audit entry, so the changes can be reviewed and reverted one by one
+func (trs TransactionRuleService) Apply(ctx context.Context, query models.TransactionRuleRangeModel) (models.TransactionRuleApplyResultModel, error) {
+	startTime := time.Now()
+	ctx = common.WithAuditSource(ctx, constants.AuditSourceTransactionRules)
+
+	tx, err := trs.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.TransactionRuleApplyResultModel{}, huma.Error500InternalServerError("Failed to start transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := trs.rpts.WithTx(ctx, tx)
+
+	rules, err := rootTx.TsctRule.GetEvaluable(ctx, nil)
+	if err != nil {
+		return models.TransactionRuleApplyResultModel{}, err
+	}
+
+	subjects, err := trs.getRangeSubjects(ctx, rootTx, query)
+	if err != nil {
+		return models.TransactionRuleApplyResultModel{}, err
+	}
+
+	matchers := newTransactionRuleMatchers(rules)
+	updatedIDs := []int64{}
+	affectedAccounts := make(map[int64]bool)
+	affectedCategories := make(map[int64]bool)
+
+	for _, subject := range subjects {
+		change, ok := transactionRuleChange(subject, evaluateTransactionRules(matchers, subject))
+		if !ok {
+			continue
+		}
+
+		existing, err := rootTx.Tsct.GetDetailForUpdate(ctx, subject.ID)
+		if err != nil {
+			return models.TransactionRuleApplyResultModel{}, err
+		}
+
+		// Only transfers have a destination account, whatever the subject looked like when it was listed
+		if existing.Type != "transfer" && change.After.DestinationAccountID != nil {
+			change.After.DestinationAccountID = nil
+			change.ChangedFields = slices.DeleteFunc(change.ChangedFields, func(field string) bool { return field == "destinationAccountId" })
+			if len(change.ChangedFields) == 0 {
+				continue
+			}
+		}
+
+		var oldDestAccountID *int64
+		if existing.DestinationAccount != nil {
+			oldDestAccountID = &existing.DestinationAccount.ID
+		}
+		newCategoryID := existing.Category.ID
+		if change.After.CategoryID != nil {
+			newCategoryID = *change.After.CategoryID
+			affectedCategories[existing.Category.ID] = true
+			affectedCategories[newCategoryID] = true
+		}
+		newDestAccountID := oldDestAccountID
+		if change.After.DestinationAccountID != nil {
+			newDestAccountID = change.After.DestinationAccountID
+		}
+		affectedAccounts[existing.Account.ID] = true
+
+		if change.After.CategoryID != nil || change.After.DestinationAccountID != nil {
+			if err := trs.tsvc.ValidateReferences(ctx, existing.Type, existing.Account.ID, newDestAccountID, &newCategoryID); err != nil {
+				return models.TransactionRuleApplyResultModel{}, huma.Error400BadRequest(
+					fmt.Sprintf("Transaction %d: %s", subject.ID, err.Error()),
+				)
+			}
+		}
+
+		if err := trs.tsvc.RequireEditableAccounts(ctx, existing.Account.ID, oldDestAccountID); err != nil {
+			return models.TransactionRuleApplyResultModel{}, err
+		}
+
+		// Moving a transfer to another destination account moves its amount between the two balances
+		if change.After.DestinationAccountID != nil {
+			if err := trs.tsvc.RequireEditableAccounts(ctx, existing.Account.ID, newDestAccountID); err != nil {
+				return models.TransactionRuleApplyResultModel{}, err
+			}
+			if err := trs.tsvc.RevertBalanceChanges(ctx, rootTx, existing.Type, existing.Amount, existing.Account.ID, oldDestAccountID, existing.DestinationAmount); err != nil {
+				return models.TransactionRuleApplyResultModel{}, err
+			}
+			if err := trs.tsvc.ApplyBalanceChanges(ctx, rootTx, existing.Type, existing.Amount, existing.Account.ID, newDestAccountID, existing.DestinationAmount); err != nil {
+				return models.TransactionRuleApplyResultModel{}, err
+			}
+			if oldDestAccountID != nil {
+				affectedAccounts[*oldDestAccountID] = true
+			}
+			affectedAccounts[*newDestAccountID] = true
+		}
+
+		updated := existing
+		if change.After.CategoryID != nil || change.After.Note != nil || change.After.DestinationAccountID != nil {
+			updated, err = rootTx.Tsct.Update(ctx, subject.ID, models.UpdateTransactionModel{
+				CategoryID:           change.After.CategoryID,
+				Note:                 change.After.Note,
+				DestinationAccountID: change.After.DestinationAccountID,
+			})
+			if err != nil {
+				return models.TransactionRuleApplyResultModel{}, err
+			}
+		}
+
+		if len(change.AddedTagIDs) > 0 {
+			if err := rootTx.TsctRule.AddTags(ctx, subject.ID, change.AddedTagIDs); err != nil {
+				return models.TransactionRuleApplyResultModel{}, err
+			}
+			if updated, err = rootTx.Tsct.GetDetail(ctx, subject.ID); err != nil {
+				return models.TransactionRuleApplyResultModel{}, err
+			}
+		}
+
+		if err := trs.tsvc.RecordRevision(ctx, rootTx, existing, updated); err != nil {
+			return models.TransactionRuleApplyResultModel{}, err
+		}
+
+		if err := recordAudit(ctx, rootTx, constants.EntityTransaction, subject.ID, constants.AuditOperationUpdate, existing, updated); err != nil {
+			return models.TransactionRuleApplyResultModel{}, err
+		}
+
+		updatedIDs = append(updatedIDs, subject.ID)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.TransactionRuleApplyResultModel{}, huma.Error500InternalServerError("Failed to commit transaction", err)
+	}
+
+	if len(updatedIDs) > 0 {
+		go func() {
+			bgCtx := context.Background()
+
+			common.InvalidateCacheForEntity(bgCtx, trs.rdb, constants.EntityTransaction, map[string]interface{}{})
+
+			for accountID := range affectedAccounts {
+				common.InvalidateCacheForEntity(bgCtx, trs.rdb, constants.EntityAccount, map[string]interface{}{
+					"accountId": accountID,
+				})
+			}
+
+			for categoryID := range affectedCategories {
+				common.InvalidateCacheForEntity(bgCtx, trs.rdb, constants.EntityCategory, map[string]interface{}{
+					"categoryId": categoryID,
+				})
+			}
+		}()
+	}
+
+	return models.TransactionRuleApplyResultModel{
+		CheckedCount: len(subjects),
+		UpdatedIDs:   updatedIDs,
+		DurationMs:   time.Since(startTime).Milliseconds(),
+	}, nil
+}
+
+func (trs TransactionRuleService) getRangeSubjects(ctx context.Context, rpts repositories.RootRepository, query models.TransactionRuleRangeModel) ([]models.TransactionRuleSubjectModel, error) {
+	if query.EndDate.Before(query.StartDate) {
+		return nil, huma.Error400BadRequest("End date must not be before start date")
+	}
+
+	subjects, err := rpts.TsctRule.GetSubjects(ctx, query.StartDate, query.EndDate, maxTransactionRuleRangeSize+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(subjects) > maxTransactionRuleRangeSize {
+		return nil, huma.Error400BadRequest(
+			fmt.Sprintf("Range has more than %d transactions, narrow it down", maxTransactionRuleRangeSize),
+		)
+	}
+	return subjects, nil
+}
+
+// validateRule checks a rule before it is saved so it can be applied to the transactions it matches
+// A category action needs a type condition of the category type, and a destination account action needs the transfer type
+func (trs TransactionRuleService) validateRule(ctx context.Context, conditions models.TransactionRuleConditionsModel, actions models.TransactionRuleActionsModel) error {
+	if actions.CategoryID == nil && len(actions.TagIDs) == 0 && actions.Note == nil && actions.DestinationAccountID == nil {
+		return huma.Error400BadRequest("Rule needs at least one action")
+	}
+
+	if conditions.NotePattern != nil {
+		if _, err := regexp.Compile(*conditions.NotePattern); err != nil {
+			return huma.Error400BadRequest("Note pattern is not a valid regular expression", err)
+		}
+	}
+
+	if conditions.MinAmount != nil && conditions.MaxAmount != nil && *conditions.MinAmount > *conditions.MaxAmount {
+		return huma.Error400BadRequest("Minimum amount must not be greater than maximum amount")
+	}
+
+	locationParts := 0
+	for _, present := range []bool{conditions.Latitude != nil, conditions.Longitude != nil, conditions.RadiusMeters != nil} {
+		if present {
+			locationParts++
+		}
+	}
+	if locationParts != 0 && locationParts != 3 {
+		return huma.Error400BadRequest("Latitude, longitude and radius must be provided together or neither")
+	}
+
+	if conditions.AccountID != nil {
+		if _, err := trs.rpts.Acc.GetDetail(ctx, *conditions.AccountID); err != nil {
+			return huma.Error400BadRequest("Account not found", err)
+		}
+	}
+
+	if actions.CategoryID != nil {
+		category, err := trs.rpts.Cat.GetDetail(ctx, *actions.CategoryID)
+		if err != nil {
+			return huma.Error400BadRequest("Category not found", err)
+		}
+		if conditions.Type == nil || *conditions.Type != category.Type {
+			return huma.Error400BadRequest("Rule setting a category needs a type condition matching the category type")
+		}
+	}
+
+	if actions.DestinationAccountID != nil {
+		if conditions.Type == nil || *conditions.Type != "transfer" {
+			return huma.Error400BadRequest("Rule setting a destination account needs the transfer type condition")
+		}
+		if _, err := trs.rpts.Acc.GetDetail(ctx, *actions.DestinationAccountID); err != nil {
+			return huma.Error400BadRequest("Destination account not found", err)
+		}
+	}
+
+	for _, tagID := range actions.TagIDs {
+		if _, err := trs.rpts.Tag.GetDetail(ctx, tagID); err != nil {
+			return huma.Error400BadRequest(fmt.Sprintf("Tag %d not found", tagID), err)
+		}
+	}
+
+	return nil
+}
+
+// evaluateRulesForNewTransaction runs the current user's enabled rules against a transaction about to be recorded
+func evaluateRulesForNewTransaction(ctx context.Context, rpts repositories.RootRepository, subject models.TransactionRuleSubjectModel) (models.TransactionRuleOutcomeModel, error) {
+	rules, err := rpts.TsctRule.GetEvaluable(ctx, nil)
+	if err != nil {
+		return models.TransactionRuleOutcomeModel{}, err
+	}
+	return evaluateTransactionRules(newTransactionRuleMatchers(rules), subject), nil
+}
+
+// transactionRuleMatcher is a rule with its note pattern compiled, so it can be evaluated against many transactions
+type transactionRuleMatcher struct {
+	rule models.TransactionRuleModel
+	note *regexp.Regexp
+}
+
+// newTransactionRuleMatchers compiles the rules in the given order; rules with a pattern that no longer compiles are skipped
+func newTransactionRuleMatchers(rules []models.TransactionRuleModel) []transactionRuleMatcher {
+	matchers := make([]transactionRuleMatcher, 0, len(rules))
+	for _, rule := range rules {
+		matcher := transactionRuleMatcher{rule: rule}
+		if rule.Conditions.NotePattern != nil {
+			note, err := regexp.Compile(*rule.Conditions.NotePattern)
+			if err != nil {
+				continue
+			}
+			matcher.note = note
+		}
+		matchers = append(matchers, matcher)
+	}
+	return matchers
+}
+
+// evaluateTransactionRules applies matching rules in priority order
+// The first matching rule with an action for a field sets it, later ones cannot override it; tags of all matching rules are added
+func evaluateTransactionRules(matchers []transactionRuleMatcher, subject models.TransactionRuleSubjectModel) models.TransactionRuleOutcomeModel {
+	var outcome models.TransactionRuleOutcomeModel
+	for _, matcher := range matchers {
+		if !matcher.matches(subject) {
+			continue
+		}
+		actions := matcher.rule.Actions
+		outcome.RuleIDs = append(outcome.RuleIDs, matcher.rule.ID)
+		if outcome.CategoryID == nil && actions.CategoryID != nil {
+			outcome.CategoryID = actions.CategoryID
+		}
+		if outcome.Note == nil && actions.Note != nil {
+			outcome.Note = actions.Note
+		}
+		if outcome.DestinationAccountID == nil && actions.DestinationAccountID != nil && *actions.DestinationAccountID != subject.AccountID {
+			outcome.DestinationAccountID = actions.DestinationAccountID
+		}
+		for _, tagID := range actions.TagIDs {
+			if !slices.Contains(outcome.TagIDs, tagID) {
+				outcome.TagIDs = append(outcome.TagIDs, tagID)
+			}
+		}
+	}
+	return outcome
+}
+
+func (m transactionRuleMatcher) matches(subject models.TransactionRuleSubjectModel) bool {
+	c := m.rule.Conditions
+	if c.Type != nil && *c.Type != subject.Type {
+		return false
+	}
+	if c.AccountID != nil && *c.AccountID != subject.AccountID {
+		return false
+	}
+	if c.MinAmount != nil && subject.Amount < *c.MinAmount {
+		return false
+	}
+	if c.MaxAmount != nil && subject.Amount > *c.MaxAmount {
+		return false
+	}
+	if c.CurrencyCode != nil && *c.CurrencyCode != subject.CurrencyCode {
+		return false
+	}
+	if m.note != nil && (subject.Note == nil || !m.note.MatchString(*subject.Note)) {
+		return false
+	}
+	if c.Latitude != nil && c.Longitude != nil && c.RadiusMeters != nil {
+		if subject.Latitude == nil || subject.Longitude == nil {
+			return false
+		}
+		if distanceMeters(*c.Latitude, *c.Longitude, *subject.Latitude, *subject.Longitude) > float64(*c.RadiusMeters) {
+			return false
+		}
+	}
+	return true
+}
+
+// transactionRuleChange compares an outcome with the transaction and keeps only what would actually change
+func transactionRuleChange(subject models.TransactionRuleSubjectModel, outcome models.TransactionRuleOutcomeModel) (models.TransactionRuleChangeModel, bool) {
+	change := models.TransactionRuleChangeModel{
+		TransactionID: subject.ID,
+		Date:          subject.Date,
+		ChangedFields: []string{},
+		AddedTagIDs:   []int64{},
+		RuleIDs:       outcome.RuleIDs,
+	}
+
+	if outcome.CategoryID != nil && *outcome.CategoryID != subject.CategoryID {
+		change.ChangedFields = append(change.ChangedFields, "categoryId")
+		change.Before.CategoryID = &subject.CategoryID
+		change.After.CategoryID = outcome.CategoryID
+	}
+	if outcome.Note != nil && !equalPtr(outcome.Note, subject.Note) {
+		change.ChangedFields = append(change.ChangedFields, "note")
+		change.Before.Note = subject.Note
+		change.After.Note = outcome.Note
+	}
+	if outcome.DestinationAccountID != nil && subject.Type == "transfer" && !equalPtr(outcome.DestinationAccountID, subject.DestinationAccountID) {
+		change.ChangedFields = append(change.ChangedFields, "destinationAccountId")
+		change.Before.DestinationAccountID = subject.DestinationAccountID
+		change.After.DestinationAccountID = outcome.DestinationAccountID
+	}
+	for _, tagID := range outcome.TagIDs {
+		if !slices.Contains(subject.TagIDs, tagID) {
+			change.AddedTagIDs = append(change.AddedTagIDs, tagID)
+		}
+	}
+	if len(change.AddedTagIDs) > 0 {
+		change.ChangedFields = append(change.ChangedFields, "tagIds")
+	}
+
+	return change, len(change.ChangedFields) > 0
+}
+
+// distanceMeters returns the great-circle distance between two coordinates using the haversine formula
+func distanceMeters(lat1, lng1, lat2, lng2 float64) float64 {
+	const earthRadiusMeters = 6371000
+	toRadians := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRadians(lat2 - lat1)
+	dLng := toRadians(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return earthRadiusMeters * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
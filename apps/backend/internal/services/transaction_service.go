@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
@@ -63,7 +64,10 @@ func (ts TransactionService) GetPaged(ctx context.Context, p models.Transactions
 		}
 	}
 
-	cacheKey := common.BuildPagedCacheKey(constants.EntityTransaction, p)
+	// Normalize the search text so spacing and casing variants of the same query share a cache entry
+	p.Q = strings.Join(strings.Fields(strings.ToLower(p.Q)), " ")
+
+	cacheKey := common.BuildPagedCacheKey(ctx, constants.EntityTransaction, p)
 	result, err := common.FetchWithCache(ctx, ts.rdb, cacheKey, constants.CacheTTLPaged, func(ctx context.Context) (models.TransactionsPagedModel, error) {
 		return ts.rpts.Tsct.GetPaged(ctx, p)
 	}, "transaction")
@@ -81,7 +85,7 @@ func (ts TransactionService) GetPaged(ctx context.Context, p models.Transactions
 }
 
 func (ts TransactionService) GetDetail(ctx context.Context, id int64) (models.TransactionModel, error) {
-	cacheKey := common.BuildDetailCacheKey(constants.EntityTransaction, id)
+	cacheKey := common.BuildDetailCacheKey(ctx, constants.EntityTransaction, id)
 	result, err := common.FetchWithCache(ctx, ts.rdb, cacheKey, constants.CacheTTLDetail, func(ctx context.Context) (models.TransactionModel, error) {
 		return ts.rpts.Tsct.GetDetail(ctx, id)
 	}, "transaction")
@@ -104,6 +108,10 @@ func (ts TransactionService) Create(ctx context.Context, p models.CreateTransact
 		return models.TransactionModel{}, huma.Error400BadRequest("Both latitude and longitude must be provided together or neither")
 	}
 
+	if err := validateTransferFields(p.Type, p.DestinationAmount, p.FeeAmount, p.FeeCategoryID); err != nil {
+		return models.TransactionModel{}, err
+	}
+
 	// Get base currency
 	baseCurrency, err := ts.rpts.CurConfig.GetBaseCurrency(ctx)
 	if err != nil {
@@ -119,10 +127,52 @@ func (ts TransactionService) Create(ctx context.Context, p models.CreateTransact
 	// Update the request model with calculated base amount
 	p.Amount = baseAmount
 
+	// Rules may set the category, note or destination account, so they run before references are validated
+	var ruleTagIDs []int64
+	if !p.SkipRules {
+		currencyCode := baseCurrency
+		if p.CurrencyCode != nil && *p.CurrencyCode != "" {
+			currencyCode = *p.CurrencyCode
+		}
+		outcome, err := evaluateRulesForNewTransaction(ctx, *ts.rpts, models.TransactionRuleSubjectModel{
+			Date:                 p.Date,
+			Type:                 p.Type,
+			Amount:               p.Amount,
+			AccountID:            p.AccountID,
+			CategoryID:           p.CategoryID,
+			DestinationAccountID: p.DestinationAccountID,
+			CurrencyCode:         currencyCode,
+			Note:                 p.Note,
+			Latitude:             p.Latitude,
+			Longitude:            p.Longitude,
+		})
+		if err != nil {
+			return models.TransactionModel{}, err
+		}
+		if outcome.CategoryID != nil {
+			p.CategoryID = *outcome.CategoryID
+		}
+		if outcome.Note != nil {
+			p.Note = outcome.Note
+		}
+		if outcome.DestinationAccountID != nil && p.Type == "transfer" {
+			p.DestinationAccountID = outcome.DestinationAccountID
+		}
+		ruleTagIDs = outcome.TagIDs
+	}
+
 	if err := ts.ValidateReferences(ctx, p.Type, p.AccountID, p.DestinationAccountID, &p.CategoryID); err != nil {
 		return models.TransactionModel{}, err
 	}
 
+	if err := requirePayee(ctx, ts.rpts, p.PayeeID); err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	if err := ts.RequireEditableAccounts(ctx, p.AccountID, p.DestinationAccountID); err != nil {
+		return models.TransactionModel{}, err
+	}
+
 	tx, err := ts.rpts.Pool.Begin(ctx)
 
 	if err != nil {
@@ -144,7 +194,24 @@ func (ts TransactionService) Create(ctx context.Context, p models.CreateTransact
 		return models.TransactionModel{}, err
 	}
 
-	if err := ts.ApplyBalanceChanges(ctx, rootTx, p.Type, p.Amount, p.AccountID, p.DestinationAccountID); err != nil {
+	if err := ts.ApplyBalanceChanges(ctx, rootTx, p.Type, p.Amount, p.AccountID, p.DestinationAccountID, p.DestinationAmount); err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	if transaction, err = ts.SyncTransferFee(ctx, rootTx, transaction, p.FeeAmount, p.FeeCategoryID); err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	if len(ruleTagIDs) > 0 {
+		if err := rootTx.TsctRule.AddTags(ctx, transaction.ID, ruleTagIDs); err != nil {
+			return models.TransactionModel{}, err
+		}
+		if transaction, err = rootTx.Tsct.GetDetail(ctx, transaction.ID); err != nil {
+			return models.TransactionModel{}, err
+		}
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityTransaction, transaction.ID, constants.AuditOperationCreate, nil, transaction); err != nil {
 		return models.TransactionModel{}, err
 	}
 
@@ -166,11 +233,14 @@ func (ts TransactionService) Create(ctx context.Context, p models.CreateTransact
 	}); err != nil {
 		observability.NewLogger("service", "TransactionService").Warn("cache invalidation failed", "error", err)
 	}
+	ts.invalidateFeeCategory(ctx, transaction.Fee)
 
 	return transaction, nil
 }
 
-func (ts TransactionService) Update(ctx context.Context, id int64, p models.UpdateTransactionModel) (models.TransactionModel, error) {
+// Update changes a transaction and moves its amount between the account balances
+// With ifMatch set, the transaction is only changed while its ETag still matches
+func (ts TransactionService) Update(ctx context.Context, id int64, p models.UpdateTransactionModel, ifMatch string) (models.TransactionModel, error) {
 	// Validate coordinates: both must be present or both must be nil
 	latPresent := p.Latitude != nil && *p.Latitude != 0
 	lngPresent := p.Longitude != nil && *p.Longitude != 0
@@ -178,6 +248,10 @@ func (ts TransactionService) Update(ctx context.Context, id int64, p models.Upda
 		return models.TransactionModel{}, huma.Error400BadRequest("Both latitude and longitude must be provided together or neither")
 	}
 
+	if err := requirePayee(ctx, ts.rpts, p.PayeeID); err != nil {
+		return models.TransactionModel{}, err
+	}
+
 	tx, err := ts.rpts.Pool.Begin(ctx)
 	if err != nil {
 		return models.TransactionModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
@@ -185,21 +259,27 @@ func (ts TransactionService) Update(ctx context.Context, id int64, p models.Upda
 	defer tx.Rollback(ctx)
 
 	rootTx := ts.rpts.WithTx(ctx, tx)
-	existing, err := rootTx.Tsct.GetDetail(ctx, id)
+	existing, err := rootTx.Tsct.GetDetailForUpdate(ctx, id)
 	if err != nil {
 		return models.TransactionModel{}, err
 	}
 
+	if err := common.RequireETag(ifMatch, existing.ETag, existing); err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	if err := ts.RequireUnlocked(existing); err != nil {
+		return models.TransactionModel{}, err
+	}
+
 	oldDestAccountID := (*int64)(nil)
 	if existing.DestinationAccount != nil {
 		oldDestAccountID = &existing.DestinationAccount.ID
 	}
-	if err := ts.RevertBalanceChanges(ctx, rootTx, existing.Type, existing.Amount, existing.Account.ID, oldDestAccountID); err != nil {
+	if err := ts.RequireEditableAccounts(ctx, existing.Account.ID, oldDestAccountID); err != nil {
 		return models.TransactionModel{}, err
 	}
-
-	transaction, err := rootTx.Tsct.Update(ctx, id, p)
-	if err != nil {
+	if err := ts.RevertBalanceChanges(ctx, rootTx, existing.Type, existing.Amount, existing.Account.ID, oldDestAccountID, existing.DestinationAmount); err != nil {
 		return models.TransactionModel{}, err
 	}
 
@@ -207,6 +287,18 @@ func (ts TransactionService) Update(ctx context.Context, id int64, p models.Upda
 	if p.Type != nil {
 		newType = *p.Type
 	}
+	if err := validateTransferFields(newType, p.DestinationAmount, p.FeeAmount, p.FeeCategoryID); err != nil {
+		return models.TransactionModel{}, err
+	}
+	if newType != "transfer" && existing.DestinationAmount != nil {
+		none := int64(0)
+		p.DestinationAmount = &none
+	}
+
+	transaction, err := rootTx.Tsct.Update(ctx, id, p)
+	if err != nil {
+		return models.TransactionModel{}, err
+	}
 	newAmount := existing.Amount
 	if p.Amount != nil {
 		newAmount = *p.Amount
@@ -231,7 +323,27 @@ func (ts TransactionService) Update(ctx context.Context, id int64, p models.Upda
 		return models.TransactionModel{}, err
 	}
 
-	if err := ts.ApplyBalanceChanges(ctx, rootTx, newType, newAmount, newAccountID, newDestAccountID); err != nil {
+	if err := ts.ValidateSplits(existing, newType, newAmount); err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	if err := ts.RequireEditableAccounts(ctx, newAccountID, newDestAccountID); err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	if err := ts.ApplyBalanceChanges(ctx, rootTx, newType, newAmount, newAccountID, newDestAccountID, transaction.DestinationAmount); err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	if transaction, err = ts.SyncTransferFee(ctx, rootTx, transaction, p.FeeAmount, p.FeeCategoryID); err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	if err := ts.RecordRevision(ctx, rootTx, existing, transaction); err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityTransaction, id, constants.AuditOperationUpdate, existing, transaction); err != nil {
 		return models.TransactionModel{}, err
 	}
 
@@ -270,10 +382,14 @@ func (ts TransactionService) Update(ctx context.Context, id int64, p models.Upda
 			observability.NewLogger("service", "TransactionService").Warn("cache invalidation failed", "error", err)
 		}
 	}
+	ts.invalidateFeeCategory(ctx, existing.Fee)
+	ts.invalidateFeeCategory(ctx, transaction.Fee)
 	return transaction, nil
 }
 
-func (ts TransactionService) Delete(ctx context.Context, id int64) error {
+// Delete moves a transaction to the trash and takes its amount off the account balances
+// With ifMatch set, the transaction is only deleted while its ETag still matches
+func (ts TransactionService) Delete(ctx context.Context, id int64, ifMatch string) error {
 	tx, err := ts.rpts.Pool.Begin(ctx)
 	if err != nil {
 		return huma.Error422UnprocessableEntity("failed to start transaction")
@@ -281,20 +397,16 @@ func (ts TransactionService) Delete(ctx context.Context, id int64) error {
 	defer tx.Rollback(ctx)
 
 	rootTx := ts.rpts.WithTx(ctx, tx)
-	existing, err := rootTx.Tsct.GetDetail(ctx, id)
+	existing, err := rootTx.Tsct.GetDetailForUpdate(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	oldDestAccountID := (*int64)(nil)
-	if existing.DestinationAccount != nil {
-		oldDestAccountID = &existing.DestinationAccount.ID
-	}
-	if err := ts.RevertBalanceChanges(ctx, rootTx, existing.Type, existing.Amount, existing.Account.ID, oldDestAccountID); err != nil {
+	if err := common.RequireETag(ifMatch, existing.ETag, existing); err != nil {
 		return err
 	}
 
-	if err := rootTx.Tsct.Delete(ctx, id); err != nil {
+	if err := ts.Trash(ctx, rootTx, existing); err != nil {
 		return err
 	}
 
@@ -316,18 +428,129 @@ func (ts TransactionService) Delete(ctx context.Context, id int64) error {
 	}); err != nil {
 		observability.NewLogger("service", "TransactionService").Warn("cache invalidation failed", "error", err)
 	}
+	ts.invalidateFeeCategory(ctx, existing.Fee)
 
 	return nil
 }
 
-func (ts TransactionService) ApplyBalanceChanges(ctx context.Context, root repositories.RootRepository, txType string, amount int64, accountID int64, destAccountID *int64) error {
+// Trash moves a transaction to the trash within the caller's database transaction: its amount comes off the account
+// balances, its transfer fee is trashed with it and the deletion is audited. Split lines and revisions stay with it for a restore
+// Callers lock the transaction first and update caches and the geo index once committed
+func (ts TransactionService) Trash(ctx context.Context, root repositories.RootRepository, existing models.TransactionModel) error {
+	if err := ts.RequireUnlocked(existing); err != nil {
+		return err
+	}
+
+	destAccountID := (*int64)(nil)
+	if existing.DestinationAccount != nil {
+		destAccountID = &existing.DestinationAccount.ID
+	}
+	if err := ts.RequireEditableAccounts(ctx, existing.Account.ID, destAccountID); err != nil {
+		return err
+	}
+	if err := ts.RevertBalanceChanges(ctx, root, existing.Type, existing.Amount, existing.Account.ID, destAccountID, existing.DestinationAmount); err != nil {
+		return err
+	}
+
+	if err := ts.DeleteTransferFee(ctx, root, existing); err != nil {
+		return err
+	}
+
+	if err := root.Tsct.Delete(ctx, existing.ID); err != nil {
+		return err
+	}
+
+	return recordAudit(ctx, root, constants.EntityTransaction, existing.ID, constants.AuditOperationDelete, existing, nil)
+}
+
+// Restore brings a transaction back from the trash and reapplies it to the account balances
+// Its accounts and category must still exist, so they are restored first. The fee of a transfer comes back with it
+// when it was deleted together with the transfer
+func (ts TransactionService) Restore(ctx context.Context, id int64) (models.TransactionModel, error) {
+	tx, err := ts.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.TransactionModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := ts.rpts.WithTx(ctx, tx)
+	feeID, err := rootTx.Tsct.RestoreFee(ctx, id)
+	if err != nil {
+		return models.TransactionModel{}, err
+	}
+	if err := rootTx.Tsct.Restore(ctx, id); err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	transaction, err := rootTx.Tsct.GetDetail(ctx, id)
+	if err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	destAccountID := (*int64)(nil)
+	if transaction.DestinationAccount != nil {
+		destAccountID = &transaction.DestinationAccount.ID
+	}
+	if err := ts.ValidateReferences(ctx, transaction.Type, transaction.Account.ID, destAccountID, &transaction.Category.ID); err != nil {
+		return models.TransactionModel{}, err
+	}
+	if err := ts.RequireEditableAccounts(ctx, transaction.Account.ID, destAccountID); err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	if err := ts.ApplyBalanceChanges(ctx, rootTx, transaction.Type, transaction.Amount, transaction.Account.ID, destAccountID, transaction.DestinationAmount); err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	if feeID != nil {
+		fee, err := rootTx.Tsct.GetDetail(ctx, *feeID)
+		if err != nil {
+			return models.TransactionModel{}, err
+		}
+		if err := ts.ApplyBalanceChanges(ctx, rootTx, fee.Type, fee.Amount, fee.Account.ID, nil, nil); err != nil {
+			return models.TransactionModel{}, err
+		}
+		if err := recordAudit(ctx, rootTx, constants.EntityTransaction, fee.ID, constants.AuditOperationRestore, nil, fee); err != nil {
+			return models.TransactionModel{}, err
+		}
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityTransaction, id, constants.AuditOperationRestore, nil, transaction); err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.TransactionModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
+	if transaction.Latitude != nil && transaction.Longitude != nil {
+		go func(lat, lng float64) {
+			ts.geoIndexMgr.Index(context.Background(), transaction.ID, lat, lng)
+		}(*transaction.Latitude, *transaction.Longitude)
+	}
+
+	if err := common.InvalidateCacheForEntity(ctx, ts.rdb, constants.EntityTransaction, map[string]interface{}{
+		"transactionId": transaction.ID,
+		"accountId":     transaction.Account.ID,
+		"categoryId":    transaction.Category.ID,
+	}); err != nil {
+		observability.NewLogger("service", "TransactionService").Warn("cache invalidation failed", "error", err)
+	}
+	ts.invalidateFeeCategory(ctx, transaction.Fee)
+
+	return transaction, nil
+}
+
+// ApplyBalanceChanges moves the amount of a transaction into the account balances
+// A transfer credits destAmount to the destination account when set, and its amount otherwise
+func (ts TransactionService) ApplyBalanceChanges(ctx context.Context, root repositories.RootRepository, txType string, amount int64, accountID int64, destAccountID *int64, destAmount *int64) error {
 	switch txType {
 	case "transfer":
 		if destAccountID != nil {
 			if err := root.Acc.UpdateBalance(ctx, accountID, -amount); err != nil {
 				return huma.Error422UnprocessableEntity("failed to update source account balance")
 			}
-			if err := root.Acc.UpdateBalance(ctx, *destAccountID, amount); err != nil {
+			if err := root.Acc.UpdateBalance(ctx, *destAccountID, transferCredit(amount, destAmount)); err != nil {
 				return huma.Error422UnprocessableEntity("failed to update destination account balance")
 			}
 		}
@@ -343,14 +566,15 @@ func (ts TransactionService) ApplyBalanceChanges(ctx context.Context, root repos
 	return nil
 }
 
-func (ts TransactionService) RevertBalanceChanges(ctx context.Context, root repositories.RootRepository, txType string, amount int64, accountID int64, destAccountID *int64) error {
+// RevertBalanceChanges takes the amount of a transaction back out of the account balances, undoing ApplyBalanceChanges
+func (ts TransactionService) RevertBalanceChanges(ctx context.Context, root repositories.RootRepository, txType string, amount int64, accountID int64, destAccountID *int64, destAmount *int64) error {
 	switch txType {
 	case "transfer":
 		if destAccountID != nil {
 			if err := root.Acc.UpdateBalance(ctx, accountID, amount); err != nil {
 				return huma.Error422UnprocessableEntity("failed to revert source account balance")
 			}
-			if err := root.Acc.UpdateBalance(ctx, *destAccountID, -amount); err != nil {
+			if err := root.Acc.UpdateBalance(ctx, *destAccountID, -transferCredit(amount, destAmount)); err != nil {
 				return huma.Error422UnprocessableEntity("failed to revert destination account balance")
 			}
 		}
@@ -366,6 +590,221 @@ func (ts TransactionService) RevertBalanceChanges(ctx context.Context, root repo
 	return nil
 }
 
+// SyncTransferFee keeps the expense recording the fee of a transfer in line with the transfer
+// feeAmount adds the fee or changes its amount and 0 removes it; feeCategoryID sets its category. An existing fee follows
+// the transfer to its source account and date, and is removed when the transaction is no longer a transfer
+// Returns the transfer as it is afterwards
+func (ts TransactionService) SyncTransferFee(ctx context.Context, root repositories.RootRepository, transfer models.TransactionModel, feeAmount *int64, feeCategoryID *int64) (models.TransactionModel, error) {
+	if transfer.Fee == nil {
+		if transfer.Type != "transfer" || feeAmount == nil || *feeAmount == 0 {
+			if feeCategoryID != nil {
+				return models.TransactionModel{}, huma.Error400BadRequest("feeAmount is required to add a fee")
+			}
+			return transfer, nil
+		}
+		if feeCategoryID == nil {
+			return models.TransactionModel{}, huma.Error400BadRequest("feeCategoryId is required to add a fee")
+		}
+		if err := ts.ValidateReferences(ctx, "expense", transfer.Account.ID, nil, feeCategoryID); err != nil {
+			return models.TransactionModel{}, err
+		}
+
+		note := "Transfer fee"
+		fee, err := root.Tsct.Create(ctx, models.CreateTransactionModel{
+			Type:       "expense",
+			Date:       transfer.Date,
+			Amount:     *feeAmount,
+			AccountID:  transfer.Account.ID,
+			CategoryID: *feeCategoryID,
+			Note:       &note,
+			Status:     &transfer.Status,
+		}, nil, nil, nil)
+		if err != nil {
+			return models.TransactionModel{}, err
+		}
+		if err := ts.ApplyBalanceChanges(ctx, root, fee.Type, fee.Amount, fee.Account.ID, nil, nil); err != nil {
+			return models.TransactionModel{}, err
+		}
+		if err := root.Tsct.SetFeeTransaction(ctx, transfer.ID, &fee.ID); err != nil {
+			return models.TransactionModel{}, err
+		}
+		if _, err := root.TsctRel.Create(ctx, models.CreateTransactionRelationModel{
+			SourceTransactionID:  fee.ID,
+			RelatedTransactionID: transfer.ID,
+			RelationType:         constants.TransactionRelationFeeOf,
+		}); err != nil {
+			return models.TransactionModel{}, err
+		}
+		if err := recordAudit(ctx, root, constants.EntityTransaction, fee.ID, constants.AuditOperationCreate, nil, fee); err != nil {
+			return models.TransactionModel{}, err
+		}
+		return root.Tsct.GetDetail(ctx, transfer.ID)
+	}
+
+	if transfer.Type != "transfer" || (feeAmount != nil && *feeAmount == 0) {
+		if err := ts.DeleteTransferFee(ctx, root, transfer); err != nil {
+			return models.TransactionModel{}, err
+		}
+		if err := root.Tsct.SetFeeTransaction(ctx, transfer.ID, nil); err != nil {
+			return models.TransactionModel{}, err
+		}
+		return root.Tsct.GetDetail(ctx, transfer.ID)
+	}
+
+	fee, err := root.Tsct.GetDetailForUpdate(ctx, transfer.Fee.ID)
+	if err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	var p models.UpdateTransactionModel
+	if feeAmount != nil && *feeAmount != fee.Amount {
+		p.Amount = feeAmount
+	}
+	if feeCategoryID != nil && *feeCategoryID != fee.Category.ID {
+		p.CategoryID = feeCategoryID
+	}
+	if transfer.Account.ID != fee.Account.ID {
+		p.AccountID = &transfer.Account.ID
+	}
+	if !transfer.Date.Equal(fee.Date) {
+		p.Date = &transfer.Date
+	}
+	if p.Amount == nil && p.CategoryID == nil && p.AccountID == nil && p.Date == nil {
+		return transfer, nil
+	}
+
+	if err := ts.RequireUnlocked(fee); err != nil {
+		return models.TransactionModel{}, err
+	}
+	newAmount := fee.Amount
+	if p.Amount != nil {
+		newAmount = *p.Amount
+	}
+	newCategoryID := fee.Category.ID
+	if p.CategoryID != nil {
+		newCategoryID = *p.CategoryID
+	}
+	if err := ts.ValidateReferences(ctx, fee.Type, transfer.Account.ID, nil, &newCategoryID); err != nil {
+		return models.TransactionModel{}, err
+	}
+	if err := ts.ValidateSplits(fee, fee.Type, newAmount); err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	if err := ts.RevertBalanceChanges(ctx, root, fee.Type, fee.Amount, fee.Account.ID, nil, nil); err != nil {
+		return models.TransactionModel{}, err
+	}
+	updated, err := root.Tsct.Update(ctx, fee.ID, p)
+	if err != nil {
+		return models.TransactionModel{}, err
+	}
+	if err := ts.ApplyBalanceChanges(ctx, root, updated.Type, updated.Amount, updated.Account.ID, nil, nil); err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	if err := ts.RecordRevision(ctx, root, fee, updated); err != nil {
+		return models.TransactionModel{}, err
+	}
+	if err := recordAudit(ctx, root, constants.EntityTransaction, fee.ID, constants.AuditOperationUpdate, fee, updated); err != nil {
+		return models.TransactionModel{}, err
+	}
+	return root.Tsct.GetDetail(ctx, transfer.ID)
+}
+
+// DeleteTransferFee moves the fee of a transfer to the trash and takes it off the account balance
+// The transfer keeps the link, so restoring it brings the fee back; unlink it with SetFeeTransaction to remove the fee for good
+func (ts TransactionService) DeleteTransferFee(ctx context.Context, root repositories.RootRepository, transfer models.TransactionModel) error {
+	if transfer.Fee == nil {
+		return nil
+	}
+
+	fee, err := root.Tsct.GetDetailForUpdate(ctx, transfer.Fee.ID)
+	if err != nil {
+		return err
+	}
+	if err := ts.RequireUnlocked(fee); err != nil {
+		return err
+	}
+	if err := ts.RevertBalanceChanges(ctx, root, fee.Type, fee.Amount, fee.Account.ID, nil, nil); err != nil {
+		return err
+	}
+	if err := root.Tsct.Delete(ctx, fee.ID); err != nil {
+		return err
+	}
+	return recordAudit(ctx, root, constants.EntityTransaction, fee.ID, constants.AuditOperationDelete, fee, nil)
+}
+
+// invalidateFeeCategory drops the cached statistics of the category a transfer fee is booked on
+func (ts TransactionService) invalidateFeeCategory(ctx context.Context, fee *models.TransactionFeeEmbedded) {
+	if fee == nil {
+		return
+	}
+	if err := common.InvalidateCacheForEntity(ctx, ts.rdb, constants.EntityCategory, map[string]interface{}{"categoryId": fee.CategoryID}); err != nil {
+		observability.NewLogger("service", "TransactionService").Warn("cache invalidation failed", "error", err)
+	}
+}
+
+// RecordRevision keeps the fields an update changed and the balance change it applied in the transaction history
+// Updates that change nothing are not recorded
+func (ts TransactionService) RecordRevision(ctx context.Context, root repositories.RootRepository, existing models.TransactionModel, updated models.TransactionModel) error {
+	fields, before, after := diffTransactionRevision(existing, updated)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	_, err := root.TsctRev.Create(ctx, models.CreateTransactionRevisionModel{
+		TransactionID: existing.ID,
+		ChangedFields: fields,
+		Before:        before,
+		After:         after,
+		BalanceDeltas: transactionBalanceDeltas(existing, updated),
+	})
+	return err
+}
+
+// FindPossibleDuplicates returns the existing transactions likely to be the same as the given one, most likely first
+// Callers report them as a warning; nothing is blocked
+func (ts TransactionService) FindPossibleDuplicates(ctx context.Context, transaction models.TransactionModel) ([]models.TransactionDuplicateCandidateModel, error) {
+	return findTransactionDuplicates(ctx, *ts.rpts, transaction, duplicateWarningMinScore)
+}
+
+// RequireEditableAccounts rejects changes to transactions on accounts the current user may only view
+// Recording or changing a transaction on a shared account needs the owner or editor role on it
+func (ts TransactionService) RequireEditableAccounts(ctx context.Context, accountID int64, destAccountID *int64) error {
+	if _, err := requireAccess(ctx, ts.rpts, constants.EntityAccount, accountID, constants.ShareRoleOwner, constants.ShareRoleEditor); err != nil {
+		return err
+	}
+	if destAccountID != nil && *destAccountID != 0 {
+		if _, err := requireAccess(ctx, ts.rpts, constants.EntityAccount, *destAccountID, constants.ShareRoleOwner, constants.ShareRoleEditor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RequireUnlocked rejects changes to transactions locked by a completed reconciliation
+func (ts TransactionService) RequireUnlocked(existing models.TransactionModel) error {
+	if existing.Status == constants.TransactionStatusReconciled {
+		return huma.Error409Conflict("Reconciled transactions cannot be changed or deleted")
+	}
+	return nil
+}
+
+// ValidateSplits keeps the split lines of a transaction consistent with a change of its type or amount
+// A split transaction cannot become a transfer, and its amount only changes together with its lines
+func (ts TransactionService) ValidateSplits(existing models.TransactionModel, newType string, newAmount int64) error {
+	if len(existing.Splits) == 0 {
+		return nil
+	}
+	if newType != existing.Type {
+		return huma.Error400BadRequest("Transaction is split, remove its split lines before changing its type")
+	}
+	if newAmount != existing.Amount {
+		return huma.Error400BadRequest("Transaction is split, update its split lines to change the amount")
+	}
+	return nil
+}
+
 func (ts TransactionService) ValidateReferences(ctx context.Context, txType string, accountID int64, destAccountID *int64, categoryID *int64) error {
 	g, ctx := errgroup.WithContext(ctx)
 
@@ -464,3 +903,24 @@ func (ts TransactionService) ApplyCurrencyConversion(ctx context.Context, baseCu
 	// Return: converted amount, original foreign amount, exchange rate
 	return baseAmount, &amount, &rate, nil
 }
+
+// transferCredit is what a transfer adds to its destination account: destAmount when set, e.g. after a currency
+// conversion, and the amount taken from the source account otherwise
+func transferCredit(amount int64, destAmount *int64) int64 {
+	if destAmount != nil {
+		return *destAmount
+	}
+	return amount
+}
+
+// validateTransferFields rejects a destination amount or fee on a transaction that is not a transfer
+// Zeros only remove them, so they are accepted on any type
+func validateTransferFields(txType string, destAmount *int64, feeAmount *int64, feeCategoryID *int64) error {
+	if txType == "transfer" {
+		return nil
+	}
+	if (destAmount != nil && *destAmount != 0) || (feeAmount != nil && *feeAmount != 0) || feeCategoryID != nil {
+		return huma.Error400BadRequest("destinationAmount, feeAmount and feeCategoryId only apply to transfers")
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/dimasbaguspm/spenicle-api/internal/repositories"
+	"github.com/redis/go-redis/v9"
+)
+
+type TransactionSplitService struct {
+	rpts *repositories.RootRepository
+	rdb  *redis.Client
+	tsvc TransactionService
+}
+
+func NewTransactionSplitService(rpts *repositories.RootRepository, rdb *redis.Client, tsvc TransactionService) TransactionSplitService {
+	return TransactionSplitService{
+		rpts: rpts,
+		rdb:  rdb,
+		tsvc: tsvc,
+	}
+}
+
+// GetList returns the split lines of a transaction, read from its cached detail
+func (tss TransactionSplitService) GetList(ctx context.Context, transactionID int64) (models.TransactionSplitsListModel, error) {
+	transaction, err := tss.tsvc.GetDetail(ctx, transactionID)
+	if err != nil {
+		return models.TransactionSplitsListModel{}, err
+	}
+
+	items := transaction.Splits
+	if items == nil {
+		items = []models.TransactionSplitModel{}
+	}
+	return models.TransactionSplitsListModel{Items: items}, nil
+}
+
+// Replace sets the split lines of a transaction; an empty list turns it back into a single-category transaction
+// Lines must sum to the transaction amount and use categories of the transaction type; transfers cannot be split
+// The balance of the account is untouched since the total stays the same
+// The transaction is locked while the lines are swapped, and ifMatch must match its ETag like on Update
+func (tss TransactionSplitService) Replace(ctx context.Context, transactionID int64, p models.ReplaceTransactionSplitsModel, ifMatch string) (models.TransactionModel, error) {
+	tx, err := tss.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.TransactionModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := tss.rpts.WithTx(ctx, tx)
+	existing, err := rootTx.Tsct.GetDetailForUpdate(ctx, transactionID)
+	if err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	if err := common.RequireETag(ifMatch, existing.ETag, existing); err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	if err := tss.tsvc.RequireUnlocked(existing); err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	var destAccountID *int64
+	if existing.DestinationAccount != nil {
+		destAccountID = &existing.DestinationAccount.ID
+	}
+	if err := tss.tsvc.RequireEditableAccounts(ctx, existing.Account.ID, destAccountID); err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	if len(p.Lines) > 0 {
+		if existing.Type == "transfer" {
+			return models.TransactionModel{}, huma.Error400BadRequest("Transfers cannot be split")
+		}
+		if err := tss.validateLines(ctx, existing, p.Lines); err != nil {
+			return models.TransactionModel{}, err
+		}
+	}
+
+	if err := rootTx.TsctSplit.Replace(ctx, transactionID, p.Lines); err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	transaction, err := rootTx.Tsct.GetDetail(ctx, transactionID)
+	if err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityTransaction, transactionID, constants.AuditOperationUpdate, existing, transaction); err != nil {
+		return models.TransactionModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.TransactionModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
+	if err := common.InvalidateCacheForEntity(ctx, tss.rdb, constants.EntityTransaction, map[string]interface{}{
+		"transactionId": transactionID,
+		"accountId":     existing.Account.ID,
+		"categoryId":    existing.Category.ID,
+	}); err != nil {
+		observability.NewLogger("service", "TransactionSplitService").Warn("cache invalidation failed", "error", err)
+	}
+
+	// Category statistics of every line category, before and after, include this transaction's amounts
+	categoryIDs := make(map[int64]bool)
+	for _, split := range existing.Splits {
+		categoryIDs[split.Category.ID] = true
+	}
+	for _, line := range p.Lines {
+		categoryIDs[line.CategoryID] = true
+	}
+	for categoryID := range categoryIDs {
+		if err := common.InvalidateCacheForEntity(ctx, tss.rdb, constants.EntityCategory, map[string]interface{}{"categoryId": categoryID}); err != nil {
+			observability.NewLogger("service", "TransactionSplitService").Warn("cache invalidation failed", "error", err)
+		}
+	}
+
+	return transaction, nil
+}
+
+func (tss TransactionSplitService) validateLines(ctx context.Context, transaction models.TransactionModel, lines []models.TransactionSplitLineModel) error {
+	var total int64
+	checkedCategories := make(map[int64]bool)
+	checkedTags := make(map[int64]bool)
+
+	for i, line := range lines {
+		total += line.Amount
+
+		if !checkedCategories[line.CategoryID] {
+			category, err := tss.rpts.Cat.GetDetail(ctx, line.CategoryID)
+			if err != nil {
+				return huma.Error400BadRequest(fmt.Sprintf("Line %d: Category not found", i+1), err)
+			}
+			if category.Type != transaction.Type {
+				return huma.Error400BadRequest(fmt.Sprintf("Line %d: Category type does not match transaction type", i+1))
+			}
+			checkedCategories[line.CategoryID] = true
+		}
+
+		for _, tagID := range line.TagIDs {
+			if checkedTags[tagID] {
+				continue
+			}
+			if _, err := tss.rpts.Tag.GetDetail(ctx, tagID); err != nil {
+				return huma.Error400BadRequest(fmt.Sprintf("Line %d: Tag not found", i+1), err)
+			}
+			checkedTags[tagID] = true
+		}
+	}
+
+	if total != transaction.Amount {
+		return huma.Error400BadRequest(fmt.Sprintf("Split lines sum to %d but the transaction amount is %d", total, transaction.Amount))
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 
+	"github.com/danielgtaylor/huma/v2"
 	"github.com/dimasbaguspm/spenicle-api/internal/common"
 	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
@@ -21,28 +22,44 @@ func NewTransactionTagService(rpts *repositories.RootRepository, rdb *redis.Clie
 }
 
 func (tts TransactionTagService) GetPaged(ctx context.Context, q models.TransactionTagsSearchModel) (models.TransactionTagsPagedModel, error) {
-	cacheKey := common.BuildPagedCacheKey(constants.EntityTransactionTag, q)
+	cacheKey := common.BuildPagedCacheKey(ctx, constants.EntityTransactionTag, q)
 	return common.FetchWithCache(ctx, tts.rdb, cacheKey, constants.CacheTTLPaged, func(ctx context.Context) (models.TransactionTagsPagedModel, error) {
 		return tts.rpts.TsctTag.GetPaged(ctx, q)
 	}, "transaction_tag")
 }
 
 func (tts TransactionTagService) GetDetail(ctx context.Context, ID int64) (models.TransactionTagModel, error) {
-	cacheKey := common.BuildDetailCacheKey(constants.EntityTransactionTag, ID)
+	cacheKey := common.BuildDetailCacheKey(ctx, constants.EntityTransactionTag, ID)
 	return common.FetchWithCache(ctx, tts.rdb, cacheKey, constants.CacheTTLDetail, func(ctx context.Context) (models.TransactionTagModel, error) {
 		return tts.rpts.TsctTag.GetDetail(ctx, ID)
 	}, "transaction_tag")
 }
 
 func (tts TransactionTagService) Create(ctx context.Context, payload models.CreateTransactionTagModel) (models.TransactionTagModel, error) {
-	tag, err := tts.rpts.TsctTag.Create(ctx, payload)
+	tx, err := tts.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.TransactionTagModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := tts.rpts.WithTx(ctx, tx)
+	tag, err := rootTx.TsctTag.Create(ctx, payload)
 	if err != nil {
 		return tag, err
 	}
 
+	// Tag links are audited against their transaction
+	if err := recordAudit(ctx, rootTx, constants.EntityTransactionTag, payload.TransactionID, constants.AuditOperationCreate, nil, tag); err != nil {
+		return models.TransactionTagModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.TransactionTagModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
 	// Get transaction to find accountId for cache invalidation
-	tx, _ := tts.rpts.Tsct.GetDetail(ctx, payload.TransactionID)
-	accountId := tx.Account.ID
+	transaction, _ := tts.rpts.Tsct.GetDetail(ctx, payload.TransactionID)
+	accountId := transaction.Account.ID
 
 	if err := common.InvalidateCacheForEntity(ctx, tts.rdb, constants.EntityTransactionTag, map[string]interface{}{"tagId": tag.ID, "accountId": accountId}); err != nil {
 		observability.NewLogger("service", "TransactionTagService").Warn("cache invalidation failed", "error", err)
@@ -51,14 +68,29 @@ func (tts TransactionTagService) Create(ctx context.Context, payload models.Crea
 }
 
 func (tts TransactionTagService) Delete(ctx context.Context, transactionID, tagID int64) error {
-	err := tts.rpts.TsctTag.Delete(ctx, transactionID, tagID)
+	tx, err := tts.rpts.Pool.Begin(ctx)
 	if err != nil {
+		return huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := tts.rpts.WithTx(ctx, tx)
+	if err := rootTx.TsctTag.Delete(ctx, transactionID, tagID); err != nil {
 		return err
 	}
 
+	removed := map[string]int64{"transactionId": transactionID, "tagId": tagID}
+	if err := recordAudit(ctx, rootTx, constants.EntityTransactionTag, transactionID, constants.AuditOperationDelete, removed, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
 	// Get transaction to find accountId for cache invalidation
-	tx, _ := tts.rpts.Tsct.GetDetail(ctx, transactionID)
-	accountId := tx.Account.ID
+	transaction, _ := tts.rpts.Tsct.GetDetail(ctx, transactionID)
+	accountId := transaction.Account.ID
 
 	if err := common.InvalidateCacheForEntity(ctx, tts.rdb, constants.EntityTransactionTag, map[string]interface{}{"tagId": tagID, "accountId": accountId}); err != nil {
 		observability.NewLogger("service", "TransactionTagService").Warn("cache invalidation failed", "error", err)
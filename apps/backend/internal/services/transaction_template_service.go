@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 
+	"github.com/danielgtaylor/huma/v2"
 	"github.com/dimasbaguspm/spenicle-api/internal/common"
 	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/models"
@@ -21,25 +22,44 @@ func NewTransactionTemplateService(rpts *repositories.RootRepository, rdb *redis
 }
 
 func (tts TransactionTemplateService) GetPaged(ctx context.Context, query models.TransactionTemplatesSearchModel) (models.TransactionTemplatesPagedModel, error) {
-	cacheKey := common.BuildPagedCacheKey(constants.EntityTransactionTemplate, query)
+	cacheKey := common.BuildPagedCacheKey(ctx, constants.EntityTransactionTemplate, query)
 	return common.FetchWithCache(ctx, tts.rdb, cacheKey, constants.CacheTTLPaged, func(ctx context.Context) (models.TransactionTemplatesPagedModel, error) {
 		return tts.rpts.TsctTem.GetPaged(ctx, query)
 	}, "transaction_template")
 }
 
 func (tts TransactionTemplateService) GetDetail(ctx context.Context, id int64) (models.TransactionTemplateModel, error) {
-	cacheKey := common.BuildDetailCacheKey(constants.EntityTransactionTemplate, id)
+	cacheKey := common.BuildDetailCacheKey(ctx, constants.EntityTransactionTemplate, id)
 	return common.FetchWithCache(ctx, tts.rdb, cacheKey, constants.CacheTTLDetail, func(ctx context.Context) (models.TransactionTemplateModel, error) {
 		return tts.rpts.TsctTem.GetDetail(ctx, id)
 	}, "transaction_template")
 }
 
 func (tts TransactionTemplateService) Create(ctx context.Context, payload models.CreateTransactionTemplateModel) (models.TransactionTemplateModel, error) {
-	template, err := tts.rpts.TsctTem.Create(ctx, payload)
+	if err := requirePayee(ctx, tts.rpts, payload.PayeeID); err != nil {
+		return models.TransactionTemplateModel{}, err
+	}
+
+	tx, err := tts.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.TransactionTemplateModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := tts.rpts.WithTx(ctx, tx)
+	template, err := rootTx.TsctTem.Create(ctx, payload)
 	if err != nil {
 		return template, err
 	}
 
+	if err := recordAudit(ctx, rootTx, constants.EntityTransactionTemplate, template.ID, constants.AuditOperationCreate, nil, template); err != nil {
+		return models.TransactionTemplateModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.TransactionTemplateModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
 	if err := common.InvalidateCacheForEntity(ctx, tts.rdb, constants.EntityTransactionTemplate, map[string]interface{}{"templateId": template.ID}); err != nil {
 		observability.NewLogger("service", "TransactionTemplateService").Warn("cache invalidation failed", "error", err)
 	}
@@ -48,11 +68,49 @@ func (tts TransactionTemplateService) Create(ctx context.Context, payload models
 }
 
 func (tts TransactionTemplateService) Update(ctx context.Context, id int64, payload models.UpdateTransactionTemplateModel) (models.TransactionTemplateModel, error) {
-	template, err := tts.rpts.TsctTem.Update(ctx, id, payload)
+	if _, err := requireAccess(ctx, tts.rpts, constants.EntityTransactionTemplate, id, constants.ShareRoleOwner, constants.ShareRoleEditor); err != nil {
+		return models.TransactionTemplateModel{}, err
+	}
+
+	// Moving a template to another account needs edit access on that account too
+	for _, accountID := range []*int64{payload.AccountID, payload.DestinationAccountID} {
+		if accountID == nil || *accountID == 0 {
+			continue
+		}
+		if _, err := requireAccess(ctx, tts.rpts, constants.EntityAccount, *accountID, constants.ShareRoleOwner, constants.ShareRoleEditor); err != nil {
+			return models.TransactionTemplateModel{}, err
+		}
+	}
+
+	if err := requirePayee(ctx, tts.rpts, payload.PayeeID); err != nil {
+		return models.TransactionTemplateModel{}, err
+	}
+
+	tx, err := tts.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.TransactionTemplateModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := tts.rpts.WithTx(ctx, tx)
+	existing, err := rootTx.TsctTem.GetDetail(ctx, id)
+	if err != nil {
+		return models.TransactionTemplateModel{}, err
+	}
+
+	template, err := rootTx.TsctTem.Update(ctx, id, payload)
 	if err != nil {
 		return template, err
 	}
 
+	if err := recordAudit(ctx, rootTx, constants.EntityTransactionTemplate, id, constants.AuditOperationUpdate, existing, template); err != nil {
+		return models.TransactionTemplateModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.TransactionTemplateModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
 	if err := common.InvalidateCacheForEntity(ctx, tts.rdb, constants.EntityTransactionTemplate, map[string]interface{}{"templateId": id}); err != nil {
 		observability.NewLogger("service", "TransactionTemplateService").Warn("cache invalidation failed", "error", err)
 	}
@@ -61,11 +119,34 @@ func (tts TransactionTemplateService) Update(ctx context.Context, id int64, payl
 }
 
 func (tts TransactionTemplateService) Delete(ctx context.Context, id int64) error {
-	err := tts.rpts.TsctTem.Delete(ctx, id)
+	if _, err := requireAccess(ctx, tts.rpts, constants.EntityTransactionTemplate, id, constants.ShareRoleOwner); err != nil {
+		return err
+	}
+
+	tx, err := tts.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := tts.rpts.WithTx(ctx, tx)
+	existing, err := rootTx.TsctTem.GetDetail(ctx, id)
 	if err != nil {
 		return err
 	}
 
+	if err := rootTx.TsctTem.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityTransactionTemplate, id, constants.AuditOperationDelete, existing, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
 	if err := common.InvalidateCacheForEntity(ctx, tts.rdb, constants.EntityTransactionTemplate, map[string]interface{}{"templateId": id}); err != nil {
 		observability.NewLogger("service", "TransactionTemplateService").Warn("cache invalidation failed", "error", err)
 	}
@@ -73,8 +154,53 @@ func (tts TransactionTemplateService) Delete(ctx context.Context, id int64) erro
 	return nil
 }
 
+// Restore brings a template back from the trash; only its owner can restore it
+func (tts TransactionTemplateService) Restore(ctx context.Context, id int64) (models.TransactionTemplateModel, error) {
+	tx, err := tts.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.TransactionTemplateModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := tts.rpts.WithTx(ctx, tx)
+	if err := rootTx.TsctTem.Restore(ctx, id); err != nil {
+		return models.TransactionTemplateModel{}, err
+	}
+
+	template, err := rootTx.TsctTem.GetDetail(ctx, id)
+	if err != nil {
+		return models.TransactionTemplateModel{}, err
+	}
+
+	if err := recordAudit(ctx, rootTx, constants.EntityTransactionTemplate, id, constants.AuditOperationRestore, nil, template); err != nil {
+		return models.TransactionTemplateModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.TransactionTemplateModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
+	if err := common.InvalidateCacheForEntity(ctx, tts.rdb, constants.EntityTransactionTemplate, map[string]interface{}{"templateId": id}); err != nil {
+		observability.NewLogger("service", "TransactionTemplateService").Warn("cache invalidation failed", "error", err)
+	}
+
+	return template, nil
+}
+
+// GetRelatedTransactions lists the transactions created from a template
+// Transactions of a shared template are recorded by its owner, so a grantee only sees those on accounts they can access
 func (tts TransactionTemplateService) GetRelatedTransactions(ctx context.Context, templateID int64, query models.TransactionTemplateRelatedTransactionsSearchModel) (models.TransactionsPagedModel, error) {
-	ids, err := tts.rpts.TsctTem.GetRelatedTransactions(ctx, templateID, query)
+	access, err := requireAccess(ctx, tts.rpts, constants.EntityTransactionTemplate, templateID, constants.ShareRoleOwner, constants.ShareRoleEditor, constants.ShareRoleViewer)
+	if err != nil {
+		return models.TransactionsPagedModel{}, err
+	}
+
+	// Checked before the lookup so cursors fail the same way while the template has no transactions yet
+	if _, _, err := common.ParseCursorParams(query.SortBy, query.After, query.Before); err != nil {
+		return models.TransactionsPagedModel{}, err
+	}
+
+	ids, err := tts.rpts.TsctTem.GetRelatedTransactions(common.WithUserID(ctx, access.OwnerID), templateID, query)
 	if err != nil {
 		return models.TransactionsPagedModel{}, err
 	}
@@ -99,6 +225,9 @@ func (tts TransactionTemplateService) GetRelatedTransactions(ctx context.Context
 		PageSize:              query.PageSize,
 		SortBy:                query.SortBy,
 		SortOrder:             query.SortOrder,
+		After:                 query.After,
+		Before:                query.Before,
+		IncludeTotal:          query.IncludeTotal,
 		IDs:                   intIDs,
 		Type:                  query.Type,
 		AccountIDs:            query.AccountIDs,
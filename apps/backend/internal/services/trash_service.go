@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/clients"
+	"github.com/dimasbaguspm/spenicle-api/internal/configs"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/dimasbaguspm/spenicle-api/internal/repositories"
+)
+
+type TrashService struct {
+	rpts      *repositories.RootRepository
+	storage   clients.AttachmentStorage
+	retention time.Duration
+}
+
+func NewTrashService(rpts *repositories.RootRepository, storage clients.AttachmentStorage) TrashService {
+	return TrashService{
+		rpts:      rpts,
+		storage:   storage,
+		retention: configs.TrashRetention(),
+	}
+}
+
+// GetPaged lists deleted records with the time they become due for purging; it is not cached since every delete and restore changes it
+func (ts TrashService) GetPaged(ctx context.Context, query models.TrashSearchModel) (models.TrashPagedModel, error) {
+	trash, err := ts.rpts.Trash.GetPaged(ctx, query)
+	if err != nil {
+		return models.TrashPagedModel{}, err
+	}
+
+	for i := range trash.Items {
+		trash.Items[i].PurgeAt = trash.Items[i].DeletedAt.Add(ts.retention)
+	}
+	return trash, nil
+}
+
+// Purge permanently removes records deleted longer ago than the retention, then the files of their attachments
+// Files are only deleted once the database changes are committed; a failed file delete leaves an orphan, never a broken attachment
+func (ts TrashService) Purge(ctx context.Context) (models.TrashPurgeResultModel, error) {
+	tx, err := ts.rpts.Pool.Begin(ctx)
+	if err != nil {
+		return models.TrashPurgeResultModel{}, huma.Error422UnprocessableEntity("failed to start transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	rootTx := ts.rpts.WithTx(ctx, tx)
+	result, err := rootTx.Trash.Purge(ctx, time.Now().Add(-ts.retention))
+	if err != nil {
+		return models.TrashPurgeResultModel{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.TrashPurgeResultModel{}, huma.Error422UnprocessableEntity("failed to commit transaction")
+	}
+
+	for _, key := range result.StorageKeys {
+		if err := ts.storage.Delete(ctx, key); err != nil {
+			observability.RecordError("storage")
+			observability.NewLogger("service", "TrashService").Warn("purged attachment file cleanup failed", "storage_key", key, "error", err)
+		}
+	}
+
+	return result, nil
+}
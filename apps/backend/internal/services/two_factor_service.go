@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/repositories"
+)
+
+type TwoFactorService struct {
+	rpts *repositories.RootRepository
+}
+
+func NewTwoFactorService(rpts *repositories.RootRepository) TwoFactorService {
+	return TwoFactorService{rpts}
+}
+
+// Enroll generates a new pending TOTP secret; it is enforced only after Verify succeeds
+func (tfs TwoFactorService) Enroll(ctx context.Context) (models.TwoFactorEnrollResponseModel, error) {
+	state, err := tfs.rpts.Usr.GetTOTP(ctx)
+	if err != nil {
+		return models.TwoFactorEnrollResponseModel{}, err
+	}
+	if state.EnabledAt != nil {
+		return models.TwoFactorEnrollResponseModel{}, huma.Error409Conflict("Two-factor authentication is already enabled")
+	}
+
+	secret, err := common.GenerateTOTPSecret()
+	if err != nil {
+		return models.TwoFactorEnrollResponseModel{}, huma.Error500InternalServerError("Unable to generate two-factor secret", err)
+	}
+
+	if err := tfs.rpts.Usr.SetPendingTOTP(ctx, &secret); err != nil {
+		return models.TwoFactorEnrollResponseModel{}, err
+	}
+
+	return models.TwoFactorEnrollResponseModel{
+		Secret:          secret,
+		ProvisioningURI: common.BuildTOTPProvisioningURI(constants.AuthTOTPIssuer, state.Username, secret),
+	}, nil
+}
+
+// Verify confirms the pending secret with a code from the app, enables 2FA and issues recovery codes
+func (tfs TwoFactorService) Verify(ctx context.Context, p models.TwoFactorCodeRequestModel) (models.TwoFactorRecoveryCodesModel, error) {
+	state, err := tfs.rpts.Usr.GetTOTP(ctx)
+	if err != nil {
+		return models.TwoFactorRecoveryCodesModel{}, err
+	}
+	if state.EnabledAt != nil {
+		return models.TwoFactorRecoveryCodesModel{}, huma.Error409Conflict("Two-factor authentication is already enabled")
+	}
+	if state.Secret == nil {
+		return models.TwoFactorRecoveryCodesModel{}, huma.Error400BadRequest("Start enrollment before verifying a code")
+	}
+
+	step, ok := common.ValidateTOTP(*state.Secret, p.Code, time.Now())
+	if !ok {
+		return models.TwoFactorRecoveryCodesModel{}, huma.Error400BadRequest("Invalid two-factor code")
+	}
+
+	if err := tfs.rpts.Usr.EnableTOTP(ctx, step); err != nil {
+		return models.TwoFactorRecoveryCodesModel{}, err
+	}
+
+	return tfs.issueRecoveryCodes(ctx)
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes after confirming a second factor
+func (tfs TwoFactorService) RegenerateRecoveryCodes(ctx context.Context, p models.TwoFactorCodeRequestModel) (models.TwoFactorRecoveryCodesModel, error) {
+	if err := tfs.confirm(ctx, p); err != nil {
+		return models.TwoFactorRecoveryCodesModel{}, err
+	}
+	return tfs.issueRecoveryCodes(ctx)
+}
+
+// Disable turns off 2FA after confirming a second factor and discards the recovery codes
+func (tfs TwoFactorService) Disable(ctx context.Context, p models.TwoFactorCodeRequestModel) error {
+	if err := tfs.confirm(ctx, p); err != nil {
+		return err
+	}
+	if err := tfs.rpts.Usr.SetPendingTOTP(ctx, nil); err != nil {
+		return err
+	}
+	return tfs.rpts.Usr.ReplaceRecoveryCodes(ctx, []string{})
+}
+
+func (tfs TwoFactorService) confirm(ctx context.Context, p models.TwoFactorCodeRequestModel) error {
+	state, err := tfs.rpts.Usr.GetTOTP(ctx)
+	if err != nil {
+		return err
+	}
+	if state.EnabledAt == nil {
+		return huma.Error400BadRequest("Two-factor authentication is not enabled")
+	}
+
+	ok, err := verifySecondFactor(ctx, tfs.rpts, state, p.Code, p.RecoveryCode)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return huma.Error400BadRequest("Invalid two-factor code")
+	}
+	return nil
+}
+
+func (tfs TwoFactorService) issueRecoveryCodes(ctx context.Context) (models.TwoFactorRecoveryCodesModel, error) {
+	codes := make([]string, constants.AuthRecoveryCodeCount)
+	hashes := make([]string, constants.AuthRecoveryCodeCount)
+	for i := range codes {
+		bytes := make([]byte, constants.AuthRecoveryCodeByteLength)
+		if _, err := rand.Read(bytes); err != nil {
+			return models.TwoFactorRecoveryCodesModel{}, huma.Error500InternalServerError("Unable to generate recovery codes", err)
+		}
+
+		raw := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(bytes)
+		var groups []string
+		for start := 0; start < len(raw); start += 4 {
+			groups = append(groups, raw[start:min(start+4, len(raw))])
+		}
+		codes[i] = strings.Join(groups, "-")
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+
+	if err := tfs.rpts.Usr.ReplaceRecoveryCodes(ctx, hashes); err != nil {
+		return models.TwoFactorRecoveryCodesModel{}, err
+	}
+
+	return models.TwoFactorRecoveryCodesModel{RecoveryCodes: codes}, nil
+}
+
+// verifySecondFactor checks a TOTP code, or consumes a recovery code when one is given
+// TOTP codes are single-use too: a time step is rejected once it has been accepted
+func verifySecondFactor(ctx context.Context, rpts *repositories.RootRepository, state models.UserTOTPModel, code string, recoveryCode string) (bool, error) {
+	if recoveryCode != "" {
+		return rpts.Usr.UseRecoveryCode(ctx, hashRecoveryCode(recoveryCode))
+	}
+
+	if state.Secret == nil {
+		return false, nil
+	}
+
+	step, ok := common.ValidateTOTP(*state.Secret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+
+	return rpts.Usr.AdvanceTOTPStep(ctx, step)
+}
+
+// hashRecoveryCode normalises case and separators before hashing, so codes can be typed loosely
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/models"
+	"github.com/dimasbaguspm/spenicle-api/internal/repositories"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type UserService struct {
+	rpts *repositories.RootRepository
+}
+
+func NewUserService(rpts *repositories.RootRepository) UserService {
+	return UserService{rpts}
+}
+
+// GetMe returns the user attached to the request context
+func (us UserService) GetMe(ctx context.Context) (models.UserModel, error) {
+	return us.rpts.Usr.GetDetail(ctx, common.GetUserID(ctx))
+}
+
+// Create registers another user; each user only ever sees their own data.
+// Only an admin may register users
+func (us UserService) Create(ctx context.Context, payload models.CreateUserModel) (models.UserModel, error) {
+	me, err := us.rpts.Usr.GetDetail(ctx, common.GetUserID(ctx))
+	if err != nil {
+		return models.UserModel{}, err
+	}
+	if !me.IsAdmin {
+		return models.UserModel{}, huma.Error403Forbidden("Only an admin can create users")
+	}
+
+	_, err = us.rpts.Usr.GetCredentials(ctx, payload.Username)
+	if err == nil {
+		return models.UserModel{}, huma.Error409Conflict("Username is already taken")
+	}
+	var statusErr huma.StatusError
+	if !errors.As(err, &statusErr) || statusErr.GetStatus() != http.StatusNotFound {
+		return models.UserModel{}, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(payload.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return models.UserModel{}, huma.Error500InternalServerError("Unable to hash password", err)
+	}
+
+	return us.rpts.Usr.Create(ctx, payload.Username, string(hash))
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/constants"
 	"github.com/dimasbaguspm/spenicle-api/internal/observability"
 	"github.com/dimasbaguspm/spenicle-api/internal/services"
 	"github.com/redis/go-redis/v9"
@@ -68,7 +69,8 @@ func (btw *BudgetTemplateWorker) processTemplates(ctx context.Context) error {
 		templateLogger := logger.With("template_id", template.ID)
 		templateLogger.Info("processing template")
 
-		if _, err := btw.budgetTemplateService.GenerateBudgetFromTemplate(ctx, template); err != nil {
+		userCtx := common.WithAuditSource(common.WithUserID(ctx, template.UserID), constants.AuditSourceBudgetTemplateWorker)
+		if _, err := btw.budgetTemplateService.GenerateBudgetFromTemplate(userCtx, template); err != nil {
 			templateLogger.Error("failed to process template", "error", err)
 			observability.BudgetTemplatesFailed.Inc()
 			continue
@@ -22,6 +22,7 @@ const (
 type GeoIndexTransactionsWorker struct {
 	cronWorker *common.CronWorker
 	repo       repositories.TransactionRepository
+	usrRepo    repositories.UserRepository
 	geoMgr     *common.GeoIndexManager
 	rdb        *redis.Client
 }
@@ -29,12 +30,14 @@ type GeoIndexTransactionsWorker struct {
 func NewGeoIndexTransactionsWorker(
 	ctx context.Context,
 	repo repositories.TransactionRepository,
+	usrRepo repositories.UserRepository,
 	geoMgr *common.GeoIndexManager,
 	rdb *redis.Client,
 ) *GeoIndexTransactionsWorker {
 	return &GeoIndexTransactionsWorker{
 		cronWorker: common.NewCronWorker(ctx),
 		repo:       repo,
+		usrRepo:    usrRepo,
 		geoMgr:     geoMgr,
 		rdb:        rdb,
 	}
@@ -64,7 +67,13 @@ func (gitw *GeoIndexTransactionsWorker) repopulateGeoIndex(ctx context.Context)
 	logger := observability.NewLogger("worker", "GeoIndexTransactionsWorker", "run_id", runID, "task", "repopulateGeoIndex")
 	logger.Info("start")
 
-	// Fetch first 500 transactions in a single call
+	userIDs, err := gitw.usrRepo.GetActiveIDs(ctx)
+	if err != nil {
+		logger.Error("failed to fetch users", "error", err)
+		return err
+	}
+
+	// Fetch the latest 500 transactions of each user, since transaction queries are owner scoped
 	searchParams := models.TransactionsSearchModel{
 		PageNumber: 1,
 		PageSize:   geoIndexBatchSize,
@@ -72,22 +81,19 @@ func (gitw *GeoIndexTransactionsWorker) repopulateGeoIndex(ctx context.Context)
 		SortOrder:  "desc",
 	}
 
-	result, err := gitw.repo.GetPaged(ctx, searchParams)
-	if err != nil {
-		logger.Error("failed to fetch transactions", "error", err)
-		return err
-	}
-
-	if len(result.Items) == 0 {
-		logger.Info("no transactions to index")
-		return nil
-	}
-
-	// Filter transactions with coordinates
 	var txnsWithGeo []models.TransactionModel
-	for _, txn := range result.Items {
-		if txn.Latitude != nil && txn.Longitude != nil {
-			txnsWithGeo = append(txnsWithGeo, txn)
+	for _, userID := range userIDs {
+		result, err := gitw.repo.GetPaged(common.WithUserID(ctx, userID), searchParams)
+		if err != nil {
+			logger.Error("failed to fetch transactions", "user_id", userID, "error", err)
+			return err
+		}
+
+		// Filter transactions with coordinates
+		for _, txn := range result.Items {
+			if txn.Latitude != nil && txn.Longitude != nil {
+				txnsWithGeo = append(txnsWithGeo, txn)
+			}
 		}
 	}
 
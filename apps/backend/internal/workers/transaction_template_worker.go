@@ -74,7 +74,9 @@ func (ttw *TransactionTemplateWorker) processTemplates(ctx context.Context) erro
 		templateLogger := logger.With("template_id", template.ID)
 		templateLogger.Info("processing template")
 
-		if err := ttw.processTemplate(ctx, template); err != nil {
+		// Generated transactions belong to the template owner
+		userCtx := common.WithAuditSource(common.WithUserID(ctx, template.UserID), constants.AuditSourceTransactionTemplateWorker)
+		if err := ttw.processTemplate(userCtx, template); err != nil {
 			templateLogger.Error("failed to process template", "error", err, "template_name", template.Name)
 			observability.TransactionTemplatesFailed.Inc()
 			continue
@@ -114,6 +116,11 @@ func (ttw *TransactionTemplateWorker) processTemplate(ctx context.Context, templ
 
 	var note *string = &noteStr
 
+	var payeeID *int64
+	if template.Payee != nil {
+		payeeID = &template.Payee.ID
+	}
+
 	transactionRequest := models.CreateTransactionModel{
 		Type:                 template.Type,
 		Date:                 time.Now().Truncate(24 * time.Hour),
@@ -122,7 +129,9 @@ func (ttw *TransactionTemplateWorker) processTemplate(ctx context.Context, templ
 		AccountID:            template.Account.ID,
 		CategoryID:           template.Category.ID,
 		DestinationAccountID: destAccountID,
+		PayeeID:              payeeID,
 		Note:                 note,
+		SkipRules:            true, // The template already says how its transactions are categorized
 	}
 
 	transaction, err := ttw.transactionService.Create(ctx, transactionRequest)
@@ -133,6 +142,13 @@ func (ttw *TransactionTemplateWorker) processTemplate(ctx context.Context, templ
 
 	logger.Info("transaction created", "transaction_id", transaction.ID)
 
+	// A run retried after a crash may record the same transaction twice; it is kept and flagged for review
+	if duplicates, err := ttw.transactionService.FindPossibleDuplicates(ctx, transaction); err != nil {
+		logger.Warn("duplicate check failed", "error", err, "transaction_id", transaction.ID)
+	} else if len(duplicates) > 0 {
+		logger.Warn("possible duplicate transaction", "transaction_id", transaction.ID, "duplicate_id", duplicates[0].Transaction.ID, "score", duplicates[0].Score)
+	}
+
 	if err := ttw.templateRepo.CreateRelation(ctx, transaction.ID, template.ID); err != nil {
 		logger.Error("failed to create relation", "error", err, "transaction_id", transaction.ID)
 	}
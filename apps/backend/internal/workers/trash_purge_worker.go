@@ -0,0 +1,72 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/dimasbaguspm/spenicle-api/internal/common"
+	"github.com/dimasbaguspm/spenicle-api/internal/observability"
+	"github.com/dimasbaguspm/spenicle-api/internal/services"
+)
+
+// TrashPurgeWorker permanently removes soft-deleted records once they are older than the trash retention
+type TrashPurgeWorker struct {
+	cronWorker   *common.CronWorker
+	trashService services.TrashService
+}
+
+func NewTrashPurgeWorker(ctx context.Context, trashService services.TrashService) *TrashPurgeWorker {
+	return &TrashPurgeWorker{
+		cronWorker:   common.NewCronWorker(ctx),
+		trashService: trashService,
+	}
+}
+
+func (tpw *TrashPurgeWorker) Start() error {
+	logger := observability.NewLogger("worker", "TrashPurgeWorker")
+	logger.Info("starting")
+
+	err := tpw.cronWorker.Register(common.CronTask{
+		ID:             "purge-trash",
+		Name:           "Purge Trash",
+		Schedule:       6 * time.Hour,
+		Handler:        tpw.purge,
+		RunImmediately: true,
+	})
+
+	if err != nil {
+		logger.Error("failed to start", "error", err)
+	}
+	return nil
+}
+
+func (tpw *TrashPurgeWorker) purge(ctx context.Context) error {
+	runID := observability.GenerateID()
+	logger := observability.NewLogger("worker", "TrashPurgeWorker", "run_id", runID, "task", "purge")
+	logger.Info("start")
+
+	result, err := tpw.trashService.Purge(ctx)
+	if err != nil {
+		logger.Error("failed to purge trash", "error", err)
+		return err
+	}
+
+	logger.Info("completed",
+		"transactions", result.Transactions,
+		"attachments", result.Attachments,
+		"templates", result.Templates,
+		"tags", result.Tags,
+		"budgets", result.Budgets,
+		"budget_templates", result.BudgetTemplates,
+		"payees", result.Payees,
+		"categories", result.Categories,
+		"accounts", result.Accounts,
+	)
+	return nil
+}
+
+func (tpw *TrashPurgeWorker) Stop() {
+	logger := observability.NewLogger("worker", "TrashPurgeWorker")
+	logger.Info("stopping")
+	tpw.cronWorker.Stop()
+}
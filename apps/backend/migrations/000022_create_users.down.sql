@@ -0,0 +1,51 @@
+-- Rollback users table and resource ownership
+ALTER TABLE tags
+DROP CONSTRAINT IF EXISTS unique_tag_name_per_user;
+
+ALTER TABLE tags
+ADD CONSTRAINT tags_name_key UNIQUE (name);
+
+ALTER TABLE categories
+DROP CONSTRAINT IF EXISTS unique_category_name_per_type;
+
+ALTER TABLE categories
+ADD CONSTRAINT unique_category_name_per_type UNIQUE (name, type, deleted_at);
+
+DROP INDEX IF EXISTS idx_budgets_user_id;
+
+DROP INDEX IF EXISTS idx_budget_templates_user_id;
+
+DROP INDEX IF EXISTS idx_transaction_templates_user_id;
+
+DROP INDEX IF EXISTS idx_tags_user_id;
+
+DROP INDEX IF EXISTS idx_transactions_user_id;
+
+DROP INDEX IF EXISTS idx_categories_user_id;
+
+DROP INDEX IF EXISTS idx_accounts_user_id;
+
+ALTER TABLE budgets
+DROP COLUMN IF EXISTS user_id;
+
+ALTER TABLE budget_templates
+DROP COLUMN IF EXISTS user_id;
+
+ALTER TABLE transaction_templates
+DROP COLUMN IF EXISTS user_id;
+
+ALTER TABLE tags
+DROP COLUMN IF EXISTS user_id;
+
+ALTER TABLE transactions
+DROP COLUMN IF EXISTS user_id;
+
+ALTER TABLE categories
+DROP COLUMN IF EXISTS user_id;
+
+ALTER TABLE accounts
+DROP COLUMN IF EXISTS user_id;
+
+DROP INDEX IF EXISTS idx_users_deleted_at;
+
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,76 @@
+-- Create users table and attach an owner to every top-level resource
+CREATE TABLE
+    IF NOT EXISTS users (
+        id BIGSERIAL PRIMARY KEY,
+        username VARCHAR(255) NOT NULL UNIQUE,
+        password_hash TEXT NOT NULL,
+        created_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        updated_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        deleted_at TIMESTAMP
+    );
+
+CREATE INDEX idx_users_deleted_at ON users (deleted_at);
+
+-- Owner columns stay nullable so rows created before multi-user support
+-- can be adopted by the first seeded user on boot
+ALTER TABLE accounts
+ADD COLUMN user_id BIGINT REFERENCES users (id);
+
+ALTER TABLE categories
+ADD COLUMN user_id BIGINT REFERENCES users (id);
+
+ALTER TABLE transactions
+ADD COLUMN user_id BIGINT REFERENCES users (id);
+
+ALTER TABLE tags
+ADD COLUMN user_id BIGINT REFERENCES users (id);
+
+ALTER TABLE transaction_templates
+ADD COLUMN user_id BIGINT REFERENCES users (id);
+
+ALTER TABLE budget_templates
+ADD COLUMN user_id BIGINT REFERENCES users (id);
+
+ALTER TABLE budgets
+ADD COLUMN user_id BIGINT REFERENCES users (id);
+
+CREATE INDEX idx_accounts_user_id ON accounts (user_id)
+WHERE
+    deleted_at IS NULL;
+
+CREATE INDEX idx_categories_user_id ON categories (user_id)
+WHERE
+    deleted_at IS NULL;
+
+CREATE INDEX idx_transactions_user_id ON transactions (user_id)
+WHERE
+    deleted_at IS NULL;
+
+CREATE INDEX idx_tags_user_id ON tags (user_id)
+WHERE
+    deleted_at IS NULL;
+
+CREATE INDEX idx_transaction_templates_user_id ON transaction_templates (user_id)
+WHERE
+    deleted_at IS NULL;
+
+CREATE INDEX idx_budget_templates_user_id ON budget_templates (user_id)
+WHERE
+    deleted_at IS NULL;
+
+CREATE INDEX idx_budgets_user_id ON budgets (user_id)
+WHERE
+    deleted_at IS NULL;
+
+-- Names only need to be unique within a single user's data
+ALTER TABLE categories
+DROP CONSTRAINT IF EXISTS unique_category_name_per_type;
+
+ALTER TABLE categories
+ADD CONSTRAINT unique_category_name_per_type UNIQUE (user_id, name, type, deleted_at);
+
+ALTER TABLE tags
+DROP CONSTRAINT IF EXISTS tags_name_key;
+
+ALTER TABLE tags
+ADD CONSTRAINT unique_tag_name_per_user UNIQUE (user_id, name);
@@ -0,0 +1,6 @@
+-- Rollback personal access tokens table
+DROP INDEX IF EXISTS idx_api_tokens_deleted_at;
+
+DROP INDEX IF EXISTS idx_api_tokens_user_id;
+
+DROP TABLE IF EXISTS api_tokens;
@@ -0,0 +1,22 @@
+-- Create personal access tokens table
+-- Only a SHA-256 hash of the token is stored; the plain value is shown once on creation
+CREATE TABLE
+    IF NOT EXISTS api_tokens (
+        id BIGSERIAL PRIMARY KEY,
+        user_id BIGINT NOT NULL REFERENCES users (id),
+        name VARCHAR(255) NOT NULL,
+        token_prefix VARCHAR(16) NOT NULL,
+        token_hash VARCHAR(64) NOT NULL UNIQUE,
+        scopes TEXT[] NOT NULL DEFAULT '{}',
+        last_used_at TIMESTAMP,
+        expires_at TIMESTAMP,
+        created_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        updated_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        deleted_at TIMESTAMP
+    );
+
+CREATE INDEX idx_api_tokens_user_id ON api_tokens (user_id)
+WHERE
+    deleted_at IS NULL;
+
+CREATE INDEX idx_api_tokens_deleted_at ON api_tokens (deleted_at);
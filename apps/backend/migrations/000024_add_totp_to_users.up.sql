@@ -0,0 +1,22 @@
+-- Add optional TOTP two-factor authentication to users
+-- totp_secret is set on enrollment and only enforced once totp_enabled_at is set
+ALTER TABLE users
+ADD COLUMN totp_secret VARCHAR(64),
+ADD COLUMN totp_enabled_at TIMESTAMP,
+ADD COLUMN totp_last_step BIGINT;
+
+-- Single-use recovery codes, stored as SHA-256 hashes
+CREATE TABLE
+    IF NOT EXISTS user_recovery_codes (
+        id BIGSERIAL PRIMARY KEY,
+        user_id BIGINT NOT NULL REFERENCES users (id),
+        code_hash VARCHAR(64) NOT NULL,
+        used_at TIMESTAMP,
+        created_at TIMESTAMP NOT NULL DEFAULT NOW ()
+    );
+
+CREATE INDEX idx_user_recovery_codes_user_id ON user_recovery_codes (user_id)
+WHERE
+    used_at IS NULL;
+
+COMMENT ON COLUMN users.totp_last_step IS 'Last accepted TOTP time step, codes at or before it are rejected to prevent replay';
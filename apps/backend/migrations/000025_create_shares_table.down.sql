@@ -0,0 +1,8 @@
+-- Rollback shares table
+DROP INDEX IF EXISTS idx_shares_deleted_at;
+
+DROP INDEX IF EXISTS idx_shares_user_id;
+
+DROP INDEX IF EXISTS idx_shares_resource_user;
+
+DROP TABLE IF EXISTS shares;
@@ -0,0 +1,28 @@
+-- Create shares table
+-- Grants another user viewer or editor access to an account, budget template or transaction template
+-- resource_type matches the cache entity name of the shared table
+CREATE TABLE
+    IF NOT EXISTS shares (
+        id BIGSERIAL PRIMARY KEY,
+        resource_type VARCHAR(32) NOT NULL CHECK (
+            resource_type IN ('account', 'budget_template', 'transaction_template')
+        ),
+        resource_id BIGINT NOT NULL,
+        owner_id BIGINT NOT NULL REFERENCES users (id),
+        user_id BIGINT NOT NULL REFERENCES users (id),
+        role VARCHAR(16) NOT NULL CHECK (role IN ('viewer', 'editor')),
+        created_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        updated_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        deleted_at TIMESTAMP,
+        CONSTRAINT shares_not_self CHECK (owner_id <> user_id)
+    );
+
+CREATE UNIQUE INDEX idx_shares_resource_user ON shares (resource_type, resource_id, user_id)
+WHERE
+    deleted_at IS NULL;
+
+CREATE INDEX idx_shares_user_id ON shares (user_id, resource_type)
+WHERE
+    deleted_at IS NULL;
+
+CREATE INDEX idx_shares_deleted_at ON shares (deleted_at);
@@ -0,0 +1,12 @@
+-- Rollback audit_logs table
+DROP TRIGGER IF EXISTS audit_logs_append_only ON audit_logs;
+
+DROP FUNCTION IF EXISTS audit_logs_reject_change;
+
+DROP INDEX IF EXISTS idx_audit_logs_entity;
+
+DROP INDEX IF EXISTS idx_audit_logs_actor_created_at;
+
+DROP INDEX IF EXISTS idx_audit_logs_user_created_at;
+
+DROP TABLE IF EXISTS audit_logs;
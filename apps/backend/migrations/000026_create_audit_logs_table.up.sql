@@ -0,0 +1,32 @@
+-- Create audit_logs table
+-- Append-only record of every create, update and delete with before/after snapshots
+-- user_id is the owner of the changed data, actor_id the user who made the change (they differ on shared resources)
+CREATE TABLE
+    IF NOT EXISTS audit_logs (
+        id BIGSERIAL PRIMARY KEY,
+        user_id BIGINT NOT NULL REFERENCES users (id),
+        actor_id BIGINT REFERENCES users (id),
+        source VARCHAR(50) NOT NULL,
+        entity_type VARCHAR(32) NOT NULL,
+        entity_id BIGINT NOT NULL,
+        operation VARCHAR(16) NOT NULL CHECK (operation IN ('create', 'update', 'delete')),
+        before JSONB,
+        after JSONB,
+        created_at TIMESTAMP NOT NULL DEFAULT NOW ()
+    );
+
+CREATE INDEX idx_audit_logs_user_created_at ON audit_logs (user_id, created_at DESC);
+
+CREATE INDEX idx_audit_logs_actor_created_at ON audit_logs (actor_id, created_at DESC);
+
+CREATE INDEX idx_audit_logs_entity ON audit_logs (entity_type, entity_id);
+
+-- Reject any change to existing entries so the log stays append-only
+CREATE OR REPLACE FUNCTION audit_logs_reject_change () RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_logs_append_only BEFORE UPDATE OR DELETE ON audit_logs FOR EACH ROW
+EXECUTE FUNCTION audit_logs_reject_change ();
@@ -0,0 +1,19 @@
+-- Create sessions table
+-- One row per login, shared by the access and refresh tokens issued to it (their "sid" claim)
+-- ip_address and last_seen_at follow the device as it keeps using the session
+CREATE TABLE
+    IF NOT EXISTS sessions (
+        id BIGSERIAL PRIMARY KEY,
+        user_id BIGINT NOT NULL REFERENCES users (id),
+        device_name VARCHAR(100),
+        user_agent TEXT,
+        ip_address VARCHAR(45),
+        created_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        last_seen_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        expires_at TIMESTAMP NOT NULL,
+        revoked_at TIMESTAMP
+    );
+
+CREATE INDEX idx_sessions_user_id ON sessions (user_id, last_seen_at DESC)
+WHERE
+    revoked_at IS NULL;
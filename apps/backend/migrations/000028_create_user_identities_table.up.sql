@@ -0,0 +1,16 @@
+-- Create user_identities table
+-- Links a local user to an account at an OpenID Connect issuer, keyed by the issuer's stable subject
+-- A row is written on the first OIDC login, once the configured claim matched a local username
+CREATE TABLE
+    IF NOT EXISTS user_identities (
+        id BIGSERIAL PRIMARY KEY,
+        user_id BIGINT NOT NULL REFERENCES users (id),
+        issuer VARCHAR(255) NOT NULL,
+        subject VARCHAR(255) NOT NULL,
+        email VARCHAR(255),
+        created_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        last_login_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        UNIQUE (issuer, subject)
+    );
+
+CREATE INDEX idx_user_identities_user_id ON user_identities (user_id);
@@ -0,0 +1,12 @@
+-- Rollback transaction_splits table
+DROP VIEW IF EXISTS transaction_category_amounts;
+
+DROP INDEX IF EXISTS idx_transaction_split_tags_tag_id;
+
+DROP TABLE IF EXISTS transaction_split_tags;
+
+DROP INDEX IF EXISTS idx_transaction_splits_category_id;
+
+DROP INDEX IF EXISTS idx_transaction_splits_transaction_id;
+
+DROP TABLE IF EXISTS transaction_splits;
@@ -0,0 +1,53 @@
+-- Create transaction_splits table
+-- Split lines divide one transaction between categories; when present their amounts sum to the transaction amount
+CREATE TABLE
+    IF NOT EXISTS transaction_splits (
+        id BIGSERIAL PRIMARY KEY,
+        transaction_id BIGINT NOT NULL REFERENCES transactions (id),
+        category_id BIGINT NOT NULL REFERENCES categories (id),
+        amount BIGINT NOT NULL CHECK (amount > 0),
+        note TEXT,
+        created_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        updated_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        deleted_at TIMESTAMP
+    );
+
+CREATE INDEX idx_transaction_splits_transaction_id ON transaction_splits (transaction_id)
+WHERE
+    deleted_at IS NULL;
+
+CREATE INDEX idx_transaction_splits_category_id ON transaction_splits (category_id)
+WHERE
+    deleted_at IS NULL;
+
+-- Create transaction_split_tags junction table
+CREATE TABLE
+    IF NOT EXISTS transaction_split_tags (
+        id BIGSERIAL PRIMARY KEY,
+        split_id BIGINT NOT NULL REFERENCES transaction_splits (id),
+        tag_id BIGINT NOT NULL REFERENCES tags (id),
+        created_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        CONSTRAINT unique_transaction_split_tag UNIQUE (split_id, tag_id)
+    );
+
+CREATE INDEX idx_transaction_split_tags_tag_id ON transaction_split_tags (tag_id);
+
+-- One row per category amount: a split transaction contributes its lines, any other transaction itself
+-- Category reports read from here instead of transactions; amounts per transaction still sum to its total
+CREATE VIEW
+    transaction_category_amounts AS
+SELECT
+    t.id,
+    t.user_id,
+    t.type,
+    t.date,
+    t.account_id,
+    t.destination_account_id,
+    COALESCE(s.category_id, t.category_id) AS category_id,
+    COALESCE(s.amount, t.amount) AS amount,
+    s.id AS split_id,
+    t.deleted_at
+FROM
+    transactions t
+    LEFT JOIN transaction_splits s ON s.transaction_id = t.id
+    AND s.deleted_at IS NULL;
@@ -0,0 +1,35 @@
+-- Drop transaction full-text search
+DROP TRIGGER IF EXISTS accounts_search_vector ON accounts;
+
+DROP TRIGGER IF EXISTS tags_search_vector ON tags;
+
+DROP TRIGGER IF EXISTS categories_search_vector ON categories;
+
+DROP TRIGGER IF EXISTS transaction_split_tags_search_vector ON transaction_split_tags;
+
+DROP TRIGGER IF EXISTS transaction_splits_search_vector ON transaction_splits;
+
+DROP TRIGGER IF EXISTS transaction_tags_search_vector ON transaction_tags;
+
+DROP TRIGGER IF EXISTS transactions_search_vector ON transactions;
+
+DROP FUNCTION IF EXISTS accounts_search_vector_refresh ();
+
+DROP FUNCTION IF EXISTS tags_search_vector_refresh ();
+
+DROP FUNCTION IF EXISTS categories_search_vector_refresh ();
+
+DROP FUNCTION IF EXISTS transaction_split_tags_search_vector_refresh ();
+
+DROP FUNCTION IF EXISTS transaction_splits_search_vector_refresh ();
+
+DROP FUNCTION IF EXISTS transaction_tags_search_vector_refresh ();
+
+DROP FUNCTION IF EXISTS transactions_search_vector_refresh ();
+
+DROP FUNCTION IF EXISTS refresh_transaction_search_vector (BIGINT[]);
+
+DROP INDEX IF EXISTS idx_transactions_search_vector;
+
+ALTER TABLE transactions
+DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,164 @@
+-- Add a full-text search document to transactions
+-- The document covers the note, category, account and tag names plus split line notes, categories and tags
+-- The 'simple' configuration is used so matching does not depend on the language of the user's data
+ALTER TABLE transactions
+ADD COLUMN IF NOT EXISTS search_vector tsvector NOT NULL DEFAULT ''::tsvector;
+
+-- Rebuild the search document of the given transactions
+-- Notes weigh A, category and tag names B and the account name C
+CREATE OR REPLACE FUNCTION refresh_transaction_search_vector (tx_ids BIGINT[]) RETURNS VOID AS $$
+BEGIN
+    UPDATE transactions t
+    SET search_vector =
+        setweight(to_tsvector('simple', COALESCE(t.note, '')), 'A')
+        || setweight(to_tsvector('simple', COALESCE((
+            SELECT STRING_AGG(COALESCE(s.note, ''), ' ')
+            FROM transaction_splits s
+            WHERE s.transaction_id = t.id AND s.deleted_at IS NULL
+        ), '')), 'A')
+        || setweight(to_tsvector('simple', COALESCE((
+            SELECT c.name FROM categories c WHERE c.id = t.category_id AND c.deleted_at IS NULL
+        ), '')), 'B')
+        || setweight(to_tsvector('simple', COALESCE((
+            SELECT STRING_AGG(c.name, ' ')
+            FROM transaction_splits s
+            INNER JOIN categories c ON s.category_id = c.id
+            WHERE s.transaction_id = t.id AND s.deleted_at IS NULL AND c.deleted_at IS NULL
+        ), '')), 'B')
+        || setweight(to_tsvector('simple', COALESCE((
+            SELECT STRING_AGG(tg.name, ' ')
+            FROM transaction_tags tt
+            INNER JOIN tags tg ON tt.tag_id = tg.id
+            WHERE tt.transaction_id = t.id AND tg.deleted_at IS NULL
+        ), '')), 'B')
+        || setweight(to_tsvector('simple', COALESCE((
+            SELECT STRING_AGG(tg.name, ' ')
+            FROM transaction_splits s
+            INNER JOIN transaction_split_tags st ON st.split_id = s.id
+            INNER JOIN tags tg ON st.tag_id = tg.id
+            WHERE s.transaction_id = t.id AND s.deleted_at IS NULL AND tg.deleted_at IS NULL
+        ), '')), 'B')
+        || setweight(to_tsvector('simple', COALESCE((
+            SELECT a.name FROM accounts a WHERE a.id = t.account_id
+        ), '')), 'C')
+    WHERE t.id = ANY (tx_ids);
+END;
+$$ LANGUAGE plpgsql;
+
+-- Transactions: the note, category or account changed
+CREATE OR REPLACE FUNCTION transactions_search_vector_refresh () RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM refresh_transaction_search_vector (ARRAY[NEW.id]);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER transactions_search_vector AFTER INSERT
+OR
+UPDATE OF note,
+category_id,
+account_id ON transactions FOR EACH ROW
+EXECUTE FUNCTION transactions_search_vector_refresh ();
+
+-- Transaction tags: a tag was attached or detached
+CREATE OR REPLACE FUNCTION transaction_tags_search_vector_refresh () RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM refresh_transaction_search_vector (ARRAY[OLD.transaction_id]);
+    ELSE
+        PERFORM refresh_transaction_search_vector (ARRAY[NEW.transaction_id]);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER transaction_tags_search_vector AFTER INSERT
+OR DELETE ON transaction_tags FOR EACH ROW
+EXECUTE FUNCTION transaction_tags_search_vector_refresh ();
+
+-- Split lines: lines were replaced or their tags attached
+CREATE OR REPLACE FUNCTION transaction_splits_search_vector_refresh () RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM refresh_transaction_search_vector (ARRAY[NEW.transaction_id]);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER transaction_splits_search_vector AFTER INSERT
+OR
+UPDATE ON transaction_splits FOR EACH ROW
+EXECUTE FUNCTION transaction_splits_search_vector_refresh ();
+
+CREATE OR REPLACE FUNCTION transaction_split_tags_search_vector_refresh () RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM refresh_transaction_search_vector (ARRAY(
+        SELECT transaction_id FROM transaction_splits WHERE id = NEW.split_id
+    ));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER transaction_split_tags_search_vector AFTER INSERT ON transaction_split_tags FOR EACH ROW
+EXECUTE FUNCTION transaction_split_tags_search_vector_refresh ();
+
+-- Renamed or deleted categories, tags and accounts: refresh every transaction that references them
+CREATE OR REPLACE FUNCTION categories_search_vector_refresh () RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM refresh_transaction_search_vector (ARRAY(
+        SELECT id FROM transactions WHERE category_id = NEW.id
+        UNION
+        SELECT transaction_id FROM transaction_splits WHERE category_id = NEW.id AND deleted_at IS NULL
+    ));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER categories_search_vector AFTER
+UPDATE OF name,
+deleted_at ON categories FOR EACH ROW
+EXECUTE FUNCTION categories_search_vector_refresh ();
+
+CREATE OR REPLACE FUNCTION tags_search_vector_refresh () RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM refresh_transaction_search_vector (ARRAY(
+        SELECT transaction_id FROM transaction_tags WHERE tag_id = NEW.id
+        UNION
+        SELECT s.transaction_id
+        FROM transaction_split_tags st
+        INNER JOIN transaction_splits s ON st.split_id = s.id
+        WHERE st.tag_id = NEW.id AND s.deleted_at IS NULL
+    ));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tags_search_vector AFTER
+UPDATE OF name,
+deleted_at ON tags FOR EACH ROW
+EXECUTE FUNCTION tags_search_vector_refresh ();
+
+CREATE OR REPLACE FUNCTION accounts_search_vector_refresh () RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM refresh_transaction_search_vector (ARRAY(
+        SELECT id FROM transactions WHERE account_id = NEW.id
+    ));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER accounts_search_vector AFTER
+UPDATE OF name ON accounts FOR EACH ROW
+EXECUTE FUNCTION accounts_search_vector_refresh ();
+
+-- Backfill existing transactions
+SELECT
+    refresh_transaction_search_vector (
+        ARRAY (
+            SELECT
+                id
+            FROM
+                transactions
+        )
+    );
+
+CREATE INDEX idx_transactions_search_vector ON transactions USING GIN (search_vector);
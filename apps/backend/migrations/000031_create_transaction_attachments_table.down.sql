@@ -0,0 +1,4 @@
+-- Rollback transaction_attachments table
+DROP INDEX IF EXISTS idx_transaction_attachments_transaction_id;
+
+DROP TABLE IF EXISTS transaction_attachments;
@@ -0,0 +1,21 @@
+-- Create transaction_attachments table
+-- Holds the metadata of receipts and documents; the files live in the attachment storage under storage_key
+-- Rows are soft deleted on their own or together with their transaction, the stored files are kept
+CREATE TABLE
+    IF NOT EXISTS transaction_attachments (
+        id BIGSERIAL PRIMARY KEY,
+        transaction_id BIGINT NOT NULL REFERENCES transactions (id),
+        user_id BIGINT NOT NULL REFERENCES users (id),
+        file_name VARCHAR(255) NOT NULL,
+        content_type VARCHAR(100) NOT NULL,
+        size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+        checksum_sha256 CHAR(64) NOT NULL,
+        storage_key TEXT NOT NULL UNIQUE,
+        thumbnail_key TEXT,
+        created_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        deleted_at TIMESTAMP
+    );
+
+CREATE INDEX idx_transaction_attachments_transaction_id ON transaction_attachments (transaction_id)
+WHERE
+    deleted_at IS NULL;
@@ -0,0 +1,12 @@
+-- Rollback transaction status and account_reconciliations table
+DROP INDEX IF EXISTS idx_transactions_reconciliation_id;
+
+ALTER TABLE transactions
+DROP COLUMN IF EXISTS reconciliation_id,
+DROP COLUMN IF EXISTS status;
+
+DROP INDEX IF EXISTS idx_account_reconciliations_open;
+
+DROP INDEX IF EXISTS idx_account_reconciliations_account_id;
+
+DROP TABLE IF EXISTS account_reconciliations;
@@ -0,0 +1,35 @@
+-- Create account_reconciliations table
+-- A reconciliation compares the cleared balance of an account with a bank statement; completing it locks the matched transactions
+CREATE TABLE
+    IF NOT EXISTS account_reconciliations (
+        id BIGSERIAL PRIMARY KEY,
+        account_id BIGINT NOT NULL REFERENCES accounts (id),
+        user_id BIGINT NOT NULL REFERENCES users (id),
+        statement_date TIMESTAMP NOT NULL,
+        statement_balance BIGINT NOT NULL,
+        status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'completed')),
+        reconciled_count INTEGER NOT NULL DEFAULT 0,
+        created_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        updated_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        completed_at TIMESTAMP,
+        deleted_at TIMESTAMP
+    );
+
+CREATE INDEX idx_account_reconciliations_account_id ON account_reconciliations (account_id)
+WHERE
+    deleted_at IS NULL;
+
+-- At most one open reconciliation per account
+CREATE UNIQUE INDEX idx_account_reconciliations_open ON account_reconciliations (account_id)
+WHERE
+    status = 'open'
+    AND deleted_at IS NULL;
+
+-- Bank status of transactions; existing transactions start as pending
+ALTER TABLE transactions
+ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'cleared', 'reconciled')),
+ADD COLUMN IF NOT EXISTS reconciliation_id BIGINT REFERENCES account_reconciliations (id);
+
+CREATE INDEX idx_transactions_reconciliation_id ON transactions (reconciliation_id)
+WHERE
+    reconciliation_id IS NOT NULL;
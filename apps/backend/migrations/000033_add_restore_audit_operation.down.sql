@@ -0,0 +1,7 @@
+-- Rollback restore audit operation
+-- audit_logs is append-only, so existing restore entries are kept and the constraint only applies to new rows
+ALTER TABLE audit_logs
+DROP CONSTRAINT IF EXISTS audit_logs_operation_check;
+
+ALTER TABLE audit_logs
+ADD CONSTRAINT audit_logs_operation_check CHECK (operation IN ('create', 'update', 'delete')) NOT VALID;
@@ -0,0 +1,7 @@
+-- Allow restore in audit_logs operation
+-- Restoring a record from the trash is logged as its own operation, with the restored snapshot as after
+ALTER TABLE audit_logs
+DROP CONSTRAINT IF EXISTS audit_logs_operation_check;
+
+ALTER TABLE audit_logs
+ADD CONSTRAINT audit_logs_operation_check CHECK (operation IN ('create', 'update', 'delete', 'restore'));
@@ -0,0 +1,6 @@
+-- Rollback transaction_revisions table
+DROP TRIGGER IF EXISTS transaction_revisions_immutable ON transaction_revisions;
+
+DROP FUNCTION IF EXISTS transaction_revisions_reject_update;
+
+DROP TABLE IF EXISTS transaction_revisions;
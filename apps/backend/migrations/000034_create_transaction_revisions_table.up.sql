@@ -0,0 +1,25 @@
+-- Create transaction_revisions table
+-- One row per transaction update, holding the changed fields before and after and the balance change it applied
+-- Revisions are never changed; they are only removed when the trash purge removes their transaction
+CREATE TABLE
+    IF NOT EXISTS transaction_revisions (
+        id BIGSERIAL PRIMARY KEY,
+        transaction_id BIGINT NOT NULL REFERENCES transactions (id),
+        revision INTEGER NOT NULL CHECK (revision > 0),
+        actor_id BIGINT REFERENCES users (id),
+        changed_fields TEXT[] NOT NULL,
+        before JSONB NOT NULL,
+        after JSONB NOT NULL,
+        balance_deltas JSONB NOT NULL DEFAULT '[]',
+        created_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        CONSTRAINT unique_transaction_revision UNIQUE (transaction_id, revision)
+    );
+
+CREATE OR REPLACE FUNCTION transaction_revisions_reject_update () RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'transaction_revisions is immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER transaction_revisions_immutable BEFORE UPDATE ON transaction_revisions FOR EACH ROW
+EXECUTE FUNCTION transaction_revisions_reject_update ();
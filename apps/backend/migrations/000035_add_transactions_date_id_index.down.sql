@@ -0,0 +1,6 @@
+-- Rollback the (date, id) index of transactions
+CREATE INDEX IF NOT EXISTS idx_transactions_date ON transactions (date)
+WHERE
+    deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_transactions_date_id;
@@ -0,0 +1,7 @@
+-- Index the (date, id) sort key of transactions for cursor pagination
+-- It replaces the date-only index, which it covers
+CREATE INDEX IF NOT EXISTS idx_transactions_date_id ON transactions (date, id)
+WHERE
+    deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_transactions_date;
@@ -0,0 +1,10 @@
+-- Rollback payees table
+ALTER TABLE transaction_templates
+DROP COLUMN IF EXISTS payee_id;
+
+DROP INDEX IF EXISTS idx_transactions_payee_id;
+
+ALTER TABLE transactions
+DROP COLUMN IF EXISTS payee_id;
+
+DROP TABLE IF EXISTS payees;
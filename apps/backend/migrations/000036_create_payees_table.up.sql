@@ -0,0 +1,30 @@
+-- Create payees table
+-- A payee is the merchant or person on the other side of a transaction; aliases hold the other names it goes by
+CREATE TABLE
+    IF NOT EXISTS payees (
+        id BIGSERIAL PRIMARY KEY,
+        user_id BIGINT NOT NULL REFERENCES users (id),
+        name VARCHAR(100) NOT NULL,
+        aliases TEXT[] NOT NULL DEFAULT '{}',
+        created_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        updated_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        deleted_at TIMESTAMP
+    );
+
+CREATE INDEX idx_payees_user_id ON payees (user_id)
+WHERE
+    deleted_at IS NULL;
+
+CREATE INDEX idx_payees_deleted_at ON payees (deleted_at);
+
+-- Transactions and templates may name their payee
+ALTER TABLE transactions
+ADD COLUMN IF NOT EXISTS payee_id BIGINT REFERENCES payees (id);
+
+CREATE INDEX idx_transactions_payee_id ON transactions (payee_id)
+WHERE
+    payee_id IS NOT NULL
+    AND deleted_at IS NULL;
+
+ALTER TABLE transaction_templates
+ADD COLUMN IF NOT EXISTS payee_id BIGINT REFERENCES payees (id);
@@ -0,0 +1,4 @@
+-- Rollback transaction_rules table
+DROP INDEX IF EXISTS idx_transaction_rules_user_priority;
+
+DROP TABLE IF EXISTS transaction_rules;
@@ -0,0 +1,32 @@
+-- Create transaction_rules table
+-- A rule applies its actions to new transactions that meet all of its conditions, in ascending priority order
+-- Empty condition columns match any transaction; a rule without conditions matches every transaction
+CREATE TABLE
+    IF NOT EXISTS transaction_rules (
+        id BIGSERIAL PRIMARY KEY,
+        user_id BIGINT NOT NULL REFERENCES users (id),
+        name VARCHAR(100) NOT NULL,
+        priority INTEGER NOT NULL DEFAULT 100,
+        enabled BOOLEAN NOT NULL DEFAULT TRUE,
+        note_pattern TEXT,
+        min_amount BIGINT,
+        max_amount BIGINT,
+        account_id BIGINT REFERENCES accounts (id),
+        type VARCHAR(20) CHECK (type IN ('expense', 'income', 'transfer')),
+        currency_code VARCHAR(3),
+        latitude DOUBLE PRECISION,
+        longitude DOUBLE PRECISION,
+        radius_meters INTEGER CHECK (radius_meters > 0),
+        set_category_id BIGINT REFERENCES categories (id),
+        add_tag_ids BIGINT[] NOT NULL DEFAULT '{}',
+        set_note TEXT,
+        set_destination_account_id BIGINT REFERENCES accounts (id),
+        created_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        updated_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        CONSTRAINT transaction_rules_location_check CHECK (
+            (latitude IS NULL AND longitude IS NULL AND radius_meters IS NULL)
+            OR (latitude IS NOT NULL AND longitude IS NOT NULL AND radius_meters IS NOT NULL)
+        )
+    );
+
+CREATE INDEX idx_transaction_rules_user_priority ON transaction_rules (user_id, priority, id);
@@ -0,0 +1,10 @@
+-- Rollback reimbursements tables
+DROP VIEW IF EXISTS transaction_reimbursed_amounts;
+
+DROP INDEX IF EXISTS idx_reimbursement_matches_transaction_id;
+
+DROP TABLE IF EXISTS reimbursement_matches;
+
+DROP INDEX IF EXISTS idx_reimbursements_user_id;
+
+DROP TABLE IF EXISTS reimbursements;
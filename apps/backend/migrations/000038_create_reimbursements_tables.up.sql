@@ -0,0 +1,73 @@
+-- Create reimbursements table
+-- A reimbursement marks an expense as expected to be paid back, in full or in part, by a counterparty
+-- Unmarking an expense deletes its reimbursement together with its matches
+CREATE TABLE
+    IF NOT EXISTS reimbursements (
+        id BIGSERIAL PRIMARY KEY,
+        user_id BIGINT NOT NULL REFERENCES users (id),
+        transaction_id BIGINT NOT NULL REFERENCES transactions (id),
+        expected_amount BIGINT NOT NULL CHECK (expected_amount > 0),
+        counterparty VARCHAR(100) NOT NULL,
+        note TEXT,
+        created_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        updated_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        CONSTRAINT unique_reimbursement_transaction UNIQUE (transaction_id)
+    );
+
+CREATE INDEX idx_reimbursements_user_id ON reimbursements (user_id);
+
+-- Create reimbursement_matches table
+-- A match records the part of an income transaction that paid back a reimbursement
+-- One income may pay back several reimbursements, and one reimbursement may be paid back by several incomes
+CREATE TABLE
+    IF NOT EXISTS reimbursement_matches (
+        id BIGSERIAL PRIMARY KEY,
+        reimbursement_id BIGINT NOT NULL REFERENCES reimbursements (id) ON DELETE CASCADE,
+        transaction_id BIGINT NOT NULL REFERENCES transactions (id),
+        amount BIGINT NOT NULL CHECK (amount > 0),
+        created_at TIMESTAMP NOT NULL DEFAULT NOW (),
+        CONSTRAINT unique_reimbursement_match UNIQUE (reimbursement_id, transaction_id)
+    );
+
+CREATE INDEX idx_reimbursement_matches_transaction_id ON reimbursement_matches (transaction_id);
+
+-- One row per transaction with reimbursed money: an expense with the amount paid back on it, an income with the amount of it
+-- that paid expenses back. Matches count only while the expense and the income are live and still of those types
+-- Summaries leave these amounts out when asked to exclude reimbursed spending
+CREATE VIEW
+    transaction_reimbursed_amounts AS
+WITH
+    live_matches AS (
+        SELECT
+            r.transaction_id AS expense_id,
+            m.transaction_id AS income_id,
+            m.amount
+        FROM
+            reimbursement_matches m
+            INNER JOIN reimbursements r ON r.id = m.reimbursement_id
+            INNER JOIN transactions e ON e.id = r.transaction_id
+            AND e.type = 'expense'
+            AND e.deleted_at IS NULL
+            INNER JOIN transactions i ON i.id = m.transaction_id
+            AND i.type = 'income'
+            AND i.deleted_at IS NULL
+    )
+SELECT
+    transaction_id,
+    SUM(amount) AS amount
+FROM
+    (
+        SELECT
+            expense_id AS transaction_id,
+            amount
+        FROM
+            live_matches
+        UNION ALL
+        SELECT
+            income_id AS transaction_id,
+            amount
+        FROM
+            live_matches
+    ) reimbursed
+GROUP BY
+    transaction_id;
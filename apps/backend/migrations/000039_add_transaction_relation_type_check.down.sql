@@ -0,0 +1,4 @@
+-- Rollback transaction relation type check
+-- Free-form types replaced by related are not brought back
+ALTER TABLE transaction_relations
+DROP CONSTRAINT IF EXISTS transaction_relations_type_check;
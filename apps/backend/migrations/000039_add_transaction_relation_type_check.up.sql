@@ -0,0 +1,16 @@
+-- Restrict transaction_relations.relation_type to the known relation types
+-- Existing free-form types are kept when they spell a known type, any other becomes the generic related type
+UPDATE transaction_relations
+SET
+    relation_type = REPLACE(LOWER(TRIM(relation_type)), '-', '_')
+WHERE
+    REPLACE(LOWER(TRIM(relation_type)), '-', '_') IN ('refund_of', 'fee_of', 'split_of', 'duplicate_of', 'related');
+
+UPDATE transaction_relations
+SET
+    relation_type = 'related'
+WHERE
+    relation_type NOT IN ('refund_of', 'fee_of', 'split_of', 'duplicate_of', 'related');
+
+ALTER TABLE transaction_relations
+ADD CONSTRAINT transaction_relations_type_check CHECK (relation_type IN ('refund_of', 'fee_of', 'split_of', 'duplicate_of', 'related'));
@@ -0,0 +1,9 @@
+-- Rollback transfer destination amount and fee
+-- Fee expenses stay as ordinary expenses
+DROP INDEX IF EXISTS idx_transactions_fee_transaction_id;
+
+ALTER TABLE transactions
+DROP COLUMN IF EXISTS fee_transaction_id;
+
+ALTER TABLE transactions
+DROP COLUMN IF EXISTS destination_amount;
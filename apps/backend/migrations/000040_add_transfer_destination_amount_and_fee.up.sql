@@ -0,0 +1,12 @@
+-- Add destination amount and fee to transfers
+-- destination_amount is what the destination account receives when it differs from amount, e.g. after a currency conversion
+-- fee_transaction_id points at the expense recording the fee the bank took for the transfer
+ALTER TABLE transactions
+ADD COLUMN IF NOT EXISTS destination_amount BIGINT CHECK (destination_amount > 0);
+
+ALTER TABLE transactions
+ADD COLUMN IF NOT EXISTS fee_transaction_id BIGINT REFERENCES transactions (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_fee_transaction_id ON transactions (fee_transaction_id)
+WHERE
+    fee_transaction_id IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Rollback the admin flag on users
+ALTER TABLE users
+DROP COLUMN IF EXISTS is_admin;
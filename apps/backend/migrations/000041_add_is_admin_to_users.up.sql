@@ -0,0 +1,13 @@
+-- Add an admin flag to users
+-- Only admins may register further users; the seeded first user becomes the admin
+ALTER TABLE users
+ADD COLUMN IF NOT EXISTS is_admin BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE users
+SET
+    is_admin = TRUE
+WHERE
+    id = (
+        SELECT MIN(id)
+        FROM users
+    );
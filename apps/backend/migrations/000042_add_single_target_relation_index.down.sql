@@ -0,0 +1,2 @@
+-- Rollback the single-target relation index
+DROP INDEX IF EXISTS idx_transaction_relations_single_target;
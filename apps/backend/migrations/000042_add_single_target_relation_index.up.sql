@@ -0,0 +1,23 @@
+-- Allow a transaction to be the source of only one live relation of each single-target type
+-- Extra relations left by concurrent inserts are soft deleted first, keeping the oldest
+UPDATE transaction_relations r
+SET
+    deleted_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    r.deleted_at IS NULL
+    AND r.relation_type IN ('refund_of', 'fee_of', 'split_of', 'duplicate_of')
+    AND EXISTS (
+        SELECT 1
+        FROM transaction_relations k
+        WHERE
+            k.deleted_at IS NULL
+            AND k.source_transaction_id = r.source_transaction_id
+            AND k.relation_type = r.relation_type
+            AND k.id < r.id
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transaction_relations_single_target ON transaction_relations (source_transaction_id, relation_type)
+WHERE
+    deleted_at IS NULL
+    AND relation_type IN ('refund_of', 'fee_of', 'split_of', 'duplicate_of');
@@ -15,5 +15,6 @@ type RefreshRequestModel struct {
 }
 
 type RefreshResponseModel struct {
-	AccessToken string
+	AccessToken  string
+	RefreshToken string
 }